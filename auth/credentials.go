@@ -2,7 +2,10 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 // CredentialsManager 凭证管理器
@@ -40,6 +43,28 @@ func (m *CredentialsManager) CreateOrDeriveAPIKeys(ctx context.Context) (*Creden
 	return creds, nil
 }
 
+// LoadOrCreateAPIKeys 优先从存储加载凭证，不存在时创建或衍生并写回存储
+func (m *CredentialsManager) LoadOrCreateAPIKeys(ctx context.Context, store CredentialsStore) (*Credentials, error) {
+	creds, err := store.Load(ctx)
+	if err == nil {
+		m.credentials = creds
+		return creds, nil
+	}
+	if !errors.Is(err, common.ErrCredentialsNotFound) {
+		return nil, fmt.Errorf("failed to load credentials: %w", err)
+	}
+
+	creds, err = m.CreateOrDeriveAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Save(ctx, creds); err != nil {
+		return nil, fmt.Errorf("failed to save credentials: %w", err)
+	}
+	return creds, nil
+}
+
 // DeriveAPIKey 衍生 API 密钥（确定性）
 func (m *CredentialsManager) DeriveAPIKey(ctx context.Context, nonce int64) (*Credentials, error) {
 	creds, err := m.l1Signer.DeriveAPICredentials(ctx, m.clobEndpoint, nonce)
@@ -115,3 +140,8 @@ func ValidateCredentials(creds *Credentials) error {
 	}
 	return nil
 }
+
+// ClientID 由 API Key 派生的稳定标识，见 common.ClientID
+func ClientID(apiKey string) string {
+	return common.ClientID(apiKey)
+}
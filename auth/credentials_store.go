@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// CredentialsStore 凭证持久化接口
+// Load 在凭证不存在时返回 common.ErrCredentialsNotFound
+type CredentialsStore interface {
+	Load(ctx context.Context) (*Credentials, error)
+	Save(ctx context.Context, creds *Credentials) error
+	Delete(ctx context.Context) error
+}
+
+// FileCredentialsStore 基于本地文件的凭证存储（JSON 格式，权限 0600）
+type FileCredentialsStore struct {
+	path string
+}
+
+// NewFileCredentialsStore 创建文件凭证存储
+func NewFileCredentialsStore(path string) *FileCredentialsStore {
+	return &FileCredentialsStore{path: path}
+}
+
+// Load 从文件读取凭证
+func (s *FileCredentialsStore) Load(ctx context.Context) (*Credentials, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, common.ErrCredentialsNotFound
+		}
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	creds, err := UnmarshalCredentials(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+	return creds, nil
+}
+
+// Save 写入凭证（先写临时文件再重命名，避免写入中断导致文件损坏）
+func (s *FileCredentialsStore) Save(ctx context.Context, creds *Credentials) error {
+	if err := ValidateCredentials(creds); err != nil {
+		return err
+	}
+
+	data, err := MarshalCredentials(creds)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".credentials-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to save credentials file: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除凭证文件
+func (s *FileCredentialsStore) Delete(ctx context.Context) error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete credentials file: %w", err)
+	}
+	return nil
+}
+
+// 环境变量名后缀
+const (
+	envAPIKey     = "API_KEY"
+	envSecret     = "API_SECRET"
+	envPassphrase = "API_PASSPHRASE"
+)
+
+// DefaultEnvPrefix 默认环境变量前缀
+const DefaultEnvPrefix = "POLY_"
+
+// EnvCredentialsStore 基于环境变量的凭证存储
+// 读取 {prefix}API_KEY、{prefix}API_SECRET、{prefix}API_PASSPHRASE
+// Save/Delete 仅影响当前进程的环境变量，不会持久化
+type EnvCredentialsStore struct {
+	prefix string
+}
+
+// NewEnvCredentialsStore 创建环境变量凭证存储，prefix 为空时使用 DefaultEnvPrefix
+func NewEnvCredentialsStore(prefix string) *EnvCredentialsStore {
+	if prefix == "" {
+		prefix = DefaultEnvPrefix
+	}
+	return &EnvCredentialsStore{prefix: prefix}
+}
+
+// Load 从环境变量读取凭证
+func (s *EnvCredentialsStore) Load(ctx context.Context) (*Credentials, error) {
+	creds := &Credentials{
+		APIKey:     os.Getenv(s.prefix + envAPIKey),
+		Secret:     os.Getenv(s.prefix + envSecret),
+		Passphrase: os.Getenv(s.prefix + envPassphrase),
+	}
+
+	if creds.APIKey == "" && creds.Secret == "" && creds.Passphrase == "" {
+		return nil, common.ErrCredentialsNotFound
+	}
+	if err := ValidateCredentials(creds); err != nil {
+		return nil, fmt.Errorf("incomplete credentials in environment: %w", err)
+	}
+	return creds, nil
+}
+
+// Save 写入当前进程环境变量
+func (s *EnvCredentialsStore) Save(ctx context.Context, creds *Credentials) error {
+	if err := ValidateCredentials(creds); err != nil {
+		return err
+	}
+
+	if err := os.Setenv(s.prefix+envAPIKey, creds.APIKey); err != nil {
+		return err
+	}
+	if err := os.Setenv(s.prefix+envSecret, creds.Secret); err != nil {
+		return err
+	}
+	return os.Setenv(s.prefix+envPassphrase, creds.Passphrase)
+}
+
+// Delete 清除当前进程环境变量
+func (s *EnvCredentialsStore) Delete(ctx context.Context) error {
+	for _, name := range []string{envAPIKey, envSecret, envPassphrase} {
+		if err := os.Unsetenv(s.prefix + name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
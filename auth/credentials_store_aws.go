@@ -0,0 +1,304 @@
+//go:build awssecrets
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// AWSSecretsConfig AWS Secrets Manager 配置
+type AWSSecretsConfig struct {
+	SecretID        string        // Secret 名称或 ARN
+	Region          string        // 区域，为空时读取 AWS_REGION
+	AccessKeyID     string        // 为空时读取 AWS_ACCESS_KEY_ID
+	SecretAccessKey string        // 为空时读取 AWS_SECRET_ACCESS_KEY
+	SessionToken    string        // 为空时读取 AWS_SESSION_TOKEN（可选）
+	Endpoint        string        // 自定义端点（如 VPC endpoint 或本地模拟），默认按区域生成
+	Timeout         time.Duration // 请求超时，默认 10s
+	HTTPClient      *http.Client  // 自定义 HTTP 客户端（可选）
+}
+
+// AWSSecretsCredentialsStore 基于 AWS Secrets Manager 的凭证存储
+// 使用 SigV4 直接调用 Secrets Manager JSON API，无需 AWS SDK；需使用 -tags awssecrets 编译
+type AWSSecretsCredentialsStore struct {
+	config     AWSSecretsConfig
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewAWSSecretsCredentialsStore 创建 AWS Secrets Manager 凭证存储
+func NewAWSSecretsCredentialsStore(config AWSSecretsConfig) (*AWSSecretsCredentialsStore, error) {
+	if config.Region == "" {
+		config.Region = os.Getenv("AWS_REGION")
+	}
+	if config.AccessKeyID == "" {
+		config.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	if config.SecretAccessKey == "" {
+		config.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if config.SessionToken == "" {
+		config.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	if config.Endpoint == "" && config.Region != "" {
+		config.Endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", config.Region)
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	if config.SecretID == "" || config.Region == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("%w: secret ID, region and AWS access keys are required", common.ErrInvalidConfig)
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+
+	return &AWSSecretsCredentialsStore{
+		config:     config,
+		httpClient: httpClient,
+		now:        time.Now,
+	}, nil
+}
+
+// awsErrorResponse Secrets Manager 错误响应
+type awsErrorResponse struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// isType 判断错误类型（__type 可能带有命名空间前缀）
+func (e *awsErrorResponse) isType(name string) bool {
+	return e.Type == name || strings.HasSuffix(e.Type, "#"+name)
+}
+
+// Load 从 Secrets Manager 读取凭证
+func (s *AWSSecretsCredentialsStore) Load(ctx context.Context) (*Credentials, error) {
+	var resp struct {
+		SecretString string `json:"SecretString"`
+	}
+	apiErr, err := s.call(ctx, "GetSecretValue", map[string]interface{}{
+		"SecretId": s.config.SecretID,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if apiErr != nil {
+		if apiErr.isType("ResourceNotFoundException") {
+			return nil, common.ErrCredentialsNotFound
+		}
+		return nil, fmt.Errorf("secrets manager GetSecretValue failed: %s: %s", apiErr.Type, apiErr.Message)
+	}
+
+	creds, err := UnmarshalCredentials([]byte(resp.SecretString))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse secret: %w", err)
+	}
+	return creds, nil
+}
+
+// Save 写入凭证（Secret 不存在时自动创建）
+func (s *AWSSecretsCredentialsStore) Save(ctx context.Context, creds *Credentials) error {
+	if err := ValidateCredentials(creds); err != nil {
+		return err
+	}
+
+	data, err := MarshalCredentials(creds)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	apiErr, err := s.call(ctx, "PutSecretValue", map[string]interface{}{
+		"SecretId":     s.config.SecretID,
+		"SecretString": string(data),
+	}, nil)
+	if err != nil {
+		return err
+	}
+	if apiErr == nil {
+		return nil
+	}
+	if !apiErr.isType("ResourceNotFoundException") {
+		return fmt.Errorf("secrets manager PutSecretValue failed: %s: %s", apiErr.Type, apiErr.Message)
+	}
+
+	apiErr, err = s.call(ctx, "CreateSecret", map[string]interface{}{
+		"Name":         s.config.SecretID,
+		"SecretString": string(data),
+	}, nil)
+	if err != nil {
+		return err
+	}
+	if apiErr != nil {
+		return fmt.Errorf("secrets manager CreateSecret failed: %s: %s", apiErr.Type, apiErr.Message)
+	}
+	return nil
+}
+
+// Delete 立即删除 Secret（不保留恢复窗口）
+func (s *AWSSecretsCredentialsStore) Delete(ctx context.Context) error {
+	apiErr, err := s.call(ctx, "DeleteSecret", map[string]interface{}{
+		"SecretId":                   s.config.SecretID,
+		"ForceDeleteWithoutRecovery": true,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	if apiErr != nil && !apiErr.isType("ResourceNotFoundException") {
+		return fmt.Errorf("secrets manager DeleteSecret failed: %s: %s", apiErr.Type, apiErr.Message)
+	}
+	return nil
+}
+
+// call 调用 Secrets Manager 接口
+// 返回的 awsErrorResponse 非 nil 表示服务端返回了业务错误
+func (s *AWSSecretsCredentialsStore) call(ctx context.Context, action string, input interface{}, output interface{}) (*awsErrorResponse, error) {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.Endpoint+"/", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager."+action)
+	s.sign(req, payload)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("secrets manager request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr awsErrorResponse
+		if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Type == "" {
+			return nil, fmt.Errorf("secrets manager %s failed: status %d: %s", action, resp.StatusCode, body)
+		}
+		return &apiErr, nil
+	}
+
+	if output != nil {
+		if err := json.Unmarshal(body, output); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return nil, nil
+}
+
+// sign 使用 AWS SigV4 签名请求
+func (s *AWSSecretsCredentialsStore) sign(req *http.Request, payload []byte) {
+	const service = "secretsmanager"
+
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.config.SessionToken)
+	}
+
+	payloadHash := sha256Hex(payload)
+
+	// 规范化请求头（小写、排序）
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.config.Region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery 规范化查询字符串
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
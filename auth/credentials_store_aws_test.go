@@ -0,0 +1,104 @@
+//go:build awssecrets
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestAWSSecretsCredentialsStore(t *testing.T) {
+	secret := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20240101/us-east-1/secretsmanager/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-target, Signature=") {
+			t.Errorf("unexpected Authorization header: %s", auth)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		notFound := func() {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"com.amazonaws.secretsmanager#ResourceNotFoundException","message":"not found"}`))
+		}
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "secretsmanager.GetSecretValue":
+			if secret == "" {
+				notFound()
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"SecretString": secret})
+		case "secretsmanager.PutSecretValue":
+			if secret == "" {
+				notFound()
+				return
+			}
+			secret = body["SecretString"].(string)
+			w.Write([]byte(`{}`))
+		case "secretsmanager.CreateSecret":
+			if body["Name"] != "polymarket/creds" {
+				t.Errorf("CreateSecret Name = %v", body["Name"])
+			}
+			secret = body["SecretString"].(string)
+			w.Write([]byte(`{}`))
+		case "secretsmanager.DeleteSecret":
+			secret = ""
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected target %s", r.Header.Get("X-Amz-Target"))
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	store, err := NewAWSSecretsCredentialsStore(AWSSecretsConfig{
+		SecretID:        "polymarket/creds",
+		Region:          "us-east-1",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		Endpoint:        server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewAWSSecretsCredentialsStore() error: %v", err)
+	}
+	store.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	ctx := context.Background()
+	if _, err := store.Load(ctx); !errors.Is(err, common.ErrCredentialsNotFound) {
+		t.Fatalf("Load() error = %v, expected ErrCredentialsNotFound", err)
+	}
+
+	creds := &Credentials{APIKey: "k", Secret: "s", Passphrase: "p"}
+	if err := store.Save(ctx, creds); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	// Second save goes through PutSecretValue
+	if err := store.Save(ctx, creds); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if *loaded != *creds {
+		t.Errorf("Load() = %+v, expected %+v", loaded, creds)
+	}
+
+	if err := store.Delete(ctx); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if _, err := store.Load(ctx); !errors.Is(err, common.ErrCredentialsNotFound) {
+		t.Errorf("Load() after Delete error = %v, expected ErrCredentialsNotFound", err)
+	}
+}
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func testStoreCredentials() *Credentials {
+	return &Credentials{
+		APIKey:     "api-key",
+		Secret:     "c2VjcmV0",
+		Passphrase: "passphrase",
+	}
+}
+
+func TestFileCredentialsStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "creds.json")
+	store := NewFileCredentialsStore(path)
+
+	if _, err := store.Load(ctx); !errors.Is(err, common.ErrCredentialsNotFound) {
+		t.Fatalf("Load() error = %v, expected ErrCredentialsNotFound", err)
+	}
+
+	creds := testStoreCredentials()
+	if err := store.Save(ctx, creds); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, expected 0600", info.Mode().Perm())
+	}
+
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if *loaded != *creds {
+		t.Errorf("Load() = %+v, expected %+v", loaded, creds)
+	}
+
+	if err := store.Delete(ctx); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if _, err := store.Load(ctx); !errors.Is(err, common.ErrCredentialsNotFound) {
+		t.Errorf("Load() after Delete error = %v, expected ErrCredentialsNotFound", err)
+	}
+	if err := store.Delete(ctx); err != nil {
+		t.Errorf("Delete() of missing file should succeed, got %v", err)
+	}
+}
+
+func TestFileCredentialsStoreSaveInvalid(t *testing.T) {
+	store := NewFileCredentialsStore(filepath.Join(t.TempDir(), "creds.json"))
+	if err := store.Save(context.Background(), &Credentials{APIKey: "key"}); err == nil {
+		t.Error("Save() should reject incomplete credentials")
+	}
+}
+
+func TestEnvCredentialsStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewEnvCredentialsStore("PMTEST_")
+	t.Cleanup(func() { store.Delete(ctx) })
+
+	if _, err := store.Load(ctx); !errors.Is(err, common.ErrCredentialsNotFound) {
+		t.Fatalf("Load() error = %v, expected ErrCredentialsNotFound", err)
+	}
+
+	t.Setenv("PMTEST_API_KEY", "api-key")
+	if _, err := store.Load(ctx); err == nil || errors.Is(err, common.ErrCredentialsNotFound) {
+		t.Errorf("Load() with partial env should fail with incomplete error, got %v", err)
+	}
+
+	creds := testStoreCredentials()
+	if err := store.Save(ctx, creds); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if *loaded != *creds {
+		t.Errorf("Load() = %+v, expected %+v", loaded, creds)
+	}
+
+	if err := store.Delete(ctx); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if os.Getenv("PMTEST_API_SECRET") != "" {
+		t.Error("Delete() should unset environment variables")
+	}
+}
+
+func TestNewEnvCredentialsStoreDefaultPrefix(t *testing.T) {
+	t.Setenv("POLY_API_KEY", "k")
+	t.Setenv("POLY_API_SECRET", "s")
+	t.Setenv("POLY_API_PASSPHRASE", "p")
+
+	creds, err := NewEnvCredentialsStore("").Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if creds.APIKey != "k" || creds.Secret != "s" || creds.Passphrase != "p" {
+		t.Errorf("Load() = %+v", creds)
+	}
+}
+
+func TestCredentialsManagerLoadOrCreateAPIKeysFromStore(t *testing.T) {
+	ctx := context.Background()
+	signer, _ := NewL1Signer(testPrivateKey, 137)
+	manager := NewCredentialsManager(signer, "http://127.0.0.1:0")
+
+	store := NewFileCredentialsStore(filepath.Join(t.TempDir(), "creds.json"))
+	creds := testStoreCredentials()
+	if err := store.Save(ctx, creds); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := manager.LoadOrCreateAPIKeys(ctx, store)
+	if err != nil {
+		t.Fatalf("LoadOrCreateAPIKeys() error: %v", err)
+	}
+	if *loaded != *creds || !manager.HasCredentials() {
+		t.Errorf("LoadOrCreateAPIKeys() = %+v, expected stored credentials", loaded)
+	}
+}
@@ -0,0 +1,173 @@
+//go:build vault
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// VaultConfig HashiCorp Vault KV v2 配置
+type VaultConfig struct {
+	Address    string        // Vault 地址，为空时读取 VAULT_ADDR
+	Token      string        // 访问令牌，为空时读取 VAULT_TOKEN
+	Namespace  string        // 企业版命名空间，为空时读取 VAULT_NAMESPACE
+	Mount      string        // KV v2 挂载路径，默认 "secret"
+	Path       string        // 凭证在挂载点下的路径
+	Timeout    time.Duration // 请求超时，默认 10s
+	HTTPClient *http.Client  // 自定义 HTTP 客户端（可选）
+}
+
+// VaultCredentialsStore 基于 HashiCorp Vault KV v2 的凭证存储
+// 直接调用 Vault HTTP API，无需额外依赖；需使用 -tags vault 编译
+type VaultCredentialsStore struct {
+	config     VaultConfig
+	httpClient *http.Client
+}
+
+// NewVaultCredentialsStore 创建 Vault 凭证存储
+func NewVaultCredentialsStore(config VaultConfig) (*VaultCredentialsStore, error) {
+	if config.Address == "" {
+		config.Address = os.Getenv("VAULT_ADDR")
+	}
+	if config.Token == "" {
+		config.Token = os.Getenv("VAULT_TOKEN")
+	}
+	if config.Namespace == "" {
+		config.Namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+	if config.Mount == "" {
+		config.Mount = "secret"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	if config.Address == "" || config.Token == "" || config.Path == "" {
+		return nil, fmt.Errorf("%w: vault address, token and path are required", common.ErrInvalidConfig)
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+
+	return &VaultCredentialsStore{
+		config:     config,
+		httpClient: httpClient,
+	}, nil
+}
+
+// vaultKVResponse KV v2 读取响应
+type vaultKVResponse struct {
+	Data struct {
+		Data *Credentials `json:"data"`
+	} `json:"data"`
+}
+
+// Load 从 Vault 读取凭证
+func (s *VaultCredentialsStore) Load(ctx context.Context) (*Credentials, error) {
+	body, status, err := s.do(ctx, http.MethodGet, s.url("data"), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, common.ErrCredentialsNotFound
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("vault read failed: status %d: %s", status, body)
+	}
+
+	var resp vaultKVResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse vault response: %w", err)
+	}
+	// 已删除的版本返回 data 为 null
+	if resp.Data.Data == nil {
+		return nil, common.ErrCredentialsNotFound
+	}
+	return resp.Data.Data, nil
+}
+
+// Save 写入凭证到 Vault（创建新版本）
+func (s *VaultCredentialsStore) Save(ctx context.Context, creds *Credentials) error {
+	if err := ValidateCredentials(creds); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{"data": creds})
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	body, status, err := s.do(ctx, http.MethodPost, s.url("data"), payload)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return fmt.Errorf("vault write failed: status %d: %s", status, body)
+	}
+	return nil
+}
+
+// Delete 删除 Vault 中的凭证（包括所有版本和元数据）
+func (s *VaultCredentialsStore) Delete(ctx context.Context) error {
+	body, status, err := s.do(ctx, http.MethodDelete, s.url("metadata"), nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusNoContent && status != http.StatusOK && status != http.StatusNotFound {
+		return fmt.Errorf("vault delete failed: status %d: %s", status, body)
+	}
+	return nil
+}
+
+// url 构建 KV v2 接口地址，kind 为 "data" 或 "metadata"
+func (s *VaultCredentialsStore) url(kind string) string {
+	return fmt.Sprintf("%s/v1/%s/%s/%s",
+		strings.TrimRight(s.config.Address, "/"),
+		strings.Trim(s.config.Mount, "/"),
+		kind,
+		strings.TrimLeft(s.config.Path, "/"))
+}
+
+// do 发送请求并返回响应体和状态码
+func (s *VaultCredentialsStore) do(ctx context.Context, method, url string, payload []byte) ([]byte, int, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", s.config.Token)
+	if s.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.config.Namespace)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read vault response: %w", err)
+	}
+	return body, resp.StatusCode, nil
+}
@@ -0,0 +1,90 @@
+//go:build vault
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestVaultCredentialsStore(t *testing.T) {
+	var stored map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/secret/data/polymarket/creds":
+			if stored == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"data": stored},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/secret/data/polymarket/creds":
+			var body struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			stored = body.Data
+			w.Write([]byte(`{"data":{"version":1}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/secret/metadata/polymarket/creds":
+			stored = nil
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewVaultCredentialsStore(VaultConfig{
+		Address: server.URL,
+		Token:   "root",
+		Path:    "polymarket/creds",
+	})
+	if err != nil {
+		t.Fatalf("NewVaultCredentialsStore() error: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := store.Load(ctx); !errors.Is(err, common.ErrCredentialsNotFound) {
+		t.Fatalf("Load() error = %v, expected ErrCredentialsNotFound", err)
+	}
+
+	creds := &Credentials{APIKey: "k", Secret: "s", Passphrase: "p"}
+	if err := store.Save(ctx, creds); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if *loaded != *creds {
+		t.Errorf("Load() = %+v, expected %+v", loaded, creds)
+	}
+
+	if err := store.Delete(ctx); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if _, err := store.Load(ctx); !errors.Is(err, common.ErrCredentialsNotFound) {
+		t.Errorf("Load() after Delete error = %v, expected ErrCredentialsNotFound", err)
+	}
+}
+
+func TestNewVaultCredentialsStoreMissingConfig(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+	if _, err := NewVaultCredentialsStore(VaultConfig{Path: "x"}); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("error = %v, expected ErrInvalidConfig", err)
+	}
+}
@@ -283,3 +283,25 @@ func TestCredentialsManagerConcurrentAccess(t *testing.T) {
 		<-done
 	}
 }
+
+func TestClientID(t *testing.T) {
+	id := ClientID("test-api-key")
+	if len(id) != 16 || id != ClientID("test-api-key") {
+		t.Fatalf("ClientID should be a stable 16-char hex string, got %q", id)
+	}
+	if id == ClientID("other-api-key") {
+		t.Error("different keys should map to different IDs")
+	}
+	if ClientID("") != "" {
+		t.Error("empty key should map to an empty ID")
+	}
+
+	creds := &Credentials{APIKey: "test-api-key"}
+	if creds.ClientID() != id {
+		t.Errorf("Credentials.ClientID() = %q, expected %q", creds.ClientID(), id)
+	}
+	var none *Credentials
+	if none.ClientID() != "" {
+		t.Error("nil credentials should have an empty ID")
+	}
+}
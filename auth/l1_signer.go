@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 
@@ -104,6 +105,27 @@ func (s *L1Signer) SignMessage(message []byte) ([]byte, error) {
 
 // SignTypedData 签名 EIP-712 类型数据
 func (s *L1Signer) SignTypedData(typedData *TypedData) ([]byte, error) {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	// 签名
+	signature, err := crypto.Sign(hash, s.wallet.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign typed data: %w", err)
+	}
+
+	// 调整 v 值
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+
+	return signature, nil
+}
+
+// HashTypedData 计算 EIP-712 签名摘要 keccak256("\x19\x01" + domainSeparator + hashStruct(message))
+func HashTypedData(typedData *TypedData) ([]byte, error) {
 	// 转换为 go-ethereum 的类型
 	types := make(apitypes.Types)
 	for name, fields := range typedData.Types {
@@ -158,35 +180,12 @@ func (s *L1Signer) SignTypedData(typedData *TypedData) ([]byte, error) {
 	rawData := []byte{0x19, 0x01}
 	rawData = append(rawData, domainSeparator...)
 	rawData = append(rawData, messageHash...)
-	hash := crypto.Keccak256Hash(rawData)
-
-	// 签名
-	signature, err := crypto.Sign(hash.Bytes(), s.wallet.PrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign typed data: %w", err)
-	}
-
-	// 调整 v 值
-	if signature[64] < 27 {
-		signature[64] += 27
-	}
-
-	return signature, nil
+	return crypto.Keccak256(rawData), nil
 }
 
-// SignClobAuth 签名 CLOB 认证消息
+// SignClobAuth 签名 CLOB 认证消息，域的 chainId 使用签名器的链 ID
 func (s *L1Signer) SignClobAuth(timestamp string, nonce int64) (*L1AuthHeaders, error) {
-	typedData := &TypedData{
-		Types:       ClobAuthTypes,
-		PrimaryType: "ClobAuth",
-		Domain:      ClobAuthDomain,
-		Message: map[string]interface{}{
-			"address":   s.GetAddress(),
-			"timestamp": timestamp,
-			"nonce":     big.NewInt(nonce),
-			"message":   ClobAuthMessage,
-		},
-	}
+	typedData := ClobAuthTypedData(s.GetAddress(), timestamp, nonce, s.chainID)
 
 	signature, err := s.SignTypedData(typedData)
 	if err != nil {
@@ -201,6 +200,21 @@ func (s *L1Signer) SignClobAuth(timestamp string, nonce int64) (*L1AuthHeaders,
 	}, nil
 }
 
+// ClobAuthTypedData 构建 CLOB 认证消息的 EIP-712 类型数据
+func ClobAuthTypedData(address, timestamp string, nonce int64, chainID int) *TypedData {
+	return &TypedData{
+		Types:       ClobAuthTypes,
+		PrimaryType: "ClobAuth",
+		Domain:      ClobAuthDomainForChain(chainID),
+		Message: map[string]interface{}{
+			"address":   address,
+			"timestamp": timestamp,
+			"nonce":     big.NewInt(nonce),
+			"message":   ClobAuthMessage,
+		},
+	}
+}
+
 // CreateAPICredentials 创建 API 凭证
 func (s *L1Signer) CreateAPICredentials(ctx context.Context, clobEndpoint string) (*Credentials, error) {
 	timestamp := pmcommon.TimestampSecStr()
@@ -260,42 +274,57 @@ func (s *L1Signer) DeriveAPICredentials(ctx context.Context, clobEndpoint string
 
 // SignOrder 签名订单
 func (s *L1Signer) SignOrder(order *OrderPayload, exchangeAddress string) (string, error) {
+	typedData, err := OrderTypedData(order, s.chainID, exchangeAddress)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := s.SignTypedData(typedData)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign order: %w", err)
+	}
+
+	return hexutil.Encode(signature), nil
+}
+
+// OrderTypedData 构建订单的 EIP-712 类型数据
+func OrderTypedData(order *OrderPayload, chainID int, exchangeAddress string) (*TypedData, error) {
 	salt, ok := new(big.Int).SetString(order.Salt, 10)
 	if !ok {
-		return "", fmt.Errorf("invalid salt: %s", order.Salt)
+		return nil, fmt.Errorf("invalid salt: %s", order.Salt)
 	}
 
 	tokenID, ok := new(big.Int).SetString(order.TokenID, 10)
 	if !ok {
-		return "", fmt.Errorf("invalid token ID: %s", order.TokenID)
+		return nil, fmt.Errorf("invalid token ID: %s", order.TokenID)
 	}
 
 	makerAmount, ok := new(big.Int).SetString(order.MakerAmount, 10)
 	if !ok {
-		return "", fmt.Errorf("invalid maker amount: %s", order.MakerAmount)
+		return nil, fmt.Errorf("invalid maker amount: %s", order.MakerAmount)
 	}
 
 	takerAmount, ok := new(big.Int).SetString(order.TakerAmount, 10)
 	if !ok {
-		return "", fmt.Errorf("invalid taker amount: %s", order.TakerAmount)
+		return nil, fmt.Errorf("invalid taker amount: %s", order.TakerAmount)
 	}
 
 	expiration, ok := new(big.Int).SetString(order.Expiration, 10)
 	if !ok {
-		return "", fmt.Errorf("invalid expiration: %s", order.Expiration)
+		return nil, fmt.Errorf("invalid expiration: %s", order.Expiration)
 	}
 
 	nonce, ok := new(big.Int).SetString(order.Nonce, 10)
 	if !ok {
-		return "", fmt.Errorf("invalid nonce: %s", order.Nonce)
+		return nil, fmt.Errorf("invalid nonce: %s", order.Nonce)
 	}
 
 	feeRateBps, ok := new(big.Int).SetString(order.FeeRateBps, 10)
 	if !ok {
-		return "", fmt.Errorf("invalid fee rate: %s", order.FeeRateBps)
+		return nil, fmt.Errorf("invalid fee rate: %s", order.FeeRateBps)
 	}
 
-	domain := PolymarketExchangeDomain(s.chainID, exchangeAddress)
+	domain := PolymarketExchangeDomain(chainID, exchangeAddress)
 
 	// go-ethereum EIP-712 expects addresses as checksummed hex strings
 	makerAddr := common.HexToAddress(order.Maker).Hex()
@@ -322,12 +351,7 @@ func (s *L1Signer) SignOrder(order *OrderPayload, exchangeAddress string) (strin
 		},
 	}
 
-	signature, err := s.SignTypedData(typedData)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign order: %w", err)
-	}
-
-	return hexutil.Encode(signature), nil
+	return typedData, nil
 }
 
 // GetChainID 获取链 ID
@@ -335,6 +359,16 @@ func (s *L1Signer) GetChainID() int {
 	return s.chainID
 }
 
+// SignTransaction 用钱包私钥签名链上交易（EIP-155 / EIP-1559）
+func (s *L1Signer) SignTransaction(tx *types.Transaction) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(big.NewInt(int64(s.chainID)))
+	signed, err := types.SignTx(tx, signer, s.wallet.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return signed, nil
+}
+
 // MarshalCredentials 序列化凭证
 func MarshalCredentials(creds *Credentials) ([]byte, error) {
 	return json.Marshal(creds)
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultL2HeaderTTL 默认认证头复用窗口
+// 时间戳已回拨 TimestampSkew，服务器看到的时间戳最多落后 15 秒，远小于服务器接受的时钟偏差
+const DefaultL2HeaderTTL = 10 * time.Second
+
+// defaultL2HeaderMaxEntries 默认最多缓存的请求数
+const defaultL2HeaderMaxEntries = 256
+
+// L2HeaderCacheConfig 认证头复用配置
+// L2 签名覆盖 timestamp + method + path + body，对同一请求在时间戳仍被服务器接受的窗口内复用认证头，
+// 高频轮询或突发请求（如 GET /data/orders、DELETE /cancel-all）可省去每次的 HMAC 计算
+type L2HeaderCacheConfig struct {
+	// TTL 复用窗口，<= 0 使用 DefaultL2HeaderTTL
+	// 服务器看到的时间戳最多落后 TTL + TimestampSkew，应明显小于服务器接受的时钟偏差（见 clob.MaxClockSkew）
+	TTL time.Duration
+	// RefreshAhead 缓存剩余有效期不足该值时先返回缓存，同时在后台重新签名，<= 0 或 >= TTL 时使用 TTL/2
+	RefreshAhead time.Duration
+	// MaxEntries 最多缓存的请求数，达到上限后新请求不再缓存（Precompute 不受限制），<= 0 使用 256
+	MaxEntries int
+}
+
+// l2HeaderKey 缓存键，签名内容中除时间戳以外的部分
+type l2HeaderKey struct {
+	method, path, body string
+}
+
+// l2HeaderEntry 缓存的认证头
+type l2HeaderEntry struct {
+	headers    *L2AuthHeaders
+	issuedAt   time.Time // 时间戳对应的签名器时钟时刻（已截断到秒），用于计算时间戳的实际年龄
+	refreshing bool      // 后台重新签名中
+}
+
+// l2HeaderCache 认证头复用缓存
+type l2HeaderCache struct {
+	config       L2HeaderCacheConfig
+	ttl          time.Duration
+	refreshAhead time.Duration
+	maxEntries   int
+
+	mu      sync.Mutex
+	entries map[l2HeaderKey]*l2HeaderEntry
+}
+
+// newL2HeaderCache 按配置创建缓存并填充默认值
+func newL2HeaderCache(config L2HeaderCacheConfig) *l2HeaderCache {
+	c := &l2HeaderCache{
+		config:       config,
+		ttl:          config.TTL,
+		refreshAhead: config.RefreshAhead,
+		maxEntries:   config.MaxEntries,
+		entries:      make(map[l2HeaderKey]*l2HeaderEntry),
+	}
+	if c.ttl <= 0 {
+		c.ttl = DefaultL2HeaderTTL
+	}
+	if c.refreshAhead <= 0 || c.refreshAhead >= c.ttl {
+		c.refreshAhead = c.ttl / 2
+	}
+	if c.maxEntries <= 0 {
+		c.maxEntries = defaultL2HeaderMaxEntries
+	}
+	return c
+}
+
+// SetHeaderCache 启用认证头复用，nil 表示关闭；修改配置会清空已缓存的认证头
+func (s *L2Signer) SetHeaderCache(config *L2HeaderCacheConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if config == nil {
+		s.cache = nil
+		return
+	}
+	s.cache = newL2HeaderCache(*config)
+}
+
+// Precompute 预先签名并缓存指定请求的认证头，需先调用 SetHeaderCache
+// 适合在突发请求前（如开盘、撤单预案）预热，首个请求也无需签名
+func (s *L2Signer) Precompute(method, path, body string) error {
+	s.mu.Lock()
+	cache := s.cache
+	s.mu.Unlock()
+	if cache == nil {
+		return fmt.Errorf("header cache not enabled, call SetHeaderCache first")
+	}
+	_, err := cache.sign(s, l2HeaderKey{method: method, path: path, body: body}, true)
+	return err
+}
+
+// resetCacheLocked 清空缓存的认证头（凭证或时钟变化后旧的认证头不再可用，需持有 s.mu）
+func (s *L2Signer) resetCacheLocked() {
+	if s.cache != nil {
+		s.cache = newL2HeaderCache(s.cache.config)
+	}
+}
+
+// get 返回复用窗口内的认证头，临近过期时触发后台刷新，已过期或未缓存时同步签名
+func (c *l2HeaderCache) get(s *L2Signer, method, path, body string) (*L2AuthHeaders, error) {
+	key := l2HeaderKey{method: method, path: path, body: body}
+	now := s.Now()
+
+	c.mu.Lock()
+	if entry := c.entries[key]; entry != nil {
+		age := now.Sub(entry.issuedAt)
+		if age >= 0 && age < c.ttl {
+			if age >= c.ttl-c.refreshAhead && !entry.refreshing {
+				entry.refreshing = true
+				go c.refresh(s, key)
+			}
+			headers := *entry.headers
+			c.mu.Unlock()
+			return &headers, nil
+		}
+	}
+	c.mu.Unlock()
+
+	return c.sign(s, key, false)
+}
+
+// sign 以当前时间签名并写入缓存，force 为 true 时忽略 MaxEntries
+func (c *l2HeaderCache) sign(s *L2Signer, key l2HeaderKey, force bool) (*L2AuthHeaders, error) {
+	timestamp := timestampAt(s.Now())
+	headers, err := s.signHeaders(key.method, key.path, timestamp, key.body)
+	if err != nil {
+		return nil, err
+	}
+	sec, _ := strconv.ParseInt(timestamp, 10, 64)
+	entry := &l2HeaderEntry{headers: headers, issuedAt: time.Unix(sec, 0).Add(TimestampSkew)}
+
+	c.mu.Lock()
+	if _, ok := c.entries[key]; ok || force || len(c.entries) < c.maxEntries {
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	copied := *headers
+	return &copied, nil
+}
+
+// refresh 后台重新签名，失败时保留旧的认证头，过期后由 get 同步签名并返回错误
+func (c *l2HeaderCache) refresh(s *L2Signer, key l2HeaderKey) {
+	if _, err := c.sign(s, key, false); err != nil {
+		c.mu.Lock()
+		if entry := c.entries[key]; entry != nil {
+			entry.refreshing = false
+		}
+		c.mu.Unlock()
+	}
+}
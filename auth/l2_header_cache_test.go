@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"encoding/base64"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for the signer
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newCacheTestSigner(t *testing.T, clock *fakeClock) *L2Signer {
+	t.Helper()
+	signer := NewL2Signer("0x1234", &Credentials{
+		APIKey:     "test-api-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("test-secret")),
+		Passphrase: "test-passphrase",
+	})
+	signer.SetClock(clock.Now)
+	return signer
+}
+
+// assertValidHeaders checks that the signature matches the headers' own timestamp and that
+// the timestamp is no older than maxAge relative to the signer clock
+func assertValidHeaders(t *testing.T, signer *L2Signer, headers *L2AuthHeaders, method, path, body string, maxAge time.Duration) {
+	t.Helper()
+	want, err := signer.Sign(method, path, headers.Timestamp, body)
+	if err != nil {
+		t.Fatalf("Sign() error: %v", err)
+	}
+	if headers.Signature != want {
+		t.Errorf("signature does not match timestamp %s", headers.Timestamp)
+	}
+	sec, _ := strconv.ParseInt(headers.Timestamp, 10, 64)
+	if age := signer.Now().Sub(time.Unix(sec, 0)); age > maxAge+TimestampSkew || age < TimestampSkew {
+		t.Errorf("timestamp age %v outside [%v, %v]", age, TimestampSkew, maxAge+TimestampSkew)
+	}
+}
+
+func TestL2SignerClock(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	signer := newCacheTestSigner(t, clock)
+
+	if got := signer.Timestamp(); got != "1699999995" {
+		t.Errorf("Timestamp() = %s, expected local clock minus 5s", got)
+	}
+	headers, err := signer.GetAuthHeaders("GET", "/orders", "")
+	if err != nil {
+		t.Fatalf("GetAuthHeaders() error: %v", err)
+	}
+	if headers.Timestamp != "1699999995" {
+		t.Errorf("headers.Timestamp = %s", headers.Timestamp)
+	}
+
+	signer.SetClock(nil)
+	if sec, _ := strconv.ParseInt(signer.Timestamp(), 10, 64); time.Since(time.Unix(sec, 0)) < TimestampSkew {
+		t.Error("SetClock(nil) should restore the local clock")
+	}
+}
+
+func TestL2SignerHeaderCache(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	signer := newCacheTestSigner(t, clock)
+	signer.SetHeaderCache(&L2HeaderCacheConfig{TTL: 10 * time.Second, RefreshAhead: 4 * time.Second})
+
+	first, err := signer.GetAuthHeaders("DELETE", "/cancel-all", "")
+	if err != nil {
+		t.Fatalf("GetAuthHeaders() error: %v", err)
+	}
+
+	// within the window the same request reuses the headers
+	clock.Advance(3 * time.Second)
+	second, _ := signer.GetAuthHeaders("DELETE", "/cancel-all", "")
+	if second.Timestamp != first.Timestamp {
+		t.Errorf("expected cached headers, got timestamp %s then %s", first.Timestamp, second.Timestamp)
+	}
+	assertValidHeaders(t, signer, second, "DELETE", "/cancel-all", "", 10*time.Second)
+
+	// a different body is signed separately
+	other, _ := signer.GetAuthHeaders("DELETE", "/cancel-all", `{"x":1}`)
+	assertValidHeaders(t, signer, other, "DELETE", "/cancel-all", `{"x":1}`, 0)
+
+	// inside the refresh window the cached headers are returned and re-signed in the background
+	clock.Advance(4 * time.Second)
+	stale, _ := signer.GetAuthHeaders("DELETE", "/cancel-all", "")
+	if stale.Timestamp != first.Timestamp {
+		t.Errorf("expected cached headers while refreshing, got %s", stale.Timestamp)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		refreshed, _ := signer.GetAuthHeaders("DELETE", "/cancel-all", "")
+		if refreshed.Timestamp != first.Timestamp {
+			assertValidHeaders(t, signer, refreshed, "DELETE", "/cancel-all", "", 0)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("headers were not refreshed in the background")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// an expired entry is never served
+	clock.Advance(11 * time.Second)
+	expired, _ := signer.GetAuthHeaders("DELETE", "/cancel-all", "")
+	assertValidHeaders(t, signer, expired, "DELETE", "/cancel-all", "", 0)
+}
+
+func TestL2SignerHeaderCacheInvalidation(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	signer := newCacheTestSigner(t, clock)
+	signer.SetHeaderCache(&L2HeaderCacheConfig{})
+
+	if err := signer.Precompute("GET", "/orders", ""); err != nil {
+		t.Fatalf("Precompute() error: %v", err)
+	}
+	cached, _ := signer.GetAuthHeaders("GET", "/orders", "")
+
+	signer.UpdateCredentials(&Credentials{
+		APIKey:     "rotated-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("rotated-secret")),
+		Passphrase: "rotated-passphrase",
+	})
+	rotated, _ := signer.GetAuthHeaders("GET", "/orders", "")
+	if rotated.APIKey != "rotated-key" || rotated.Signature == cached.Signature {
+		t.Errorf("headers signed with old credentials after rotation: %+v", rotated)
+	}
+	assertValidHeaders(t, signer, rotated, "GET", "/orders", "", 0)
+
+	// a clock change (e.g. after syncing with the server) drops headers from the old clock
+	clock2 := &fakeClock{now: time.Unix(1700000100, 0)}
+	signer.SetClock(clock2.Now)
+	synced, _ := signer.GetAuthHeaders("GET", "/orders", "")
+	assertValidHeaders(t, signer, synced, "GET", "/orders", "", 0)
+
+	signer.SetHeaderCache(nil)
+	if err := signer.Precompute("GET", "/orders", ""); err == nil {
+		t.Error("Precompute() should fail without a cache")
+	}
+}
+
+func TestL2SignerHeaderCacheMaxEntries(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	signer := newCacheTestSigner(t, clock)
+	signer.SetHeaderCache(&L2HeaderCacheConfig{MaxEntries: 1})
+
+	signer.GetAuthHeaders("GET", "/a", "")
+	signer.GetAuthHeaders("GET", "/b", "")
+	if n := len(signer.cache.entries); n != 1 {
+		t.Errorf("expected 1 cached entry, got %d", n)
+	}
+	// explicit precompute is not limited
+	signer.Precompute("GET", "/b", "")
+	if n := len(signer.cache.entries); n != 2 {
+		t.Errorf("expected precomputed entry to be cached, got %d entries", n)
+	}
+}
@@ -4,16 +4,24 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
-	"fmt"
 	"net/http"
-
-	"github.com/binary-jerry/polymarket-sdk/common"
+	"strconv"
+	"sync"
+	"time"
 )
 
+// TimestampSkew 签名时间戳相对本地时钟的回拨量，避免本地时钟略快于服务器导致认证失败
+// 参考：https://github.com/Polymarket/py-clob-client/issues/190
+const TimestampSkew = 5 * time.Second
+
 // L2Signer L2 HMAC 签名器
 type L2Signer struct {
 	credentials *Credentials
 	address     string
+
+	mu    sync.Mutex
+	now   func() time.Time
+	cache *l2HeaderCache // 认证头复用缓存，nil 表示每次请求重新签名
 }
 
 // NewL2Signer 创建 L2 签名器
@@ -21,9 +29,40 @@ func NewL2Signer(address string, creds *Credentials) *L2Signer {
 	return &L2Signer{
 		credentials: creds,
 		address:     address,
+		now:         time.Now,
 	}
 }
 
+// SetClock 设置签名时间戳的时钟来源，nil 恢复为 time.Now
+// 本地时钟与服务器偏差较大时，可传入按服务器时间校正后的时钟
+func (s *L2Signer) SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+	s.resetCacheLocked()
+}
+
+// Now 签名器时钟的当前时间
+func (s *L2Signer) Now() time.Time {
+	s.mu.Lock()
+	now := s.now
+	s.mu.Unlock()
+	return now()
+}
+
+// Timestamp 当前签名使用的 POLY_TIMESTAMP（秒级，已回拨 TimestampSkew）
+func (s *L2Signer) Timestamp() string {
+	return timestampAt(s.Now())
+}
+
+// timestampAt 时刻 t 对应的 POLY_TIMESTAMP
+func timestampAt(t time.Time) string {
+	return strconv.FormatInt(t.Add(-TimestampSkew).Unix(), 10)
+}
+
 // Sign 签名请求
 // signature = Base64(HMAC-SHA256(secret, timestamp + method + path + body))
 // 注意：顺序必须是 timestamp + method + path + body（与 Python SDK 一致）
@@ -60,12 +99,20 @@ func (s *L2Signer) Sign(method, path, timestamp, body string) (string, error) {
 }
 
 // GetAuthHeaders 获取认证头
+// 启用 SetHeaderCache 后，复用窗口内相同 method + path + body 的请求返回缓存的认证头
 func (s *L2Signer) GetAuthHeaders(method, path, body string) (*L2AuthHeaders, error) {
-	// Polymarket API 使用秒级时间戳（与 Python SDK 一致）
-	// 减去 5 秒以避免时钟偏差导致的认证失败
-	// 参考：https://github.com/Polymarket/py-clob-client/issues/190
-	timestamp := fmt.Sprintf("%d", common.TimestampSec()-5)
+	s.mu.Lock()
+	cache := s.cache
+	s.mu.Unlock()
+	if cache != nil {
+		return cache.get(s, method, path, body)
+	}
+	return s.signHeaders(method, path, timestampAt(s.Now()), body)
+}
 
+// signHeaders 以指定时间戳签名并生成认证头
+func (s *L2Signer) signHeaders(method, path, timestamp, body string) (*L2AuthHeaders, error) {
+	// Polymarket API 使用秒级时间戳（与 Python SDK 一致）
 	signature, err := s.Sign(method, path, timestamp, body)
 	if err != nil {
 		return nil, err
@@ -117,9 +164,12 @@ func (s *L2Signer) GetAddress() string {
 	return s.address
 }
 
-// UpdateCredentials 更新凭证
+// UpdateCredentials 更新凭证，同时清空已缓存的认证头
 func (s *L2Signer) UpdateCredentials(creds *Credentials) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.credentials = creds
+	s.resetCacheLocked()
 }
 
 // IsValid 检查签名器是否有效
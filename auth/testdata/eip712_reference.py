@@ -0,0 +1,265 @@
+"""Independent EIP-712 reference for signing_vectors.json (standard library only).
+
+Recomputes every vector's digest and signature from its inputs with a
+self-contained Keccak-256, EIP-712 encoder and RFC 6979 secp256k1 signer,
+written from the EIP-712 spec and the type definitions in py-order-utils
+(Order) and py-clob-client (ClobAuth). It shares no code with the Go signer.
+
+The vectors copied from the Python SDKs' own test suites check this script;
+the remaining vectors are then checked against it. Use it where pip cannot
+reach PyPI; gen_signing_vectors.py against the Python SDKs stays the
+authoritative source.
+
+    python auth/testdata/eip712_reference.py auth/testdata/signing_vectors.json
+    python auth/testdata/eip712_reference.py --write auth/testdata/signing_vectors.json
+"""
+
+import hashlib
+import hmac
+import json
+import sys
+
+REFERENCE_SOURCE = "eip712_reference.py (independent EIP-712 implementation)"
+
+# py-clob-client: CLOB_AUTH_MESSAGE / ClobAuth / get_clob_auth_domain
+CLOB_AUTH_MESSAGE = "This message attests that I control the given wallet"
+CLOB_AUTH_TYPE = "ClobAuth(address address,string timestamp,uint256 nonce,string message)"
+CLOB_DOMAIN_TYPE = "EIP712Domain(string name,string version,uint256 chainId)"
+CLOB_DOMAIN_NAME = "ClobAuthDomain"
+
+# py-order-utils: Order model / get_domain
+ORDER_TYPE = (
+    "Order(uint256 salt,address maker,address signer,address taker,uint256 tokenId,"
+    "uint256 makerAmount,uint256 takerAmount,uint256 expiration,uint256 nonce,"
+    "uint256 feeRateBps,uint8 side,uint8 signatureType)"
+)
+EXCHANGE_DOMAIN_TYPE = "EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"
+EXCHANGE_DOMAIN_NAME = "Polymarket CTF Exchange"
+
+# Keccak-256 (original padding, as used by Ethereum; hashlib.sha3_256 differs)
+
+_RC = [
+    0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+    0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+    0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+    0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+    0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+    0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+]
+_ROT = [
+    [0, 36, 3, 41, 18],
+    [1, 44, 10, 45, 2],
+    [62, 6, 43, 15, 61],
+    [28, 55, 25, 21, 56],
+    [27, 20, 39, 8, 14],
+]
+_MASK = (1 << 64) - 1
+
+
+def _rol(v, n):
+    return ((v << n) | (v >> (64 - n))) & _MASK if n else v
+
+
+def _keccak_f(a):
+    for rc in _RC:
+        c = [a[x][0] ^ a[x][1] ^ a[x][2] ^ a[x][3] ^ a[x][4] for x in range(5)]
+        d = [c[(x - 1) % 5] ^ _rol(c[(x + 1) % 5], 1) for x in range(5)]
+        a = [[a[x][y] ^ d[x] for y in range(5)] for x in range(5)]
+        b = [[0] * 5 for _ in range(5)]
+        for x in range(5):
+            for y in range(5):
+                b[y][(2 * x + 3 * y) % 5] = _rol(a[x][y], _ROT[x][y])
+        a = [[b[x][y] ^ (~b[(x + 1) % 5][y] & b[(x + 2) % 5][y]) for y in range(5)] for x in range(5)]
+        a[0][0] ^= rc
+    return a
+
+
+def keccak256(data):
+    rate = 136
+    msg = bytearray(data) + b"\x01"
+    msg += b"\x00" * (-len(msg) % rate)
+    msg[-1] |= 0x80
+    a = [[0] * 5 for _ in range(5)]
+    for off in range(0, len(msg), rate):
+        block = msg[off:off + rate]
+        for i in range(rate // 8):
+            a[i % 5][i // 5] ^= int.from_bytes(block[8 * i:8 * i + 8], "little")
+        a = _keccak_f(a)
+    out = b"".join(a[i % 5][i // 5].to_bytes(8, "little") for i in range(4))
+    return out
+
+
+# secp256k1 with RFC 6979 deterministic nonces and low-s normalisation
+
+_P = 2**256 - 2**32 - 977
+_N = 0xFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141
+_G = (
+    0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798,
+    0x483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8,
+)
+
+
+def _add(p, q):
+    if p is None:
+        return q
+    if q is None:
+        return p
+    if p[0] == q[0] and (p[1] + q[1]) % _P == 0:
+        return None
+    if p == q:
+        lam = 3 * p[0] * p[0] * pow(2 * p[1], -1, _P) % _P
+    else:
+        lam = (q[1] - p[1]) * pow(q[0] - p[0], -1, _P) % _P
+    x = (lam * lam - p[0] - q[0]) % _P
+    return x, (lam * (p[0] - x) - p[1]) % _P
+
+
+def _mul(k, p):
+    r = None
+    while k:
+        if k & 1:
+            r = _add(r, p)
+        p = _add(p, p)
+        k >>= 1
+    return r
+
+
+def _rfc6979_nonces(key, digest):
+    x = key.to_bytes(32, "big")
+    h = (int.from_bytes(digest, "big") % _N).to_bytes(32, "big")
+    v, k = b"\x01" * 32, b"\x00" * 32
+    k = hmac.new(k, v + b"\x00" + x + h, hashlib.sha256).digest()
+    v = hmac.new(k, v, hashlib.sha256).digest()
+    k = hmac.new(k, v + b"\x01" + x + h, hashlib.sha256).digest()
+    v = hmac.new(k, v, hashlib.sha256).digest()
+    while True:
+        v = hmac.new(k, v, hashlib.sha256).digest()
+        candidate = int.from_bytes(v, "big")
+        if 1 <= candidate < _N:
+            yield candidate
+        k = hmac.new(k, v + b"\x00", hashlib.sha256).digest()
+        v = hmac.new(k, v, hashlib.sha256).digest()
+
+
+def sign(key, digest):
+    z = int.from_bytes(digest, "big")
+    for k in _rfc6979_nonces(key, digest):
+        point = _mul(k, _G)
+        r = point[0] % _N
+        if r == 0:
+            continue
+        s = pow(k, -1, _N) * (z + r * key) % _N
+        if s == 0:
+            continue
+        recovery = point[1] & 1
+        if s > _N // 2:
+            s, recovery = _N - s, recovery ^ 1
+        return r.to_bytes(32, "big") + s.to_bytes(32, "big") + bytes([27 + recovery])
+
+
+def address_of(key):
+    x, y = _mul(key, _G)
+    return "0x" + keccak256(x.to_bytes(32, "big") + y.to_bytes(32, "big"))[-20:].hex()
+
+
+# EIP-712 encoding
+
+
+def _uint(v):
+    return int(v).to_bytes(32, "big")
+
+
+def _address(v):
+    return bytes(12) + bytes.fromhex(v[2:])
+
+
+def _string(v):
+    return keccak256(v.encode())
+
+
+def _struct(type_string, *words):
+    return keccak256(keccak256(type_string.encode()) + b"".join(words))
+
+
+def _digest(domain, message):
+    return keccak256(b"\x19\x01" + domain + message)
+
+
+def clob_auth(v, key):
+    domain = _struct(CLOB_DOMAIN_TYPE, _string(CLOB_DOMAIN_NAME), _string("1"), _uint(v["chainId"]))
+    message = _struct(
+        CLOB_AUTH_TYPE,
+        _address(address_of(key)),
+        _string(v["timestamp"]),
+        _uint(v.get("nonce", 0)),
+        _string(CLOB_AUTH_MESSAGE),
+    )
+    return _digest(domain, message)
+
+
+def order(v):
+    o = v["order"]
+    domain = _struct(
+        EXCHANGE_DOMAIN_TYPE,
+        _string(EXCHANGE_DOMAIN_NAME),
+        _string("1"),
+        _uint(v["chainId"]),
+        _address(v["exchange"]),
+    )
+    message = _struct(
+        ORDER_TYPE,
+        _uint(o["salt"]),
+        _address(o["maker"]),
+        _address(o["signer"]),
+        _address(o["taker"]),
+        _uint(o["tokenId"]),
+        _uint(o["makerAmount"]),
+        _uint(o["takerAmount"]),
+        _uint(o["expiration"]),
+        _uint(o["nonce"]),
+        _uint(o["feeRateBps"]),
+        _uint(o["side"]),
+        _uint(o["signatureType"]),
+    )
+    return _digest(domain, message)
+
+
+def compute(v):
+    key = int(v["privateKey"], 16)
+    digest = clob_auth(v, key) if v["kind"] == "clob_auth" else order(v)
+    return "0x" + digest.hex(), "0x" + sign(key, digest).hex()
+
+
+def main(args):
+    write = "--write" in args
+    path = [a for a in args if a != "--write"][0]
+    with open(path) as f:
+        vectors = json.load(f)
+
+    # vectors taken from the Python SDK test suites validate this implementation first
+    failed = 0
+    for v in vectors:
+        digest, signature = compute(v)
+        matches = digest == v["hash"].lower() and signature == v["signature"].lower()
+        upstream = v["source"].startswith(("py-clob-client", "py-order-utils"))
+        if not matches and (upstream or not write):
+            print("MISMATCH %s: hash %s signature %s" % (v["name"], digest, signature))
+            failed += 1
+            continue
+        if upstream:
+            print("ok       %s (%s)" % (v["name"], v["source"]))
+            continue
+        print("ok       %s" % v["name"])
+        if write:
+            v["hash"], v["signature"], v["source"] = digest, signature, REFERENCE_SOURCE
+
+    if failed:
+        sys.exit(1)
+    if write:
+        with open(path, "w") as f:
+            json.dump(vectors, f, indent=2)
+            f.write("\n")
+
+
+if __name__ == "__main__":
+    main(sys.argv[1:])
@@ -0,0 +1,81 @@
+"""Regenerate the expected values in signing_vectors.json with the Python SDK.
+
+The inputs (keys, payloads) stay as they are; hash and signature are recomputed
+with py-clob-client / py-order-utils and the source is updated, so the Go test
+suite then checks this SDK against the Python implementation.
+
+    pip install py-clob-client
+    python auth/testdata/gen_signing_vectors.py auth/testdata/signing_vectors.json
+"""
+
+import json
+import sys
+from importlib.metadata import version
+
+from eth_utils import keccak
+from py_clob_client.signer import Signer
+from py_clob_client.signing.eip712 import get_clob_auth_domain, sign_clob_auth_message
+from py_clob_client.signing.model import ClobAuth
+from py_order_utils.builders import OrderBuilder
+from py_order_utils.model import OrderData
+from py_order_utils.signer import Signer as OrderSigner
+
+# same text as auth.ClobAuthMessage
+CLOB_AUTH_MESSAGE = "This message attests that I control the given wallet"
+
+
+def clob_auth(v):
+    signer = Signer(v["privateKey"], v["chainId"])
+    msg = ClobAuth(
+        address=signer.address(),
+        timestamp=v["timestamp"],
+        nonce=v["nonce"],
+        message=CLOB_AUTH_MESSAGE,
+    )
+    digest = "0x" + keccak(msg.signable_bytes(get_clob_auth_domain(v["chainId"]))).hex()
+    return digest, sign_clob_auth_message(signer, int(v["timestamp"]), v["nonce"])
+
+
+def order(v):
+    o = v["order"]
+    builder = OrderBuilder(
+        v["exchange"],
+        v["chainId"],
+        OrderSigner(v["privateKey"]),
+        salt_generator=lambda: int(o["salt"]),
+    )
+    built = builder.build_order(
+        OrderData(
+            maker=o["maker"],
+            taker=o["taker"],
+            tokenId=o["tokenId"],
+            makerAmount=o["makerAmount"],
+            takerAmount=o["takerAmount"],
+            side=o["side"],
+            feeRateBps=o["feeRateBps"],
+            nonce=o["nonce"],
+            signer=o["signer"],
+            expiration=o["expiration"],
+            signatureType=o["signatureType"],
+        )
+    )
+    return builder.build_order_hash(built), builder.build_order_signature(built)
+
+
+def main(path):
+    with open(path) as f:
+        vectors = json.load(f)
+
+    source = "py-clob-client %s / py-order-utils %s" % (version("py-clob-client"), version("py-order-utils"))
+    for v in vectors:
+        digest, signature = clob_auth(v) if v["kind"] == "clob_auth" else order(v)
+        prefix = lambda s: s if s.startswith("0x") else "0x" + s
+        v["hash"], v["signature"], v["source"] = prefix(digest), prefix(signature), source
+
+    with open(path, "w") as f:
+        json.dump(vectors, f, indent=2)
+        f.write("\n")
+
+
+if __name__ == "__main__":
+    main(sys.argv[1])
@@ -5,14 +5,12 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	pmcommon "github.com/binary-jerry/polymarket-sdk/common"
 )
 
-// Credentials API 凭证
-type Credentials struct {
-	APIKey     string `json:"apiKey"`
-	Secret     string `json:"secret"`     // Base64 编码
-	Passphrase string `json:"passphrase"`
-}
+// Credentials API 凭证，定义在 common 中以便不依赖签名库的包使用
+type Credentials = pmcommon.Credentials
 
 // Wallet 钱包信息
 type Wallet struct {
@@ -92,13 +90,22 @@ type TypedData struct {
 	Message     map[string]interface{}      `json:"message"`
 }
 
-// ClobAuthDomain CLOB 认证 EIP-712 域
+// ClobAuthDomain CLOB 认证 EIP-712 域（Polygon 主网），其他链使用 ClobAuthDomainForChain
 var ClobAuthDomain = TypedDataDomain{
 	Name:    "ClobAuthDomain",
 	Version: "1",
 	ChainId: big.NewInt(137),
 }
 
+// ClobAuthDomainForChain 指定链的 CLOB 认证 EIP-712 域
+func ClobAuthDomainForChain(chainID int) TypedDataDomain {
+	return TypedDataDomain{
+		Name:    ClobAuthDomain.Name,
+		Version: ClobAuthDomain.Version,
+		ChainId: big.NewInt(int64(chainID)),
+	}
+}
+
 // ClobAuthTypes CLOB 认证类型定义
 var ClobAuthTypes = map[string][]TypedDataField{
 	"ClobAuth": {
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+const signingVectorsPath = "testdata/signing_vectors.json"
+
+func TestSigningVectors(t *testing.T) {
+	vectors, err := LoadSigningVectors(signingVectorsPath)
+	if err != nil {
+		t.Fatalf("LoadSigningVectors() error: %v", err)
+	}
+
+	kinds := make(map[string]bool)
+	signatureTypes := make(map[int]bool)
+	for i := range vectors {
+		v := &vectors[i]
+		kinds[v.Kind] = true
+		if v.Order != nil {
+			signatureTypes[v.Order.SignatureType] = true
+		}
+		t.Run(v.Name, func(t *testing.T) {
+			if err := VerifySigningVector(v); err != nil {
+				t.Errorf("%v (source: %s)", err, v.Source)
+			}
+		})
+	}
+
+	// The suite must keep covering auth headers and every signature type
+	if !kinds[VectorKindClobAuth] || !kinds[VectorKindOrder] {
+		t.Errorf("vectors cover kinds %v, expected clob_auth and order", kinds)
+	}
+	for _, sigType := range []SignatureType{SignatureTypeEOA, SignatureTypePolyProxy, SignatureTypePolyGnosisSafe} {
+		if !signatureTypes[int(sigType)] {
+			t.Errorf("no order vector for signature type %d", sigType)
+		}
+	}
+}
+
+func TestVerifySigningVectorDetectsMismatch(t *testing.T) {
+	vectors, err := LoadSigningVectors(signingVectorsPath)
+	if err != nil {
+		t.Fatalf("LoadSigningVectors() error: %v", err)
+	}
+
+	var order SigningVector
+	for _, v := range vectors {
+		if v.Kind == VectorKindOrder {
+			order = v
+			break
+		}
+	}
+
+	// A payload change alters the digest
+	changed := order
+	changedOrder := *order.Order
+	changedOrder.FeeRateBps = "1"
+	changed.Order = &changedOrder
+	if err := VerifySigningVector(&changed); err == nil || !strings.Contains(err.Error(), "hash mismatch") {
+		t.Errorf("expected hash mismatch, got %v", err)
+	}
+
+	// Same digest but a different expected signature is reported as a signature mismatch
+	wrongSig := order
+	wrongSig.Signature = "0x" + strings.Repeat("00", 65)
+	if err := VerifySigningVector(&wrongSig); err == nil || !strings.Contains(err.Error(), "signature mismatch") {
+		t.Errorf("expected signature mismatch, got %v", err)
+	}
+
+	unknown := order
+	unknown.Kind = "unknown"
+	if err := VerifySigningVector(&unknown); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
+
+func TestSignClobAuthUsesSignerChain(t *testing.T) {
+	signer, err := NewL1Signer(testPrivateKey, 80002)
+	if err != nil {
+		t.Fatalf("NewL1Signer() error: %v", err)
+	}
+
+	// Same inputs as the clob_auth_amoy vector from py-clob-client
+	headers, err := signer.SignClobAuth("10000000", 23)
+	if err != nil {
+		t.Fatalf("SignClobAuth() error: %v", err)
+	}
+	const expected = "0xf62319a987514da40e57e2f4d7529f7bac38f0355bd88bb5adbb3768d80de6c1682518e0af677d5260366425f4361e7b70c25ae232aff0ab2331e2b164a1aedc1b"
+	if headers.Signature != expected {
+		t.Errorf("Signature = %s, expected %s", headers.Signature, expected)
+	}
+}
@@ -0,0 +1,268 @@
+package bench
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// TestMain silences SDK debug logging so it does not skew the measurements.
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func BenchmarkBookApplySnapshot(b *testing.B) {
+	for _, levels := range []int{10, 49} {
+		b.Run(fmt.Sprintf("levels=%d", levels), func(b *testing.B) {
+			ob := orderbook.NewOrderBook("bench")
+			msg := BookSnapshot("bench", levels)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ob.ApplyBookSnapshot(msg, int64(i))
+			}
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "snapshots/s")
+		})
+	}
+}
+
+func BenchmarkBookApplyPriceChange(b *testing.B) {
+	ob := orderbook.NewOrderBook("bench")
+	ob.ApplyBookSnapshot(BookSnapshot("bench", 49), 0)
+	changes := PriceChanges("bench", 4096, 1)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ob.ApplyPriceChange(changes[i%len(changes)], int64(i))
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "deltas/s")
+}
+
+// BenchmarkBookApplyAndReadBBO measures the realistic pattern of applying a
+// delta and immediately reading the BBO (forces a re-sort of the dirty side).
+func BenchmarkBookApplyAndReadBBO(b *testing.B) {
+	ob := orderbook.NewOrderBook("bench")
+	ob.ApplyBookSnapshot(BookSnapshot("bench", 49), 0)
+	changes := PriceChanges("bench", 4096, 1)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ob.ApplyPriceChange(changes[i%len(changes)], int64(i))
+		ob.GetBBO()
+	}
+}
+
+func BenchmarkBBOReadConcurrentWriters(b *testing.B) {
+	for _, writers := range []int{0, 1, 4} {
+		b.Run(fmt.Sprintf("writers=%d", writers), func(b *testing.B) {
+			ob := orderbook.NewOrderBook("bench")
+			ob.ApplyBookSnapshot(BookSnapshot("bench", 49), 0)
+			changes := PriceChanges("bench", 4096, 2)
+
+			stop := make(chan struct{})
+			var wg sync.WaitGroup
+			for w := 0; w < writers; w++ {
+				wg.Add(1)
+				go func(offset int) {
+					defer wg.Done()
+					for i := offset; ; i++ {
+						select {
+						case <-stop:
+							return
+						default:
+						}
+						ob.ApplyPriceChange(changes[i%len(changes)], 1)
+					}
+				}(w * 1000)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					ob.GetBBO()
+				}
+			})
+			b.StopTimer()
+
+			close(stop)
+			wg.Wait()
+		})
+	}
+}
+
+// BenchmarkGetBestAskUnderWriteLoad reads the best ask of random tokens out
+// of 500 subscribed ones through SDK.GetBestAsk from parallel goroutines while
+// a mock WebSocket server streams price changes as fast as the SDK applies
+// them. Reads go through the manager's book index and the per-book top-of-book
+// snapshot, so they should not queue behind the message-apply goroutine; the
+// writes/s metric confirms the write load kept running during the measurement.
+//
+//	go test -run '^$' -bench GetBestAskUnderWriteLoad -cpu 1,4,8 ./bench/
+func BenchmarkGetBestAskUnderWriteLoad(b *testing.B) {
+	const tokens = 500
+
+	server := NewMockBookServer(49)
+	defer server.Close()
+
+	config := orderbook.DefaultConfig()
+	config.WSEndpoint = "ws" + strings.TrimPrefix(server.URL, "http")
+	sdk := orderbook.NewSDK(config)
+	ctx := context.Background()
+	if err := sdk.Start(ctx); err != nil {
+		b.Fatal(err)
+	}
+	defer sdk.Close()
+
+	tokenIDs := make([]string, tokens)
+	for i := range tokenIDs {
+		tokenIDs[i] = fmt.Sprintf("bench-%d", i)
+	}
+	if err := sdk.Subscribe(tokenIDs); err != nil {
+		b.Fatal(err)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if err := sdk.WaitForSnapshots(waitCtx, tokenIDs); err != nil {
+		b.Fatal(err)
+	}
+
+	// Drain update notifications like a real consumer would.
+	go func() {
+		for range sdk.Updates() {
+		}
+	}()
+
+	applied := func() uint64 {
+		var total uint64
+		for _, tokenID := range tokenIDs {
+			if stats, ok := sdk.GetGapStats(tokenID); ok {
+				total += uint64(stats.Updates)
+			}
+		}
+		return total
+	}
+
+	var seed atomic.Int64
+	before := applied()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		rng := rand.New(rand.NewSource(seed.Add(1)))
+		for pb.Next() {
+			// ErrNoData is expected when a delta has emptied the ask side.
+			sdk.GetBestAsk(tokenIDs[rng.Intn(tokens)])
+		}
+	})
+	b.StopTimer()
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "reads/s")
+	b.ReportMetric(float64(applied()-before)/b.Elapsed().Seconds(), "writes/s")
+}
+
+func BenchmarkOrderSignAndSerialize(b *testing.B) {
+	l1Signer, err := auth.NewL1Signer(TestPrivateKey, 137)
+	if err != nil {
+		b.Fatal(err)
+	}
+	config := clob.DefaultConfig()
+	signer := clob.NewOrderSigner(l1Signer, config.ChainID, config.ExchangeAddress, config.NegRiskExchangeAddress, config.NegRiskAdapterAddress)
+	req := OrderRequest()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		signed, err := signer.CreateSignedOrder(req)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := json.Marshal(&clob.PostOrderRequest{Order: signed, Owner: "bench", OrderType: clob.OrderTypeGTC}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHTTPSubmitMock(b *testing.B) {
+	server := NewMockCLOBServer()
+	defer server.Close()
+
+	config := clob.DefaultConfig()
+	config.Endpoint = server.URL
+	config.Timeout = 5 * time.Second
+	config.MaxRetries = 0
+	creds := &auth.Credentials{
+		APIKey:     "bench-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("bench-secret")),
+		Passphrase: "bench-pass",
+	}
+	client, err := clob.NewClientWithCredentials(config, TestPrivateKey, creds)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	order, err := client.CreatePreSignedOrder(OrderRequest())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.SubmitPreSignedOrder(ctx, order); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "orders/s")
+}
+
+// BenchmarkBookDepthRead compares copying the full bid side with iterating it
+// in place, the pattern of analytics loops that walk the book on every update.
+func BenchmarkBookDepthRead(b *testing.B) {
+	ob := orderbook.NewOrderBook("bench")
+	ob.ApplyBookSnapshot(BookSnapshot("bench", 49), 0)
+
+	b.Run("GetAllBids", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			n := 0
+			for _, level := range ob.GetAllBids() {
+				if level.Size.Sign() > 0 {
+					n++
+				}
+			}
+		}
+	})
+	b.Run("IterateBids", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			n := 0
+			ob.IterateBids(func(level orderbook.OrderSummary) bool {
+				if level.Size.Sign() > 0 {
+					n++
+				}
+				return true
+			})
+		}
+	})
+}
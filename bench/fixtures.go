@@ -0,0 +1,167 @@
+// Package bench SDK 性能基准测试
+//
+// 基准覆盖订单簿快照/增量应用吞吐、并发写入下的 BBO 读取延迟、
+// 持续行情写入下跨多个 token 的 SDK.GetBestAsk 读取吞吐、
+// 订单签名与序列化延迟，以及基于本地 mock 服务器的下单提交吞吐。
+// 所有输入由固定种子生成，结果可复现；CI 中可用 benchstat 对比：
+//
+//	go test -run '^$' -bench . -benchmem -count 10 ./bench/ > new.txt
+//	benchstat old.txt new.txt
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// TestPrivateKey 基准测试使用的私钥（公开的测试私钥，勿用于真实资金）
+const TestPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// BookSnapshot 生成每侧 levels 档的订单簿快照，买单 0.01~0.49，卖单 0.51~0.99
+func BookSnapshot(tokenID string, levels int) *orderbook.BookMessage {
+	if levels > 49 {
+		levels = 49
+	}
+
+	msg := &orderbook.BookMessage{
+		EventType: orderbook.EventTypeBook,
+		AssetID:   tokenID,
+		Market:    "bench-market",
+		Timestamp: "1",
+		Hash:      "bench-hash",
+	}
+	for i := 0; i < levels; i++ {
+		msg.Bids = append(msg.Bids, orderbook.RawOrderSummary{
+			Price: tickPrice(49 - i),
+			Size:  strconv.Itoa(100 + i),
+		})
+		msg.Asks = append(msg.Asks, orderbook.RawOrderSummary{
+			Price: tickPrice(51 + i),
+			Size:  strconv.Itoa(100 + i),
+		})
+	}
+	return msg
+}
+
+// PriceChanges 生成 n 条可复现的随机增量（约 20% 为删除档位）
+func PriceChanges(tokenID string, n int, seed int64) []*orderbook.PriceChange {
+	rng := rand.New(rand.NewSource(seed))
+	changes := make([]*orderbook.PriceChange, n)
+	for i := range changes {
+		side, tick := orderbook.SideBuy, 1+rng.Intn(49)
+		if rng.Intn(2) == 0 {
+			side, tick = orderbook.SideSell, 51+rng.Intn(49)
+		}
+		size := strconv.Itoa(1 + rng.Intn(1000))
+		if rng.Intn(5) == 0 {
+			size = "0"
+		}
+		changes[i] = &orderbook.PriceChange{
+			AssetID: tokenID,
+			Price:   tickPrice(tick),
+			Size:    size,
+			Side:    side,
+			Hash:    "bench-hash",
+		}
+	}
+	return changes
+}
+
+// OrderRequest 基准测试使用的下单请求
+func OrderRequest() *clob.CreateOrderRequest {
+	return &clob.CreateOrderRequest{
+		TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+		Side:    clob.OrderSideBuy,
+		Price:   decimal.NewFromFloat(0.55),
+		Size:    decimal.NewFromInt(100),
+		Type:    clob.OrderTypeGTC,
+	}
+}
+
+// NewMockCLOBServer 创建接受所有下单请求的 mock CLOB 服务器
+func NewMockCLOBServer() *httptest.Server {
+	resp, _ := json.Marshal(clob.OrderResponse{Success: true, OrderID: "bench-order", Status: "LIVE"})
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(resp)
+	}))
+}
+
+// NewMockBookServer 创建模拟行情 WebSocket 服务器：收到订阅后推送各 token 的快照（每侧 levels 档），
+// 随后在连接上不间断地轮流推送这些 token 的随机增量，直到连接关闭
+func NewMockBookServer(levels int) *httptest.Server {
+	changes := PriceChanges("", 4096, 3)
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		subscribed := make(chan []string, 16)
+		go func() {
+			defer close(subscribed)
+			for {
+				var req orderbook.SubscribeRequest
+				if err := conn.ReadJSON(&req); err != nil {
+					return
+				}
+				if len(req.AssetsIDs) > 0 {
+					subscribed <- req.AssetsIDs
+				}
+			}
+		}()
+
+		var tokens []string
+		for i := 0; ; i++ {
+			// 尚无订阅时阻塞等待，之后在推送增量的间隙检查新订阅
+			var ids []string
+			if len(tokens) == 0 {
+				ids = <-subscribed
+				if ids == nil {
+					return
+				}
+			} else {
+				select {
+				case ids = <-subscribed:
+				default:
+				}
+			}
+			for _, tokenID := range ids {
+				if err := conn.WriteJSON(BookSnapshot(tokenID, levels)); err != nil {
+					return
+				}
+			}
+			tokens = append(tokens, ids...)
+
+			change := *changes[i%len(changes)]
+			change.AssetID = tokens[i%len(tokens)]
+			change.Hash = fmt.Sprintf("bench-%d", i)
+			msg := orderbook.PriceChangeMessage{
+				EventType:    orderbook.EventTypePriceChange,
+				Market:       "bench-market",
+				PriceChanges: []orderbook.PriceChange{change},
+				Timestamp:    strconv.Itoa(2 + i),
+			}
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+// tickPrice 价格刻度（1/100）转为字符串
+func tickPrice(tick int) string {
+	return decimal.New(int64(tick), -2).String()
+}
@@ -3,6 +3,10 @@ package clob
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 // GetBalanceAllowance 获取余额和授权
@@ -12,7 +16,7 @@ func (c *Client) GetBalanceAllowance(ctx context.Context, params *BalanceAllowan
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("GET", "/balance-allowance", "")
+	authHeaders, err := c.getL2AuthHeaders(ctx, "GET", "/balance-allowance", "")
 	if err != nil {
 		return nil, err
 	}
@@ -47,6 +51,131 @@ func (c *Client) GetConditionalBalance(ctx context.Context, tokenID string) (*Ba
 	return c.GetBalanceAllowance(ctx, params)
 }
 
+// BalancesQueryOptions 批量余额查询选项
+type BalancesQueryOptions struct {
+	Concurrency int // API 查询最大并发数，默认 8
+
+	// 链上查询（可选）：通过 ERC1155 balanceOfBatch 一次性读取余额
+	RPCEndpoint       string // Polygon RPC 端点，为空时不使用链上查询
+	PreferOnChain     bool   // 为 true 时直接使用链上查询；否则仅在 API 查询失败时回退
+	ConditionalTokens string // 条件代币合约地址，默认 ConditionalTokensAddress
+}
+
+// DefaultBalancesQueryOptions 默认批量余额查询选项
+func DefaultBalancesQueryOptions() *BalancesQueryOptions {
+	return &BalancesQueryOptions{
+		Concurrency:       8,
+		ConditionalTokens: ConditionalTokensAddress,
+	}
+}
+
+// GetConditionalBalances 批量获取条件代币余额
+// 通过有限并发调用 API；配置 RPCEndpoint 后，API 失败的 token 会回退到链上查询
+// 链上查询结果的 Allowance 为 0（仅返回余额）
+func (c *Client) GetConditionalBalances(ctx context.Context, tokenIDs []string, opts *BalancesQueryOptions) (map[string]*BalanceAllowance, error) {
+	if opts == nil {
+		opts = DefaultBalancesQueryOptions()
+	}
+	if len(tokenIDs) == 0 {
+		return map[string]*BalanceAllowance{}, nil
+	}
+
+	results := make(map[string]*BalanceAllowance, len(tokenIDs))
+	failed := tokenIDs
+	var firstErr error
+
+	if !opts.PreferOnChain || opts.RPCEndpoint == "" {
+		results, failed, firstErr = c.getConditionalBalancesAPI(ctx, tokenIDs, opts.Concurrency)
+	}
+
+	if len(failed) > 0 && opts.RPCEndpoint != "" {
+		contract := opts.ConditionalTokens
+		if contract == "" {
+			contract = ConditionalTokensAddress
+		}
+		httpClient := &http.Client{Timeout: c.config.Timeout}
+
+		balances, err := queryOnChainBalances(ctx, httpClient, opts.RPCEndpoint, contract, c.GetFunderAddress(), failed)
+		if err != nil {
+			return results, fmt.Errorf("failed to query on-chain balances: %w", err)
+		}
+		for tokenID, balance := range balances {
+			results[tokenID] = &BalanceAllowance{Balance: balance}
+		}
+		return results, nil
+	}
+
+	if firstErr != nil {
+		return results, fmt.Errorf("failed to get balances for %d tokens: %w", len(failed), firstErr)
+	}
+	return results, nil
+}
+
+// getConditionalBalancesAPI 并发调用 API 查询余额，返回成功结果、失败 token 和首个错误
+func (c *Client) getConditionalBalancesAPI(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*BalanceAllowance, []string, error) {
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	// 预先获取凭证，避免并发请求重复创建
+	if err := c.ensureCredentials(ctx); err != nil {
+		return map[string]*BalanceAllowance{}, tokenIDs, fmt.Errorf("failed to ensure credentials: %w", err)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		results  = make(map[string]*BalanceAllowance, len(tokenIDs))
+		failed   []string
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+
+	for _, tokenID := range tokenIDs {
+		wg.Add(1)
+		go func(tokenID string) {
+			defer wg.Done()
+			defer common.RecoverPanic("clob.balances", func(err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				failed = append(failed, tokenID)
+				if firstErr == nil {
+					firstErr = err
+				}
+			})
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				mu.Lock()
+				failed = append(failed, tokenID)
+				if firstErr == nil {
+					firstErr = ctx.Err()
+				}
+				mu.Unlock()
+				return
+			}
+
+			balance, err := c.GetConditionalBalance(ctx, tokenID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed = append(failed, tokenID)
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			results[tokenID] = balance
+		}(tokenID)
+	}
+	wg.Wait()
+
+	return results, failed, firstErr
+}
+
 // GetTickSize 获取价格最小变动单位
 func (c *Client) GetTickSize(ctx context.Context, tokenID string) (*TickSize, error) {
 	if tokenID == "" {
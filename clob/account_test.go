@@ -256,3 +256,65 @@ func TestGetPricesEmpty(t *testing.T) {
 		t.Error("Prices should be nil for empty token IDs")
 	}
 }
+
+func TestGetConditionalBalances(t *testing.T) {
+	client, server := setupAccountTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		tokenID := r.URL.Query().Get("token_id")
+		balance, _ := decimal.NewFromString(tokenID)
+		json.NewEncoder(w).Encode(BalanceAllowance{Balance: balance.Mul(decimal.NewFromInt(10))})
+	})
+	defer server.Close()
+
+	tokenIDs := []string{"1", "2", "3", "4", "5"}
+	balances, err := client.GetConditionalBalances(context.Background(), tokenIDs, &BalancesQueryOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("GetConditionalBalances() error: %v", err)
+	}
+	if len(balances) != len(tokenIDs) {
+		t.Fatalf("Expected %d balances, got %d", len(tokenIDs), len(balances))
+	}
+	if balances["3"].Balance.IntPart() != 30 {
+		t.Errorf("Balance for token 3 = %s, expected 30", balances["3"].Balance)
+	}
+}
+
+func TestGetConditionalBalancesOnChainFallback(t *testing.T) {
+	client, server := setupAccountTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("token_id") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(BalanceAllowance{Balance: decimal.NewFromInt(100)})
+	})
+	defer server.Close()
+
+	rpc := newTestRPCServer(t, 250)
+
+	balances, err := client.GetConditionalBalances(context.Background(), []string{"1", "2"}, &BalancesQueryOptions{
+		RPCEndpoint: rpc.URL,
+	})
+	if err != nil {
+		t.Fatalf("GetConditionalBalances() error: %v", err)
+	}
+	if balances["1"].Balance.IntPart() != 100 {
+		t.Errorf("Balance for token 1 = %s, expected 100 from API", balances["1"].Balance)
+	}
+	if balances["2"].Balance.IntPart() != 250 {
+		t.Errorf("Balance for token 2 = %s, expected 250 from chain", balances["2"].Balance)
+	}
+}
+
+func TestGetConditionalBalancesError(t *testing.T) {
+	client, server := setupAccountTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer server.Close()
+
+	balances, err := client.GetConditionalBalances(context.Background(), []string{"1", "2"}, nil)
+	if err == nil {
+		t.Fatal("GetConditionalBalances() should fail when API fails without RPC fallback")
+	}
+	if len(balances) != 0 {
+		t.Errorf("Expected no balances, got %d", len(balances))
+	}
+}
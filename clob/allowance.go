@@ -0,0 +1,210 @@
+package clob
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// 授权相关函数选择器
+const (
+	erc20ApproveSelector             = "095ea7b3" // approve(address,uint256)
+	erc20AllowanceSelector           = "dd62ed3e" // allowance(address,address)
+	erc1155SetApprovalForAllSelector = "a22cb465" // setApprovalForAll(address,bool)
+	erc1155IsApprovedForAllSelector  = "e985e9c5" // isApprovedForAll(address,address)
+)
+
+var (
+	// maxUint256 无限授权额度
+	maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	// unlimitedAllowance 额度不低于该值（2^255）视为已无限授权，转账消耗不会使其失效
+	unlimitedAllowance = new(big.Int).Lsh(big.NewInt(1), 255)
+)
+
+// ConditionalTokensContract 条件代币合约地址，未配置时使用主网地址
+func (t *Treasury) ConditionalTokensContract() string {
+	if addr := t.client.config.ConditionalTokensAddress; addr != "" {
+		return addr
+	}
+	return ConditionalTokensAddress
+}
+
+// TradingSpenders 下单前需要授权的合约：标准交易合约、NegRisk 交易合约与 NegRisk 适配器
+func (t *Treasury) TradingSpenders() []string {
+	config := t.client.config
+	var spenders []string
+	for _, addr := range []string{config.ExchangeAddress, config.NegRiskExchangeAddress, config.NegRiskAdapterAddress} {
+		if addr != "" {
+			spenders = append(spenders, addr)
+		}
+	}
+	return spenders
+}
+
+// Allowance 查询 owner 授权给 spender 的 USDC.e 额度（单位 USDC）
+func (t *Treasury) Allowance(ctx context.Context, owner, spender string) (decimal.Decimal, error) {
+	units, err := t.collateralAllowance(ctx, owner, spender)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.NewFromBigInt(units, -USDCDecimals), nil
+}
+
+// IsApprovedForAll 查询 owner 是否已授权 operator 转移其条件代币
+func (t *Treasury) IsApprovedForAll(ctx context.Context, owner, operator string) (bool, error) {
+	data, err := encodeAddressPair(erc1155IsApprovedForAllSelector, owner, operator)
+	if err != nil {
+		return false, err
+	}
+	result, err := t.callUint256(ctx, t.ConditionalTokensContract(), data)
+	if err != nil {
+		return false, fmt.Errorf("failed to query approval for %s: %w", operator, err)
+	}
+	return result.Sign() != 0, nil
+}
+
+// ApproveCollateral 授权 spender 使用签名钱包的 USDC.e，amount 为 nil 时授权无限额度
+// 返回交易哈希（不等待上链，可配合 WaitForReceipt）
+func (t *Treasury) ApproveCollateral(ctx context.Context, spender string, amount *big.Int) (string, error) {
+	if !ethcommon.IsHexAddress(spender) {
+		return "", fmt.Errorf("%w: %s", common.ErrInvalidAddress, spender)
+	}
+	if amount == nil {
+		amount = maxUint256
+	}
+	contract, err := t.TokenAddress(USDCBridged)
+	if err != nil {
+		return "", err
+	}
+
+	selector, _ := hex.DecodeString(erc20ApproveSelector)
+	data := append(selector, ethcommon.LeftPadBytes(ethcommon.HexToAddress(spender).Bytes(), 32)...)
+	data = append(data, uint256Word(amount)...)
+	return t.sendTransaction(ctx, ethcommon.HexToAddress(contract), data)
+}
+
+// SetApprovalForAll 授权或撤销 operator 转移签名钱包的全部条件代币，返回交易哈希
+func (t *Treasury) SetApprovalForAll(ctx context.Context, operator string, approved bool) (string, error) {
+	if !ethcommon.IsHexAddress(operator) {
+		return "", fmt.Errorf("%w: %s", common.ErrInvalidAddress, operator)
+	}
+
+	flag := big.NewInt(0)
+	if approved {
+		flag = big.NewInt(1)
+	}
+	selector, _ := hex.DecodeString(erc1155SetApprovalForAllSelector)
+	data := append(selector, ethcommon.LeftPadBytes(ethcommon.HexToAddress(operator).Bytes(), 32)...)
+	data = append(data, uint256Word(flag)...)
+	return t.sendTransaction(ctx, ethcommon.HexToAddress(t.ConditionalTokensContract()), data)
+}
+
+// ApproveConditionalTokens 授权 operator 转移签名钱包的全部条件代币（卖出所需），返回交易哈希
+// 撤销授权使用 SetApprovalForAll(ctx, operator, false)
+func (t *Treasury) ApproveConditionalTokens(ctx context.Context, operator string) (string, error) {
+	return t.SetApprovalForAll(ctx, operator, true)
+}
+
+// SetAllAllowances 同 SetTradingAllowances
+func (t *Treasury) SetAllAllowances(ctx context.Context) ([]string, error) {
+	return t.SetTradingAllowances(ctx)
+}
+
+// SetTradingAllowances 为签名钱包设置交易所需的全部授权并等待上链，返回发出的交易哈希
+// 对每个 TradingSpenders 授权无限 USDC.e 额度（买入）与条件代币转移权限（卖出），已授权的跳过；
+// 仅支持 EOA 模式，代理钱包（POLY_PROXY / GNOSIS_SAFE）的授权由 Polymarket 代理合约管理
+func (t *Treasury) SetTradingAllowances(ctx context.Context) ([]string, error) {
+	owner := t.client.GetAddress()
+	if !strings.EqualFold(t.client.GetFunderAddress(), owner) {
+		return nil, fmt.Errorf("setting allowances for proxy wallet %s is not supported, only EOA funders can approve directly", t.client.GetFunderAddress())
+	}
+
+	var txHashes []string
+	send := func(hash string, err error) error {
+		if err != nil {
+			return err
+		}
+		txHashes = append(txHashes, hash)
+		_, err = t.WaitForReceipt(ctx, hash)
+		return err
+	}
+
+	for _, spender := range t.TradingSpenders() {
+		allowance, err := t.collateralAllowance(ctx, owner, spender)
+		if err != nil {
+			return txHashes, err
+		}
+		if allowance.Cmp(unlimitedAllowance) < 0 {
+			if err := send(t.ApproveCollateral(ctx, spender, nil)); err != nil {
+				return txHashes, fmt.Errorf("failed to approve USDC.e for %s: %w", spender, err)
+			}
+		}
+
+		approved, err := t.IsApprovedForAll(ctx, owner, spender)
+		if err != nil {
+			return txHashes, err
+		}
+		if !approved {
+			if err := send(t.ApproveConditionalTokens(ctx, spender)); err != nil {
+				return txHashes, fmt.Errorf("failed to approve conditional tokens for %s: %w", spender, err)
+			}
+		}
+	}
+
+	return txHashes, nil
+}
+
+// collateralAllowance 查询 USDC.e 授权额度（最小单位）
+func (t *Treasury) collateralAllowance(ctx context.Context, owner, spender string) (*big.Int, error) {
+	contract, err := t.TokenAddress(USDCBridged)
+	if err != nil {
+		return nil, err
+	}
+	data, err := encodeAddressPair(erc20AllowanceSelector, owner, spender)
+	if err != nil {
+		return nil, err
+	}
+	result, err := t.callUint256(ctx, contract, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query allowance for %s: %w", spender, err)
+	}
+	return result, nil
+}
+
+// callUint256 eth_call 只读调用并解析单个 uint256 返回值
+func (t *Treasury) callUint256(ctx context.Context, contract string, data []byte) (*big.Int, error) {
+	var result string
+	err := rpcCall(ctx, t.httpClient, t.config.RPCEndpoint, "eth_call", []interface{}{
+		map[string]string{"to": contract, "data": hexutil.Encode(data)},
+		"latest",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(result, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid eth_call result: %w", err)
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
+
+// encodeAddressPair ABI 编码 (address, address) 参数的调用
+func encodeAddressPair(selectorHex, a, b string) ([]byte, error) {
+	for _, addr := range []string{a, b} {
+		if !ethcommon.IsHexAddress(addr) {
+			return nil, fmt.Errorf("%w: %s", common.ErrInvalidAddress, addr)
+		}
+	}
+	selector, _ := hex.DecodeString(selectorHex)
+	data := append(selector, ethcommon.LeftPadBytes(ethcommon.HexToAddress(a).Bytes(), 32)...)
+	return append(data, ethcommon.LeftPadBytes(ethcommon.HexToAddress(b).Bytes(), 32)...), nil
+}
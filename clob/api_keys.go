@@ -0,0 +1,59 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+)
+
+// apiKeysResponse GET /auth/api-keys 响应
+type apiKeysResponse struct {
+	APIKeys []string `json:"apiKeys"`
+}
+
+// GetAPIKeys 获取当前认证地址下的全部 API Key
+// 不会自动创建或衍生凭证；ctx 携带凭证（WithCredentials）时以该凭证认证
+func (c *Client) GetAPIKeys(ctx context.Context) ([]string, error) {
+	authHeaders, err := c.getL2AuthHeaders(ctx, "GET", "/auth/api-keys", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var result apiKeysResponse
+	if err := c.httpClient.DoWithAuth(ctx, "GET", "/auth/api-keys", nil, authHeaders, &result); err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	return result.APIKeys, nil
+}
+
+// DeleteAPIKey 删除 API Key
+// 服务端只允许删除本次请求认证所用的 Key，因此 apiKey 必须是当前凭证的 Key；
+// 删除其他 Key 时用 WithCredentials 携带该 Key 的完整凭证
+// 删除的是客户端自身凭证时会清空客户端凭证，之后的请求会重新衍生
+func (c *Client) DeleteAPIKey(ctx context.Context, apiKey string) error {
+	_, creds := c.requestSigner(ctx)
+	if creds == nil {
+		return fmt.Errorf("no credentials available, call CreateOrDeriveAPICredentials first")
+	}
+	if creds.APIKey != apiKey {
+		return fmt.Errorf("can only delete the api key used to authenticate (%s), pass credentials for %s via WithCredentials", creds.APIKey, apiKey)
+	}
+
+	authHeaders, err := c.getL2AuthHeaders(ctx, "DELETE", "/auth/api-key", "")
+	if err != nil {
+		return err
+	}
+
+	if err := c.httpClient.DoWithAuth(ctx, "DELETE", "/auth/api-key", nil, authHeaders, nil); err != nil {
+		return fmt.Errorf("failed to delete api key: %w", err)
+	}
+
+	c.mu.Lock()
+	if c.credentials != nil && c.credentials.APIKey == apiKey {
+		c.credentials = nil
+		c.l2Signer = nil
+	}
+	c.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package clob
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+)
+
+func TestGetAPIKeys(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/auth/api-keys" || r.Header.Get("POLY_API_KEY") != "test-api-key" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apiKeys":["k1","test-api-key"]}`))
+	})
+	defer server.Close()
+
+	keys, err := client.GetAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("GetAPIKeys() error: %v", err)
+	}
+	if len(keys) != 2 || keys[1] != "test-api-key" {
+		t.Errorf("keys = %v", keys)
+	}
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	var deleted []string
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/auth/api-key" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		deleted = append(deleted, r.Header.Get("POLY_API_KEY"))
+		w.Write([]byte(`"OK"`))
+	})
+	defer server.Close()
+	ctx := context.Background()
+
+	// Only the authenticating key can be deleted
+	if err := client.DeleteAPIKey(ctx, "other-key"); err == nil {
+		t.Error("expected error deleting a key without its credentials")
+	}
+
+	scoped := WithCredentials(ctx, &auth.Credentials{APIKey: "other-key", Secret: "c2VjcmV0", Passphrase: "p"})
+	if err := client.DeleteAPIKey(scoped, "other-key"); err != nil {
+		t.Fatalf("DeleteAPIKey(scoped) error: %v", err)
+	}
+	if client.GetCredentials() == nil {
+		t.Error("deleting a scoped key should keep client credentials")
+	}
+
+	if err := client.DeleteAPIKey(ctx, "test-api-key"); err != nil {
+		t.Fatalf("DeleteAPIKey() error: %v", err)
+	}
+	if client.GetCredentials() != nil {
+		t.Error("client credentials should be cleared after deleting them")
+	}
+	if len(deleted) != 2 || deleted[0] != "other-key" || deleted[1] != "test-api-key" {
+		t.Errorf("deleted = %v", deleted)
+	}
+}
@@ -0,0 +1,362 @@
+package clob
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+)
+
+// auditRedacted 审计记录中替换凭证的占位符
+const auditRedacted = "[REDACTED]"
+
+// AuditConfig 审计日志配置
+// 每条下单、撤单请求与结果（即事件日志中的每个 TradingEvent）以 JSON 行追加到 Dir 下的文件，
+// 文件超过 MaxFileSize 后轮换；每条记录包含上一条记录的哈希，修改、插入或删除中间的记录都能被 VerifyAuditLog 发现
+type AuditConfig struct {
+	Dir         string // 审计文件目录，不存在时自动创建
+	FilePrefix  string // 文件名前缀，文件名为 <prefix>-<首条记录序号>.jsonl
+	MaxFileSize int64  // 单个文件大小上限（字节）
+	MaxFiles    int    // 保留的文件数，超出时删除最旧的文件；0 表示不删除（合规场景通常需要全部保留）
+	Sync        bool   // 每条记录写入后 fsync，进程崩溃也不丢失记录，代价是下单路径多一次磁盘同步
+}
+
+// DefaultAuditConfig 默认审计日志配置
+func DefaultAuditConfig() *AuditConfig {
+	return &AuditConfig{
+		Dir:         "audit",
+		FilePrefix:  "trading-audit",
+		MaxFileSize: 64 << 20,
+	}
+}
+
+// AuditRecord 审计文件中的一行
+// Hash = sha256("<Seq>|<PrevHash>|" + Event 原始 JSON)，Seq 从 1 开始连续递增，首条记录的 PrevHash 为空
+type AuditRecord struct {
+	Seq      uint64          `json:"seq"`
+	PrevHash string          `json:"prev_hash"`
+	Hash     string          `json:"hash"`
+	Event    json.RawMessage `json:"event"`
+}
+
+// auditHash 计算记录哈希
+func auditHash(seq uint64, prevHash string, event []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d|%s|", seq, prevHash)
+	h.Write(event)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// AuditLog 轮换的 JSON 行审计日志（并发安全）
+// 写入失败只记录日志，不影响下单与撤单
+type AuditLog struct {
+	mu       sync.Mutex
+	config   *AuditConfig
+	file     *os.File
+	size     int64
+	seq      uint64
+	prevHash string
+	failed   bool // 已报告写入失败，恢复前不重复打印
+	closed   bool
+}
+
+// OpenAuditLog 打开审计日志，目录中已有审计文件时从最后一条记录继续哈希链
+func OpenAuditLog(config *AuditConfig) (*AuditLog, error) {
+	if config == nil {
+		config = DefaultAuditConfig()
+	}
+	if config.Dir == "" {
+		return nil, fmt.Errorf("audit log directory is required")
+	}
+	if config.FilePrefix == "" {
+		config.FilePrefix = "trading-audit"
+	}
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = 64 << 20
+	}
+	if err := os.MkdirAll(config.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	a := &AuditLog{config: config}
+	files, err := auditFiles(config.Dir, config.FilePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) > 0 {
+		last := files[len(files)-1]
+		record, err := lastAuditRecord(last)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resume audit log %s: %w", last, err)
+		}
+		if record != nil {
+			a.seq, a.prevHash = record.Seq, record.Hash
+		}
+		if err := a.openFile(last); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// Write 追加一条事件记录
+func (a *AuditLog) Write(event TradingEvent) error {
+	return a.append(event)
+}
+
+// write 脱敏后追加事件，失败时打印日志
+func (a *AuditLog) write(event TradingEvent, creds *auth.Credentials) {
+	event.Error = redactCredentials(event.Error, creds)
+	err := a.append(event)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err != nil && !a.failed {
+		log.Printf("[Polymarket Audit] failed to write audit record: %v", err)
+	}
+	a.failed = err != nil
+}
+
+// append 序列化事件并写入当前文件，需要时轮换
+func (a *AuditLog) append(event TradingEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.closed {
+		return fmt.Errorf("audit log closed")
+	}
+	seq := a.seq + 1
+	record := AuditRecord{Seq: seq, PrevHash: a.prevHash, Hash: auditHash(seq, a.prevHash, payload), Event: payload}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	if a.file == nil || (a.size > 0 && a.size+int64(len(line)) > a.config.MaxFileSize) {
+		if err := a.rotateLocked(seq); err != nil {
+			return err
+		}
+	}
+	n, err := a.file.Write(line)
+	a.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	if a.config.Sync {
+		if err := a.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync audit log: %w", err)
+		}
+	}
+
+	a.seq, a.prevHash = seq, record.Hash
+	return nil
+}
+
+// rotateLocked 关闭当前文件，以 seq 命名新文件并清理超出 MaxFiles 的旧文件（调用者需持有锁）
+func (a *AuditLog) rotateLocked(seq uint64) error {
+	if a.file != nil {
+		a.file.Sync()
+		a.file.Close()
+		a.file = nil
+	}
+	name := filepath.Join(a.config.Dir, fmt.Sprintf("%s-%020d.jsonl", a.config.FilePrefix, seq))
+	if err := a.openFile(name); err != nil {
+		return err
+	}
+
+	if a.config.MaxFiles > 0 {
+		files, err := auditFiles(a.config.Dir, a.config.FilePrefix)
+		if err != nil {
+			return err
+		}
+		for len(files) > a.config.MaxFiles {
+			os.Remove(files[0])
+			files = files[1:]
+		}
+	}
+	return nil
+}
+
+// openFile 以追加方式打开文件
+func (a *AuditLog) openFile(name string) error {
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	a.file, a.size = file, info.Size()
+	return nil
+}
+
+// Close 同步并关闭当前文件，之后的写入返回错误
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.closed = true
+	if a.file == nil {
+		return nil
+	}
+	syncErr := a.file.Sync()
+	closeErr := a.file.Close()
+	a.file = nil
+	if syncErr != nil {
+		return syncErr
+	}
+	return closeErr
+}
+
+// VerifyAuditLog 按顺序校验目录中全部审计文件的哈希链，返回校验通过的记录数
+// 因 MaxFiles 删除过旧文件时，从现存最旧文件的首条记录开始校验
+func VerifyAuditLog(dir, prefix string) (int, error) {
+	files, err := auditFiles(dir, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var seq uint64
+	var prevHash string
+	for _, name := range files {
+		file, err := os.Open(name)
+		if err != nil {
+			return count, fmt.Errorf("failed to open audit log: %w", err)
+		}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 16<<20)
+		line := 0
+		for scanner.Scan() {
+			line++
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			var record AuditRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				file.Close()
+				return count, fmt.Errorf("%s:%d: invalid audit record: %w", name, line, err)
+			}
+			if count > 0 && (record.Seq != seq+1 || record.PrevHash != prevHash) {
+				file.Close()
+				return count, fmt.Errorf("%s:%d: audit chain broken after seq %d", name, line, seq)
+			}
+			if auditHash(record.Seq, record.PrevHash, record.Event) != record.Hash {
+				file.Close()
+				return count, fmt.Errorf("%s:%d: audit record %d hash mismatch", name, line, record.Seq)
+			}
+			seq, prevHash = record.Seq, record.Hash
+			count++
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return count, fmt.Errorf("failed to read audit log %s: %w", name, err)
+		}
+	}
+	return count, nil
+}
+
+// auditFiles 目录中前缀匹配的审计文件，按首条记录序号排序
+func auditFiles(dir, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	type numbered struct {
+		name string
+		seq  uint64
+	}
+	var files []numbered
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix+"-") || !strings.HasSuffix(name, ".jsonl") {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, prefix+"-"), ".jsonl"), 10, 64)
+		if err != nil {
+			continue
+		}
+		files = append(files, numbered{name: filepath.Join(dir, name), seq: seq})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].seq < files[j].seq })
+
+	result := make([]string, len(files))
+	for i, f := range files {
+		result[i] = f.name
+	}
+	return result, nil
+}
+
+// lastAuditRecord 读取文件的最后一条记录，文件为空时返回 nil
+func lastAuditRecord(name string) (*AuditRecord, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// 审计文件只追加，从尾部向前找到最后一个完整行即可，无需读取整个文件
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	const chunk = 64 * 1024
+	var tail []byte
+	for offset := info.Size(); offset > 0; {
+		n := min(int64(chunk), offset)
+		offset -= n
+		buf := make([]byte, n)
+		if _, err := file.ReadAt(buf, offset); err != nil && err != io.EOF {
+			return nil, err
+		}
+		tail = append(buf, tail...)
+		trimmed := bytes.TrimRight(tail, "\n")
+		if idx := bytes.LastIndexByte(trimmed, '\n'); idx >= 0 || offset == 0 {
+			line := trimmed[idx+1:]
+			if len(line) == 0 {
+				return nil, nil
+			}
+			var record AuditRecord
+			if err := json.Unmarshal(line, &record); err != nil {
+				return nil, fmt.Errorf("invalid last record: %w", err)
+			}
+			return &record, nil
+		}
+	}
+	return nil, nil
+}
+
+// redactCredentials 将文本中出现的 API 凭证替换为占位符
+func redactCredentials(text string, creds *auth.Credentials) string {
+	if text == "" || creds == nil {
+		return text
+	}
+	for _, secret := range []string{creds.Secret, creds.Passphrase, creds.APIKey} {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, auditRedacted)
+		}
+	}
+	return text
+}
@@ -0,0 +1,138 @@
+package clob
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestAuditLog_RecordsTradingActions(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid passphrase test-passphrase"}`))
+	})
+	defer server.Close()
+
+	config := DefaultAuditConfig()
+	config.Dir = t.TempDir()
+	audit, err := OpenAuditLog(config)
+	if err != nil {
+		t.Fatalf("OpenAuditLog() error: %v", err)
+	}
+	client.audit = audit
+
+	client.CancelOrder(context.Background(), "order-1")
+	client.RecordEvent(TradingEvent{Type: TradingEventFill, OrderID: "order-2", Size: decimal.NewFromInt(5)})
+	client.Close()
+
+	if n, err := VerifyAuditLog(config.Dir, config.FilePrefix); err != nil || n != 2 {
+		t.Fatalf("VerifyAuditLog() = %d, %v", n, err)
+	}
+	data := readAuditDir(t, config.Dir)
+	if strings.Contains(data, "test-passphrase") || !strings.Contains(data, auditRedacted) {
+		t.Errorf("credentials should be redacted: %s", data)
+	}
+	if !strings.Contains(data, `"type":"cancel"`) || !strings.Contains(data, `"order_id":"order-2"`) {
+		t.Errorf("unexpected audit content: %s", data)
+	}
+}
+
+func TestAuditLog_RotationAndResume(t *testing.T) {
+	config := DefaultAuditConfig()
+	config.Dir = t.TempDir()
+	config.MaxFileSize = 600
+	audit, err := OpenAuditLog(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 6; i++ {
+		if err := audit.Write(TradingEvent{Type: TradingEventOrderSubmit, TokenID: "12345"}); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+	}
+	audit.Close()
+	if err := audit.Write(TradingEvent{Type: TradingEventOrderSubmit}); err == nil {
+		t.Error("write after Close should fail")
+	}
+
+	// Reopening continues the hash chain in the newest file
+	audit, err = OpenAuditLog(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	audit.Write(TradingEvent{Type: TradingEventCancel, OrderID: "order-1"})
+	audit.Close()
+
+	files, _ := auditFiles(config.Dir, config.FilePrefix)
+	if len(files) < 2 {
+		t.Fatalf("expected rotated files, got %v", files)
+	}
+	if n, err := VerifyAuditLog(config.Dir, config.FilePrefix); err != nil || n != 7 {
+		t.Fatalf("VerifyAuditLog() = %d, %v", n, err)
+	}
+
+	// MaxFiles prunes the oldest files, the remaining chain still verifies
+	config.MaxFiles = 1
+	audit, _ = OpenAuditLog(config)
+	for i := 0; i < 3; i++ {
+		audit.Write(TradingEvent{Type: TradingEventOrderSubmit, TokenID: "12345"})
+	}
+	audit.Close()
+	if files, _ := auditFiles(config.Dir, config.FilePrefix); len(files) != 1 {
+		t.Errorf("expected 1 file after pruning, got %v", files)
+	}
+	if _, err := VerifyAuditLog(config.Dir, config.FilePrefix); err != nil {
+		t.Errorf("VerifyAuditLog() after pruning: %v", err)
+	}
+}
+
+func TestVerifyAuditLog_DetectsTampering(t *testing.T) {
+	config := DefaultAuditConfig()
+	config.Dir = t.TempDir()
+	audit, err := OpenAuditLog(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"order-1", "order-2", "order-3"} {
+		audit.Write(TradingEvent{Type: TradingEventCancel, OrderID: id})
+	}
+	audit.Close()
+
+	files, _ := auditFiles(config.Dir, config.FilePrefix)
+	original, _ := os.ReadFile(files[0])
+
+	// Edited event
+	os.WriteFile(files[0], []byte(strings.Replace(string(original), "order-2", "order-9", 1)), 0o600)
+	if _, err := VerifyAuditLog(config.Dir, config.FilePrefix); err == nil || !strings.Contains(err.Error(), "hash mismatch") {
+		t.Errorf("expected hash mismatch, got %v", err)
+	}
+
+	// Deleted record
+	lines := strings.SplitAfter(string(original), "\n")
+	os.WriteFile(files[0], []byte(lines[0]+lines[2]), 0o600)
+	if n, err := VerifyAuditLog(config.Dir, config.FilePrefix); err == nil || n != 1 {
+		t.Errorf("expected broken chain after 1 record, got %d, %v", n, err)
+	}
+}
+
+func readAuditDir(t *testing.T, dir string) string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sb strings.Builder
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sb.Write(data)
+	}
+	return sb.String()
+}
@@ -0,0 +1,162 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// pendingTradeStatuses 已撮合但尚未上链的成交状态，余额还未反映这些成交
+var pendingTradeStatuses = map[string]bool{
+	"MATCHED":  true,
+	"RETRYING": true,
+}
+
+// BuyingPowerConfig 购买力计算配置
+type BuyingPowerConfig struct {
+	// SettlementWindow 查询该时长内的成交统计未结算金额，<= 0 使用 1 小时
+	SettlementWindow time.Duration
+	// SettlementHaircut 未结算卖出收入的折扣比例，只按 (1 - SettlementHaircut) 计入；
+	// 默认 1 表示结算前完全不计入，结算可能失败（FAILED）时不应提前使用这部分资金
+	SettlementHaircut decimal.Decimal
+	// BalanceHaircut 余额折扣比例（安全垫），如 0.02 表示保留余额的 2%
+	BalanceHaircut decimal.Decimal
+	// Reserve 固定保留的 USDC 金额，不用于新买单
+	Reserve decimal.Decimal
+}
+
+// DefaultBuyingPowerConfig 默认购买力计算配置
+func DefaultBuyingPowerConfig() *BuyingPowerConfig {
+	return &BuyingPowerConfig{
+		SettlementWindow:  time.Hour,
+		SettlementHaircut: decimal.NewFromInt(1),
+	}
+}
+
+// BuyingPower 可用购买力明细，金额单位均为 USDC
+// Available = Balance - BalanceHaircut - Reserve - OpenOrders - PendingBuys + PendingSellCredit，不小于 0
+type BuyingPower struct {
+	Balance           decimal.Decimal // 抵押品余额
+	BalanceHaircut    decimal.Decimal // 余额折扣扣除的金额
+	Reserve           decimal.Decimal // 固定保留金额
+	OpenOrders        decimal.Decimal // 挂单中的买单占用（价格 × 剩余数量）
+	PendingBuys       decimal.Decimal // 已撮合未结算的买入金额，余额尚未扣除
+	PendingSells      decimal.Decimal // 已撮合未结算的卖出收入，余额尚未计入
+	PendingSellCredit decimal.Decimal // 卖出收入中按折扣计入购买力的部分
+	Available         decimal.Decimal // 可用于新买单的金额
+	OpenBuyOrders     int             // 挂单中的买单数量
+	PendingTrades     int             // 未结算的成交数量
+	Time              time.Time       // 计算时间
+}
+
+// GetBuyingPower 计算可用于新买单的 USDC 金额
+// 综合抵押品余额、挂单中买单的占用与已撮合未结算的成交，避免各处用余额减挂单自行推算；
+// 配置取自 Config.BuyingPower，nil 时使用 DefaultBuyingPowerConfig。手续费不计入
+func (c *Client) GetBuyingPower(ctx context.Context) (*BuyingPower, error) {
+	config := c.config.BuyingPower
+	if config == nil {
+		config = DefaultBuyingPowerConfig()
+	}
+	window := config.SettlementWindow
+	if window <= 0 {
+		window = time.Hour
+	}
+	now := time.Now()
+
+	balance, err := c.GetCollateralBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collateral balance: %w", err)
+	}
+	orders, err := c.GetOpenOrders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open orders: %w", err)
+	}
+	trades, err := c.GetTrades(ctx, &TradesQueryParams{After: strconv.FormatInt(now.Add(-window).Unix(), 10)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent trades: %w", err)
+	}
+
+	bp := &BuyingPower{
+		// 余额以最小单位返回
+		Balance: balance.Balance.Shift(-USDCDecimals),
+		Reserve: config.Reserve,
+		Time:    now,
+	}
+	bp.BalanceHaircut = bp.Balance.Mul(config.BalanceHaircut)
+
+	for _, order := range orders {
+		if order.Side != OrderSideBuy || !order.IsActive() {
+			continue
+		}
+		if remaining := order.GetRemainingSize(); remaining.IsPositive() {
+			bp.OpenOrders = bp.OpenOrders.Add(order.Price.Mul(remaining))
+			bp.OpenBuyOrders++
+		}
+	}
+
+	owner := c.ownerAPIKey(ctx)
+	for _, trade := range trades {
+		if !pendingTradeStatuses[strings.ToUpper(trade.Status)] {
+			continue
+		}
+		buys, sells := ownTradeNotional(trade, owner)
+		if buys.IsZero() && sells.IsZero() {
+			continue
+		}
+		bp.PendingBuys = bp.PendingBuys.Add(buys)
+		bp.PendingSells = bp.PendingSells.Add(sells)
+		bp.PendingTrades++
+	}
+
+	credit := decimal.NewFromInt(1).Sub(config.SettlementHaircut)
+	if credit.IsPositive() {
+		bp.PendingSellCredit = bp.PendingSells.Mul(decimal.Min(credit, decimal.NewFromInt(1)))
+	}
+
+	bp.Available = bp.Balance.
+		Sub(bp.BalanceHaircut).
+		Sub(bp.Reserve).
+		Sub(bp.OpenOrders).
+		Sub(bp.PendingBuys).
+		Add(bp.PendingSellCredit)
+	if bp.Available.IsNegative() {
+		bp.Available = decimal.Zero
+	}
+	return bp, nil
+}
+
+// ownTradeNotional 成交中属于自己的买入与卖出金额
+// 作为 taker 时取成交本身的方向与数量；作为 maker 时累加 owner 为自己的 maker 订单
+func ownTradeNotional(trade *Trade, owner string) (buys, sells decimal.Decimal) {
+	if !strings.EqualFold(trade.TraderSide, "MAKER") {
+		notional := trade.Price.Mul(trade.Size)
+		if trade.Side == OrderSideBuy {
+			return notional, decimal.Zero
+		}
+		return decimal.Zero, notional
+	}
+
+	for _, maker := range trade.MakerOrders {
+		if owner != "" && maker.Owner != "" && maker.Owner != owner {
+			continue
+		}
+		price, err := decimal.NewFromString(maker.Price)
+		if err != nil {
+			continue
+		}
+		size, err := decimal.NewFromString(maker.MatchedAmount)
+		if err != nil {
+			continue
+		}
+		if OrderSide(strings.ToUpper(maker.Side)) == OrderSideBuy {
+			buys = buys.Add(price.Mul(size))
+		} else {
+			sells = sells.Add(price.Mul(size))
+		}
+	}
+	return buys, sells
+}
@@ -0,0 +1,82 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestClient_GetBuyingPower(t *testing.T) {
+	d := decimal.RequireFromString
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/balance-allowance":
+			json.NewEncoder(w).Encode(BalanceAllowance{Balance: decimal.NewFromInt(1000000000)}) // 1000 USDC
+		case "/orders":
+			json.NewEncoder(w).Encode([]*Order{
+				{ID: "b1", Status: OrderStatusLive, Side: OrderSideBuy, Price: d("0.40"), OriginalSize: d("500"), SizeMatched: d("100")},
+				{ID: "s1", Status: OrderStatusLive, Side: OrderSideSell, Price: d("0.60"), OriginalSize: d("100")},
+			})
+		case "/trades":
+			if r.URL.Query().Get("after") == "" {
+				t.Error("trades should be limited to the settlement window")
+			}
+			json.NewEncoder(w).Encode(TradesResponse{
+				NextCursor: EndCursor,
+				Data: []*Trade{
+					// Taker buy, not yet mined
+					{ID: "t1", Status: "MATCHED", TraderSide: "TAKER", Side: OrderSideBuy, Price: d("0.50"), Size: d("100")},
+					// Maker sell: only the order owned by this API key counts
+					{ID: "t2", Status: "RETRYING", TraderSide: "MAKER", Side: OrderSideBuy, MakerOrders: []MakerOrder{
+						{Owner: "test-api-key", Side: "SELL", Price: "0.80", MatchedAmount: "50"},
+						{Owner: "someone-else", Side: "SELL", Price: "0.80", MatchedAmount: "999"},
+					}},
+					// Settled trades are already reflected in the balance
+					{ID: "t3", Status: "CONFIRMED", TraderSide: "TAKER", Side: OrderSideBuy, Price: d("0.50"), Size: d("1000")},
+				},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	bp, err := client.GetBuyingPower(context.Background())
+	if err != nil {
+		t.Fatalf("GetBuyingPower() error: %v", err)
+	}
+	// 1000 - 0.40*400 - 0.50*100, pending sell proceeds are not credited by default
+	if !bp.Balance.Equal(d("1000")) || !bp.OpenOrders.Equal(d("160")) || bp.OpenBuyOrders != 1 {
+		t.Errorf("unexpected balance/open orders: %+v", bp)
+	}
+	if !bp.PendingBuys.Equal(d("50")) || !bp.PendingSells.Equal(d("40")) || bp.PendingTrades != 2 || !bp.PendingSellCredit.IsZero() {
+		t.Errorf("unexpected pending settlements: %+v", bp)
+	}
+	if !bp.Available.Equal(d("790")) {
+		t.Errorf("Available = %s, expected 790", bp.Available)
+	}
+
+	// Haircuts and reserve
+	client.config.BuyingPower = &BuyingPowerConfig{
+		SettlementHaircut: d("0.5"),
+		BalanceHaircut:    d("0.1"),
+		Reserve:           d("100"),
+	}
+	bp, err = client.GetBuyingPower(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1000 - 100 - 100 - 160 - 50 + 20
+	if !bp.PendingSellCredit.Equal(d("20")) || !bp.Available.Equal(d("610")) {
+		t.Errorf("unexpected buying power with haircuts: %+v", bp)
+	}
+
+	client.config.BuyingPower.Reserve = d("5000")
+	if bp, _ := client.GetBuyingPower(context.Background()); !bp.Available.IsZero() {
+		t.Errorf("Available should not go negative, got %s", bp.Available)
+	}
+}
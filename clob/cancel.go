@@ -0,0 +1,171 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// CancelStatus 撤单结果分类
+type CancelStatus string
+
+const (
+	CancelStatusCanceled CancelStatus = "CANCELED"  // 本次请求撤销成功
+	CancelStatusInactive CancelStatus = "INACTIVE"  // 订单此前已撤销、已成交或已过期
+	CancelStatusNotFound CancelStatus = "NOT_FOUND" // 交易所没有该订单
+	CancelStatusFailed   CancelStatus = "FAILED"    // 其他原因失败（网络、认证、限流等），可以重试
+)
+
+// CancelResult 单个订单的撤单结果
+type CancelResult struct {
+	OrderID string
+	Status  CancelStatus
+	Reason  string // 未撤销的原因（交易所返回的原文或错误信息）
+}
+
+// Gone 订单是否已不在订单簿上（撤销成功或早已不活跃），清理流程可据此停止重试
+func (r CancelResult) Gone() bool {
+	return r.Status == CancelStatusCanceled || r.Status == CancelStatusInactive
+}
+
+// CancelStatusOf 对 CancelOrder 返回的错误分类，err 为 nil 时为 CancelStatusCanceled
+func CancelStatusOf(err error) CancelStatus {
+	switch {
+	case err == nil:
+		return CancelStatusCanceled
+	case errors.Is(err, common.ErrOrderAlreadyCanceled):
+		return CancelStatusInactive
+	case errors.Is(err, common.ErrOrderNotFound):
+		return CancelStatusNotFound
+	}
+
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode >= 500 || apiErr.StatusCode == 429 {
+		return CancelStatusFailed
+	}
+	if status := classifyCancelReason(apiErr.Code + " " + apiErr.Message); status != CancelStatusFailed {
+		return status
+	}
+	if apiErr.StatusCode == 404 {
+		return CancelStatusNotFound
+	}
+	return CancelStatusFailed
+}
+
+// classifyCancelReason 根据交易所返回的原因文本分类
+// "not found or already canceled" 这类无法区分的原因视为已不活跃
+func classifyCancelReason(reason string) CancelStatus {
+	reason = strings.ToLower(reason)
+	for _, keyword := range []string{"already canceled", "already cancelled", "matched", "filled", "expired", "not active", "inactive"} {
+		if strings.Contains(reason, keyword) {
+			return CancelStatusInactive
+		}
+	}
+	for _, keyword := range []string{"not found", "can't be found", "cannot be found", "does not exist"} {
+		if strings.Contains(reason, keyword) {
+			return CancelStatusNotFound
+		}
+	}
+	return CancelStatusFailed
+}
+
+// cancelError 按撤单结果包装错误，已不活跃与不存在的订单可用 errors.Is 判断
+func cancelError(err error) error {
+	switch CancelStatusOf(err) {
+	case CancelStatusInactive:
+		if !errors.Is(err, common.ErrOrderAlreadyCanceled) {
+			return fmt.Errorf("failed to cancel order: %w: %w", common.ErrOrderAlreadyCanceled, err)
+		}
+	case CancelStatusNotFound:
+		if !errors.Is(err, common.ErrOrderNotFound) {
+			return fmt.Errorf("failed to cancel order: %w: %w", common.ErrOrderNotFound, err)
+		}
+	}
+	return fmt.Errorf("failed to cancel order: %w", err)
+}
+
+// CancelOrderWithResult 撤销单个订单并返回分类结果
+// 订单已不活跃或不存在时不返回错误，由结果的 Status 区分；只有 CancelStatusFailed 时返回错误
+func (c *Client) CancelOrderWithResult(ctx context.Context, orderID string) (*CancelResult, error) {
+	err := c.cancelOrder(ctx, orderID)
+	result := &CancelResult{OrderID: orderID, Status: CancelStatusOf(err)}
+	if err != nil {
+		result.Reason = err.Error()
+	}
+	if result.Status == CancelStatusFailed {
+		return result, err
+	}
+	return result, nil
+}
+
+// Results 将批量撤单响应转换为 orderIDs 中每个订单的分类结果，顺序与 orderIDs 一致
+// 响应中未出现的订单视为 CancelStatusFailed
+func (r *CancelResponse) Results(orderIDs []string) []CancelResult {
+	canceled := make(map[string]bool)
+	notCanceled := make(map[string]bool)
+	if r != nil {
+		for _, id := range r.Canceled {
+			canceled[id] = true
+		}
+		for _, id := range r.NotCanceled {
+			notCanceled[id] = true
+		}
+	}
+
+	results := make([]CancelResult, len(orderIDs))
+	for i, id := range orderIDs {
+		result := CancelResult{OrderID: id}
+		switch {
+		case canceled[id]:
+			result.Status = CancelStatusCanceled
+		case notCanceled[id]:
+			result.Reason = r.NotCanceledReasons[id]
+			result.Status = classifyCancelReason(result.Reason)
+			if result.Reason == "" {
+				// 没有原因时交易所只是拒绝撤销，订单已不在可撤销状态
+				result.Status = CancelStatusInactive
+			}
+		default:
+			result.Status = CancelStatusFailed
+			result.Reason = "missing from cancel response"
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// UnmarshalJSON 自定义 JSON 反序列化
+// not_canceled 兼容订单 ID 数组与 {订单 ID: 原因} 对象两种格式，对象格式的原因保存在 NotCanceledReasons
+func (r *CancelResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Canceled    []string        `json:"canceled"`
+		NotCanceled json.RawMessage `json:"not_canceled"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = CancelResponse{Canceled: raw.Canceled}
+	trimmed := strings.TrimSpace(string(raw.NotCanceled))
+	switch {
+	case trimmed == "" || trimmed == "null":
+	case strings.HasPrefix(trimmed, "["):
+		if err := json.Unmarshal(raw.NotCanceled, &r.NotCanceled); err != nil {
+			return fmt.Errorf("invalid not_canceled: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(raw.NotCanceled, &r.NotCanceledReasons); err != nil {
+			return fmt.Errorf("invalid not_canceled: %w", err)
+		}
+		for id := range r.NotCanceledReasons {
+			r.NotCanceled = append(r.NotCanceled, id)
+		}
+		sort.Strings(r.NotCanceled)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestCancelStatusOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want CancelStatus
+	}{
+		{nil, CancelStatusCanceled},
+		{fmt.Errorf("wrapped: %w", common.ErrOrderAlreadyCanceled), CancelStatusInactive},
+		{common.ErrOrderNotFound, CancelStatusNotFound},
+		{common.NewAPIError(400, "order can't be found - already canceled or matched", ""), CancelStatusInactive},
+		{common.NewAPIError(400, "Order not found", ""), CancelStatusNotFound},
+		{common.NewAPIError(404, "Not Found", ""), CancelStatusNotFound},
+		{common.NewAPIError(400, "invalid order id", ""), CancelStatusFailed},
+		{common.NewAPIError(503, "order not found", ""), CancelStatusFailed},
+		{errors.New("connection reset"), CancelStatusFailed},
+	}
+	for _, tt := range tests {
+		if got := CancelStatusOf(tt.err); got != tt.want {
+			t.Errorf("CancelStatusOf(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCancelOrderAlreadyInactive(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"order can't be found - already canceled or matched"}`))
+	})
+	defer server.Close()
+	ctx := context.Background()
+
+	err := client.CancelOrder(ctx, "order-1")
+	if !errors.Is(err, common.ErrOrderAlreadyCanceled) {
+		t.Errorf("expected ErrOrderAlreadyCanceled, got %v", err)
+	}
+
+	result, err := client.CancelOrderWithResult(ctx, "order-1")
+	if err != nil || result.Status != CancelStatusInactive || !result.Gone() {
+		t.Errorf("CancelOrderWithResult() = %+v, %v", result, err)
+	}
+
+	client.config.IdempotentCancel = true
+	if err := client.CancelOrder(ctx, "order-1"); err != nil {
+		t.Errorf("idempotent cancel should succeed, got %v", err)
+	}
+}
+
+func TestCancelOrderNotFoundStillFailsWhenIdempotent(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"order not found"}`))
+	})
+	defer server.Close()
+	client.config.IdempotentCancel = true
+
+	if err := client.CancelOrder(context.Background(), "order-1"); !errors.Is(err, common.ErrOrderNotFound) {
+		t.Errorf("expected ErrOrderNotFound, got %v", err)
+	}
+	result, err := client.CancelOrderWithResult(context.Background(), "order-1")
+	if err != nil || result.Status != CancelStatusNotFound || result.Gone() {
+		t.Errorf("CancelOrderWithResult() = %+v, %v", result, err)
+	}
+}
+
+func TestCancelOrderWithResultFailure(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer server.Close()
+
+	result, err := client.CancelOrderWithResult(context.Background(), "order-1")
+	if err == nil || result.Status != CancelStatusFailed {
+		t.Errorf("expected a failed result with error, got %+v, %v", result, err)
+	}
+}
+
+func TestCancelResponseResults(t *testing.T) {
+	var resp CancelResponse
+	data := `{"canceled":["a"],"not_canceled":{"b":"order can't be found - already canceled or matched","c":"order not found"}}`
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if len(resp.NotCanceled) != 2 || resp.NotCanceled[0] != "b" {
+		t.Errorf("NotCanceled = %v", resp.NotCanceled)
+	}
+
+	results := resp.Results([]string{"a", "b", "c", "d"})
+	want := []CancelStatus{CancelStatusCanceled, CancelStatusInactive, CancelStatusNotFound, CancelStatusFailed}
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("result %s = %s, want %s", result.OrderID, result.Status, want[i])
+		}
+	}
+
+	// The array form is still accepted
+	if err := json.Unmarshal([]byte(`{"canceled":[],"not_canceled":["x"]}`), &resp); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if len(resp.NotCanceled) != 1 || resp.NotCanceledReasons != nil {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
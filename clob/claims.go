@@ -0,0 +1,352 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// LockService 跨进程的租约锁服务，用于多个进程共用一个 funder 钱包时协调市场归属
+// 可基于 Redis（SET key owner NX PX ttl，续期与释放时校验 owner）、etcd（lease + 事务）等实现；
+// SDK 只内置进程内的 MemoryLockService
+type LockService interface {
+	// Acquire 以 owner 身份获取 key，ttl 后自动过期；owner 已持有时续期并返回 true，被其他 owner 持有时返回 false
+	Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Release 释放 owner 持有的 key，key 不存在或属于其他 owner 时不做任何事
+	Release(ctx context.Context, key, owner string) error
+}
+
+// memoryLease 进程内租约
+type memoryLease struct {
+	owner   string
+	expires time.Time
+}
+
+// MemoryLockService 进程内的 LockService 实现，用于测试或同一进程内的多个客户端
+type MemoryLockService struct {
+	mu     sync.Mutex
+	leases map[string]memoryLease
+	now    func() time.Time
+}
+
+// NewMemoryLockService 创建进程内锁服务
+func NewMemoryLockService() *MemoryLockService {
+	return &MemoryLockService{leases: make(map[string]memoryLease), now: time.Now}
+}
+
+// Acquire 实现 LockService
+func (s *MemoryLockService) Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if lease, ok := s.leases[key]; ok && lease.owner != owner && now.Before(lease.expires) {
+		return false, nil
+	}
+	s.leases[key] = memoryLease{owner: owner, expires: now.Add(ttl)}
+	return true, nil
+}
+
+// Release 实现 LockService
+func (s *MemoryLockService) Release(ctx context.Context, key, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lease, ok := s.leases[key]; ok && lease.owner == owner {
+		delete(s.leases, key)
+	}
+	return nil
+}
+
+// ClaimsConfig 市场认领配置
+type ClaimsConfig struct {
+	Locks         LockService
+	Owner         string                      // 本进程标识，为空时使用 "<hostname>-<pid>"
+	Namespace     string                      // 锁 key 前缀，同一 funder 的进程应使用相同前缀（如包含 funder 地址）
+	TTL           time.Duration               // 租约时长，进程崩溃后其他进程最迟 TTL 后可接手
+	RenewInterval time.Duration               // 续期间隔，应明显小于 TTL，<= 0 时取 TTL/3
+	MarketKey     func(tokenID string) string // 将 token 映射为认领的市场标识（如 conditionID）；nil 时按 token 认领
+}
+
+// DefaultClaimsConfig 默认配置，需设置 Locks
+func DefaultClaimsConfig() *ClaimsConfig {
+	return &ClaimsConfig{
+		Namespace: "polymarket:claims:",
+		TTL:       30 * time.Second,
+	}
+}
+
+// MarketClaims 本进程认领的市场
+// 设置到 Client（SetClaims）后，下单与撤单只允许针对已认领的市场，避免多个进程同时管理同一批订单；
+// 后台定期续期，续期失败或租约被其他进程取得时该市场从已认领集合移除并通过 Lost 通知
+type MarketClaims struct {
+	config *ClaimsConfig
+
+	mu     sync.Mutex
+	held   map[string]struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	lost chan string
+}
+
+// NewMarketClaims 创建市场认领，调用 Start 开始后台续期
+func NewMarketClaims(config *ClaimsConfig) (*MarketClaims, error) {
+	if config == nil || config.Locks == nil {
+		return nil, fmt.Errorf("%w: claims require a lock service", common.ErrInvalidConfig)
+	}
+	if config.TTL <= 0 {
+		config.TTL = 30 * time.Second
+	}
+	if config.RenewInterval <= 0 {
+		config.RenewInterval = config.TTL / 3
+	}
+	if config.Owner == "" {
+		host, _ := os.Hostname()
+		config.Owner = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+
+	return &MarketClaims{
+		config: config,
+		held:   make(map[string]struct{}),
+		lost:   make(chan string, 100),
+	}, nil
+}
+
+// Owner 本进程标识
+func (m *MarketClaims) Owner() string {
+	return m.config.Owner
+}
+
+// Lost 失去认领的市场（满时丢弃），收到后应停止管理该市场的订单
+func (m *MarketClaims) Lost() <-chan string {
+	return m.lost
+}
+
+// Claim 认领市场，已被其他进程认领时返回 ErrMarketClaimed
+func (m *MarketClaims) Claim(ctx context.Context, market string) error {
+	if market == "" {
+		return fmt.Errorf("market is required")
+	}
+	ok, err := m.config.Locks.Acquire(ctx, m.key(market), m.config.Owner, m.config.TTL)
+	if err != nil {
+		return fmt.Errorf("failed to claim market %s: %w", market, err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", common.ErrMarketClaimed, market)
+	}
+
+	m.mu.Lock()
+	m.held[market] = struct{}{}
+	m.mu.Unlock()
+	return nil
+}
+
+// ClaimToken 认领 token 所属的市场（按 MarketKey 映射）
+func (m *MarketClaims) ClaimToken(ctx context.Context, tokenID string) error {
+	return m.Claim(ctx, m.marketOf(tokenID))
+}
+
+// Release 释放市场认领
+func (m *MarketClaims) Release(ctx context.Context, market string) error {
+	m.mu.Lock()
+	delete(m.held, market)
+	m.mu.Unlock()
+	return m.config.Locks.Release(ctx, m.key(market), m.config.Owner)
+}
+
+// Owns 是否认领了市场
+func (m *MarketClaims) Owns(market string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.held[market]
+	return ok
+}
+
+// OwnsToken 是否认领了 token 所属的市场
+func (m *MarketClaims) OwnsToken(tokenID string) bool {
+	return m.Owns(m.marketOf(tokenID))
+}
+
+// Markets 已认领的市场，已排序
+func (m *MarketClaims) Markets() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]string, 0, len(m.held))
+	for market := range m.held {
+		result = append(result, market)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Start 启动后台续期
+func (m *MarketClaims) Start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		common.Supervise(ctx, "clob.claims", nil, m.renewLoop)
+	}()
+}
+
+// Close 停止续期并释放全部认领
+func (m *MarketClaims) Close(ctx context.Context) error {
+	m.mu.Lock()
+	cancel := m.cancel
+	m.cancel = nil
+	m.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		m.wg.Wait()
+	}
+
+	var firstErr error
+	for _, market := range m.Markets() {
+		if err := m.Release(ctx, market); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// renewLoop 定期续期
+func (m *MarketClaims) renewLoop(ctx context.Context) {
+	ticker := time.NewTicker(m.config.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Renew(ctx)
+		}
+	}
+}
+
+// Renew 续期全部认领，返回失去认领的市场
+// 锁服务出错时同样视为失去认领：无法确认租约仍然有效时，不能继续管理该市场的订单
+func (m *MarketClaims) Renew(ctx context.Context) []string {
+	var lost []string
+	for _, market := range m.Markets() {
+		ok, err := m.config.Locks.Acquire(ctx, m.key(market), m.config.Owner, m.config.TTL)
+		if ok && err == nil {
+			if !m.Owns(market) {
+				// 续期期间被主动释放，撤销刚续上的租约
+				m.config.Locks.Release(ctx, m.key(market), m.config.Owner)
+			}
+			continue
+		}
+		if ctx.Err() != nil {
+			return lost
+		}
+		if err != nil {
+			log.Printf("[Polymarket Claims] failed to renew claim on %s: %v", market, err)
+		}
+
+		m.mu.Lock()
+		_, held := m.held[market]
+		delete(m.held, market)
+		m.mu.Unlock()
+		if !held {
+			// 续期期间已被主动释放
+			continue
+		}
+		lost = append(lost, market)
+		select {
+		case m.lost <- market:
+		default:
+		}
+	}
+	return lost
+}
+
+// check 校验 token 所属市场均已认领
+func (m *MarketClaims) check(tokenIDs ...string) error {
+	for _, tokenID := range tokenIDs {
+		if market := m.marketOf(tokenID); !m.Owns(market) {
+			return fmt.Errorf("%w: %s", common.ErrMarketNotClaimed, market)
+		}
+	}
+	return nil
+}
+
+// marketOf token 对应的认领市场标识
+func (m *MarketClaims) marketOf(tokenID string) string {
+	if m.config.MarketKey != nil {
+		if market := m.config.MarketKey(tokenID); market != "" {
+			return market
+		}
+	}
+	return tokenID
+}
+
+// key 市场的锁 key
+func (m *MarketClaims) key(market string) string {
+	return m.config.Namespace + market
+}
+
+// SetClaims 设置市场认领，nil 表示不限制
+// 设置后下单、按订单 ID 撤单与按市场/资产撤单只允许针对已认领的市场；
+// 事件日志中查不到所属 token 的订单（如重启前的挂单）无法判断归属，不做限制；
+// CancelAllOrders 作为紧急撤单不受限制
+func (c *Client) SetClaims(claims *MarketClaims) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.claims = claims
+}
+
+// Claims 当前的市场认领，未设置时为 nil
+func (c *Client) Claims() *MarketClaims {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.claims
+}
+
+// checkClaimedTokens 校验下单 token 所属市场已认领
+func (c *Client) checkClaimedTokens(tokenIDs ...string) error {
+	if claims := c.Claims(); claims != nil {
+		return claims.check(tokenIDs...)
+	}
+	return nil
+}
+
+// checkClaimedOrders 校验撤单订单所属市场已认领，订单所属 token 从事件日志中查找
+func (c *Client) checkClaimedOrders(orderIDs []string) error {
+	claims := c.Claims()
+	if claims == nil {
+		return nil
+	}
+	for _, orderID := range orderIDs {
+		if tokenID := c.eventLog.tokenForOrder(orderID); tokenID != "" {
+			if err := claims.check(tokenID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkClaimedMarket 校验市场已认领（按市场撤单）
+func (c *Client) checkClaimedMarket(market string) error {
+	if claims := c.Claims(); claims != nil && !claims.Owns(market) {
+		return fmt.Errorf("%w: %s", common.ErrMarketNotClaimed, market)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func newTestClaims(t *testing.T, locks LockService, owner string) *MarketClaims {
+	t.Helper()
+	config := DefaultClaimsConfig()
+	config.Locks = locks
+	config.Owner = owner
+	config.MarketKey = func(tokenID string) string {
+		if tokenID == "12345" || tokenID == "67890" {
+			return "condition-1"
+		}
+		return ""
+	}
+	claims, err := NewMarketClaims(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return claims
+}
+
+func TestMarketClaims_ExclusiveAcrossOwners(t *testing.T) {
+	locks := NewMemoryLockService()
+	clock := time.Unix(1700000000, 0)
+	locks.now = func() time.Time { return clock }
+	ctx := context.Background()
+
+	a := newTestClaims(t, locks, "process-a")
+	b := newTestClaims(t, locks, "process-b")
+
+	if err := a.ClaimToken(ctx, "12345"); err != nil {
+		t.Fatalf("ClaimToken() error: %v", err)
+	}
+	if !a.OwnsToken("67890") {
+		t.Error("both outcomes of the market should be owned")
+	}
+	if err := b.Claim(ctx, "condition-1"); !errors.Is(err, common.ErrMarketClaimed) {
+		t.Errorf("expected ErrMarketClaimed, got %v", err)
+	}
+
+	// The lease expires without renewal and another process takes over
+	clock = clock.Add(time.Minute)
+	if err := b.Claim(ctx, "condition-1"); err != nil {
+		t.Fatalf("expired claim should be taken over, got %v", err)
+	}
+	if lost := a.Renew(ctx); len(lost) != 1 || lost[0] != "condition-1" {
+		t.Errorf("Renew() lost = %v", lost)
+	}
+	if a.Owns("condition-1") || len(a.Lost()) != 1 {
+		t.Error("process-a should have lost the market")
+	}
+
+	if err := b.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Claim(ctx, "condition-1"); err != nil {
+		t.Errorf("released market should be claimable, got %v", err)
+	}
+}
+
+func TestClient_ClaimsGuardOrdersAndCancels(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/order":
+			w.Write([]byte(`{"success":true,"orderID":"order-1","status":"live"}`))
+		default:
+			w.Write([]byte(`{"canceled":[],"not_canceled":{}}`))
+		}
+	})
+	defer server.Close()
+	ctx := context.Background()
+
+	claims := newTestClaims(t, NewMemoryLockService(), "process-a")
+	client.SetClaims(claims)
+
+	req := &CreateOrderRequest{
+		TokenID: "12345",
+		Side:    OrderSideBuy,
+		Price:   decimal.RequireFromString("0.5"),
+		Size:    decimal.NewFromInt(10),
+		Type:    OrderTypeGTC,
+	}
+	if _, err := client.CreateOrder(ctx, req); !errors.Is(err, common.ErrMarketNotClaimed) {
+		t.Fatalf("expected ErrMarketNotClaimed, got %v", err)
+	}
+	if _, err := client.CancelOrdersByMarket(ctx, "condition-1"); !errors.Is(err, common.ErrMarketNotClaimed) {
+		t.Errorf("expected ErrMarketNotClaimed, got %v", err)
+	}
+
+	if err := claims.Claim(ctx, "condition-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateOrder(ctx, req); err != nil {
+		t.Fatalf("CreateOrder() error: %v", err)
+	}
+	if _, err := client.CancelOrdersByAsset(ctx, "67890"); err != nil {
+		t.Errorf("CancelOrdersByAsset() error: %v", err)
+	}
+
+	// Once the claim is gone, the known order can no longer be canceled from this process
+	claims.Release(ctx, "condition-1")
+	if _, err := client.CancelOrders(ctx, []string{"order-1"}); !errors.Is(err, common.ErrMarketNotClaimed) {
+		t.Errorf("expected ErrMarketNotClaimed, got %v", err)
+	}
+	// Orders of unknown tokens are not restricted
+	if _, err := client.CancelOrders(ctx, []string{"order-unknown"}); err != nil {
+		t.Errorf("CancelOrders() of an unknown order error: %v", err)
+	}
+}
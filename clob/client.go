@@ -14,7 +14,8 @@ import (
 type Client struct {
 	mu sync.RWMutex
 
-	httpClient   *common.HTTPClient
+	httpClient   *common.HTTPClient // 读请求（查询、行情、凭证）
+	writeClient  *common.HTTPClient // 写请求（下单、撤单），独立的超时与重试
 	config       *Config
 
 	// 认证
@@ -24,6 +25,30 @@ type Client struct {
 
 	// 订单签名
 	orderSigner  *OrderSigner
+
+	// 最近的下单/撤单/成交事件
+	eventLog     *EventLog
+
+	// 按 token 缓存的市场接单状态
+	marketStatus *marketStatusCache
+
+	// 按市场的下单/撤单自限流
+	throttle     *orderThrottle
+
+	// 审计日志（可选）
+	audit        *AuditLog
+
+	// 多进程共用 funder 时本进程认领的市场（可选）
+	claims       *MarketClaims
+
+	// 本地仅挂单检查（可选）
+	passive      *PassiveConfig
+
+	// 本地订单簿对手盘档位，供按订单簿定价的市价单使用（可选）
+	bookLevels   BookLevelsFunc
+
+	// L2 签名时间戳的时钟来源，nil 使用本地时钟
+	l2Clock      func() time.Time
 }
 
 // Config CLOB 模块配置
@@ -34,11 +59,51 @@ type Config struct {
 	MaxRetries           int           // 最大重试次数
 	RetryDelayMs         int           // 重试间隔
 
+	// 写请求（下单、撤单）的超时与重试，与上面的读请求配置相互独立
+	// 下单通常需要短超时且不重试：超时后重试可能重复下单，应先查询订单状态再决定
+	WriteTimeout      time.Duration // 写请求超时，0 表示使用 Timeout
+	WriteMaxRetries   int           // 写请求最大重试次数，0 表示不重试
+	WriteRetryDelayMs int           // 写请求重试间隔（毫秒），0 表示使用 RetryDelayMs
+
 	// 合约地址
 	ExchangeAddress        string // 标准市场交易合约
 	NegRiskExchangeAddress string // NegRisk 市场交易合约
 	NegRiskAdapterAddress  string // NegRisk 适配器合约
 	CollateralAddress      string // 抵押品合约地址
+
+	// 条件代币（ERC1155）合约地址，为空使用 ConditionalTokensAddress
+	ConditionalTokensAddress string
+
+	// 出口地址池（可选），用于多出口 IP 部署
+	LocalAddrs *common.LocalAddrPool
+
+	// 交易事件日志容量（<= 0 使用 DefaultEventLogSize）
+	EventLogSize int
+
+	// 市场停止接单状态的缓存时长（<= 0 使用 DefaultMarketStatusTTL）
+	MarketStatusTTL time.Duration
+
+	// 按市场的下单/撤单自限流（可选），nil 表示不限流，运行时可通过 SetThrottle 调整
+	Throttle *ThrottleConfig
+
+	// CancelOrder 对已撤销/已成交的订单返回 nil 而非错误，便于清理流程重复撤单
+	IdempotentCancel bool
+
+	// 审计日志（可选），设置后每条下单/撤单请求与结果写入轮换的 JSON 行文件，nil 表示不启用
+	Audit *AuditConfig
+
+	// 购买力计算（见 GetBuyingPower），nil 使用 DefaultBuyingPowerConfig
+	BuyingPower *BuyingPowerConfig
+
+	// L2 认证头复用（可选），nil 表示每次请求重新签名，见 PrecomputeL2Headers
+	L2HeaderCache *auth.L2HeaderCacheConfig
+	// L2 签名时间戳的时钟来源（可选），nil 使用本地时钟，见 SyncL2Clock
+	L2Clock func() time.Time
+
+	// 连接预热与保活（见 WarmUp）
+	MaxIdleConnsPerHost int           // 每主机空闲连接数，0 使用默认值（不小于 WarmConns）
+	WarmConns           int           // 预热的连接数
+	KeepAliveInterval   time.Duration // 预热后的保活间隔，0 表示不保活
 }
 
 // DefaultConfig 默认配置
@@ -49,10 +114,16 @@ func DefaultConfig() *Config {
 		Timeout:                30 * time.Second,
 		MaxRetries:             3,
 		RetryDelayMs:           1000,
+		WriteTimeout:           10 * time.Second,
+		WriteMaxRetries:        0,
 		ExchangeAddress:        "0x4bFb41d5B3570DeFd03C39a9A4D8De6Bd8b8982e",
 		NegRiskExchangeAddress: "0xC5d563A36AE78145C45a50134d48A1215220f80a",
 		NegRiskAdapterAddress:  "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296",
 		CollateralAddress:      "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174",
+		EventLogSize:           DefaultEventLogSize,
+		MarketStatusTTL:        DefaultMarketStatusTTL,
+		WarmConns:              2,
+		KeepAliveInterval:      30 * time.Second,
 	}
 }
 
@@ -72,6 +143,25 @@ func NewClient(config *Config, privateKey string) (*Client, error) {
 		Timeout:      config.Timeout,
 		MaxRetries:   config.MaxRetries,
 		RetryDelayMs: config.RetryDelayMs,
+		LocalAddrs:   config.LocalAddrs,
+	}
+
+	// 写请求走独立的连接池，预热与保活只作用于写客户端
+	writeConfig := &common.HTTPClientConfig{
+		BaseURL:      config.Endpoint,
+		Timeout:      config.WriteTimeout,
+		MaxRetries:   config.WriteMaxRetries,
+		RetryDelayMs: config.WriteRetryDelayMs,
+		LocalAddrs:   config.LocalAddrs,
+	}
+	if writeConfig.Timeout <= 0 {
+		writeConfig.Timeout = config.Timeout
+	}
+	if writeConfig.RetryDelayMs <= 0 {
+		writeConfig.RetryDelayMs = config.RetryDelayMs
+	}
+	if config.MaxIdleConnsPerHost > 0 || config.WarmConns > 0 {
+		writeConfig.MaxIdleConnsPerHost = max(config.MaxIdleConnsPerHost, config.WarmConns)
 	}
 
 	orderSigner := NewOrderSigner(
@@ -82,11 +172,24 @@ func NewClient(config *Config, privateKey string) (*Client, error) {
 		config.NegRiskAdapterAddress,
 	)
 
+	var audit *AuditLog
+	if config.Audit != nil {
+		if audit, err = OpenAuditLog(config.Audit); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Client{
 		httpClient:  common.NewHTTPClient(httpConfig),
+		writeClient: common.NewHTTPClient(writeConfig),
 		config:      config,
 		l1Signer:    l1Signer,
 		orderSigner: orderSigner,
+		eventLog:    NewEventLog(config.EventLogSize),
+		marketStatus: newMarketStatusCache(config.MarketStatusTTL),
+		throttle:     newOrderThrottle(config.Throttle),
+		audit:        audit,
+		l2Clock:      config.L2Clock,
 	}, nil
 }
 
@@ -98,14 +201,57 @@ func NewClientWithCredentials(config *Config, privateKey string, creds *auth.Cre
 	}
 
 	client.credentials = creds
-	client.l2Signer = auth.NewL2Signer(client.l1Signer.GetAddress(), creds)
+	client.l2Signer = client.newL2Signer(client.l1Signer.GetAddress(), creds)
 
 	return client, nil
 }
 
-// Close 关闭客户端
+// Close 关闭客户端，停止连接保活
 func (c *Client) Close() {
-	// HTTP 客户端无需显式关闭
+	c.writeClient.StopKeepAlive()
+	if c.audit != nil {
+		c.audit.Close()
+	}
+}
+
+// WarmUp 在写客户端上预先建立 WarmConns 个到 CLOB 的 TLS 连接，减少空闲后首笔下单的握手延迟
+// KeepAliveInterval > 0 时同时启动后台保活，定期请求以保持连接不被关闭，Close 时停止
+func (c *Client) WarmUp(ctx context.Context) error {
+	if err := c.writeClient.Warmup(ctx, "/", c.config.WarmConns); err != nil {
+		return err
+	}
+	c.mu.RLock()
+	interval := c.config.KeepAliveInterval
+	c.mu.RUnlock()
+	c.writeClient.StartKeepAlive("/", interval, c.config.WarmConns)
+	return nil
+}
+
+// SetRetryPolicy 运行时调整读请求的最大重试次数与重试间隔（毫秒），写请求见 SetWriteRetryPolicy
+// 单次调用可通过 common.WithRetry 携带 RetryOptions 的 ctx 单独指定重试策略
+func (c *Client) SetRetryPolicy(maxRetries, retryDelayMs int) {
+	c.mu.Lock()
+	c.config.MaxRetries = maxRetries
+	c.config.RetryDelayMs = retryDelayMs
+	c.mu.Unlock()
+	c.httpClient.SetRetryPolicy(maxRetries, time.Duration(retryDelayMs)*time.Millisecond)
+}
+
+// SetWriteRetryPolicy 运行时调整写请求（下单、撤单）的最大重试次数与重试间隔（毫秒）
+func (c *Client) SetWriteRetryPolicy(maxRetries, retryDelayMs int) {
+	c.mu.Lock()
+	c.config.WriteMaxRetries = maxRetries
+	c.config.WriteRetryDelayMs = retryDelayMs
+	c.mu.Unlock()
+	c.writeClient.SetRetryPolicy(maxRetries, time.Duration(retryDelayMs)*time.Millisecond)
+}
+
+// SetKeepAliveInterval 运行时调整保活间隔，保活已在运行时按新间隔重启，0 表示停止保活
+func (c *Client) SetKeepAliveInterval(interval time.Duration) {
+	c.mu.Lock()
+	c.config.KeepAliveInterval = interval
+	c.mu.Unlock()
+	c.writeClient.UpdateKeepAlive("/", interval, c.config.WarmConns)
 }
 
 // GetAddress 获取钱包地址
@@ -125,7 +271,7 @@ func (c *Client) SetCredentials(creds *auth.Credentials) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.credentials = creds
-	c.l2Signer = auth.NewL2Signer(c.l1Signer.GetAddress(), creds)
+	c.l2Signer = c.newL2Signer(c.l1Signer.GetAddress(), creds)
 }
 
 // SetCredentialsWithAddress 设置凭证（指定账户地址）
@@ -133,7 +279,7 @@ func (c *Client) SetCredentialsWithAddress(creds *auth.Credentials, address stri
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.credentials = creds
-	c.l2Signer = auth.NewL2Signer(address, creds)
+	c.l2Signer = c.newL2Signer(address, creds)
 }
 
 // SetFunderAddress 设置代理钱包地址（用于代理钱包模式）
@@ -187,8 +333,12 @@ func (c *Client) DeriveAPICredentials(ctx context.Context, nonce int64) (*auth.C
 	return creds, nil
 }
 
-// ensureCredentials 确保有 API 凭证
+// ensureCredentials 确保有 API 凭证（ctx 携带凭证时直接使用，不会衍生客户端凭证）
 func (c *Client) ensureCredentials(ctx context.Context) error {
+	if _, ok := CredentialsFromContext(ctx); ok {
+		return nil
+	}
+
 	c.mu.RLock()
 	hasCredentials := c.credentials != nil && c.l2Signer != nil
 	c.mu.RUnlock()
@@ -201,16 +351,14 @@ func (c *Client) ensureCredentials(ctx context.Context) error {
 	return err
 }
 
-// getL2AuthHeaders 获取 L2 认证头
-func (c *Client) getL2AuthHeaders(method, path, body string) (map[string]string, error) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	if c.l2Signer == nil {
+// getL2AuthHeaders 获取 L2 认证头，ctx 携带凭证时使用该凭证签名
+func (c *Client) getL2AuthHeaders(ctx context.Context, method, path, body string) (map[string]string, error) {
+	signer, _ := c.requestSigner(ctx)
+	if signer == nil {
 		return nil, fmt.Errorf("no credentials available, call CreateOrDeriveAPICredentials first")
 	}
 
-	headers, err := c.l2Signer.GetAuthHeaders(method, path, body)
+	headers, err := signer.GetAuthHeaders(method, path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,11 @@
 package clob
 
 import (
+	"context"
 	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -165,7 +169,7 @@ func TestClientGetConfig(t *testing.T) {
 func TestClientGetL2AuthHeadersWithoutCredentials(t *testing.T) {
 	client, _ := NewClient(nil, testPrivKey)
 
-	_, err := client.getL2AuthHeaders("GET", "/orders", "")
+	_, err := client.getL2AuthHeaders(context.Background(), "GET", "/orders", "")
 	if err == nil {
 		t.Error("getL2AuthHeaders() should fail without credentials")
 	}
@@ -180,7 +184,7 @@ func TestClientGetL2AuthHeadersWithCredentials(t *testing.T) {
 
 	client, _ := NewClientWithCredentials(nil, testPrivKey, creds)
 
-	headers, err := client.getL2AuthHeaders("GET", "/orders", "")
+	headers, err := client.getL2AuthHeaders(context.Background(), "GET", "/orders", "")
 	if err != nil {
 		t.Fatalf("getL2AuthHeaders() error: %v", err)
 	}
@@ -264,3 +268,84 @@ func TestConfigContractAddresses(t *testing.T) {
 		}
 	}
 }
+
+func TestClient_WarmUp(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.Endpoint = server.URL
+	config.WarmConns = 3
+	config.KeepAliveInterval = 10 * time.Millisecond
+	client, err := NewClient(config, testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.WarmUp(context.Background()); err != nil {
+		t.Fatalf("WarmUp() error: %v", err)
+	}
+	if got := atomic.LoadInt32(&hits); got < 3 {
+		t.Errorf("expected at least 3 warmup requests, got %d", got)
+	}
+
+	// keepalive keeps pinging in the background until Close
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&hits) < 6 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&hits); got < 6 {
+		t.Errorf("expected keepalive requests, got %d", got)
+	}
+}
+
+func TestClient_SeparateReadWritePolicies(t *testing.T) {
+	var reads, writes int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			atomic.AddInt32(&writes, 1)
+		} else {
+			atomic.AddInt32(&reads, 1)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.Endpoint = server.URL
+	config.MaxRetries = 2
+	config.RetryDelayMs = 1
+	creds := &auth.Credentials{
+		APIKey:     "test-api-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("test-secret")),
+		Passphrase: "test-passphrase",
+	}
+	client, err := NewClientWithCredentials(config, testPrivKey, creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reads retry with MaxRetries, writes default to a single attempt
+	client.GetOrder(context.Background(), "0x1")
+	client.CancelOrder(context.Background(), "0x1")
+	if got := atomic.LoadInt32(&reads); got != 3 {
+		t.Errorf("expected 3 read attempts, got %d", got)
+	}
+	if got := atomic.LoadInt32(&writes); got != 1 {
+		t.Errorf("expected 1 write attempt, got %d", got)
+	}
+
+	client.SetWriteRetryPolicy(1, 1)
+	client.CancelOrder(context.Background(), "0x1")
+	if got := atomic.LoadInt32(&writes); got != 3 {
+		t.Errorf("expected write retry after SetWriteRetryPolicy, got %d attempts", got)
+	}
+	if client.GetConfig().WriteMaxRetries != 1 || client.GetConfig().MaxRetries != 2 {
+		t.Errorf("unexpected config after SetWriteRetryPolicy: %+v", client.GetConfig())
+	}
+}
@@ -0,0 +1,80 @@
+package clob
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// 二元市场中两个结果的价格之和为 1，因此以价格 p 买入 YES 与以价格 1-p 卖出 NO 在订单簿上等价
+// （CLOB 将两者撮合在同一本订单簿中）。以下工具在两种表示之间转换订单意图，
+// 使针对某一结果编写的策略可以在流动性更好的一侧执行
+
+// ComplementPrice 互补结果上的等价价格 1 - p
+func ComplementPrice(price decimal.Decimal) decimal.Decimal {
+	return decimal.NewFromInt(1).Sub(price)
+}
+
+// ComplementSide 互补结果上的等价方向（买卖互换）
+func ComplementSide(side OrderSide) OrderSide {
+	return side.Opposite()
+}
+
+// RoundPriceToTick 将价格对齐到 tick
+// up 为 true 时向上取整，否则向下取整
+func RoundPriceToTick(price, tick decimal.Decimal, up bool) decimal.Decimal {
+	steps := price.Div(tick)
+	if up {
+		steps = steps.Ceil()
+	} else {
+		steps = steps.Floor()
+	}
+	return steps.Mul(tick)
+}
+
+// ComplementOrder 将某一结果上的限价单转换为互补结果（complementTokenID）上的等价订单：价格 1-p、方向相反、数量不变
+// tick 为互补 token 的最小价格单位；1-p 不在 tick 上时按不劣于原意图的方向取整：
+// 转换后为 BUY 时向下取整（不多付），为 SELL 时向上取整（不少收）
+// 注意卖出互补结果需要持有对应份额；其余字段（类型、过期时间、PostOnly 等）原样复制
+func ComplementOrder(req *CreateOrderRequest, complementTokenID string, tick decimal.Decimal) (*CreateOrderRequest, error) {
+	if req == nil {
+		return nil, newValidationError("order", common.ErrInvalidOrder, "request is nil")
+	}
+	if complementTokenID == "" {
+		return nil, newValidationError("tokenID", common.ErrInvalidOrder, "complement token ID is required")
+	}
+	if !tick.IsPositive() {
+		return nil, newValidationError("tick", common.ErrInvalidPrice, "tick size must be positive, got %s", tick)
+	}
+	if req.Side != OrderSideBuy && req.Side != OrderSideSell {
+		return nil, newValidationError("side", common.ErrInvalidOrderSide, "must be BUY or SELL, got %q", req.Side)
+	}
+
+	side := ComplementSide(req.Side)
+	price := RoundPriceToTick(ComplementPrice(req.Price), tick, side == OrderSideSell)
+	if price.LessThan(tick) || price.GreaterThan(decimal.NewFromInt(1).Sub(tick)) {
+		return nil, newValidationError("price", common.ErrInvalidPrice,
+			"complement of %s is %s, outside [%s, %s]", req.Price, price, tick, decimal.NewFromInt(1).Sub(tick))
+	}
+
+	out := *req
+	out.TokenID = complementTokenID
+	out.Side = side
+	out.Price = price
+	return &out, nil
+}
+
+// ComplementOrders 批量转换，任一订单无法转换时返回错误
+func ComplementOrders(reqs []*CreateOrderRequest, complementTokenID string, tick decimal.Decimal) ([]*CreateOrderRequest, error) {
+	out := make([]*CreateOrderRequest, 0, len(reqs))
+	for i, req := range reqs {
+		c, err := ComplementOrder(req, complementTokenID, tick)
+		if err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
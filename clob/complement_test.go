@@ -0,0 +1,74 @@
+package clob
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestComplementOrder(t *testing.T) {
+	d := decimal.RequireFromString
+	tests := []struct {
+		name      string
+		side      OrderSide
+		price     string
+		tick      string
+		wantSide  OrderSide
+		wantPrice string
+	}{
+		{"buy on tick", OrderSideBuy, "0.37", "0.01", OrderSideSell, "0.63"},
+		{"sell on tick", OrderSideSell, "0.37", "0.01", OrderSideBuy, "0.63"},
+		// 1 - 0.375 = 0.625: a resulting SELL rounds up, a resulting BUY rounds down
+		{"buy off tick", OrderSideBuy, "0.375", "0.01", OrderSideSell, "0.63"},
+		{"sell off tick", OrderSideSell, "0.375", "0.01", OrderSideBuy, "0.62"},
+		{"fine tick", OrderSideBuy, "0.972", "0.001", OrderSideSell, "0.028"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validOrderRequest()
+			req.Side = tt.side
+			req.Price = d(tt.price)
+			req.PostOnly = true
+
+			out, err := ComplementOrder(req, "67890", d(tt.tick))
+			if err != nil {
+				t.Fatalf("ComplementOrder() error: %v", err)
+			}
+			if out.TokenID != "67890" || out.Side != tt.wantSide || !out.Price.Equal(d(tt.wantPrice)) {
+				t.Errorf("got %s %s@%s, expected %s @%s", out.TokenID, out.Side, out.Price, tt.wantSide, tt.wantPrice)
+			}
+			if !out.Size.Equal(req.Size) || !out.PostOnly || out.Type != req.Type {
+				t.Errorf("other fields not copied: %+v", out)
+			}
+			if req.TokenID != "12345" {
+				t.Error("original request was modified")
+			}
+		})
+	}
+}
+
+func TestComplementOrder_Errors(t *testing.T) {
+	d := decimal.RequireFromString
+	req := validOrderRequest()
+	// BUY at 0.995 -> SELL at 0.005, rounded up to 0.01
+	req.Price = d("0.995")
+
+	if _, err := ComplementOrder(req, "67890", d("0.01")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	req.Side = OrderSideSell // complement BUY at 0.005 rounds down to 0
+	if _, err := ComplementOrder(req, "67890", d("0.01")); !errors.Is(err, common.ErrInvalidPrice) {
+		t.Errorf("expected ErrInvalidPrice, got %v", err)
+	}
+	if _, err := ComplementOrder(req, "", d("0.01")); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("expected ErrInvalidOrder, got %v", err)
+	}
+	if _, err := ComplementOrders([]*CreateOrderRequest{validOrderRequest(), req}, "67890", d("0.01")); !errors.Is(err, common.ErrInvalidPrice) {
+		t.Errorf("expected batch ErrInvalidPrice, got %v", err)
+	}
+}
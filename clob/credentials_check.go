@@ -0,0 +1,72 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// MaxClockSkew 本地时钟与服务器时间允许的最大偏差，超过时 L2 签名的时间戳可能被拒绝
+const MaxClockSkew = 30 * time.Second
+
+// CredentialsStatus 凭证校验结果
+type CredentialsStatus struct {
+	APIKey    string        // 被校验的 API Key
+	Address   string        // L2 认证使用的 POLY_ADDRESS
+	ClockSkew time.Duration // 服务器时间减本地时间
+	APIKeys   []string      // 该地址下的全部 API Key
+}
+
+// ValidateCredentials 以一次轻量的认证请求（GET /auth/api-keys）校验当前凭证是否可用，适合开盘前的健康检查
+// 不会自动创建或衍生凭证；ctx 携带凭证（WithCredentials）时校验该凭证
+// 失败时返回可用 errors.Is 判断的错误：
+//   - common.ErrCredentialsNotFound: 没有凭证
+//   - common.ErrClockSkew: 本地时钟偏差超过 MaxClockSkew（认证被拒绝时优先归因于时钟）
+//   - common.ErrCredentialsRevoked: 凭证被撤销或无效
+//   - common.ErrAddressMismatch: 认证通过，但该 API Key 不属于 POLY_ADDRESS 对应的账户
+func (c *Client) ValidateCredentials(ctx context.Context) (*CredentialsStatus, error) {
+	signer, creds := c.requestSigner(ctx)
+	if signer == nil || creds == nil {
+		return nil, fmt.Errorf("%w: call CreateOrDeriveAPICredentials or SetCredentials first", common.ErrCredentialsNotFound)
+	}
+
+	status := &CredentialsStatus{APIKey: creds.APIKey, Address: signer.GetAddress()}
+
+	skew, err := c.serverClockSkew(ctx)
+	if err != nil {
+		return status, fmt.Errorf("failed to get server time: %w", err)
+	}
+	status.ClockSkew = skew
+
+	keys, err := c.GetAPIKeys(ctx)
+	if err != nil {
+		if common.IsUnauthorized(err) || common.IsForbidden(err) {
+			if skew > MaxClockSkew || skew < -MaxClockSkew {
+				return status, fmt.Errorf("%w: local clock is off by %v: %v", common.ErrClockSkew, skew.Round(time.Second), err)
+			}
+			return status, fmt.Errorf("%w: api key %s: %v", common.ErrCredentialsRevoked, creds.APIKey, err)
+		}
+		return status, err
+	}
+	status.APIKeys = keys
+
+	for _, key := range keys {
+		if key == creds.APIKey {
+			return status, nil
+		}
+	}
+	return status, fmt.Errorf("%w: api key %s not listed for %s", common.ErrAddressMismatch, creds.APIKey, status.Address)
+}
+
+// serverClockSkew 通过 GET /time 计算服务器时间与本地时间的偏差（按请求往返中点估算）
+func (c *Client) serverClockSkew(ctx context.Context) (time.Duration, error) {
+	var serverSec int64
+	start := time.Now()
+	if err := c.httpClient.Get(ctx, "/time", nil, &serverSec); err != nil {
+		return 0, err
+	}
+	local := start.Add(time.Since(start) / 2)
+	return time.Unix(serverSec, 0).Sub(local).Truncate(time.Second), nil
+}
@@ -0,0 +1,91 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// credentialsServer serves /time with the given offset and /auth/api-keys with the given status and keys
+func credentialsServer(offset time.Duration, status int, keys string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/time":
+			w.Write([]byte(strconv.FormatInt(time.Now().Add(offset).Unix(), 10)))
+		case "/auth/api-keys":
+			if r.Header.Get("POLY_API_KEY") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			if status == http.StatusOK {
+				w.Write([]byte(`{"apiKeys":` + keys + `}`))
+			} else {
+				w.Write([]byte(`{"error":"Unauthorized/Invalid api key"}`))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		offset  time.Duration
+		status  int
+		keys    string
+		wantErr error
+	}{
+		{"valid", 0, http.StatusOK, `["other-key","test-api-key"]`, nil},
+		{"revoked", 0, http.StatusUnauthorized, "", common.ErrCredentialsRevoked},
+		{"clock skew", -2 * time.Minute, http.StatusUnauthorized, "", common.ErrClockSkew},
+		{"wrong address", 0, http.StatusOK, `["other-key"]`, common.ErrAddressMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := setupTestClient(t, credentialsServer(tt.offset, tt.status, tt.keys))
+			defer server.Close()
+
+			status, err := client.ValidateCredentials(context.Background())
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("ValidateCredentials() error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateCredentials() error = %v, expected %v", err, tt.wantErr)
+			}
+			if status == nil || status.APIKey != "test-api-key" || status.Address != client.GetAddress() {
+				t.Errorf("status = %+v", status)
+			}
+			if tt.offset != 0 && (status.ClockSkew > -time.Minute || status.ClockSkew < -3*time.Minute) {
+				t.Errorf("ClockSkew = %v, expected about %v", status.ClockSkew, tt.offset)
+			}
+		})
+	}
+}
+
+func TestValidateCredentials_NoCredentials(t *testing.T) {
+	client, err := NewClient(nil, ordersTestPrivKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ValidateCredentials(context.Background()); !errors.Is(err, common.ErrCredentialsNotFound) {
+		t.Errorf("expected ErrCredentialsNotFound, got %v", err)
+	}
+
+	// Scoped credentials are validated instead of the client's
+	client, server := setupTestClient(t, credentialsServer(0, http.StatusOK, `["scoped-key"]`))
+	defer server.Close()
+	ctx := WithCredentials(context.Background(), &auth.Credentials{APIKey: "scoped-key", Secret: "c2VjcmV0", Passphrase: "p"})
+	if status, err := client.ValidateCredentials(ctx); err != nil || status.APIKey != "scoped-key" {
+		t.Errorf("ValidateCredentials(scoped) = %+v, %v", status, err)
+	}
+}
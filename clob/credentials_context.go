@@ -0,0 +1,75 @@
+package clob
+
+import (
+	"context"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+)
+
+// credentialsContextKey ctx 中作用域凭证的 key
+type credentialsContextKey struct{}
+
+// scopedCredentials ctx 携带的凭证
+type scopedCredentials struct {
+	creds   *auth.Credentials
+	address string // L2 认证的 POLY_ADDRESS，为空时使用客户端签名钱包地址
+}
+
+// WithCredentials 返回携带指定 API 凭证的 ctx，使用该 ctx 的请求以这组凭证认证
+// 同一个 Client（及其 HTTP 连接池）即可为多个子账户路由请求；订单仍由 Client 的私钥与 funder 签名
+func WithCredentials(ctx context.Context, creds *auth.Credentials) context.Context {
+	return WithCredentialsAndAddress(ctx, creds, "")
+}
+
+// WithCredentialsAndAddress 同 WithCredentials，并指定 L2 认证使用的账户地址
+func WithCredentialsAndAddress(ctx context.Context, creds *auth.Credentials, address string) context.Context {
+	if creds == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, credentialsContextKey{}, &scopedCredentials{creds: creds, address: address})
+}
+
+// CredentialsFromContext 获取 ctx 携带的凭证
+func CredentialsFromContext(ctx context.Context) (*auth.Credentials, bool) {
+	scoped, ok := ctx.Value(credentialsContextKey{}).(*scopedCredentials)
+	if !ok {
+		return nil, false
+	}
+	return scoped.creds, true
+}
+
+// requestSigner 选择本次请求的 L2 签名器与凭证：优先使用 ctx 携带的凭证，否则使用客户端凭证
+func (c *Client) requestSigner(ctx context.Context) (*auth.L2Signer, *auth.Credentials) {
+	if scoped, ok := ctx.Value(credentialsContextKey{}).(*scopedCredentials); ok {
+		address := scoped.address
+		if address == "" {
+			address = c.l1Signer.GetAddress()
+		}
+		// ctx 凭证的签名器按请求创建，只沿用时钟，不复用认证头
+		signer := auth.NewL2Signer(address, scoped.creds)
+		c.mu.RLock()
+		signer.SetClock(c.l2Clock)
+		c.mu.RUnlock()
+		return signer, scoped.creds
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.l2Signer, c.credentials
+}
+
+// ownerAPIKey 下单请求中的 Owner（当前请求凭证的 API Key）
+func (c *Client) ownerAPIKey(ctx context.Context) string {
+	_, creds := c.requestSigner(ctx)
+	if creds == nil {
+		return ""
+	}
+	return creds.APIKey
+}
+
+// ClientID 当前请求凭证（ctx 携带的凭证优先）的稳定标识，由 API Key 哈希派生，未设置凭证时为空
+// 事件日志中的每条记录都带有该标识，多凭证部署可据此审计订单来源而无需记录原始 API Key
+func (c *Client) ClientID(ctx context.Context) string {
+	_, creds := c.requestSigner(ctx)
+	return creds.ClientID()
+}
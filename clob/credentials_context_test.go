@@ -0,0 +1,138 @@
+package clob
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+)
+
+func TestWithCredentials_RoutesPerRequest(t *testing.T) {
+	var mu sync.Mutex
+	var seenKeys, seenOwners, seenAddresses []string
+
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seenKeys = append(seenKeys, r.Header.Get("POLY_API_KEY"))
+		seenAddresses = append(seenAddresses, r.Header.Get("POLY_ADDRESS"))
+		if r.URL.Path == "/order" {
+			var body PostOrderRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			seenOwners = append(seenOwners, body.Owner)
+		}
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"orderID":"0x1"}`))
+	})
+	defer server.Close()
+
+	sub := &auth.Credentials{
+		APIKey:     "sub-api-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("sub-secret")),
+		Passphrase: "sub-passphrase",
+	}
+	subCtx := WithCredentials(context.Background(), sub)
+
+	if got, ok := CredentialsFromContext(subCtx); !ok || got != sub {
+		t.Fatal("CredentialsFromContext should return the scoped credentials")
+	}
+	if _, ok := CredentialsFromContext(context.Background()); ok {
+		t.Fatal("plain context should carry no credentials")
+	}
+
+	if err := client.CancelAllOrders(subCtx); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CancelAllOrders(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateOrder(subCtx, validOrderRequest()); err != nil {
+		t.Fatal(err)
+	}
+	addrCtx := WithCredentialsAndAddress(context.Background(), sub, "0x00000000000000000000000000000000000000aa")
+	if err := client.CancelAllOrders(addrCtx); err != nil {
+		t.Fatal(err)
+	}
+
+	wantKeys := []string{"sub-api-key", "test-api-key", "sub-api-key", "sub-api-key"}
+	for i, want := range wantKeys {
+		if seenKeys[i] != want {
+			t.Errorf("request %d POLY_API_KEY = %s, expected %s", i, seenKeys[i], want)
+		}
+	}
+	if len(seenOwners) != 1 || seenOwners[0] != "sub-api-key" {
+		t.Errorf("order owners = %v, expected [sub-api-key]", seenOwners)
+	}
+	if seenAddresses[0] != client.GetAddress() || seenAddresses[3] != "0x00000000000000000000000000000000000000aa" {
+		t.Errorf("POLY_ADDRESS = %v", seenAddresses)
+	}
+
+	// The client's own credentials are untouched
+	if client.GetCredentials().APIKey != "test-api-key" {
+		t.Error("scoped credentials must not replace client credentials")
+	}
+}
+
+func TestWithCredentials_SkipsDerivation(t *testing.T) {
+	client, err := NewClient(nil, testPrivKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub := &auth.Credentials{APIKey: "k", Secret: base64.StdEncoding.EncodeToString([]byte("s")), Passphrase: "p"}
+	if err := client.ensureCredentials(WithCredentials(context.Background(), sub)); err != nil {
+		t.Errorf("ensureCredentials() with scoped credentials error: %v", err)
+	}
+	if client.GetCredentials() != nil {
+		t.Error("client credentials should not be derived")
+	}
+
+	headers, err := client.getL2AuthHeaders(WithCredentials(context.Background(), sub), "GET", "/orders", "")
+	if err != nil || headers["POLY_API_KEY"] != "k" {
+		t.Errorf("getL2AuthHeaders() = %v, %v", headers, err)
+	}
+}
+
+func TestClient_ClientIDInEvents(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"orderID":"0x1","canceled":["0x1"]}`))
+	})
+	defer server.Close()
+
+	sub := &auth.Credentials{APIKey: "sub-api-key", Secret: base64.StdEncoding.EncodeToString([]byte("s")), Passphrase: "p"}
+	subCtx := WithCredentials(context.Background(), sub)
+
+	mainID, subID := client.ClientID(context.Background()), client.ClientID(subCtx)
+	if mainID != auth.ClientID("test-api-key") || subID != sub.ClientID() || mainID == subID {
+		t.Fatalf("ClientID main=%q sub=%q", mainID, subID)
+	}
+
+	if _, err := client.CreateOrder(context.Background(), validOrderRequest()); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CancelOrder(subCtx, "0x1"); err != nil {
+		t.Fatal(err)
+	}
+	client.RecordFill(&Trade{TakerOrderID: "0x1", Owner: "sub-api-key"})
+
+	events := client.RecentEvents()
+	want := []string{mainID, mainID, subID, subID}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(events))
+	}
+	for i, event := range events {
+		if event.ClientID != want[i] {
+			t.Errorf("event %d (%s) ClientID = %q, expected %q", i, event.Type, event.ClientID, want[i])
+		}
+		if strings.Contains(event.ClientID, "api-key") {
+			t.Errorf("event %d leaks the raw API key", i)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package clob
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+)
+
+// DefaultEventLogSize 默认事件日志容量
+const DefaultEventLogSize = 1000
+
+// TradingEventType 交易事件类型
+type TradingEventType string
+
+const (
+	// TradingEventOrderSubmit 订单提交
+	TradingEventOrderSubmit TradingEventType = "order_submit"
+	// TradingEventOrderResponse 订单提交响应
+	TradingEventOrderResponse TradingEventType = "order_response"
+	// TradingEventCancel 撤单请求及结果
+	TradingEventCancel TradingEventType = "cancel"
+	// TradingEventFill 成交
+	TradingEventFill TradingEventType = "fill"
+)
+
+// TradingEvent 交易事件记录
+type TradingEvent struct {
+	Time      time.Time        `json:"time"`
+	Type      TradingEventType `json:"type"`
+	OrderID   string           `json:"order_id,omitempty"`
+	TokenID   string           `json:"token_id,omitempty"`
+	Market    string           `json:"market,omitempty"` // 按市场撤单或成交所在市场
+	Side      OrderSide        `json:"side,omitempty"`
+	Price     decimal.Decimal  `json:"price"`
+	Size      decimal.Decimal  `json:"size"`
+	OrderType OrderType        `json:"order_type,omitempty"`
+	Status    string           `json:"status,omitempty"`    // 响应状态（如 LIVE/MATCHED）或成交状态
+	Canceled  []string         `json:"canceled,omitempty"`  // 撤单成功的订单
+	Error     string           `json:"error,omitempty"`     // 请求错误或交易所返回的错误信息
+	ClientID  string           `json:"client_id,omitempty"` // 发出请求的凭证标识（auth.ClientID），成交记录取自 Trade.Owner
+}
+
+// EventLog 固定容量的交易事件环形缓冲区（并发安全）
+// 写满后覆盖最旧的记录，用于事后排查
+type EventLog struct {
+	mu     sync.Mutex
+	events []TradingEvent
+	next   int
+	full   bool
+}
+
+// NewEventLog 创建事件日志，capacity <= 0 时使用默认容量
+func NewEventLog(capacity int) *EventLog {
+	if capacity <= 0 {
+		capacity = DefaultEventLogSize
+	}
+	return &EventLog{events: make([]TradingEvent, capacity)}
+}
+
+// Add 追加事件，未设置时间时使用当前时间
+func (l *EventLog) Add(event TradingEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events[l.next] = event
+	l.next = (l.next + 1) % len(l.events)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Recent 获取最近 n 条事件（按时间从旧到新），n <= 0 时返回全部
+func (l *EventLog) Recent(n int) []TradingEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	size := l.len()
+	if n <= 0 || n > size {
+		n = size
+	}
+
+	result := make([]TradingEvent, n)
+	start := (l.next - n + len(l.events)) % len(l.events)
+	for i := 0; i < n; i++ {
+		result[i] = l.events[(start+i)%len(l.events)]
+	}
+	return result
+}
+
+// Len 获取当前事件数量
+func (l *EventLog) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.len()
+}
+
+// Cap 获取容量
+func (l *EventLog) Cap() int {
+	return len(l.events)
+}
+
+// tokenForOrder 从最近的事件中查找订单所属 token，查不到时返回空字符串
+func (l *EventLog) tokenForOrder(orderID string) string {
+	if orderID == "" {
+		return ""
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	size := l.len()
+	for i := 1; i <= size; i++ {
+		event := l.events[(l.next-i+len(l.events))%len(l.events)]
+		if event.OrderID == orderID && event.TokenID != "" {
+			return event.TokenID
+		}
+	}
+	return ""
+}
+
+func (l *EventLog) len() int {
+	if l.full {
+		return len(l.events)
+	}
+	return l.next
+}
+
+// RecentEvents 获取事件日志中的全部交易事件（按时间从旧到新）
+func (c *Client) RecentEvents() []TradingEvent {
+	return c.eventLog.Recent(0)
+}
+
+// RecordEvent 记录外部来源的交易事件（如用户频道推送的成交）
+// 通过 NewOrderTracker(client, ...) 跟踪的订单，其成交由跟踪器自动记录，无需再调用
+func (c *Client) RecordEvent(event TradingEvent) {
+	c.record(context.Background(), event)
+}
+
+// record 写入事件日志，启用审计日志时同时写入审计文件
+func (c *Client) record(ctx context.Context, event TradingEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	c.eventLog.Add(event)
+	if c.audit != nil {
+		_, creds := c.requestSigner(ctx)
+		c.audit.write(event, creds)
+	}
+}
+
+// RecordFill 记录成交
+// Taker 成交记录 TakerOrderID 与 Trade.Side；Maker 成交（TraderSide 为 MAKER）记录 MakerOrders 中属于本账户
+// （Owner 与 Trade.Owner 相同，或 MakerAddress 为 funder 地址）的每个订单及其方向、价格与成交数量
+func (c *Client) RecordFill(trade *Trade) {
+	if trade == nil {
+		return
+	}
+	clientID := auth.ClientID(trade.Owner)
+	if !strings.EqualFold(trade.TraderSide, "MAKER") {
+		c.record(context.Background(), TradingEvent{
+			Type:     TradingEventFill,
+			OrderID:  trade.TakerOrderID,
+			TokenID:  trade.AssetID,
+			Market:   trade.Market,
+			Side:     trade.Side,
+			Price:    trade.Price,
+			Size:     trade.Size,
+			Status:   trade.Status,
+			ClientID: clientID,
+		})
+		return
+	}
+
+	funder := c.GetFunderAddress()
+	for _, maker := range trade.MakerOrders {
+		own := (trade.Owner != "" && maker.Owner == trade.Owner) ||
+			(funder != "" && strings.EqualFold(maker.MakerAddress, funder))
+		if !own {
+			continue
+		}
+		tokenID := maker.AssetID
+		if tokenID == "" {
+			tokenID = trade.AssetID
+		}
+		price, _ := decimal.NewFromString(maker.Price)
+		size, _ := decimal.NewFromString(maker.MatchedAmount)
+		c.record(context.Background(), TradingEvent{
+			Type:     TradingEventFill,
+			OrderID:  maker.OrderID,
+			TokenID:  tokenID,
+			Market:   trade.Market,
+			Side:     OrderSide(strings.ToUpper(maker.Side)),
+			Price:    price,
+			Size:     size,
+			Status:   trade.Status,
+			ClientID: clientID,
+		})
+	}
+}
+
+// recordSubmit 记录订单提交
+func (c *Client) recordSubmit(ctx context.Context, req *CreateOrderRequest, orderType OrderType) {
+	event := orderEvent(TradingEventOrderSubmit, req, orderType)
+	event.ClientID = c.ClientID(ctx)
+	c.record(ctx, event)
+}
+
+// recordResponse 记录订单提交结果
+func (c *Client) recordResponse(ctx context.Context, req *CreateOrderRequest, orderType OrderType, resp *OrderResponse, err error) {
+	event := orderEvent(TradingEventOrderResponse, req, orderType)
+	event.ClientID = c.ClientID(ctx)
+	if err != nil {
+		event.Error = err.Error()
+	}
+	if resp != nil {
+		event.OrderID = resp.OrderID
+		event.Status = resp.Status
+		if resp.ErrorMsg != "" {
+			event.Error = resp.ErrorMsg
+		}
+	}
+	c.record(ctx, event)
+}
+
+// recordBatchResponses 记录批量提交结果，请求失败时每个订单记录同一错误
+func (c *Client) recordBatchResponses(ctx context.Context, reqs []*CreateOrderRequest, postReqs []*PostOrderRequest, results []*OrderResponse, err error) {
+	for i, req := range reqs {
+		var resp *OrderResponse
+		if err == nil && i < len(results) {
+			resp = results[i]
+		}
+		c.recordResponse(ctx, req, postReqs[i].OrderType, resp, err)
+	}
+}
+
+// recordCancel 记录撤单
+func (c *Client) recordCancel(ctx context.Context, orderIDs []string, market, tokenID string, resp *CancelResponse, err error) {
+	event := TradingEvent{
+		Type:     TradingEventCancel,
+		OrderID:  strings.Join(orderIDs, ","),
+		TokenID:  tokenID,
+		Market:   market,
+		ClientID: c.ClientID(ctx),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	if resp != nil {
+		event.Canceled = resp.Canceled
+		if len(resp.NotCanceled) > 0 && event.Error == "" {
+			event.Error = "not canceled: " + strings.Join(resp.NotCanceled, ",")
+		}
+	}
+	c.record(ctx, event)
+}
+
+// orderEvent 根据下单请求构造事件
+func orderEvent(typ TradingEventType, req *CreateOrderRequest, orderType OrderType) TradingEvent {
+	event := TradingEvent{Type: typ, OrderType: orderType}
+	if req != nil {
+		event.TokenID = req.TokenID
+		event.Side = req.Side
+		event.Price = req.Price
+		event.Size = req.Size
+	}
+	return event
+}
@@ -0,0 +1,141 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestEventLog_Wraparound(t *testing.T) {
+	log := NewEventLog(3)
+
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		log.Add(TradingEvent{Type: TradingEventFill, OrderID: id})
+	}
+
+	if log.Len() != 3 {
+		t.Fatalf("Expected 3 events, got %d", log.Len())
+	}
+
+	all := log.Recent(0)
+	want := []string{"c", "d", "e"}
+	for i, e := range all {
+		if e.OrderID != want[i] {
+			t.Errorf("Event %d: expected %s, got %s", i, want[i], e.OrderID)
+		}
+		if e.Time.IsZero() {
+			t.Errorf("Event %d: expected timestamp to be set", i)
+		}
+	}
+
+	last := log.Recent(2)
+	if len(last) != 2 || last[0].OrderID != "d" || last[1].OrderID != "e" {
+		t.Errorf("Unexpected Recent(2): %+v", last)
+	}
+}
+
+func TestEventLog_DefaultCapacity(t *testing.T) {
+	log := NewEventLog(0)
+	if log.Cap() != DefaultEventLogSize {
+		t.Errorf("Expected capacity %d, got %d", DefaultEventLogSize, log.Cap())
+	}
+	if len(log.Recent(10)) != 0 {
+		t.Error("Expected empty log")
+	}
+}
+
+func TestClient_RecentEvents_OrderAndCancel(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/order":
+			json.NewEncoder(w).Encode(OrderResponse{Success: true, OrderID: "order-1", Status: "LIVE"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/orders":
+			json.NewEncoder(w).Encode(CancelResponse{Canceled: []string{"order-1"}, NotCanceled: []string{"order-2"}})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+	if _, err := client.CreateOrder(ctx, validOrderRequest()); err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if _, err := client.CancelOrders(ctx, []string{"order-1", "order-2"}); err != nil {
+		t.Fatalf("CancelOrders failed: %v", err)
+	}
+	client.RecordFill(&Trade{AssetID: "token", Side: OrderSideBuy, Price: decimal.NewFromFloat(0.5), Size: decimal.NewFromInt(10)})
+
+	events := client.RecentEvents()
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(events))
+	}
+
+	if events[0].Type != TradingEventOrderSubmit {
+		t.Errorf("Expected submit event first, got %s", events[0].Type)
+	}
+	if events[1].Type != TradingEventOrderResponse || events[1].OrderID != "order-1" || events[1].Status != "LIVE" {
+		t.Errorf("Unexpected response event: %+v", events[1])
+	}
+	if events[2].Type != TradingEventCancel || len(events[2].Canceled) != 1 || events[2].Error == "" {
+		t.Errorf("Unexpected cancel event: %+v", events[2])
+	}
+	if events[3].Type != TradingEventFill || events[3].TokenID != "token" {
+		t.Errorf("Unexpected fill event: %+v", events[3])
+	}
+}
+
+func TestClient_RecentEvents_RecordsErrors(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid order"}`))
+	})
+	defer server.Close()
+
+	if _, err := client.CreateOrder(context.Background(), validOrderRequest()); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	events := client.RecentEvents()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[1].Type != TradingEventOrderResponse || events[1].Error == "" {
+		t.Errorf("Expected failed response to be recorded, got %+v", events[1])
+	}
+}
+
+func TestClient_RecordFill_Maker(t *testing.T) {
+	client, err := NewClient(nil, testPrivKey)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+
+	// We were the maker: the taker order ID and side belong to the counterparty
+	client.RecordFill(&Trade{
+		TakerOrderID: "taker-order",
+		AssetID:      "token",
+		Side:         OrderSideBuy,
+		Price:        decimal.NewFromFloat(0.5),
+		Size:         decimal.NewFromInt(30),
+		Owner:        "our-key",
+		TraderSide:   "MAKER",
+		MakerOrders: []MakerOrder{
+			{OrderID: "other-maker", Owner: "other-key", MatchedAmount: "20", Price: "0.5", Side: "SELL", AssetID: "token"},
+			{OrderID: "our-order", Owner: "our-key", MatchedAmount: "10", Price: "0.5", Side: "SELL", AssetID: "token"},
+		},
+	})
+
+	events := client.RecentEvents()
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 fill event, got %+v", events)
+	}
+	e := events[0]
+	if e.OrderID != "our-order" || e.Side != OrderSideSell || !e.Size.Equal(decimal.NewFromInt(10)) || !e.Price.Equal(decimal.NewFromFloat(0.5)) {
+		t.Errorf("Unexpected maker fill event: %+v", e)
+	}
+}
+
@@ -0,0 +1,55 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// newL2Signer 按配置创建 L2 签名器（时钟来源与认证头复用），调用方需持有写锁或处于构造阶段
+func (c *Client) newL2Signer(address string, creds *auth.Credentials) *auth.L2Signer {
+	signer := auth.NewL2Signer(address, creds)
+	signer.SetClock(c.l2Clock)
+	signer.SetHeaderCache(c.config.L2HeaderCache)
+	return signer
+}
+
+// SetL2Clock 设置 L2 签名时间戳的时钟来源，nil 恢复为本地时钟，对当前与之后设置的凭证生效
+func (c *Client) SetL2Clock(now func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.l2Clock = now
+	if c.l2Signer != nil {
+		c.l2Signer.SetClock(now)
+	}
+}
+
+// SyncL2Clock 通过 GET /time 测量服务器时间偏差，并将 L2 签名时钟校正为 本地时间 + 偏差
+// 适合本地时钟无法校准（如容器内）的部署，返回测得的偏差（服务器时间减本地时间）
+func (c *Client) SyncL2Clock(ctx context.Context) (time.Duration, error) {
+	skew, err := c.serverClockSkew(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get server time: %w", err)
+	}
+	c.SetL2Clock(func() time.Time { return time.Now().Add(skew) })
+	return skew, nil
+}
+
+// PrecomputeL2Headers 预先签名并缓存客户端凭证下指定请求的 L2 认证头，需配置 Config.L2HeaderCache
+// method、path 与 body 需与实际请求签名的内容一致（path 不含查询参数，如 GET /orders、DELETE /order/<id>）；
+// 之后复用窗口内的同一请求直接使用缓存，临近过期时在后台重新签名
+func (c *Client) PrecomputeL2Headers(method, path, body string) error {
+	if c.config.L2HeaderCache == nil {
+		return fmt.Errorf("%w: L2HeaderCache is not configured", common.ErrInvalidConfig)
+	}
+	c.mu.RLock()
+	signer := c.l2Signer
+	c.mu.RUnlock()
+	if signer == nil {
+		return fmt.Errorf("%w: call CreateOrDeriveAPICredentials or SetCredentials first", common.ErrCredentialsNotFound)
+	}
+	return signer.Precompute(method, path, body)
+}
@@ -0,0 +1,87 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestClient_SyncL2Clock(t *testing.T) {
+	offset := 2 * time.Minute
+	var mu sync.Mutex
+	var timestamps []string
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/time":
+			w.Write([]byte(strconv.FormatInt(time.Now().Add(offset).Unix(), 10)))
+		case "/orders":
+			mu.Lock()
+			timestamps = append(timestamps, r.Header.Get("POLY_TIMESTAMP"))
+			mu.Unlock()
+			w.Write([]byte(`[]`))
+		}
+	})
+	defer server.Close()
+
+	skew, err := client.SyncL2Clock(context.Background())
+	if err != nil {
+		t.Fatalf("SyncL2Clock() error: %v", err)
+	}
+	if skew < offset-2*time.Second || skew > offset+2*time.Second {
+		t.Errorf("skew = %v, expected about %v", skew, offset)
+	}
+
+	if _, err := client.GetOpenOrders(context.Background()); err != nil {
+		t.Fatalf("GetOpenOrders() error: %v", err)
+	}
+	// scoped credentials use the synced clock too
+	ctx := WithCredentials(context.Background(), client.GetCredentials())
+	if _, err := client.GetOpenOrders(ctx); err != nil {
+		t.Fatalf("GetOpenOrders() with scoped credentials error: %v", err)
+	}
+
+	want := time.Now().Add(offset - auth.TimestampSkew).Unix()
+	for _, ts := range timestamps {
+		sec, _ := strconv.ParseInt(ts, 10, 64)
+		if sec < want-3 || sec > want+1 {
+			t.Errorf("POLY_TIMESTAMP = %d, expected about %d (server time minus skew)", sec, want)
+		}
+	}
+}
+
+func TestClient_PrecomputeL2Headers(t *testing.T) {
+	var mu sync.Mutex
+	var signatures []string
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		signatures = append(signatures, r.Header.Get("POLY_TIMESTAMP")+"/"+r.Header.Get("POLY_SIGNATURE"))
+		mu.Unlock()
+		w.Write([]byte(`[]`))
+	})
+	defer server.Close()
+
+	if err := client.PrecomputeL2Headers("GET", "/orders", ""); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig without L2HeaderCache, got %v", err)
+	}
+
+	client.config.L2HeaderCache = &auth.L2HeaderCacheConfig{TTL: time.Minute}
+	client.SetCredentials(client.GetCredentials())
+	if err := client.PrecomputeL2Headers("GET", "/orders", ""); err != nil {
+		t.Fatalf("PrecomputeL2Headers() error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetOpenOrders(context.Background()); err != nil {
+			t.Fatalf("GetOpenOrders() error: %v", err)
+		}
+	}
+	if len(signatures) != 3 || signatures[0] != signatures[1] || signatures[1] != signatures[2] {
+		t.Errorf("expected precomputed headers to be reused, got %v", signatures)
+	}
+}
@@ -0,0 +1,278 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// BookLevel 订单簿价位
+type BookLevel struct {
+	Price decimal.Decimal
+	Size  decimal.Decimal
+}
+
+// BookLevelsFunc 返回 side 方向订单的对手盘档位，按成交优先顺序（最优价在前）：
+// BUY 返回卖单，SELL 返回买单；ok 为 false 表示订单簿未就绪（如未订阅或未初始化）
+type BookLevelsFunc func(tokenID string, side OrderSide) (levels []BookLevel, ok bool)
+
+// SetBookSource 设置本地订单簿，供 CreateMarketOrderFromBook 计算价格以及下单前检查 FOK 订单能否完全成交，nil 表示关闭
+// 通过 polymarket.NewSDK 创建时自动接入 SDK.OrderBook
+func (c *Client) SetBookSource(levels BookLevelsFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bookLevels = levels
+}
+
+// bookSource 当前的本地订单簿
+func (c *Client) bookSource() BookLevelsFunc {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bookLevels
+}
+
+// checkFOKAvailability 按本地订单簿检查 FOK 限价单能否完全成交，未设置订单簿或订单簿未就绪时不检查
+func (c *Client) checkFOKAvailability(req *CreateOrderRequest) error {
+	if req == nil || req.Type != OrderTypeFOK {
+		return nil
+	}
+	size, _, ok := c.bookAvailable(req.TokenID, req.Side, req.Price)
+	if !ok {
+		return nil
+	}
+	return ValidateFOKAvailability(req, size)
+}
+
+// checkMarketFOKAvailability 按本地订单簿检查 FOK 市价单能否完全成交，未设置订单簿或订单簿未就绪时不检查
+func (c *Client) checkMarketFOKAvailability(req *CreateMarketOrderRequest) error {
+	if req.orderType() != OrderTypeFOK {
+		return nil
+	}
+	size, cost, ok := c.bookAvailable(req.TokenID, req.Side, req.Price)
+	if !ok {
+		return nil
+	}
+	if req.Side == OrderSideBuy {
+		return ValidateMarketFOKAvailability(req, cost)
+	}
+	return ValidateMarketFOKAvailability(req, size)
+}
+
+// bookAvailable 本地订单簿中价格不差于 limit 的对手盘份额与金额合计
+func (c *Client) bookAvailable(tokenID string, side OrderSide, limit decimal.Decimal) (size, cost decimal.Decimal, ok bool) {
+	source := c.bookSource()
+	if source == nil {
+		return decimal.Zero, decimal.Zero, false
+	}
+	levels, ok := source(tokenID, side)
+	if !ok {
+		return decimal.Zero, decimal.Zero, false
+	}
+
+	size, cost = decimal.Zero, decimal.Zero
+	for _, level := range levels {
+		if (side == OrderSideBuy && level.Price.GreaterThan(limit)) || (side == OrderSideSell && level.Price.LessThan(limit)) {
+			break
+		}
+		size = size.Add(level.Size)
+		cost = cost.Add(level.Price.Mul(level.Size))
+	}
+	return size, cost, true
+}
+
+// BookMarketOrderRequest 按本地订单簿定价的市价单请求
+// Amount 约定同 CreateMarketOrderRequest：BUY 为花费的 USDC 金额，SELL 为卖出的份额数量
+type BookMarketOrderRequest struct {
+	TokenID string
+	Side    OrderSide
+	Amount  decimal.Decimal
+	Type    OrderType // FOK（默认）或 FAK
+	// Slippage 在吃完 Amount 所需的边际价格上额外放宽的价格（BUY 加、SELL 减），容忍提交前的行情变化
+	Slippage decimal.Decimal
+	// WorstPrice 价格保护：只计入不差于该价格的档位（BUY 不高于、SELL 不低于），提交价格也不超过它；零值表示不限制
+	WorstPrice decimal.Decimal
+	// TickSize 市场价格最小变动单位，提交价格按它取整且不放宽滑点（BUY 向下、SELL 向上）；零值按 0.01
+	TickSize   decimal.Decimal
+	FeeRateBps int
+	IsNegRisk  bool
+}
+
+// BookMarketOrderResult 按订单簿定价的市价单结果
+type BookMarketOrderResult struct {
+	Response *OrderResponse
+	Price    decimal.Decimal // 提交的最差可接受价格
+
+	// 按提交前的本地订单簿估算
+	Levels           int             // 预计吃入的价位数
+	ExpectedSize     decimal.Decimal // 预计成交份额
+	ExpectedCost     decimal.Decimal // 预计成交金额（USDC）
+	ExpectedAvgPrice decimal.Decimal
+	BookCovered      bool // 本地订单簿在价格保护内能否吃完 Amount（FAK 为 false 时预计部分成交）
+
+	// 按交易所响应的实际成交，未成交时为零
+	FilledSize     decimal.Decimal
+	FilledCost     decimal.Decimal
+	FilledAvgPrice decimal.Decimal
+}
+
+// bookMarketPlan 按订单簿计算出的价格与预计成交
+type bookMarketPlan struct {
+	price      decimal.Decimal
+	levels     int
+	size       decimal.Decimal
+	cost       decimal.Decimal
+	avgPrice   decimal.Decimal
+	fullyFills bool
+}
+
+// CreateMarketOrderFromBook 按本地订单簿计算可成交的限价并提交 FOK/FAK 市价单
+// 价格为吃完 Amount 需要触及的最差价位加 Slippage；FOK 订单在本地订单簿深度不足时返回
+// ErrInsufficientLiquidity 而不提交，FAK 订单以可吃到的全部档位定价并接受部分成交
+func (c *Client) CreateMarketOrderFromBook(ctx context.Context, req *BookMarketOrderRequest) (*BookMarketOrderResult, error) {
+	if req == nil {
+		return nil, newValidationError("order", common.ErrInvalidOrder, "request is nil")
+	}
+	marketReq := &CreateMarketOrderRequest{
+		TokenID:    req.TokenID,
+		Side:       req.Side,
+		Amount:     req.Amount,
+		Price:      decimal.NewFromFloat(0.5), // 占位，仅用于提前校验其他字段
+		Type:       req.Type,
+		FeeRateBps: req.FeeRateBps,
+		IsNegRisk:  req.IsNegRisk,
+	}
+	if err := ValidateMarketOrderRequest(marketReq); err != nil {
+		return nil, err
+	}
+
+	source := c.bookSource()
+	if source == nil {
+		return nil, fmt.Errorf("%w: book source not set, see SetBookSource", common.ErrNotInitialized)
+	}
+	levels, ok := source(req.TokenID, req.Side)
+	if !ok {
+		return nil, fmt.Errorf("%w: local book for token %s is not ready", common.ErrNotInitialized, req.TokenID)
+	}
+
+	plan, err := planBookMarketOrder(req, marketReq.orderType(), levels)
+	if err != nil {
+		return nil, err
+	}
+
+	marketReq.Price = plan.price
+	resp, err := c.CreateMarketOrder(ctx, marketReq)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BookMarketOrderResult{
+		Response:         resp,
+		Price:            plan.price,
+		Levels:           plan.levels,
+		ExpectedSize:     plan.size,
+		ExpectedCost:     plan.cost,
+		ExpectedAvgPrice: plan.avgPrice,
+		BookCovered:      plan.fullyFills,
+	}
+	// BUY 付出 USDC 获得份额，SELL 付出份额获得 USDC
+	if req.Side == OrderSideBuy {
+		result.FilledCost, result.FilledSize = resp.MakingAmount, resp.TakingAmount
+	} else {
+		result.FilledSize, result.FilledCost = resp.MakingAmount, resp.TakingAmount
+	}
+	if result.FilledSize.IsPositive() {
+		result.FilledAvgPrice = result.FilledCost.Div(result.FilledSize)
+	}
+	return result, nil
+}
+
+// planBookMarketOrder 按对手盘档位计算提交价格与预计成交
+func planBookMarketOrder(req *BookMarketOrderRequest, orderType OrderType, levels []BookLevel) (*bookMarketPlan, error) {
+	buy := req.Side == OrderSideBuy
+	remaining := req.Amount
+	plan := &bookMarketPlan{size: decimal.Zero, cost: decimal.Zero}
+	var marginal decimal.Decimal
+
+	for _, level := range levels {
+		if !remaining.IsPositive() {
+			break
+		}
+		if !level.Price.IsPositive() || !level.Size.IsPositive() {
+			continue
+		}
+		if req.WorstPrice.IsPositive() {
+			if (buy && level.Price.GreaterThan(req.WorstPrice)) || (!buy && level.Price.LessThan(req.WorstPrice)) {
+				break
+			}
+		}
+
+		// BUY 按金额、SELL 按份额消耗 remaining
+		size, cost := level.Size, level.Price.Mul(level.Size)
+		if buy {
+			if cost.GreaterThan(remaining) {
+				size, cost = remaining.Div(level.Price), remaining
+			}
+			remaining = remaining.Sub(cost)
+		} else {
+			if size.GreaterThan(remaining) {
+				size, cost = remaining, remaining.Mul(level.Price)
+			}
+			remaining = remaining.Sub(size)
+		}
+
+		plan.size = plan.size.Add(size)
+		plan.cost = plan.cost.Add(cost)
+		plan.levels++
+		marginal = level.Price
+	}
+
+	plan.fullyFills = !remaining.IsPositive()
+	if plan.levels == 0 || (!plan.fullyFills && orderType == OrderTypeFOK) {
+		return nil, fmt.Errorf("%w: %s %s for %s, book covers %s shares (%s USDC) within price limit",
+			common.ErrInsufficientLiquidity, req.Side, req.TokenID, req.Amount, plan.size.StringFixed(4), plan.cost.StringFixed(2))
+	}
+	plan.avgPrice = plan.cost.Div(plan.size)
+
+	// 加上滑点容忍，超出价格保护或 (0, 1) 区间时收回，最后对齐到 tick
+	price := marginal
+	if req.Slippage.IsPositive() {
+		if buy {
+			price = price.Add(req.Slippage)
+		} else {
+			price = price.Sub(req.Slippage)
+		}
+	}
+	if req.WorstPrice.IsPositive() {
+		if (buy && price.GreaterThan(req.WorstPrice)) || (!buy && price.LessThan(req.WorstPrice)) {
+			price = req.WorstPrice
+		}
+	}
+	if !price.IsPositive() || price.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		price = marginal
+	}
+	plan.price = roundToTick(price, marginal, req.TickSize, buy)
+	return plan, nil
+}
+
+// roundToTick 将价格对齐到 tick：BUY 向下、SELL 向上取整，使滑点不超过请求的范围
+// 边际价位本身来自订单簿，取整越过它时（tick 比市场实际 tick 粗）使用边际价位
+func roundToTick(price, marginal, tick decimal.Decimal, buy bool) decimal.Decimal {
+	if !tick.IsPositive() {
+		tick = decimal.NewFromFloat(0.01)
+	}
+	if buy {
+		rounded := price.Div(tick).Floor().Mul(tick)
+		if rounded.LessThan(marginal) {
+			return marginal
+		}
+		return rounded
+	}
+	rounded := price.Div(tick).Ceil().Mul(tick)
+	if rounded.GreaterThan(marginal) {
+		return marginal
+	}
+	return rounded
+}
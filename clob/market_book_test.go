@@ -0,0 +1,150 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func levels(pairs ...string) []BookLevel {
+	out := make([]BookLevel, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		out = append(out, BookLevel{Price: decimal.RequireFromString(pairs[i]), Size: decimal.RequireFromString(pairs[i+1])})
+	}
+	return out
+}
+
+func TestPlanBookMarketOrder(t *testing.T) {
+	asks := levels("0.50", "10", "0.52", "20", "0.55", "100")
+	bids := levels("0.48", "10", "0.45", "20")
+
+	// BUY 12 USDC: 5 at 0.50 + 7 at 0.52
+	buy := &BookMarketOrderRequest{TokenID: "t", Side: OrderSideBuy, Amount: decimal.NewFromInt(12)}
+	plan, err := planBookMarketOrder(buy, OrderTypeFOK, asks)
+	if err != nil {
+		t.Fatalf("buy plan: %v", err)
+	}
+	if !plan.price.Equal(decimal.RequireFromString("0.52")) || plan.levels != 2 || !plan.fullyFills {
+		t.Errorf("unexpected buy plan: %+v", plan)
+	}
+	if !plan.cost.Equal(decimal.NewFromInt(12)) || !plan.size.Round(4).Equal(decimal.RequireFromString("23.4615")) {
+		t.Errorf("buy cost %s size %s", plan.cost, plan.size)
+	}
+
+	// SELL 15 shares: 10 at 0.48 + 5 at 0.45, with slippage
+	sell := &BookMarketOrderRequest{TokenID: "t", Side: OrderSideSell, Amount: decimal.NewFromInt(15), Slippage: decimal.RequireFromString("0.02")}
+	plan, err = planBookMarketOrder(sell, OrderTypeFOK, bids)
+	if err != nil {
+		t.Fatalf("sell plan: %v", err)
+	}
+	if !plan.price.Equal(decimal.RequireFromString("0.43")) || !plan.size.Equal(decimal.NewFromInt(15)) || !plan.cost.Equal(decimal.RequireFromString("7.05")) {
+		t.Errorf("unexpected sell plan: %+v", plan)
+	}
+
+	// WorstPrice limits both the levels counted and the slipped price
+	buy = &BookMarketOrderRequest{TokenID: "t", Side: OrderSideBuy, Amount: decimal.NewFromInt(20), WorstPrice: decimal.RequireFromString("0.52"), Slippage: decimal.RequireFromString("0.05")}
+	if _, err := planBookMarketOrder(buy, OrderTypeFOK, asks); !errors.Is(err, common.ErrInsufficientLiquidity) {
+		t.Errorf("FOK beyond WorstPrice: expected ErrInsufficientLiquidity, got %v", err)
+	}
+	plan, err = planBookMarketOrder(buy, OrderTypeFAK, asks)
+	if err != nil {
+		t.Fatalf("FAK plan: %v", err)
+	}
+	if plan.fullyFills || plan.levels != 2 || !plan.price.Equal(decimal.RequireFromString("0.52")) || !plan.cost.Equal(decimal.RequireFromString("15.4")) {
+		t.Errorf("unexpected partial FAK plan: %+v", plan)
+	}
+
+	// slippage never pushes the price out of (0, 1)
+	buy = &BookMarketOrderRequest{TokenID: "t", Side: OrderSideBuy, Amount: decimal.NewFromInt(1), Slippage: decimal.RequireFromString("0.05")}
+	plan, _ = planBookMarketOrder(buy, OrderTypeFOK, levels("0.98", "100"))
+	if !plan.price.Equal(decimal.RequireFromString("0.98")) {
+		t.Errorf("price = %s, expected slippage to be dropped", plan.price)
+	}
+
+	// the slipped price is rounded to the tick without widening the slippage
+	buy = &BookMarketOrderRequest{TokenID: "t", Side: OrderSideBuy, Amount: decimal.NewFromInt(1), Slippage: decimal.RequireFromString("0.005")}
+	plan, _ = planBookMarketOrder(buy, OrderTypeFOK, asks)
+	if !plan.price.Equal(decimal.RequireFromString("0.50")) {
+		t.Errorf("buy price = %s, expected 0.50 on a 0.01 tick", plan.price)
+	}
+	buy.TickSize = decimal.RequireFromString("0.001")
+	plan, _ = planBookMarketOrder(buy, OrderTypeFOK, asks)
+	if !plan.price.Equal(decimal.RequireFromString("0.505")) {
+		t.Errorf("buy price = %s, expected 0.505 on a 0.001 tick", plan.price)
+	}
+	sell = &BookMarketOrderRequest{TokenID: "t", Side: OrderSideSell, Amount: decimal.NewFromInt(1), Slippage: decimal.RequireFromString("0.015")}
+	plan, _ = planBookMarketOrder(sell, OrderTypeFOK, bids)
+	if !plan.price.Equal(decimal.RequireFromString("0.47")) {
+		t.Errorf("sell price = %s, expected 0.47", plan.price)
+	}
+	// a book price off the configured tick is kept rather than rounded past
+	plan, _ = planBookMarketOrder(&BookMarketOrderRequest{TokenID: "t", Side: OrderSideBuy, Amount: decimal.NewFromInt(1)}, OrderTypeFOK, levels("0.523", "100"))
+	if !plan.price.Equal(decimal.RequireFromString("0.523")) {
+		t.Errorf("buy price = %s, expected the marginal level 0.523", plan.price)
+	}
+
+	if _, err := planBookMarketOrder(sell, OrderTypeFAK, nil); !errors.Is(err, common.ErrInsufficientLiquidity) {
+		t.Errorf("empty book: expected ErrInsufficientLiquidity, got %v", err)
+	}
+}
+
+func TestClient_CreateMarketOrderFromBook(t *testing.T) {
+	posted := 0
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		posted++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"orderID":"order-1","status":"matched","makingAmount":"12","takingAmount":"23.5"}`))
+	})
+	defer server.Close()
+	ctx := context.Background()
+
+	req := &BookMarketOrderRequest{TokenID: "12345", Side: OrderSideBuy, Amount: decimal.NewFromInt(12)}
+	if _, err := client.CreateMarketOrderFromBook(ctx, req); !errors.Is(err, common.ErrNotInitialized) {
+		t.Errorf("without book source: expected ErrNotInitialized, got %v", err)
+	}
+
+	var gotSide OrderSide
+	client.SetBookSource(func(tokenID string, side OrderSide) ([]BookLevel, bool) {
+		gotSide = side
+		return levels("0.50", "10", "0.52", "20"), tokenID == "12345"
+	})
+
+	result, err := client.CreateMarketOrderFromBook(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateMarketOrderFromBook() error: %v", err)
+	}
+	if gotSide != OrderSideBuy || posted != 1 {
+		t.Errorf("side %s, posted %d", gotSide, posted)
+	}
+	if !result.Price.Equal(decimal.RequireFromString("0.52")) || result.Levels != 2 || !result.BookCovered {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if !result.FilledSize.Equal(decimal.RequireFromString("23.5")) || !result.FilledCost.Equal(decimal.NewFromInt(12)) || result.Response.OrderID != "order-1" {
+		t.Errorf("unexpected fill: %+v", result)
+	}
+
+	// book not ready, invalid request and insufficient depth are rejected before posting
+	unknown := *req
+	unknown.TokenID = "67890"
+	if _, err := client.CreateMarketOrderFromBook(ctx, &unknown); !errors.Is(err, common.ErrNotInitialized) {
+		t.Errorf("book not ready: expected ErrNotInitialized, got %v", err)
+	}
+	invalid := *req
+	invalid.Amount = decimal.Zero
+	if _, err := client.CreateMarketOrderFromBook(ctx, &invalid); !errors.Is(err, common.ErrInvalidSize) {
+		t.Errorf("zero amount: expected ErrInvalidSize, got %v", err)
+	}
+	large := *req
+	large.Amount = decimal.NewFromInt(100)
+	if _, err := client.CreateMarketOrderFromBook(ctx, &large); !errors.Is(err, common.ErrInsufficientLiquidity) {
+		t.Errorf("FOK beyond depth: expected ErrInsufficientLiquidity, got %v", err)
+	}
+	if posted != 1 {
+		t.Errorf("rejected orders should not be posted, got %d requests", posted)
+	}
+}
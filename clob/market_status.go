@@ -0,0 +1,158 @@
+package clob
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// DefaultMarketStatusTTL 市场接单状态的默认缓存时长
+const DefaultMarketStatusTTL = 30 * time.Second
+
+// notAcceptingOrdersMessages 交易所表示市场停止接单的错误信息（小写匹配）
+var notAcceptingOrdersMessages = []string{
+	"not accepting orders",
+	"market is closed",
+	"market closed",
+	"not yet ready to process new orders",
+}
+
+// marketStatusEntry 单个 token 的接单状态
+type marketStatusEntry struct {
+	accepting bool
+	expiresAt time.Time
+}
+
+// marketStatusCache 按 token 缓存市场是否接受订单（并发安全）
+// 状态来自 GetMarket 的 accepting_orders 字段或交易所返回的停止接单错误，过期后重新以交易所为准
+type marketStatusCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]marketStatusEntry
+	now     func() time.Time
+}
+
+// newMarketStatusCache 创建接单状态缓存，ttl <= 0 时使用 DefaultMarketStatusTTL
+func newMarketStatusCache(ttl time.Duration) *marketStatusCache {
+	if ttl <= 0 {
+		ttl = DefaultMarketStatusTTL
+	}
+	return &marketStatusCache{
+		ttl:     ttl,
+		entries: make(map[string]marketStatusEntry),
+		now:     time.Now,
+	}
+}
+
+// set 记录 token 的接单状态
+func (m *marketStatusCache) set(tokenID string, accepting bool) {
+	if m == nil || tokenID == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[tokenID] = marketStatusEntry{accepting: accepting, expiresAt: m.now().Add(m.ttl)}
+}
+
+// get 读取 token 的接单状态，未缓存或已过期时 known 为 false
+func (m *marketStatusCache) get(tokenID string) (accepting, known bool) {
+	if m == nil {
+		return false, false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[tokenID]
+	if !ok {
+		return false, false
+	}
+	if !m.now().Before(entry.expiresAt) {
+		delete(m.entries, tokenID)
+		return false, false
+	}
+	return entry.accepting, true
+}
+
+// isNotAcceptingOrders 判断错误信息是否表示市场停止接单
+func isNotAcceptingOrders(msg string) bool {
+	msg = strings.ToLower(msg)
+	for _, pattern := range notAcceptingOrdersMessages {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarketAcceptingOrders 返回缓存的 token 所在市场接单状态，known 为 false 表示未知（未缓存或已过期）
+func (c *Client) MarketAcceptingOrders(tokenID string) (accepting, known bool) {
+	return c.marketStatus.get(tokenID)
+}
+
+// updateMarketStatus 根据市场信息刷新其所有 token 的接单状态
+func (c *Client) updateMarketStatus(market *Market) {
+	accepting := market.AcceptingOrders && !market.Closed
+	for _, token := range market.Tokens {
+		c.marketStatus.set(token.TokenID, accepting)
+	}
+}
+
+// checkMarketAccepting 提交前检查，已知市场停止接单时返回 ErrMarketClosed，避免无效请求
+func (c *Client) checkMarketAccepting(tokenID string) error {
+	if accepting, known := c.marketStatus.get(tokenID); known && !accepting {
+		return fmt.Errorf("%w: token %s is not accepting orders", common.ErrMarketClosed, tokenID)
+	}
+	return nil
+}
+
+// observeSubmitResult 从提交结果中识别停止接单错误并写入缓存
+// 请求错误属于停止接单时返回包装了 ErrMarketClosed 的错误，否则原样返回
+func (c *Client) observeSubmitResult(tokenID string, resp *OrderResponse, err error) error {
+	if err != nil {
+		if isNotAcceptingOrders(err.Error()) {
+			c.marketStatus.set(tokenID, false)
+			return fmt.Errorf("%w: %w", common.ErrMarketClosed, err)
+		}
+		return err
+	}
+	if resp != nil && !resp.Success && isNotAcceptingOrders(resp.ErrorMsg) {
+		c.marketStatus.set(tokenID, false)
+	}
+	return nil
+}
+
+// observeBatchResults 批量提交版本的 observeSubmitResult
+func (c *Client) observeBatchResults(tokenIDs []string, results []*OrderResponse, err error) error {
+	if err != nil {
+		if isNotAcceptingOrders(err.Error()) {
+			// 无法确定是哪个订单被拒，不写入缓存，只标记错误
+			return fmt.Errorf("%w: %w", common.ErrMarketClosed, err)
+		}
+		return err
+	}
+	for i, resp := range results {
+		if i < len(tokenIDs) {
+			c.observeSubmitResult(tokenIDs[i], resp, nil)
+		}
+	}
+	return nil
+}
+
+// postTokenID 提交请求对应的 token
+func postTokenID(req *PostOrderRequest) string {
+	if req == nil || req.Order == nil {
+		return ""
+	}
+	return req.Order.TokenId
+}
+
+// batchTokenIDs 批量提交请求对应的 token 列表
+func batchTokenIDs(reqs []*PostOrderRequest) []string {
+	tokenIDs := make([]string, len(reqs))
+	for i, req := range reqs {
+		tokenIDs[i] = postTokenID(req)
+	}
+	return tokenIDs
+}
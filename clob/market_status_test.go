@@ -0,0 +1,113 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestCreateOrder_MarketClosedShortCircuit(t *testing.T) {
+	var posts int32
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&posts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"market 0xabc is not accepting orders"}`))
+	})
+	defer server.Close()
+
+	now := time.Now()
+	client.marketStatus.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := client.CreateOrder(ctx, validOrderRequest())
+	if !errors.Is(err, common.ErrMarketClosed) {
+		t.Fatalf("expected ErrMarketClosed from API error, got %v", err)
+	}
+	if accepting, known := client.MarketAcceptingOrders("12345"); !known || accepting {
+		t.Errorf("MarketAcceptingOrders() = %v, %v; expected cached closed", accepting, known)
+	}
+
+	// Cached: no request reaches the exchange
+	for i := 0; i < 3; i++ {
+		if _, err := client.CreateOrder(ctx, validOrderRequest()); !errors.Is(err, common.ErrMarketClosed) {
+			t.Fatalf("expected cached ErrMarketClosed, got %v", err)
+		}
+	}
+	if _, err := client.CreateOrders(ctx, []*CreateOrderRequest{validOrderRequest()}); !errors.Is(err, common.ErrMarketClosed) {
+		t.Errorf("expected batch ErrMarketClosed, got %v", err)
+	}
+	if got := atomic.LoadInt32(&posts); got != 1 {
+		t.Errorf("expected 1 request to exchange, got %d", got)
+	}
+
+	// After TTL the exchange is consulted again
+	now = now.Add(DefaultMarketStatusTTL)
+	if _, known := client.MarketAcceptingOrders("12345"); known {
+		t.Error("expected cache entry to expire")
+	}
+	client.CreateOrder(ctx, validOrderRequest())
+	if got := atomic.LoadInt32(&posts); got != 2 {
+		t.Errorf("expected request after TTL, got %d", got)
+	}
+}
+
+func TestCreateOrder_OtherErrorsNotCached(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(OrderResponse{Success: false, ErrorMsg: "not enough balance / allowance"})
+	})
+	defer server.Close()
+
+	resp, err := client.CreateOrder(context.Background(), validOrderRequest())
+	if err != nil || resp.Success {
+		t.Fatalf("CreateOrder() = %+v, %v", resp, err)
+	}
+	if _, known := client.MarketAcceptingOrders("12345"); known {
+		t.Error("unrelated rejection should not be cached")
+	}
+}
+
+func TestGetMarket_UpdatesMarketStatus(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Market{
+			ConditionID:     "0xabc",
+			Closed:          true,
+			AcceptingOrders: false,
+			Tokens:          []MarketToken{{TokenID: "12345"}, {TokenID: "67890"}},
+		})
+	})
+	defer server.Close()
+
+	if _, err := client.GetMarket(context.Background(), "0xabc"); err != nil {
+		t.Fatal(err)
+	}
+	for _, tokenID := range []string{"12345", "67890"} {
+		if accepting, known := client.MarketAcceptingOrders(tokenID); !known || accepting {
+			t.Errorf("token %s: accepting=%v known=%v, expected closed", tokenID, accepting, known)
+		}
+	}
+	if _, err := client.CreateOrder(context.Background(), validOrderRequest()); !errors.Is(err, common.ErrMarketClosed) {
+		t.Errorf("expected ErrMarketClosed, got %v", err)
+	}
+}
+
+func TestIsNotAcceptingOrders(t *testing.T) {
+	for msg, want := range map[string]bool{
+		"Market is not accepting orders":                    true,
+		"the market is not yet ready to process new orders": true,
+		"API error [400]: market closed":                    true,
+		"not enough balance / allowance":                    false,
+		"":                                                  false,
+	} {
+		if got := isNotAcceptingOrders(msg); got != want {
+			t.Errorf("isNotAcceptingOrders(%q) = %v, expected %v", msg, got, want)
+		}
+	}
+}
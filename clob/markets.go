@@ -0,0 +1,22 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetMarket 通过 conditionID 获取 CLOB 市场信息，同时刷新其 token 的接单状态缓存
+func (c *Client) GetMarket(ctx context.Context, conditionID string) (*Market, error) {
+	if conditionID == "" {
+		return nil, fmt.Errorf("condition ID is required")
+	}
+
+	var result Market
+	err := c.httpClient.Get(ctx, "/markets/"+conditionID, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market %s: %w", conditionID, err)
+	}
+	c.updateMarketStatus(&result)
+
+	return &result, nil
+}
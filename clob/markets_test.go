@@ -0,0 +1,56 @@
+package clob
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetMarket(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/markets/0xabc" {
+			t.Errorf("Expected path /markets/0xabc, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"condition_id": "0xabc",
+			"question_id": "0xq",
+			"question": "Will it rain?",
+			"market_slug": "will-it-rain",
+			"tokens": [
+				{"token_id": "111", "outcome": "Yes", "price": 0.42, "winner": false},
+				{"token_id": "222", "outcome": "No", "price": 0.58, "winner": false}
+			],
+			"active": true,
+			"closed": false,
+			"accepting_orders": true,
+			"neg_risk": true,
+			"minimum_order_size": 5,
+			"minimum_tick_size": 0.01,
+			"maker_base_fee": 0,
+			"taker_base_fee": 0
+		}`))
+	})
+	defer server.Close()
+
+	market, err := client.GetMarket(context.Background(), "0xabc")
+	if err != nil {
+		t.Fatalf("GetMarket() error: %v", err)
+	}
+	if market.ConditionID != "0xabc" || market.MarketSlug != "will-it-rain" || !market.NegRisk {
+		t.Errorf("unexpected market: %+v", market)
+	}
+	if len(market.Tokens) != 2 || market.Tokens[1].TokenID != "222" {
+		t.Errorf("unexpected tokens: %+v", market.Tokens)
+	}
+	if market.MinimumTickSize.String() != "0.01" {
+		t.Errorf("MinimumTickSize = %s, expected 0.01", market.MinimumTickSize)
+	}
+}
+
+func TestGetMarketEmptyConditionID(t *testing.T) {
+	client, _ := NewClient(nil, testPrivKey)
+	if _, err := client.GetMarket(context.Background(), ""); err == nil {
+		t.Error("Expected error for empty condition ID")
+	}
+}
@@ -0,0 +1,251 @@
+package clob
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// ConditionalTokensAddress 条件代币（ERC1155）合约地址 (Polygon Mainnet)
+const ConditionalTokensAddress = "0x4D97DCd97eC945f40cF65F87097ACe5EA0476045"
+
+// balanceOfBatchSelector balanceOfBatch(address[],uint256[]) 函数选择器
+const balanceOfBatchSelector = "4e1273f4"
+
+// rpcRequest JSON-RPC 请求
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// rpcResponse JSON-RPC 响应
+type rpcResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// queryOnChainBalances 通过 ERC1155 balanceOfBatch 一次性查询多个 token 的链上余额
+// 返回值为最小单位（与 CLOB API 余额单位一致）
+func queryOnChainBalances(ctx context.Context, httpClient *http.Client, rpcEndpoint, contract, owner string, tokenIDs []string) (map[string]decimal.Decimal, error) {
+	if len(tokenIDs) == 0 {
+		return map[string]decimal.Decimal{}, nil
+	}
+
+	data, err := encodeBalanceOfBatch(owner, tokenIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var hexResult string
+	err = rpcCall(ctx, httpClient, rpcEndpoint, "eth_call", []interface{}{
+		map[string]string{"to": contract, "data": "0x" + hex.EncodeToString(data)},
+		"latest",
+	}, &hexResult)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := hex.DecodeString(strings.TrimPrefix(hexResult, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid rpc result: %w", err)
+	}
+
+	values, err := decodeUint256Array(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(tokenIDs) {
+		return nil, fmt.Errorf("balanceOfBatch returned %d values, expected %d", len(values), len(tokenIDs))
+	}
+
+	balances := make(map[string]decimal.Decimal, len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		balances[tokenID] = decimal.NewFromBigInt(values[i], 0)
+	}
+	return balances, nil
+}
+
+// rpcCall 发送 JSON-RPC 请求，并把 result 解码到 result（为 nil 时忽略）
+func rpcCall(ctx context.Context, httpClient *http.Client, rpcEndpoint, method string, params []interface{}, result interface{}) error {
+	reqBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal rpc request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcEndpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to create rpc request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("rpc request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read rpc response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("rpc request failed: status %d: %s", resp.StatusCode, body)
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return fmt.Errorf("failed to parse rpc response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("rpc error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+			return fmt.Errorf("invalid rpc result: %w", err)
+		}
+	}
+	return nil
+}
+
+// rpcBatchCall 以一个 JSON-RPC 批量请求发送多个调用，按请求顺序返回各自的 result
+// 请求 ID 由 1 起按顺序分配，响应按 ID 匹配（节点可能乱序返回）；任一调用出错时返回错误
+func rpcBatchCall(ctx context.Context, httpClient *http.Client, rpcEndpoint string, calls []rpcRequest) ([]json.RawMessage, error) {
+	for i := range calls {
+		calls[i].JSONRPC = "2.0"
+		calls[i].ID = i + 1
+	}
+	reqBody, err := json.Marshal(calls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rpc batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcEndpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rpc request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rpc response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rpc request failed: status %d: %s", resp.StatusCode, body)
+	}
+
+	var rpcResps []rpcResponse
+	if err := json.Unmarshal(body, &rpcResps); err != nil {
+		return nil, fmt.Errorf("failed to parse rpc batch response (endpoint may not support batching): %w", err)
+	}
+
+	results := make([]json.RawMessage, len(calls))
+	for _, r := range rpcResps {
+		if r.ID < 1 || r.ID > len(calls) {
+			return nil, fmt.Errorf("rpc batch response has unknown id %d", r.ID)
+		}
+		if r.Error != nil {
+			return nil, fmt.Errorf("rpc error %d: %s", r.Error.Code, r.Error.Message)
+		}
+		results[r.ID-1] = r.Result
+	}
+	for i, result := range results {
+		if result == nil {
+			return nil, fmt.Errorf("rpc batch response missing id %d", i+1)
+		}
+	}
+	return results, nil
+}
+
+// encodeBalanceOfBatch ABI 编码 balanceOfBatch(address[] accounts, uint256[] ids)
+func encodeBalanceOfBatch(owner string, tokenIDs []string) ([]byte, error) {
+	if !ethcommon.IsHexAddress(owner) {
+		return nil, fmt.Errorf("invalid owner address: %s", owner)
+	}
+	ownerWord := ethcommon.LeftPadBytes(ethcommon.HexToAddress(owner).Bytes(), 32)
+
+	n := len(tokenIDs)
+	buf := make([]byte, 0, 4+32*(4+2*n))
+	selector, _ := hex.DecodeString(balanceOfBatchSelector)
+	buf = append(buf, selector...)
+
+	// 两个动态数组的偏移
+	buf = append(buf, uint256Word(big.NewInt(64))...)
+	buf = append(buf, uint256Word(big.NewInt(int64(64+32*(1+n))))...)
+
+	// accounts: 每个 id 对应同一个 owner
+	buf = append(buf, uint256Word(big.NewInt(int64(n)))...)
+	for i := 0; i < n; i++ {
+		buf = append(buf, ownerWord...)
+	}
+
+	// ids
+	buf = append(buf, uint256Word(big.NewInt(int64(n)))...)
+	for _, tokenID := range tokenIDs {
+		id, ok := new(big.Int).SetString(tokenID, 10)
+		if !ok || id.Sign() < 0 {
+			return nil, fmt.Errorf("invalid token ID: %s", tokenID)
+		}
+		buf = append(buf, uint256Word(id)...)
+	}
+
+	return buf, nil
+}
+
+// decodeUint256Array ABI 解码单个 uint256[] 返回值
+func decodeUint256Array(data []byte) ([]*big.Int, error) {
+	if len(data) < 64 {
+		return nil, fmt.Errorf("invalid uint256[] encoding: too short")
+	}
+
+	// 先与剩余字节数比较再做乘法，避免恶意 RPC 返回的超大 offset/length 溢出后通过边界检查
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(data)-32) {
+		return nil, fmt.Errorf("invalid uint256[] encoding: bad offset")
+	}
+	start := int(offset.Uint64())
+
+	length := new(big.Int).SetBytes(data[start : start+32])
+	if !length.IsUint64() || length.Uint64() > uint64(len(data)-start-32)/32 {
+		return nil, fmt.Errorf("invalid uint256[] encoding: bad length")
+	}
+
+	n := int(length.Uint64())
+	values := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		pos := start + 32 + i*32
+		values[i] = new(big.Int).SetBytes(data[pos : pos+32])
+	}
+	return values, nil
+}
+
+// uint256Word 编码为 32 字节大端
+func uint256Word(v *big.Int) []byte {
+	return ethcommon.LeftPadBytes(v.Bytes(), 32)
+}
@@ -0,0 +1,121 @@
+package clob
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// conditionalBalanceBatchSize 单次 balanceOfBatch 调用查询的 token 数，超出时拆分为多个调用并合并到一个 JSON-RPC 批量请求
+const conditionalBalanceBatchSize = 100
+
+// ConditionalBalances 直接从链上读取 owner 持有的条件代币（ERC1155）数量（单位：份）
+// CLOB API 的余额可能滞后于链上成交结算，卖出前的余额检查应以此为准；
+// token 按 conditionalBalanceBatchSize 分组调用 balanceOfBatch，所有分组在一个 JSON-RPC 批量请求中发送（节点需支持批量请求）
+func (t *Treasury) ConditionalBalances(ctx context.Context, owner string, tokenIDs []string) (map[string]decimal.Decimal, error) {
+	if !ethcommon.IsHexAddress(owner) {
+		return nil, fmt.Errorf("%w: %s", common.ErrInvalidAddress, owner)
+	}
+
+	seen := make(map[string]bool, len(tokenIDs))
+	unique := make([]string, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if !seen[tokenID] {
+			seen[tokenID] = true
+			unique = append(unique, tokenID)
+		}
+	}
+	balances := make(map[string]decimal.Decimal, len(unique))
+	if len(unique) == 0 {
+		return balances, nil
+	}
+
+	contract := t.ConditionalTokensContract()
+	var calls []rpcRequest
+	for start := 0; start < len(unique); start += conditionalBalanceBatchSize {
+		end := min(start+conditionalBalanceBatchSize, len(unique))
+		data, err := encodeBalanceOfBatch(owner, unique[start:end])
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, rpcRequest{
+			Method: "eth_call",
+			Params: []interface{}{
+				map[string]string{"to": contract, "data": "0x" + hex.EncodeToString(data)},
+				"latest",
+			},
+		})
+	}
+
+	results, err := rpcBatchCall(ctx, t.httpClient, t.config.RPCEndpoint, calls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conditional balances: %w", err)
+	}
+
+	for i, raw := range results {
+		var hexResult string
+		if err := json.Unmarshal(raw, &hexResult); err != nil {
+			return nil, fmt.Errorf("invalid rpc result: %w", err)
+		}
+		data, err := hex.DecodeString(strings.TrimPrefix(hexResult, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rpc result: %w", err)
+		}
+		values, err := decodeUint256Array(data)
+		if err != nil {
+			return nil, err
+		}
+
+		batch := unique[i*conditionalBalanceBatchSize : min((i+1)*conditionalBalanceBatchSize, len(unique))]
+		if len(values) != len(batch) {
+			return nil, fmt.Errorf("balanceOfBatch returned %d values, expected %d", len(values), len(batch))
+		}
+		for j, tokenID := range batch {
+			balances[tokenID] = decimal.NewFromBigInt(values[j], -USDCDecimals)
+		}
+	}
+	return balances, nil
+}
+
+// FunderConditionalBalances 读取资金钱包（funder，代理钱包模式下为代理钱包地址）的条件代币链上余额（单位：份）
+func (t *Treasury) FunderConditionalBalances(ctx context.Context, tokenIDs []string) (map[string]decimal.Decimal, error) {
+	return t.ConditionalBalances(ctx, t.client.GetFunderAddress(), tokenIDs)
+}
+
+// CheckSellBalances 卖出前检查：按 token 汇总 orders 中 SELL 订单的数量，与资金钱包的链上余额比较
+// 任一 token 余额不足时返回 common.ErrInsufficientBalance；BUY 订单不参与检查
+// 已挂出但未成交的卖单同样占用余额，调用方需要时应将其一并传入
+func (t *Treasury) CheckSellBalances(ctx context.Context, orders []*CreateOrderRequest) error {
+	required := make(map[string]decimal.Decimal)
+	var tokenIDs []string
+	for _, order := range orders {
+		if order == nil || order.Side != OrderSideSell {
+			continue
+		}
+		if _, ok := required[order.TokenID]; !ok {
+			tokenIDs = append(tokenIDs, order.TokenID)
+		}
+		required[order.TokenID] = required[order.TokenID].Add(order.Size)
+	}
+	if len(tokenIDs) == 0 {
+		return nil
+	}
+
+	balances, err := t.FunderConditionalBalances(ctx, tokenIDs)
+	if err != nil {
+		return err
+	}
+	for _, tokenID := range tokenIDs {
+		if balance := balances[tokenID]; balance.LessThan(required[tokenID]) {
+			return fmt.Errorf("%w: token %s holds %s on-chain, selling %s", common.ErrInsufficientBalance, tokenID, balance, required[tokenID])
+		}
+	}
+	return nil
+}
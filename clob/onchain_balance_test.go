@@ -0,0 +1,128 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// setupBalanceChain returns a treasury backed by a batch-capable node that
+// answers balanceOfBatch from balances (token ID -> base units)
+func setupBalanceChain(t *testing.T, balances map[string]int64) (*Treasury, *int) {
+	t.Helper()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var calls []rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&calls); err != nil {
+			t.Errorf("expected a batch request: %v", err)
+			return
+		}
+
+		responses := make([]map[string]interface{}, 0, len(calls))
+		// Answer in reverse order to exercise matching by id.
+		for i := len(calls) - 1; i >= 0; i-- {
+			call := calls[i].Params[0].(map[string]interface{})
+			data := hexutil.MustDecode(call["data"].(string))[4:]
+			// The second head word is the offset of the ids array.
+			offset := new(big.Int).SetBytes(data[32:64]).Int64()
+			ids, err := decodeUint256Array(append(uint256Word(big.NewInt(32)), data[offset:]...))
+			if err != nil {
+				t.Errorf("decode ids: %v", err)
+				return
+			}
+
+			values := make([]int64, len(ids))
+			for j, id := range ids {
+				values[j] = balances[id.String()]
+			}
+			responses = append(responses, map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      calls[i].ID,
+				"result":  encodeUint256ArrayResult(values...),
+			})
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(DefaultConfig(), testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultTreasuryConfig()
+	config.RPCEndpoint = server.URL
+	treasury, err := client.NewTreasury(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return treasury, &requests
+}
+
+func TestTreasury_ConditionalBalancesBatches(t *testing.T) {
+	balances := make(map[string]int64)
+	tokenIDs := make([]string, conditionalBalanceBatchSize+5)
+	for i := range tokenIDs {
+		tokenIDs[i] = fmt.Sprint(1000 + i)
+		balances[tokenIDs[i]] = int64(i) * 1_000_000
+	}
+	treasury, requests := setupBalanceChain(t, balances)
+
+	got, err := treasury.FunderConditionalBalances(context.Background(), append(tokenIDs, tokenIDs[0]))
+	if err != nil {
+		t.Fatalf("FunderConditionalBalances() error: %v", err)
+	}
+	if *requests != 1 {
+		t.Errorf("expected a single batched request, got %d", *requests)
+	}
+	if len(got) != len(tokenIDs) {
+		t.Fatalf("expected %d balances, got %d", len(tokenIDs), len(got))
+	}
+	last := tokenIDs[len(tokenIDs)-1]
+	if !got[last].Equal(decimal.NewFromInt(int64(len(tokenIDs) - 1))) {
+		t.Errorf("balance of %s = %s, expected %d shares", last, got[last], len(tokenIDs)-1)
+	}
+}
+
+func TestTreasury_ConditionalBalancesInvalidOwner(t *testing.T) {
+	treasury, _ := setupBalanceChain(t, nil)
+	if _, err := treasury.ConditionalBalances(context.Background(), "bad", []string{"1"}); !errors.Is(err, common.ErrInvalidAddress) {
+		t.Errorf("expected ErrInvalidAddress, got %v", err)
+	}
+}
+
+func TestTreasury_CheckSellBalances(t *testing.T) {
+	treasury, requests := setupBalanceChain(t, map[string]int64{"1": 10_000_000, "2": 3_000_000})
+
+	orders := []*CreateOrderRequest{
+		{TokenID: "1", Side: OrderSideSell, Size: decimal.NewFromInt(6)},
+		{TokenID: "1", Side: OrderSideSell, Size: decimal.NewFromInt(4)},
+		{TokenID: "2", Side: OrderSideBuy, Size: decimal.NewFromInt(100)},
+	}
+	if err := treasury.CheckSellBalances(context.Background(), orders); err != nil {
+		t.Errorf("CheckSellBalances() error: %v", err)
+	}
+
+	orders = append(orders, &CreateOrderRequest{TokenID: "2", Side: OrderSideSell, Size: decimal.NewFromInt(5)})
+	err := treasury.CheckSellBalances(context.Background(), orders)
+	if !errors.Is(err, common.ErrInsufficientBalance) || !strings.Contains(err.Error(), "token 2") {
+		t.Errorf("expected insufficient balance for token 2, got %v", err)
+	}
+
+	before := *requests
+	if err := treasury.CheckSellBalances(context.Background(), orders[2:3]); err != nil || *requests != before {
+		t.Errorf("buy-only orders should not query the chain, err=%v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package clob
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestBalanceOfBatchSelector(t *testing.T) {
+	hash := crypto.Keccak256([]byte("balanceOfBatch(address[],uint256[])"))
+	if got := hex.EncodeToString(hash[:4]); got != balanceOfBatchSelector {
+		t.Errorf("selector = %s, expected %s", got, balanceOfBatchSelector)
+	}
+}
+
+func TestEncodeBalanceOfBatch(t *testing.T) {
+	data, err := encodeBalanceOfBatch("0x0000000000000000000000000000000000000001", []string{"7", "8"})
+	if err != nil {
+		t.Fatalf("encodeBalanceOfBatch() error: %v", err)
+	}
+
+	// selector + 2 offsets + (len + 2 accounts) + (len + 2 ids)
+	if len(data) != 4+32*8 {
+		t.Fatalf("encoded length = %d, expected %d", len(data), 4+32*8)
+	}
+	word := func(i int) *big.Int { return new(big.Int).SetBytes(data[4+32*i : 4+32*(i+1)]) }
+	if word(0).Int64() != 64 || word(1).Int64() != 160 {
+		t.Errorf("offsets = %s, %s, expected 64, 160", word(0), word(1))
+	}
+	if word(2).Int64() != 2 || word(3).Int64() != 1 || word(4).Int64() != 1 {
+		t.Error("accounts array encoded incorrectly")
+	}
+	if word(5).Int64() != 2 || word(6).Int64() != 7 || word(7).Int64() != 8 {
+		t.Error("ids array encoded incorrectly")
+	}
+
+	if _, err := encodeBalanceOfBatch("bad", []string{"1"}); err == nil {
+		t.Error("expected error for invalid owner")
+	}
+	if _, err := encodeBalanceOfBatch("0x0000000000000000000000000000000000000001", []string{"abc"}); err == nil {
+		t.Error("expected error for invalid token ID")
+	}
+}
+
+func TestDecodeUint256Array_Malformed(t *testing.T) {
+	valid, _ := hex.DecodeString(strings.TrimPrefix(encodeUint256ArrayResult(5), "0x"))
+	values, err := decodeUint256Array(valid)
+	if err != nil || len(values) != 1 || values[0].Int64() != 5 {
+		t.Fatalf("decodeUint256Array() = %v, %v", values, err)
+	}
+
+	// length*32 wraps around to 32, so a product-based bounds check would pass
+	wrapping := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 59), big.NewInt(1))
+	maxWord := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	cases := map[string][]byte{
+		"oversized length": append(append(uint256Word(big.NewInt(32)), uint256Word(wrapping)...), uint256Word(big.NewInt(5))...),
+		"max length":       append(append(uint256Word(big.NewInt(32)), uint256Word(maxWord)...), uint256Word(big.NewInt(5))...),
+		"oversized offset": append(uint256Word(big.NewInt(1<<63-8)), uint256Word(big.NewInt(1))...),
+		"too short":        uint256Word(big.NewInt(32)),
+	}
+	for name, data := range cases {
+		if _, err := decodeUint256Array(data); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// encodeUint256ArrayResult 构造 uint256[] 返回值
+func encodeUint256ArrayResult(values ...int64) string {
+	buf := uint256Word(big.NewInt(32))
+	buf = append(buf, uint256Word(big.NewInt(int64(len(values))))...)
+	for _, v := range values {
+		buf = append(buf, uint256Word(big.NewInt(v))...)
+	}
+	return "0x" + hex.EncodeToString(buf)
+}
+
+func newTestRPCServer(t *testing.T, values ...int64) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Method != "eth_call" {
+			t.Errorf("Expected eth_call, got %s", req.Method)
+		}
+		call := req.Params[0].(map[string]interface{})
+		if !strings.HasPrefix(call["data"].(string), "0x"+balanceOfBatchSelector) {
+			t.Errorf("unexpected call data: %v", call["data"])
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  encodeUint256ArrayResult(values...),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestQueryOnChainBalances(t *testing.T) {
+	server := newTestRPCServer(t, 1000000, 0)
+
+	balances, err := queryOnChainBalances(context.Background(), http.DefaultClient, server.URL,
+		ConditionalTokensAddress, "0x0000000000000000000000000000000000000001", []string{"11", "22"})
+	if err != nil {
+		t.Fatalf("queryOnChainBalances() error: %v", err)
+	}
+	if balances["11"].IntPart() != 1000000 || !balances["22"].IsZero() {
+		t.Errorf("unexpected balances: %v", balances)
+	}
+}
@@ -0,0 +1,445 @@
+package clob
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// OrderLifecycle 订单生命周期事件类型
+type OrderLifecycle string
+
+const (
+	// OrderAccepted 订单已被撮合引擎接受（挂出或立即撮合）
+	OrderAccepted OrderLifecycle = "accepted"
+	// OrderPartiallyFilled 订单部分成交
+	OrderPartiallyFilled OrderLifecycle = "partially_filled"
+	// OrderFilled 订单完全成交，最终状态
+	OrderFilled OrderLifecycle = "filled"
+	// OrderCanceled 订单已撤销（含部分成交后撤销），最终状态
+	OrderCanceled OrderLifecycle = "canceled"
+	// OrderExpired GTD 订单到期后被撤销，最终状态
+	OrderExpired OrderLifecycle = "expired"
+)
+
+// IsFinal 是否为最终状态
+func (l OrderLifecycle) IsFinal() bool {
+	return l == OrderFilled || l == OrderCanceled || l == OrderExpired
+}
+
+// OrderUpdate 订单状态更新，来自 GetOrder 轮询或 USER 频道推送
+// 零值字段表示未知，不覆盖已跟踪的值
+type OrderUpdate struct {
+	OrderID      string
+	Status       OrderStatus // LIVE/DELAYED/MATCHED/CANCELED
+	AssetID      string
+	Side         OrderSide
+	Price        decimal.Decimal
+	OriginalSize decimal.Decimal
+	SizeMatched  decimal.Decimal
+	Expiration   time.Time // GTD 订单到期时间
+	Time         time.Time // 更新时间，零值使用当前时间
+}
+
+// OrderUpdateFromOrder 将 GetOrder 的结果转换为状态更新
+func OrderUpdateFromOrder(order *Order) OrderUpdate {
+	update := OrderUpdate{
+		OrderID:      order.ID,
+		Status:       order.Status,
+		AssetID:      order.AssetID,
+		Side:         order.Side,
+		Price:        order.Price,
+		OriginalSize: order.OriginalSize,
+		SizeMatched:  order.SizeMatched,
+	}
+	if exp, err := decimal.NewFromString(order.Expiration); err == nil && exp.IsPositive() {
+		update.Expiration = time.Unix(exp.IntPart(), 0)
+	}
+	return update
+}
+
+// TrackedOrder 被跟踪订单的当前状态
+type TrackedOrder struct {
+	ID           string
+	AssetID      string
+	Side         OrderSide
+	Price        decimal.Decimal
+	OriginalSize decimal.Decimal
+	SizeMatched  decimal.Decimal
+	Expiration   time.Time
+	State        OrderLifecycle // 最近一次事件，空表示尚未确认被接受
+	TrackedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// OrderTrackerEvent 订单生命周期事件
+type OrderTrackerEvent struct {
+	Type   OrderLifecycle
+	Order  TrackedOrder    // 事件发生后的订单状态
+	Filled decimal.Decimal // 本次新增成交数量，仅成交事件填充
+	Time   time.Time
+}
+
+// OrderTrackerCallbacks 按事件类型分发的回调，在发出事件的协程中同步调用，不应阻塞
+type OrderTrackerCallbacks struct {
+	OnAccepted        func(OrderTrackerEvent)
+	OnPartiallyFilled func(OrderTrackerEvent)
+	OnFilled          func(OrderTrackerEvent)
+	OnCanceled        func(OrderTrackerEvent)
+	OnExpired         func(OrderTrackerEvent)
+}
+
+// OrderTrackerConfig 订单跟踪器配置
+type OrderTrackerConfig struct {
+	PollInterval   time.Duration // GetOrder 轮询间隔，0 表示不轮询（仅使用 Apply 推送的更新）
+	RequestTimeout time.Duration // 单次 GetOrder 请求超时
+	ChannelSize    int           // 事件 channel 容量，满时丢弃
+}
+
+// DefaultOrderTrackerConfig 默认配置
+func DefaultOrderTrackerConfig() *OrderTrackerConfig {
+	return &OrderTrackerConfig{
+		PollInterval:   2 * time.Second,
+		RequestTimeout: 10 * time.Second,
+		ChannelSize:    256,
+	}
+}
+
+// OrderFetcher 查询单个订单（*Client 已实现）
+type OrderFetcher interface {
+	GetOrder(ctx context.Context, orderID string) (*Order, error)
+}
+
+// fillRecorder 接收成交事件的事件日志（*Client 已实现）
+type fillRecorder interface {
+	RecordEvent(event TradingEvent)
+}
+
+// OrderTracker 订单生命周期跟踪器
+// 跟踪已提交的订单，对比前后状态生成 Accepted/PartiallyFilled/Filled/Canceled/Expired 事件，
+// 通过 Events channel 与 OrderTrackerCallbacks 发出；状态来自定期 GetOrder 轮询，
+// 也可通过 Apply 推送 USER 频道的更新以降低延迟。订单到达最终状态后停止跟踪
+type OrderTracker struct {
+	mu sync.Mutex
+
+	fetcher   OrderFetcher
+	recorder  fillRecorder // 非 nil 时成交写入交易事件日志
+	config    *OrderTrackerConfig
+	orders    map[string]*TrackedOrder
+	callbacks OrderTrackerCallbacks
+
+	eventChan chan OrderTrackerEvent
+	dropped   atomic.Int64
+	pollErrs  atomic.Int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	now    func() time.Time
+}
+
+// NewOrderTracker 创建订单跟踪器，fetcher 为 nil 时不轮询
+// fetcher 为 *Client 时，轮询或 Apply 检测到的成交同时写入其事件日志（RecentEvents）
+func NewOrderTracker(fetcher OrderFetcher, config *OrderTrackerConfig) *OrderTracker {
+	if config == nil {
+		config = DefaultOrderTrackerConfig()
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = 10 * time.Second
+	}
+	if config.ChannelSize <= 0 {
+		config.ChannelSize = 256
+	}
+
+	recorder, _ := fetcher.(fillRecorder)
+	return &OrderTracker{
+		fetcher:   fetcher,
+		recorder:  recorder,
+		config:    config,
+		orders:    make(map[string]*TrackedOrder),
+		eventChan: make(chan OrderTrackerEvent, config.ChannelSize),
+		now:       time.Now,
+	}
+}
+
+// SetCallbacks 设置事件回调，替换之前设置的回调
+func (t *OrderTracker) SetCallbacks(callbacks OrderTrackerCallbacks) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.callbacks = callbacks
+}
+
+// Events 获取事件 channel（满时丢弃，见 Dropped）
+func (t *OrderTracker) Events() <-chan OrderTrackerEvent {
+	return t.eventChan
+}
+
+// Dropped channel 满时丢弃的事件数
+func (t *OrderTracker) Dropped() int64 {
+	return t.dropped.Load()
+}
+
+// PollErrors GetOrder 轮询失败次数，失败的订单在下次轮询时重试
+func (t *OrderTracker) PollErrors() int64 {
+	return t.pollErrs.Load()
+}
+
+// Track 开始跟踪订单，已跟踪的订单被忽略
+func (t *OrderTracker) Track(orderIDs ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for _, id := range orderIDs {
+		if id == "" {
+			continue
+		}
+		if _, ok := t.orders[id]; !ok {
+			t.orders[id] = &TrackedOrder{ID: id, TrackedAt: now, UpdatedAt: now}
+		}
+	}
+}
+
+// TrackResponse 跟踪下单成功的订单，失败的响应被忽略
+func (t *OrderTracker) TrackResponse(responses ...*OrderResponse) {
+	for _, resp := range responses {
+		if resp != nil && resp.Success {
+			t.Track(resp.OrderID)
+		}
+	}
+}
+
+// Untrack 停止跟踪订单，不发出事件
+func (t *OrderTracker) Untrack(orderIDs ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, id := range orderIDs {
+		delete(t.orders, id)
+	}
+}
+
+// Get 获取被跟踪订单的当前状态
+func (t *OrderTracker) Get(orderID string) (TrackedOrder, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	order, ok := t.orders[orderID]
+	if !ok {
+		return TrackedOrder{}, false
+	}
+	return *order, true
+}
+
+// Orders 获取所有被跟踪订单的当前状态
+func (t *OrderTracker) Orders() []TrackedOrder {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	orders := make([]TrackedOrder, 0, len(t.orders))
+	for _, order := range t.orders {
+		orders = append(orders, *order)
+	}
+	return orders
+}
+
+// Apply 应用一次订单状态更新并发出产生的事件，返回产生的事件；未跟踪的订单被忽略
+// 成交数量只增不减，乱序到达的旧更新不会回退状态
+func (t *OrderTracker) Apply(update OrderUpdate) []OrderTrackerEvent {
+	t.mu.Lock()
+	order, ok := t.orders[update.OrderID]
+	if !ok {
+		t.mu.Unlock()
+		return nil
+	}
+	if update.Time.IsZero() {
+		update.Time = t.now()
+	}
+	events := transition(order, update)
+	if order.State.IsFinal() {
+		delete(t.orders, order.ID)
+	}
+	callbacks := t.callbacks
+	t.mu.Unlock()
+
+	for _, event := range events {
+		t.emit(event, callbacks)
+	}
+	return events
+}
+
+// transition 更新订单状态并返回产生的事件
+func transition(order *TrackedOrder, update OrderUpdate) []OrderTrackerEvent {
+	if update.AssetID != "" {
+		order.AssetID = update.AssetID
+	}
+	if update.Side != "" {
+		order.Side = update.Side
+	}
+	if update.Price.IsPositive() {
+		order.Price = update.Price
+	}
+	if update.OriginalSize.IsPositive() {
+		order.OriginalSize = update.OriginalSize
+	}
+	if !update.Expiration.IsZero() {
+		order.Expiration = update.Expiration
+	}
+	order.UpdatedAt = update.Time
+
+	var events []OrderTrackerEvent
+	add := func(typ OrderLifecycle, filled decimal.Decimal) {
+		order.State = typ
+		events = append(events, OrderTrackerEvent{Type: typ, Order: *order, Filled: filled, Time: update.Time})
+	}
+
+	if order.State == "" {
+		add(OrderAccepted, decimal.Zero)
+	}
+
+	if update.SizeMatched.GreaterThan(order.SizeMatched) {
+		filled := update.SizeMatched.Sub(order.SizeMatched)
+		order.SizeMatched = update.SizeMatched
+		if update.Status == OrderStatusMatched ||
+			(order.OriginalSize.IsPositive() && order.SizeMatched.GreaterThanOrEqual(order.OriginalSize)) {
+			add(OrderFilled, filled)
+		} else {
+			add(OrderPartiallyFilled, filled)
+		}
+	} else if update.Status == OrderStatusMatched {
+		add(OrderFilled, decimal.Zero)
+	}
+
+	if update.Status == OrderStatusCanceled && !order.State.IsFinal() {
+		if !order.Expiration.IsZero() && !update.Time.Before(order.Expiration) {
+			add(OrderExpired, decimal.Zero)
+		} else {
+			add(OrderCanceled, decimal.Zero)
+		}
+	}
+
+	return events
+}
+
+// emit 发出事件到 channel 与回调，成交同时写入事件日志
+func (t *OrderTracker) emit(event OrderTrackerEvent, callbacks OrderTrackerCallbacks) {
+	if t.recorder != nil && event.Filled.IsPositive() {
+		t.recorder.RecordEvent(TradingEvent{
+			Time:    event.Time,
+			Type:    TradingEventFill,
+			OrderID: event.Order.ID,
+			TokenID: event.Order.AssetID,
+			Side:    event.Order.Side,
+			Price:   event.Order.Price,
+			Size:    event.Filled,
+			Status:  string(event.Type),
+		})
+	}
+
+	select {
+	case t.eventChan <- event:
+	default:
+		t.dropped.Add(1)
+	}
+
+	var callback func(OrderTrackerEvent)
+	switch event.Type {
+	case OrderAccepted:
+		callback = callbacks.OnAccepted
+	case OrderPartiallyFilled:
+		callback = callbacks.OnPartiallyFilled
+	case OrderFilled:
+		callback = callbacks.OnFilled
+	case OrderCanceled:
+		callback = callbacks.OnCanceled
+	case OrderExpired:
+		callback = callbacks.OnExpired
+	}
+	if callback != nil {
+		callback(event)
+	}
+}
+
+// Poll 立即通过 GetOrder 查询所有被跟踪订单并应用结果，返回最后一个查询错误
+func (t *OrderTracker) Poll(ctx context.Context) error {
+	if t.fetcher == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	ids := make([]string, 0, len(t.orders))
+	for id := range t.orders {
+		ids = append(ids, id)
+	}
+	t.mu.Unlock()
+
+	var lastErr error
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, t.config.RequestTimeout)
+		order, err := t.fetcher.GetOrder(reqCtx, id)
+		cancel()
+		if err != nil {
+			t.pollErrs.Add(1)
+			lastErr = err
+			continue
+		}
+		if order == nil || order.ID == "" {
+			continue
+		}
+		update := OrderUpdateFromOrder(order)
+		update.OrderID = id
+		t.Apply(update)
+	}
+	return lastErr
+}
+
+// Start 启动后台轮询，PollInterval 为 0 或 fetcher 为 nil 时不启动
+func (t *OrderTracker) Start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cancel != nil || t.fetcher == nil || t.config.PollInterval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		common.Supervise(ctx, "clob.order_tracker", nil, t.loop)
+	}()
+}
+
+// Stop 停止后台轮询
+func (t *OrderTracker) Stop() {
+	t.mu.Lock()
+	cancel := t.cancel
+	t.cancel = nil
+	t.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		t.wg.Wait()
+	}
+}
+
+// loop 定期轮询
+func (t *OrderTracker) loop(ctx context.Context) {
+	ticker := time.NewTicker(t.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.Poll(ctx)
+		}
+	}
+}
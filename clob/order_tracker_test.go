@@ -0,0 +1,255 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type fakeOrderFetcher struct {
+	mu     sync.Mutex
+	orders map[string]*Order
+	err    error
+}
+
+func (f *fakeOrderFetcher) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	order, ok := f.orders[orderID]
+	if !ok {
+		return &Order{}, nil
+	}
+	copied := *order
+	return &copied, nil
+}
+
+func (f *fakeOrderFetcher) set(order *Order) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.orders[order.ID] = order
+}
+
+func trackerOrder(id string, status OrderStatus, size, matched string) *Order {
+	return &Order{
+		ID:           id,
+		Status:       status,
+		AssetID:      "token-1",
+		Side:         OrderSideBuy,
+		Price:        decimal.RequireFromString("0.5"),
+		OriginalSize: decimal.RequireFromString(size),
+		SizeMatched:  decimal.RequireFromString(matched),
+		Expiration:   "0",
+	}
+}
+
+func eventTypes(events []OrderTrackerEvent) []OrderLifecycle {
+	types := make([]OrderLifecycle, len(events))
+	for i, e := range events {
+		types[i] = e.Type
+	}
+	return types
+}
+
+func drainTracker(tracker *OrderTracker) []OrderTrackerEvent {
+	var events []OrderTrackerEvent
+	for {
+		select {
+		case e := <-tracker.Events():
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestOrderTracker_PollLifecycle(t *testing.T) {
+	fetcher := &fakeOrderFetcher{orders: make(map[string]*Order)}
+	tracker := NewOrderTracker(fetcher, nil)
+	ctx := context.Background()
+
+	var filled []decimal.Decimal
+	tracker.SetCallbacks(OrderTrackerCallbacks{
+		OnPartiallyFilled: func(e OrderTrackerEvent) { filled = append(filled, e.Filled) },
+		OnFilled:          func(e OrderTrackerEvent) { filled = append(filled, e.Filled) },
+	})
+	tracker.TrackResponse(&OrderResponse{Success: true, OrderID: "o1"}, &OrderResponse{Success: false, OrderID: "bad"})
+	if _, ok := tracker.Get("bad"); ok {
+		t.Fatal("Failed response should not be tracked")
+	}
+
+	// Unknown to the server yet: no events
+	if err := tracker.Poll(ctx); err != nil {
+		t.Fatalf("Poll() error: %v", err)
+	}
+	if events := drainTracker(tracker); len(events) != 0 {
+		t.Fatalf("Expected no events, got %v", eventTypes(events))
+	}
+
+	fetcher.set(trackerOrder("o1", OrderStatusLive, "100", "0"))
+	tracker.Poll(ctx)
+	fetcher.set(trackerOrder("o1", OrderStatusLive, "100", "40"))
+	tracker.Poll(ctx)
+	tracker.Poll(ctx) // unchanged, no event
+	fetcher.set(trackerOrder("o1", OrderStatusMatched, "100", "100"))
+	tracker.Poll(ctx)
+
+	events := drainTracker(tracker)
+	want := []OrderLifecycle{OrderAccepted, OrderPartiallyFilled, OrderFilled}
+	if got := eventTypes(events); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Fatalf("events = %v, expected %v", got, want)
+	}
+	if len(filled) != 2 || !filled[0].Equal(decimal.NewFromInt(40)) || !filled[1].Equal(decimal.NewFromInt(60)) {
+		t.Errorf("filled = %v", filled)
+	}
+	last := events[2].Order
+	if last.AssetID != "token-1" || !last.SizeMatched.Equal(decimal.NewFromInt(100)) || last.State != OrderFilled {
+		t.Errorf("Unexpected final order: %+v", last)
+	}
+
+	// Final orders stop being tracked
+	if _, ok := tracker.Get("o1"); ok {
+		t.Error("Filled order should no longer be tracked")
+	}
+}
+
+func TestOrderTracker_CanceledAndExpired(t *testing.T) {
+	tracker := NewOrderTracker(nil, nil)
+	now := time.Unix(2000, 0)
+	tracker.now = func() time.Time { return now }
+	tracker.Track("c1", "e1")
+
+	var canceled, expired int
+	tracker.SetCallbacks(OrderTrackerCallbacks{
+		OnCanceled: func(OrderTrackerEvent) { canceled++ },
+		OnExpired:  func(OrderTrackerEvent) { expired++ },
+	})
+
+	tracker.Apply(OrderUpdate{OrderID: "c1", Status: OrderStatusLive, OriginalSize: decimal.NewFromInt(10)})
+	tracker.Apply(OrderUpdate{OrderID: "c1", Status: OrderStatusLive, SizeMatched: decimal.NewFromInt(3)})
+	events := tracker.Apply(OrderUpdate{OrderID: "c1", Status: OrderStatusCanceled, SizeMatched: decimal.NewFromInt(3)})
+	if len(events) != 1 || events[0].Type != OrderCanceled || !events[0].Order.SizeMatched.Equal(decimal.NewFromInt(3)) {
+		t.Fatalf("Unexpected cancel events: %+v", events)
+	}
+
+	// A GTD order canceled at or after its expiration is reported as expired
+	tracker.Apply(OrderUpdate{OrderID: "e1", Status: OrderStatusLive, Expiration: now.Add(-time.Second)})
+	events = tracker.Apply(OrderUpdate{OrderID: "e1", Status: OrderStatusCanceled})
+	if len(events) != 1 || events[0].Type != OrderExpired {
+		t.Fatalf("Unexpected expiry events: %+v", events)
+	}
+
+	if canceled != 1 || expired != 1 {
+		t.Errorf("canceled = %d, expired = %d", canceled, expired)
+	}
+	if len(tracker.Orders()) != 0 {
+		t.Errorf("Expected no tracked orders, got %d", len(tracker.Orders()))
+	}
+}
+
+func TestOrderTracker_ImmediateMatchAndStaleUpdates(t *testing.T) {
+	tracker := NewOrderTracker(nil, nil)
+	tracker.Track("o1")
+
+	// Stale update with lower size_matched does not regress state
+	tracker.Apply(OrderUpdate{OrderID: "o1", Status: OrderStatusLive, OriginalSize: decimal.NewFromInt(10), SizeMatched: decimal.NewFromInt(5)})
+	if events := tracker.Apply(OrderUpdate{OrderID: "o1", Status: OrderStatusLive, SizeMatched: decimal.NewFromInt(2)}); len(events) != 0 {
+		t.Errorf("Stale update produced events: %v", eventTypes(events))
+	}
+	order, _ := tracker.Get("o1")
+	if !order.SizeMatched.Equal(decimal.NewFromInt(5)) || order.State != OrderPartiallyFilled {
+		t.Errorf("Unexpected order: %+v", order)
+	}
+
+	// An order matched on submission emits Accepted then Filled
+	tracker.Track("o2")
+	events := tracker.Apply(OrderUpdate{OrderID: "o2", Status: OrderStatusMatched, OriginalSize: decimal.NewFromInt(10), SizeMatched: decimal.NewFromInt(10)})
+	if got := eventTypes(events); len(got) != 2 || got[0] != OrderAccepted || got[1] != OrderFilled {
+		t.Errorf("events = %v", got)
+	}
+
+	// Untracked orders are ignored
+	if events := tracker.Apply(OrderUpdate{OrderID: "other", Status: OrderStatusLive}); events != nil {
+		t.Errorf("Untracked order produced events: %v", eventTypes(events))
+	}
+}
+
+func TestOrderTracker_PollErrorsAndDrops(t *testing.T) {
+	fetcher := &fakeOrderFetcher{orders: make(map[string]*Order), err: errors.New("boom")}
+	tracker := NewOrderTracker(fetcher, &OrderTrackerConfig{ChannelSize: 1})
+	tracker.Track("o1")
+
+	if err := tracker.Poll(context.Background()); err == nil || tracker.PollErrors() != 1 {
+		t.Fatalf("Poll() err = %v, errors = %d", err, tracker.PollErrors())
+	}
+
+	fetcher.err = nil
+	fetcher.set(trackerOrder("o1", OrderStatusMatched, "10", "10"))
+	tracker.Poll(context.Background())
+	if tracker.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, expected 1", tracker.Dropped())
+	}
+}
+
+func TestOrderTracker_StartStop(t *testing.T) {
+	fetcher := &fakeOrderFetcher{orders: make(map[string]*Order)}
+	fetcher.set(trackerOrder("o1", OrderStatusCanceled, "10", "0"))
+	tracker := NewOrderTracker(fetcher, &OrderTrackerConfig{PollInterval: 10 * time.Millisecond})
+	tracker.Track("o1")
+	tracker.Start()
+	defer tracker.Stop()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case e := <-tracker.Events():
+			if e.Type == OrderCanceled {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for cancel event")
+		}
+	}
+}
+
+func TestOrderUpdateFromOrder_Expiration(t *testing.T) {
+	order := trackerOrder("o1", OrderStatusLive, "10", "0")
+	if update := OrderUpdateFromOrder(order); !update.Expiration.IsZero() {
+		t.Errorf("Expiration = %v, expected zero", update.Expiration)
+	}
+	order.Expiration = "1700000000"
+	if update := OrderUpdateFromOrder(order); !update.Expiration.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expiration = %v", update.Expiration)
+	}
+}
+
+func TestOrderTracker_RecordsFillsInClientEventLog(t *testing.T) {
+	client, err := NewClient(nil, testPrivKey)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	tracker := NewOrderTracker(client, &OrderTrackerConfig{})
+	tracker.Track("o1")
+
+	tracker.Apply(OrderUpdate{OrderID: "o1", Status: OrderStatusLive, AssetID: "token-1", Side: OrderSideSell, Price: decimal.RequireFromString("0.6"), OriginalSize: decimal.NewFromInt(10)})
+	tracker.Apply(OrderUpdate{OrderID: "o1", Status: OrderStatusLive, SizeMatched: decimal.NewFromInt(4)})
+	tracker.Apply(OrderUpdate{OrderID: "o1", Status: OrderStatusMatched, SizeMatched: decimal.NewFromInt(10)})
+
+	events := client.RecentEvents()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 fill events, got %+v", events)
+	}
+	for i, want := range []int64{4, 6} {
+		e := events[i]
+		if e.Type != TradingEventFill || e.OrderID != "o1" || e.TokenID != "token-1" || e.Side != OrderSideSell || !e.Size.Equal(decimal.NewFromInt(want)) {
+			t.Errorf("event %d = %+v", i, e)
+		}
+	}
+}
+
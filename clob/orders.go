@@ -4,10 +4,29 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // CreateOrder 创建订单
 func (c *Client) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*OrderResponse, error) {
+	// 签名前校验订单
+	if err := ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+	if err := c.checkFOKAvailability(req); err != nil {
+		return nil, err
+	}
+	if err := c.checkMarketAccepting(req.TokenID); err != nil {
+		return nil, err
+	}
+	req, err := c.applyPassive(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.throttleOrders(ctx, req.TokenID); err != nil {
+		return nil, err
+	}
+
 	if err := c.ensureCredentials(ctx); err != nil {
 		return nil, fmt.Errorf("failed to ensure credentials: %w", err)
 	}
@@ -18,18 +37,48 @@ func (c *Client) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*Ord
 		return nil, fmt.Errorf("failed to create signed order: %w", err)
 	}
 
-	// 确定订单类型
-	orderType := req.Type
-	if orderType == "" {
-		return nil, fmt.Errorf("order type is required, must be GTC/FOK/GTD/FAK")
+	return c.postOrder(ctx, req, signedOrder)
+}
+
+// CreateMarketOrder 创建市价单
+// BUY 按 USDC 金额、SELL 按份额下单，Price 为最差可接受价格，类型默认 FOK
+func (c *Client) CreateMarketOrder(ctx context.Context, req *CreateMarketOrderRequest) (*OrderResponse, error) {
+	if err := ValidateMarketOrderRequest(req); err != nil {
+		return nil, err
+	}
+	if err := c.checkMarketFOKAvailability(req); err != nil {
+		return nil, err
+	}
+	if err := c.checkMarketAccepting(req.TokenID); err != nil {
+		return nil, err
+	}
+	if err := c.throttleOrders(ctx, req.TokenID); err != nil {
+		return nil, err
 	}
 
+	if err := c.ensureCredentials(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure credentials: %w", err)
+	}
+
+	signedOrder, err := c.orderSigner.CreateSignedMarketOrder(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signed order: %w", err)
+	}
+
+	return c.postOrder(ctx, req.limitOrderView(), signedOrder)
+}
+
+// postOrder 提交已签名订单，req 用于订单类型、事件日志与市场状态记录
+func (c *Client) postOrder(ctx context.Context, req *CreateOrderRequest, signedOrder *SignedOrder) (*OrderResponse, error) {
+	orderType := req.Type
+
 	// 构建提交请求
 	// Owner 使用 API Key（与 Python SDK 一致）
 	postReq := &PostOrderRequest{
 		Order:     signedOrder,
-		Owner:     c.credentials.APIKey,
+		Owner:     c.ownerAPIKey(ctx),
 		OrderType: orderType,
+		PostOnly:  req.PostOnly,
 	}
 
 	// 序列化请求体
@@ -39,17 +88,21 @@ func (c *Client) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*Ord
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("POST", "/order", string(bodyBytes))
+	authHeaders, err := c.getL2AuthHeaders(ctx, "POST", "/order", string(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 
 	// 发送请求
+	c.recordSubmit(ctx, req, orderType)
 	var result OrderResponse
-	err = c.httpClient.DoWithAuth(ctx, "POST", "/order", postReq, authHeaders, &result)
+	err = c.writeClient.DoWithAuth(ctx, "POST", "/order", postReq, authHeaders, &result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create order: %w", err)
+		c.recordResponse(ctx, req, orderType, nil, err)
+		return nil, fmt.Errorf("failed to create order: %w", c.observeSubmitResult(req.TokenID, nil, err))
 	}
+	c.recordResponse(ctx, req, orderType, &result, nil)
+	c.observeSubmitResult(req.TokenID, &result, nil)
 
 	return &result, nil
 }
@@ -64,13 +117,45 @@ func (c *Client) CreateOrders(ctx context.Context, reqs []*CreateOrderRequest) (
 		return nil, fmt.Errorf("maximum 15 orders per batch, got %d", len(reqs))
 	}
 
+	// 签名前校验所有订单，任一不合法则整批拒绝
+	for i, req := range reqs {
+		if err := ValidateOrderRequest(req); err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		if err := c.checkFOKAvailability(req); err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		if err := c.checkMarketAccepting(req.TokenID); err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+	}
+
+	// 本地仅挂单检查，改价不修改调用者的切片
+	checked := make([]*CreateOrderRequest, len(reqs))
+	for i, req := range reqs {
+		passive, err := c.applyPassive(req)
+		if err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		checked[i] = passive
+	}
+	reqs = checked
+
+	tokenIDs := make([]string, len(reqs))
+	for i, req := range reqs {
+		tokenIDs[i] = req.TokenID
+	}
+	if err := c.throttleOrders(ctx, tokenIDs...); err != nil {
+		return nil, err
+	}
+
 	if err := c.ensureCredentials(ctx); err != nil {
 		return nil, fmt.Errorf("failed to ensure credentials: %w", err)
 	}
 
 	// 创建已签名订单
 	// Owner 使用 API Key（与 Python SDK 一致）
-	ownerKey := c.credentials.APIKey
+	ownerKey := c.ownerAPIKey(ctx)
 	postReqs := make([]*PostOrderRequest, 0, len(reqs))
 	for _, req := range reqs {
 		signedOrder, err := c.orderSigner.CreateSignedOrder(req)
@@ -78,15 +163,11 @@ func (c *Client) CreateOrders(ctx context.Context, reqs []*CreateOrderRequest) (
 			return nil, fmt.Errorf("failed to create signed order: %w", err)
 		}
 
-		orderType := req.Type
-		if orderType == "" {
-			orderType = OrderTypeGTC
-		}
-
 		postReqs = append(postReqs, &PostOrderRequest{
 			Order:     signedOrder,
 			Owner:     ownerKey,
-			OrderType: orderType,
+			OrderType: req.Type,
+			PostOnly:  req.PostOnly,
 		})
 	}
 
@@ -97,15 +178,19 @@ func (c *Client) CreateOrders(ctx context.Context, reqs []*CreateOrderRequest) (
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("POST", "/orders", string(bodyBytes))
+	authHeaders, err := c.getL2AuthHeaders(ctx, "POST", "/orders", string(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 
 	// 发送请求
+	for i, req := range reqs {
+		c.recordSubmit(ctx, req, postReqs[i].OrderType)
+	}
 	var results []*OrderResponse
-	err = c.httpClient.DoWithAuth(ctx, "POST", "/orders", postReqs, authHeaders, &results)
-	if err != nil {
+	err = c.writeClient.DoWithAuth(ctx, "POST", "/orders", postReqs, authHeaders, &results)
+	c.recordBatchResponses(ctx, reqs, postReqs, results, err)
+	if err := c.observeBatchResults(batchTokenIDs(postReqs), results, err); err != nil {
 		return nil, fmt.Errorf("failed to create orders: %w", err)
 	}
 
@@ -125,7 +210,7 @@ func (c *Client) GetOrder(ctx context.Context, orderID string) (*Order, error) {
 	path := "/data/order/" + orderID
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("GET", path, "")
+	authHeaders, err := c.getL2AuthHeaders(ctx, "GET", path, "")
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +235,7 @@ func (c *Client) GetOrders(ctx context.Context, params *OrdersQueryParams) ([]*O
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("GET", "/orders", "")
+	authHeaders, err := c.getL2AuthHeaders(ctx, "GET", "/orders", "")
 	if err != nil {
 		return nil, err
 	}
@@ -170,10 +255,24 @@ func (c *Client) GetOpenOrders(ctx context.Context) ([]*Order, error) {
 }
 
 // CancelOrder 取消单个订单
+// 订单已撤销/已成交时返回的错误包装 common.ErrOrderAlreadyCanceled，不存在时包装 common.ErrOrderNotFound；
+// Config.IdempotentCancel 开启时已不活跃的订单返回 nil。需要区分结果时使用 CancelOrderWithResult
 func (c *Client) CancelOrder(ctx context.Context, orderID string) error {
+	err := c.cancelOrder(ctx, orderID)
+	if err == nil || (c.config.IdempotentCancel && CancelStatusOf(err) == CancelStatusInactive) {
+		return nil
+	}
+	return cancelError(err)
+}
+
+// cancelOrder 发送撤单请求，返回未包装的错误
+func (c *Client) cancelOrder(ctx context.Context, orderID string) error {
 	if orderID == "" {
 		return fmt.Errorf("order ID is required")
 	}
+	if err := c.throttleCancels(ctx, []string{orderID}); err != nil {
+		return err
+	}
 
 	if err := c.ensureCredentials(ctx); err != nil {
 		return fmt.Errorf("failed to ensure credentials: %w", err)
@@ -182,17 +281,14 @@ func (c *Client) CancelOrder(ctx context.Context, orderID string) error {
 	path := "/order/" + orderID
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("DELETE", path, "")
+	authHeaders, err := c.getL2AuthHeaders(ctx, "DELETE", path, "")
 	if err != nil {
 		return err
 	}
 
-	err = c.httpClient.DoWithAuth(ctx, "DELETE", path, nil, authHeaders, nil)
-	if err != nil {
-		return fmt.Errorf("failed to cancel order: %w", err)
-	}
-
-	return nil
+	err = c.writeClient.DoWithAuth(ctx, "DELETE", path, nil, authHeaders, nil)
+	c.recordCancel(ctx, []string{orderID}, "", "", nil, err)
+	return err
 }
 
 // CancelOrders 批量取消订单
@@ -200,6 +296,9 @@ func (c *Client) CancelOrders(ctx context.Context, orderIDs []string) (*CancelRe
 	if len(orderIDs) == 0 {
 		return nil, nil
 	}
+	if err := c.throttleCancels(ctx, orderIDs); err != nil {
+		return nil, err
+	}
 
 	if err := c.ensureCredentials(ctx); err != nil {
 		return nil, fmt.Errorf("failed to ensure credentials: %w", err)
@@ -215,16 +314,18 @@ func (c *Client) CancelOrders(ctx context.Context, orderIDs []string) (*CancelRe
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("DELETE", "/orders", string(bodyBytes))
+	authHeaders, err := c.getL2AuthHeaders(ctx, "DELETE", "/orders", string(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 
 	var result CancelResponse
-	err = c.httpClient.DoWithAuth(ctx, "DELETE", "/orders", body, authHeaders, &result)
+	err = c.writeClient.DoWithAuth(ctx, "DELETE", "/orders", body, authHeaders, &result)
 	if err != nil {
+		c.recordCancel(ctx, orderIDs, "", "", nil, err)
 		return nil, fmt.Errorf("failed to cancel orders: %w", err)
 	}
+	c.recordCancel(ctx, orderIDs, "", "", &result, nil)
 
 	return &result, nil
 }
@@ -234,6 +335,12 @@ func (c *Client) CancelOrdersByMarket(ctx context.Context, marketID string) (*Ca
 	if marketID == "" {
 		return nil, fmt.Errorf("market ID is required")
 	}
+	if err := c.checkClaimedMarket(marketID); err != nil {
+		return nil, err
+	}
+	if err := c.throttle.acquire(ctx, throttleCancels, []string{marketID}); err != nil {
+		return nil, err
+	}
 
 	if err := c.ensureCredentials(ctx); err != nil {
 		return nil, fmt.Errorf("failed to ensure credentials: %w", err)
@@ -249,16 +356,18 @@ func (c *Client) CancelOrdersByMarket(ctx context.Context, marketID string) (*Ca
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("DELETE", "/orders", string(bodyBytes))
+	authHeaders, err := c.getL2AuthHeaders(ctx, "DELETE", "/orders", string(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 
 	var result CancelResponse
-	err = c.httpClient.DoWithAuth(ctx, "DELETE", "/orders", body, authHeaders, &result)
+	err = c.writeClient.DoWithAuth(ctx, "DELETE", "/orders", body, authHeaders, &result)
 	if err != nil {
+		c.recordCancel(ctx, nil, marketID, "", nil, err)
 		return nil, fmt.Errorf("failed to cancel orders by market: %w", err)
 	}
+	c.recordCancel(ctx, nil, marketID, "", &result, nil)
 
 	return &result, nil
 }
@@ -268,6 +377,12 @@ func (c *Client) CancelOrdersByAsset(ctx context.Context, assetID string) (*Canc
 	if assetID == "" {
 		return nil, fmt.Errorf("asset ID is required")
 	}
+	if err := c.checkClaimedTokens(assetID); err != nil {
+		return nil, err
+	}
+	if err := c.throttle.acquire(ctx, throttleCancels, []string{c.throttle.marketKey(assetID)}); err != nil {
+		return nil, err
+	}
 
 	if err := c.ensureCredentials(ctx); err != nil {
 		return nil, fmt.Errorf("failed to ensure credentials: %w", err)
@@ -283,16 +398,18 @@ func (c *Client) CancelOrdersByAsset(ctx context.Context, assetID string) (*Canc
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("DELETE", "/orders", string(bodyBytes))
+	authHeaders, err := c.getL2AuthHeaders(ctx, "DELETE", "/orders", string(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 
 	var result CancelResponse
-	err = c.httpClient.DoWithAuth(ctx, "DELETE", "/orders", body, authHeaders, &result)
+	err = c.writeClient.DoWithAuth(ctx, "DELETE", "/orders", body, authHeaders, &result)
 	if err != nil {
+		c.recordCancel(ctx, nil, "", assetID, nil, err)
 		return nil, fmt.Errorf("failed to cancel orders by asset: %w", err)
 	}
+	c.recordCancel(ctx, nil, "", assetID, &result, nil)
 
 	return &result, nil
 }
@@ -304,12 +421,13 @@ func (c *Client) CancelAllOrders(ctx context.Context) error {
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("DELETE", "/cancel-all", "")
+	authHeaders, err := c.getL2AuthHeaders(ctx, "DELETE", "/cancel-all", "")
 	if err != nil {
 		return err
 	}
 
-	err = c.httpClient.DoWithAuth(ctx, "DELETE", "/cancel-all", nil, authHeaders, nil)
+	err = c.writeClient.DoWithAuth(ctx, "DELETE", "/cancel-all", nil, authHeaders, nil)
+	c.recordCancel(ctx, nil, "", "", nil, err)
 	if err != nil {
 		return fmt.Errorf("failed to cancel all orders: %w", err)
 	}
@@ -322,35 +440,37 @@ type PreSignedOrder struct {
 	SignedOrder *SignedOrder        // 已签名的订单
 	PostRequest *PostOrderRequest   // 提交请求体
 	Request     *CreateOrderRequest // 原始请求（用于参考）
+	CreatedAt   time.Time           // 签名时间
 }
 
 // CreatePreSignedOrder 创建预签名订单（不提交）
 // 返回预签名订单，可以在之后快速提交
 func (c *Client) CreatePreSignedOrder(req *CreateOrderRequest) (*PreSignedOrder, error) {
+	// 签名前校验订单
+	if err := ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
 	// 创建已签名订单
 	signedOrder, err := c.orderSigner.CreateSignedOrder(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create signed order: %w", err)
 	}
 
-	// 确定订单类型
-	orderType := req.Type
-	if orderType == "" {
-		orderType = OrderTypeGTC
-	}
-
 	// 构建提交请求
 	// Owner 使用 funder 地址（代理钱包模式）或签名者地址（EOA 模式）
 	postReq := &PostOrderRequest{
 		Order:     signedOrder,
 		Owner:     c.GetFunderAddress(),
-		OrderType: orderType,
+		OrderType: req.Type,
+		PostOnly:  req.PostOnly,
 	}
 
 	return &PreSignedOrder{
 		SignedOrder: signedOrder,
 		PostRequest: postReq,
 		Request:     req,
+		CreatedAt:   time.Now(),
 	}, nil
 }
 
@@ -360,6 +480,17 @@ func (c *Client) SubmitPreSignedOrder(ctx context.Context, preSignedOrder *PreSi
 	if preSignedOrder == nil || preSignedOrder.PostRequest == nil {
 		return nil, fmt.Errorf("invalid pre-signed order")
 	}
+	tokenID := postTokenID(preSignedOrder.PostRequest)
+	if err := c.checkMarketAccepting(tokenID); err != nil {
+		return nil, err
+	}
+	// 签名时的订单簿可能已变化，提交前按当前订单簿检查 FOK
+	if err := c.checkFOKAvailability(preSignedOrder.Request); err != nil {
+		return nil, err
+	}
+	if err := c.throttleOrders(ctx, tokenID); err != nil {
+		return nil, err
+	}
 
 	if err := c.ensureCredentials(ctx); err != nil {
 		return nil, fmt.Errorf("failed to ensure credentials: %w", err)
@@ -372,17 +503,22 @@ func (c *Client) SubmitPreSignedOrder(ctx context.Context, preSignedOrder *PreSi
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("POST", "/order", string(bodyBytes))
+	authHeaders, err := c.getL2AuthHeaders(ctx, "POST", "/order", string(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 
 	// 发送请求
+	orderType := preSignedOrder.PostRequest.OrderType
+	c.recordSubmit(ctx, preSignedOrder.Request, orderType)
 	var result OrderResponse
-	err = c.httpClient.DoWithAuth(ctx, "POST", "/order", preSignedOrder.PostRequest, authHeaders, &result)
+	err = c.writeClient.DoWithAuth(ctx, "POST", "/order", preSignedOrder.PostRequest, authHeaders, &result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to submit pre-signed order: %w", err)
+		c.recordResponse(ctx, preSignedOrder.Request, orderType, nil, err)
+		return nil, fmt.Errorf("failed to submit pre-signed order: %w", c.observeSubmitResult(tokenID, nil, err))
 	}
+	c.recordResponse(ctx, preSignedOrder.Request, orderType, &result, nil)
+	c.observeSubmitResult(tokenID, &result, nil)
 
 	return &result, nil
 }
@@ -425,8 +561,14 @@ func (c *Client) SubmitPreSignedOrders(ctx context.Context, preSignedOrders []*P
 		if preSignedOrder == nil || preSignedOrder.PostRequest == nil {
 			return nil, fmt.Errorf("invalid pre-signed order in batch")
 		}
+		if err := c.checkMarketAccepting(postTokenID(preSignedOrder.PostRequest)); err != nil {
+			return nil, err
+		}
 		postReqs = append(postReqs, preSignedOrder.PostRequest)
 	}
+	if err := c.throttleOrders(ctx, batchTokenIDs(postReqs)...); err != nil {
+		return nil, err
+	}
 
 	// 序列化请求体
 	bodyBytes, err := json.Marshal(postReqs)
@@ -435,15 +577,21 @@ func (c *Client) SubmitPreSignedOrders(ctx context.Context, preSignedOrders []*P
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("POST", "/orders", string(bodyBytes))
+	authHeaders, err := c.getL2AuthHeaders(ctx, "POST", "/orders", string(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 
 	// 发送请求
+	reqs := make([]*CreateOrderRequest, len(preSignedOrders))
+	for i, preSignedOrder := range preSignedOrders {
+		reqs[i] = preSignedOrder.Request
+		c.recordSubmit(ctx, reqs[i], postReqs[i].OrderType)
+	}
 	var results []*OrderResponse
-	err = c.httpClient.DoWithAuth(ctx, "POST", "/orders", postReqs, authHeaders, &results)
-	if err != nil {
+	err = c.writeClient.DoWithAuth(ctx, "POST", "/orders", postReqs, authHeaders, &results)
+	c.recordBatchResponses(ctx, reqs, postReqs, results, err)
+	if err := c.observeBatchResults(batchTokenIDs(postReqs), results, err); err != nil {
 		return nil, fmt.Errorf("failed to submit pre-signed orders: %w", err)
 	}
 
@@ -45,8 +45,8 @@ func setupTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest
 
 func TestGetOrder(t *testing.T) {
 	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/order/order-123" {
-			t.Errorf("Expected path /order/order-123, got %s", r.URL.Path)
+		if r.URL.Path != "/data/order/order-123" {
+			t.Errorf("Expected path /data/order/order-123, got %s", r.URL.Path)
 		}
 		if r.Method != http.MethodGet {
 			t.Errorf("Expected GET method, got %s", r.Method)
@@ -366,3 +366,36 @@ func TestCreateOrdersTooMany(t *testing.T) {
 		t.Error("CreateOrders() should fail with more than 15 orders")
 	}
 }
+
+func TestCreateMarketOrder(t *testing.T) {
+	var posted PostOrderRequest
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(OrderResponse{Success: true, OrderID: "0xmarket", Status: "matched"})
+	})
+	defer server.Close()
+
+	resp, err := client.CreateMarketOrder(context.Background(), &CreateMarketOrderRequest{
+		TokenID: "12345",
+		Side:    OrderSideBuy,
+		Amount:  decimal.NewFromInt(25),
+		Price:   decimal.RequireFromString("0.4"),
+	})
+	if err != nil {
+		t.Fatalf("CreateMarketOrder() error: %v", err)
+	}
+	if resp.OrderID != "0xmarket" {
+		t.Errorf("OrderID = %s", resp.OrderID)
+	}
+
+	// 25 USDC at worst price 0.4 -> 62.5 shares
+	if posted.OrderType != OrderTypeFOK || posted.Order.Side != "BUY" {
+		t.Errorf("posted type/side = %s/%s", posted.OrderType, posted.Order.Side)
+	}
+	if posted.Order.MakerAmount != "25000000" || posted.Order.TakerAmount != "62500000" {
+		t.Errorf("posted amounts = %s/%s, expected 25000000/62500000", posted.Order.MakerAmount, posted.Order.TakerAmount)
+	}
+}
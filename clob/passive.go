@@ -0,0 +1,93 @@
+package clob
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// defaultPassiveTick 未配置 TickSize 时改价使用的最小变动单位
+var defaultPassiveTick = decimal.RequireFromString("0.01")
+
+// PassiveConfig 本地模拟仅挂单（post-only）配置
+// 对 Passive 或 PostOnly 的 GTC/GTD 订单，提交前用本地订单簿的最优价判断是否会立即成交：
+// 买单价格 >= 最优卖价、卖单价格 <= 最优买价视为会吃单，按 Reprice 拒绝或改价到对手价内一个 tick
+type PassiveConfig struct {
+	// Quotes 本地最优买卖价，无挂单的一侧返回零值；ok 为 false 表示订单簿未就绪（如未订阅或未初始化）
+	Quotes func(tokenID string) (bid, ask decimal.Decimal, ok bool)
+	// TickSize token 的最小价格变动单位，nil 或返回非正数时使用 0.01
+	TickSize func(tokenID string) decimal.Decimal
+	// Reprice 会吃单时改价为买：最优卖价 - tick、卖：最优买价 + tick；false 时返回 ErrOrderWouldCross
+	Reprice bool
+	// RequireQuotes 订单簿未就绪时拒绝订单；默认放行（交易所仍会按 PostOnly 标识校验）
+	RequireQuotes bool
+}
+
+// SetPassive 设置本地仅挂单检查，nil 表示关闭
+func (c *Client) SetPassive(config *PassiveConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.passive = config
+}
+
+// passiveConfig 当前的本地仅挂单检查配置
+func (c *Client) passiveConfig() *PassiveConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.passive
+}
+
+// applyPassive 按本地订单簿检查仅挂单订单，需要改价时返回改价后的副本，不修改调用者的请求
+func (c *Client) applyPassive(req *CreateOrderRequest) (*CreateOrderRequest, error) {
+	config := c.passiveConfig()
+	if config == nil || config.Quotes == nil || (!req.Passive && !req.PostOnly) {
+		return req, nil
+	}
+	if req.Type == OrderTypeFOK || req.Type == OrderTypeFAK {
+		return req, nil
+	}
+
+	bid, ask, ok := config.Quotes(req.TokenID)
+	if !ok {
+		if config.RequireQuotes {
+			return nil, fmt.Errorf("%w: local book for token %s is not ready", common.ErrOrderWouldCross, req.TokenID)
+		}
+		return req, nil
+	}
+
+	tick := defaultPassiveTick
+	if config.TickSize != nil {
+		if t := config.TickSize(req.TokenID); t.IsPositive() {
+			tick = t
+		}
+	}
+
+	var price decimal.Decimal
+	switch req.Side {
+	case OrderSideBuy:
+		if !ask.IsPositive() || req.Price.LessThan(ask) {
+			return req, nil
+		}
+		price = ask.Sub(tick)
+	case OrderSideSell:
+		if !bid.IsPositive() || req.Price.GreaterThan(bid) {
+			return req, nil
+		}
+		price = bid.Add(tick)
+	default:
+		return req, nil
+	}
+
+	if !config.Reprice {
+		return nil, fmt.Errorf("%w: %s %s @ %s against bid %s / ask %s", common.ErrOrderWouldCross, req.Side, req.TokenID, req.Price, bid, ask)
+	}
+	if !price.IsPositive() || price.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return nil, fmt.Errorf("%w: no passive price for %s %s inside bid %s / ask %s", common.ErrOrderWouldCross, req.Side, req.TokenID, bid, ask)
+	}
+
+	repriced := *req
+	repriced.Price = price
+	return &repriced, nil
+}
@@ -0,0 +1,111 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestClient_PassiveRejectsCrossingOrders(t *testing.T) {
+	posted := 0
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		posted++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"orderID":"order-1","status":"live"}`))
+	})
+	defer server.Close()
+	ctx := context.Background()
+
+	client.SetPassive(&PassiveConfig{
+		Quotes: func(tokenID string) (decimal.Decimal, decimal.Decimal, bool) {
+			return decimal.RequireFromString("0.48"), decimal.RequireFromString("0.50"), tokenID == "12345"
+		},
+	})
+
+	order := func(side OrderSide, price string) *CreateOrderRequest {
+		return &CreateOrderRequest{
+			TokenID: "12345",
+			Side:    side,
+			Price:   decimal.RequireFromString(price),
+			Size:    decimal.NewFromInt(10),
+			Type:    OrderTypeGTC,
+			Passive: true,
+		}
+	}
+
+	if _, err := client.CreateOrder(ctx, order(OrderSideBuy, "0.50")); !errors.Is(err, common.ErrOrderWouldCross) {
+		t.Errorf("buy at the ask: expected ErrOrderWouldCross, got %v", err)
+	}
+	if _, err := client.CreateOrders(ctx, []*CreateOrderRequest{order(OrderSideBuy, "0.47"), order(OrderSideSell, "0.45")}); !errors.Is(err, common.ErrOrderWouldCross) {
+		t.Errorf("crossing sell in batch: expected ErrOrderWouldCross, got %v", err)
+	}
+	if posted != 0 {
+		t.Fatalf("rejected orders should not be posted, got %d requests", posted)
+	}
+
+	// Resting orders, non-passive orders and tokens without a local book pass through
+	if _, err := client.CreateOrder(ctx, order(OrderSideSell, "0.49")); err != nil {
+		t.Errorf("resting sell: %v", err)
+	}
+	aggressive := order(OrderSideBuy, "0.55")
+	aggressive.Passive = false
+	if _, err := client.CreateOrder(ctx, aggressive); err != nil {
+		t.Errorf("non-passive order: %v", err)
+	}
+	unknown := order(OrderSideBuy, "0.55")
+	unknown.TokenID = "67890"
+	if _, err := client.CreateOrder(ctx, unknown); err != nil {
+		t.Errorf("token without a book: %v", err)
+	}
+	if posted != 3 {
+		t.Errorf("expected 3 posted orders, got %d", posted)
+	}
+}
+
+func TestClient_PassiveReprices(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"orderID":"order-1","status":"live"}`))
+	})
+	defer server.Close()
+
+	client.SetPassive(&PassiveConfig{
+		Quotes: func(string) (decimal.Decimal, decimal.Decimal, bool) {
+			return decimal.RequireFromString("0.48"), decimal.RequireFromString("0.50"), true
+		},
+		TickSize: func(string) decimal.Decimal { return decimal.RequireFromString("0.001") },
+		Reprice:  true,
+	})
+
+	req := &CreateOrderRequest{
+		TokenID:  "12345",
+		Side:     OrderSideBuy,
+		Price:    decimal.RequireFromString("0.52"),
+		Size:     decimal.NewFromInt(10),
+		Type:     OrderTypeGTC,
+		PostOnly: true,
+	}
+	if _, err := client.CreateOrder(context.Background(), req); err != nil {
+		t.Fatalf("CreateOrder() error: %v", err)
+	}
+	if !req.Price.Equal(decimal.RequireFromString("0.52")) {
+		t.Errorf("caller request should not be modified, price = %s", req.Price)
+	}
+	events := client.RecentEvents()
+	if len(events) == 0 || !events[0].Price.Equal(decimal.RequireFromString("0.499")) {
+		t.Errorf("expected order repriced to 0.499, events = %+v", events)
+	}
+
+	// FOK/FAK cannot be passive
+	req.Type = OrderTypeFOK
+	req.PostOnly = false
+	req.Passive = true
+	if _, err := client.CreateOrder(context.Background(), req); !errors.Is(err, common.ErrInvalidOrderType) {
+		t.Errorf("expected ErrInvalidOrderType, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package clob
+
+import (
+	"context"
+	"sort"
+)
+
+// GetPositions 获取当前持仓（Size 为份额）
+// CLOB 没有持仓接口：从成交历史收集交易过的 token（含作为 maker 成交的 token），再批量查询条件代币余额，
+// 余额为 0 的 token 不返回；通过 split/merge 或转账获得、从未成交过的 token 不会出现在结果中
+func (c *Client) GetPositions(ctx context.Context, opts *BalancesQueryOptions) ([]*Position, error) {
+	trades, err := c.GetTrades(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string]*Position)
+	add := func(tokenID, market, outcome string) {
+		if tokenID == "" {
+			return
+		}
+		if _, ok := candidates[tokenID]; !ok {
+			candidates[tokenID] = &Position{TokenID: tokenID, MarketID: market, Outcome: outcome}
+		}
+	}
+	for _, trade := range trades {
+		add(trade.AssetID, trade.Market, trade.Outcome)
+		for _, maker := range trade.MakerOrders {
+			add(maker.AssetID, trade.Market, maker.Outcome)
+		}
+	}
+
+	tokenIDs := make([]string, 0, len(candidates))
+	for tokenID := range candidates {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Strings(tokenIDs)
+
+	balances, err := c.GetConditionalBalances(ctx, tokenIDs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]*Position, 0, len(balances))
+	for _, tokenID := range tokenIDs {
+		balance, ok := balances[tokenID]
+		if !ok || !balance.Balance.IsPositive() {
+			continue
+		}
+		pos := candidates[tokenID]
+		// 余额以最小单位返回，条件代币与 USDC 同为 6 位精度
+		pos.Size = balance.Balance.Shift(-USDCDecimals)
+		positions = append(positions, pos)
+	}
+	return positions, nil
+}
@@ -0,0 +1,51 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestGetPositions(t *testing.T) {
+	balances := map[string]int64{"yes-1": 12500000, "no-1": 0, "yes-2": 3000000}
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/trades":
+			json.NewEncoder(w).Encode(TradesResponse{
+				NextCursor: EndCursor,
+				Data: []*Trade{
+					{ID: "t1", Market: "cond-1", AssetID: "yes-1", Outcome: "Yes"},
+					{ID: "t2", Market: "cond-1", AssetID: "yes-1", Outcome: "Yes",
+						MakerOrders: []MakerOrder{{AssetID: "no-1", Outcome: "No"}}},
+					{ID: "t3", Market: "cond-2", AssetID: "other", MakerOrders: []MakerOrder{{AssetID: "yes-2", Outcome: "Yes"}}},
+				},
+			})
+		case "/balance-allowance":
+			tokenID := r.URL.Query().Get("token_id")
+			json.NewEncoder(w).Encode(BalanceAllowance{Balance: decimal.NewFromInt(balances[tokenID])})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	positions, err := client.GetPositions(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetPositions() error: %v", err)
+	}
+	if len(positions) != 2 {
+		t.Fatalf("expected 2 positions, got %d", len(positions))
+	}
+
+	// Sorted by token ID: yes-1, yes-2
+	if p := positions[0]; p.TokenID != "yes-1" || p.MarketID != "cond-1" || p.Outcome != "Yes" || !p.Size.Equal(decimal.RequireFromString("12.5")) {
+		t.Errorf("unexpected position: %+v", p)
+	}
+	if p := positions[1]; p.TokenID != "yes-2" || p.MarketID != "cond-2" || !p.Size.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("maker-side token should be tracked: %+v", p)
+	}
+}
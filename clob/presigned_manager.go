@@ -0,0 +1,308 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// PreSignedManagerConfig 预签名订单管理器配置
+type PreSignedManagerConfig struct {
+	PoolSize        int           // 每个 (token, side, price) 预签名订单数量
+	MaxAge          time.Duration // 非 GTD 订单的最长持有时间，超过后重新签名（salt 刷新）
+	GTDLifetime     time.Duration // GTD 订单的有效期，签名时 expiration = now + GTDLifetime
+	RefreshMargin   time.Duration // 在过期前多久刷新
+	RefreshInterval time.Duration // 后台检查间隔
+}
+
+// DefaultPreSignedManagerConfig 默认配置
+func DefaultPreSignedManagerConfig() *PreSignedManagerConfig {
+	return &PreSignedManagerConfig{
+		PoolSize:        2,
+		MaxAge:          10 * time.Minute,
+		GTDLifetime:     10 * time.Minute,
+		RefreshMargin:   time.Minute,
+		RefreshInterval: 5 * time.Second,
+	}
+}
+
+// preSignedKey 预签名池键
+type preSignedKey struct {
+	tokenID string
+	side    OrderSide
+	price   string
+}
+
+func newPreSignedKey(tokenID string, side OrderSide, price decimal.Decimal) preSignedKey {
+	return preSignedKey{tokenID: tokenID, side: side, price: price.String()}
+}
+
+// pooledOrder 池中的预签名订单
+type pooledOrder struct {
+	order   *PreSignedOrder
+	staleAt time.Time // 到达此时间（减去 RefreshMargin）后不再发放
+}
+
+// preSignedPool 单个模板的预签名订单池
+type preSignedPool struct {
+	template CreateOrderRequest
+	orders   []*pooledOrder
+}
+
+// PreSignedOrderManager 预签名订单管理器
+// 按 (token, side, price) 维护预签名订单池，后台在过期前重新签名，
+// 策略触发时通过 Take 直接取出可提交的订单，省去签名耗时
+type PreSignedOrderManager struct {
+	mu sync.Mutex
+
+	client *Client
+	config *PreSignedManagerConfig
+	pools  map[preSignedKey]*preSignedPool
+
+	refillChan chan struct{}
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// NewPreSignedOrderManager 创建预签名订单管理器
+func NewPreSignedOrderManager(client *Client, config *PreSignedManagerConfig) *PreSignedOrderManager {
+	if config == nil {
+		config = DefaultPreSignedManagerConfig()
+	}
+
+	return &PreSignedOrderManager{
+		client:     client,
+		config:     config,
+		pools:      make(map[preSignedKey]*preSignedPool),
+		refillChan: make(chan struct{}, 1),
+	}
+}
+
+// Register 注册订单模板并立即填满对应的预签名池
+// 同一 (token, side, price) 重复注册会替换模板并丢弃旧订单
+func (m *PreSignedOrderManager) Register(req *CreateOrderRequest) error {
+	if req == nil {
+		return fmt.Errorf("order request is nil")
+	}
+
+	template := *req
+	if err := ValidateOrderRequest(m.prepare(template)); err != nil {
+		return err
+	}
+
+	key := newPreSignedKey(template.TokenID, template.Side, template.Price)
+	pool := &preSignedPool{template: template}
+
+	m.mu.Lock()
+	m.pools[key] = pool
+	m.mu.Unlock()
+
+	return m.fill(key, pool)
+}
+
+// Unregister 移除订单模板及其预签名订单
+func (m *PreSignedOrderManager) Unregister(tokenID string, side OrderSide, price decimal.Decimal) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pools, newPreSignedKey(tokenID, side, price))
+}
+
+// Take 取出一个可直接提交的预签名订单
+// 池中无可用订单时同步签名一个（慢路径）；取出后在后台补充
+func (m *PreSignedOrderManager) Take(tokenID string, side OrderSide, price decimal.Decimal) (*PreSignedOrder, error) {
+	key := newPreSignedKey(tokenID, side, price)
+	now := time.Now()
+
+	m.mu.Lock()
+	pool, ok := m.pools[key]
+	if !ok {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("no pre-signed template for token %s %s @ %s", tokenID, side, key.price)
+	}
+
+	var order *PreSignedOrder
+	for len(pool.orders) > 0 {
+		p := pool.orders[0]
+		pool.orders = pool.orders[1:]
+		if m.isFresh(p, now) {
+			order = p.order
+			break
+		}
+	}
+	template := pool.template
+	m.mu.Unlock()
+
+	m.triggerRefill()
+
+	if order != nil {
+		return order, nil
+	}
+
+	p, err := m.sign(template)
+	if err != nil {
+		return nil, err
+	}
+	return p.order, nil
+}
+
+// Ready 获取指定 (token, side, price) 当前可用的预签名订单数
+func (m *PreSignedOrderManager) Ready(tokenID string, side OrderSide, price decimal.Decimal) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pool, ok := m.pools[newPreSignedKey(tokenID, side, price)]
+	if !ok {
+		return 0
+	}
+
+	now := time.Now()
+	count := 0
+	for _, p := range pool.orders {
+		if m.isFresh(p, now) {
+			count++
+		}
+	}
+	return count
+}
+
+// Start 启动后台刷新
+func (m *PreSignedOrderManager) Start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		common.Supervise(ctx, "clob.presigned", nil, m.refreshLoop)
+	}()
+}
+
+// Stop 停止后台刷新
+func (m *PreSignedOrderManager) Stop() {
+	m.mu.Lock()
+	cancel := m.cancel
+	m.cancel = nil
+	m.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		m.wg.Wait()
+	}
+}
+
+// Refresh 丢弃即将过期的订单并补满所有池
+func (m *PreSignedOrderManager) Refresh() error {
+	now := time.Now()
+
+	m.mu.Lock()
+	pools := make(map[preSignedKey]*preSignedPool, len(m.pools))
+	for key, pool := range m.pools {
+		fresh := pool.orders[:0]
+		for _, p := range pool.orders {
+			if m.isFresh(p, now) {
+				fresh = append(fresh, p)
+			}
+		}
+		pool.orders = fresh
+		pools[key] = pool
+	}
+	m.mu.Unlock()
+
+	var firstErr error
+	for key, pool := range pools {
+		if err := m.fill(key, pool); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// refreshLoop 后台刷新循环
+func (m *PreSignedOrderManager) refreshLoop(ctx context.Context) {
+	ticker := time.NewTicker(m.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.refillChan:
+		}
+		m.Refresh()
+	}
+}
+
+// triggerRefill 通知后台补充（非阻塞）
+func (m *PreSignedOrderManager) triggerRefill() {
+	select {
+	case m.refillChan <- struct{}{}:
+	default:
+	}
+}
+
+// fill 将池补满到 PoolSize（签名在锁外进行）
+func (m *PreSignedOrderManager) fill(key preSignedKey, pool *preSignedPool) error {
+	for {
+		m.mu.Lock()
+		// 模板已被移除或替换，或池已满
+		if m.pools[key] != pool || len(pool.orders) >= m.config.PoolSize {
+			m.mu.Unlock()
+			return nil
+		}
+		template := pool.template
+		m.mu.Unlock()
+
+		p, err := m.sign(template)
+		if err != nil {
+			return err
+		}
+
+		m.mu.Lock()
+		if m.pools[key] == pool && len(pool.orders) < m.config.PoolSize {
+			pool.orders = append(pool.orders, p)
+		}
+		m.mu.Unlock()
+	}
+}
+
+// prepare 根据模板生成本次签名的请求（GTD 订单设置过期时间）
+func (m *PreSignedOrderManager) prepare(template CreateOrderRequest) *CreateOrderRequest {
+	req := template
+	if req.Type == OrderTypeGTD {
+		req.ExpiresAt = time.Now().Add(m.config.GTDLifetime).Unix()
+	}
+	return &req
+}
+
+// sign 按模板签名一个订单并计算其失效时间
+func (m *PreSignedOrderManager) sign(template CreateOrderRequest) (*pooledOrder, error) {
+	req := m.prepare(template)
+	order, err := m.client.CreatePreSignedOrder(req)
+	if err != nil {
+		return nil, err
+	}
+
+	staleAt := order.CreatedAt.Add(m.config.MaxAge)
+	if req.ExpiresAt > 0 {
+		staleAt = time.Unix(req.ExpiresAt, 0)
+	}
+	return &pooledOrder{order: order, staleAt: staleAt}, nil
+}
+
+// isFresh 订单在刷新窗口之外仍可发放
+func (m *PreSignedOrderManager) isFresh(p *pooledOrder, now time.Time) bool {
+	return now.Before(p.staleAt.Add(-m.config.RefreshMargin))
+}
@@ -0,0 +1,133 @@
+package clob
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func newTestPreSignedManager(t *testing.T, config *PreSignedManagerConfig) *PreSignedOrderManager {
+	t.Helper()
+
+	client, err := NewClient(nil, testPrivKey)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	return NewPreSignedOrderManager(client, config)
+}
+
+func TestPreSignedOrderManagerRegisterAndTake(t *testing.T) {
+	m := newTestPreSignedManager(t, nil)
+	price := decimal.RequireFromString("0.55")
+
+	req := validOrderRequest()
+	if err := m.Register(req); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	if got := m.Ready(req.TokenID, req.Side, price); got != 2 {
+		t.Fatalf("Ready() = %d, expected 2", got)
+	}
+
+	first, err := m.Take(req.TokenID, req.Side, decimal.RequireFromString("0.550"))
+	if err != nil {
+		t.Fatalf("Take() error: %v", err)
+	}
+	second, _ := m.Take(req.TokenID, req.Side, price)
+	if first.SignedOrder.Salt == second.SignedOrder.Salt {
+		t.Error("pre-signed orders should have distinct salts")
+	}
+	if got := m.Ready(req.TokenID, req.Side, price); got != 0 {
+		t.Errorf("Ready() = %d, expected 0 after taking all", got)
+	}
+
+	// Empty pool falls back to signing synchronously
+	third, err := m.Take(req.TokenID, req.Side, price)
+	if err != nil || third == nil {
+		t.Fatalf("Take() on empty pool error: %v", err)
+	}
+
+	if err := m.Refresh(); err != nil {
+		t.Fatalf("Refresh() error: %v", err)
+	}
+	if got := m.Ready(req.TokenID, req.Side, price); got != 2 {
+		t.Errorf("Ready() = %d, expected 2 after Refresh", got)
+	}
+}
+
+func TestPreSignedOrderManagerUnknownTemplate(t *testing.T) {
+	m := newTestPreSignedManager(t, nil)
+	if _, err := m.Take("missing", OrderSideBuy, decimal.RequireFromString("0.5")); err == nil {
+		t.Error("Take() should fail for unregistered template")
+	}
+}
+
+func TestPreSignedOrderManagerRegisterInvalid(t *testing.T) {
+	m := newTestPreSignedManager(t, nil)
+	req := validOrderRequest()
+	req.Type = OrderTypeFOK
+	req.PostOnly = true
+	if err := m.Register(req); err == nil {
+		t.Error("Register() should validate the template")
+	}
+}
+
+func TestPreSignedOrderManagerGTDExpiration(t *testing.T) {
+	config := DefaultPreSignedManagerConfig()
+	config.GTDLifetime = 30 * time.Minute
+	m := newTestPreSignedManager(t, config)
+
+	req := validOrderRequest()
+	req.Type = OrderTypeGTD
+	if err := m.Register(req); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	order, err := m.Take(req.TokenID, req.Side, req.Price)
+	if err != nil {
+		t.Fatalf("Take() error: %v", err)
+	}
+	expiresAt := order.Request.ExpiresAt
+	if expiresAt < time.Now().Add(29*time.Minute).Unix() || expiresAt > time.Now().Add(31*time.Minute).Unix() {
+		t.Errorf("ExpiresAt = %d, expected about 30 minutes from now", expiresAt)
+	}
+}
+
+func TestPreSignedOrderManagerStaleOrdersReplaced(t *testing.T) {
+	config := DefaultPreSignedManagerConfig()
+	config.MaxAge = time.Minute
+	config.RefreshMargin = time.Minute // orders are stale immediately
+	m := newTestPreSignedManager(t, config)
+
+	req := validOrderRequest()
+	if err := m.Register(req); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	if got := m.Ready(req.TokenID, req.Side, req.Price); got != 0 {
+		t.Errorf("Ready() = %d, stale orders should not be counted", got)
+	}
+}
+
+func TestPreSignedOrderManagerBackgroundRefill(t *testing.T) {
+	config := DefaultPreSignedManagerConfig()
+	config.RefreshInterval = time.Hour
+	m := newTestPreSignedManager(t, config)
+	m.Start()
+	defer m.Stop()
+
+	req := validOrderRequest()
+	if err := m.Register(req); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	if _, err := m.Take(req.TokenID, req.Side, req.Price); err != nil {
+		t.Fatalf("Take() error: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for m.Ready(req.TokenID, req.Side, req.Price) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("pool was not refilled in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"sync/atomic"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/shopspring/decimal"
@@ -21,6 +22,23 @@ type OrderSigner struct {
 	negRiskAdapter  string // NegRisk 适配器合约
 	funderAddress   string // 代理钱包地址（持有资金）
 	signatureType   int    // 签名类型: 0=EOA, 1=POLY_PROXY, 2=GNOSIS_SAFE
+
+	saltSource  SaltSource  // nil 时使用随机 salt
+	nonceSource NonceSource // 请求未指定 Nonce 时使用，nil 时为 0
+}
+
+// SaltSource 订单 salt 来源，结果必须是非负 int64 范围内的整数
+type SaltSource func() (*big.Int, error)
+
+// NonceSource 订单 nonce 来源
+type NonceSource func() (*big.Int, error)
+
+// SequenceSource 从 start 开始每次调用加 1 的数值来源，并发安全，可用作 SaltSource 或 NonceSource
+func SequenceSource(start int64) func() (*big.Int, error) {
+	next := start - 1
+	return func() (*big.Int, error) {
+		return big.NewInt(atomic.AddInt64(&next, 1)), nil
+	}
 }
 
 // NewOrderSigner 创建订单签名器
@@ -45,6 +63,17 @@ func (s *OrderSigner) SetSignatureType(sigType int) {
 	s.signatureType = sigType
 }
 
+// SetSaltSource 设置 salt 来源，nil 恢复为随机 salt
+// 测试中注入固定的 salt 可生成逐字节一致的签名订单，用于与 Python SDK 生成的样例比对签名
+func (s *OrderSigner) SetSaltSource(source SaltSource) {
+	s.saltSource = source
+}
+
+// SetNonceSource 设置请求未指定 Nonce 时的 nonce 来源，nil 恢复为 0
+func (s *OrderSigner) SetNonceSource(source NonceSource) {
+	s.nonceSource = source
+}
+
 // GetMakerAddress 获取 Maker 地址（如果设置了 funder 则返回 funder，否则返回签名者地址）
 // 返回 checksum 格式的地址
 func (s *OrderSigner) GetMakerAddress() string {
@@ -60,8 +89,22 @@ func (s *OrderSigner) GetMakerAddress() string {
 
 // CreateSignedOrder 创建已签名订单
 func (s *OrderSigner) CreateSignedOrder(req *CreateOrderRequest) (*SignedOrder, error) {
+	// 计算 makerAmount 和 takerAmount
+	makerAmount, takerAmount := s.calculateAmounts(req.Side, req.Price, req.Size)
+	return s.signOrder(req, makerAmount, takerAmount)
+}
+
+// CreateSignedMarketOrder 创建已签名市价单
+// BUY 以 USDC 金额为 makerAmount，SELL 以份额为 makerAmount，另一侧按最差价格换算
+func (s *OrderSigner) CreateSignedMarketOrder(req *CreateMarketOrderRequest) (*SignedOrder, error) {
+	makerAmount, takerAmount := s.calculateMarketAmounts(req.Side, req.Price, req.Amount)
+	return s.signOrder(req.limitOrderView(), makerAmount, takerAmount)
+}
+
+// signOrder 按给定的 makerAmount/takerAmount 构建并签名订单，其余字段取自 req
+func (s *OrderSigner) signOrder(req *CreateOrderRequest, makerAmount, takerAmount *big.Int) (*SignedOrder, error) {
 	// 生成盐值
-	salt, err := common.GenerateSalt()
+	salt, err := s.generateSalt()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate salt: %w", err)
 	}
@@ -74,13 +117,17 @@ func (s *OrderSigner) CreateSignedOrder(req *CreateOrderRequest) (*SignedOrder,
 		if !ok {
 			return nil, fmt.Errorf("invalid nonce: %s", req.Nonce)
 		}
+	} else if s.nonceSource != nil {
+		if nonce, err = s.nonceSource(); err != nil {
+			return nil, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		if nonce == nil || nonce.Sign() < 0 {
+			return nil, fmt.Errorf("invalid nonce from source: %v", nonce)
+		}
 	} else {
 		nonce = big.NewInt(0)
 	}
 
-	// 计算 makerAmount 和 takerAmount
-	makerAmount, takerAmount := s.calculateAmounts(req.Side, req.Price, req.Size)
-
 	// 确定过期时间
 	expiration := int64(0)
 	if req.ExpiresAt > 0 {
@@ -151,6 +198,23 @@ func (s *OrderSigner) CreateSignedOrder(req *CreateOrderRequest) (*SignedOrder,
 	return signedOrder, nil
 }
 
+// generateSalt 按 saltSource 生成 salt，未设置时随机生成
+// SignedOrder.Salt 为 int64，超出范围的 salt 会导致提交的订单与签名不一致，因此直接拒绝
+func (s *OrderSigner) generateSalt() (*big.Int, error) {
+	if s.saltSource == nil {
+		return common.GenerateSalt()
+	}
+
+	salt, err := s.saltSource()
+	if err != nil {
+		return nil, err
+	}
+	if salt == nil || salt.Sign() < 0 || !salt.IsInt64() {
+		return nil, fmt.Errorf("salt from source must be a non-negative int64, got %v", salt)
+	}
+	return salt, nil
+}
+
 // calculateAmounts 计算 makerAmount 和 takerAmount
 // BUY: maker 给 USDC (makerAmount), taker 给 shares (takerAmount)
 // SELL: maker 给 shares (makerAmount), taker 给 USDC (takerAmount)
@@ -188,6 +252,25 @@ func (s *OrderSigner) calculateAmounts(side OrderSide, price, size decimal.Decim
 	return sharesBigInt, usdcBigInt
 }
 
+// calculateMarketAmounts 计算市价单的 makerAmount 和 takerAmount
+// BUY: maker 给 amount USDC（最多 2 位小数），taker 给 amount / price 份额（最多 4 位小数）
+// SELL: maker 给 amount 份额（最多 2 位小数），taker 给 amount * price USDC（最多 4 位小数）
+func (s *OrderSigner) calculateMarketAmounts(side OrderSide, price, amount decimal.Decimal) (*big.Int, *big.Int) {
+	usdcDecimals := decimal.NewFromInt(Decimal6)
+
+	truncatedPrice := price.Truncate(4)
+	makerRaw := amount.Truncate(2)
+
+	var takerRaw decimal.Decimal
+	if side == OrderSideBuy {
+		takerRaw = makerRaw.Div(truncatedPrice).Truncate(4)
+	} else {
+		takerRaw = makerRaw.Mul(truncatedPrice).Truncate(4)
+	}
+
+	return makerRaw.Mul(usdcDecimals).BigInt(), takerRaw.Mul(usdcDecimals).BigInt()
+}
+
 // sideToString 将 OrderSide 转换为字符串
 func sideToString(side OrderSide) string {
 	if side == OrderSideBuy {
@@ -1,12 +1,14 @@
 package clob
 
 import (
+	"math/big"
 	"strings"
 	"testing"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 // 测试用私钥
@@ -61,8 +63,8 @@ func TestOrderSignerCreateSignedOrder(t *testing.T) {
 	}
 
 	// Verify fields
-	if signedOrder.Salt == "" {
-		t.Error("Salt should not be empty")
+	if signedOrder.Salt == 0 {
+		t.Error("Salt should not be zero")
 	}
 	if signedOrder.Maker == "" {
 		t.Error("Maker should not be empty")
@@ -135,9 +137,9 @@ func TestOrderSignerCreateSignedOrderNegRisk(t *testing.T) {
 		t.Fatalf("CreateSignedOrder() error: %v", err)
 	}
 
-	// For NegRisk, taker should be the adapter address (case-insensitive comparison)
-	if !strings.EqualFold(signedOrder.Taker, negRiskAdapter) {
-		t.Errorf("Taker = %s, expected %s for NegRisk order", signedOrder.Taker, negRiskAdapter)
+	// NegRisk orders also use the zero address as taker
+	if !strings.EqualFold(signedOrder.Taker, common.ZeroAddress) {
+		t.Errorf("Taker = %s, expected %s for NegRisk order", signedOrder.Taker, common.ZeroAddress)
 	}
 }
 
@@ -373,3 +375,86 @@ func TestOrderSignerDeterministicWithSameNonceAndSalt(t *testing.T) {
 		t.Error("Each order should have a unique salt")
 	}
 }
+
+func TestCalculateMarketAmounts(t *testing.T) {
+	signer, _ := auth.NewL1Signer(testPrivateKey, 137)
+	orderSigner := NewOrderSigner(
+		signer,
+		137,
+		"0x4bFb41d5B3570DeFd03C39a9A4D8De6Bd8b8982e",
+		"0xC5d563A36AE78145C45a50134d48A1215220f80a",
+		"0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296",
+	)
+
+	// BUY 10 USDC at worst price 0.3: maker = 10 USDC, taker = 33.3333 shares
+	makerAmount, takerAmount := orderSigner.calculateMarketAmounts(OrderSideBuy, decimal.RequireFromString("0.3"), decimal.NewFromInt(10))
+	if makerAmount.Int64() != 10000000 || takerAmount.Int64() != 33333300 {
+		t.Errorf("BUY amounts = %s/%s, expected 10000000/33333300", makerAmount, takerAmount)
+	}
+
+	// SELL 15.557 shares at worst price 0.47: maker = 15.55 shares, taker = 7.3085 USDC
+	makerAmount, takerAmount = orderSigner.calculateMarketAmounts(OrderSideSell, decimal.RequireFromString("0.47"), decimal.RequireFromString("15.557"))
+	if makerAmount.Int64() != 15550000 || takerAmount.Int64() != 7308500 {
+		t.Errorf("SELL amounts = %s/%s, expected 15550000/7308500", makerAmount, takerAmount)
+	}
+}
+
+func TestOrderSignerInjectedSaltAndNonce(t *testing.T) {
+	newSigner := func() *OrderSigner {
+		signer, _ := auth.NewL1Signer(testPrivateKey, 137)
+		orderSigner := NewOrderSigner(
+			signer,
+			137,
+			"0x4bFb41d5B3570DeFd03C39a9A4D8De6Bd8b8982e",
+			"0xC5d563A36AE78145C45a50134d48A1215220f80a",
+			"0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296",
+		)
+		orderSigner.SetSaltSource(SequenceSource(1000))
+		orderSigner.SetNonceSource(SequenceSource(7))
+		return orderSigner
+	}
+
+	req := &CreateOrderRequest{
+		TokenID: "12345",
+		Side:    OrderSideBuy,
+		Price:   decimal.NewFromFloat(0.55),
+		Size:    decimal.NewFromInt(100),
+	}
+
+	// Two independent signers with the same sources produce byte-identical orders
+	first, err := newSigner().CreateSignedOrder(req)
+	if err != nil {
+		t.Fatalf("CreateSignedOrder() error: %v", err)
+	}
+	replay, _ := newSigner().CreateSignedOrder(req)
+	if *first != *replay {
+		t.Errorf("orders differ across runs:\n%+v\n%+v", first, replay)
+	}
+	if first.Salt != 1000 || first.Nonce != "7" {
+		t.Errorf("salt = %d, nonce = %s", first.Salt, first.Nonce)
+	}
+
+	// Sequences advance per order; an explicit request nonce still wins
+	orderSigner := newSigner()
+	orderSigner.CreateSignedOrder(req)
+	explicit := *req
+	explicit.Nonce = "42"
+	second, _ := orderSigner.CreateSignedOrder(&explicit)
+	if second.Salt != 1001 || second.Nonce != "42" {
+		t.Errorf("salt = %d, nonce = %s", second.Salt, second.Nonce)
+	}
+
+	// Out-of-range salts are rejected rather than silently truncated
+	orderSigner.SetSaltSource(func() (*big.Int, error) {
+		return new(big.Int).Lsh(big.NewInt(1), 64), nil
+	})
+	if _, err := orderSigner.CreateSignedOrder(req); err == nil {
+		t.Error("expected error for salt outside int64")
+	}
+
+	// nil restores random salts
+	orderSigner.SetSaltSource(nil)
+	if order, err := orderSigner.CreateSignedOrder(req); err != nil || order.Salt == 1002 {
+		t.Errorf("random salt after reset: %+v, %v", order, err)
+	}
+}
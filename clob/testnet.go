@@ -0,0 +1,245 @@
+package clob
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// Polygon Amoy 测试网
+const (
+	// AmoyChainID Amoy 测试网链 ID
+	AmoyChainID = 80002
+
+	// AmoyExchangeAddress 标准市场交易合约
+	AmoyExchangeAddress = "0xdFE02Eb6733538f8Ea35D585af8DE5958AD99E40"
+	// AmoyNegRiskExchangeAddress NegRisk 市场交易合约
+	AmoyNegRiskExchangeAddress = "0xC5d563A36AE78145C45a50134d48A1215220f80a"
+	// AmoyNegRiskAdapterAddress NegRisk 适配器合约
+	AmoyNegRiskAdapterAddress = "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296"
+	// AmoyCollateralAddress 测试 USDC 抵押品合约
+	AmoyCollateralAddress = "0x9c4e1703476e875070ee25b56a58b008cfb8fa78"
+	// AmoyConditionalTokensAddress 条件代币合约
+	AmoyConditionalTokensAddress = "0x69308FB512518e39F9b16112fA8d994F4e2Bf8bB"
+)
+
+// erc20MintSelector mint(address,uint256)，测试网抵押品合约开放铸造时使用
+const erc20MintSelector = "40c10f19"
+
+// AmoyConfig Amoy 测试网配置，Endpoint 沿用默认值，需按测试环境替换
+func AmoyConfig() *Config {
+	config := DefaultConfig()
+	config.ChainID = AmoyChainID
+	config.ExchangeAddress = AmoyExchangeAddress
+	config.NegRiskExchangeAddress = AmoyNegRiskExchangeAddress
+	config.NegRiskAdapterAddress = AmoyNegRiskAdapterAddress
+	config.CollateralAddress = AmoyCollateralAddress
+	config.ConditionalTokensAddress = AmoyConditionalTokensAddress
+	return config
+}
+
+// TestnetBootstrapConfig 测试网初始化配置
+type TestnetBootstrapConfig struct {
+	Treasury *TreasuryConfig // 链上操作配置，RPCEndpoint 必填
+
+	// 领取测试 USDC：余额低于 MinCollateral 时优先调用 Faucet，未设置时按 Mint 铸造差额
+	MinCollateral decimal.Decimal                                 // 签名钱包的最低 USDC.e 余额，0 表示不检查
+	Faucet        func(ctx context.Context, address string) error // 水龙头回调（可选），为 address 申请测试 USDC
+	Mint          bool                                            // 抵押品合约开放 mint(address,uint256) 时直接铸造
+	FundTimeout   time.Duration                                   // 等待余额到账的超时
+
+	// 下单/撤单往返验证，TokenID 为空时跳过
+	TokenID    string
+	NegRisk    bool            // TokenID 是否属于 NegRisk 市场
+	OrderPrice decimal.Decimal // 往返买单价格，0 表示使用最小 tick（远离盘口，不会成交）
+	OrderSize  decimal.Decimal // 往返买单数量
+}
+
+// DefaultTestnetBootstrapConfig 默认配置
+func DefaultTestnetBootstrapConfig() *TestnetBootstrapConfig {
+	return &TestnetBootstrapConfig{
+		Treasury:      DefaultTreasuryConfig(),
+		MinCollateral: decimal.NewFromInt(100),
+		FundTimeout:   2 * time.Minute,
+		OrderSize:     decimal.NewFromInt(5),
+	}
+}
+
+// TestnetBootstrapResult 测试网初始化结果
+type TestnetBootstrapResult struct {
+	Collateral  decimal.Decimal // 初始化后签名钱包的 USDC.e 余额
+	FundTxHash  string          // 铸造交易哈希，未铸造时为空
+	ApprovalTxs []string        // 授权交易哈希，已授权的合约不会重复发送
+	OrderID     string          // 往返验证的订单 ID，未验证时为空
+}
+
+// BootstrapTestnet 初始化测试网账户，使下游应用的 CI 可以跑通端到端交易流程
+// 依次执行：余额不足时领取或铸造测试 USDC、设置交易授权、挂一笔不会成交的买单并撤销；
+// 拒绝在 Polygon 主网执行，仅支持 EOA 模式（funder 即签名钱包）
+func (c *Client) BootstrapTestnet(ctx context.Context, config *TestnetBootstrapConfig) (*TestnetBootstrapResult, error) {
+	if config == nil {
+		config = DefaultTestnetBootstrapConfig()
+	}
+	if c.config.ChainID == 137 {
+		return nil, fmt.Errorf("%w: testnet bootstrap refused on Polygon mainnet (chain 137)", common.ErrInvalidConfig)
+	}
+	if config.Treasury == nil {
+		return nil, fmt.Errorf("%w: treasury config with RPC endpoint is required", common.ErrInvalidConfig)
+	}
+
+	treasury, err := c.NewTreasury(config.Treasury)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TestnetBootstrapResult{}
+	result.Collateral, result.FundTxHash, err = treasury.ensureTestCollateral(ctx, config)
+	if err != nil {
+		return result, err
+	}
+
+	result.ApprovalTxs, err = treasury.SetTradingAllowances(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to set trading allowances: %w", err)
+	}
+
+	if config.TokenID != "" {
+		result.OrderID, err = c.verifyOrderRoundTrip(ctx, config)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// MintTestCollateral 调用测试网抵押品合约的 mint(address,uint256) 为签名钱包铸造 USDC，返回交易哈希
+// 仅适用于开放铸造的测试代币，主网拒绝执行
+func (t *Treasury) MintTestCollateral(ctx context.Context, amount decimal.Decimal) (string, error) {
+	if t.client.config.ChainID == 137 {
+		return "", fmt.Errorf("%w: minting is not available on Polygon mainnet", common.ErrInvalidConfig)
+	}
+	units, err := usdcUnits(amount)
+	if err != nil {
+		return "", err
+	}
+	contract, err := t.TokenAddress(USDCBridged)
+	if err != nil {
+		return "", err
+	}
+
+	selector, _ := hex.DecodeString(erc20MintSelector)
+	data := append(selector, ethcommon.LeftPadBytes(ethcommon.HexToAddress(t.client.GetAddress()).Bytes(), 32)...)
+	data = append(data, uint256Word(units)...)
+	return t.sendTransaction(ctx, ethcommon.HexToAddress(contract), data)
+}
+
+// ensureTestCollateral 余额低于 MinCollateral 时领取或铸造测试 USDC，并等待到账
+func (t *Treasury) ensureTestCollateral(ctx context.Context, config *TestnetBootstrapConfig) (decimal.Decimal, string, error) {
+	owner := t.client.GetAddress()
+	if !strings.EqualFold(t.client.GetFunderAddress(), owner) {
+		return decimal.Zero, "", fmt.Errorf("testnet bootstrap for proxy wallet %s is not supported, only EOA funders can be funded directly", t.client.GetFunderAddress())
+	}
+
+	balance, err := t.BalanceOf(ctx, USDCBridged, owner)
+	if err != nil {
+		return decimal.Zero, "", err
+	}
+	if balance.GreaterThanOrEqual(config.MinCollateral) {
+		return balance, "", nil
+	}
+
+	var txHash string
+	switch {
+	case config.Faucet != nil:
+		if err := config.Faucet(ctx, owner); err != nil {
+			return balance, "", fmt.Errorf("faucet request failed: %w", err)
+		}
+	case config.Mint:
+		txHash, err = t.MintTestCollateral(ctx, config.MinCollateral.Sub(balance))
+		if err != nil {
+			return balance, "", fmt.Errorf("failed to mint test collateral: %w", err)
+		}
+		if _, err := t.WaitForReceipt(ctx, txHash); err != nil {
+			return balance, txHash, fmt.Errorf("failed to mint test collateral: %w", err)
+		}
+	default:
+		return balance, "", fmt.Errorf("%w: USDC.e balance %s < %s and neither faucet nor mint is configured",
+			common.ErrInsufficientBalance, balance, config.MinCollateral)
+	}
+
+	balance, err = t.waitForBalance(ctx, owner, config.MinCollateral, config.FundTimeout)
+	return balance, txHash, err
+}
+
+// waitForBalance 轮询签名钱包余额直到不低于 min，timeout <= 0 时只受 ctx 限制
+func (t *Treasury) waitForBalance(ctx context.Context, owner string, min decimal.Decimal, timeout time.Duration) (decimal.Decimal, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(t.config.ReceiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		balance, err := t.BalanceOf(ctx, USDCBridged, owner)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if balance.GreaterThanOrEqual(min) {
+			return balance, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return balance, fmt.Errorf("%w: USDC.e balance %s < %s after funding: %v", common.ErrInsufficientBalance, balance, min, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// verifyOrderRoundTrip 挂一笔仅挂单（post-only）的买单并立即撤销，验证凭证、签名与授权链路
+func (c *Client) verifyOrderRoundTrip(ctx context.Context, config *TestnetBootstrapConfig) (string, error) {
+	price := config.OrderPrice
+	if price.IsZero() {
+		tick, err := c.GetTickSize(ctx, config.TokenID)
+		if err != nil {
+			return "", err
+		}
+		price = tick.TickSize
+	}
+	size := config.OrderSize
+	if size.IsZero() {
+		size = decimal.NewFromInt(5)
+	}
+
+	resp, err := c.CreateOrder(ctx, &CreateOrderRequest{
+		TokenID:   config.TokenID,
+		Side:      OrderSideBuy,
+		Price:     price,
+		Size:      size,
+		Type:      OrderTypeGTC,
+		PostOnly:  true,
+		IsNegRisk: config.NegRisk,
+	})
+	if err != nil {
+		return "", fmt.Errorf("round-trip order failed: %w", err)
+	}
+	if !resp.Success || resp.OrderID == "" {
+		return "", fmt.Errorf("round-trip order rejected: %s", resp.ErrorMsg)
+	}
+
+	if err := c.CancelOrder(ctx, resp.OrderID); err != nil {
+		return resp.OrderID, fmt.Errorf("failed to cancel round-trip order %s: %w", resp.OrderID, err)
+	}
+	return resp.OrderID, nil
+}
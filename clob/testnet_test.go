@@ -0,0 +1,158 @@
+package clob
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// setupTestnet returns an Amoy client backed by a fake CLOB server and a mock chain
+func setupTestnet(t *testing.T, handler http.HandlerFunc) (*Client, *mockChain, *TestnetBootstrapConfig) {
+	t.Helper()
+
+	chain := &mockChain{t: t, balances: make(map[string]*big.Int)}
+	rpc := httptest.NewServer(chain)
+	t.Cleanup(rpc.Close)
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := AmoyConfig()
+	config.Endpoint = server.URL
+	config.MaxRetries = 0
+	creds := &auth.Credentials{
+		APIKey:     "test-api-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("test-secret")),
+		Passphrase: "test-passphrase",
+	}
+	client, err := NewClientWithCredentials(config, testPrivateKey, creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bootstrap := DefaultTestnetBootstrapConfig()
+	bootstrap.Treasury.RPCEndpoint = rpc.URL
+	bootstrap.Treasury.ReceiptPollInterval = 10 * time.Millisecond
+	bootstrap.FundTimeout = time.Second
+	return client, chain, bootstrap
+}
+
+func TestBootstrapTestnet_RefusesMainnet(t *testing.T) {
+	client, err := NewClient(DefaultConfig(), testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.BootstrapTestnet(context.Background(), nil); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig on mainnet, got %v", err)
+	}
+}
+
+func TestBootstrapTestnet_MintApproveAndRoundTrip(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	var posted PostOrderRequest
+	client, chain, config := setupTestnet(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/tick-size":
+			w.Write([]byte(`{"minimum_tick_size":"0.01"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/order":
+			json.NewDecoder(r.Body).Decode(&posted)
+			w.Write([]byte(`{"success":true,"orderID":"0xorder","status":"live"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/order/0xorder":
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	config.Mint = true
+	config.TokenID = "12345"
+
+	result, err := client.BootstrapTestnet(context.Background(), config)
+	if err != nil {
+		t.Fatalf("BootstrapTestnet() error: %v", err)
+	}
+
+	if result.FundTxHash == "" || !result.Collateral.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("expected 100 USDC minted, got %s (tx %q)", result.Collateral, result.FundTxHash)
+	}
+	if len(result.ApprovalTxs) != 6 {
+		t.Errorf("expected 6 approvals (USDC + CTF for 3 spenders), got %d", len(result.ApprovalTxs))
+	}
+	if len(chain.sent) != 7 || chain.sent[0].ChainId().Int64() != AmoyChainID {
+		t.Fatalf("expected 7 Amoy transactions, got %d", len(chain.sent))
+	}
+
+	// First approval: USDC.e approve(exchange, max) on the Amoy collateral
+	approve := chain.sent[1]
+	if !strings.EqualFold(approve.To().Hex(), AmoyCollateralAddress) || hexutil.Encode(approve.Data()[:4]) != "0x"+erc20ApproveSelector {
+		t.Errorf("unexpected approve tx to %s data %x", approve.To().Hex(), approve.Data()[:4])
+	}
+	if ethcommon.BytesToAddress(approve.Data()[4:36]).Hex() != ethcommon.HexToAddress(AmoyExchangeAddress).Hex() {
+		t.Errorf("approve spender = %x", approve.Data()[4:36])
+	}
+	if ctf := chain.sent[2]; !strings.EqualFold(ctf.To().Hex(), AmoyConditionalTokensAddress) {
+		t.Errorf("setApprovalForAll sent to %s, expected conditional tokens", ctf.To().Hex())
+	}
+
+	if result.OrderID != "0xorder" || !posted.PostOnly || posted.OrderType != OrderTypeGTC {
+		t.Errorf("unexpected round-trip order %q: %+v", result.OrderID, posted)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 3 || calls[2] != "DELETE /order/0xorder" {
+		t.Errorf("unexpected CLOB calls %v", calls)
+	}
+}
+
+func TestBootstrapTestnet_Funding(t *testing.T) {
+	client, chain, config := setupTestnet(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	ctx := context.Background()
+	owner := client.GetAddress()
+
+	// Neither faucet nor mint configured
+	if _, err := client.BootstrapTestnet(ctx, config); !errors.Is(err, common.ErrInsufficientBalance) {
+		t.Fatalf("expected ErrInsufficientBalance, got %v", err)
+	}
+	if len(chain.sent) != 0 {
+		t.Fatalf("no transactions expected before funding, got %d", len(chain.sent))
+	}
+
+	// Faucet callback funds the wallet out of band
+	var requested string
+	config.Faucet = func(ctx context.Context, address string) error {
+		requested = address
+		chain.mu.Lock()
+		chain.balances[chain.key(AmoyCollateralAddress, address)] = big.NewInt(250_000_000)
+		chain.mu.Unlock()
+		return nil
+	}
+	result, err := client.BootstrapTestnet(ctx, config)
+	if err != nil {
+		t.Fatalf("BootstrapTestnet() error: %v", err)
+	}
+	if requested != owner || !result.Collateral.Equal(decimal.NewFromInt(250)) || result.FundTxHash != "" || result.OrderID != "" {
+		t.Errorf("unexpected result %+v (faucet for %s)", result, requested)
+	}
+}
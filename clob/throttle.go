@@ -0,0 +1,213 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// ThrottleConfig 按市场的下单/撤单自限流（令牌桶）
+// 用于避免单个策略频繁报撤触发交易所处罚，并平滑突发的批量重新报价
+type ThrottleConfig struct {
+	OrdersPerSecond  float64 // 每个市场每秒最多新订单数，<= 0 表示不限制
+	OrderBurst       int     // 新订单令牌桶容量，<= 0 时取 OrdersPerSecond 向上取整（至少 1）
+	CancelsPerSecond float64 // 每个市场每秒最多撤单数，<= 0 表示不限制
+	CancelBurst      int     // 撤单令牌桶容量，<= 0 时取 CancelsPerSecond 向上取整（至少 1）
+
+	// Wait 为 true 时令牌不足则等待（ctx 截止时间前拿不到令牌时立即失败），否则立即返回 ErrThrottled
+	Wait bool
+
+	// MarketKey 将 token 映射为市场标识（如 conditionID），使同一市场的各结果共用令牌桶；nil 时每个 token 单独计数
+	// CancelOrdersByMarket 直接以 marketID 作为市场标识；CancelAllOrders 作为紧急撤单不受限流
+	MarketKey func(tokenID string) string
+}
+
+// throttleKind 限流的请求类别
+type throttleKind int
+
+const (
+	throttleOrders throttleKind = iota
+	throttleCancels
+)
+
+// String 实现 Stringer 接口
+func (k throttleKind) String() string {
+	if k == throttleCancels {
+		return "cancels"
+	}
+	return "orders"
+}
+
+// tokenBucket 令牌桶，tokens 可以为负数，表示已被等待中的请求预占
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// orderThrottle 按市场维护下单与撤单令牌桶（并发安全）
+type orderThrottle struct {
+	mu      sync.Mutex
+	config  ThrottleConfig
+	buckets [2]map[string]*tokenBucket
+	now     func() time.Time
+}
+
+// newOrderThrottle 创建限流器，config 为 nil 时不限流
+func newOrderThrottle(config *ThrottleConfig) *orderThrottle {
+	t := &orderThrottle{now: time.Now}
+	t.setConfig(config)
+	return t
+}
+
+// setConfig 替换限流配置并重置令牌桶
+func (t *orderThrottle) setConfig(config *ThrottleConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.config = ThrottleConfig{}
+	if config != nil {
+		t.config = *config
+	}
+	t.buckets = [2]map[string]*tokenBucket{make(map[string]*tokenBucket), make(map[string]*tokenBucket)}
+}
+
+// limit 返回类别的速率与容量，rate <= 0 表示不限制
+func (t *orderThrottle) limit(kind throttleKind) (rate float64, burst float64) {
+	rate, b := t.config.OrdersPerSecond, t.config.OrderBurst
+	if kind == throttleCancels {
+		rate, b = t.config.CancelsPerSecond, t.config.CancelBurst
+	}
+	if b <= 0 {
+		b = max(1, int(math.Ceil(rate)))
+	}
+	return rate, float64(b)
+}
+
+// marketKey token 对应的市场标识
+func (t *orderThrottle) marketKey(tokenID string) string {
+	if t == nil {
+		return tokenID
+	}
+	t.mu.Lock()
+	keyFn := t.config.MarketKey
+	t.mu.Unlock()
+	if keyFn != nil && tokenID != "" {
+		if key := keyFn(tokenID); key != "" {
+			return key
+		}
+	}
+	return tokenID
+}
+
+// acquire 为每个市场标识各取一个令牌（同一标识出现多次则取多个），全部取得或全部不取
+// 非等待模式下令牌不足返回 ErrThrottled；等待模式下预占令牌并等待补足，ctx 取消时归还
+func (t *orderThrottle) acquire(ctx context.Context, kind throttleKind, keys []string) error {
+	if t == nil || len(keys) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]float64, len(keys))
+	for _, key := range keys {
+		counts[key]++
+	}
+
+	t.mu.Lock()
+	rate, burst := t.limit(kind)
+	if rate <= 0 {
+		t.mu.Unlock()
+		return nil
+	}
+
+	now := t.now()
+	buckets := t.buckets[kind]
+	var wait time.Duration
+	for key, n := range counts {
+		bucket, ok := buckets[key]
+		if !ok {
+			bucket = &tokenBucket{tokens: burst, last: now}
+			buckets[key] = bucket
+		}
+		bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.last).Seconds()*rate)
+		bucket.last = now
+
+		if deficit := n - bucket.tokens; deficit > 0 {
+			keyWait := time.Duration(deficit / rate * float64(time.Second))
+			if !t.config.Wait {
+				t.mu.Unlock()
+				return fmt.Errorf("%w: %s for market %s exceed %.4g/s, retry in %v", common.ErrThrottled, kind, key, rate, keyWait.Round(time.Millisecond))
+			}
+			wait = max(wait, keyWait)
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && wait > 0 && now.Add(wait).After(deadline) {
+		t.mu.Unlock()
+		return fmt.Errorf("%w: %s would wait %v, beyond the context deadline", common.ErrThrottled, kind, wait.Round(time.Millisecond))
+	}
+	for key, n := range counts {
+		buckets[key].tokens -= n
+	}
+	t.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		t.refund(kind, counts)
+		return ctx.Err()
+	}
+}
+
+// refund 归还未使用的预占令牌
+func (t *orderThrottle) refund(kind throttleKind, counts map[string]float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, n := range counts {
+		if bucket, ok := t.buckets[kind][key]; ok {
+			bucket.tokens += n
+		}
+	}
+}
+
+// SetThrottle 运行时替换按市场的下单/撤单限流配置，nil 表示不限流
+func (c *Client) SetThrottle(config *ThrottleConfig) {
+	c.mu.Lock()
+	c.config.Throttle = config
+	c.mu.Unlock()
+	c.throttle.setConfig(config)
+}
+
+// throttleOrders 校验市场认领并为即将提交的订单取得下单令牌
+func (c *Client) throttleOrders(ctx context.Context, tokenIDs ...string) error {
+	if err := c.checkClaimedTokens(tokenIDs...); err != nil {
+		return err
+	}
+	keys := make([]string, len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		keys[i] = c.throttle.marketKey(tokenID)
+	}
+	return c.throttle.acquire(ctx, throttleOrders, keys)
+}
+
+// throttleCancels 校验市场认领并为撤单取得撤单令牌，订单所属 token 从事件日志中查找
+// 查不到 token 的订单（如其他进程下的单）共用一个令牌桶
+func (c *Client) throttleCancels(ctx context.Context, orderIDs []string) error {
+	if err := c.checkClaimedOrders(orderIDs); err != nil {
+		return err
+	}
+	keys := make([]string, len(orderIDs))
+	for i, orderID := range orderIDs {
+		keys[i] = c.throttle.marketKey(c.eventLog.tokenForOrder(orderID))
+	}
+	return c.throttle.acquire(ctx, throttleCancels, keys)
+}
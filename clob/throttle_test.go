@@ -0,0 +1,122 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func newTestThrottle(config *ThrottleConfig) (*orderThrottle, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	throttle := newOrderThrottle(config)
+	throttle.now = func() time.Time { return now }
+	return throttle, &now
+}
+
+func TestOrderThrottle_TokenBucket(t *testing.T) {
+	throttle, now := newTestThrottle(&ThrottleConfig{OrdersPerSecond: 2})
+	ctx := context.Background()
+
+	// Burst defaults to ceil(rate) = 2
+	for i := 0; i < 2; i++ {
+		if err := throttle.acquire(ctx, throttleOrders, []string{"a"}); err != nil {
+			t.Fatalf("acquire %d error: %v", i, err)
+		}
+	}
+	if err := throttle.acquire(ctx, throttleOrders, []string{"a"}); !errors.Is(err, common.ErrThrottled) {
+		t.Fatalf("expected ErrThrottled, got %v", err)
+	}
+
+	// Other markets and cancels have their own buckets
+	if err := throttle.acquire(ctx, throttleOrders, []string{"b"}); err != nil {
+		t.Errorf("market b should not be throttled: %v", err)
+	}
+	if err := throttle.acquire(ctx, throttleCancels, []string{"a", "a", "a"}); err != nil {
+		t.Errorf("cancels are unlimited by default: %v", err)
+	}
+
+	// One token refills every 500ms
+	*now = now.Add(500 * time.Millisecond)
+	if err := throttle.acquire(ctx, throttleOrders, []string{"a"}); err != nil {
+		t.Errorf("expected refill after 500ms: %v", err)
+	}
+}
+
+func TestOrderThrottle_BatchAllOrNothing(t *testing.T) {
+	throttle, _ := newTestThrottle(&ThrottleConfig{CancelsPerSecond: 1, CancelBurst: 2})
+	ctx := context.Background()
+
+	if err := throttle.acquire(ctx, throttleCancels, []string{"a", "b", "a", "a"}); !errors.Is(err, common.ErrThrottled) {
+		t.Fatalf("expected ErrThrottled for 3 cancels in market a, got %v", err)
+	}
+	// Nothing was taken by the rejected batch
+	if err := throttle.acquire(ctx, throttleCancels, []string{"a", "a", "b", "b"}); err != nil {
+		t.Errorf("rejected batch should not consume tokens: %v", err)
+	}
+}
+
+func TestOrderThrottle_WaitAndMarketKey(t *testing.T) {
+	throttle := newOrderThrottle(&ThrottleConfig{
+		OrdersPerSecond: 50,
+		OrderBurst:      1,
+		Wait:            true,
+		MarketKey:       func(tokenID string) string { return "market" },
+	})
+	ctx := context.Background()
+
+	// yes and no share one bucket, the second order waits ~20ms
+	start := time.Now()
+	if err := throttle.acquire(ctx, throttleOrders, []string{throttle.marketKey("yes")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := throttle.acquire(ctx, throttleOrders, []string{throttle.marketKey("no")}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected to wait for a token, took %v", elapsed)
+	}
+
+	// A deadline shorter than the wait fails fast
+	short, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	if err := throttle.acquire(short, throttleOrders, []string{"market"}); !errors.Is(err, common.ErrThrottled) {
+		t.Errorf("expected ErrThrottled before deadline, got %v", err)
+	}
+}
+
+func TestClient_ThrottleCancels(t *testing.T) {
+	var deletes int32
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&deletes, 1)
+		w.Write([]byte(`{}`))
+	})
+	defer server.Close()
+
+	client.SetThrottle(&ThrottleConfig{CancelsPerSecond: 1})
+	client.RecordEvent(TradingEvent{Type: TradingEventOrderResponse, OrderID: "0x1", TokenID: "t1"})
+	client.RecordEvent(TradingEvent{Type: TradingEventOrderResponse, OrderID: "0x2", TokenID: "t1"})
+	client.RecordEvent(TradingEvent{Type: TradingEventOrderResponse, OrderID: "0x3", TokenID: "t2"})
+
+	ctx := context.Background()
+	if err := client.CancelOrder(ctx, "0x1"); err != nil {
+		t.Fatalf("CancelOrder() error: %v", err)
+	}
+	// 0x2 is in the same market (t1) as 0x1
+	if err := client.CancelOrder(ctx, "0x2"); !errors.Is(err, common.ErrThrottled) {
+		t.Errorf("expected ErrThrottled, got %v", err)
+	}
+	if err := client.CancelOrder(ctx, "0x3"); err != nil {
+		t.Errorf("CancelOrder() in another market error: %v", err)
+	}
+	if got := atomic.LoadInt32(&deletes); got != 2 {
+		t.Errorf("expected 2 cancel requests, got %d", got)
+	}
+	if client.GetConfig().Throttle == nil {
+		t.Error("SetThrottle() should update the config")
+	}
+}
@@ -40,7 +40,7 @@ func (c *Client) GetTrades(ctx context.Context, params *TradesQueryParams) ([]*T
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("GET", "/trades", "")
+	authHeaders, err := c.getL2AuthHeaders(ctx, "GET", "/trades", "")
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +98,7 @@ func (c *Client) GetTradesPage(ctx context.Context, params *TradesQueryParams, c
 	}
 
 	// 获取认证头
-	authHeaders, err := c.getL2AuthHeaders("GET", "/trades", "")
+	authHeaders, err := c.getL2AuthHeaders(ctx, "GET", "/trades", "")
 	if err != nil {
 		return nil, err
 	}
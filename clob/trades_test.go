@@ -58,7 +58,7 @@ func TestGetTrades(t *testing.T) {
 				Side:      OrderSideBuy,
 				Price:     decimal.NewFromFloat(0.55),
 				Size:      decimal.NewFromInt(100),
-				Timestamp: time.Now(),
+				MatchTime: "1700000000",
 			},
 			{
 				ID:        "trade-2",
@@ -67,11 +67,11 @@ func TestGetTrades(t *testing.T) {
 				Side:      OrderSideSell,
 				Price:     decimal.NewFromFloat(0.45),
 				Size:      decimal.NewFromInt(50),
-				Timestamp: time.Now(),
+				MatchTime: "1700000000",
 			},
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(trades)
+		json.NewEncoder(w).Encode(TradesResponse{NextCursor: EndCursor, Data: trades})
 	})
 	defer server.Close()
 
@@ -94,7 +94,7 @@ func TestGetTradesWithParams(t *testing.T) {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode([]*Trade{})
+		json.NewEncoder(w).Encode(TradesResponse{NextCursor: EndCursor})
 	})
 	defer server.Close()
 
@@ -118,7 +118,7 @@ func TestGetTradesByMarket(t *testing.T) {
 			{ID: "trade-1", Market: "market-123"},
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(trades)
+		json.NewEncoder(w).Encode(TradesResponse{NextCursor: EndCursor, Data: trades})
 	})
 	defer server.Close()
 
@@ -150,7 +150,7 @@ func TestGetTradesByMarketDefaultLimit(t *testing.T) {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode([]*Trade{})
+		json.NewEncoder(w).Encode(TradesResponse{NextCursor: EndCursor})
 	})
 	defer server.Close()
 
@@ -170,7 +170,7 @@ func TestGetTradesByAsset(t *testing.T) {
 			{ID: "trade-1", AssetID: "asset-123"},
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(trades)
+		json.NewEncoder(w).Encode(TradesResponse{NextCursor: EndCursor, Data: trades})
 	})
 	defer server.Close()
 
@@ -206,7 +206,7 @@ func TestGetRecentTrades(t *testing.T) {
 			{ID: "trade-2"},
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(trades)
+		json.NewEncoder(w).Encode(TradesResponse{NextCursor: EndCursor, Data: trades})
 	})
 	defer server.Close()
 
@@ -226,7 +226,7 @@ func TestGetRecentTradesDefaultLimit(t *testing.T) {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode([]*Trade{})
+		json.NewEncoder(w).Encode(TradesResponse{NextCursor: EndCursor})
 	})
 	defer server.Close()
 
@@ -249,7 +249,7 @@ func TestGetTradesByTimeRange(t *testing.T) {
 			{ID: "trade-1"},
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(trades)
+		json.NewEncoder(w).Encode(TradesResponse{NextCursor: EndCursor, Data: trades})
 	})
 	defer server.Close()
 
@@ -269,7 +269,7 @@ func TestGetTradesByTimeRangeDefaultLimit(t *testing.T) {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode([]*Trade{})
+		json.NewEncoder(w).Encode(TradesResponse{NextCursor: EndCursor})
 	})
 	defer server.Close()
 
@@ -0,0 +1,24 @@
+package clob
+
+import "context"
+
+// TradingClient 策略使用的交易接口（订单、撤单、成交、余额）
+// *Client 与模拟盘 sim.Exchange 均实现该接口，策略依赖它即可在实盘与模拟盘之间切换
+type TradingClient interface {
+	CreateOrder(ctx context.Context, req *CreateOrderRequest) (*OrderResponse, error)
+	CreateOrders(ctx context.Context, reqs []*CreateOrderRequest) ([]*OrderResponse, error)
+	GetOrder(ctx context.Context, orderID string) (*Order, error)
+	GetOrders(ctx context.Context, params *OrdersQueryParams) ([]*Order, error)
+	GetOpenOrders(ctx context.Context) ([]*Order, error)
+	CancelOrder(ctx context.Context, orderID string) error
+	CancelOrders(ctx context.Context, orderIDs []string) (*CancelResponse, error)
+	CancelOrdersByMarket(ctx context.Context, marketID string) (*CancelResponse, error)
+	CancelOrdersByAsset(ctx context.Context, assetID string) (*CancelResponse, error)
+	CancelAllOrders(ctx context.Context) error
+	GetTrades(ctx context.Context, params *TradesQueryParams) ([]*Trade, error)
+	GetBalanceAllowance(ctx context.Context, params *BalanceAllowanceParams) (*BalanceAllowance, error)
+	GetCollateralBalance(ctx context.Context) (*BalanceAllowance, error)
+	GetConditionalBalance(ctx context.Context, tokenID string) (*BalanceAllowance, error)
+}
+
+var _ TradingClient = (*Client)(nil)
@@ -0,0 +1,348 @@
+package clob
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// Polygon 上的两种 USDC
+// CLOB 的抵押品是桥接的 USDC.e，原生 USDC 转入资金钱包后不能直接用于下单，需要先兑换
+const (
+	// USDCeAddress 桥接 USDC（USDC.e），Polymarket 抵押品
+	USDCeAddress = "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"
+	// NativeUSDCAddress Circle 原生 USDC
+	NativeUSDCAddress = "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359"
+	// USDCDecimals USDC 精度
+	USDCDecimals = 6
+)
+
+// ERC20 函数选择器
+const (
+	erc20TransferSelector  = "a9059cbb" // transfer(address,uint256)
+	erc20BalanceOfSelector = "70a08231" // balanceOf(address)
+)
+
+// USDCToken USDC 种类
+type USDCToken string
+
+const (
+	// USDCBridged 桥接 USDC.e（抵押品）
+	USDCBridged USDCToken = "USDC.e"
+	// USDCNative 原生 USDC
+	USDCNative USDCToken = "USDC"
+)
+
+// TreasuryConfig 资金划转配置
+type TreasuryConfig struct {
+	RPCEndpoint         string        // Polygon RPC 端点（必填）
+	GasLimit            uint64        // 固定 gas 上限，0 表示 eth_estimateGas 估算后上浮 20%
+	MinTipGwei          int64         // 最低优先费（gwei），Polygon 要求不低于 25
+	ReceiptPollInterval time.Duration // 等待回执的轮询间隔
+	RequestTimeout      time.Duration // 单次 RPC 请求超时
+}
+
+// DefaultTreasuryConfig 默认配置
+func DefaultTreasuryConfig() *TreasuryConfig {
+	return &TreasuryConfig{
+		MinTipGwei:          30,
+		ReceiptPollInterval: 2 * time.Second,
+		RequestTimeout:      10 * time.Second,
+	}
+}
+
+// TxReceipt 交易回执
+type TxReceipt struct {
+	TxHash      string
+	BlockNumber uint64
+	GasUsed     uint64
+	Success     bool
+}
+
+// Treasury 链上 USDC 划转：在签名钱包（EOA）、资金钱包（funder）与外部财务钱包之间转账
+// 交易由签名钱包发起并支付 gas（MATIC/POL），因此只能从签名钱包转出；
+// 代理钱包（POLY_PROXY / GNOSIS_SAFE）中的资金需要通过代理合约调用转出，不在此支持
+type Treasury struct {
+	client     *Client
+	config     *TreasuryConfig
+	httpClient *http.Client
+}
+
+// NewTreasury 创建资金划转工具
+func (c *Client) NewTreasury(config *TreasuryConfig) (*Treasury, error) {
+	if config == nil {
+		config = DefaultTreasuryConfig()
+	}
+	if config.RPCEndpoint == "" {
+		return nil, fmt.Errorf("%w: RPC endpoint is required", common.ErrInvalidConfig)
+	}
+	if config.ReceiptPollInterval <= 0 {
+		config.ReceiptPollInterval = 2 * time.Second
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = 10 * time.Second
+	}
+
+	return &Treasury{
+		client:     c,
+		config:     config,
+		httpClient: &http.Client{Timeout: config.RequestTimeout},
+	}, nil
+}
+
+// TokenAddress 获取 USDC 合约地址，USDC.e 使用 Config.CollateralAddress
+func (t *Treasury) TokenAddress(token USDCToken) (string, error) {
+	switch token {
+	case USDCBridged:
+		if addr := t.client.config.CollateralAddress; addr != "" {
+			return addr, nil
+		}
+		return USDCeAddress, nil
+	case USDCNative:
+		return NativeUSDCAddress, nil
+	default:
+		return "", fmt.Errorf("unknown USDC token: %s", token)
+	}
+}
+
+// BalanceOf 查询地址的 USDC 链上余额（单位 USDC）
+func (t *Treasury) BalanceOf(ctx context.Context, token USDCToken, owner string) (decimal.Decimal, error) {
+	contract, err := t.TokenAddress(token)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if !ethcommon.IsHexAddress(owner) {
+		return decimal.Zero, fmt.Errorf("%w: %s", common.ErrInvalidAddress, owner)
+	}
+
+	selector, _ := hex.DecodeString(erc20BalanceOfSelector)
+	data := append(selector, ethcommon.LeftPadBytes(ethcommon.HexToAddress(owner).Bytes(), 32)...)
+
+	var result string
+	err = rpcCall(ctx, t.httpClient, t.config.RPCEndpoint, "eth_call", []interface{}{
+		map[string]string{"to": contract, "data": hexutil.Encode(data)},
+		"latest",
+	}, &result)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to query %s balance: %w", token, err)
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(result, "0x"))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid balanceOf result: %w", err)
+	}
+	return decimal.NewFromBigInt(new(big.Int).SetBytes(raw), -USDCDecimals), nil
+}
+
+// Transfer 从签名钱包转出 USDC，返回交易哈希（不等待上链，可配合 WaitForReceipt）
+func (t *Treasury) Transfer(ctx context.Context, token USDCToken, to string, amount decimal.Decimal) (string, error) {
+	contract, err := t.TokenAddress(token)
+	if err != nil {
+		return "", err
+	}
+	if !ethcommon.IsHexAddress(to) {
+		return "", fmt.Errorf("%w: %s", common.ErrInvalidAddress, to)
+	}
+
+	units, err := usdcUnits(amount)
+	if err != nil {
+		return "", err
+	}
+
+	from := t.client.GetAddress()
+	balance, err := t.BalanceOf(ctx, token, from)
+	if err != nil {
+		return "", err
+	}
+	if balance.LessThan(amount) {
+		return "", fmt.Errorf("%w: %s balance %s < %s", common.ErrInsufficientBalance, token, balance, amount)
+	}
+
+	data := encodeERC20Transfer(ethcommon.HexToAddress(to), units)
+	return t.sendTransaction(ctx, ethcommon.HexToAddress(contract), data)
+}
+
+// Deposit 将签名钱包中的 USDC.e 转入资金钱包（funder）
+// 签名钱包只有原生 USDC 时返回错误提示先兑换：原生 USDC 不能作为 CLOB 抵押品
+func (t *Treasury) Deposit(ctx context.Context, amount decimal.Decimal) (string, error) {
+	funder := t.client.GetFunderAddress()
+	if strings.EqualFold(funder, t.client.GetAddress()) {
+		return "", fmt.Errorf("funder is the signing wallet (EOA mode), deposit is not needed")
+	}
+
+	balance, err := t.BalanceOf(ctx, USDCBridged, t.client.GetAddress())
+	if err != nil {
+		return "", err
+	}
+	if balance.LessThan(amount) {
+		native, nerr := t.BalanceOf(ctx, USDCNative, t.client.GetAddress())
+		if nerr == nil && native.GreaterThanOrEqual(amount) {
+			return "", fmt.Errorf("%w: USDC.e balance %s < %s; wallet holds %s native USDC, which must be swapped to USDC.e before it can be used as collateral",
+				common.ErrInsufficientBalance, balance, amount, native)
+		}
+	}
+
+	return t.Transfer(ctx, USDCBridged, funder, amount)
+}
+
+// Withdraw 将资金钱包中的 USDC.e 转到外部地址
+// 仅支持 EOA 模式（funder 即签名钱包），代理钱包需通过 Polymarket 界面或代理合约转出
+func (t *Treasury) Withdraw(ctx context.Context, to string, amount decimal.Decimal) (string, error) {
+	if !strings.EqualFold(t.client.GetFunderAddress(), t.client.GetAddress()) {
+		return "", fmt.Errorf("withdraw from proxy wallet %s is not supported, only EOA funders can be withdrawn directly", t.client.GetFunderAddress())
+	}
+	return t.Transfer(ctx, USDCBridged, to, amount)
+}
+
+// WaitForReceipt 轮询等待交易回执，直到上链或 ctx 取消
+// 交易执行失败（status=0）时返回回执及错误
+func (t *Treasury) WaitForReceipt(ctx context.Context, txHash string) (*TxReceipt, error) {
+	ticker := time.NewTicker(t.config.ReceiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		var raw *struct {
+			TransactionHash string         `json:"transactionHash"`
+			BlockNumber     hexutil.Uint64 `json:"blockNumber"`
+			GasUsed         hexutil.Uint64 `json:"gasUsed"`
+			Status          hexutil.Uint64 `json:"status"`
+		}
+		if err := rpcCall(ctx, t.httpClient, t.config.RPCEndpoint, "eth_getTransactionReceipt", []interface{}{txHash}, &raw); err != nil {
+			return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+		}
+
+		if raw != nil {
+			receipt := &TxReceipt{
+				TxHash:      raw.TransactionHash,
+				BlockNumber: uint64(raw.BlockNumber),
+				GasUsed:     uint64(raw.GasUsed),
+				Success:     raw.Status == 1,
+			}
+			if !receipt.Success {
+				return receipt, fmt.Errorf("transaction %s reverted", txHash)
+			}
+			return receipt, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendTransaction 构建、签名并广播 EIP-1559 交易
+func (t *Treasury) sendTransaction(ctx context.Context, to ethcommon.Address, data []byte) (string, error) {
+	from := t.client.GetAddress()
+	endpoint := t.config.RPCEndpoint
+
+	var nonce hexutil.Uint64
+	if err := rpcCall(ctx, t.httpClient, endpoint, "eth_getTransactionCount", []interface{}{from, "pending"}, &nonce); err != nil {
+		return "", fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	tipCap, feeCap, err := t.suggestFees(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	gasLimit := t.config.GasLimit
+	if gasLimit == 0 {
+		var estimate hexutil.Uint64
+		err := rpcCall(ctx, t.httpClient, endpoint, "eth_estimateGas", []interface{}{
+			map[string]string{"from": from, "to": to.Hex(), "data": hexutil.Encode(data)},
+		}, &estimate)
+		if err != nil {
+			return "", fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		gasLimit = uint64(estimate) * 12 / 10
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(int64(t.client.config.ChainID)),
+		Nonce:     uint64(nonce),
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gasLimit,
+		To:        &to,
+		Value:     big.NewInt(0),
+		Data:      data,
+	})
+
+	signed, err := t.client.l1Signer.SignTransaction(tx)
+	if err != nil {
+		return "", err
+	}
+	rawTx, err := signed.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	var txHash string
+	if err := rpcCall(ctx, t.httpClient, endpoint, "eth_sendRawTransaction", []interface{}{hexutil.Encode(rawTx)}, &txHash); err != nil {
+		return "", fmt.Errorf("failed to send transaction: %w", err)
+	}
+	return txHash, nil
+}
+
+// suggestFees 计算 EIP-1559 费用：tip 取节点建议与 MinTipGwei 的较大者，feeCap = 2 * baseFee + tip
+func (t *Treasury) suggestFees(ctx context.Context) (tipCap, feeCap *big.Int, err error) {
+	endpoint := t.config.RPCEndpoint
+
+	var tip hexutil.Big
+	if err := rpcCall(ctx, t.httpClient, endpoint, "eth_maxPriorityFeePerGas", []interface{}{}, &tip); err != nil {
+		return nil, nil, fmt.Errorf("failed to get priority fee: %w", err)
+	}
+	tipCap = (*big.Int)(&tip)
+	if minTip := new(big.Int).Mul(big.NewInt(t.config.MinTipGwei), big.NewInt(1e9)); tipCap.Cmp(minTip) < 0 {
+		tipCap = minTip
+	}
+
+	var block struct {
+		BaseFeePerGas *hexutil.Big `json:"baseFeePerGas"`
+	}
+	if err := rpcCall(ctx, t.httpClient, endpoint, "eth_getBlockByNumber", []interface{}{"latest", false}, &block); err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if block.BaseFeePerGas == nil {
+		return nil, nil, fmt.Errorf("latest block has no base fee")
+	}
+
+	feeCap = new(big.Int).Mul(block.BaseFeePerGas.ToInt(), big.NewInt(2))
+	feeCap.Add(feeCap, tipCap)
+	return tipCap, feeCap, nil
+}
+
+// usdcUnits 将 USDC 数量转换为最小单位，最多 6 位小数
+func usdcUnits(amount decimal.Decimal) (*big.Int, error) {
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive, got %s", common.ErrInvalidSize, amount)
+	}
+	units := amount.Shift(USDCDecimals)
+	if !units.IsInteger() {
+		return nil, fmt.Errorf("%w: amount %s has more than %d decimals", common.ErrInvalidSize, amount, USDCDecimals)
+	}
+	return units.BigInt(), nil
+}
+
+// encodeERC20Transfer ABI 编码 transfer(address to, uint256 amount)
+func encodeERC20Transfer(to ethcommon.Address, amount *big.Int) []byte {
+	selector, _ := hex.DecodeString(erc20TransferSelector)
+	data := make([]byte, 0, 4+64)
+	data = append(data, selector...)
+	data = append(data, ethcommon.LeftPadBytes(to.Bytes(), 32)...)
+	data = append(data, uint256Word(amount)...)
+	return data
+}
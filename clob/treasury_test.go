@@ -0,0 +1,221 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// mockChain is a minimal JSON-RPC node holding USDC balances
+type mockChain struct {
+	mu       sync.Mutex
+	t        *testing.T
+	balances map[string]*big.Int // token contract + owner (lowercase) -> units
+	sent     []*types.Transaction
+	mined    bool
+}
+
+func (m *mockChain) key(token, owner string) string {
+	return strings.ToLower(token) + "/" + strings.ToLower(owner)
+}
+
+func (m *mockChain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result interface{}
+	switch req.Method {
+	case "eth_call":
+		var call struct{ To, Data string }
+		json.Unmarshal(req.Params[0], &call)
+		owner := "0x" + call.Data[len(call.Data)-40:]
+		balance := m.balances[m.key(call.To, owner)]
+		if balance == nil {
+			balance = big.NewInt(0)
+		}
+		result = hexutil.Encode(uint256Word(balance))
+	case "eth_getTransactionCount":
+		result = "0x7"
+	case "eth_maxPriorityFeePerGas":
+		result = "0x3b9aca00" // 1 gwei, below MinTipGwei
+	case "eth_getBlockByNumber":
+		result = map[string]string{"baseFeePerGas": "0x174876e800"} // 100 gwei
+	case "eth_estimateGas":
+		result = "0xc350" // 50000
+	case "eth_sendRawTransaction":
+		var raw string
+		json.Unmarshal(req.Params[0], &raw)
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(hexutil.MustDecode(raw)); err != nil {
+			m.t.Errorf("invalid raw transaction: %v", err)
+		}
+		m.sent = append(m.sent, tx)
+		// Test collateral mint(address,uint256) credits the recipient immediately
+		if data := tx.Data(); len(data) == 68 && hexutil.Encode(data[:4]) == "0x"+erc20MintSelector {
+			key := m.key(tx.To().Hex(), ethcommon.BytesToAddress(data[4:36]).Hex())
+			if m.balances[key] == nil {
+				m.balances[key] = big.NewInt(0)
+			}
+			m.balances[key].Add(m.balances[key], new(big.Int).SetBytes(data[36:]))
+		}
+		result = tx.Hash().Hex()
+	case "eth_getTransactionReceipt":
+		if !m.mined {
+			m.mined = true
+			result = nil
+		} else {
+			result = map[string]string{"transactionHash": "0xabc", "blockNumber": "0x10", "gasUsed": "0x5208", "status": "0x1"}
+		}
+	default:
+		m.t.Errorf("unexpected rpc method %s", req.Method)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result})
+}
+
+func setupTreasury(t *testing.T) (*Treasury, *mockChain) {
+	chain := &mockChain{t: t, balances: make(map[string]*big.Int)}
+	server := httptest.NewServer(chain)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(DefaultConfig(), testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultTreasuryConfig()
+	config.RPCEndpoint = server.URL
+	config.ReceiptPollInterval = 10 * time.Millisecond
+	treasury, err := client.NewTreasury(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return treasury, chain
+}
+
+func TestTreasury_TransferBuildsSignedTx(t *testing.T) {
+	treasury, chain := setupTreasury(t)
+	from := treasury.client.GetAddress()
+	chain.balances[chain.key(USDCeAddress, from)] = big.NewInt(100_000_000) // 100 USDC.e
+
+	to := "0x1111111111111111111111111111111111111111"
+	hash, err := treasury.Transfer(context.Background(), USDCBridged, to, decimal.RequireFromString("12.5"))
+	if err != nil {
+		t.Fatalf("Transfer() error: %v", err)
+	}
+	if len(chain.sent) != 1 || chain.sent[0].Hash().Hex() != hash {
+		t.Fatalf("expected one sent tx matching %s", hash)
+	}
+
+	tx := chain.sent[0]
+	if tx.To().Hex() != ethcommon.HexToAddress(USDCeAddress).Hex() {
+		t.Errorf("tx.To = %s, expected USDC.e", tx.To().Hex())
+	}
+	if tx.Nonce() != 7 || tx.Gas() != 60000 || tx.ChainId().Int64() != 137 {
+		t.Errorf("nonce/gas/chain = %d/%d/%s", tx.Nonce(), tx.Gas(), tx.ChainId())
+	}
+	if tx.GasTipCap().Cmp(big.NewInt(30e9)) != 0 || tx.GasFeeCap().Cmp(big.NewInt(230e9)) != 0 {
+		t.Errorf("tip/feeCap = %s/%s", tx.GasTipCap(), tx.GasFeeCap())
+	}
+
+	want := encodeERC20Transfer(ethcommon.HexToAddress(to), big.NewInt(12_500_000))
+	if hexutil.Encode(tx.Data()) != hexutil.Encode(want) {
+		t.Errorf("tx data = %x", tx.Data())
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil || !strings.EqualFold(sender.Hex(), from) {
+		t.Errorf("sender = %s, %v; expected %s", sender.Hex(), err, from)
+	}
+
+	receipt, err := treasury.WaitForReceipt(context.Background(), hash)
+	if err != nil || !receipt.Success || receipt.BlockNumber != 16 {
+		t.Errorf("WaitForReceipt() = %+v, %v", receipt, err)
+	}
+}
+
+func TestTreasury_Validation(t *testing.T) {
+	treasury, chain := setupTreasury(t)
+	ctx := context.Background()
+	to := "0x1111111111111111111111111111111111111111"
+
+	if _, err := treasury.Transfer(ctx, USDCBridged, to, decimal.RequireFromString("1")); !errors.Is(err, common.ErrInsufficientBalance) {
+		t.Errorf("expected ErrInsufficientBalance, got %v", err)
+	}
+	if _, err := treasury.Transfer(ctx, USDCBridged, to, decimal.RequireFromString("0.0000001")); !errors.Is(err, common.ErrInvalidSize) {
+		t.Errorf("expected ErrInvalidSize for 7 decimals, got %v", err)
+	}
+	if _, err := treasury.Transfer(ctx, USDCBridged, "not-an-address", decimal.RequireFromString("1")); !errors.Is(err, common.ErrInvalidAddress) {
+		t.Errorf("expected ErrInvalidAddress, got %v", err)
+	}
+
+	// EOA mode: deposit is a no-op error, withdraw goes straight out of the EOA
+	if _, err := treasury.Deposit(ctx, decimal.RequireFromString("1")); err == nil {
+		t.Error("expected error depositing in EOA mode")
+	}
+
+	// Proxy mode: only native USDC available -> explain it must be swapped
+	treasury.client.SetFunderAddress("0x2222222222222222222222222222222222222222")
+	chain.balances[chain.key(NativeUSDCAddress, treasury.client.GetAddress())] = big.NewInt(50_000_000)
+	_, err := treasury.Deposit(ctx, decimal.RequireFromString("10"))
+	if !errors.Is(err, common.ErrInsufficientBalance) || !strings.Contains(err.Error(), "native USDC") {
+		t.Errorf("expected native USDC hint, got %v", err)
+	}
+	if _, err := treasury.Withdraw(ctx, to, decimal.RequireFromString("1")); err == nil {
+		t.Error("expected error withdrawing from proxy wallet")
+	}
+
+	balance, err := treasury.BalanceOf(ctx, USDCNative, treasury.client.GetAddress())
+	if err != nil || !balance.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("BalanceOf(native) = %s, %v", balance, err)
+	}
+
+	if _, err := (&Client{config: DefaultConfig()}).NewTreasury(nil); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig without RPC endpoint, got %v", err)
+	}
+}
+
+func TestTreasury_ApproveConditionalTokens(t *testing.T) {
+	treasury, chain := setupTreasury(t)
+	operator := treasury.TradingSpenders()[0]
+
+	hash, err := treasury.ApproveConditionalTokens(context.Background(), operator)
+	if err != nil {
+		t.Fatalf("ApproveConditionalTokens() error: %v", err)
+	}
+	if len(chain.sent) != 1 || chain.sent[0].Hash().Hex() != hash {
+		t.Fatalf("expected one sent tx matching %s", hash)
+	}
+
+	tx := chain.sent[0]
+	if tx.To().Hex() != ethcommon.HexToAddress(treasury.ConditionalTokensContract()).Hex() {
+		t.Errorf("tx.To = %s, expected conditional tokens contract", tx.To().Hex())
+	}
+	data := tx.Data()
+	if len(data) != 68 || hexutil.Encode(data[:4]) != "0x"+erc1155SetApprovalForAllSelector {
+		t.Fatalf("tx data = %x", data)
+	}
+	if ethcommon.BytesToAddress(data[4:36]).Hex() != ethcommon.HexToAddress(operator).Hex() || data[67] != 1 {
+		t.Errorf("expected setApprovalForAll(%s, true), got %x", operator, data[4:])
+	}
+}
@@ -2,10 +2,13 @@ package clob
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 // Timestamp 灵活的时间戳类型，可以解析数字或字符串
@@ -65,24 +68,16 @@ const (
 	OrderTypeFAK OrderType = "FAK"
 )
 
-// OrderSide 订单方向
-type OrderSide string
+// OrderSide 订单方向，与 orderbook.Side 同为 common.Side
+type OrderSide = common.Side
 
 const (
 	// OrderSideBuy 买入
-	OrderSideBuy OrderSide = "BUY"
+	OrderSideBuy = common.SideBuy
 	// OrderSideSell 卖出
-	OrderSideSell OrderSide = "SELL"
+	OrderSideSell = common.SideSell
 )
 
-// ToInt 转换为整数
-func (s OrderSide) ToInt() int {
-	if s == OrderSideBuy {
-		return 0
-	}
-	return 1
-}
-
 // OrderStatus 订单状态
 type OrderStatus string
 
@@ -141,11 +136,55 @@ type CreateOrderRequest struct {
 	ExpiresAt     int64           `json:"expiration,omitempty"`  // GTD 订单的过期时间戳
 	FeeRateBps    int             `json:"feeRateBps,omitempty"`
 	Nonce         string          `json:"nonce,omitempty"`
+	PostOnly      bool            `json:"postOnly,omitempty"`    // 仅挂单，FOK/FAK 不可用
+	Passive       bool            `json:"-"`                     // 本地模拟仅挂单，提交前按本地订单簿检查（见 Client.SetPassive），FOK/FAK 不可用
 
 	// NegRisk 标识（内部使用）
 	IsNegRisk     bool            `json:"-"`
 }
 
+// CreateMarketOrderRequest 市价单请求（Polymarket 市价单约定）
+// BUY 时 Amount 为花费的 USDC 金额，SELL 时 Amount 为卖出的份额数量
+// Price 为最差可接受价格（BUY 为上限，SELL 为下限），决定按 Amount 换算出的另一侧数量
+type CreateMarketOrderRequest struct {
+	TokenID    string          `json:"tokenID"`
+	Side       OrderSide       `json:"side"`
+	Amount     decimal.Decimal `json:"amount"`
+	Price      decimal.Decimal `json:"price"`
+	Type       OrderType       `json:"type,omitempty"` // FOK（默认）或 FAK
+	FeeRateBps int             `json:"feeRateBps,omitempty"`
+	Nonce      string          `json:"nonce,omitempty"`
+
+	// NegRisk 标识（内部使用）
+	IsNegRisk bool `json:"-"`
+}
+
+// orderType 返回订单类型，未设置时为 FOK
+func (r *CreateMarketOrderRequest) orderType() OrderType {
+	if r.Type == "" {
+		return OrderTypeFOK
+	}
+	return r.Type
+}
+
+// limitOrderView 转换为等价的限价单请求（份额按 Price 换算），用于签名公共字段、事件日志与市场状态检查
+func (r *CreateMarketOrderRequest) limitOrderView() *CreateOrderRequest {
+	size := r.Amount
+	if r.Side == OrderSideBuy && r.Price.IsPositive() {
+		size = r.Amount.Div(r.Price).Truncate(4)
+	}
+	return &CreateOrderRequest{
+		TokenID:    r.TokenID,
+		Side:       r.Side,
+		Price:      r.Price,
+		Size:       size,
+		Type:       r.orderType(),
+		FeeRateBps: r.FeeRateBps,
+		Nonce:      r.Nonce,
+		IsNegRisk:  r.IsNegRisk,
+	}
+}
+
 // SignedOrder 已签名订单
 type SignedOrder struct {
 	Salt          int64  `json:"salt"`           // 数字类型，与 Python SDK 一致
@@ -182,11 +221,90 @@ type OrdersQueryParams struct {
 }
 
 // OrderResponse 订单响应
+// 可立即成交的订单会带回本次撮合的数量与链上交易哈希，无需再调用 GetOrder
 type OrderResponse struct {
-	Success  bool   `json:"success"`
-	OrderID  string `json:"orderID,omitempty"`
-	Status   string `json:"status,omitempty"`
-	ErrorMsg string `json:"errorMsg,omitempty"`
+	Success           bool            `json:"success"`
+	OrderID           string          `json:"orderID,omitempty"`
+	Status            string          `json:"status,omitempty"`
+	ErrorMsg          string          `json:"errorMsg,omitempty"`
+	MakingAmount      decimal.Decimal `json:"makingAmount"`                 // 本次撮合中付出的数量（BUY 为 USDC，SELL 为份额）
+	TakingAmount      decimal.Decimal `json:"takingAmount"`                 // 本次撮合中获得的数量（BUY 为份额，SELL 为 USDC）
+	TransactionHashes []string        `json:"transactionsHashes,omitempty"` // 成交的链上交易哈希
+}
+
+// UnmarshalJSON 自定义 JSON 反序列化
+// 未成交时 makingAmount/takingAmount 为空字符串；交易哈希字段兼容 transactionsHashes 与 transactionHashes 两种拼写
+func (r *OrderResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Success            bool            `json:"success"`
+		OrderID            string          `json:"orderID"`
+		Status             string          `json:"status"`
+		ErrorMsg           string          `json:"errorMsg"`
+		MakingAmount       json.RawMessage `json:"makingAmount"`
+		TakingAmount       json.RawMessage `json:"takingAmount"`
+		TransactionsHashes []string        `json:"transactionsHashes"`
+		TransactionHashes  []string        `json:"transactionHashes"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	making, err := parseAmount(raw.MakingAmount)
+	if err != nil {
+		return fmt.Errorf("invalid makingAmount: %w", err)
+	}
+	taking, err := parseAmount(raw.TakingAmount)
+	if err != nil {
+		return fmt.Errorf("invalid takingAmount: %w", err)
+	}
+
+	*r = OrderResponse{
+		Success:           raw.Success,
+		OrderID:           raw.OrderID,
+		Status:            raw.Status,
+		ErrorMsg:          raw.ErrorMsg,
+		MakingAmount:      making,
+		TakingAmount:      taking,
+		TransactionHashes: raw.TransactionsHashes,
+	}
+	if len(r.TransactionHashes) == 0 {
+		r.TransactionHashes = raw.TransactionHashes
+	}
+	return nil
+}
+
+// parseAmount 解析数量字段，兼容字符串与数字，缺失、null 或空字符串视为 0
+func parseAmount(data json.RawMessage) (decimal.Decimal, error) {
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return decimal.Zero, err
+		}
+	}
+	if s == "" || s == "null" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(s)
+}
+
+// MatchedSize 本次撮合成交的份额数量，side 为下单方向
+func (r *OrderResponse) MatchedSize(side OrderSide) decimal.Decimal {
+	if side == OrderSideBuy {
+		return r.TakingAmount
+	}
+	return r.MakingAmount
+}
+
+// AvgFillPrice 本次撮合的成交均价，未成交时返回 0
+func (r *OrderResponse) AvgFillPrice(side OrderSide) decimal.Decimal {
+	size, notional := r.TakingAmount, r.MakingAmount
+	if side != OrderSideBuy {
+		size, notional = r.MakingAmount, r.TakingAmount
+	}
+	if !size.IsPositive() {
+		return decimal.Zero
+	}
+	return notional.Div(size)
 }
 
 // Trade 成交记录
@@ -282,6 +400,50 @@ type BatchCancelRequest struct {
 type CancelResponse struct {
 	Canceled []string `json:"canceled,omitempty"`
 	NotCanceled []string `json:"not_canceled,omitempty"`
+	NotCanceledReasons map[string]string `json:"-"` // 未撤销订单的原因（交易所返回原因时填充），分类见 Results
+}
+
+// Market CLOB 市场信息（/markets/{condition_id}）
+type Market struct {
+	ConditionID      string          `json:"condition_id"`
+	QuestionID       string          `json:"question_id"`
+	Question         string          `json:"question"`
+	MarketSlug       string          `json:"market_slug"`
+	Tokens           []MarketToken   `json:"tokens"`
+	Active           bool            `json:"active"`
+	Closed           bool            `json:"closed"`
+	Archived         bool            `json:"archived"`
+	AcceptingOrders  bool            `json:"accepting_orders"`
+	EnableOrderBook  bool            `json:"enable_order_book"`
+	NegRisk          bool            `json:"neg_risk"`
+	NegRiskMarketID  string          `json:"neg_risk_market_id,omitempty"`
+	MinimumOrderSize decimal.Decimal `json:"minimum_order_size"`
+	MinimumTickSize  decimal.Decimal `json:"minimum_tick_size"`
+	MakerBaseFee     int             `json:"maker_base_fee"`
+	TakerBaseFee     int             `json:"taker_base_fee"`
+	EndDateISO       string          `json:"end_date_iso,omitempty"`
+	Rewards          MarketRewards   `json:"rewards"`
+}
+
+// MarketRewards 市场流动性奖励参数
+type MarketRewards struct {
+	Rates     []RewardRate    `json:"rates"`
+	MinSize   decimal.Decimal `json:"min_size"`   // 计分的最小挂单数量
+	MaxSpread decimal.Decimal `json:"max_spread"` // 距中间价的最大计分距离（美分）
+}
+
+// RewardRate 奖励发放速率
+type RewardRate struct {
+	AssetAddress     string          `json:"asset_address"`
+	RewardsDailyRate decimal.Decimal `json:"rewards_daily_rate"`
+}
+
+// MarketToken CLOB 市场中的 token
+type MarketToken struct {
+	TokenID string          `json:"token_id"`
+	Outcome string          `json:"outcome"`
+	Price   decimal.Decimal `json:"price"`
+	Winner  bool            `json:"winner"`
 }
 
 // TickSize 价格最小变动单位
@@ -1,8 +1,8 @@
 package clob
 
 import (
+	"encoding/json"
 	"testing"
-	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -214,7 +214,7 @@ func TestCreateOrderRequest(t *testing.T) {
 
 func TestSignedOrder(t *testing.T) {
 	order := &SignedOrder{
-		Salt:          "12345",
+		Salt:          12345,
 		Maker:         "0x1234",
 		Signer:        "0x1234",
 		Taker:         "0x0000000000000000000000000000000000000000",
@@ -229,7 +229,7 @@ func TestSignedOrder(t *testing.T) {
 		Signature:     "0xabcdef",
 	}
 
-	if order.Salt != "12345" {
+	if order.Salt != 12345 {
 		t.Error("Salt mismatch")
 	}
 	if order.SignatureType != 0 {
@@ -288,17 +288,16 @@ func TestBalanceAllowance(t *testing.T) {
 }
 
 func TestTrade(t *testing.T) {
-	now := time.Now()
 	trade := &Trade{
-		ID:        "trade-123",
-		Market:    "market-456",
-		AssetID:   "asset-789",
-		Side:      OrderSideBuy,
-		Price:     decimal.NewFromFloat(0.65),
-		Size:      decimal.NewFromInt(50),
-		Fee:       decimal.NewFromFloat(0.01),
-		Timestamp: now,
-		TradeType: "MAKER",
+		ID:         "trade-123",
+		Market:     "market-456",
+		AssetID:    "asset-789",
+		Side:       OrderSideBuy,
+		Price:      decimal.NewFromFloat(0.65),
+		Size:       decimal.NewFromInt(50),
+		FeeRateBPS: "100",
+		MatchTime:  "1700000000",
+		TraderSide: "MAKER",
 	}
 
 	if trade.ID != "trade-123" {
@@ -307,8 +306,8 @@ func TestTrade(t *testing.T) {
 	if trade.Side != OrderSideBuy {
 		t.Error("Side mismatch")
 	}
-	if trade.TradeType != "MAKER" {
-		t.Error("TradeType mismatch")
+	if trade.TraderSide != "MAKER" {
+		t.Error("TraderSide mismatch")
 	}
 }
 
@@ -392,3 +391,62 @@ func TestPriceInfo(t *testing.T) {
 		t.Error("Price mismatch")
 	}
 }
+
+func TestOrderResponseUnmarshal(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		making     string
+		taking     string
+		hashes     int
+		side       OrderSide
+		size, avgP string
+	}{
+		{
+			name:   "matched buy",
+			data:   `{"success":true,"orderID":"0xabc","status":"matched","makingAmount":"5.2","takingAmount":"10","transactionsHashes":["0x1","0x2"]}`,
+			making: "5.2", taking: "10", hashes: 2, side: OrderSideBuy, size: "10", avgP: "0.52",
+		},
+		{
+			name:   "matched sell with alternate hash key",
+			data:   `{"success":true,"status":"matched","makingAmount":"10","takingAmount":"4.8","transactionHashes":["0x1"]}`,
+			making: "10", taking: "4.8", hashes: 1, side: OrderSideSell, size: "10", avgP: "0.48",
+		},
+		{
+			name:   "resting order with empty amounts",
+			data:   `{"success":true,"orderID":"0xabc","status":"live","makingAmount":"","takingAmount":""}`,
+			making: "0", taking: "0", side: OrderSideBuy, size: "0", avgP: "0",
+		},
+		{
+			name:   "numeric amounts",
+			data:   `{"success":true,"makingAmount":3,"takingAmount":6}`,
+			making: "3", taking: "6", side: OrderSideBuy, size: "6", avgP: "0.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp OrderResponse
+			if err := json.Unmarshal([]byte(tt.data), &resp); err != nil {
+				t.Fatalf("Unmarshal() error: %v", err)
+			}
+			if !resp.MakingAmount.Equal(decimal.RequireFromString(tt.making)) || !resp.TakingAmount.Equal(decimal.RequireFromString(tt.taking)) {
+				t.Errorf("making/taking = %s/%s, expected %s/%s", resp.MakingAmount, resp.TakingAmount, tt.making, tt.taking)
+			}
+			if len(resp.TransactionHashes) != tt.hashes {
+				t.Errorf("TransactionHashes = %v, expected %d", resp.TransactionHashes, tt.hashes)
+			}
+			if got := resp.MatchedSize(tt.side); !got.Equal(decimal.RequireFromString(tt.size)) {
+				t.Errorf("MatchedSize() = %s, expected %s", got, tt.size)
+			}
+			if got := resp.AvgFillPrice(tt.side); !got.Equal(decimal.RequireFromString(tt.avgP)) {
+				t.Errorf("AvgFillPrice() = %s, expected %s", got, tt.avgP)
+			}
+		})
+	}
+
+	var resp OrderResponse
+	if err := json.Unmarshal([]byte(`{"makingAmount":"abc"}`), &resp); err == nil {
+		t.Error("expected error for invalid makingAmount")
+	}
+}
@@ -0,0 +1,159 @@
+package clob
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// OrderValidationError 订单客户端校验错误
+// Err 为 common 包中对应的哨兵错误，可用 errors.Is 判断
+type OrderValidationError struct {
+	Field  string
+	Reason string
+	Err    error
+}
+
+// Error 实现 error 接口
+func (e *OrderValidationError) Error() string {
+	return fmt.Sprintf("%v: %s: %s", e.Err, e.Field, e.Reason)
+}
+
+// Unwrap 返回底层哨兵错误
+func (e *OrderValidationError) Unwrap() error {
+	return e.Err
+}
+
+func newValidationError(field string, err error, format string, args ...interface{}) *OrderValidationError {
+	return &OrderValidationError{
+		Field:  field,
+		Reason: fmt.Sprintf(format, args...),
+		Err:    err,
+	}
+}
+
+// ValidateOrderRequest 在签名前校验订单请求
+// 规则:
+//   - tokenID 必填，side 必须为 BUY/SELL
+//   - price 必须在 (0, 1) 区间内，size 必须大于 0
+//   - type 必填，必须为 GTC/GTD/FOK/FAK
+//   - GTD 必须设置过期时间，其他类型不能设置过期时间
+//   - FOK/FAK 不能使用 postOnly 或 passive
+func ValidateOrderRequest(req *CreateOrderRequest) error {
+	if req == nil {
+		return newValidationError("order", common.ErrInvalidOrder, "request is nil")
+	}
+
+	if req.TokenID == "" {
+		return newValidationError("tokenID", common.ErrInvalidOrder, "token ID is required")
+	}
+
+	switch req.Side {
+	case OrderSideBuy, OrderSideSell:
+	default:
+		return newValidationError("side", common.ErrInvalidOrderSide, "must be BUY or SELL, got %q", req.Side)
+	}
+
+	if !req.Price.IsPositive() || req.Price.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return newValidationError("price", common.ErrInvalidPrice, "must be between 0 and 1 (exclusive), got %s", req.Price)
+	}
+
+	if !req.Size.IsPositive() {
+		return newValidationError("size", common.ErrInvalidSize, "must be positive, got %s", req.Size)
+	}
+
+	orderType := req.Type
+	switch orderType {
+	case OrderTypeGTD:
+		if req.ExpiresAt <= 0 {
+			return newValidationError("expiration", common.ErrInvalidOrderType, "GTD order requires an expiration")
+		}
+	case OrderTypeGTC, OrderTypeFOK, OrderTypeFAK:
+		if req.ExpiresAt != 0 {
+			return newValidationError("expiration", common.ErrInvalidOrderType, "%s order must not set an expiration", orderType)
+		}
+	case "":
+		return newValidationError("type", common.ErrInvalidOrderType, "order type is required, must be GTC/GTD/FOK/FAK")
+	default:
+		return newValidationError("type", common.ErrInvalidOrderType, "must be GTC/GTD/FOK/FAK, got %q", req.Type)
+	}
+
+	if req.PostOnly && (orderType == OrderTypeFOK || orderType == OrderTypeFAK) {
+		return newValidationError("postOnly", common.ErrInvalidOrderType, "postOnly is not allowed with %s orders", orderType)
+	}
+	if req.Passive && (orderType == OrderTypeFOK || orderType == OrderTypeFAK) {
+		return newValidationError("passive", common.ErrInvalidOrderType, "passive is not allowed with %s orders", orderType)
+	}
+
+	return nil
+}
+
+// ValidateMarketOrderRequest 在签名前校验市价单请求
+// 规则:
+//   - tokenID 必填，side 必须为 BUY/SELL
+//   - price（最差可接受价格）必须在 (0, 1) 区间内
+//   - amount 截断到 2 位小数后必须大于 0
+//   - type 为空时按 FOK 处理，否则必须为 FOK/FAK
+func ValidateMarketOrderRequest(req *CreateMarketOrderRequest) error {
+	if req == nil {
+		return newValidationError("order", common.ErrInvalidOrder, "request is nil")
+	}
+
+	if req.TokenID == "" {
+		return newValidationError("tokenID", common.ErrInvalidOrder, "token ID is required")
+	}
+
+	switch req.Side {
+	case OrderSideBuy, OrderSideSell:
+	default:
+		return newValidationError("side", common.ErrInvalidOrderSide, "must be BUY or SELL, got %q", req.Side)
+	}
+
+	if !req.Price.IsPositive() || req.Price.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return newValidationError("price", common.ErrInvalidPrice, "must be between 0 and 1 (exclusive), got %s", req.Price)
+	}
+
+	if !req.Amount.Truncate(2).IsPositive() {
+		return newValidationError("amount", common.ErrInvalidSize, "must be at least 0.01, got %s", req.Amount)
+	}
+
+	switch req.orderType() {
+	case OrderTypeFOK, OrderTypeFAK:
+	default:
+		return newValidationError("type", common.ErrInvalidOrderType, "market orders must be FOK or FAK, got %q", req.Type)
+	}
+
+	return nil
+}
+
+// ValidateFOKAvailability 校验 FOK 订单是否可以完全成交
+// availableSize 为订单价格内对手盘的可成交数量；非 FOK 订单直接通过
+func ValidateFOKAvailability(req *CreateOrderRequest, availableSize decimal.Decimal) error {
+	if req == nil || req.Type != OrderTypeFOK {
+		return nil
+	}
+
+	if availableSize.LessThan(req.Size) {
+		return newValidationError("size", common.ErrInvalidSize,
+			"FOK order requires full size %s, only %s available", req.Size, availableSize)
+	}
+
+	return nil
+}
+
+// ValidateMarketFOKAvailability 校验 FOK 市价单是否可以完全成交
+// available 为最差可接受价格内对手盘的可成交量，单位同 Amount（BUY 为 USDC 金额，SELL 为份额）；非 FOK 订单直接通过
+func ValidateMarketFOKAvailability(req *CreateMarketOrderRequest, available decimal.Decimal) error {
+	if req == nil || req.orderType() != OrderTypeFOK {
+		return nil
+	}
+
+	if available.LessThan(req.Amount) {
+		return newValidationError("amount", common.ErrInvalidSize,
+			"FOK order requires full amount %s, only %s available", req.Amount, available)
+	}
+
+	return nil
+}
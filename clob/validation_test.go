@@ -0,0 +1,217 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func validOrderRequest() *CreateOrderRequest {
+	return &CreateOrderRequest{
+		TokenID: "12345",
+		Side:    OrderSideBuy,
+		Price:   decimal.NewFromFloat(0.55),
+		Size:    decimal.NewFromInt(100),
+		Type:    OrderTypeGTC,
+	}
+}
+
+func TestValidateOrderRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(r *CreateOrderRequest)
+		wantErr error
+		field   string
+	}{
+		{"valid GTC", func(r *CreateOrderRequest) {}, nil, ""},
+		{"valid GTD", func(r *CreateOrderRequest) { r.Type = OrderTypeGTD; r.ExpiresAt = 1900000000 }, nil, ""},
+		{"valid FOK", func(r *CreateOrderRequest) { r.Type = OrderTypeFOK }, nil, ""},
+		{"GTC post only", func(r *CreateOrderRequest) { r.PostOnly = true }, nil, ""},
+		{"missing token", func(r *CreateOrderRequest) { r.TokenID = "" }, common.ErrInvalidOrder, "tokenID"},
+		{"invalid side", func(r *CreateOrderRequest) { r.Side = "HOLD" }, common.ErrInvalidOrderSide, "side"},
+		{"zero price", func(r *CreateOrderRequest) { r.Price = decimal.Zero }, common.ErrInvalidPrice, "price"},
+		{"price one", func(r *CreateOrderRequest) { r.Price = decimal.NewFromInt(1) }, common.ErrInvalidPrice, "price"},
+		{"negative size", func(r *CreateOrderRequest) { r.Size = decimal.NewFromInt(-1) }, common.ErrInvalidSize, "size"},
+		{"empty type", func(r *CreateOrderRequest) { r.Type = "" }, common.ErrInvalidOrderType, "type"},
+		{"unknown type", func(r *CreateOrderRequest) { r.Type = "IOC" }, common.ErrInvalidOrderType, "type"},
+		{"GTD without expiration", func(r *CreateOrderRequest) { r.Type = OrderTypeGTD }, common.ErrInvalidOrderType, "expiration"},
+		{"GTC with expiration", func(r *CreateOrderRequest) { r.ExpiresAt = 1900000000 }, common.ErrInvalidOrderType, "expiration"},
+		{"FOK post only", func(r *CreateOrderRequest) { r.Type = OrderTypeFOK; r.PostOnly = true }, common.ErrInvalidOrderType, "postOnly"},
+		{"FAK post only", func(r *CreateOrderRequest) { r.Type = OrderTypeFAK; r.PostOnly = true }, common.ErrInvalidOrderType, "postOnly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validOrderRequest()
+			tt.mutate(req)
+
+			err := ValidateOrderRequest(req)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("ValidateOrderRequest() error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateOrderRequest() error = %v, expected %v", err, tt.wantErr)
+			}
+			var verr *OrderValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("error should be *OrderValidationError, got %T", err)
+			}
+			if verr.Field != tt.field {
+				t.Errorf("Field = %s, expected %s", verr.Field, tt.field)
+			}
+		})
+	}
+}
+
+func TestValidateOrderRequestNil(t *testing.T) {
+	if err := ValidateOrderRequest(nil); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("ValidateOrderRequest(nil) error = %v, expected ErrInvalidOrder", err)
+	}
+}
+
+func TestValidateFOKAvailability(t *testing.T) {
+	req := validOrderRequest()
+	req.Type = OrderTypeFOK
+
+	if err := ValidateFOKAvailability(req, decimal.NewFromInt(100)); err != nil {
+		t.Errorf("full size available should pass, got %v", err)
+	}
+	if err := ValidateFOKAvailability(req, decimal.NewFromInt(99)); !errors.Is(err, common.ErrInvalidSize) {
+		t.Errorf("partial availability error = %v, expected ErrInvalidSize", err)
+	}
+
+	req.Type = OrderTypeFAK
+	if err := ValidateFOKAvailability(req, decimal.Zero); err != nil {
+		t.Errorf("non-FOK order should pass, got %v", err)
+	}
+}
+
+func TestValidateMarketFOKAvailability(t *testing.T) {
+	req := &CreateMarketOrderRequest{TokenID: "12345", Side: OrderSideBuy, Amount: decimal.NewFromInt(10), Price: decimal.NewFromFloat(0.6)}
+
+	if err := ValidateMarketFOKAvailability(req, decimal.NewFromInt(10)); err != nil {
+		t.Errorf("full amount available should pass, got %v", err)
+	}
+	if err := ValidateMarketFOKAvailability(req, decimal.NewFromInt(9)); !errors.Is(err, common.ErrInvalidSize) {
+		t.Errorf("partial availability error = %v, expected ErrInvalidSize", err)
+	}
+
+	req.Type = OrderTypeFAK
+	if err := ValidateMarketFOKAvailability(req, decimal.Zero); err != nil {
+		t.Errorf("FAK order should pass, got %v", err)
+	}
+}
+
+func TestCreateOrderValidatesBeforeSigning(t *testing.T) {
+	client, err := NewClient(nil, testPrivKey)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	ctx := context.Background()
+
+	// Empty type is rejected with the same typed error as ValidateOrderRequest
+	req := validOrderRequest()
+	req.Type = ""
+	var verr *OrderValidationError
+	if _, err := client.CreateOrder(ctx, req); !errors.As(err, &verr) || verr.Field != "type" {
+		t.Errorf("CreateOrder() empty type error = %v, expected *OrderValidationError on type", err)
+	}
+	if _, err := client.CreateOrders(ctx, []*CreateOrderRequest{req}); !errors.As(err, &verr) || verr.Field != "type" {
+		t.Errorf("CreateOrders() empty type error = %v, expected *OrderValidationError on type", err)
+	}
+
+	// FOK orders are checked against the local book: 60 shares at or below 0.55
+	client.SetBookSource(func(tokenID string, side OrderSide) ([]BookLevel, bool) {
+		return []BookLevel{
+			{Price: decimal.RequireFromString("0.5"), Size: decimal.NewFromInt(40)},
+			{Price: decimal.RequireFromString("0.55"), Size: decimal.NewFromInt(20)},
+			{Price: decimal.RequireFromString("0.6"), Size: decimal.NewFromInt(100)},
+		}, true
+	})
+	fok := validOrderRequest()
+	fok.Type = OrderTypeFOK
+	if _, err := client.CreateOrder(ctx, fok); !errors.Is(err, common.ErrInvalidSize) {
+		t.Errorf("CreateOrder() FOK error = %v, expected ErrInvalidSize", err)
+	}
+	if _, err := client.CreateOrders(ctx, []*CreateOrderRequest{fok}); !errors.Is(err, common.ErrInvalidSize) {
+		t.Errorf("CreateOrders() FOK error = %v, expected ErrInvalidSize", err)
+	}
+	preSigned, err := client.CreatePreSignedOrder(fok)
+	if err != nil {
+		t.Fatalf("CreatePreSignedOrder() error: %v", err)
+	}
+	if _, err := client.SubmitPreSignedOrder(ctx, preSigned); !errors.Is(err, common.ErrInvalidSize) {
+		t.Errorf("SubmitPreSignedOrder() FOK error = %v, expected ErrInvalidSize", err)
+	}
+
+	// A market buy of 40 USDC at worst 0.55 can only spend 31 USDC
+	market := &CreateMarketOrderRequest{TokenID: "12345", Side: OrderSideBuy, Amount: decimal.NewFromInt(40), Price: decimal.RequireFromString("0.55")}
+	if _, err := client.CreateMarketOrder(ctx, market); !errors.Is(err, common.ErrInvalidSize) {
+		t.Errorf("CreateMarketOrder() FOK error = %v, expected ErrInvalidSize", err)
+	}
+}
+
+func TestCreatePreSignedOrderValidation(t *testing.T) {
+	client, err := NewClient(nil, testPrivKey)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+
+	req := validOrderRequest()
+	req.Type = OrderTypeFOK
+	req.PostOnly = true
+
+	if _, err := client.CreatePreSignedOrder(req); !errors.Is(err, common.ErrInvalidOrderType) {
+		t.Errorf("CreatePreSignedOrder() error = %v, expected ErrInvalidOrderType", err)
+	}
+
+	req.PostOnly = false
+	preSigned, err := client.CreatePreSignedOrder(req)
+	if err != nil {
+		t.Fatalf("CreatePreSignedOrder() error: %v", err)
+	}
+	if preSigned.PostRequest.OrderType != OrderTypeFOK {
+		t.Errorf("OrderType = %s, expected FOK", preSigned.PostRequest.OrderType)
+	}
+}
+
+func TestValidateMarketOrderRequest(t *testing.T) {
+	valid := func() *CreateMarketOrderRequest {
+		return &CreateMarketOrderRequest{
+			TokenID: "12345",
+			Side:    OrderSideBuy,
+			Amount:  decimal.NewFromInt(10),
+			Price:   decimal.NewFromFloat(0.6),
+		}
+	}
+
+	if err := ValidateMarketOrderRequest(valid()); err != nil {
+		t.Errorf("valid request error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*CreateMarketOrderRequest)
+		want   error
+	}{
+		{"dust amount", func(r *CreateMarketOrderRequest) { r.Amount = decimal.NewFromFloat(0.004) }, common.ErrInvalidSize},
+		{"missing price", func(r *CreateMarketOrderRequest) { r.Price = decimal.Zero }, common.ErrInvalidPrice},
+		{"GTC type", func(r *CreateMarketOrderRequest) { r.Type = OrderTypeGTC }, common.ErrInvalidOrderType},
+		{"bad side", func(r *CreateMarketOrderRequest) { r.Side = "HOLD" }, common.ErrInvalidOrderSide},
+	}
+	for _, tt := range tests {
+		req := valid()
+		tt.mutate(req)
+		if err := ValidateMarketOrderRequest(req); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, expected %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package clob
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// WatchdogReason 看门狗触发原因
+type WatchdogReason string
+
+const (
+	// WatchdogHeartbeatTimeout 策略心跳超时
+	WatchdogHeartbeatTimeout WatchdogReason = "heartbeat_timeout"
+	// WatchdogFeedStale 行情停滞
+	WatchdogFeedStale WatchdogReason = "feed_stale"
+)
+
+// WatchdogConfig 撤单看门狗配置，超时项为 0 表示不检查
+type WatchdogConfig struct {
+	HeartbeatTimeout time.Duration // 策略心跳超时
+	FeedStaleAfter   time.Duration // 行情停滞阈值
+	CheckInterval    time.Duration // 检查间隔
+	CancelTimeout    time.Duration // 撤单请求超时
+}
+
+// DefaultWatchdogConfig 默认配置
+func DefaultWatchdogConfig() *WatchdogConfig {
+	return &WatchdogConfig{
+		HeartbeatTimeout: 10 * time.Second,
+		FeedStaleAfter:   30 * time.Second,
+		CheckInterval:    time.Second,
+		CancelTimeout:    10 * time.Second,
+	}
+}
+
+// WatchdogTrip 看门狗触发记录
+type WatchdogTrip struct {
+	Reason WatchdogReason
+	Time   time.Time
+	Age    time.Duration // 触发时心跳/行情已停滞的时长
+	Err    error         // 撤单失败时的错误，下次检查会重试
+}
+
+// OrderCanceler 撤销全部订单（*Client 已实现）
+type OrderCanceler interface {
+	CancelAllOrders(ctx context.Context) error
+}
+
+// CancelWatchdog 撤单看门狗（客户端模拟 cancel-on-disconnect）
+// Polymarket 没有服务端的断线撤单，策略心跳超时或行情停滞超过阈值时撤销全部订单；
+// 触发后不再重复撤单，直到心跳与行情均恢复正常
+type CancelWatchdog struct {
+	mu sync.Mutex
+
+	canceler OrderCanceler
+	config   *WatchdogConfig
+
+	feedSource    func() time.Time
+	lastHeartbeat time.Time
+	lastFeed      time.Time
+	startedAt     time.Time
+
+	tripped  bool // 已触发且撤单成功
+	tripChan chan WatchdogTrip
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	now    func() time.Time
+}
+
+// NewCancelWatchdog 创建撤单看门狗
+func NewCancelWatchdog(canceler OrderCanceler, config *WatchdogConfig) *CancelWatchdog {
+	if config == nil {
+		config = DefaultWatchdogConfig()
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = time.Second
+	}
+	if config.CancelTimeout <= 0 {
+		config.CancelTimeout = 10 * time.Second
+	}
+
+	return &CancelWatchdog{
+		canceler: canceler,
+		config:   config,
+		tripChan: make(chan WatchdogTrip, 16),
+		now:      time.Now,
+	}
+}
+
+// SetFeedSource 设置行情最近更新时间的来源（如 orderbook.SDK.LastMessageTime）
+// 未设置时使用 FeedUpdate 手动上报
+func (w *CancelWatchdog) SetFeedSource(source func() time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.feedSource = source
+}
+
+// Heartbeat 策略心跳，应在策略主循环中定期调用
+func (w *CancelWatchdog) Heartbeat() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lastHeartbeat = w.now()
+}
+
+// FeedUpdate 上报收到行情
+func (w *CancelWatchdog) FeedUpdate() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lastFeed = w.now()
+}
+
+// Trips 获取触发记录 channel（满时丢弃）
+func (w *CancelWatchdog) Trips() <-chan WatchdogTrip {
+	return w.tripChan
+}
+
+// Tripped 是否处于已触发状态
+func (w *CancelWatchdog) Tripped() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.tripped
+}
+
+// Start 启动看门狗，心跳与行情计时从此刻开始
+func (w *CancelWatchdog) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		return
+	}
+
+	now := w.now()
+	w.startedAt = now
+	w.lastHeartbeat = now
+	w.tripped = false
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		common.Supervise(ctx, "clob.watchdog", nil, w.loop)
+	}()
+}
+
+// Stop 停止看门狗
+func (w *CancelWatchdog) Stop() {
+	w.mu.Lock()
+	cancel := w.cancel
+	w.cancel = nil
+	w.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		w.wg.Wait()
+	}
+}
+
+// loop 定期检查
+func (w *CancelWatchdog) loop(ctx context.Context) {
+	ticker := time.NewTicker(w.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+// check 检查心跳与行情，需要时撤销全部订单
+func (w *CancelWatchdog) check(ctx context.Context) {
+	reason, age := w.evaluate()
+
+	w.mu.Lock()
+	if reason == "" {
+		// 恢复正常后重新就绪
+		w.tripped = false
+		w.mu.Unlock()
+		return
+	}
+	if w.tripped {
+		w.mu.Unlock()
+		return
+	}
+	w.mu.Unlock()
+
+	cancelCtx, cancel := context.WithTimeout(ctx, w.config.CancelTimeout)
+	err := w.canceler.CancelAllOrders(cancelCtx)
+	cancel()
+
+	w.mu.Lock()
+	w.tripped = err == nil
+	trip := WatchdogTrip{Reason: reason, Time: w.now(), Age: age, Err: err}
+	w.mu.Unlock()
+
+	select {
+	case w.tripChan <- trip:
+	default:
+	}
+}
+
+// evaluate 判断是否需要触发
+func (w *CancelWatchdog) evaluate() (WatchdogReason, time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+
+	if w.config.HeartbeatTimeout > 0 {
+		if age := now.Sub(w.lastHeartbeat); age > w.config.HeartbeatTimeout {
+			return WatchdogHeartbeatTimeout, age
+		}
+	}
+
+	if w.config.FeedStaleAfter > 0 {
+		last := w.lastFeed
+		if w.feedSource != nil {
+			if t := w.feedSource(); t.After(last) {
+				last = t
+			}
+		}
+		// 从未收到行情时从启动时刻计时
+		if last.Before(w.startedAt) {
+			last = w.startedAt
+		}
+		if age := now.Sub(last); age > w.config.FeedStaleAfter {
+			return WatchdogFeedStale, age
+		}
+	}
+
+	return "", 0
+}
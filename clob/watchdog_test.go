@@ -0,0 +1,130 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeCanceler struct {
+	calls int32
+	err   error
+}
+
+func (f *fakeCanceler) CancelAllOrders(ctx context.Context) error {
+	atomic.AddInt32(&f.calls, 1)
+	return f.err
+}
+
+func newTestWatchdog(canceler OrderCanceler, config *WatchdogConfig) (*CancelWatchdog, *time.Time) {
+	w := NewCancelWatchdog(canceler, config)
+	now := time.Unix(1000, 0)
+	w.now = func() time.Time { return now }
+	w.startedAt = now
+	w.lastHeartbeat = now
+	return w, &now
+}
+
+func TestCancelWatchdog_HeartbeatTimeout(t *testing.T) {
+	canceler := &fakeCanceler{}
+	w, now := newTestWatchdog(canceler, &WatchdogConfig{HeartbeatTimeout: 5 * time.Second})
+	ctx := context.Background()
+
+	*now = now.Add(3 * time.Second)
+	w.check(ctx)
+	if canceler.calls != 0 {
+		t.Fatal("Should not cancel before heartbeat timeout")
+	}
+
+	*now = now.Add(3 * time.Second)
+	w.check(ctx)
+	if canceler.calls != 1 || !w.Tripped() {
+		t.Fatalf("Expected cancel on heartbeat timeout, calls=%d", canceler.calls)
+	}
+
+	trip := <-w.Trips()
+	if trip.Reason != WatchdogHeartbeatTimeout || trip.Age != 6*time.Second || trip.Err != nil {
+		t.Errorf("Unexpected trip: %+v", trip)
+	}
+
+	// Stays tripped without cancelling again
+	*now = now.Add(time.Second)
+	w.check(ctx)
+	if canceler.calls != 1 {
+		t.Errorf("Expected no repeated cancel, calls=%d", canceler.calls)
+	}
+
+	// Heartbeat resumes, watchdog re-arms
+	w.Heartbeat()
+	w.check(ctx)
+	if w.Tripped() {
+		t.Error("Expected watchdog to re-arm after heartbeat")
+	}
+}
+
+func TestCancelWatchdog_FeedStale(t *testing.T) {
+	canceler := &fakeCanceler{}
+	w, now := newTestWatchdog(canceler, &WatchdogConfig{FeedStaleAfter: 10 * time.Second})
+
+	var feedAt time.Time
+	w.SetFeedSource(func() time.Time { return feedAt })
+
+	// Feed never received: measured from start
+	*now = now.Add(8 * time.Second)
+	feedAt = *now
+	*now = now.Add(8 * time.Second)
+	w.check(context.Background())
+	if canceler.calls != 0 {
+		t.Fatal("Feed is fresh, should not cancel")
+	}
+
+	*now = now.Add(5 * time.Second)
+	w.check(context.Background())
+	if canceler.calls != 1 {
+		t.Fatalf("Expected cancel on stale feed, calls=%d", canceler.calls)
+	}
+	if trip := <-w.Trips(); trip.Reason != WatchdogFeedStale {
+		t.Errorf("Unexpected reason: %s", trip.Reason)
+	}
+}
+
+func TestCancelWatchdog_RetriesFailedCancel(t *testing.T) {
+	canceler := &fakeCanceler{err: errors.New("network down")}
+	w, now := newTestWatchdog(canceler, &WatchdogConfig{HeartbeatTimeout: time.Second})
+
+	*now = now.Add(2 * time.Second)
+	w.check(context.Background())
+	w.check(context.Background())
+
+	if canceler.calls != 2 {
+		t.Errorf("Expected cancel to be retried, calls=%d", canceler.calls)
+	}
+	if w.Tripped() {
+		t.Error("Failed cancel should not mark watchdog as tripped")
+	}
+	if trip := <-w.Trips(); trip.Err == nil {
+		t.Error("Expected trip to carry the cancel error")
+	}
+}
+
+func TestCancelWatchdog_StartStop(t *testing.T) {
+	canceler := &fakeCanceler{}
+	w := NewCancelWatchdog(canceler, &WatchdogConfig{
+		HeartbeatTimeout: 20 * time.Millisecond,
+		CheckInterval:    5 * time.Millisecond,
+	})
+
+	w.Start()
+	defer w.Stop()
+
+	select {
+	case trip := <-w.Trips():
+		if trip.Reason != WatchdogHeartbeatTimeout {
+			t.Errorf("Unexpected reason: %s", trip.Reason)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for watchdog trip")
+	}
+}
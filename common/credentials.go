@@ -0,0 +1,34 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Credentials API 凭证（L2 认证），不依赖签名库，只读取用户频道等场景无需引入 auth
+type Credentials struct {
+	APIKey     string `json:"apiKey"`
+	Secret     string `json:"secret"` // Base64 编码
+	Passphrase string `json:"passphrase"`
+}
+
+// clientIDLength ClientID 的十六进制长度
+const clientIDLength = 16
+
+// ClientID 由 API Key 派生的稳定标识（SHA-256 前 8 字节的十六进制），apiKey 为空时返回空字符串
+// 可写入日志、指标与订单记录，区分多凭证部署中是哪组凭证下的单，而不暴露原始 API Key
+func ClientID(apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])[:clientIDLength]
+}
+
+// ClientID 凭证的稳定标识，见 ClientID 函数
+func (c *Credentials) ClientID() string {
+	if c == nil {
+		return ""
+	}
+	return ClientID(c.APIKey)
+}
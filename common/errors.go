@@ -7,36 +7,47 @@ import (
 
 // 通用错误
 var (
-	ErrNotInitialized     = errors.New("not initialized")
-	ErrInvalidConfig      = errors.New("invalid configuration")
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrInvalidPrivateKey  = errors.New("invalid private key")
-	ErrUnauthorized       = errors.New("unauthorized")
-	ErrForbidden          = errors.New("forbidden")
-	ErrNotFound           = errors.New("not found")
-	ErrRateLimited        = errors.New("rate limited")
-	ErrServerError        = errors.New("server error")
-	ErrTimeout            = errors.New("request timeout")
-	ErrBadRequest         = errors.New("bad request")
+	ErrNotInitialized      = errors.New("not initialized")
+	ErrInvalidConfig       = errors.New("invalid configuration")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrInvalidPrivateKey   = errors.New("invalid private key")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrForbidden           = errors.New("forbidden")
+	ErrNotFound            = errors.New("not found")
+	ErrRateLimited         = errors.New("rate limited")
+	ErrServerError         = errors.New("server error")
+	ErrTimeout             = errors.New("request timeout")
+	ErrBadRequest          = errors.New("bad request")
+	ErrCredentialsNotFound = errors.New("credentials not found")
+	ErrCredentialsRevoked  = errors.New("credentials revoked or invalid")
+	ErrClockSkew           = errors.New("local clock skew too large")
+	ErrPanicRecovered      = errors.New("recovered from panic")
+	ErrSessionClosed       = errors.New("session closed")
 )
 
 // 订单相关错误
 var (
-	ErrInvalidOrder         = errors.New("invalid order")
-	ErrInsufficientBalance  = errors.New("insufficient balance")
-	ErrOrderNotFound        = errors.New("order not found")
-	ErrOrderAlreadyCanceled = errors.New("order already canceled")
-	ErrInvalidOrderType     = errors.New("invalid order type")
-	ErrInvalidOrderSide     = errors.New("invalid order side")
-	ErrInvalidPrice         = errors.New("invalid price")
-	ErrInvalidSize          = errors.New("invalid size")
+	ErrInvalidOrder          = errors.New("invalid order")
+	ErrInsufficientBalance   = errors.New("insufficient balance")
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrOrderAlreadyCanceled  = errors.New("order already canceled")
+	ErrInvalidOrderType      = errors.New("invalid order type")
+	ErrInvalidOrderSide      = errors.New("invalid order side")
+	ErrInvalidPrice          = errors.New("invalid price")
+	ErrInvalidSize           = errors.New("invalid size")
+	ErrThrottled             = errors.New("throttled by local order rate limit")
+	ErrRiskLimitExceeded     = errors.New("order rejected by local risk limit")
+	ErrOrderWouldCross       = errors.New("passive order would cross the book")
+	ErrInsufficientLiquidity = errors.New("insufficient liquidity in the order book")
 )
 
 // 市场相关错误
 var (
-	ErrMarketNotFound  = errors.New("market not found")
-	ErrMarketClosed    = errors.New("market is closed")
-	ErrMarketNotActive = errors.New("market is not active")
+	ErrMarketNotFound   = errors.New("market not found")
+	ErrMarketClosed     = errors.New("market is closed")
+	ErrMarketNotActive  = errors.New("market is not active")
+	ErrMarketClaimed    = errors.New("market is claimed by another process")
+	ErrMarketNotClaimed = errors.New("market is not claimed by this process")
 )
 
 // 签名相关错误
@@ -45,6 +56,7 @@ var (
 	ErrInvalidSignature  = errors.New("invalid signature")
 	ErrInvalidAddress    = errors.New("invalid address")
 	ErrInvalidTimestamp  = errors.New("invalid timestamp")
+	ErrAddressMismatch   = errors.New("credentials belong to a different address")
 )
 
 // APIError API 错误响应
@@ -53,14 +65,19 @@ type APIError struct {
 	Code       string `json:"error,omitempty"`
 	Message    string `json:"message,omitempty"`
 	Details    string `json:"details,omitempty"`
+	Type       string `json:"type,omitempty"` // 错误类别（Gamma 等接口返回，如 "not found error"、"validation error"）
 }
 
 // Error 实现 error 接口
 func (e *APIError) Error() string {
+	text := e.Code
 	if e.Message != "" {
-		return fmt.Sprintf("API error [%d]: %s - %s", e.StatusCode, e.Code, e.Message)
+		text += " - " + e.Message
 	}
-	return fmt.Sprintf("API error [%d]: %s", e.StatusCode, e.Code)
+	if e.Type != "" {
+		return fmt.Sprintf("API error [%d] %s: %s", e.StatusCode, e.Type, text)
+	}
+	return fmt.Sprintf("API error [%d]: %s", e.StatusCode, text)
 }
 
 // NewAPIError 创建 API 错误
@@ -96,6 +113,18 @@ func IsUnauthorized(err error) bool {
 	return false
 }
 
+// IsForbidden 判断是否为 403 错误
+func IsForbidden(err error) bool {
+	if errors.Is(err, ErrForbidden) {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == 403
+	}
+	return false
+}
+
 // IsRateLimited 判断是否为速率限制错误
 func IsRateLimited(err error) bool {
 	if errors.Is(err, ErrRateLimited) {
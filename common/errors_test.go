@@ -27,11 +27,20 @@ func TestAPIError(t *testing.T) {
 			message:    "",
 			expected:   "API error [500]: INTERNAL_ERROR",
 		},
+		{
+			name:       "with type",
+			statusCode: 404,
+			code:       "market not found",
+			expected:   "API error [404] not found error: market not found",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := NewAPIError(tt.statusCode, tt.code, tt.message)
+			if tt.name == "with type" {
+				err.Type = "not found error"
+			}
 			if err.Error() != tt.expected {
 				t.Errorf("Expected %q, got %q", tt.expected, err.Error())
 			}
@@ -108,6 +117,38 @@ func TestIsUnauthorized(t *testing.T) {
 	}
 }
 
+func TestIsForbidden(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "ErrForbidden",
+			err:      ErrForbidden,
+			expected: true,
+		},
+		{
+			name:     "API 403 error",
+			err:      &APIError{StatusCode: 403, Code: "FORBIDDEN"},
+			expected: true,
+		},
+		{
+			name:     "API 401 error",
+			err:      &APIError{StatusCode: 401, Code: "UNAUTHORIZED"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsForbidden(tt.err); got != tt.expected {
+				t.Errorf("IsForbidden() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestIsRateLimited(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -9,7 +9,9 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -19,7 +21,13 @@ type HTTPClient struct {
 	baseURL        string
 	maxRetries     int
 	retryDelay     time.Duration
+	policyMu       sync.RWMutex // 保护 maxRetries/retryDelay，支持运行时调整
 	defaultHeaders map[string]string
+	jsonOptions    JSONDecodeOptions
+
+	// 连接保活
+	keepAliveMu   sync.Mutex
+	keepAliveStop chan struct{}
 }
 
 // HTTPClientConfig HTTP 客户端配置
@@ -28,6 +36,12 @@ type HTTPClientConfig struct {
 	Timeout      time.Duration
 	MaxRetries   int
 	RetryDelayMs int
+	LocalAddrs   *LocalAddrPool     // 出口地址池（可选），新连接轮询绑定本地地址
+	JSONOptions  *JSONDecodeOptions // 响应解码选项（可选），默认 DefaultJSONDecodeOptions
+
+	// 连接池（可选），0 使用 http.DefaultTransport 的默认值
+	MaxIdleConnsPerHost int           // 每个主机保留的空闲连接数，预热多个连接时需不小于预热数
+	IdleConnTimeout     time.Duration // 空闲连接关闭前的保留时间
 }
 
 // NewHTTPClient 创建 HTTP 客户端
@@ -45,14 +59,39 @@ func NewHTTPClient(config *HTTPClientConfig) *HTTPClient {
 		timeout = 30 * time.Second
 	}
 
+	httpClient := &http.Client{
+		Timeout: timeout,
+	}
+	if config.LocalAddrs != nil || config.MaxIdleConnsPerHost > 0 || config.IdleConnTimeout > 0 {
+		// 克隆的默认 Transport 保留 ForceAttemptHTTP2，自定义拨号时仍协商 HTTP/2
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if config.LocalAddrs != nil {
+			transport.DialContext = config.LocalAddrs.DialContext
+		}
+		if config.MaxIdleConnsPerHost > 0 {
+			transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+			if transport.MaxIdleConns < config.MaxIdleConnsPerHost {
+				transport.MaxIdleConns = config.MaxIdleConnsPerHost
+			}
+		}
+		if config.IdleConnTimeout > 0 {
+			transport.IdleConnTimeout = config.IdleConnTimeout
+		}
+		httpClient.Transport = transport
+	}
+
+	jsonOptions := DefaultJSONDecodeOptions()
+	if config.JSONOptions != nil {
+		jsonOptions = *config.JSONOptions
+	}
+
 	return &HTTPClient{
-		client: &http.Client{
-			Timeout: timeout,
-		},
+		client:         httpClient,
 		baseURL:        strings.TrimSuffix(config.BaseURL, "/"),
 		maxRetries:     config.MaxRetries,
 		retryDelay:     time.Duration(config.RetryDelayMs) * time.Millisecond,
 		defaultHeaders: make(map[string]string),
+		jsonOptions:    jsonOptions,
 	}
 }
 
@@ -117,16 +156,42 @@ func (c *HTTPClient) buildURL(path string, params interface{}) string {
 	return fullURL
 }
 
-// doRequest 执行 HTTP 请求
+// SetRetryPolicy 运行时调整最大重试次数与重试间隔，对之后发起的请求生效
+func (c *HTTPClient) SetRetryPolicy(maxRetries int, retryDelay time.Duration) {
+	c.policyMu.Lock()
+	defer c.policyMu.Unlock()
+	c.maxRetries = maxRetries
+	c.retryDelay = retryDelay
+}
+
+// RetryPolicy 获取当前最大重试次数与重试间隔
+func (c *HTTPClient) RetryPolicy() (int, time.Duration) {
+	c.policyMu.RLock()
+	defer c.policyMu.RUnlock()
+	return c.maxRetries, c.retryDelay
+}
+
+// doRequest 执行 HTTP 请求，ctx 携带 RetryOptions 时按其覆盖客户端的重试策略
 func (c *HTTPClient) doRequest(ctx context.Context, method, fullURL string, body interface{}, extraHeaders map[string]string, result interface{}) error {
 	var lastErr error
+	maxRetries, retryDelay := c.RetryPolicy()
+	retryOn := RetryOnAll
+	if opts, ok := RetryOptionsFromContext(ctx); ok {
+		maxRetries = opts.MaxRetries
+		if opts.RetryDelay > 0 {
+			retryDelay = opts.RetryDelay
+		}
+		if opts.RetryOn != 0 {
+			retryOn = opts.RetryOn
+		}
+	}
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(c.retryDelay):
+			case <-time.After(retryDelay):
 			}
 		}
 
@@ -137,17 +202,9 @@ func (c *HTTPClient) doRequest(ctx context.Context, method, fullURL string, body
 
 		lastErr = err
 
-		// 不重试的错误类型
-		if IsUnauthorized(err) || IsNotFound(err) {
+		if !shouldRetry(err, retryOn) {
 			return err
 		}
-
-		// 4xx 错误（除了 429）不重试
-		if apiErr, ok := err.(*APIError); ok {
-			if apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 && apiErr.StatusCode != 429 {
-				return err
-			}
-		}
 	}
 
 	return lastErr
@@ -218,7 +275,7 @@ func (c *HTTPClient) doSingleRequest(ctx context.Context, method, fullURL string
 		//		Message:    "resource not found (null response)",
 		//	}
 		//}
-		if err := json.Unmarshal(respBody, result); err != nil {
+		if err := DecodeJSON(respBody, result, c.jsonOptions); err != nil {
 			return fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 	}
@@ -227,6 +284,12 @@ func (c *HTTPClient) doSingleRequest(ctx context.Context, method, fullURL string
 }
 
 // structToQueryString 将结构体转换为查询字符串
+// 支持的 url 标签选项：
+//   - omitempty：零值不输出
+//   - comma：切片以逗号连接为单个参数（默认每个元素重复 key）
+//   - unix / unixmilli：time.Time 输出秒 / 毫秒时间戳（默认 RFC3339）
+//
+// 除基础类型外，time.Time 与实现 fmt.Stringer 的类型（如 decimal.Decimal）按其字符串形式输出
 func structToQueryString(params interface{}) string {
 	if params == nil {
 		return ""
@@ -260,7 +323,16 @@ func structToQueryString(params interface{}) string {
 		// 解析标签
 		parts := strings.Split(tag, ",")
 		key := parts[0]
-		omitempty := len(parts) > 1 && parts[1] == "omitempty"
+		opts := parts[1:]
+		omitempty := hasTagOption(opts, "omitempty")
+
+		// time.Time、fmt.Stringer 等非基础类型
+		if strValue, ok := formatQueryValue(field, opts); ok {
+			if strValue != "" && !(omitempty && isZeroValue(field)) {
+				values.Set(key, strValue)
+			}
+			continue
+		}
 
 		// 获取字段值
 		var strValue string
@@ -294,11 +366,12 @@ func structToQueryString(params interface{}) string {
 				}
 			}
 		case reflect.Slice:
-			// 处理数组类型，生成 key=val1&key=val2 格式
-			if field.Len() > 0 || !omitempty {
-				for j := 0; j < field.Len(); j++ {
-					elem := field.Index(j)
-					var elemStr string
+			// 处理数组类型，默认生成 key=val1&key=val2 格式，comma 选项生成 key=val1,val2
+			var elems []string
+			for j := 0; j < field.Len(); j++ {
+				elem := field.Index(j)
+				elemStr, ok := formatQueryValue(elem, opts)
+				if !ok {
 					switch elem.Kind() {
 					case reflect.String:
 						elemStr = elem.String()
@@ -307,9 +380,18 @@ func structToQueryString(params interface{}) string {
 					case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 						elemStr = fmt.Sprintf("%d", elem.Uint())
 					}
-					if elemStr != "" {
-						values.Add(key, elemStr)
-					}
+				}
+				if elemStr != "" {
+					elems = append(elems, elemStr)
+				}
+			}
+			if hasTagOption(opts, "comma") {
+				if len(elems) > 0 {
+					values.Set(key, strings.Join(elems, ","))
+				}
+			} else {
+				for _, elemStr := range elems {
+					values.Add(key, elemStr)
 				}
 			}
 			continue // slice 已处理，跳过后面的逻辑
@@ -325,6 +407,82 @@ func structToQueryString(params interface{}) string {
 	return values.Encode()
 }
 
+var timeType = reflect.TypeOf(time.Time{})
+
+// hasTagOption 判断标签选项中是否包含 option
+func hasTagOption(opts []string, option string) bool {
+	for _, opt := range opts {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// formatQueryValue 格式化 time.Time 与实现 fmt.Stringer 的值（含指针），ok 为 false 表示交给基础类型处理
+// nil 指针返回空字符串
+func formatQueryValue(v reflect.Value, opts []string) (string, bool) {
+	if v.Kind() == reflect.Ptr {
+		if !isQueryFormattable(v.Type().Elem()) {
+			return "", false
+		}
+		if v.IsNil() {
+			return "", true
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		tm := v.Interface().(time.Time)
+		if tm.IsZero() {
+			return "", true
+		}
+		switch {
+		case hasTagOption(opts, "unix"):
+			return strconv.FormatInt(tm.Unix(), 10), true
+		case hasTagOption(opts, "unixmilli"):
+			return strconv.FormatInt(tm.UnixMilli(), 10), true
+		default:
+			return tm.UTC().Format(time.RFC3339), true
+		}
+	}
+
+	if !v.CanInterface() {
+		return "", false
+	}
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return stringer.String(), true
+	}
+	if v.CanAddr() {
+		if stringer, ok := v.Addr().Interface().(fmt.Stringer); ok {
+			return stringer.String(), true
+		}
+	}
+	return "", false
+}
+
+// isQueryFormattable 判断类型是否由 formatQueryValue 处理
+func isQueryFormattable(t reflect.Type) bool {
+	stringerType := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	return t == timeType || t.Implements(stringerType) || reflect.PointerTo(t).Implements(stringerType)
+}
+
+// isZeroValue 判断值是否为零值，优先使用类型自身的 IsZero 方法（如 decimal.Decimal、time.Time）
+func isZeroValue(v reflect.Value) bool {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	if v.CanInterface() {
+		if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+			return z.IsZero()
+		}
+	}
+	return v.IsZero()
+}
+
 // GetBaseURL 获取基础 URL
 func (c *HTTPClient) GetBaseURL() string {
 	return c.baseURL
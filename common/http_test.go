@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 func TestNewHTTPClient(t *testing.T) {
@@ -320,6 +324,70 @@ func TestStructToQueryStringWithSlice(t *testing.T) {
 	}
 }
 
+type testSide string
+
+func (s testSide) String() string { return strings.ToUpper(string(s)) }
+
+func TestStructToQueryStringExtendedTypes(t *testing.T) {
+	type TestParams struct {
+		Price     decimal.Decimal   `url:"price,omitempty"`
+		MinSize   *decimal.Decimal  `url:"min_size,omitempty"`
+		ZeroPrice decimal.Decimal   `url:"zero_price"`
+		Side      testSide          `url:"side,omitempty"`
+		After     time.Time         `url:"after,omitempty"`
+		Before    time.Time         `url:"before,unix"`
+		Since     *time.Time        `url:"since,unixmilli,omitempty"`
+		Tokens    []string          `url:"tokens,comma,omitempty"`
+		Prices    []decimal.Decimal `url:"prices,comma"`
+		Ids       []int             `url:"id"`
+	}
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC+8", 8*3600))
+	minSize := decimal.RequireFromString("5")
+	params := &TestParams{
+		Price:   decimal.RequireFromString("0.55"),
+		MinSize: &minSize,
+		Side:    "buy",
+		After:   ts,
+		Before:  ts,
+		Since:   &ts,
+		Tokens:  []string{"a", "b"},
+		Prices:  []decimal.Decimal{decimal.RequireFromString("0.1"), decimal.RequireFromString("0.2")},
+		Ids:     []int{1, 2},
+	}
+
+	got, err := url.ParseQuery(structToQueryString(params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"price":      {"0.55"},
+		"min_size":   {"5"},
+		"zero_price": {"0"},
+		"side":       {"BUY"},
+		"after":      {"2024-01-01T19:04:05Z"},
+		"before":     {"1704135845"},
+		"since":      {"1704135845000"},
+		"tokens":     {"a,b"},
+		"prices":     {"0.1,0.2"},
+		"id":         {"1", "2"},
+	}
+	for key, values := range want {
+		if strings.Join(got[key], "&") != strings.Join(values, "&") {
+			t.Errorf("%s = %v, expected %v", key, got[key], values)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected params: %v", got)
+	}
+
+	// omitempty drops zero decimals, nil pointers, zero times and empty comma slices
+	empty := structToQueryString(&TestParams{Price: decimal.Zero})
+	if empty != "zero_price=0" {
+		t.Errorf("empty params = %q, expected zero_price=0", empty)
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
@@ -0,0 +1,92 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONDecodeOptions JSON 解码选项
+type JSONDecodeOptions struct {
+	// UseNumber 解码到 interface{} 时数字保留为 json.Number 而不是 float64
+	// token ID 是 77 位整数，经过 float64 会丢失精度
+	UseNumber bool
+	// DisallowUnknownFields 响应包含结构体未定义的字段时返回错误（用于测试中发现 API 变更）
+	DisallowUnknownFields bool
+}
+
+// DefaultJSONDecodeOptions 默认解码选项
+func DefaultJSONDecodeOptions() JSONDecodeOptions {
+	return JSONDecodeOptions{UseNumber: true}
+}
+
+// DecodeJSON 按选项解码 JSON，与 json.Unmarshal 一样要求 data 只包含一个 JSON 值
+func DecodeJSON(data []byte, v interface{}, opts JSONDecodeOptions) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if opts.UseNumber {
+		dec.UseNumber()
+	}
+	if opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("invalid character after top-level JSON value")
+	}
+	return nil
+}
+
+// TokenID CLOB token ID（77 位十进制整数）
+// 反序列化时同时接受 JSON 字符串和整数字面量，数字不经过 float64；序列化为字符串
+type TokenID string
+
+// UnmarshalJSON 自定义 JSON 反序列化
+func (t *TokenID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	// 字符串原样保留
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*t = TokenID(s)
+		return nil
+	}
+
+	// 数字字面量直接取原文，不经过 float64
+	if !isDecimalInteger(string(data)) {
+		return fmt.Errorf("invalid token ID %s: must be a decimal integer", data)
+	}
+	*t = TokenID(data)
+	return nil
+}
+
+// MarshalJSON 序列化为 JSON 字符串
+func (t TokenID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(t))
+}
+
+// String 返回十进制字符串
+func (t TokenID) String() string {
+	return string(t)
+}
+
+// isDecimalInteger 是否为非负十进制整数
+func isDecimalInteger(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// A real 77-digit CLOB token ID, far beyond float64's 53-bit mantissa
+const bigTokenID = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+
+func TestDecodeJSON_UseNumber(t *testing.T) {
+	data := []byte(`{"token_id": ` + bigTokenID + `}`)
+
+	var lossy map[string]interface{}
+	if err := DecodeJSON(data, &lossy, JSONDecodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := lossy["token_id"].(float64); !ok {
+		t.Fatalf("without UseNumber expected float64, got %T", lossy["token_id"])
+	}
+
+	var exact map[string]interface{}
+	if err := DecodeJSON(data, &exact, JSONDecodeOptions{UseNumber: true}); err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := exact["token_id"].(json.Number); !ok || n.String() != bigTokenID {
+		t.Errorf("token_id = %v (%T), expected %s", exact["token_id"], exact["token_id"], bigTokenID)
+	}
+}
+
+func TestDecodeJSON_Options(t *testing.T) {
+	var v struct {
+		A int `json:"a"`
+	}
+
+	if err := DecodeJSON([]byte(`{"a":1,"b":2}`), &v, JSONDecodeOptions{DisallowUnknownFields: true}); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+	if err := DecodeJSON([]byte(`{"a":1} {"a":2}`), &v, DefaultJSONDecodeOptions()); err == nil {
+		t.Error("Expected error for trailing data")
+	}
+	if err := DecodeJSON([]byte("{\"a\":1}\n"), &v, DefaultJSONDecodeOptions()); err != nil || v.A != 1 {
+		t.Errorf("DecodeJSON() = %v, a=%d", err, v.A)
+	}
+}
+
+func TestTokenID_RoundTrip(t *testing.T) {
+	var v struct {
+		Number TokenID   `json:"number"`
+		String TokenID   `json:"string"`
+		List   []TokenID `json:"list"`
+	}
+	data := `{"number":` + bigTokenID + `,"string":"` + bigTokenID + `","list":[` + bigTokenID + `,"` + bigTokenID + `"]}`
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range append([]TokenID{v.Number, v.String}, v.List...) {
+		if id.String() != bigTokenID {
+			t.Errorf("token ID = %s, expected %s", id, bigTokenID)
+		}
+	}
+
+	out, err := json.Marshal(v.Number)
+	if err != nil || string(out) != `"`+bigTokenID+`"` {
+		t.Errorf("Marshal() = %s, %v", out, err)
+	}
+
+	var bad TokenID
+	for _, input := range []string{`1.5e76`, `-1`, `true`} {
+		if err := json.Unmarshal([]byte(input), &bad); err == nil {
+			t.Errorf("Expected error for %s", input)
+		}
+	}
+}
+
+func TestHTTPClient_PreservesLargeNumbers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token_id": ` + bigTokenID + `, "tokens": [{"token_id": ` + bigTokenID + `}]}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(&HTTPClientConfig{BaseURL: server.URL})
+
+	var generic map[string]interface{}
+	if err := client.Get(context.Background(), "/", nil, &generic); err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := generic["token_id"].(json.Number); !ok || n.String() != bigTokenID {
+		t.Errorf("generic token_id = %v (%T)", generic["token_id"], generic["token_id"])
+	}
+
+	var typed struct {
+		TokenID TokenID `json:"token_id"`
+		Tokens  []struct {
+			TokenID TokenID `json:"token_id"`
+		} `json:"tokens"`
+	}
+	if err := client.Get(context.Background(), "/", nil, &typed); err != nil {
+		t.Fatal(err)
+	}
+	if typed.TokenID.String() != bigTokenID || typed.Tokens[0].TokenID.String() != bigTokenID {
+		t.Errorf("typed token IDs = %s, %s", typed.TokenID, typed.Tokens[0].TokenID)
+	}
+
+	// Explicitly opting out restores encoding/json defaults
+	lossy := NewHTTPClient(&HTTPClientConfig{BaseURL: server.URL, JSONOptions: &JSONDecodeOptions{}})
+	generic = nil
+	if err := lossy.Get(context.Background(), "/", nil, &generic); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := generic["token_id"].(float64); !ok {
+		t.Errorf("expected float64 with UseNumber disabled, got %T", generic["token_id"])
+	}
+}
@@ -0,0 +1,101 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// LocalAddrPool 本地出口地址池
+// 多出口 IP 部署时，每个新连接按轮询绑定到不同本地地址，以分散单 IP 连接数限制；
+// 被封禁的地址可通过 Ban 暂时跳过
+type LocalAddrPool struct {
+	mu     sync.Mutex
+	addrs  []net.IP
+	next   int
+	banned map[string]time.Time // IP -> 解封时间
+}
+
+// NewLocalAddrPool 创建本地地址池，addrs 为 IP 字符串列表
+func NewLocalAddrPool(addrs []string) (*LocalAddrPool, error) {
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("%w: local address list is empty", ErrInvalidConfig)
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+	for _, a := range addrs {
+		ip := net.ParseIP(a)
+		if ip == nil {
+			return nil, fmt.Errorf("%w: invalid local address %q", ErrInvalidConfig, a)
+		}
+		ips = append(ips, ip)
+	}
+
+	return &LocalAddrPool{
+		addrs:  ips,
+		banned: make(map[string]time.Time),
+	}, nil
+}
+
+// Next 轮询获取下一个可用地址
+// 跳过封禁中的地址；若全部被封禁，返回最早解封的地址
+func (p *LocalAddrPool) Next() net.IP {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var fallback net.IP
+	var fallbackUntil time.Time
+
+	for i := 0; i < len(p.addrs); i++ {
+		ip := p.addrs[(p.next+i)%len(p.addrs)]
+		until, banned := p.banned[ip.String()]
+		if !banned || !now.Before(until) {
+			delete(p.banned, ip.String())
+			p.next = (p.next + i + 1) % len(p.addrs)
+			return ip
+		}
+		if fallback == nil || until.Before(fallbackUntil) {
+			fallback, fallbackUntil = ip, until
+		}
+	}
+
+	return fallback
+}
+
+// Ban 在 d 时间内跳过指定地址（例如收到 403/429 时）
+func (p *LocalAddrPool) Ban(ip net.IP, d time.Duration) {
+	if ip == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.banned[ip.String()] = time.Now().Add(d)
+}
+
+// Addrs 获取地址列表
+func (p *LocalAddrPool) Addrs() []net.IP {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make([]net.IP, len(p.addrs))
+	copy(result, p.addrs)
+	return result
+}
+
+// Dialer 创建绑定到指定本地地址的 net.Dialer
+func (p *LocalAddrPool) Dialer(ip net.IP, timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		LocalAddr: &net.TCPAddr{IP: ip},
+	}
+}
+
+// DialContext 使用下一个地址建立连接，可直接用作 http.Transport.DialContext
+func (p *LocalAddrPool) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	return p.Dialer(p.Next(), 30*time.Second).DialContext(ctx, network, addr)
+}
@@ -0,0 +1,94 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewLocalAddrPoolInvalid(t *testing.T) {
+	if _, err := NewLocalAddrPool(nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("NewLocalAddrPool(nil) error = %v, expected ErrInvalidConfig", err)
+	}
+	if _, err := NewLocalAddrPool([]string{"10.0.0.1", "not-an-ip"}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("NewLocalAddrPool() error = %v, expected ErrInvalidConfig", err)
+	}
+}
+
+func TestLocalAddrPoolNextRoundRobin(t *testing.T) {
+	pool, err := NewLocalAddrPool([]string{"10.0.0.1", "10.0.0.2", "10.0.0.3"})
+	if err != nil {
+		t.Fatalf("NewLocalAddrPool() error: %v", err)
+	}
+
+	expected := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.1"}
+	for i, want := range expected {
+		if got := pool.Next().String(); got != want {
+			t.Errorf("Next() #%d = %s, expected %s", i, got, want)
+		}
+	}
+}
+
+func TestLocalAddrPoolBan(t *testing.T) {
+	pool, _ := NewLocalAddrPool([]string{"10.0.0.1", "10.0.0.2"})
+
+	pool.Ban(net.ParseIP("10.0.0.1"), time.Hour)
+	for i := 0; i < 3; i++ {
+		if got := pool.Next().String(); got != "10.0.0.2" {
+			t.Errorf("Next() = %s, banned address should be skipped", got)
+		}
+	}
+
+	// All banned: fall back to the one unbanned soonest
+	pool.Ban(net.ParseIP("10.0.0.2"), 2*time.Hour)
+	if got := pool.Next().String(); got != "10.0.0.1" {
+		t.Errorf("Next() = %s, expected earliest-unbanned 10.0.0.1", got)
+	}
+
+	// Expired ban is lifted
+	pool.Ban(net.ParseIP("10.0.0.2"), -time.Second)
+	seen := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		seen[pool.Next().String()] = true
+	}
+	if !seen["10.0.0.2"] {
+		t.Error("expired ban should be lifted")
+	}
+}
+
+func TestHTTPClientLocalAddrs(t *testing.T) {
+	var mu sync.Mutex
+	remotes := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, _ := net.SplitHostPort(r.RemoteAddr)
+		mu.Lock()
+		remotes[host] = true
+		mu.Unlock()
+		w.Header().Set("Connection", "close")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	pool, _ := NewLocalAddrPool([]string{"127.0.0.1", "127.0.0.2"})
+	client := NewHTTPClient(&HTTPClientConfig{
+		BaseURL:    server.URL,
+		Timeout:    5 * time.Second,
+		LocalAddrs: pool,
+	})
+
+	for i := 0; i < 2; i++ {
+		var result map[string]interface{}
+		if err := client.Get(context.Background(), "/", nil, &result); err != nil {
+			t.Fatalf("Get() error: %v", err)
+		}
+	}
+
+	if !remotes["127.0.0.1"] || !remotes["127.0.0.2"] {
+		t.Errorf("expected connections from both local addresses, got %v", remotes)
+	}
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"context"
+	"time"
+)
+
+// RetryOn 可重试的错误类别，可按位组合
+type RetryOn uint8
+
+const (
+	// RetryOnNetworkError 未取得有效响应的错误：连接失败、超时、响应读取或解析失败
+	RetryOnNetworkError RetryOn = 1 << iota
+	// RetryOnRateLimit 429 限流
+	RetryOnRateLimit
+	// RetryOnServerError 5xx 服务端错误
+	RetryOnServerError
+
+	// RetryOnAll 全部可重试类别（客户端默认行为）
+	RetryOnAll = RetryOnNetworkError | RetryOnRateLimit | RetryOnServerError
+)
+
+// RetryOptions 单次调用的重试策略，覆盖客户端的全局 MaxRetries
+// 幂等的查询可以放宽重试；下单等非幂等 POST 在网络错误时可能已被服务端接受，
+// 通常只应在明确未被处理的 429 上重试，例如 RetryOptions{MaxRetries: 2, RetryOn: RetryOnRateLimit}
+type RetryOptions struct {
+	MaxRetries int           // 最大重试次数，0 表示不重试
+	RetryDelay time.Duration // 重试间隔，<= 0 时使用客户端设置
+	RetryOn    RetryOn       // 可重试的错误类别，0 表示 RetryOnAll
+}
+
+// retryContextKey ctx 中单次调用重试策略的 key
+type retryContextKey struct{}
+
+// WithRetry 返回携带重试策略的 ctx，使用该 ctx 的 gamma / clob 请求按此策略重试
+func WithRetry(ctx context.Context, opts RetryOptions) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, opts)
+}
+
+// WithoutRetry 返回禁止重试的 ctx，等同于 WithRetry(ctx, RetryOptions{})
+func WithoutRetry(ctx context.Context) context.Context {
+	return WithRetry(ctx, RetryOptions{})
+}
+
+// RetryOptionsFromContext 获取 ctx 携带的重试策略
+func RetryOptionsFromContext(ctx context.Context) (RetryOptions, bool) {
+	opts, ok := ctx.Value(retryContextKey{}).(RetryOptions)
+	return opts, ok
+}
+
+// shouldRetry 判断错误是否属于可重试类别
+// 认证失败、404 以及 429 以外的 4xx 永远不重试
+func shouldRetry(err error, retryOn RetryOn) bool {
+	if IsUnauthorized(err) || IsNotFound(err) {
+		return false
+	}
+	if apiErr, ok := err.(*APIError); ok {
+		switch {
+		case apiErr.StatusCode == 429:
+			return retryOn&RetryOnRateLimit != 0
+		case apiErr.StatusCode >= 500:
+			return retryOn&RetryOnServerError != 0
+		default:
+			return false
+		}
+	}
+	return retryOn&RetryOnNetworkError != 0
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newStatusServer(t *testing.T, status int) (*httptest.Server, *int32) {
+	t.Helper()
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func TestHTTPClient_WithRetryOverridesClientPolicy(t *testing.T) {
+	server, hits := newStatusServer(t, http.StatusInternalServerError)
+	client := NewHTTPClient(&HTTPClientConfig{BaseURL: server.URL, MaxRetries: 5, RetryDelayMs: 1})
+
+	ctx := WithRetry(context.Background(), RetryOptions{MaxRetries: 1, RetryDelay: time.Millisecond})
+	if err := client.Get(ctx, "/", nil, nil); err == nil {
+		t.Fatal("expected error from failing server")
+	}
+	if got := atomic.LoadInt32(hits); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestHTTPClient_WithoutRetry(t *testing.T) {
+	server, hits := newStatusServer(t, http.StatusTooManyRequests)
+	client := NewHTTPClient(&HTTPClientConfig{BaseURL: server.URL, MaxRetries: 5, RetryDelayMs: 1})
+
+	if err := client.Post(WithoutRetry(context.Background()), "/order", nil, nil); err == nil {
+		t.Fatal("expected error from rate limited server")
+	}
+	if got := atomic.LoadInt32(hits); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+}
+
+func TestHTTPClient_WithRetryClasses(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		retryOn  RetryOn
+		attempts int32
+	}{
+		{"rate limit retried", http.StatusTooManyRequests, RetryOnRateLimit, 3},
+		{"server error not in classes", http.StatusBadGateway, RetryOnRateLimit, 1},
+		{"server error retried", http.StatusBadGateway, RetryOnServerError, 3},
+		{"default classes", http.StatusBadGateway, 0, 3},
+		{"client error never retried", http.StatusBadRequest, RetryOnAll, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, hits := newStatusServer(t, tt.status)
+			client := NewHTTPClient(&HTTPClientConfig{BaseURL: server.URL})
+
+			ctx := WithRetry(context.Background(), RetryOptions{MaxRetries: 2, RetryDelay: time.Millisecond, RetryOn: tt.retryOn})
+			if err := client.Get(ctx, "/", nil, nil); err == nil {
+				t.Fatal("expected error")
+			}
+			if got := atomic.LoadInt32(hits); got != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, got)
+			}
+		})
+	}
+}
+
+func TestShouldRetry_NetworkError(t *testing.T) {
+	client := NewHTTPClient(&HTTPClientConfig{BaseURL: "http://127.0.0.1:1"})
+	err := client.Get(context.Background(), "/", nil, nil)
+	if err == nil {
+		t.Fatal("expected connection error")
+	}
+
+	if !shouldRetry(err, RetryOnNetworkError) {
+		t.Error("connection error should be retried under RetryOnNetworkError")
+	}
+	if shouldRetry(err, RetryOnRateLimit|RetryOnServerError) {
+		t.Error("connection error should not be retried without RetryOnNetworkError")
+	}
+}
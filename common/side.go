@@ -0,0 +1,48 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Side 买卖方向，订单簿（orderbook.Side）与交易（clob.OrderSide）共用同一类型，跨层传递无需转换
+type Side string
+
+const (
+	// SideBuy 买入 / 买盘（bid）
+	SideBuy Side = "BUY"
+	// SideSell 卖出 / 卖盘（ask）
+	SideSell Side = "SELL"
+)
+
+// ParseSide 解析方向，不区分大小写，接受 buy/sell 与 bid/ask
+func ParseSide(s string) (Side, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "BUY", "BID":
+		return SideBuy, nil
+	case "SELL", "ASK":
+		return SideSell, nil
+	}
+	return "", fmt.Errorf("invalid side %q, must be BUY or SELL", s)
+}
+
+// IsValid 是否为 BUY 或 SELL
+func (s Side) IsValid() bool {
+	return s == SideBuy || s == SideSell
+}
+
+// Opposite 对手方向：买单与卖盘（asks）成交，卖单与买盘（bids）成交
+func (s Side) Opposite() Side {
+	if s == SideBuy {
+		return SideSell
+	}
+	return SideBuy
+}
+
+// ToInt 订单签名中的方向编码：BUY 为 0，SELL 为 1
+func (s Side) ToInt() int {
+	if s == SideBuy {
+		return 0
+	}
+	return 1
+}
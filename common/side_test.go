@@ -0,0 +1,37 @@
+package common
+
+import "testing"
+
+func TestParseSide(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Side
+	}{
+		{"BUY", SideBuy},
+		{"buy", SideBuy},
+		{" bid ", SideBuy},
+		{"SELL", SideSell},
+		{"Ask", SideSell},
+	}
+	for _, tt := range tests {
+		got, err := ParseSide(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSide(%q) = %q, %v, expected %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseSide("hold"); err == nil {
+		t.Error("ParseSide() should reject unknown sides")
+	}
+}
+
+func TestSide(t *testing.T) {
+	if SideBuy.Opposite() != SideSell || SideSell.Opposite() != SideBuy {
+		t.Error("Opposite() should swap BUY and SELL")
+	}
+	if SideBuy.ToInt() != 0 || SideSell.ToInt() != 1 {
+		t.Errorf("ToInt() = %d, %d, expected 0, 1", SideBuy.ToInt(), SideSell.ToInt())
+	}
+	if !SideBuy.IsValid() || Side("buy").IsValid() || Side("").IsValid() {
+		t.Error("IsValid() should only accept BUY and SELL")
+	}
+}
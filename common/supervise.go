@@ -0,0 +1,121 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// RestartPolicy 被监督协程 panic 后的重启策略
+type RestartPolicy struct {
+	MinBackoff  time.Duration // 首次重启前的等待时间
+	MaxBackoff  time.Duration // 指数退避上限
+	MaxRestarts int           // 最大连续重启次数，<= 0 表示不限
+	ResetAfter  time.Duration // 单次运行超过该时长视为恢复稳定，退避和重启计数清零
+}
+
+// DefaultRestartPolicy 默认重启策略
+func DefaultRestartPolicy() *RestartPolicy {
+	return &RestartPolicy{
+		MinBackoff: time.Second,
+		MaxBackoff: 30 * time.Second,
+		ResetAfter: time.Minute,
+	}
+}
+
+// panicCounts 按组件统计已恢复的 panic 次数
+var panicCounts = struct {
+	sync.Mutex
+	m map[string]int64
+}{m: make(map[string]int64)}
+
+// PanicCount 获取组件已恢复的 panic 次数
+func PanicCount(component string) int64 {
+	panicCounts.Lock()
+	defer panicCounts.Unlock()
+	return panicCounts.m[component]
+}
+
+// PanicCounts 获取全部组件的 panic 次数快照，可定期导出到监控系统
+func PanicCounts() map[string]int64 {
+	panicCounts.Lock()
+	defer panicCounts.Unlock()
+
+	counts := make(map[string]int64, len(panicCounts.m))
+	for component, n := range panicCounts.m {
+		counts[component] = n
+	}
+	return counts
+}
+
+// RecoverPanic 恢复当前协程的 panic，记录日志与堆栈并累加计数，随后调用 onPanic
+// 必须直接以 defer RecoverPanic(...) 的形式使用，否则 recover 不生效；没有 panic 时不做任何事
+func RecoverPanic(component string, onPanic func(err error)) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	panicCounts.Lock()
+	panicCounts.m[component]++
+	count := panicCounts.m[component]
+	panicCounts.Unlock()
+
+	log.Printf("[Polymarket Supervisor] %s panicked (total %d): %v\n%s", component, count, r, debug.Stack())
+
+	if onPanic != nil {
+		onPanic(fmt.Errorf("%w in %s: %v", ErrPanicRecovered, component, r))
+	}
+}
+
+// Supervise 在当前协程中运行 fn，fn panic 时恢复并按退避策略重新运行
+// fn 正常返回、ctx 取消或达到最大重启次数时返回；policy 为 nil 时使用默认策略
+// SDK 内部的消费循环（各组件的 Run）与后台协程都经由此函数运行，单条消息触发的 panic 不会永久终止消费
+func Supervise(ctx context.Context, component string, policy *RestartPolicy, fn func(ctx context.Context)) {
+	if policy == nil {
+		policy = DefaultRestartPolicy()
+	}
+
+	backoff := policy.MinBackoff
+	restarts := 0
+	for {
+		started := time.Now()
+		if !runRecovered(ctx, component, fn) || ctx.Err() != nil {
+			return
+		}
+
+		if policy.ResetAfter > 0 && time.Since(started) >= policy.ResetAfter {
+			backoff = policy.MinBackoff
+			restarts = 0
+		}
+		restarts++
+		if policy.MaxRestarts > 0 && restarts > policy.MaxRestarts {
+			log.Printf("[Polymarket Supervisor] %s exceeded %d restarts, giving up", component, policy.MaxRestarts)
+			return
+		}
+
+		log.Printf("[Polymarket Supervisor] restarting %s in %v (restart %d)", component, backoff, restarts)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// runRecovered 运行一次 fn，返回是否发生了 panic
+func runRecovered(ctx context.Context, component string, fn func(ctx context.Context)) (panicked bool) {
+	defer RecoverPanic(component, func(error) { panicked = true })
+	fn(ctx)
+	return false
+}
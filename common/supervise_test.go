@@ -0,0 +1,99 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func fastPolicy() *RestartPolicy {
+	return &RestartPolicy{MinBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+}
+
+func TestSupervise_RestartsAfterPanic(t *testing.T) {
+	component := t.Name()
+	runs := 0
+	Supervise(context.Background(), component, fastPolicy(), func(ctx context.Context) {
+		runs++
+		if runs < 3 {
+			panic("boom")
+		}
+	})
+
+	if runs != 3 {
+		t.Errorf("expected 3 runs, got %d", runs)
+	}
+	if got := PanicCount(component); got != 2 {
+		t.Errorf("expected panic count 2, got %d", got)
+	}
+	if got := PanicCounts()[component]; got != 2 {
+		t.Errorf("expected PanicCounts entry 2, got %d", got)
+	}
+}
+
+func TestSupervise_MaxRestarts(t *testing.T) {
+	policy := fastPolicy()
+	policy.MaxRestarts = 2
+
+	runs := 0
+	Supervise(context.Background(), t.Name(), policy, func(ctx context.Context) {
+		runs++
+		panic("always")
+	})
+
+	// Initial run plus two restarts
+	if runs != 3 {
+		t.Errorf("expected 3 runs, got %d", runs)
+	}
+}
+
+func TestSupervise_StopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := &RestartPolicy{MinBackoff: time.Hour}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Supervise(ctx, t.Name(), policy, func(ctx context.Context) {
+			panic("boom")
+		})
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Supervise did not return after cancel")
+	}
+}
+
+func TestSupervise_NoPanic(t *testing.T) {
+	runs := 0
+	Supervise(context.Background(), t.Name(), nil, func(ctx context.Context) { runs++ })
+
+	if runs != 1 {
+		t.Errorf("expected a single run, got %d", runs)
+	}
+	if got := PanicCount(t.Name()); got != 0 {
+		t.Errorf("expected no panics counted, got %d", got)
+	}
+}
+
+func TestRecoverPanic(t *testing.T) {
+	var recovered error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer RecoverPanic(t.Name(), func(err error) { recovered = err })
+		panic("boom")
+	}()
+	<-done
+
+	if !errors.Is(recovered, ErrPanicRecovered) {
+		t.Fatalf("expected ErrPanicRecovered, got %v", recovered)
+	}
+	if got := PanicCount(t.Name()); got != 1 {
+		t.Errorf("expected panic count 1, got %d", got)
+	}
+}
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// ModulePath SDK 的 Go 模块路径
+const ModulePath = "github.com/binary-jerry/polymarket-sdk"
+
+// DevelVersion 无法从构建信息取得版本时（如在本仓库内直接构建或测试）使用的版本号
+const DevelVersion = "(devel)"
+
+var (
+	versionOnce sync.Once
+	version     string
+)
+
+// SDKVersion 当前构建使用的 SDK 版本（如 v1.2.3 或伪版本号），取自二进制的模块构建信息
+// 写入导出数据，便于下游识别混用不同 SDK 版本的数据管线
+func SDKVersion() string {
+	versionOnce.Do(func() {
+		version = DevelVersion
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		if info.Main.Path == ModulePath && info.Main.Version != "" {
+			version = info.Main.Version
+			return
+		}
+		for _, dep := range info.Deps {
+			if dep.Path != ModulePath {
+				continue
+			}
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				dep = dep.Replace
+			}
+			if dep.Version != "" {
+				version = dep.Version
+			}
+			return
+		}
+	})
+	return version
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Warmup 并发发送 conns 个轻量 GET 请求，预先完成 DNS、TCP 与 TLS 握手并把连接放回连接池
+// 响应状态码不影响结果，只要连接建立成功即视为预热成功
+// HTTP/2 下同一主机通常复用单个连接，此时主要节省的是握手耗时
+func (c *HTTPClient) Warmup(ctx context.Context, path string, conns int) error {
+	if conns <= 0 {
+		conns = 1
+	}
+
+	fullURL := c.buildURL(path, nil)
+	errs := make(chan error, conns)
+	var wg sync.WaitGroup
+	for i := 0; i < conns; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer RecoverPanic("common.warmup", func(err error) { errs <- err })
+			errs <- c.ping(ctx, fullURL)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return fmt.Errorf("warmup failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// ping 发送单个 GET 请求并读完响应体，使连接可以被复用
+func (c *HTTPClient) ping(ctx context.Context, fullURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range c.defaultHeaders {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// StartKeepAlive 启动后台保活，每隔 interval 对 path 执行一次 Warmup，避免空闲连接被关闭
+// interval 应小于连接池的 IdleConnTimeout 和服务端的空闲超时；重复调用会先停止之前的保活
+func (c *HTTPClient) StartKeepAlive(path string, interval time.Duration, conns int) {
+	if interval <= 0 {
+		return
+	}
+
+	c.keepAliveMu.Lock()
+	defer c.keepAliveMu.Unlock()
+	c.startKeepAliveLocked(path, interval, conns)
+}
+
+// UpdateKeepAlive 以新的间隔重启正在运行的保活，interval <= 0 时停止；未启动时无操作
+func (c *HTTPClient) UpdateKeepAlive(path string, interval time.Duration, conns int) {
+	c.keepAliveMu.Lock()
+	defer c.keepAliveMu.Unlock()
+	if c.keepAliveStop == nil {
+		return
+	}
+	if interval <= 0 {
+		close(c.keepAliveStop)
+		c.keepAliveStop = nil
+		return
+	}
+	c.startKeepAliveLocked(path, interval, conns)
+}
+
+// startKeepAliveLocked 停止之前的保活并启动新的保活协程（调用者需持有 keepAliveMu）
+func (c *HTTPClient) startKeepAliveLocked(path string, interval time.Duration, conns int) {
+	if c.keepAliveStop != nil {
+		close(c.keepAliveStop)
+	}
+	stop := make(chan struct{})
+	c.keepAliveStop = stop
+
+	// 停止信号由 stop 传递，panic 后的退避期间收到停止信号时，重启后的循环会立即退出
+	go Supervise(context.Background(), "common.keepalive", nil, func(context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				_ = c.Warmup(ctx, path, conns)
+				cancel()
+			}
+		}
+	})
+}
+
+// StopKeepAlive 停止后台保活，未启动时无操作
+func (c *HTTPClient) StopKeepAlive() {
+	c.keepAliveMu.Lock()
+	defer c.keepAliveMu.Unlock()
+	if c.keepAliveStop != nil {
+		close(c.keepAliveStop)
+		c.keepAliveStop = nil
+	}
+}
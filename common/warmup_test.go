@@ -0,0 +1,143 @@
+package common
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCountingServer(delay time.Duration) (*httptest.Server, *int32, *int32) {
+	var conns, hits int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(delay)
+		w.Write([]byte("OK"))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	server.Start()
+	return server, &conns, &hits
+}
+
+func TestHTTPClient_WarmupReusesConnections(t *testing.T) {
+	server, conns, _ := newCountingServer(50 * time.Millisecond)
+	defer server.Close()
+
+	client := NewHTTPClient(&HTTPClientConfig{BaseURL: server.URL, MaxIdleConnsPerHost: 4})
+	if err := client.Warmup(context.Background(), "/", 4); err != nil {
+		t.Fatalf("Warmup() error: %v", err)
+	}
+	if got := atomic.LoadInt32(conns); got != 4 {
+		t.Fatalf("expected 4 warmed connections, got %d", got)
+	}
+
+	// Concurrent requests after warmup should all ride on pooled connections
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Get(context.Background(), "/", nil, nil); err != nil {
+				t.Errorf("Get() error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := atomic.LoadInt32(conns); got != 4 {
+		t.Errorf("expected no new connections after warmup, got %d total", got)
+	}
+}
+
+func TestHTTPClient_WarmupError(t *testing.T) {
+	client := NewHTTPClient(&HTTPClientConfig{BaseURL: "http://127.0.0.1:1"})
+	if err := client.Warmup(context.Background(), "/", 2); err == nil {
+		t.Error("expected error warming up unreachable host")
+	}
+}
+
+func TestHTTPClient_KeepAlive(t *testing.T) {
+	server, _, hits := newCountingServer(0)
+	defer server.Close()
+
+	client := NewHTTPClient(&HTTPClientConfig{BaseURL: server.URL})
+	client.StartKeepAlive("/", 10*time.Millisecond, 1)
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(hits) < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if atomic.LoadInt32(hits) < 3 {
+		t.Fatalf("expected keepalive pings, got %d", atomic.LoadInt32(hits))
+	}
+
+	client.StopKeepAlive()
+	time.Sleep(30 * time.Millisecond)
+	stopped := atomic.LoadInt32(hits)
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(hits); got != stopped {
+		t.Errorf("keepalive still running after stop: %d -> %d", stopped, got)
+	}
+	client.StopKeepAlive() // idempotent
+}
+
+func TestHTTPClient_UpdateKeepAlive(t *testing.T) {
+	server, _, hits := newCountingServer(0)
+	defer server.Close()
+
+	client := NewHTTPClient(&HTTPClientConfig{BaseURL: server.URL})
+
+	// Not running: update is a no-op
+	client.UpdateKeepAlive("/", 5*time.Millisecond, 1)
+	time.Sleep(30 * time.Millisecond)
+	if got := atomic.LoadInt32(hits); got != 0 {
+		t.Fatalf("update should not start keepalive, got %d hits", got)
+	}
+
+	client.StartKeepAlive("/", time.Hour, 1)
+	client.UpdateKeepAlive("/", 5*time.Millisecond, 1)
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(hits) < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if atomic.LoadInt32(hits) < 3 {
+		t.Fatalf("expected keepalive at the new interval, got %d", atomic.LoadInt32(hits))
+	}
+
+	client.UpdateKeepAlive("/", 0, 1)
+	time.Sleep(20 * time.Millisecond)
+	stopped := atomic.LoadInt32(hits)
+	time.Sleep(30 * time.Millisecond)
+	if got := atomic.LoadInt32(hits); got != stopped {
+		t.Errorf("keepalive still running after zero interval: %d -> %d", stopped, got)
+	}
+}
+
+func TestHTTPClient_SetRetryPolicy(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(&HTTPClientConfig{BaseURL: server.URL, MaxRetries: 0})
+	client.SetRetryPolicy(2, time.Millisecond)
+	if retries, delay := client.RetryPolicy(); retries != 2 || delay != time.Millisecond {
+		t.Errorf("RetryPolicy() = %d, %v", retries, delay)
+	}
+
+	if err := client.Get(context.Background(), "/", nil, nil); err == nil {
+		t.Fatal("expected error from failing server")
+	}
+	if got := atomic.LoadInt32(&hits); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
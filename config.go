@@ -1,10 +1,19 @@
 package polymarket
 
-import "time"
+import (
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/data"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
 
 // ChainID Polygon 主网链 ID
 const ChainID = 137
 
+// AmoyChainID Polygon Amoy 测试网链 ID，与 clob.AmoyChainID 相同
+const AmoyChainID = 80002
+
 // API 端点常量
 const (
 	// GammaEndpoint Gamma API 端点（市场数据）
@@ -15,6 +24,9 @@ const (
 
 	// WSEndpoint WebSocket 端点（订单簿）
 	WSEndpoint = "wss://ws-subscriptions-clob.polymarket.com/ws/market"
+
+	// DataEndpoint Data API 端点（持仓与账户活动）
+	DataEndpoint = data.DefaultEndpoint
 )
 
 // 合约地址常量 (Polygon Mainnet)
@@ -41,12 +53,29 @@ type Config struct {
 	GammaEndpoint string // Gamma API 端点
 	CLOBEndpoint  string // CLOB API 端点
 	WSEndpoint    string // WebSocket 端点
+	DataEndpoint  string // Data API 端点
+
+	// 链 ID，0 表示 Polygon 主网（ChainID）
+	ChainID int
+
+	// 账户配置（NewSDK 创建交易客户端时生效）
+	PrivateKeyFile string // 私钥文件路径（可选），通过 LoadPrivateKey 读取
+	SignatureType  int    // 签名类型：0=EOA, 1=POLY_PROXY, 2=GNOSIS_SAFE
+	FunderAddress  string // 代理钱包地址（持有资金），代理钱包模式必填
 
 	// HTTP 配置
 	HTTPTimeout   time.Duration // HTTP 请求超时
 	MaxRetries    int           // 最大重试次数
 	RetryDelayMs  int           // 重试间隔（毫秒）
 
+	// CLOB 写请求（下单、撤单）的超时与重试，与上面的读请求配置相互独立
+	WriteTimeout    time.Duration // 0 表示使用 HTTPTimeout
+	WriteMaxRetries int           // 默认 0 不重试，避免超时后重复下单
+
+	// CLOB 连接预热与保活（调用 Trading.WarmUp 时生效）
+	WarmConns         int           // 预热的连接数
+	KeepAliveInterval time.Duration // 保活间隔，0 表示不保活
+
 	// WebSocket 配置（订单簿）
 	MaxTokensPerConn     int // 每个连接最大 token 数
 	ReconnectMinInterval int // 最小重连间隔（毫秒）
@@ -56,12 +85,49 @@ type Config struct {
 	PongTimeout          int // pong 超时（秒）
 	MessageBufferSize    int // 消息缓冲区大小
 	UpdateChannelSize    int // 更新通知 channel 大小
+	MaxTimestampGapMs    int // 相邻增量时间戳间隔超过该值（毫秒）时重新订阅，0 表示不启用
+
+	// WebSocket 代理（可选），socks5:// 或 http:// 地址，可带 user:pass@ 认证，各连接轮流分配
+	WSProxyURLs []string
+
+	// 订阅状态存储（可选），配置后可在重启时通过 OrderBook.RestoreSubscriptions 恢复订阅
+	SubscriptionStore orderbook.SubscriptionStore
+
+	// 只在最优买卖价或其数量变化时推送 book / price_change 更新（适合行情类消费者）
+	BBOOnly bool
+
+	// 原始帧录制（可选）：保留最近 RecordWindow 秒的 WebSocket 帧，异常时通过 OrderBook.FlushRecent 导出
+	RecordWindow   int // 录制窗口（秒），0 表示不录制
+	RecordMaxBytes int // 每个连接录制的字节数上限，0 表示不限制
+
+	// token 到 WebSocket 连接的放置策略（可选），nil 时每个连接按顺序装满 MaxTokensPerConn 个 token
+	// 如 orderbook.NewGroupPlacement 按 event 分组，使一次断线只影响同一组相关市场
+	WSPlacement orderbook.PlacementStrategy
+
+	// 行情延迟统计（可选），非 nil 时按 token 统计交易所时间戳到本地接收的延迟，通过 OrderBook.FeedLatency() 查看与接收告警
+	FeedLatency *orderbook.FeedLatencyConfig
 
 	// 合约地址配置
 	CTFExchangeAddress        string // 标准市场交易合约
 	NegRiskCTFExchangeAddress string // NegRisk 市场交易合约
 	NegRiskAdapterAddress     string // NegRisk 适配器合约
 	CollateralAddress         string // 抵押品合约地址
+	ConditionalTokensAddress  string // 条件代币合约地址
+
+	// Polygon RPC 端点（可选），用于链上授权与资金划转（SDK.NewTreasury）
+	RPCEndpoint string
+
+	// 交易事件日志容量（最近的下单/撤单/成交记录）
+	EventLogSize int
+
+	// Trading.CancelOrder 对已撤销/已成交的订单返回 nil
+	IdempotentCancel bool
+
+	// 交易客户端与会话的可选配置（限流、审计、购买力、L2 认证头复用、会话风控）
+	TradingOptions
+
+	// 出口地址池（可选），WebSocket 与 HTTP 客户端共享，用于多出口 IP 部署
+	LocalAddrs *common.LocalAddrPool
 }
 
 // DefaultConfig 返回默认配置
@@ -71,12 +137,20 @@ func DefaultConfig() *Config {
 		GammaEndpoint: GammaEndpoint,
 		CLOBEndpoint:  CLOBEndpoint,
 		WSEndpoint:    WSEndpoint,
+		DataEndpoint:  DataEndpoint,
+
+		ChainID: ChainID,
 
 		// HTTP 配置
 		HTTPTimeout:  30 * time.Second,
 		MaxRetries:   3,
 		RetryDelayMs: 1000,
 
+		WriteTimeout: 10 * time.Second,
+
+		WarmConns:         2,
+		KeepAliveInterval: 30 * time.Second,
+
 		// WebSocket 配置
 		MaxTokensPerConn:     50,
 		ReconnectMinInterval: 1000,
@@ -92,9 +166,21 @@ func DefaultConfig() *Config {
 		NegRiskCTFExchangeAddress: NegRiskCTFExchangeAddress,
 		NegRiskAdapterAddress:     NegRiskAdapterAddress,
 		CollateralAddress:         CollateralAddress,
+		ConditionalTokensAddress:  ConditionalTokensAddress,
+
+		EventLogSize: defaultEventLogSize,
 	}
 }
 
+// AmoyConfig 返回 Amoy 测试网配置（链 ID 与合约地址），API 端点沿用默认值，需按测试环境替换
+// 配合 Trading.BootstrapTestnet 初始化测试账户
+func AmoyConfig() *Config {
+	config := DefaultConfig()
+	config.ChainID = AmoyChainID
+	applyAmoyContracts(config)
+	return config
+}
+
 // Validate 验证配置
 func (c *Config) Validate() error {
 	if c.GammaEndpoint == "" {
@@ -103,9 +189,15 @@ func (c *Config) Validate() error {
 	if c.CLOBEndpoint == "" {
 		c.CLOBEndpoint = CLOBEndpoint
 	}
+	if c.DataEndpoint == "" {
+		c.DataEndpoint = DataEndpoint
+	}
 	if c.WSEndpoint == "" {
 		c.WSEndpoint = WSEndpoint
 	}
+	if c.ChainID == 0 {
+		c.ChainID = ChainID
+	}
 	if c.HTTPTimeout == 0 {
 		c.HTTPTimeout = 30 * time.Second
 	}
@@ -148,6 +240,9 @@ func (c *Config) Validate() error {
 	if c.CollateralAddress == "" {
 		c.CollateralAddress = CollateralAddress
 	}
+	if c.ConditionalTokensAddress == "" {
+		c.ConditionalTokensAddress = ConditionalTokensAddress
+	}
 	return nil
 }
 
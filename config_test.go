@@ -245,3 +245,17 @@ func TestContractAddressFormats(t *testing.T) {
 		}
 	}
 }
+
+func TestAmoyConfig(t *testing.T) {
+	config := AmoyConfig()
+	if config.ChainID != 80002 {
+		t.Errorf("ChainID = %d, expected 80002", config.ChainID)
+	}
+
+	// Zero ChainID falls back to mainnet
+	empty := &Config{}
+	empty.Validate()
+	if empty.ChainID != ChainID || empty.ConditionalTokensAddress != ConditionalTokensAddress {
+		t.Errorf("Validate() chain/CTF = %d/%s", empty.ChainID, empty.ConditionalTokensAddress)
+	}
+}
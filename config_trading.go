@@ -0,0 +1,80 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"fmt"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// defaultEventLogSize 交易事件日志默认容量
+const defaultEventLogSize = clob.DefaultEventLogSize
+
+// TradingOptions 交易客户端与会话的可选配置，嵌入 Config，nosigning 构建中为空
+type TradingOptions struct {
+	// 按市场的下单/撤单自限流（可选），nil 表示不限流
+	OrderThrottle *clob.ThrottleConfig
+
+	// 交易审计日志（可选），nil 表示不启用
+	Audit *clob.AuditConfig
+
+	// Trading.GetBuyingPower 的折扣与保留金额（可选），nil 使用默认配置
+	BuyingPower *clob.BuyingPowerConfig
+
+	// L2 认证头复用（可选），nil 表示每次请求重新签名，见 Trading.PrecomputeL2Headers
+	L2HeaderCache *auth.L2HeaderCacheConfig
+
+	// NewSession 未传入限制时使用的会话风控（可选），nil 表示不限制
+	SessionLimits *SessionLimits
+}
+
+// validate 校验风控与限流参数
+func (o *TradingOptions) validate() error {
+	if l := o.SessionLimits; l != nil {
+		if l.MaxOrderSize.IsNegative() || l.MaxOrderNotional.IsNegative() || l.MaxOpenOrders < 0 || l.MaxOpenNotional.IsNegative() {
+			return fmt.Errorf("%w: session limits must not be negative", common.ErrInvalidConfig)
+		}
+	}
+	if t := o.OrderThrottle; t != nil {
+		if t.OrdersPerSecond < 0 || t.CancelsPerSecond < 0 || t.OrderBurst < 0 || t.CancelBurst < 0 {
+			return fmt.Errorf("%w: order throttle rates must not be negative", common.ErrInvalidConfig)
+		}
+	}
+	return nil
+}
+
+// applyAmoyContracts 切换为 Amoy 测试网合约地址
+func applyAmoyContracts(config *Config) {
+	config.CTFExchangeAddress = clob.AmoyExchangeAddress
+	config.NegRiskCTFExchangeAddress = clob.AmoyNegRiskExchangeAddress
+	config.NegRiskAdapterAddress = clob.AmoyNegRiskAdapterAddress
+	config.CollateralAddress = clob.AmoyCollateralAddress
+	config.ConditionalTokensAddress = clob.AmoyConditionalTokensAddress
+}
+
+// tradingOptions 读取会话风控与下单限流变量，均未设置时保持 nil
+func (r *envReader) tradingOptions(options *TradingOptions) {
+	limits := &SessionLimits{
+		MaxOrderSize:     r.decimal(EnvMaxOrderSize),
+		MaxOrderNotional: r.decimal(EnvMaxOrderNotional),
+		MaxOpenOrders:    r.int(EnvMaxOpenOrders),
+		MaxOpenNotional:  r.decimal(EnvMaxOpenNotional),
+	}
+	if !limits.MaxOrderSize.IsZero() || !limits.MaxOrderNotional.IsZero() || limits.MaxOpenOrders != 0 || !limits.MaxOpenNotional.IsZero() {
+		options.SessionLimits = limits
+	}
+
+	throttle := &clob.ThrottleConfig{
+		OrdersPerSecond:  r.float(EnvOrdersPerSecond),
+		OrderBurst:       r.int(EnvOrderBurst),
+		CancelsPerSecond: r.float(EnvCancelsPerSecond),
+		CancelBurst:      r.int(EnvCancelBurst),
+		Wait:             r.bool(EnvThrottleWait),
+	}
+	if throttle.OrdersPerSecond > 0 || throttle.CancelsPerSecond > 0 {
+		options.OrderThrottle = throttle
+	}
+}
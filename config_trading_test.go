@@ -0,0 +1,35 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+)
+
+func TestTradingConstantsMatchCLOB(t *testing.T) {
+	// The root package repeats these so that nosigning builds need not import clob
+	if AmoyChainID != clob.AmoyChainID {
+		t.Errorf("AmoyChainID = %d, clob.AmoyChainID = %d", AmoyChainID, clob.AmoyChainID)
+	}
+	if DefaultConfig().EventLogSize != clob.DefaultEventLogSize {
+		t.Errorf("EventLogSize = %d, expected %d", DefaultConfig().EventLogSize, clob.DefaultEventLogSize)
+	}
+}
+
+func TestAmoyConfigContracts(t *testing.T) {
+	config := AmoyConfig()
+	if config.CollateralAddress == CollateralAddress || config.CTFExchangeAddress == CTFExchangeAddress {
+		t.Error("AmoyConfig should not use mainnet collateral or exchange addresses")
+	}
+
+	sdk, err := NewSDK(config, sdkTestPrivateKey)
+	if err != nil {
+		t.Fatalf("NewSDK() error: %v", err)
+	}
+	defer sdk.Close()
+	if got := sdk.l1Signer.GetChainID(); got != AmoyChainID {
+		t.Errorf("signer chain ID = %d, expected %d", got, AmoyChainID)
+	}
+}
@@ -0,0 +1,21 @@
+package data
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetActivity 获取钱包的账户活动（成交、拆分、合并、赎回等），默认按时间倒序
+// 与本地成交记录对账时可按 Types 只取 ActivityTrade，并用 Start/End 限定时间范围
+func (c *Client) GetActivity(ctx context.Context, user string, params *ActivityParams) ([]Activity, error) {
+	path, err := userPath("/activity", user)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Activity
+	if err := c.httpClient.Get(ctx, path, params, &result); err != nil {
+		return nil, fmt.Errorf("failed to get activity for %s: %w", user, err)
+	}
+	return result, nil
+}
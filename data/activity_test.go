@@ -0,0 +1,60 @@
+package data
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestGetActivity(t *testing.T) {
+	start := time.Unix(1723700000, 0)
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/activity" {
+			t.Errorf("Expected path /activity, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("user") != testUser || q.Get("type") != "TRADE,REDEEM" || q.Get("side") != "BUY" ||
+			q.Get("start") != "1723700000" || q.Get("end") != "" || q.Get("sortDirection") != "ASC" {
+			t.Errorf("Unexpected query: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"proxyWallet":"` + testUser + `","timestamp":1723772457,"conditionId":"0xaaa",
+			"type":"TRADE","size":100,"usdcSize":42,"transactionHash":"0xtx","price":0.42,"asset":"123",
+			"side":"BUY","outcomeIndex":0,"title":"Will it rain?","outcome":"Yes"},
+			{"timestamp":1723772500,"type":"REDEEM","size":100,"usdcSize":100,"asset":"123","side":""}]`))
+	})
+	defer server.Close()
+
+	activity, err := client.GetActivity(context.Background(), testUser, &ActivityParams{
+		Types:         []ActivityType{ActivityTrade, ActivityRedeem},
+		Side:          common.SideBuy,
+		Start:         start,
+		SortDirection: SortAsc,
+	})
+	if err != nil {
+		t.Fatalf("GetActivity() error: %v", err)
+	}
+	if len(activity) != 2 {
+		t.Fatalf("Expected 2 activities, got %d", len(activity))
+	}
+	trade := activity[0]
+	if trade.Type != ActivityTrade || trade.Side != common.SideBuy || !trade.UsdcSize.Equal(decimal.NewFromInt(42)) {
+		t.Errorf("Unexpected trade: %+v", trade)
+	}
+	if !trade.Time().Equal(time.Unix(1723772457, 0)) {
+		t.Errorf("Time() = %v", trade.Time())
+	}
+	if activity[1].Type != ActivityRedeem {
+		t.Errorf("Unexpected activity: %+v", activity[1])
+	}
+
+	if _, err := client.GetActivity(context.Background(), "", nil); err == nil {
+		t.Error("Expected error for empty user")
+	}
+}
@@ -0,0 +1,73 @@
+package data
+
+import (
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// DefaultEndpoint Data API 端点（持仓、持仓价值、账户活动）
+const DefaultEndpoint = "https://data-api.polymarket.com"
+
+// Client Data API 客户端
+// 接口均为公开数据，按钱包地址查询，无需认证；使用代理钱包交易时应传入 funder（代理钱包）地址而非签名地址
+type Client struct {
+	httpClient *common.HTTPClient
+	config     *Config
+}
+
+// Config Data API 客户端配置
+type Config struct {
+	Endpoint     string        // API 端点
+	Timeout      time.Duration // 请求超时
+	MaxRetries   int           // 最大重试次数
+	RetryDelayMs int           // 重试间隔（毫秒）
+
+	// 出口地址池（可选），用于多出口 IP 部署
+	LocalAddrs *common.LocalAddrPool
+}
+
+// DefaultConfig 默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Endpoint:     DefaultEndpoint,
+		Timeout:      30 * time.Second,
+		MaxRetries:   3,
+		RetryDelayMs: 1000,
+	}
+}
+
+// NewClient 创建 Data API 客户端
+func NewClient(config *Config) *Client {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	httpConfig := &common.HTTPClientConfig{
+		BaseURL:      config.Endpoint,
+		Timeout:      config.Timeout,
+		MaxRetries:   config.MaxRetries,
+		RetryDelayMs: config.RetryDelayMs,
+		LocalAddrs:   config.LocalAddrs,
+	}
+
+	return &Client{
+		httpClient: common.NewHTTPClient(httpConfig),
+		config:     config,
+	}
+}
+
+// Close 关闭客户端
+func (c *Client) Close() {
+	// HTTP 客户端无需显式关闭
+}
+
+// SetRetryPolicy 运行时调整请求的最大重试次数与重试间隔（毫秒）
+func (c *Client) SetRetryPolicy(maxRetries, retryDelayMs int) {
+	c.httpClient.SetRetryPolicy(maxRetries, time.Duration(retryDelayMs)*time.Millisecond)
+}
+
+// GetConfig 获取配置
+func (c *Client) GetConfig() *Config {
+	return c.config
+}
@@ -0,0 +1,78 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxPositionsLimit /positions 单页最大条数
+const maxPositionsLimit = 500
+
+// userPath 带 user 查询参数的路径
+func userPath(path, user string) (string, error) {
+	if user == "" {
+		return "", fmt.Errorf("user address is required")
+	}
+	return path + "?user=" + url.QueryEscape(user), nil
+}
+
+// GetPositions 获取钱包的当前持仓（单页，Limit 为 0 时使用服务端默认值）
+func (c *Client) GetPositions(ctx context.Context, user string, params *PositionsParams) ([]Position, error) {
+	path, err := userPath("/positions", user)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Position
+	if err := c.httpClient.Get(ctx, path, params, &result); err != nil {
+		return nil, fmt.Errorf("failed to get positions for %s: %w", user, err)
+	}
+	return result, nil
+}
+
+// GetAllPositions 获取钱包的全部持仓（自动分页，忽略 params 中的 Offset）
+func (c *Client) GetAllPositions(ctx context.Context, user string, params *PositionsParams) ([]Position, error) {
+	query := PositionsParams{}
+	if params != nil {
+		query = *params
+	}
+	if query.Limit <= 0 || query.Limit > maxPositionsLimit {
+		query.Limit = maxPositionsLimit
+	}
+	query.Offset = 0
+
+	var all []Position
+	for {
+		page, err := c.GetPositions(ctx, user, &query)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < query.Limit {
+			return all, nil
+		}
+		query.Offset += len(page)
+	}
+}
+
+// GetPositionValue 获取钱包持仓的当前总价值（USDC），markets 非空时只计算这些市场（condition ID）
+func (c *Client) GetPositionValue(ctx context.Context, user string, markets ...string) (decimal.Decimal, error) {
+	path, err := userPath("/value", user)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	var result []PositionValue
+	if err := c.httpClient.Get(ctx, path, &valueParams{Markets: markets}, &result); err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get position value for %s: %w", user, err)
+	}
+
+	total := decimal.Zero
+	for _, v := range result {
+		total = total.Add(v.Value)
+	}
+	return total, nil
+}
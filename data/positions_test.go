@@ -0,0 +1,130 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const testUser = "0x56687bf447db6ffa42ffe2204a05edaa20f55839"
+
+func setupTestServer(handler http.HandlerFunc) (*httptest.Server, *Client) {
+	server := httptest.NewServer(handler)
+	client := NewClient(&Config{
+		Endpoint:     server.URL,
+		Timeout:      5 * time.Second,
+		MaxRetries:   0,
+		RetryDelayMs: 100,
+	})
+	return server, client
+}
+
+func TestGetPositions(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/positions" {
+			t.Errorf("Expected path /positions, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("user") != testUser || q.Get("market") != "0xaaa,0xbbb" || q.Get("sizeThreshold") != "0.1" ||
+			q.Get("redeemable") != "true" || q.Get("sortBy") != "CASHPNL" || q.Get("limit") != "10" {
+			t.Errorf("Unexpected query: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"proxyWallet":"` + testUser + `","asset":"123","conditionId":"0xaaa","size":120.5,
+			"avgPrice":0.42,"initialValue":50.61,"currentValue":60.25,"cashPnl":9.64,"percentPnl":19.04,
+			"curPrice":0.5,"redeemable":true,"mergeable":false,"title":"Will it rain?","outcome":"Yes",
+			"outcomeIndex":0,"oppositeOutcome":"No","oppositeAsset":"456","negativeRisk":true}]`))
+	})
+	defer server.Close()
+
+	redeemable := true
+	positions, err := client.GetPositions(context.Background(), testUser, &PositionsParams{
+		Markets:       []string{"0xaaa", "0xbbb"},
+		SizeThreshold: decimal.RequireFromString("0.1"),
+		Redeemable:    &redeemable,
+		SortBy:        PositionSortByCashPnl,
+		Limit:         10,
+	})
+	if err != nil {
+		t.Fatalf("GetPositions() error: %v", err)
+	}
+	if len(positions) != 1 {
+		t.Fatalf("Expected 1 position, got %d", len(positions))
+	}
+	p := positions[0]
+	if p.Asset != "123" || !p.Size.Equal(decimal.RequireFromString("120.5")) || !p.CurrentValue.Equal(decimal.RequireFromString("60.25")) {
+		t.Errorf("Unexpected position: %+v", p)
+	}
+	if !p.Redeemable || !p.NegativeRisk || p.OppositeAsset != "456" {
+		t.Errorf("Unexpected flags: %+v", p)
+	}
+
+	if _, err := client.GetPositions(context.Background(), "", nil); err == nil {
+		t.Error("Expected error for empty user")
+	}
+}
+
+func TestGetAllPositions(t *testing.T) {
+	const total = 1200
+	requests := 0
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if limit != maxPositionsLimit {
+			t.Errorf("limit = %d, expected %d", limit, maxPositionsLimit)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("["))
+		for i := offset; i < total && i < offset+limit; i++ {
+			if i > offset {
+				w.Write([]byte(","))
+			}
+			fmt.Fprintf(w, `{"asset":"%d","size":1}`, i)
+		}
+		w.Write([]byte("]"))
+	})
+	defer server.Close()
+
+	positions, err := client.GetAllPositions(context.Background(), testUser, &PositionsParams{Offset: 7})
+	if err != nil {
+		t.Fatalf("GetAllPositions() error: %v", err)
+	}
+	if len(positions) != total || positions[total-1].Asset != "1199" {
+		t.Errorf("Got %d positions", len(positions))
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 pages, got %d requests", requests)
+	}
+}
+
+func TestGetPositionValue(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/value" {
+			t.Errorf("Expected path /value, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("user") != testUser || r.URL.Query().Get("market") != "0xaaa" {
+			t.Errorf("Unexpected query: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"user":"` + testUser + `","value":1234.56}]`))
+	})
+	defer server.Close()
+
+	value, err := client.GetPositionValue(context.Background(), testUser, "0xaaa")
+	if err != nil {
+		t.Fatalf("GetPositionValue() error: %v", err)
+	}
+	if !value.Equal(decimal.RequireFromString("1234.56")) {
+		t.Errorf("value = %s, expected 1234.56", value)
+	}
+}
@@ -0,0 +1,142 @@
+package data
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// Position Data API 返回的持仓（/positions），数值字段以 USDC 或份额计
+type Position struct {
+	ProxyWallet        string          `json:"proxyWallet"`
+	Asset              string          `json:"asset"` // token ID
+	ConditionID        string          `json:"conditionId"`
+	Size               decimal.Decimal `json:"size"`
+	AvgPrice           decimal.Decimal `json:"avgPrice"`
+	InitialValue       decimal.Decimal `json:"initialValue"`
+	CurrentValue       decimal.Decimal `json:"currentValue"`
+	CashPnl            decimal.Decimal `json:"cashPnl"`
+	PercentPnl         decimal.Decimal `json:"percentPnl"`
+	TotalBought        decimal.Decimal `json:"totalBought"`
+	RealizedPnl        decimal.Decimal `json:"realizedPnl"`
+	PercentRealizedPnl decimal.Decimal `json:"percentRealizedPnl"`
+	CurPrice           decimal.Decimal `json:"curPrice"`
+	Redeemable         bool            `json:"redeemable"` // 市场已结算，可赎回
+	Mergeable          bool            `json:"mergeable"`  // 同时持有互补结果，可合并为 USDC
+	Title              string          `json:"title"`
+	Slug               string          `json:"slug"`
+	Icon               string          `json:"icon"`
+	EventSlug          string          `json:"eventSlug"`
+	Outcome            string          `json:"outcome"`
+	OutcomeIndex       int             `json:"outcomeIndex"`
+	OppositeOutcome    string          `json:"oppositeOutcome"`
+	OppositeAsset      string          `json:"oppositeAsset"`
+	EndDate            string          `json:"endDate"`
+	NegativeRisk       bool            `json:"negativeRisk"`
+}
+
+// PositionSortBy 持仓排序字段
+type PositionSortBy string
+
+const (
+	PositionSortByTokens     PositionSortBy = "TOKENS"
+	PositionSortByCurrent    PositionSortBy = "CURRENT"
+	PositionSortByInitial    PositionSortBy = "INITIAL"
+	PositionSortByCashPnl    PositionSortBy = "CASHPNL"
+	PositionSortByPercentPnl PositionSortBy = "PERCENTPNL"
+	PositionSortByPrice      PositionSortBy = "PRICE"
+	PositionSortByResolving  PositionSortBy = "RESOLVING"
+	PositionSortByTitle      PositionSortBy = "TITLE"
+)
+
+// SortDirection 排序方向
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "ASC"
+	SortDesc SortDirection = "DESC"
+)
+
+// PositionsParams 持仓查询参数（user 由 GetPositions 的参数指定）
+type PositionsParams struct {
+	Markets       []string        `url:"market,omitempty,comma"`  // 按 condition ID 过滤
+	SizeThreshold decimal.Decimal `url:"sizeThreshold,omitempty"` // 只返回份额不小于该值的持仓，零值使用服务端默认值（1）
+	Redeemable    *bool           `url:"redeemable,omitempty"`
+	Mergeable     *bool           `url:"mergeable,omitempty"`
+	Title         string          `url:"title,omitempty"`
+	SortBy        PositionSortBy  `url:"sortBy,omitempty"`
+	SortDirection SortDirection   `url:"sortDirection,omitempty"`
+	Limit         int             `url:"limit,omitempty"`
+	Offset        int             `url:"offset,omitempty"`
+}
+
+// PositionValue 持仓总价值（/value）
+type PositionValue struct {
+	User  string          `json:"user"`
+	Value decimal.Decimal `json:"value"`
+}
+
+// valueParams 持仓价值查询参数
+type valueParams struct {
+	Markets []string `url:"market,omitempty,comma"`
+}
+
+// ActivityType 账户活动类型
+type ActivityType string
+
+const (
+	ActivityTrade      ActivityType = "TRADE"
+	ActivitySplit      ActivityType = "SPLIT"
+	ActivityMerge      ActivityType = "MERGE"
+	ActivityRedeem     ActivityType = "REDEEM"
+	ActivityReward     ActivityType = "REWARD"
+	ActivityConversion ActivityType = "CONVERSION"
+)
+
+// Activity 账户活动（/activity）：成交、拆分、合并、赎回、奖励与 NegRisk 转换
+type Activity struct {
+	ProxyWallet     string          `json:"proxyWallet"`
+	Timestamp       int64           `json:"timestamp"` // 秒
+	ConditionID     string          `json:"conditionId"`
+	Type            ActivityType    `json:"type"`
+	Size            decimal.Decimal `json:"size"`     // 份额
+	UsdcSize        decimal.Decimal `json:"usdcSize"` // USDC 金额
+	TransactionHash string          `json:"transactionHash"`
+	Price           decimal.Decimal `json:"price"`
+	Asset           string          `json:"asset"`
+	Side            common.Side     `json:"side"` // 仅 TRADE 填充
+	OutcomeIndex    int             `json:"outcomeIndex"`
+	Title           string          `json:"title"`
+	Slug            string          `json:"slug"`
+	EventSlug       string          `json:"eventSlug"`
+	Outcome         string          `json:"outcome"`
+}
+
+// Time 活动时间
+func (a *Activity) Time() time.Time {
+	return time.Unix(a.Timestamp, 0)
+}
+
+// ActivitySortBy 活动排序字段
+type ActivitySortBy string
+
+const (
+	ActivitySortByTimestamp ActivitySortBy = "TIMESTAMP"
+	ActivitySortByTokens    ActivitySortBy = "TOKENS"
+	ActivitySortByCash      ActivitySortBy = "CASH"
+)
+
+// ActivityParams 账户活动查询参数（user 由 GetActivity 的参数指定）
+type ActivityParams struct {
+	Markets       []string       `url:"market,omitempty,comma"` // 按 condition ID 过滤
+	Types         []ActivityType `url:"type,omitempty,comma"`
+	Side          common.Side    `url:"side,omitempty"`
+	Start         time.Time      `url:"start,omitempty,unix"`
+	End           time.Time      `url:"end,omitempty,unix"`
+	SortBy        ActivitySortBy `url:"sortBy,omitempty"`
+	SortDirection SortDirection  `url:"sortDirection,omitempty"`
+	Limit         int            `url:"limit,omitempty"`
+	Offset        int            `url:"offset,omitempty"`
+}
@@ -0,0 +1,81 @@
+package polymarket
+
+import (
+	"go/build"
+	"strings"
+	"testing"
+)
+
+const modulePath = "github.com/binary-jerry/polymarket-sdk"
+
+// marketDataPackages can be used without pulling in the signing stack
+var marketDataPackages = []string{"common", "data", "gamma", "orderbook", "userws"}
+
+// signingImports are heavy or trading-only imports forbidden in market-data packages
+var signingImports = []string{
+	"github.com/ethereum/go-ethereum",
+	modulePath + "/auth",
+	modulePath + "/clob",
+}
+
+// packageImports lists the imports of the non-test Go files in dir built with tags
+func packageImports(t *testing.T, dir string, tags []string) []string {
+	t.Helper()
+
+	ctx := build.Default
+	ctx.BuildTags = tags
+	pkg, err := ctx.ImportDir(dir, 0)
+	if err != nil {
+		t.Fatalf("import %s: %v", dir, err)
+	}
+	return pkg.Imports
+}
+
+// assertNoSigningImports walks in-module imports of pkg transitively; external imports are checked directly
+func assertNoSigningImports(t *testing.T, pkg string, tags []string) {
+	t.Helper()
+
+	root := strings.TrimSuffix(modulePath+"/"+pkg, "/")
+	seen := map[string]bool{}
+	queue := []string{root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+
+		dir := "." + strings.TrimPrefix(current, modulePath)
+		for _, imp := range packageImports(t, dir, tags) {
+			for _, forbidden := range signingImports {
+				if imp == forbidden || strings.HasPrefix(imp, forbidden+"/") {
+					t.Errorf("%s (via %s, tags %v) imports %s", root, current, tags, imp)
+				}
+			}
+			if strings.HasPrefix(imp, modulePath+"/") {
+				queue = append(queue, imp)
+			}
+		}
+	}
+}
+
+func TestMarketDataPackagesAvoidSigningDependencies(t *testing.T) {
+	for _, pkg := range marketDataPackages {
+		assertNoSigningImports(t, pkg, nil)
+	}
+}
+
+func TestRootPackageNoSigningBuild(t *testing.T) {
+	// The nosigning tag drops the trading half of the root package
+	assertNoSigningImports(t, "", []string{"nosigning"})
+
+	// Without it the root package still provides trading
+	var hasCLOB bool
+	for _, imp := range packageImports(t, ".", nil) {
+		hasCLOB = hasCLOB || imp == modulePath+"/clob"
+	}
+	if !hasCLOB {
+		t.Error("default build of the root package should import clob")
+	}
+}
@@ -0,0 +1,230 @@
+package polymarket
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// ConfigFromEnv 读取的环境变量，未设置或为空时使用 DefaultConfig 的值
+const (
+	// 端点与网络
+	EnvGammaEndpoint = "POLYMARKET_GAMMA_ENDPOINT" // Gamma API 端点
+	EnvCLOBEndpoint  = "POLYMARKET_CLOB_ENDPOINT"  // CLOB API 端点
+	EnvWSEndpoint    = "POLYMARKET_WS_ENDPOINT"    // 订单簿 WebSocket 端点
+	EnvDataEndpoint  = "POLYMARKET_DATA_ENDPOINT"  // Data API 端点
+	EnvChainID       = "POLYMARKET_CHAIN_ID"       // 链 ID，137 或 80002（Amoy，同时切换为 Amoy 合约地址）
+	EnvWSProxyURLs   = "POLYMARKET_WS_PROXY_URLS"  // WebSocket 代理列表，逗号分隔
+	EnvRPCEndpoint   = "POLYMARKET_RPC_ENDPOINT"   // Polygon RPC 端点，链上授权与资金划转使用
+
+	// HTTP
+	EnvHTTPTimeout  = "POLYMARKET_HTTP_TIMEOUT"  // 读请求超时，time.ParseDuration 格式（如 30s）
+	EnvWriteTimeout = "POLYMARKET_WRITE_TIMEOUT" // 写请求超时，time.ParseDuration 格式
+	EnvMaxRetries   = "POLYMARKET_MAX_RETRIES"   // 读请求最大重试次数
+
+	// 账户
+	EnvPrivateKeyFile = "POLYMARKET_PRIVATE_KEY_FILE" // 私钥文件路径
+	EnvSignatureType  = "POLYMARKET_SIGNATURE_TYPE"   // 签名类型：0/eoa, 1/poly_proxy, 2/gnosis_safe
+	EnvFunderAddress  = "POLYMARKET_FUNDER_ADDRESS"   // 代理钱包地址，签名类型非 EOA 时必填
+
+	// 会话风控（Config.SessionLimits），nosigning 构建中忽略
+	EnvMaxOrderSize     = "POLYMARKET_MAX_ORDER_SIZE"     // 单笔订单最大份数
+	EnvMaxOrderNotional = "POLYMARKET_MAX_ORDER_NOTIONAL" // 单笔订单最大名义金额（USDC）
+	EnvMaxOpenOrders    = "POLYMARKET_MAX_OPEN_ORDERS"    // 同时挂单数上限
+	EnvMaxOpenNotional  = "POLYMARKET_MAX_OPEN_NOTIONAL"  // 挂单名义金额合计上限（USDC）
+
+	// 下单/撤单自限流（Config.OrderThrottle），nosigning 构建中忽略
+	EnvOrdersPerSecond  = "POLYMARKET_ORDERS_PER_SECOND"  // 每个市场每秒最多新订单数
+	EnvOrderBurst       = "POLYMARKET_ORDER_BURST"        // 新订单令牌桶容量
+	EnvCancelsPerSecond = "POLYMARKET_CANCELS_PER_SECOND" // 每个市场每秒最多撤单数
+	EnvCancelBurst      = "POLYMARKET_CANCEL_BURST"       // 撤单令牌桶容量
+	EnvThrottleWait     = "POLYMARKET_THROTTLE_WAIT"      // 令牌不足时是否等待（true/false）
+)
+
+// ConfigFromEnv 从环境变量构建并校验配置，统一嵌入本 SDK 的各服务的部署配置方式
+// 读取的变量见 Env* 常量；任一变量格式错误或组合无效时返回 common.ErrInvalidConfig
+// 私钥本身不进入 Config，通过 LoadPrivateKey 从 POLYMARKET_PRIVATE_KEY_FILE 读取
+func ConfigFromEnv() (*Config, error) {
+	config := DefaultConfig()
+	env := envReader{}
+
+	if chainID := env.int(EnvChainID); chainID == AmoyChainID {
+		config = AmoyConfig()
+	} else if chainID != 0 {
+		config.ChainID = chainID
+	}
+
+	env.string(EnvGammaEndpoint, &config.GammaEndpoint)
+	env.string(EnvCLOBEndpoint, &config.CLOBEndpoint)
+	env.string(EnvWSEndpoint, &config.WSEndpoint)
+	env.string(EnvDataEndpoint, &config.DataEndpoint)
+	env.string(EnvRPCEndpoint, &config.RPCEndpoint)
+	if v := os.Getenv(EnvWSProxyURLs); v != "" {
+		for _, u := range strings.Split(v, ",") {
+			if u = strings.TrimSpace(u); u != "" {
+				config.WSProxyURLs = append(config.WSProxyURLs, u)
+			}
+		}
+	}
+
+	env.duration(EnvHTTPTimeout, &config.HTTPTimeout)
+	env.duration(EnvWriteTimeout, &config.WriteTimeout)
+	if v := env.int(EnvMaxRetries); v != 0 {
+		config.MaxRetries = v
+	}
+
+	env.string(EnvPrivateKeyFile, &config.PrivateKeyFile)
+	env.string(EnvFunderAddress, &config.FunderAddress)
+	if v := os.Getenv(EnvSignatureType); v != "" {
+		signatureType, err := parseSignatureType(v)
+		if err != nil {
+			env.fail(EnvSignatureType, err)
+		}
+		config.SignatureType = signatureType
+	}
+
+	env.tradingOptions(&config.TradingOptions)
+
+	if env.err != nil {
+		return nil, env.err
+	}
+
+	config.Validate()
+	if err := config.validateAccount(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoadPrivateKey 读取 PrivateKeyFile 中的私钥（去除首尾空白），未配置文件时返回错误
+func (c *Config) LoadPrivateKey() (string, error) {
+	if c.PrivateKeyFile == "" {
+		return "", fmt.Errorf("%w: private key file not configured", common.ErrInvalidConfig)
+	}
+	data, err := os.ReadFile(c.PrivateKeyFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read private key file: %w", err)
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("%w: private key file %s is empty", common.ErrInvalidConfig, c.PrivateKeyFile)
+	}
+	return key, nil
+}
+
+// validateAccount 校验签名类型、代理钱包地址与风控/限流参数
+func (c *Config) validateAccount() error {
+	switch {
+	case c.SignatureType < 0 || c.SignatureType > 2:
+		return fmt.Errorf("%w: unsupported signature type %d", common.ErrInvalidConfig, c.SignatureType)
+	case c.FunderAddress != "" && !common.IsValidAddress(c.FunderAddress):
+		return fmt.Errorf("%w: invalid funder address %q", common.ErrInvalidConfig, c.FunderAddress)
+	case c.SignatureType != 0 && c.FunderAddress == "":
+		return fmt.Errorf("%w: funder address is required for signature type %d", common.ErrInvalidConfig, c.SignatureType)
+	}
+	return c.TradingOptions.validate()
+}
+
+// parseSignatureType 解析签名类型，支持数字与名称
+func parseSignatureType(v string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "0", "eoa":
+		return 0, nil
+	case "1", "poly_proxy", "proxy":
+		return 1, nil
+	case "2", "gnosis_safe", "safe":
+		return 2, nil
+	}
+	return 0, fmt.Errorf("unsupported signature type %q", v)
+}
+
+// envReader 读取环境变量并记录第一个解析错误
+type envReader struct {
+	err error
+}
+
+// fail 记录变量解析错误（只保留第一个）
+func (r *envReader) fail(name string, err error) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%w: %s: %v", common.ErrInvalidConfig, name, err)
+	}
+}
+
+// string 变量非空时写入 dst
+func (r *envReader) string(name string, dst *string) {
+	if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+		*dst = v
+	}
+}
+
+// int 解析整数，未设置时返回 0
+func (r *envReader) int(name string) int {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		r.fail(name, err)
+	}
+	return n
+}
+
+// float 解析浮点数，未设置时返回 0
+func (r *envReader) float(name string) float64 {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		r.fail(name, err)
+	}
+	return f
+}
+
+// bool 解析布尔值，未设置时返回 false
+func (r *envReader) bool(name string) bool {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		r.fail(name, err)
+	}
+	return b
+}
+
+// decimal 解析十进制数，未设置时返回 0
+func (r *envReader) decimal(name string) decimal.Decimal {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return decimal.Zero
+	}
+	d, err := decimal.NewFromString(v)
+	if err != nil {
+		r.fail(name, err)
+	}
+	return d
+}
+
+// duration 解析时长，变量非空时写入 dst
+func (r *envReader) duration(name string, dst *time.Duration) {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		r.fail(name, err)
+		return
+	}
+	*dst = d
+}
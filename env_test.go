@@ -0,0 +1,99 @@
+package polymarket
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// envTestPrivateKey is only written to and read back from a key file
+const envTestPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+func TestConfigFromEnvDefaults(t *testing.T) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error: %v", err)
+	}
+	if config.CLOBEndpoint != CLOBEndpoint || config.ChainID != ChainID {
+		t.Errorf("expected defaults, got endpoint %s chain %d", config.CLOBEndpoint, config.ChainID)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	funder := "0x1234567890abcdef1234567890abcdef12345678"
+	t.Setenv(EnvCLOBEndpoint, "https://clob.example.com")
+	t.Setenv(EnvChainID, "80002")
+	t.Setenv(EnvWSProxyURLs, "socks5://a:1080, http://b:8080")
+	t.Setenv(EnvRPCEndpoint, "https://polygon-rpc.example.com")
+	t.Setenv(EnvDataEndpoint, "https://data.example.com")
+	t.Setenv(EnvHTTPTimeout, "5s")
+	t.Setenv(EnvPrivateKeyFile, "/run/secrets/key")
+	t.Setenv(EnvSignatureType, "gnosis_safe")
+	t.Setenv(EnvFunderAddress, funder)
+
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error: %v", err)
+	}
+	if config.CLOBEndpoint != "https://clob.example.com" || config.GammaEndpoint != GammaEndpoint {
+		t.Errorf("unexpected endpoints: %s %s", config.CLOBEndpoint, config.GammaEndpoint)
+	}
+	if config.ChainID != AmoyChainID {
+		t.Errorf("ChainID = %d, expected %d", config.ChainID, AmoyChainID)
+	}
+	if config.RPCEndpoint != "https://polygon-rpc.example.com" || config.DataEndpoint != "https://data.example.com" {
+		t.Errorf("RPCEndpoint = %s, DataEndpoint = %s", config.RPCEndpoint, config.DataEndpoint)
+	}
+	if len(config.WSProxyURLs) != 2 || config.WSProxyURLs[1] != "http://b:8080" {
+		t.Errorf("unexpected proxies: %v", config.WSProxyURLs)
+	}
+	if config.HTTPTimeout != 5*time.Second {
+		t.Errorf("HTTPTimeout = %v", config.HTTPTimeout)
+	}
+	if config.PrivateKeyFile != "/run/secrets/key" || config.SignatureType != 2 || config.FunderAddress != funder {
+		t.Errorf("unexpected account config: %s %d %s", config.PrivateKeyFile, config.SignatureType, config.FunderAddress)
+	}
+}
+
+func TestConfigFromEnvInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"bad int", map[string]string{EnvChainID: "polygon"}},
+		{"bad duration", map[string]string{EnvHTTPTimeout: "5"}},
+		{"bad signature type", map[string]string{EnvSignatureType: "ledger"}},
+		{"proxy without funder", map[string]string{EnvSignatureType: "1"}},
+		{"bad funder", map[string]string{EnvSignatureType: "1", EnvFunderAddress: "0x123"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if _, err := ConfigFromEnv(); !errors.Is(err, common.ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig, got %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigLoadPrivateKey(t *testing.T) {
+	config := DefaultConfig()
+	if _, err := config.LoadPrivateKey(); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig without a key file, got %v", err)
+	}
+
+	config.PrivateKeyFile = filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(config.PrivateKeyFile, []byte(envTestPrivateKey+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	key, err := config.LoadPrivateKey()
+	if err != nil || key != envTestPrivateKey {
+		t.Errorf("LoadPrivateKey() = %q, %v", key, err)
+	}
+}
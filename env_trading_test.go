@@ -0,0 +1,79 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestConfigFromEnvTradingOptions(t *testing.T) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error: %v", err)
+	}
+	if config.SessionLimits != nil || config.OrderThrottle != nil {
+		t.Errorf("limits should be unset without env vars, got %+v %+v", config.SessionLimits, config.OrderThrottle)
+	}
+
+	t.Setenv(EnvChainID, "80002")
+	t.Setenv(EnvMaxOrderNotional, "250.5")
+	t.Setenv(EnvMaxOpenOrders, "20")
+	t.Setenv(EnvOrdersPerSecond, "2.5")
+	t.Setenv(EnvThrottleWait, "true")
+
+	config, err = ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error: %v", err)
+	}
+	if config.ChainID != AmoyChainID || config.CollateralAddress == CollateralAddress {
+		t.Errorf("Amoy chain should switch contract addresses, got chain %d collateral %s", config.ChainID, config.CollateralAddress)
+	}
+	if config.SessionLimits == nil || !config.SessionLimits.MaxOrderNotional.Equal(decimal.RequireFromString("250.5")) || config.SessionLimits.MaxOpenOrders != 20 {
+		t.Errorf("unexpected session limits: %+v", config.SessionLimits)
+	}
+	if config.OrderThrottle == nil || config.OrderThrottle.OrdersPerSecond != 2.5 || !config.OrderThrottle.Wait {
+		t.Errorf("unexpected throttle: %+v", config.OrderThrottle)
+	}
+}
+
+func TestConfigFromEnvTradingOptionsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"bad int", map[string]string{EnvMaxOpenOrders: "many"}},
+		{"negative limit", map[string]string{EnvMaxOrderSize: "-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if _, err := ConfigFromEnv(); !errors.Is(err, common.ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewSDKAppliesAccountConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.SignatureType = 1
+	config.FunderAddress = "0x1234567890abcdef1234567890abcdef12345678"
+
+	sdk, err := NewSDK(config, sdkTestPrivateKey)
+	if err != nil {
+		t.Fatalf("NewSDK() error: %v", err)
+	}
+	defer sdk.Close()
+
+	if got := sdk.Trading.GetFunderAddress(); !strings.EqualFold(got, config.FunderAddress) {
+		t.Errorf("funder address = %s, want %s", got, config.FunderAddress)
+	}
+}
@@ -0,0 +1,193 @@
+package polymarket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/gamma"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// EventOutcome 事件下的单个结果（一个市场的一个 token）
+type EventOutcome struct {
+	MarketID    string
+	MarketSlug  string
+	Question    string
+	ConditionID string
+	Outcome     string // 结果标签，如 "Yes" / "No"
+	OutcomeIdx  int    // 在市场 outcomes 中的下标，0 为 YES
+	TokenID     string
+	NegRisk     bool
+}
+
+// EventOutcomeBook 单个结果的订单簿快照
+type EventOutcomeBook struct {
+	EventOutcome
+	BBO *orderbook.BBO // 订单簿未初始化时为 nil
+}
+
+// NegRiskSum NegRisk 事件各市场 YES 价格之和
+// 理论上互斥结果的 YES 价格之和应接近 1，偏离即存在套利空间
+type NegRiskSum struct {
+	BidSum  decimal.Decimal // 各市场 YES 最优买价之和
+	AskSum  decimal.Decimal // 各市场 YES 最优卖价之和
+	Markets int             // 参与计算的市场数
+	Missing []string        // 缺少买价或卖价的 YES token
+}
+
+// IsComplete 是否所有市场都有完整报价
+func (n *NegRiskSum) IsComplete() bool {
+	return len(n.Missing) == 0
+}
+
+// EventSubscription 事件订阅句柄
+type EventSubscription struct {
+	Event    *gamma.Event
+	Outcomes []EventOutcome
+
+	sdk *SDK
+}
+
+// SubscribeEvent 解析事件下所有未关闭市场的 token 并统一订阅
+// 订单簿需已启动（OrderBook.Start）
+func (s *SDK) SubscribeEvent(ctx context.Context, eventSlug string) (*EventSubscription, error) {
+	if s.Markets == nil {
+		return nil, fmt.Errorf("markets client not initialized")
+	}
+	if !s.OrderBook.IsStarted() {
+		return nil, orderbook.ErrNotStarted
+	}
+
+	event, err := s.Markets.GetEventBySlug(ctx, eventSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := resolveEventOutcomes(event)
+	if len(outcomes) == 0 {
+		return nil, fmt.Errorf("event %s has no open markets with tokens", eventSlug)
+	}
+
+	sub := &EventSubscription{
+		Event:    event,
+		Outcomes: outcomes,
+		sdk:      s,
+	}
+
+	// 先注册元数据，保证首个更新事件即携带可读信息
+	for _, o := range outcomes {
+		meta := orderbook.TokenMetadata{
+			MarketSlug: o.MarketSlug,
+			Outcome:    o.Outcome,
+			NegRisk:    o.NegRisk,
+		}
+		if err := s.OrderBook.SetTokenMetadata(o.TokenID, meta); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.OrderBook.Subscribe(sub.TokenIDs()); err != nil {
+		return nil, fmt.Errorf("failed to subscribe event %s: %w", eventSlug, err)
+	}
+
+	return sub, nil
+}
+
+// resolveEventOutcomes 展开事件下所有未关闭市场的结果
+func resolveEventOutcomes(event *gamma.Event) []EventOutcome {
+	var outcomes []EventOutcome
+	for i := range event.Markets {
+		m := &event.Markets[i]
+		if m.Closed || m.Archived {
+			continue
+		}
+
+		labels := m.GetOutcomes()
+		for idx, tokenID := range m.GetClobTokenIDs() {
+			label := ""
+			if idx < len(labels) {
+				label = labels[idx]
+			}
+			outcomes = append(outcomes, EventOutcome{
+				MarketID:    m.ID,
+				MarketSlug:  m.Slug,
+				Question:    m.Question,
+				ConditionID: m.ConditionID,
+				Outcome:     label,
+				OutcomeIdx:  idx,
+				TokenID:     tokenID,
+				NegRisk:     m.NegRisk || event.NegRisk,
+			})
+		}
+	}
+	return outcomes
+}
+
+// TokenIDs 获取事件下所有 token ID
+func (e *EventSubscription) TokenIDs() []string {
+	ids := make([]string, 0, len(e.Outcomes))
+	for _, o := range e.Outcomes {
+		ids = append(ids, o.TokenID)
+	}
+	return ids
+}
+
+// Books 获取每个结果的最优买卖价
+func (e *EventSubscription) Books() []EventOutcomeBook {
+	books := make([]EventOutcomeBook, 0, len(e.Outcomes))
+	for _, o := range e.Outcomes {
+		book := EventOutcomeBook{EventOutcome: o}
+		if bbo, err := e.sdk.OrderBook.GetBBO(o.TokenID); err == nil {
+			book.BBO = bbo
+		}
+		books = append(books, book)
+	}
+	return books
+}
+
+// NegRiskSum 计算各市场 YES token 的最优买卖价之和
+// 仅适用于 NegRisk 事件
+func (e *EventSubscription) NegRiskSum() (*NegRiskSum, error) {
+	if !e.Event.NegRisk {
+		return nil, fmt.Errorf("event %s is not a NegRisk event", e.Event.Slug)
+	}
+
+	return sumNegRisk(e.Books()), nil
+}
+
+// sumNegRisk 汇总 YES 结果的报价
+func sumNegRisk(books []EventOutcomeBook) *NegRiskSum {
+	result := &NegRiskSum{
+		BidSum: decimal.Zero,
+		AskSum: decimal.Zero,
+	}
+
+	for _, b := range books {
+		if b.OutcomeIdx != 0 {
+			continue
+		}
+		result.Markets++
+
+		if b.BBO == nil || b.BBO.BestBid == nil || b.BBO.BestAsk == nil {
+			result.Missing = append(result.Missing, b.TokenID)
+		}
+		if b.BBO == nil {
+			continue
+		}
+		if b.BBO.BestBid != nil {
+			result.BidSum = result.BidSum.Add(b.BBO.BestBid.Price)
+		}
+		if b.BBO.BestAsk != nil {
+			result.AskSum = result.AskSum.Add(b.BBO.BestAsk.Price)
+		}
+	}
+
+	return result
+}
+
+// Unsubscribe 取消订阅事件下所有 token
+func (e *EventSubscription) Unsubscribe() error {
+	return e.sdk.OrderBook.Unsubscribe(e.TokenIDs())
+}
@@ -0,0 +1,71 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/gamma"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+func TestResolveEventOutcomes(t *testing.T) {
+	event := &gamma.Event{
+		Slug:    "election",
+		NegRisk: true,
+		Markets: []gamma.Market{
+			{ID: "m1", Slug: "a-wins", Outcomes: `["Yes","No"]`, ClobTokenIds: `["t1","t2"]`},
+			{ID: "m2", Slug: "b-wins", Outcomes: `["Yes","No"]`, ClobTokenIds: `["t3","t4"]`},
+			{ID: "m3", Slug: "c-wins", Closed: true, ClobTokenIds: `["t5","t6"]`},
+		},
+	}
+
+	outcomes := resolveEventOutcomes(event)
+	if len(outcomes) != 4 {
+		t.Fatalf("Expected 4 outcomes, got %d", len(outcomes))
+	}
+	if outcomes[2].TokenID != "t3" || outcomes[2].Outcome != "Yes" || outcomes[2].MarketSlug != "b-wins" {
+		t.Errorf("Unexpected outcome: %+v", outcomes[2])
+	}
+	if outcomes[3].OutcomeIdx != 1 || !outcomes[3].NegRisk {
+		t.Errorf("Unexpected outcome: %+v", outcomes[3])
+	}
+}
+
+func TestSumNegRisk(t *testing.T) {
+	price := func(p string) *orderbook.BestPrice {
+		return &orderbook.BestPrice{Price: decimal.RequireFromString(p)}
+	}
+	books := []EventOutcomeBook{
+		{EventOutcome: EventOutcome{TokenID: "t1", OutcomeIdx: 0}, BBO: &orderbook.BBO{BestBid: price("0.40"), BestAsk: price("0.42")}},
+		{EventOutcome: EventOutcome{TokenID: "t2", OutcomeIdx: 1}, BBO: &orderbook.BBO{BestBid: price("0.58"), BestAsk: price("0.60")}},
+		{EventOutcome: EventOutcome{TokenID: "t3", OutcomeIdx: 0}, BBO: &orderbook.BBO{BestBid: price("0.55"), BestAsk: price("0.57")}},
+		{EventOutcome: EventOutcome{TokenID: "t5", OutcomeIdx: 0}},
+	}
+
+	sum := sumNegRisk(books)
+	if sum.Markets != 3 {
+		t.Errorf("Markets = %d, expected 3", sum.Markets)
+	}
+	if !sum.BidSum.Equal(decimal.RequireFromString("0.95")) {
+		t.Errorf("BidSum = %s, expected 0.95", sum.BidSum)
+	}
+	if !sum.AskSum.Equal(decimal.RequireFromString("0.99")) {
+		t.Errorf("AskSum = %s, expected 0.99", sum.AskSum)
+	}
+	if sum.IsComplete() || len(sum.Missing) != 1 || sum.Missing[0] != "t5" {
+		t.Errorf("Missing = %v, expected [t5]", sum.Missing)
+	}
+}
+
+func TestSubscribeEventNotStarted(t *testing.T) {
+	sdk := NewPublicSDK(nil)
+	defer sdk.Close()
+
+	_, err := sdk.SubscribeEvent(context.Background(), "election")
+	if !errors.Is(err, orderbook.ErrNotStarted) {
+		t.Errorf("SubscribeEvent() error = %v, expected ErrNotStarted", err)
+	}
+}
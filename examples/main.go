@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -72,8 +73,10 @@ func main() {
 
 	// 等待初始化完成
 	log.Println("Waiting for orderbooks to initialize...")
-	for !sdk.IsAllInitialized() {
-		time.Sleep(100 * time.Millisecond)
+	waitCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := sdk.WaitForSnapshots(waitCtx, tokenIDs); err != nil {
+		log.Fatalf("Failed to wait for snapshots: %v", err)
 	}
 	log.Println("All orderbooks initialized")
 
@@ -124,9 +127,18 @@ func printOrderBookInfo(sdk *orderbook.SDK, tokenIDs []string) {
 		return
 	}
 
-	if priceSum.LessThan(decimal.NewFromInt(1)) {
-		log.Printf("Yes Price %s, No Price %s, sum %s \n", yes.Price, no.Price, priceSum)
+	if priceSum.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return
+	}
+
+	// 最优卖价之和小于 1 时，再按深度与 taker 手续费计算可实现的净边际
+	edge, err := sdk.ArbEdge(tokenIDs[0], tokenIDs[1], decimal.NewFromInt(100), 200)
+	if err != nil {
+		log.Printf("ArbEdge error: %v", err)
+		return
 	}
+	log.Printf("Yes Price %s, No Price %s, sum %s, filled %s, cost/share %s, net edge/share %s \n",
+		yes.Price, no.Price, priceSum, edge.FilledSize, edge.CostPerShare, edge.EdgePerShare)
 }
 
 func demonstrateAPI(sdk *orderbook.SDK, tokenID string) {
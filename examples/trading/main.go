@@ -1,3 +1,5 @@
+//go:build !nosigning
+
 package main
 
 import (
@@ -50,6 +52,22 @@ func main() {
 	fmt.Printf("USDC 余额: %s\n", balance.Balance)
 	fmt.Printf("USDC 授权: %s\n\n", balance.Allowance)
 
+	// 设置了 POLYMARKET_RPC_ENDPOINT 时在链上授权 USDC.e 与条件代币（仅 EOA，已授权的跳过）
+	if os.Getenv(polymarket.EnvRPCEndpoint) != "" {
+		fmt.Println("=== 设置链上授权 ===")
+		treasuryConfig := clob.DefaultTreasuryConfig()
+		treasuryConfig.RPCEndpoint = os.Getenv(polymarket.EnvRPCEndpoint)
+		treasury, err := sdk.NewTreasury(treasuryConfig)
+		if err != nil {
+			log.Fatalf("创建链上工具失败: %v", err)
+		}
+		txHashes, err := treasury.SetAllAllowances(ctx)
+		if err != nil {
+			log.Fatalf("设置授权失败: %v", err)
+		}
+		fmt.Printf("授权交易: %v\n\n", txHashes)
+	}
+
 	// 3. 获取活跃市场
 	fmt.Println("=== 获取市场用于交易 ===")
 	markets, err := sdk.Markets.GetActiveMarkets(ctx, 1)
@@ -0,0 +1,538 @@
+// Package execution 母单拆分执行算法
+//
+// Executor 将一笔母单拆成多笔子单执行：
+//   - TWAP：在给定时长内按等间隔时间片下 FAK 子单，每片目标为累计计划量减去已成交量，未成交部分顺延到下一片；
+//     子单价格取对手盘最优价并受母单限价约束
+//   - Iceberg：以限价挂出不超过 ClipSize 的可见子单（GTC），子单完全成交或被撤销后再挂出下一笔
+//
+// 子单状态通过 TradingClient.GetOrder 轮询跟踪，订单簿更新（Process）会提前触发一次检查。
+// 可暂停、恢复与取消；暂停和取消会撤销正在挂着的子单。
+//
+// TriggerEngine 提供客户端止损/止盈条件单：在行情满足条件时提交预先定义的订单，触发器可持久化以便重启后恢复。
+package execution
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// Algo 执行算法
+type Algo string
+
+const (
+	AlgoTWAP    Algo = "TWAP"
+	AlgoIceberg Algo = "ICEBERG"
+)
+
+// State 执行状态
+type State string
+
+const (
+	StateIdle      State = "IDLE"      // 未启动
+	StateRunning   State = "RUNNING"   // 执行中
+	StatePaused    State = "PAUSED"    // 已暂停
+	StateCompleted State = "COMPLETED" // 母单全部成交
+	StateExpired   State = "EXPIRED"   // TWAP 时间表结束仍有未成交数量
+	StateCanceled  State = "CANCELED"  // 被取消
+)
+
+// sizeDecimals 子单份额精度
+const sizeDecimals = 2
+
+// ParentOrder 母单
+type ParentOrder struct {
+	TokenID    string
+	Side       clob.OrderSide
+	Size       decimal.Decimal // 总份额
+	LimitPrice decimal.Decimal // 限价：BUY 为最高价，SELL 为最低价
+	TickSize   decimal.Decimal // 子单价格对齐单位，为 0 时使用 0.01
+	FeeRateBps int
+	IsNegRisk  bool
+}
+
+// TWAPParams TWAP 参数
+type TWAPParams struct {
+	Duration time.Duration // 总执行时长
+	Slices   int           // 时间片数量
+}
+
+// IcebergParams 冰山单参数
+type IcebergParams struct {
+	ClipSize decimal.Decimal // 每笔可见子单的最大份额
+}
+
+// Config 执行器配置
+type Config struct {
+	PollInterval   time.Duration // 子单状态轮询间隔
+	RequestTimeout time.Duration // 单次下单/查询/撤单请求超时
+	DepthLevels    int           // TWAP 定价读取的订单簿档数
+}
+
+// DefaultConfig 默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		PollInterval:   time.Second,
+		RequestTimeout: 10 * time.Second,
+		DepthLevels:    1,
+	}
+}
+
+// Status 执行进度
+type Status struct {
+	Algo           Algo
+	State          State
+	Filled         decimal.Decimal // 已成交份额
+	Remaining      decimal.Decimal // 未成交份额
+	Children       int             // 已提交的子单数
+	WorkingOrderID string          // 当前挂着的子单 ID
+	LastError      error           // 最近一次请求错误，下次检查会重试
+}
+
+// child 当前子单
+type child struct {
+	orderID string
+	matched decimal.Decimal // 已计入 filled 的成交量
+}
+
+// Executor 母单执行器
+type Executor struct {
+	client clob.TradingClient
+	books  orderbook.BookReader
+	config *Config
+	parent ParentOrder
+	algo   Algo
+	twap   TWAPParams
+	clip   decimal.Decimal
+
+	// runMu 串行化执行步骤与暂停/取消，保证撤单与下单不交错
+	runMu sync.Mutex
+
+	mu        sync.Mutex
+	state     State
+	filled    decimal.Decimal
+	children  int
+	working   *child
+	lastErr   error
+	slice     int       // 下一个 TWAP 时间片序号
+	startedAt time.Time // TWAP 时间表起点（暂停期间顺延）
+	pausedAt  time.Time
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+	now    func() time.Time
+}
+
+// NewTWAP 创建 TWAP 执行器，books 用于读取对手盘最优价（如 orderbook.SDK）
+func NewTWAP(client clob.TradingClient, books orderbook.BookReader, parent ParentOrder, params TWAPParams, config *Config) (*Executor, error) {
+	if params.Slices <= 0 || params.Duration <= 0 {
+		return nil, fmt.Errorf("%w: TWAP requires positive duration and slices", common.ErrInvalidConfig)
+	}
+	e, err := newExecutor(client, books, parent, AlgoTWAP, config)
+	if err != nil {
+		return nil, err
+	}
+	e.twap = params
+	return e, nil
+}
+
+// NewIceberg 创建冰山单执行器，子单以母单限价挂出
+func NewIceberg(client clob.TradingClient, parent ParentOrder, params IcebergParams, config *Config) (*Executor, error) {
+	if params.ClipSize.Truncate(sizeDecimals).LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("%w: iceberg clip size must be at least 0.01", common.ErrInvalidConfig)
+	}
+	e, err := newExecutor(client, nil, parent, AlgoIceberg, config)
+	if err != nil {
+		return nil, err
+	}
+	e.clip = params.ClipSize.Truncate(sizeDecimals)
+	return e, nil
+}
+
+// newExecutor 校验母单并创建执行器
+func newExecutor(client clob.TradingClient, books orderbook.BookReader, parent ParentOrder, algo Algo, config *Config) (*Executor, error) {
+	if parent.TokenID == "" {
+		return nil, fmt.Errorf("%w: token ID is required", common.ErrInvalidOrder)
+	}
+	if parent.Side != clob.OrderSideBuy && parent.Side != clob.OrderSideSell {
+		return nil, fmt.Errorf("%w: must be BUY or SELL, got %q", common.ErrInvalidOrderSide, parent.Side)
+	}
+	if !parent.Size.Truncate(sizeDecimals).IsPositive() {
+		return nil, fmt.Errorf("%w: parent size must be at least 0.01, got %s", common.ErrInvalidSize, parent.Size)
+	}
+	if !parent.LimitPrice.IsPositive() || parent.LimitPrice.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return nil, fmt.Errorf("%w: limit price must be between 0 and 1 (exclusive), got %s", common.ErrInvalidPrice, parent.LimitPrice)
+	}
+	if !parent.TickSize.IsPositive() {
+		parent.TickSize = decimal.RequireFromString("0.01")
+	}
+	parent.Size = parent.Size.Truncate(sizeDecimals)
+
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = 10 * time.Second
+	}
+	if config.DepthLevels <= 0 {
+		config.DepthLevels = 1
+	}
+
+	return &Executor{
+		client: client,
+		books:  books,
+		config: config,
+		parent: parent,
+		algo:   algo,
+		state:  StateIdle,
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		now:    time.Now,
+	}, nil
+}
+
+// Start 开始执行，只能调用一次
+func (e *Executor) Start() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state != StateIdle {
+		return fmt.Errorf("executor already started (state %s)", e.state)
+	}
+	e.state = StateRunning
+	e.startedAt = e.now()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	go func() {
+		defer close(e.done)
+		common.Supervise(ctx, "execution.executor", nil, e.loop)
+	}()
+	return nil
+}
+
+// Done 执行结束（完成、到期或取消）时关闭
+func (e *Executor) Done() <-chan struct{} {
+	return e.done
+}
+
+// Status 获取执行进度
+func (e *Executor) Status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status := Status{
+		Algo:      e.algo,
+		State:     e.state,
+		Filled:    e.filled,
+		Remaining: e.parent.Size.Sub(e.filled),
+		Children:  e.children,
+		LastError: e.lastErr,
+	}
+	if e.working != nil {
+		status.WorkingOrderID = e.working.orderID
+	}
+	return status
+}
+
+// Process 处理订单簿更新，母单 token 有变化时提前检查子单状态
+// 可在自己的更新循环中调用，或配合 orderbook.SDK.Updates() 使用
+func (e *Executor) Process(update orderbook.OrderBookUpdate) {
+	if update.TokenID != e.parent.TokenID {
+		return
+	}
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Pause 暂停执行并撤销正在挂着的子单；TWAP 时间表在暂停期间顺延
+func (e *Executor) Pause(ctx context.Context) error {
+	e.runMu.Lock()
+	defer e.runMu.Unlock()
+
+	e.mu.Lock()
+	if e.state != StateRunning {
+		state := e.state
+		e.mu.Unlock()
+		return fmt.Errorf("cannot pause executor in state %s", state)
+	}
+	e.state = StatePaused
+	e.pausedAt = e.now()
+	e.mu.Unlock()
+
+	return e.cancelWorking(ctx)
+}
+
+// Resume 恢复执行
+func (e *Executor) Resume() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state != StatePaused {
+		return fmt.Errorf("cannot resume executor in state %s", e.state)
+	}
+	e.state = StateRunning
+	e.startedAt = e.startedAt.Add(e.now().Sub(e.pausedAt))
+	e.pausedAt = time.Time{}
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Cancel 取消执行并撤销正在挂着的子单，已成交部分保留
+func (e *Executor) Cancel(ctx context.Context) error {
+	e.runMu.Lock()
+	defer e.runMu.Unlock()
+
+	e.mu.Lock()
+	if e.finishedLocked() {
+		e.mu.Unlock()
+		return nil
+	}
+	started := e.state != StateIdle
+	e.mu.Unlock()
+
+	if err := e.cancelWorking(ctx); err != nil {
+		return err
+	}
+	e.finish(StateCanceled)
+	if !started {
+		close(e.done)
+	}
+	return nil
+}
+
+// loop 按轮询间隔或订单簿更新推进执行
+func (e *Executor) loop(ctx context.Context) {
+	ticker := time.NewTicker(e.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if e.step(ctx) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-e.wake:
+		}
+	}
+}
+
+// step 同步子单成交并按算法下新子单，返回执行是否已结束
+func (e *Executor) step(ctx context.Context) bool {
+	e.runMu.Lock()
+	defer e.runMu.Unlock()
+
+	e.mu.Lock()
+	if e.finishedLocked() {
+		e.mu.Unlock()
+		return true
+	}
+	e.mu.Unlock()
+
+	if err := e.syncWorking(ctx); err != nil {
+		e.setError(err)
+		return false
+	}
+
+	e.mu.Lock()
+	if !e.filled.LessThan(e.parent.Size) {
+		e.mu.Unlock()
+		e.finish(StateCompleted)
+		return true
+	}
+	if e.state != StateRunning || e.working != nil {
+		e.mu.Unlock()
+		return false
+	}
+	var size decimal.Decimal
+	var orderType clob.OrderType
+	switch e.algo {
+	case AlgoTWAP:
+		if e.slice >= e.twap.Slices {
+			e.mu.Unlock()
+			e.finish(StateExpired)
+			return true
+		}
+		interval := e.twap.Duration / time.Duration(e.twap.Slices)
+		if e.now().Before(e.startedAt.Add(interval * time.Duration(e.slice))) {
+			e.mu.Unlock()
+			return false
+		}
+		e.slice++
+		// 累计计划量减去已成交量，之前未成交的部分顺延到本片
+		target := e.parent.Size.Mul(decimal.NewFromInt(int64(e.slice))).Div(decimal.NewFromInt(int64(e.twap.Slices)))
+		size = target.Sub(e.filled).Truncate(sizeDecimals)
+		orderType = clob.OrderTypeFAK
+	case AlgoIceberg:
+		size = decimal.Min(e.clip, e.parent.Size.Sub(e.filled))
+		orderType = clob.OrderTypeGTC
+	}
+	e.mu.Unlock()
+
+	if !size.IsPositive() {
+		return false
+	}
+	if err := e.post(ctx, size, orderType); err != nil {
+		e.setError(err)
+	}
+	return false
+}
+
+// syncWorking 查询当前子单，累加新增成交量，子单结束后清除
+func (e *Executor) syncWorking(ctx context.Context) error {
+	e.mu.Lock()
+	working := e.working
+	e.mu.Unlock()
+	if working == nil {
+		return nil
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, e.config.RequestTimeout)
+	order, err := e.client.GetOrder(reqCtx, working.orderID)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to get child order %s: %w", working.orderID, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if delta := order.SizeMatched.Sub(working.matched); delta.IsPositive() {
+		e.filled = e.filled.Add(delta)
+		working.matched = order.SizeMatched
+	}
+	if !order.IsActive() || order.IsFilled() {
+		e.working = nil
+	}
+	return nil
+}
+
+// post 按算法定价并提交子单
+func (e *Executor) post(ctx context.Context, size decimal.Decimal, orderType clob.OrderType) error {
+	req := &clob.CreateOrderRequest{
+		TokenID:    e.parent.TokenID,
+		Side:       e.parent.Side,
+		Price:      e.childPrice(),
+		Size:       size,
+		Type:       orderType,
+		FeeRateBps: e.parent.FeeRateBps,
+		IsNegRisk:  e.parent.IsNegRisk,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, e.config.RequestTimeout)
+	resp, err := e.client.CreateOrder(reqCtx, req)
+	cancel()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to post child order: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("child order rejected: %s", resp.ErrorMsg)
+	}
+	e.children++
+	e.lastErr = nil
+	if resp.OrderID != "" {
+		e.working = &child{orderID: resp.OrderID}
+	}
+	return nil
+}
+
+// childPrice 子单价格：冰山单使用限价；TWAP 取对手盘最优价并受限价约束，无行情时使用限价
+func (e *Executor) childPrice() decimal.Decimal {
+	limit := e.parent.LimitPrice
+	buy := e.parent.Side == clob.OrderSideBuy
+	// 限价按不越界的方向对齐 tick
+	limit = clob.RoundPriceToTick(limit, e.parent.TickSize, !buy)
+	if e.algo != AlgoTWAP || e.books == nil {
+		return limit
+	}
+
+	bids, asks, err := e.books.GetDepth(e.parent.TokenID, e.config.DepthLevels)
+	if err != nil {
+		return limit
+	}
+	if buy && len(asks) > 0 && asks[0].Price.LessThan(limit) {
+		return asks[0].Price
+	}
+	if !buy && len(bids) > 0 && bids[0].Price.GreaterThan(limit) {
+		return bids[0].Price
+	}
+	return limit
+}
+
+// cancelWorking 撤销当前子单并同步其最终成交量
+func (e *Executor) cancelWorking(ctx context.Context) error {
+	e.mu.Lock()
+	working := e.working
+	e.mu.Unlock()
+	if working == nil {
+		return nil
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, e.config.RequestTimeout)
+	err := e.client.CancelOrder(reqCtx, working.orderID)
+	cancel()
+	if err != nil {
+		err = fmt.Errorf("failed to cancel child order %s: %w", working.orderID, err)
+		e.setError(err)
+		return err
+	}
+
+	// 撤单前可能已有成交
+	if err := e.syncWorking(ctx); err != nil {
+		e.setError(err)
+	}
+	e.mu.Lock()
+	e.working = nil
+	e.mu.Unlock()
+	return nil
+}
+
+// finish 进入终止状态并停止循环
+func (e *Executor) finish(state State) {
+	e.mu.Lock()
+	e.state = state
+	cancel := e.cancel
+	e.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// finishedLocked 是否已处于终止状态
+func (e *Executor) finishedLocked() bool {
+	return e.state.IsTerminal()
+}
+
+// setError 记录最近一次错误
+func (e *Executor) setError(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.lastErr = err
+}
+
+// IsTerminal 状态是否为终止状态
+func (s State) IsTerminal() bool {
+	return s == StateCompleted || s == StateExpired || s == StateCanceled
+}
@@ -0,0 +1,279 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// fakeClient records child orders; FAK orders fill up to fakFill, GTC orders rest until filled by the test
+type fakeClient struct {
+	clob.TradingClient
+
+	mu       sync.Mutex
+	orders   map[string]*clob.Order
+	posted   []*clob.CreateOrderRequest
+	canceled []string
+	fakFill  decimal.Decimal // max fill per FAK child, zero means fill fully
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{orders: make(map[string]*clob.Order)}
+}
+
+func (f *fakeClient) CreateOrder(ctx context.Context, req *clob.CreateOrderRequest) (*clob.OrderResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := fmt.Sprintf("o%d", len(f.posted)+1)
+	f.posted = append(f.posted, req)
+	order := &clob.Order{ID: id, Status: clob.OrderStatusLive, OriginalSize: req.Size, Price: req.Price, Side: req.Side}
+	if req.Type == clob.OrderTypeFAK {
+		order.SizeMatched = req.Size
+		if f.fakFill.IsPositive() && f.fakFill.LessThan(req.Size) {
+			order.SizeMatched = f.fakFill
+		}
+		order.Status = clob.OrderStatusMatched
+	}
+	f.orders[id] = order
+	return &clob.OrderResponse{Success: true, OrderID: id}, nil
+}
+
+func (f *fakeClient) GetOrder(ctx context.Context, orderID string) (*clob.Order, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	order, ok := f.orders[orderID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	copied := *order
+	return &copied, nil
+}
+
+func (f *fakeClient) CancelOrder(ctx context.Context, orderID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.canceled = append(f.canceled, orderID)
+	f.orders[orderID].Status = clob.OrderStatusCanceled
+	return nil
+}
+
+// fill matches size on a resting order
+func (f *fakeClient) fill(orderID string, size string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	order := f.orders[orderID]
+	order.SizeMatched = order.SizeMatched.Add(decimal.RequireFromString(size))
+	if order.IsFilled() {
+		order.Status = clob.OrderStatusMatched
+	}
+}
+
+// askBook is a BookReader with a single best ask
+type askBook struct{ ask decimal.Decimal }
+
+func (b askBook) GetBBO(tokenID string) (*orderbook.BBO, error) {
+	return nil, orderbook.ErrNotInitialized
+}
+
+func (b askBook) GetDepth(tokenID string, depth int) ([]orderbook.OrderSummary, []orderbook.OrderSummary, error) {
+	return nil, []orderbook.OrderSummary{{Price: b.ask, Size: decimal.NewFromInt(1000)}}, nil
+}
+
+func testParent(size string) ParentOrder {
+	return ParentOrder{
+		TokenID:    "tok",
+		Side:       clob.OrderSideBuy,
+		Size:       decimal.RequireFromString(size),
+		LimitPrice: decimal.RequireFromString("0.55"),
+	}
+}
+
+func TestTWAP_Schedule(t *testing.T) {
+	client := newFakeClient()
+	client.fakFill = decimal.NewFromInt(20)
+	exec, err := NewTWAP(client, askBook{ask: decimal.RequireFromString("0.52")}, testParent("100"),
+		TWAPParams{Duration: 4 * time.Minute, Slices: 4}, nil)
+	if err != nil {
+		t.Fatalf("NewTWAP() error: %v", err)
+	}
+	now := time.Unix(1000, 0)
+	exec.now = func() time.Time { return now }
+	exec.state, exec.startedAt = StateRunning, now
+
+	ctx := context.Background()
+	exec.step(ctx) // slice 1: 25, fills 20
+	exec.step(ctx) // before slice 2: no new child
+	if len(client.posted) != 1 || !client.posted[0].Size.Equal(decimal.NewFromInt(25)) {
+		t.Fatalf("expected one child of 25, got %d", len(client.posted))
+	}
+	if !client.posted[0].Price.Equal(decimal.RequireFromString("0.52")) || client.posted[0].Type != clob.OrderTypeFAK {
+		t.Errorf("child priced at %s type %s, expected best ask FAK", client.posted[0].Price, client.posted[0].Type)
+	}
+
+	// Pausing shifts the schedule
+	if err := exec.Pause(ctx); err != nil {
+		t.Fatalf("Pause() error: %v", err)
+	}
+	now = now.Add(5 * time.Minute)
+	exec.step(ctx)
+	if len(client.posted) != 1 {
+		t.Error("no child should be posted while paused")
+	}
+	if err := exec.Resume(); err != nil {
+		t.Fatalf("Resume() error: %v", err)
+	}
+	now = now.Add(time.Minute)
+	exec.step(ctx) // slice 2: target 50 - filled 20 = 30
+	if len(client.posted) != 2 || !client.posted[1].Size.Equal(decimal.NewFromInt(30)) {
+		t.Fatalf("expected catch-up child of 30, got %v", client.posted[len(client.posted)-1].Size)
+	}
+
+	client.fakFill = decimal.Zero
+	for i := 0; i < 2; i++ {
+		now = now.Add(time.Minute)
+		exec.step(ctx)
+	}
+	if done := exec.step(ctx); !done {
+		t.Fatal("expected executor to finish")
+	}
+	status := exec.Status()
+	if status.State != StateCompleted || !status.Filled.Equal(decimal.NewFromInt(100)) || status.Children != 4 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestTWAP_Expired(t *testing.T) {
+	client := newFakeClient()
+	client.fakFill = decimal.NewFromInt(10)
+	exec, _ := NewTWAP(client, nil, testParent("100"), TWAPParams{Duration: time.Minute, Slices: 2}, nil)
+	now := time.Unix(1000, 0)
+	exec.now = func() time.Time { return now }
+	exec.state, exec.startedAt = StateRunning, now
+
+	ctx := context.Background()
+	exec.step(ctx)
+	if !client.posted[0].Price.Equal(decimal.RequireFromString("0.55")) {
+		t.Errorf("without a book the child should use the limit, got %s", client.posted[0].Price)
+	}
+	now = now.Add(time.Minute)
+	exec.step(ctx)
+	if done := exec.step(ctx); !done || exec.Status().State != StateExpired {
+		t.Errorf("expected expired, got %s", exec.Status().State)
+	}
+	if !exec.Status().Remaining.Equal(decimal.NewFromInt(80)) {
+		t.Errorf("remaining = %s", exec.Status().Remaining)
+	}
+}
+
+func TestIceberg(t *testing.T) {
+	client := newFakeClient()
+	exec, err := NewIceberg(client, testParent("25"), IcebergParams{ClipSize: decimal.NewFromInt(10)},
+		&Config{PollInterval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewIceberg() error: %v", err)
+	}
+	if err := exec.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+
+	waitFor := func(cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out, status %+v", exec.Status())
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	working := func(id string) func() bool {
+		return func() bool { return exec.Status().WorkingOrderID == id }
+	}
+
+	waitFor(working("o1"))
+	client.fill("o1", "4")
+	exec.Process(orderbook.OrderBookUpdate{TokenID: "tok"})
+	waitFor(func() bool { return exec.Status().Filled.Equal(decimal.NewFromInt(4)) })
+	client.fill("o1", "6")
+	waitFor(working("o2"))
+
+	// Pause cancels the resting clip; resume posts a fresh one
+	if err := exec.Pause(context.Background()); err != nil {
+		t.Fatalf("Pause() error: %v", err)
+	}
+	if len(client.canceled) != 1 || client.canceled[0] != "o2" {
+		t.Errorf("expected o2 canceled, got %v", client.canceled)
+	}
+	if err := exec.Resume(); err != nil {
+		t.Fatalf("Resume() error: %v", err)
+	}
+	waitFor(working("o3"))
+	client.fill("o3", "10")
+	waitFor(working("o4"))
+
+	client.mu.Lock()
+	last := client.posted[3]
+	client.mu.Unlock()
+	if !last.Size.Equal(decimal.NewFromInt(5)) || !last.Price.Equal(decimal.RequireFromString("0.55")) || last.Type != clob.OrderTypeGTC {
+		t.Errorf("unexpected final clip: %+v", last)
+	}
+
+	client.fill("o4", "5")
+	select {
+	case <-exec.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("executor did not finish")
+	}
+	if status := exec.Status(); status.State != StateCompleted || !status.Remaining.IsZero() {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestExecutor_Cancel(t *testing.T) {
+	client := newFakeClient()
+	exec, _ := NewIceberg(client, testParent("25"), IcebergParams{ClipSize: decimal.NewFromInt(10)},
+		&Config{PollInterval: 5 * time.Millisecond})
+	if err := exec.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	for exec.Status().WorkingOrderID == "" {
+		time.Sleep(time.Millisecond)
+	}
+	client.fill("o1", "3")
+
+	if err := exec.Cancel(context.Background()); err != nil {
+		t.Fatalf("Cancel() error: %v", err)
+	}
+	<-exec.Done()
+	status := exec.Status()
+	if status.State != StateCanceled || !status.Filled.Equal(decimal.NewFromInt(3)) || status.WorkingOrderID != "" {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if err := exec.Start(); err == nil {
+		t.Error("Start after Cancel should fail")
+	}
+}
+
+func TestNewExecutor_Validation(t *testing.T) {
+	if _, err := NewTWAP(newFakeClient(), nil, testParent("100"), TWAPParams{}, nil); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+	if _, err := NewIceberg(newFakeClient(), testParent("100"), IcebergParams{ClipSize: decimal.RequireFromString("0.001")}, nil); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+	parent := testParent("100")
+	parent.LimitPrice = decimal.NewFromInt(1)
+	if _, err := NewIceberg(newFakeClient(), parent, IcebergParams{ClipSize: decimal.NewFromInt(10)}, nil); !errors.Is(err, common.ErrInvalidPrice) {
+		t.Errorf("expected ErrInvalidPrice, got %v", err)
+	}
+}
@@ -0,0 +1,444 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// TriggerCondition 触发条件
+type TriggerCondition string
+
+const (
+	// TriggerPriceAtOrBelow 价格跌至触发价及以下（止损）
+	TriggerPriceAtOrBelow TriggerCondition = "price_at_or_below"
+	// TriggerPriceAtOrAbove 价格升至触发价及以上（止盈）
+	TriggerPriceAtOrAbove TriggerCondition = "price_at_or_above"
+)
+
+// TriggerPriceSource 触发判断使用的价格
+type TriggerPriceSource string
+
+const (
+	// TriggerSourceExecutable 订单可成交的对手价：SELL 单看最优买价，BUY 单看最优卖价（默认）
+	TriggerSourceExecutable TriggerPriceSource = "executable"
+	// TriggerSourceLastTrade 最新成交价，需以 orderbook.EventTypeLastTradePrice 订阅对应 token
+	TriggerSourceLastTrade TriggerPriceSource = "last_trade"
+	// TriggerSourceMid 中间价
+	TriggerSourceMid TriggerPriceSource = "mid"
+)
+
+// Trigger 客户端条件单：条件满足时提交预先定义的订单
+// Polymarket 没有原生止损/止盈单，触发器只在进程运行并收到行情时生效
+type Trigger struct {
+	ID        string                  `json:"id"`
+	TokenID   string                  `json:"token_id"`
+	Condition TriggerCondition        `json:"condition"`
+	Price     decimal.Decimal         `json:"price"` // 触发价
+	Source    TriggerPriceSource      `json:"source,omitempty"`
+	Order     clob.CreateOrderRequest `json:"order"`              // 触发后提交的订单
+	NegRisk   bool                    `json:"neg_risk,omitempty"` // Order.IsNegRisk 不参与序列化，单独保存
+	OCOGroup  string                  `json:"oco_group,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// TriggerEvent 触发记录
+type TriggerEvent struct {
+	Trigger  Trigger
+	Value    decimal.Decimal // 触发时的价格
+	Time     time.Time
+	Response *clob.OrderResponse // 下单响应，请求失败时为 nil
+	Err      error               // 下单或持久化失败原因；失败的触发器不会重新挂起
+	Canceled []string            // 同 OCO 组被一并撤销的触发器 ID
+}
+
+// TriggerStore 触发器持久化接口，Save 整体替换已保存的触发器
+type TriggerStore interface {
+	Load(ctx context.Context) ([]Trigger, error)
+	Save(ctx context.Context, triggers []Trigger) error
+}
+
+// FileTriggerStore 基于本地文件的触发器存储（JSON 格式）
+type FileTriggerStore struct {
+	path string
+}
+
+// NewFileTriggerStore 创建文件触发器存储
+func NewFileTriggerStore(path string) *FileTriggerStore {
+	return &FileTriggerStore{path: path}
+}
+
+// Load 读取触发器，文件不存在时返回空列表
+func (s *FileTriggerStore) Load(ctx context.Context) ([]Trigger, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read trigger file: %w", err)
+	}
+
+	var triggers []Trigger
+	if err := json.Unmarshal(data, &triggers); err != nil {
+		return nil, fmt.Errorf("failed to parse trigger file: %w", err)
+	}
+	return triggers, nil
+}
+
+// Save 写入触发器（先写临时文件再重命名，避免写入中断导致文件损坏）
+func (s *FileTriggerStore) Save(ctx context.Context, triggers []Trigger) error {
+	data, err := json.MarshalIndent(triggers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal triggers: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".triggers-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write triggers: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write triggers: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to save trigger file: %w", err)
+	}
+	return nil
+}
+
+// TriggerConfig 触发引擎配置
+type TriggerConfig struct {
+	ChannelSize   int           // 触发记录 channel 缓冲区大小，满时丢弃
+	SubmitTimeout time.Duration // 触发后下单请求超时
+}
+
+// DefaultTriggerConfig 默认配置
+func DefaultTriggerConfig() *TriggerConfig {
+	return &TriggerConfig{
+		ChannelSize:   100,
+		SubmitTimeout: 10 * time.Second,
+	}
+}
+
+// OrderCreator 提交订单（*clob.Client 与 sim.Exchange 已实现）
+type OrderCreator interface {
+	CreateOrder(ctx context.Context, req *clob.CreateOrderRequest) (*clob.OrderResponse, error)
+}
+
+// TriggerEngine 客户端止损/止盈引擎，在订单簿更新流上评估触发器，条件满足时提交对应订单
+// 触发器在下单前先从存储中移除，进程在下单前后崩溃都不会在重启后重复下单（至多一次）
+type TriggerEngine struct {
+	mu       sync.Mutex
+	client   OrderCreator
+	books    orderbook.BookReader
+	store    TriggerStore
+	config   *TriggerConfig
+	triggers map[string]*Trigger        // ID -> trigger
+	lastPx   map[string]decimal.Decimal // tokenID -> 最新成交价
+
+	eventChan chan TriggerEvent
+	now       func() time.Time
+}
+
+// NewTriggerEngine 创建触发引擎，store 为 nil 时不持久化
+func NewTriggerEngine(client OrderCreator, books orderbook.BookReader, store TriggerStore, config *TriggerConfig) *TriggerEngine {
+	if config == nil {
+		config = DefaultTriggerConfig()
+	}
+	if config.SubmitTimeout <= 0 {
+		config.SubmitTimeout = 10 * time.Second
+	}
+
+	return &TriggerEngine{
+		client:    client,
+		books:     books,
+		store:     store,
+		config:    config,
+		triggers:  make(map[string]*Trigger),
+		lastPx:    make(map[string]decimal.Decimal),
+		eventChan: make(chan TriggerEvent, config.ChannelSize),
+		now:       time.Now,
+	}
+}
+
+// Load 从存储恢复触发器（替换当前全部触发器），应在处理行情前调用
+func (e *TriggerEngine) Load(ctx context.Context) error {
+	if e.store == nil {
+		return nil
+	}
+	triggers, err := e.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	loaded := make(map[string]*Trigger, len(triggers))
+	for i := range triggers {
+		t := triggers[i]
+		if t.Source == "" {
+			t.Source = TriggerSourceExecutable
+		}
+		if err := validateTrigger(&t); err != nil {
+			return fmt.Errorf("invalid stored trigger %q: %w", t.ID, err)
+		}
+		t.Order.IsNegRisk = t.NegRisk
+		loaded[t.ID] = &t
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.triggers = loaded
+	return nil
+}
+
+// Add 添加触发器并持久化，相同 ID 的触发器会被替换
+func (e *TriggerEngine) Add(ctx context.Context, t Trigger) error {
+	if t.Source == "" {
+		t.Source = TriggerSourceExecutable
+	}
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = e.now()
+	}
+	t.NegRisk = t.Order.IsNegRisk
+	if err := validateTrigger(&t); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	prev := e.triggers[t.ID]
+	e.triggers[t.ID] = &t
+	if err := e.saveLocked(ctx); err != nil {
+		if prev != nil {
+			e.triggers[t.ID] = prev
+		} else {
+			delete(e.triggers, t.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+// Remove 移除触发器并持久化
+func (e *TriggerEngine) Remove(ctx context.Context, id string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	prev, ok := e.triggers[id]
+	if !ok {
+		return nil
+	}
+	delete(e.triggers, id)
+	if err := e.saveLocked(ctx); err != nil {
+		e.triggers[id] = prev
+		return err
+	}
+	return nil
+}
+
+// Triggers 当前挂起的触发器，按创建时间排序
+func (e *TriggerEngine) Triggers() []Trigger {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.listLocked()
+}
+
+// Events 获取触发记录 channel
+func (e *TriggerEngine) Events() <-chan TriggerEvent {
+	return e.eventChan
+}
+
+// Run 持续消费更新流并评估触发器，直到 ctx 取消或 updates 关闭
+func (e *TriggerEngine) Run(ctx context.Context, updates <-chan orderbook.OrderBookUpdate) {
+	common.Supervise(ctx, "execution.trigger", nil, func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				e.Process(ctx, update)
+			}
+		}
+	})
+}
+
+// Process 评估更新所属 token 的触发器，条件满足的触发器被移除并提交订单，返回触发记录
+func (e *TriggerEngine) Process(ctx context.Context, update orderbook.OrderBookUpdate) []TriggerEvent {
+	fired, saveErr := e.collect(ctx, update)
+
+	for i := range fired {
+		ev := &fired[i]
+		submitCtx, cancel := context.WithTimeout(ctx, e.config.SubmitTimeout)
+		order := ev.Trigger.Order
+		resp, err := e.client.CreateOrder(submitCtx, &order)
+		cancel()
+		if err == nil && resp != nil && !resp.Success {
+			err = fmt.Errorf("triggered order rejected: %s", resp.ErrorMsg)
+		}
+		ev.Response = resp
+		// 持久化失败时仍下单：止损优先于至多一次保证
+		ev.Err = errors.Join(err, saveErr)
+
+		select {
+		case e.eventChan <- *ev:
+		default:
+		}
+	}
+	return fired
+}
+
+// collect 找出条件满足的触发器，连同同 OCO 组的触发器一起移除并持久化
+func (e *TriggerEngine) collect(ctx context.Context, update orderbook.OrderBookUpdate) ([]TriggerEvent, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if update.LastTrade != nil {
+		if px, err := decimal.NewFromString(update.LastTrade.Price); err == nil {
+			e.lastPx[update.TokenID] = px
+		}
+	}
+
+	var fired []TriggerEvent
+	for _, t := range e.listLocked() {
+		if t.TokenID != update.TokenID {
+			continue
+		}
+		if _, pending := e.triggers[t.ID]; !pending {
+			continue // 已被同组触发器撤销
+		}
+		value, ok := e.priceLocked(&t)
+		if !ok || !conditionMet(t.Condition, value, t.Price) {
+			continue
+		}
+
+		delete(e.triggers, t.ID)
+		ev := TriggerEvent{Trigger: t, Value: value, Time: e.now()}
+		if t.OCOGroup != "" {
+			for id, other := range e.triggers {
+				if other.OCOGroup == t.OCOGroup {
+					delete(e.triggers, id)
+					ev.Canceled = append(ev.Canceled, id)
+				}
+			}
+			sort.Strings(ev.Canceled)
+		}
+		fired = append(fired, ev)
+	}
+
+	if len(fired) == 0 {
+		return nil, nil
+	}
+	return fired, e.saveLocked(ctx)
+}
+
+// priceLocked 按触发器的价格来源取当前价格，数据不足时返回 false
+func (e *TriggerEngine) priceLocked(t *Trigger) (decimal.Decimal, bool) {
+	if t.Source == TriggerSourceLastTrade {
+		px, ok := e.lastPx[t.TokenID]
+		return px, ok
+	}
+
+	bbo, err := e.books.GetBBO(t.TokenID)
+	if err != nil || bbo == nil {
+		return decimal.Zero, false
+	}
+	if t.Source == TriggerSourceMid {
+		if bbo.BestBid == nil || bbo.BestAsk == nil {
+			return decimal.Zero, false
+		}
+		return bbo.BestBid.Price.Add(bbo.BestAsk.Price).Div(decimal.NewFromInt(2)), true
+	}
+
+	if t.Order.Side == clob.OrderSideSell {
+		if bbo.BestBid == nil {
+			return decimal.Zero, false
+		}
+		return bbo.BestBid.Price, true
+	}
+	if bbo.BestAsk == nil {
+		return decimal.Zero, false
+	}
+	return bbo.BestAsk.Price, true
+}
+
+// saveLocked 持久化当前触发器
+func (e *TriggerEngine) saveLocked(ctx context.Context) error {
+	if e.store == nil {
+		return nil
+	}
+	if err := e.store.Save(ctx, e.listLocked()); err != nil {
+		return fmt.Errorf("failed to save triggers: %w", err)
+	}
+	return nil
+}
+
+// listLocked 按创建时间排序的触发器副本
+func (e *TriggerEngine) listLocked() []Trigger {
+	list := make([]Trigger, 0, len(e.triggers))
+	for _, t := range e.triggers {
+		list = append(list, *t)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.Before(list[j].CreatedAt)
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// conditionMet 判断价格是否满足触发条件
+func conditionMet(cond TriggerCondition, value, price decimal.Decimal) bool {
+	if cond == TriggerPriceAtOrBelow {
+		return value.LessThanOrEqual(price)
+	}
+	return value.GreaterThanOrEqual(price)
+}
+
+// validateTrigger 校验触发器
+func validateTrigger(t *Trigger) error {
+	if t.ID == "" {
+		return fmt.Errorf("%w: trigger ID is required", common.ErrInvalidConfig)
+	}
+	if t.TokenID == "" {
+		return fmt.Errorf("%w: trigger token ID is required", common.ErrInvalidConfig)
+	}
+	switch t.Condition {
+	case TriggerPriceAtOrBelow, TriggerPriceAtOrAbove:
+	default:
+		return fmt.Errorf("%w: unknown trigger condition %q", common.ErrInvalidConfig, t.Condition)
+	}
+	switch t.Source {
+	case TriggerSourceExecutable, TriggerSourceLastTrade, TriggerSourceMid:
+	default:
+		return fmt.Errorf("%w: unknown trigger price source %q", common.ErrInvalidConfig, t.Source)
+	}
+	if !t.Price.IsPositive() || t.Price.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return fmt.Errorf("%w: trigger price must be between 0 and 1 (exclusive), got %s", common.ErrInvalidPrice, t.Price)
+	}
+	if t.Order.TokenID != t.TokenID {
+		return fmt.Errorf("%w: trigger order token %q does not match trigger token %q", common.ErrInvalidOrder, t.Order.TokenID, t.TokenID)
+	}
+	return clob.ValidateOrderRequest(&t.Order)
+}
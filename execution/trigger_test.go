@@ -0,0 +1,145 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// bboBook is a BookReader with a settable BBO
+type bboBook struct{ bid, ask decimal.Decimal }
+
+func (b *bboBook) GetBBO(tokenID string) (*orderbook.BBO, error) {
+	return &orderbook.BBO{
+		BestBid: &orderbook.BestPrice{Price: b.bid},
+		BestAsk: &orderbook.BestPrice{Price: b.ask},
+	}, nil
+}
+
+func (b *bboBook) GetDepth(tokenID string, depth int) ([]orderbook.OrderSummary, []orderbook.OrderSummary, error) {
+	return nil, nil, nil
+}
+
+func sellOrder(price string) clob.CreateOrderRequest {
+	return clob.CreateOrderRequest{
+		TokenID: "tok",
+		Side:    clob.OrderSideSell,
+		Price:   decimal.RequireFromString(price),
+		Size:    decimal.NewFromInt(50),
+		Type:    clob.OrderTypeFAK,
+	}
+}
+
+func TestTriggerEngine_StopLossWithOCO(t *testing.T) {
+	d := decimal.RequireFromString
+	client := newFakeClient()
+	book := &bboBook{bid: d("0.50"), ask: d("0.52")}
+	store := NewFileTriggerStore(filepath.Join(t.TempDir(), "triggers.json"))
+	engine := NewTriggerEngine(client, book, store, nil)
+	ctx := context.Background()
+
+	stop := Trigger{ID: "stop", TokenID: "tok", Condition: TriggerPriceAtOrBelow, Price: d("0.45"), Order: sellOrder("0.40"), OCOGroup: "bracket"}
+	stop.Order.IsNegRisk = true
+	target := Trigger{ID: "tp", TokenID: "tok", Condition: TriggerPriceAtOrAbove, Price: d("0.70"), Order: sellOrder("0.70"), OCOGroup: "bracket"}
+	for _, tr := range []Trigger{stop, target} {
+		if err := engine.Add(ctx, tr); err != nil {
+			t.Fatalf("Add(%s) error: %v", tr.ID, err)
+		}
+	}
+
+	// Triggers survive a restart
+	restored := NewTriggerEngine(client, book, store, nil)
+	if err := restored.Load(ctx); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := restored.Triggers(); len(got) != 2 || !got[0].Order.IsNegRisk {
+		t.Fatalf("restored triggers = %+v", got)
+	}
+
+	update := orderbook.OrderBookUpdate{TokenID: "tok", EventType: orderbook.EventTypePriceChange}
+	if fired := restored.Process(ctx, update); len(fired) != 0 {
+		t.Fatalf("nothing should fire at bid 0.50, got %d", len(fired))
+	}
+
+	// The stop uses the bid for a SELL order; the ask staying high does not matter
+	book.bid = d("0.45")
+	fired := restored.Process(ctx, update)
+	if len(fired) != 1 || fired[0].Trigger.ID != "stop" || fired[0].Err != nil {
+		t.Fatalf("expected stop to fire, got %+v", fired)
+	}
+	if len(fired[0].Canceled) != 1 || fired[0].Canceled[0] != "tp" {
+		t.Errorf("expected take-profit canceled, got %v", fired[0].Canceled)
+	}
+	if len(client.posted) != 1 || !client.posted[0].Price.Equal(d("0.40")) || !client.posted[0].IsNegRisk {
+		t.Errorf("unexpected submitted order: %+v", client.posted)
+	}
+	if ev := <-restored.Events(); ev.Response == nil || ev.Response.OrderID != "o1" {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+
+	// Fired triggers are removed from the store so they do not fire again after restart
+	if saved, _ := store.Load(ctx); len(saved) != 0 {
+		t.Errorf("store still has %d triggers", len(saved))
+	}
+	if fired := restored.Process(ctx, update); len(fired) != 0 {
+		t.Error("trigger fired twice")
+	}
+}
+
+func TestTriggerEngine_LastTrade(t *testing.T) {
+	d := decimal.RequireFromString
+	client := newFakeClient()
+	engine := NewTriggerEngine(client, &bboBook{bid: d("0.10"), ask: d("0.90")}, nil, nil)
+	ctx := context.Background()
+
+	buy := sellOrder("0.60")
+	buy.Side = clob.OrderSideBuy
+	err := engine.Add(ctx, Trigger{ID: "breakout", TokenID: "tok", Condition: TriggerPriceAtOrAbove, Price: d("0.60"), Source: TriggerSourceLastTrade, Order: buy})
+	if err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+
+	if fired := engine.Process(ctx, orderbook.OrderBookUpdate{TokenID: "tok", EventType: orderbook.EventTypePriceChange}); len(fired) != 0 {
+		t.Error("should not fire without a trade print")
+	}
+	trade := orderbook.OrderBookUpdate{
+		TokenID:   "tok",
+		EventType: orderbook.EventTypeLastTradePrice,
+		LastTrade: &orderbook.LastTradePriceMessage{Price: "0.61", Size: "10", Side: orderbook.SideBuy},
+	}
+	if fired := engine.Process(ctx, trade); len(fired) != 1 || !fired[0].Value.Equal(d("0.61")) {
+		t.Errorf("expected breakout to fire at 0.61, got %+v", fired)
+	}
+}
+
+func TestTriggerEngine_Validation(t *testing.T) {
+	d := decimal.RequireFromString
+	engine := NewTriggerEngine(newFakeClient(), &bboBook{}, nil, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		trigger Trigger
+		wantErr error
+	}{
+		{"missing id", Trigger{TokenID: "tok", Condition: TriggerPriceAtOrBelow, Price: d("0.4"), Order: sellOrder("0.4")}, common.ErrInvalidConfig},
+		{"bad condition", Trigger{ID: "x", TokenID: "tok", Condition: "crosses", Price: d("0.4"), Order: sellOrder("0.4")}, common.ErrInvalidConfig},
+		{"bad price", Trigger{ID: "x", TokenID: "tok", Condition: TriggerPriceAtOrBelow, Price: d("1"), Order: sellOrder("0.4")}, common.ErrInvalidPrice},
+		{"token mismatch", Trigger{ID: "x", TokenID: "other", Condition: TriggerPriceAtOrBelow, Price: d("0.4"), Order: sellOrder("0.4")}, common.ErrInvalidOrder},
+		{"invalid order", Trigger{ID: "x", TokenID: "tok", Condition: TriggerPriceAtOrBelow, Price: d("0.4"), Order: sellOrder("0")}, common.ErrInvalidPrice},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := engine.Add(ctx, tt.trigger); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
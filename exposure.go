@@ -0,0 +1,234 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/gamma"
+)
+
+// UncategorizedCategory Gamma 未提供分类时使用的分类名
+const UncategorizedCategory = "uncategorized"
+
+// PositionExposure 单个 token 的持仓敞口
+type PositionExposure struct {
+	TokenID     string
+	ConditionID string
+	MarketSlug  string
+	Question    string
+	Outcome     string
+	Size        decimal.Decimal // 份额，也是该结果兑付时的最大赔付（USDC）
+	Price       decimal.Decimal // 估值价格：订单簿中间价，无行情时使用 Gamma 结果价格
+	Value       decimal.Decimal // Size * Price
+	Priced      bool            // 是否取得了估值价格
+}
+
+// EventExposure 单个事件（如一场选举、一场比赛）下的持仓敞口汇总
+type EventExposure struct {
+	EventID   string // Gamma 未返回所属事件时为空，此时按市场单独汇总
+	EventSlug string
+	Title     string
+	Category  string
+	Positions []PositionExposure
+	Value     decimal.Decimal // 持仓市值合计
+	MaxPayout decimal.Decimal // 各结果份额合计（所有持仓结果同时兑付时的上限）
+	Share     float64         // 占全部持仓市值的比例
+}
+
+// CategoryExposure 单个分类下的持仓敞口汇总
+type CategoryExposure struct {
+	Category  string
+	Events    int
+	Value     decimal.Decimal
+	MaxPayout decimal.Decimal
+	Share     float64
+}
+
+// GetExposureByEvent 按事件汇总当前持仓敞口，按市值降序
+// 持仓来自 Trading.GetPositions，事件与分类来自 Gamma 市场信息，估值优先使用已订阅的订单簿中间价
+func (s *SDK) GetExposureByEvent(ctx context.Context) ([]EventExposure, error) {
+	if s.Trading == nil {
+		return nil, fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+	if s.Markets == nil {
+		return nil, fmt.Errorf("markets client not initialized")
+	}
+
+	positions, err := s.Trading.GetPositions(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+
+	markets, err := s.positionMarkets(ctx, positions)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregateExposure(positions, markets, s.bookMid), nil
+}
+
+// GetExposureByCategory 按分类汇总当前持仓敞口，按市值降序
+func (s *SDK) GetExposureByCategory(ctx context.Context) ([]CategoryExposure, error) {
+	events, err := s.GetExposureByEvent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return aggregateCategories(events), nil
+}
+
+// positionMarkets 批量查询持仓所属的 Gamma 市场，返回 conditionID -> market
+func (s *SDK) positionMarkets(ctx context.Context, positions []*clob.Position) (map[string]*gamma.Market, error) {
+	conditionIDs := make([]string, 0, len(positions))
+	for _, pos := range positions {
+		conditionIDs = append(conditionIDs, pos.MarketID)
+	}
+
+	batch, err := s.Markets.GetMarketsByConditionIDs(ctx, conditionIDs)
+	if err != nil {
+		return nil, err
+	}
+	markets := make(map[string]*gamma.Market, len(batch))
+	for i := range batch {
+		markets[batch[i].ConditionID] = &batch[i]
+	}
+	return markets, nil
+}
+
+// bookMid 已订阅且已初始化的订单簿中间价
+func (s *SDK) bookMid(tokenID string) (decimal.Decimal, bool) {
+	if s.OrderBook == nil {
+		return decimal.Zero, false
+	}
+	mid, err := s.OrderBook.GetMidPrice(tokenID)
+	if err != nil {
+		return decimal.Zero, false
+	}
+	return mid, true
+}
+
+// aggregateExposure 将持仓按事件分组汇总
+// mid 返回 token 的实时价格，不可用时回退到 Gamma 的结果价格
+func aggregateExposure(positions []*clob.Position, markets map[string]*gamma.Market, mid func(tokenID string) (decimal.Decimal, bool)) []EventExposure {
+	groups := make(map[string]*EventExposure)
+	var order []string
+	total := decimal.Zero
+
+	for _, pos := range positions {
+		pe := PositionExposure{
+			TokenID:     pos.TokenID,
+			ConditionID: pos.MarketID,
+			Outcome:     pos.Outcome,
+			Size:        pos.Size,
+		}
+
+		market := markets[pos.MarketID]
+		key := "market:" + pos.MarketID
+		group := EventExposure{Category: UncategorizedCategory}
+		if market != nil {
+			pe.MarketSlug = market.Slug
+			pe.Question = market.Question
+			group.Title = market.Question
+			group.EventSlug = market.Slug
+			if market.Category != "" {
+				group.Category = market.Category
+			}
+			if len(market.Events) > 0 {
+				event := market.Events[0]
+				key = "event:" + event.ID
+				group.EventID = event.ID
+				group.EventSlug = event.Slug
+				group.Title = event.Title
+				if market.Category == "" && len(event.Tags) > 0 {
+					group.Category = event.Tags[0].Label
+				}
+			}
+		}
+
+		if price, ok := mid(pos.TokenID); ok {
+			pe.Price, pe.Priced = price, true
+		} else if price, ok := gammaOutcomePrice(market, pos.TokenID); ok {
+			pe.Price, pe.Priced = price, true
+		}
+		pe.Value = pe.Size.Mul(pe.Price)
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.Positions = append(g.Positions, pe)
+		g.Value = g.Value.Add(pe.Value)
+		g.MaxPayout = g.MaxPayout.Add(pe.Size)
+		total = total.Add(pe.Value)
+	}
+
+	result := make([]EventExposure, 0, len(order))
+	for _, key := range order {
+		g := groups[key]
+		if total.IsPositive() {
+			g.Share, _ = g.Value.Div(total).Float64()
+		}
+		result = append(result, *g)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Value.GreaterThan(result[j].Value)
+	})
+	return result
+}
+
+// aggregateCategories 将事件敞口按分类汇总
+func aggregateCategories(events []EventExposure) []CategoryExposure {
+	byCategory := make(map[string]*CategoryExposure)
+	var order []string
+	total := decimal.Zero
+
+	for _, ev := range events {
+		c, ok := byCategory[ev.Category]
+		if !ok {
+			c = &CategoryExposure{Category: ev.Category}
+			byCategory[ev.Category] = c
+			order = append(order, ev.Category)
+		}
+		c.Events++
+		c.Value = c.Value.Add(ev.Value)
+		c.MaxPayout = c.MaxPayout.Add(ev.MaxPayout)
+		total = total.Add(ev.Value)
+	}
+
+	result := make([]CategoryExposure, 0, len(order))
+	for _, category := range order {
+		c := byCategory[category]
+		if total.IsPositive() {
+			c.Share, _ = c.Value.Div(total).Float64()
+		}
+		result = append(result, *c)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Value.GreaterThan(result[j].Value)
+	})
+	return result
+}
+
+// gammaOutcomePrice Gamma 市场中 token 对应结果的价格
+func gammaOutcomePrice(market *gamma.Market, tokenID string) (decimal.Decimal, bool) {
+	if market == nil {
+		return decimal.Zero, false
+	}
+	prices, err := market.GetOutcomePrices()
+	if err != nil {
+		return decimal.Zero, false
+	}
+	for i, id := range market.GetClobTokenIDs() {
+		if id == tokenID && i < len(prices) {
+			return prices[i], true
+		}
+	}
+	return decimal.Zero, false
+}
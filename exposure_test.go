@@ -0,0 +1,65 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/gamma"
+)
+
+func TestAggregateExposure(t *testing.T) {
+	d := decimal.RequireFromString
+	election := gamma.Event{ID: "e1", Slug: "election", Title: "Election", Tags: []gamma.Tag{{Label: "Politics"}}}
+	markets := map[string]*gamma.Market{
+		"c1": {ConditionID: "c1", Slug: "cand-a", Events: []gamma.Event{election}, ClobTokenIds: `["a-yes","a-no"]`, OutcomePrices: `["0.6","0.4"]`},
+		"c2": {ConditionID: "c2", Slug: "cand-b", Events: []gamma.Event{election}, ClobTokenIds: `["b-yes","b-no"]`, OutcomePrices: `["0.3","0.7"]`},
+		"c3": {ConditionID: "c3", Slug: "game", Question: "Who wins?", Category: "Sports", ClobTokenIds: `["g-yes","g-no"]`, OutcomePrices: `["0.5","0.5"]`},
+	}
+	positions := []*clob.Position{
+		{TokenID: "a-yes", MarketID: "c1", Size: d("100")},
+		{TokenID: "b-no", MarketID: "c2", Size: d("50")},
+		{TokenID: "g-yes", MarketID: "c3", Size: d("40")},
+		{TokenID: "x", MarketID: "unknown", Size: d("10")},
+	}
+	// The live book overrides the Gamma price for a-yes
+	mid := func(tokenID string) (decimal.Decimal, bool) {
+		if tokenID == "a-yes" {
+			return d("0.65"), true
+		}
+		return decimal.Zero, false
+	}
+
+	events := aggregateExposure(positions, markets, mid)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(events))
+	}
+
+	// Election: 100*0.65 + 50*0.7 = 100
+	e := events[0]
+	if e.EventID != "e1" || e.Category != "Politics" || len(e.Positions) != 2 || !e.Value.Equal(d("100")) || !e.MaxPayout.Equal(d("150")) {
+		t.Errorf("unexpected election exposure: %+v", e)
+	}
+	if e.Share < 0.83 || e.Share > 0.84 { // 100 / 120
+		t.Errorf("share = %v", e.Share)
+	}
+
+	// Markets without an event are grouped on their own
+	g := events[1]
+	if g.EventID != "" || g.Title != "Who wins?" || g.Category != "Sports" || !g.Value.Equal(d("20")) {
+		t.Errorf("unexpected game exposure: %+v", g)
+	}
+
+	u := events[2]
+	if u.Category != UncategorizedCategory || u.Positions[0].Priced || !u.MaxPayout.Equal(d("10")) {
+		t.Errorf("unexpected unknown-market exposure: %+v", u)
+	}
+
+	categories := aggregateCategories(events)
+	if len(categories) != 3 || categories[0].Category != "Politics" || categories[0].Events != 1 || !categories[0].Value.Equal(d("100")) {
+		t.Errorf("unexpected categories: %+v", categories)
+	}
+}
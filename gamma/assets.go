@@ -0,0 +1,200 @@
+package gamma
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAssetCacheSize 默认最多缓存的图片资源数
+	DefaultAssetCacheSize = 256
+	// DefaultAssetMaxAge 默认缓存新鲜期
+	DefaultAssetMaxAge = 10 * time.Minute
+)
+
+// Asset 图片资源（市场/事件的 image、icon）
+type Asset struct {
+	URL          string
+	Data         []byte
+	ContentType  string
+	ETag         string
+	LastModified string
+	FetchedAt    time.Time // 最近一次从服务器获取或校验的时间
+}
+
+// AssetCache 图片资源缓存（并发安全）
+// 新鲜期内直接返回缓存；过期后使用 If-None-Match / If-Modified-Since 条件请求，
+// 服务器返回 304 时复用缓存内容
+type AssetCache struct {
+	mu         sync.Mutex
+	httpClient *http.Client
+	entries    map[string]*Asset
+	maxEntries int
+	maxAge     time.Duration
+	now        func() time.Time
+}
+
+// NewAssetCache 创建图片资源缓存
+func NewAssetCache(config *Config) *AssetCache {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	maxEntries := config.AssetCacheSize
+	if maxEntries <= 0 {
+		maxEntries = DefaultAssetCacheSize
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	httpClient := &http.Client{Timeout: timeout}
+	if config.LocalAddrs != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = config.LocalAddrs.DialContext
+		httpClient.Transport = transport
+	}
+
+	return &AssetCache{
+		httpClient: httpClient,
+		entries:    make(map[string]*Asset),
+		maxEntries: maxEntries,
+		maxAge:     config.AssetMaxAge,
+		now:        time.Now,
+	}
+}
+
+// Get 获取资源，优先使用缓存
+func (c *AssetCache) Get(ctx context.Context, url string) (*Asset, error) {
+	if url == "" {
+		return nil, fmt.Errorf("asset URL is required")
+	}
+
+	c.mu.Lock()
+	cached := c.entries[url]
+	if cached != nil && c.now().Sub(cached.FetchedAt) < c.maxAge {
+		c.mu.Unlock()
+		return cached, nil
+	}
+	c.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create asset request: %w", err)
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch asset %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		refreshed := *cached
+		refreshed.FetchedAt = c.now()
+		c.store(&refreshed)
+		return &refreshed, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch asset %s: status %d", url, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read asset %s: %w", url, err)
+	}
+
+	asset := &Asset{
+		URL:          url,
+		Data:         data,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    c.now(),
+	}
+	c.store(asset)
+	return asset, nil
+}
+
+// Invalidate 移除指定资源的缓存
+func (c *AssetCache) Invalidate(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, url)
+}
+
+// Len 获取缓存的资源数
+func (c *AssetCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// store 写入缓存，超出容量时淘汰最久未校验的资源
+func (c *AssetCache) store(asset *Asset) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[asset.URL]; !ok && len(c.entries) >= c.maxEntries {
+		var oldest string
+		var oldestAt time.Time
+		for url, e := range c.entries {
+			if oldest == "" || e.FetchedAt.Before(oldestAt) {
+				oldest, oldestAt = url, e.FetchedAt
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[asset.URL] = asset
+}
+
+// GetAsset 获取图片资源（带缓存）
+func (c *Client) GetAsset(ctx context.Context, url string) (*Asset, error) {
+	return c.assets.Get(ctx, url)
+}
+
+// GetMarketImage 获取市场图片
+func (c *Client) GetMarketImage(ctx context.Context, market *Market) (*Asset, error) {
+	if market == nil || market.Image == "" {
+		return nil, fmt.Errorf("market has no image")
+	}
+	return c.assets.Get(ctx, market.Image)
+}
+
+// GetMarketIcon 获取市场图标
+func (c *Client) GetMarketIcon(ctx context.Context, market *Market) (*Asset, error) {
+	if market == nil || market.Icon == "" {
+		return nil, fmt.Errorf("market has no icon")
+	}
+	return c.assets.Get(ctx, market.Icon)
+}
+
+// GetEventImage 获取事件图片
+func (c *Client) GetEventImage(ctx context.Context, event *Event) (*Asset, error) {
+	if event == nil || event.Image == "" {
+		return nil, fmt.Errorf("event has no image")
+	}
+	return c.assets.Get(ctx, event.Image)
+}
+
+// GetEventIcon 获取事件图标
+func (c *Client) GetEventIcon(ctx context.Context, event *Event) (*Asset, error) {
+	if event == nil || event.Icon == "" {
+		return nil, fmt.Errorf("event has no icon")
+	}
+	return c.assets.Get(ctx, event.Icon)
+}
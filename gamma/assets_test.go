@@ -0,0 +1,134 @@
+package gamma
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newAssetServer(t *testing.T, hits, notModified *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png-bytes"))
+	}))
+}
+
+func TestAssetCache_ETagRevalidation(t *testing.T) {
+	var hits, notModified int32
+	server := newAssetServer(t, &hits, &notModified)
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.AssetMaxAge = time.Minute
+	cache := NewAssetCache(config)
+	now := time.Unix(1000, 0)
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	url := server.URL + "/image.png"
+
+	asset, err := cache.Get(ctx, url)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if string(asset.Data) != "png-bytes" || asset.ContentType != "image/png" || asset.ETag != `"v1"` {
+		t.Errorf("Unexpected asset: %+v", asset)
+	}
+
+	// Fresh: served from cache without a request
+	if _, err := cache.Get(ctx, url); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if hits != 1 {
+		t.Errorf("Expected 1 request while fresh, got %d", hits)
+	}
+
+	// Stale: conditional request returns 304 and cached data is reused
+	now = now.Add(2 * time.Minute)
+	asset, err = cache.Get(ctx, url)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if hits != 2 || notModified != 1 {
+		t.Errorf("Expected conditional request, hits=%d notModified=%d", hits, notModified)
+	}
+	if string(asset.Data) != "png-bytes" || !asset.FetchedAt.Equal(now) {
+		t.Errorf("Expected revalidated cached asset, got %+v", asset)
+	}
+}
+
+func TestAssetCache_Eviction(t *testing.T) {
+	var hits, notModified int32
+	server := newAssetServer(t, &hits, &notModified)
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.AssetCacheSize = 2
+	cache := NewAssetCache(config)
+
+	ctx := context.Background()
+	for _, path := range []string{"/a", "/b", "/c"} {
+		if _, err := cache.Get(ctx, server.URL+path); err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 cached assets, got %d", cache.Len())
+	}
+
+	cache.Invalidate(server.URL + "/c")
+	if cache.Len() != 1 {
+		t.Errorf("Expected 1 cached asset after invalidate, got %d", cache.Len())
+	}
+}
+
+func TestAssetCache_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cache := NewAssetCache(nil)
+	if _, err := cache.Get(context.Background(), ""); err == nil {
+		t.Error("Expected error for empty URL")
+	}
+	if _, err := cache.Get(context.Background(), server.URL+"/missing.png"); err == nil {
+		t.Error("Expected error for 404")
+	}
+}
+
+func TestClient_GetMarketAndEventImages(t *testing.T) {
+	var hits, notModified int32
+	server := newAssetServer(t, &hits, &notModified)
+	defer server.Close()
+
+	client := NewClient(nil)
+	ctx := context.Background()
+
+	market := &Market{Image: server.URL + "/market.png", Icon: server.URL + "/market-icon.png"}
+	if _, err := client.GetMarketImage(ctx, market); err != nil {
+		t.Errorf("GetMarketImage failed: %v", err)
+	}
+	if _, err := client.GetMarketIcon(ctx, market); err != nil {
+		t.Errorf("GetMarketIcon failed: %v", err)
+	}
+
+	event := &Event{Image: server.URL + "/event.png"}
+	if _, err := client.GetEventImage(ctx, event); err != nil {
+		t.Errorf("GetEventImage failed: %v", err)
+	}
+	if _, err := client.GetEventIcon(ctx, event); err == nil {
+		t.Error("Expected error for event without icon")
+	}
+}
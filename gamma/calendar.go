@@ -0,0 +1,166 @@
+package gamma
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// gammaTimeLayouts Gamma 返回的时间格式
+var gammaTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05-07",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05-07",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseGammaTime 解析 Gamma 时间字符串（如 gameStartTime "2025-01-05 18:00:00+00"），不带时区时按 UTC
+func ParseGammaTime(s string) (time.Time, error) {
+	for _, layout := range gammaTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time format: %q", s)
+}
+
+// GetGameStartTime 解析开赛时间，没有开赛时间时返回零值
+func (m *Market) GetGameStartTime() (time.Time, error) {
+	if m.GameStartTime == "" {
+		return time.Time{}, nil
+	}
+	return ParseGammaTime(m.GameStartTime)
+}
+
+// GetStartTime 解析事件开始时间，没有开始时间时返回零值
+func (e *Event) GetStartTime() (time.Time, error) {
+	if e.StartTime == "" {
+		return time.Time{}, nil
+	}
+	return ParseGammaTime(e.StartTime)
+}
+
+// CalendarConfig 交易日历配置
+type CalendarConfig struct {
+	PreStartCutoff time.Duration // 开赛前多久停止交易（进入比赛后波动剧烈）
+}
+
+// DefaultCalendarConfig 默认配置
+func DefaultCalendarConfig() *CalendarConfig {
+	return &CalendarConfig{
+		PreStartCutoff: time.Minute,
+	}
+}
+
+// Calendar 交易日历，判断定时事件市场的可交易时段，并在开赛前 T-minus 时刻回调
+type Calendar struct {
+	mu     sync.Mutex
+	config *CalendarConfig
+	timers map[*time.Timer]struct{}
+	now    func() time.Time
+}
+
+// NewCalendar 创建交易日历
+func NewCalendar(config *CalendarConfig) *Calendar {
+	if config == nil {
+		config = DefaultCalendarConfig()
+	}
+
+	return &Calendar{
+		config: config,
+		timers: make(map[*time.Timer]struct{}),
+		now:    time.Now,
+	}
+}
+
+// TimeToStart 距开赛的时间，市场没有开赛时间时第二个返回值为 false；已开赛时为负数
+func (c *Calendar) TimeToStart(m *Market) (time.Duration, bool) {
+	start, err := m.GetGameStartTime()
+	if err != nil || start.IsZero() {
+		return 0, false
+	}
+	return start.Sub(c.now()), true
+}
+
+// IsTradablePeriod 市场当前是否处于可交易时段：
+// 市场活跃且接受订单、未过结束时间，且有开赛时间时距开赛大于 PreStartCutoff
+func (c *Calendar) IsTradablePeriod(m *Market) bool {
+	if m == nil || !m.IsActive() || !m.AcceptingOrders {
+		return false
+	}
+
+	now := c.now()
+	if end, err := m.GetEndDate(); err == nil && !end.IsZero() && !now.Before(end) {
+		return false
+	}
+
+	if untilStart, ok := c.TimeToStart(m); ok && untilStart <= c.config.PreStartCutoff {
+		return false
+	}
+
+	return true
+}
+
+// ScheduleBeforeStart 在开赛前各 offset 时刻回调 fn（T-minus），已过去的时刻会被跳过
+// 返回的 cancel 可取消尚未触发的回调
+func (c *Calendar) ScheduleBeforeStart(m *Market, offsets []time.Duration, fn func(m *Market, offset time.Duration)) (cancel func(), err error) {
+	start, err := m.GetGameStartTime()
+	if err != nil {
+		return nil, err
+	}
+	if start.IsZero() {
+		return nil, fmt.Errorf("market %s has no game start time", m.Slug)
+	}
+
+	now := c.now()
+	scheduled := make([]*time.Timer, 0, len(offsets))
+
+	c.mu.Lock()
+	for _, offset := range offsets {
+		delay := start.Add(-offset).Sub(now)
+		if delay < 0 {
+			continue
+		}
+
+		offset := offset
+		var timer *time.Timer
+		timer = time.AfterFunc(delay, func() {
+			c.mu.Lock()
+			delete(c.timers, timer)
+			c.mu.Unlock()
+			fn(m, offset)
+		})
+		c.timers[timer] = struct{}{}
+		scheduled = append(scheduled, timer)
+	}
+	c.mu.Unlock()
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, timer := range scheduled {
+			timer.Stop()
+			delete(c.timers, timer)
+		}
+	}, nil
+}
+
+// Pending 尚未触发的回调数量
+func (c *Calendar) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// Stop 取消全部尚未触发的回调
+func (c *Calendar) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for timer := range c.timers {
+		timer.Stop()
+	}
+	c.timers = make(map[*time.Timer]struct{})
+}
@@ -0,0 +1,156 @@
+package gamma
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseGammaTime(t *testing.T) {
+	want := time.Date(2025, 1, 5, 18, 0, 0, 0, time.UTC)
+
+	for _, s := range []string{
+		"2025-01-05 18:00:00+00",
+		"2025-01-05T18:00:00Z",
+		"2025-01-05 18:00:00",
+		"2025-01-05 20:00:00+02:00",
+	} {
+		got, err := ParseGammaTime(s)
+		if err != nil {
+			t.Errorf("ParseGammaTime(%q) error: %v", s, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseGammaTime(%q) = %v, expected %v", s, got, want)
+		}
+	}
+
+	if _, err := ParseGammaTime("tomorrow"); err == nil {
+		t.Error("Expected error for invalid time")
+	}
+}
+
+func TestMarket_GameStartTimeJSON(t *testing.T) {
+	var m Market
+	if err := json.Unmarshal([]byte(`{"gameStartTime":"2025-01-05 18:00:00+00"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	start, err := m.GetGameStartTime()
+	if err != nil || start.Hour() != 18 {
+		t.Errorf("GetGameStartTime() = %v, %v", start, err)
+	}
+
+	if start, err := (&Market{}).GetGameStartTime(); err != nil || !start.IsZero() {
+		t.Errorf("Expected zero time without gameStartTime, got %v, %v", start, err)
+	}
+}
+
+func newTestCalendar(now time.Time) *Calendar {
+	c := NewCalendar(&CalendarConfig{PreStartCutoff: 5 * time.Minute})
+	c.now = func() time.Time { return now }
+	return c
+}
+
+func TestCalendar_IsTradablePeriod(t *testing.T) {
+	now := time.Date(2025, 1, 5, 17, 0, 0, 0, time.UTC)
+	c := newTestCalendar(now)
+
+	base := Market{Active: true, AcceptingOrders: true, EndDateIso: "2025-01-06T00:00:00Z"}
+
+	tests := []struct {
+		name   string
+		modify func(m *Market)
+		want   bool
+	}{
+		{"no start time", func(m *Market) {}, true},
+		{"well before start", func(m *Market) { m.GameStartTime = "2025-01-05 18:00:00+00" }, true},
+		{"inside cutoff", func(m *Market) { m.GameStartTime = "2025-01-05 17:04:00+00" }, false},
+		{"in game", func(m *Market) { m.GameStartTime = "2025-01-05 16:00:00+00" }, false},
+		{"not accepting orders", func(m *Market) { m.AcceptingOrders = false }, false},
+		{"closed", func(m *Market) { m.Closed = true }, false},
+		{"past end date", func(m *Market) { m.EndDateIso = "2025-01-05T12:00:00Z" }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := base
+			tt.modify(&m)
+			if got := c.IsTradablePeriod(&m); got != tt.want {
+				t.Errorf("IsTradablePeriod() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+
+	if c.IsTradablePeriod(nil) {
+		t.Error("nil market should not be tradable")
+	}
+}
+
+func TestCalendar_TimeToStart(t *testing.T) {
+	now := time.Date(2025, 1, 5, 17, 0, 0, 0, time.UTC)
+	c := newTestCalendar(now)
+
+	d, ok := c.TimeToStart(&Market{GameStartTime: "2025-01-05 18:30:00+00"})
+	if !ok || d != 90*time.Minute {
+		t.Errorf("TimeToStart() = %v, %v", d, ok)
+	}
+
+	if _, ok := c.TimeToStart(&Market{}); ok {
+		t.Error("Expected ok=false without start time")
+	}
+}
+
+func TestCalendar_ScheduleBeforeStart(t *testing.T) {
+	c := NewCalendar(nil)
+	start := time.Now().Add(100 * time.Millisecond)
+	m := &Market{Slug: "game", GameStartTime: start.UTC().Format(time.RFC3339Nano)}
+
+	fired := make(chan time.Duration, 3)
+	_, err := c.ScheduleBeforeStart(m, []time.Duration{time.Hour, 80 * time.Millisecond, 0}, func(_ *Market, offset time.Duration) {
+		fired <- offset
+	})
+	if err != nil {
+		t.Fatalf("ScheduleBeforeStart() error: %v", err)
+	}
+
+	// The T-1h offset is already in the past and must be skipped
+	if c.Pending() != 2 {
+		t.Errorf("Pending() = %d, expected 2", c.Pending())
+	}
+
+	for _, want := range []time.Duration{80 * time.Millisecond, 0} {
+		select {
+		case got := <-fired:
+			if got != want {
+				t.Errorf("fired offset %v, expected %v", got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timed out waiting for callback")
+		}
+	}
+
+	if _, err := c.ScheduleBeforeStart(&Market{}, []time.Duration{0}, func(*Market, time.Duration) {}); err == nil {
+		t.Error("Expected error for market without start time")
+	}
+}
+
+func TestCalendar_CancelAndStop(t *testing.T) {
+	c := NewCalendar(nil)
+	m := &Market{GameStartTime: time.Now().Add(time.Hour).UTC().Format(time.RFC3339)}
+
+	cancel, err := c.ScheduleBeforeStart(m, []time.Duration{30 * time.Minute}, func(*Market, time.Duration) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ScheduleBeforeStart(m, []time.Duration{10 * time.Minute}, func(*Market, time.Duration) {})
+
+	cancel()
+	if c.Pending() != 1 {
+		t.Errorf("Pending() after cancel = %d, expected 1", c.Pending())
+	}
+
+	c.Stop()
+	if c.Pending() != 0 {
+		t.Errorf("Pending() after Stop = %d, expected 0", c.Pending())
+	}
+}
@@ -10,6 +10,7 @@ import (
 type Client struct {
 	httpClient *common.HTTPClient
 	config     *Config
+	assets     *AssetCache
 }
 
 // Config Gamma 客户端配置
@@ -18,6 +19,13 @@ type Config struct {
 	Timeout      time.Duration // 请求超时
 	MaxRetries   int           // 最大重试次数
 	RetryDelayMs int           // 重试间隔（毫秒）
+
+	// 出口地址池（可选），用于多出口 IP 部署
+	LocalAddrs *common.LocalAddrPool
+
+	// 图片资源缓存
+	AssetCacheSize int           // 最多缓存的资源数，<= 0 使用默认值
+	AssetMaxAge    time.Duration // 缓存新鲜期，期内不发起请求；过期后用 ETag 条件请求校验
 }
 
 // DefaultConfig 默认配置
@@ -27,6 +35,9 @@ func DefaultConfig() *Config {
 		Timeout:      30 * time.Second,
 		MaxRetries:   3,
 		RetryDelayMs: 1000,
+
+		AssetCacheSize: DefaultAssetCacheSize,
+		AssetMaxAge:    DefaultAssetMaxAge,
 	}
 }
 
@@ -41,11 +52,13 @@ func NewClient(config *Config) *Client {
 		Timeout:      config.Timeout,
 		MaxRetries:   config.MaxRetries,
 		RetryDelayMs: config.RetryDelayMs,
+		LocalAddrs:   config.LocalAddrs,
 	}
 
 	return &Client{
 		httpClient: common.NewHTTPClient(httpConfig),
 		config:     config,
+		assets:     NewAssetCache(config),
 	}
 }
 
@@ -54,6 +67,12 @@ func (c *Client) Close() {
 	// HTTP 客户端无需显式关闭
 }
 
+// SetRetryPolicy 运行时调整请求的最大重试次数与重试间隔（毫秒）
+// 单次调用可通过 common.WithRetry 携带 RetryOptions 的 ctx 单独指定重试策略
+func (c *Client) SetRetryPolicy(maxRetries, retryDelayMs int) {
+	c.httpClient.SetRetryPolicy(maxRetries, time.Duration(retryDelayMs)*time.Millisecond)
+}
+
 // GetConfig 获取配置
 func (c *Client) GetConfig() *Config {
 	return c.config
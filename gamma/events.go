@@ -0,0 +1,114 @@
+package gamma
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetEvent 获取单个事件（包含下属市场）
+func (c *Client) GetEvent(ctx context.Context, eventID string) (*Event, error) {
+	if eventID == "" {
+		return nil, fmt.Errorf("event ID is required")
+	}
+
+	var result Event
+	err := c.httpClient.Get(ctx, "/events/"+eventID, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event %s: %w", eventID, err)
+	}
+
+	return &result, nil
+}
+
+// GetEventBySlug 通过 slug 获取事件（包含下属市场）
+func (c *Client) GetEventBySlug(ctx context.Context, slug string) (*Event, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("slug is required")
+	}
+
+	var result Event
+	err := c.httpClient.Get(ctx, "/events/slug/"+slug, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event by slug %s: %w", slug, err)
+	}
+
+	return &result, nil
+}
+
+// GetEvents 获取事件列表（分页接口，返回总数）
+// 将 NextCursor 传入下一次请求的 Cursor 即可翻页
+func (c *Client) GetEvents(ctx context.Context, params *EventListParams) (*EventListResponse, error) {
+	if params == nil {
+		params = &EventListParams{}
+	}
+
+	offset, err := pageOffset(params.Cursor, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	query := *params
+	query.Offset = offset
+
+	var raw json.RawMessage
+	if err := c.httpClient.Get(ctx, "/events/pagination", &query, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	result, hasMore, total, err := decodePage[Event](raw, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	return &EventListResponse{
+		Data:       result,
+		NextCursor: nextCursor(offset, len(result), hasMore),
+		Count:      len(result),
+		HasMore:    hasMore,
+		Total:      total,
+	}, nil
+}
+
+// GetAllEvents 获取所有事件（自动分页）
+// 未指定排序时按 id 升序，重复出现的事件按 ID 去重
+func (c *Client) GetAllEvents(ctx context.Context, params *EventListParams) ([]Event, error) {
+	query := EventListParams{}
+	if params != nil {
+		query = *params
+	}
+	if query.Limit == 0 {
+		query.Limit = 100
+	}
+	if query.Order == "" {
+		query.Order = "id"
+		query.Ascending = true
+	}
+
+	var allEvents []Event
+	seen := make(map[string]bool)
+
+	for {
+		resp, err := c.GetEvents(ctx, &query)
+		if err != nil {
+			return allEvents, err
+		}
+
+		for _, event := range resp.Data {
+			if event.ID != "" {
+				if seen[event.ID] {
+					continue
+				}
+				seen[event.ID] = true
+			}
+			allEvents = append(allEvents, event)
+		}
+
+		// 安全限制：最多获取 maxPaginatedResults 个事件
+		if resp.NextCursor == "" || len(resp.Data) == 0 || len(allEvents) >= maxPaginatedResults {
+			break
+		}
+		query.Cursor = resp.NextCursor
+	}
+
+	return allEvents, nil
+}
@@ -0,0 +1,106 @@
+package gamma
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetEvent(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events/42" {
+			t.Errorf("Expected path /events/42, got %s", r.URL.Path)
+		}
+
+		event := Event{ID: "42", Title: "Test Event"}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(event)
+	})
+	defer server.Close()
+
+	event, err := client.GetEvent(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("GetEvent() error: %v", err)
+	}
+	if event.ID != "42" {
+		t.Errorf("Event ID = %s, expected 42", event.ID)
+	}
+}
+
+func TestGetEventBySlug(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events/slug/election" {
+			t.Errorf("Expected path /events/slug/election, got %s", r.URL.Path)
+		}
+
+		event := Event{
+			ID:      "1",
+			Slug:    "election",
+			NegRisk: true,
+			Markets: []Market{
+				{ID: "m1", Outcomes: `["Yes","No"]`, ClobTokenIds: `["t1","t2"]`},
+				{ID: "m2", Outcomes: `["Yes","No"]`, ClobTokenIds: `["t3","t4"]`},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(event)
+	})
+	defer server.Close()
+
+	event, err := client.GetEventBySlug(context.Background(), "election")
+	if err != nil {
+		t.Fatalf("GetEventBySlug() error: %v", err)
+	}
+	if len(event.Markets) != 2 {
+		t.Fatalf("Expected 2 markets, got %d", len(event.Markets))
+	}
+	if ids := event.Markets[1].GetClobTokenIDs(); len(ids) != 2 || ids[0] != "t3" {
+		t.Errorf("Unexpected token IDs: %v", ids)
+	}
+}
+
+func TestGetEventEmptyID(t *testing.T) {
+	client := NewClient(nil)
+	if _, err := client.GetEvent(context.Background(), ""); err == nil {
+		t.Error("Expected error for empty event ID")
+	}
+	if _, err := client.GetEventBySlug(context.Background(), ""); err == nil {
+		t.Error("Expected error for empty slug")
+	}
+}
+
+func TestGetAllEvents(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events/pagination" {
+			t.Errorf("Expected path /events/pagination, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("offset") {
+		case "":
+			w.Write([]byte(`{"data":[{"id":"1"},{"id":"2"}],"pagination":{"hasMore":true,"totalResults":3}}`))
+		case "2":
+			w.Write([]byte(`{"data":[{"id":"3"}],"pagination":{"hasMore":false,"totalResults":3}}`))
+		default:
+			t.Errorf("Unexpected offset %s", r.URL.Query().Get("offset"))
+		}
+	})
+	defer server.Close()
+
+	resp, err := client.GetEvents(context.Background(), &EventListParams{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetEvents() error: %v", err)
+	}
+	if resp.Total != 3 || !resp.HasMore || resp.NextCursor != "2" {
+		t.Errorf("Unexpected first page: %+v", resp)
+	}
+
+	events, err := client.GetAllEvents(context.Background(), &EventListParams{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetAllEvents() error: %v", err)
+	}
+	if len(events) != 3 || events[2].ID != "3" {
+		t.Errorf("Expected 3 events, got %+v", events)
+	}
+}
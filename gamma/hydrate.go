@@ -0,0 +1,78 @@
+package gamma
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// defaultHydrateConcurrency HydrateMarkets 未指定并发数时的默认值
+const defaultHydrateConcurrency = 8
+
+// HydrateFailure 单个市场详情获取失败
+type HydrateFailure struct {
+	Index    int    // 在输入列表中的位置
+	MarketID string // 市场 ID
+	Err      error
+}
+
+// HydrateResult 批量获取市场详情的结果
+type HydrateResult struct {
+	// Markets 与输入顺序一致，获取成功的市场替换为 GetMarket 返回的完整数据，失败的保留输入中的原始数据
+	Markets []Market
+	// Failed 获取失败的市场，按输入顺序排列
+	Failed []HydrateFailure
+}
+
+// HydrateMarkets 以有限并发对列表接口返回的市场逐个调用 GetMarket 获取完整字段
+// concurrency <= 0 时使用 8。部分失败时仍返回完整的 HydrateResult（失败明细见 Failed），
+// 同时返回包含首个失败原因的错误；ctx 取消后尚未开始的请求计为失败
+func (c *Client) HydrateMarkets(ctx context.Context, markets []Market, concurrency int) (*HydrateResult, error) {
+	if concurrency <= 0 {
+		concurrency = defaultHydrateConcurrency
+	}
+
+	result := &HydrateResult{Markets: make([]Market, len(markets))}
+	copy(result.Markets, markets)
+	errs := make([]error, len(markets))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range markets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer common.RecoverPanic("gamma.hydrate", func(err error) {
+				errs[i] = err
+			})
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+
+			market, err := c.GetMarket(ctx, markets[i].ID)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			result.Markets[i] = *market
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			result.Failed = append(result.Failed, HydrateFailure{Index: i, MarketID: markets[i].ID, Err: err})
+		}
+	}
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("failed to hydrate %d of %d markets: %w", len(result.Failed), len(markets), result.Failed[0].Err)
+	}
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package gamma
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestHydrateMarkets(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id := strings.TrimPrefix(r.URL.Path, "/markets/")
+		if id == "404" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type":"not found error","error":"id not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(Market{ID: id, Question: "Full " + id, Description: "details"})
+	})
+	defer server.Close()
+
+	input := []Market{{ID: "1", Question: "Listed 1"}, {ID: "404", Question: "Listed 404"}, {ID: "3"}, {ID: "4"}, {ID: "5"}}
+	result, err := client.HydrateMarkets(context.Background(), input, 2)
+	if err == nil || !errors.Is(err, common.ErrMarketNotFound) {
+		t.Fatalf("expected partial failure wrapping ErrMarketNotFound, got %v", err)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("concurrency limit exceeded: %d requests in flight", maxInFlight)
+	}
+
+	if len(result.Markets) != len(input) {
+		t.Fatalf("expected %d markets, got %d", len(input), len(result.Markets))
+	}
+	for i, m := range result.Markets {
+		if m.ID != input[i].ID {
+			t.Errorf("market %d out of order: %s", i, m.ID)
+		}
+	}
+	if result.Markets[0].Description != "details" || result.Markets[4].Question != "Full 5" {
+		t.Errorf("markets not hydrated: %+v", result.Markets[0])
+	}
+	// the failed market keeps its listed data
+	if result.Markets[1].Question != "Listed 404" {
+		t.Errorf("failed market should keep input data, got %+v", result.Markets[1])
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Index != 1 || result.Failed[0].MarketID != "404" {
+		t.Errorf("unexpected failures: %+v", result.Failed)
+	}
+	if input[0].Description != "" {
+		t.Error("input slice should not be modified")
+	}
+}
+
+func TestHydrateMarketsCanceled(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Market{ID: "1"})
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := client.HydrateMarkets(ctx, []Market{{ID: "1"}, {ID: "2"}}, 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(result.Failed) != 2 {
+		t.Errorf("expected every market to fail, got %+v", result.Failed)
+	}
+
+	if result, err := client.HydrateMarkets(context.Background(), nil, 4); err != nil || len(result.Markets) != 0 {
+		t.Errorf("empty input = %+v, %v", result, err)
+	}
+}
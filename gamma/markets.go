@@ -2,70 +2,93 @@ package gamma
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 // GetMarkets 获取市场列表
+// 填充 NextCursor/HasMore，接口返回总数时同时填充 Total；将 NextCursor 传入下一次请求的 Cursor 即可翻页
 func (c *Client) GetMarkets(ctx context.Context, params *MarketListParams) (*MarketListResponse, error) {
 	if params == nil {
 		params = &MarketListParams{}
 	}
 
-	var result []Market
-	err := c.httpClient.Get(ctx, "/markets", params, &result)
+	offset, err := pageOffset(params.Cursor, params.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get markets: %w", err)
 	}
+	query := *params
+	query.Offset = offset
+
+	var raw json.RawMessage
+	if err := c.httpClient.Get(ctx, "/markets", &query, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get markets: %w", err)
+	}
+
+	result, hasMore, total, err := decodePage[Market](raw, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode markets: %w", err)
+	}
 
 	return &MarketListResponse{
-		Data:  result,
-		Count: len(result),
+		Data:       result,
+		NextCursor: nextCursor(offset, len(result), hasMore),
+		Limit:      query.Limit,
+		Count:      len(result),
+		HasMore:    hasMore,
+		Total:      total,
 	}, nil
 }
 
 // GetAllMarkets 获取所有市场（自动分页）
-// Polymarket API 的 offset 是跳过的记录数，从 0 开始
+// Polymarket API 的 offset 是跳过的记录数，从 0 开始；未指定排序时按 id 升序，
+// 避免翻页期间新增市场导致记录错位，重复出现的市场按 ID 去重
 func (c *Client) GetAllMarkets(ctx context.Context, params *MarketListParams) ([]Market, error) {
-	if params == nil {
-		params = &MarketListParams{}
+	query := MarketListParams{}
+	if params != nil {
+		query = *params
 	}
-
-	if params.Limit == 0 {
-		params.Limit = 100
+	if query.Limit == 0 {
+		query.Limit = 100
+	}
+	if query.Order == "" {
+		query.Order = "id"
+		query.Ascending = true
 	}
 
 	var allMarkets []Market
-	offset := 0 // offset 是跳过的记录数，从 0 开始
+	seen := make(map[string]bool)
 
 	for {
-		params.Offset = offset
-		resp, err := c.GetMarkets(ctx, params)
+		resp, err := c.GetMarkets(ctx, &query)
 		if err != nil {
 			return allMarkets, err
 		}
 
-		if len(resp.Data) == 0 {
-			break
-		}
-
-		allMarkets = append(allMarkets, resp.Data...)
-
-		if len(resp.Data) < params.Limit {
-			break
+		for _, market := range resp.Data {
+			if market.ID != "" {
+				if seen[market.ID] {
+					continue
+				}
+				seen[market.ID] = true
+			}
+			allMarkets = append(allMarkets, market)
 		}
 
-		offset += params.Limit // 每次跳过 limit 条记录
-
-		// 安全限制：最多获取 10000 个市场
-		if len(allMarkets) >= 10000 {
+		// 安全限制：最多获取 maxPaginatedResults 个市场
+		if resp.NextCursor == "" || len(resp.Data) == 0 || len(allMarkets) >= maxPaginatedResults {
 			break
 		}
+		query.Cursor = resp.NextCursor
 	}
 
 	return allMarkets, nil
 }
 
-// GetMarket 获取单个市场
+// GetMarket 获取单个市场，市场不存在时返回 common.ErrMarketNotFound
 func (c *Client) GetMarket(ctx context.Context, marketID string) (*Market, error) {
 	if marketID == "" {
 		return nil, fmt.Errorf("market ID is required")
@@ -74,13 +97,21 @@ func (c *Client) GetMarket(ctx context.Context, marketID string) (*Market, error
 	var result Market
 	err := c.httpClient.Get(ctx, "/markets/"+marketID, nil, &result)
 	if err != nil {
+		if common.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: ID %s: %w", common.ErrMarketNotFound, marketID, err)
+		}
 		return nil, fmt.Errorf("failed to get market %s: %w", marketID, err)
 	}
+	if result.ID == "" {
+		return nil, fmt.Errorf("%w: ID %s", common.ErrMarketNotFound, marketID)
+	}
 
 	return &result, nil
 }
 
 // GetMarketBySlug 通过 slug 获取市场
+// 市场不存在时返回 common.ErrMarketNotFound（接口返回 404 时同时可用 errors.As 取得 *common.APIError），
+// 其他失败（网络、5xx、参数校验等）不匹配 ErrMarketNotFound
 func (c *Client) GetMarketBySlug(ctx context.Context, slug string) (*Market, error) {
 	if slug == "" {
 		return nil, fmt.Errorf("slug is required")
@@ -89,8 +120,15 @@ func (c *Client) GetMarketBySlug(ctx context.Context, slug string) (*Market, err
 	var result Market
 	err := c.httpClient.Get(ctx, "/markets/slug/"+slug, nil, &result)
 	if err != nil {
+		if common.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: slug %s: %w", common.ErrMarketNotFound, slug, err)
+		}
 		return nil, fmt.Errorf("failed to get market by slug %s: %w", slug, err)
 	}
+	// 不存在的 slug 可能返回 200 + null
+	if result.ID == "" {
+		return nil, fmt.Errorf("%w: slug %s", common.ErrMarketNotFound, slug)
+	}
 
 	return &result, nil
 }
@@ -101,24 +139,93 @@ func (c *Client) GetMarketByConditionID(ctx context.Context, conditionID string)
 		return nil, fmt.Errorf("condition ID is required")
 	}
 
-	params := &MarketListParams{
-		Limit: 1,
+	markets, err := c.GetMarketsByConditionIDs(ctx, []string{conditionID})
+	if err != nil {
+		return nil, err
 	}
 
-	var result []Market
-	err := c.httpClient.Get(ctx, "/markets", params, &result)
+	for i := range markets {
+		if strings.EqualFold(markets[i].ConditionID, conditionID) {
+			return &markets[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: condition ID %s", common.ErrMarketNotFound, conditionID)
+}
+
+// maxMarketsPerRequest 批量查询时单个请求携带的最大筛选值数量，避免 URL 过长
+const maxMarketsPerRequest = 50
+
+// GetMarketsBySlugs 通过 slug 批量获取市场
+// 使用 slug 多值筛选，每 maxMarketsPerRequest 个 slug 合并为一次请求；重复与空 slug 会被忽略，查不到的 slug 不出现在结果中
+func (c *Client) GetMarketsBySlugs(ctx context.Context, slugs []string) ([]Market, error) {
+	markets, err := c.getMarketsBatched(ctx, slugs, func(batch []string) *MarketListParams {
+		return &MarketListParams{Slugs: batch, Limit: len(batch)}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get market by condition ID %s: %w", conditionID, err)
+		return nil, fmt.Errorf("failed to get markets by slugs: %w", err)
 	}
+	return markets, nil
+}
 
-	// 遍历查找匹配的 conditionID
-	for _, m := range result {
-		if m.ConditionID == conditionID {
-			return &m, nil
+// GetMarketsByConditionIDs 通过 conditionID 批量获取市场
+// 每 maxMarketsPerRequest 个 conditionID 合并为一次请求；重复与空 ID 会被忽略
+func (c *Client) GetMarketsByConditionIDs(ctx context.Context, conditionIDs []string) ([]Market, error) {
+	markets, err := c.getMarketsBatched(ctx, conditionIDs, func(batch []string) *MarketListParams {
+		return &MarketListParams{ConditionIDs: batch, Limit: len(batch)}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get markets by condition IDs: %w", err)
+	}
+	return markets, nil
+}
+
+// GetMarketsByTokenIDs 通过 CLOB token ID 批量获取市场（一个市场包含多个结果 token，返回数量可能少于 token 数）
+// 每 maxMarketsPerRequest 个 token 合并为一次请求；重复与空 ID 会被忽略
+func (c *Client) GetMarketsByTokenIDs(ctx context.Context, tokenIDs []string) ([]Market, error) {
+	markets, err := c.getMarketsBatched(ctx, tokenIDs, func(batch []string) *MarketListParams {
+		return &MarketListParams{ClobTokenIDs: batch, Limit: len(batch)}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get markets by token IDs: %w", err)
+	}
+	return markets, nil
+}
+
+// getMarketsBatched 对去重后的筛选值分批请求 /markets 并合并结果，不同批次返回的同一市场只保留一次
+func (c *Client) getMarketsBatched(ctx context.Context, values []string, params func(batch []string) *MarketListParams) ([]Market, error) {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
 		}
 	}
+	if len(unique) == 0 {
+		return nil, nil
+	}
 
-	return nil, fmt.Errorf("market with condition ID %s not found", conditionID)
+	var result []Market
+	found := make(map[string]bool)
+	for start := 0; start < len(unique); start += maxMarketsPerRequest {
+		end := min(start+maxMarketsPerRequest, len(unique))
+
+		var batch []Market
+		if err := c.httpClient.Get(ctx, "/markets", params(unique[start:end]), &batch); err != nil {
+			return nil, err
+		}
+		for _, m := range batch {
+			if m.ID != "" {
+				if found[m.ID] {
+					continue
+				}
+				found[m.ID] = true
+			}
+			result = append(result, m)
+		}
+	}
+	return result, nil
 }
 
 // GetActiveMarkets 获取活跃市场
@@ -3,10 +3,14 @@ package gamma
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 func setupTestServer(handler http.HandlerFunc) (*httptest.Server, *Client) {
@@ -129,6 +133,126 @@ func TestGetMarketBySlugEmpty(t *testing.T) {
 	}
 }
 
+func TestGetMarketBySlugNotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		notFound bool
+	}{
+		{"404 with gamma error body", http.StatusNotFound, `{"type":"not found error","error":"market not found"}`, true},
+		{"null body", http.StatusOK, `null`, true},
+		{"server error", http.StatusInternalServerError, `{"type":"internal error","error":"database unavailable"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			defer server.Close()
+
+			_, err := client.GetMarketBySlug(context.Background(), "missing")
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if errors.Is(err, common.ErrMarketNotFound) != tt.notFound {
+				t.Errorf("errors.Is(ErrMarketNotFound) = %v, expected %v: %v", !tt.notFound, tt.notFound, err)
+			}
+
+			var apiErr *common.APIError
+			if tt.status >= 400 {
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status || apiErr.Type == "" || apiErr.Code == "" {
+					t.Errorf("expected API error details, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestGetMarketByConditionID(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/markets" {
+			t.Errorf("Expected path /markets, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("condition_ids") != "0xABC" {
+			t.Errorf("Expected condition_ids=0xABC, got %s", r.URL.Query().Get("condition_ids"))
+		}
+
+		markets := []Market{{ID: "1", ConditionID: "0xabc"}}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	})
+	defer server.Close()
+
+	market, err := client.GetMarketByConditionID(context.Background(), "0xABC")
+	if err != nil {
+		t.Fatalf("GetMarketByConditionID() error: %v", err)
+	}
+	if market.ID != "1" {
+		t.Errorf("Market ID = %s, expected 1", market.ID)
+	}
+}
+
+func TestGetMarketByConditionIDNotFound(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Market{})
+	})
+	defer server.Close()
+
+	_, err := client.GetMarketByConditionID(context.Background(), "0xdef")
+	if !errors.Is(err, common.ErrMarketNotFound) {
+		t.Errorf("Expected ErrMarketNotFound, got %v", err)
+	}
+}
+
+func TestGetMarketsByConditionIDs(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query()["condition_ids"]
+		if len(ids) != 2 {
+			t.Errorf("Expected 2 condition_ids, got %v", ids)
+		}
+
+		markets := []Market{{ConditionID: ids[0]}, {ConditionID: ids[1]}}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	})
+	defer server.Close()
+
+	markets, err := client.GetMarketsByConditionIDs(context.Background(), []string{"0x1", "0x2"})
+	if err != nil {
+		t.Fatalf("GetMarketsByConditionIDs() error: %v", err)
+	}
+	if len(markets) != 2 {
+		t.Errorf("Expected 2 markets, got %d", len(markets))
+	}
+}
+
+func TestGetMarketsByTokenIDs(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query()["clob_token_ids"]
+		if len(ids) != 2 || r.URL.Query().Get("limit") != "2" {
+			t.Errorf("Expected 2 clob_token_ids with limit 2, got %v", r.URL.Query())
+		}
+
+		markets := []Market{{ConditionID: "0x1", ClobTokenIds: `["` + ids[0] + `","` + ids[1] + `"]`}}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	})
+	defer server.Close()
+
+	markets, err := client.GetMarketsByTokenIDs(context.Background(), []string{"111", "222"})
+	if err != nil {
+		t.Fatalf("GetMarketsByTokenIDs() error: %v", err)
+	}
+	if len(markets) != 1 || len(markets[0].GetClobTokenIDs()) != 2 {
+		t.Errorf("unexpected markets: %+v", markets)
+	}
+}
+
 func TestGetActiveMarkets(t *testing.T) {
 	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Query().Get("active") != "true" {
@@ -325,3 +449,149 @@ func TestGetAllMarketsLimit(t *testing.T) {
 		t.Errorf("Expected 100 markets, got %d", len(markets))
 	}
 }
+
+func TestGetMarketsCursor(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") != "20" {
+			t.Errorf("Expected offset=20 from cursor, got %s", r.URL.Query().Get("offset"))
+		}
+		markets := make([]Market, 10)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	})
+	defer server.Close()
+
+	resp, err := client.GetMarkets(context.Background(), &MarketListParams{Limit: 10, Offset: 5, Cursor: "20"})
+	if err != nil {
+		t.Fatalf("GetMarkets() error: %v", err)
+	}
+	if !resp.HasMore || resp.NextCursor != "30" {
+		t.Errorf("Expected HasMore with cursor 30, got %v %q", resp.HasMore, resp.NextCursor)
+	}
+
+	if _, err := client.GetMarkets(context.Background(), &MarketListParams{Cursor: "abc"}); err == nil {
+		t.Error("Expected error for invalid cursor")
+	}
+}
+
+func TestGetMarketsPaginatedResponse(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":"1"},{"id":"2"}],"pagination":{"hasMore":false,"totalResults":2}}`))
+	})
+	defer server.Close()
+
+	resp, err := client.GetMarkets(context.Background(), &MarketListParams{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetMarkets() error: %v", err)
+	}
+	if resp.Count != 2 || resp.Total != 2 || resp.HasMore || resp.NextCursor != "" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+}
+
+func TestGetAllMarketsStableIteration(t *testing.T) {
+	var offsets []string
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("order") != "id" || q.Get("ascending") != "true" {
+			t.Errorf("Expected default order=id ascending, got %s %s", q.Get("order"), q.Get("ascending"))
+		}
+		offsets = append(offsets, q.Get("offset"))
+
+		// A market inserted between pages shifts "2" onto the second page as well
+		var markets []Market
+		switch q.Get("offset") {
+		case "":
+			markets = []Market{{ID: "1"}, {ID: "2"}}
+		case "2":
+			markets = []Market{{ID: "2"}, {ID: "3"}}
+		case "4":
+			markets = []Market{{ID: "4"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	})
+	defer server.Close()
+
+	params := &MarketListParams{Limit: 2}
+	markets, err := client.GetAllMarkets(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetAllMarkets() error: %v", err)
+	}
+	if len(markets) != 4 {
+		t.Errorf("Expected 4 unique markets, got %d", len(markets))
+	}
+	if len(offsets) != 3 {
+		t.Errorf("Expected 3 requests, got %v", offsets)
+	}
+	if params.Cursor != "" || params.Order != "" {
+		t.Errorf("GetAllMarkets() should not modify caller params: %+v", params)
+	}
+}
+
+func TestGetMarketsBySlugs(t *testing.T) {
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		slugs := r.URL.Query()["slug"]
+		if len(slugs) != 2 || r.URL.Query().Get("limit") != "2" {
+			t.Errorf("Expected 2 slugs with limit 2, got %v", r.URL.Query())
+		}
+
+		markets := []Market{{ID: "1", Slug: slugs[0]}, {ID: "2", Slug: slugs[1]}}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	})
+	defer server.Close()
+
+	markets, err := client.GetMarketsBySlugs(context.Background(), []string{"a", "b", "a", ""})
+	if err != nil {
+		t.Fatalf("GetMarketsBySlugs() error: %v", err)
+	}
+	if len(markets) != 2 || markets[0].Slug != "a" || markets[1].Slug != "b" {
+		t.Errorf("unexpected markets: %+v", markets)
+	}
+}
+
+func TestGetMarketsBySlugsEmpty(t *testing.T) {
+	client := NewClient(nil)
+	markets, err := client.GetMarketsBySlugs(context.Background(), []string{""})
+	if err != nil || markets != nil {
+		t.Errorf("Expected no request for empty slugs, got %v, %v", markets, err)
+	}
+}
+
+func TestGetMarketsByConditionIDsBatches(t *testing.T) {
+	requests := 0
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		ids := r.URL.Query()["condition_ids"]
+		if len(ids) > maxMarketsPerRequest {
+			t.Errorf("Expected at most %d condition_ids per request, got %d", maxMarketsPerRequest, len(ids))
+		}
+
+		// The first market is returned by every batch to exercise de-duplication.
+		markets := []Market{{ID: "shared", ConditionID: "0x0"}}
+		for _, id := range ids {
+			markets = append(markets, Market{ID: id, ConditionID: id})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(markets)
+	})
+	defer server.Close()
+
+	ids := make([]string, maxMarketsPerRequest+10)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("0x%x", i+1)
+	}
+
+	markets, err := client.GetMarketsByConditionIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("GetMarketsByConditionIDs() error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+	if len(markets) != len(ids)+1 {
+		t.Errorf("Expected %d markets, got %d", len(ids)+1, len(markets))
+	}
+}
@@ -0,0 +1,73 @@
+package gamma
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// maxPaginatedResults GetAll* 自动分页的安全上限
+const maxPaginatedResults = 10000
+
+// Pagination 分页接口（如 /events/pagination）返回的分页信息
+type Pagination struct {
+	HasMore      bool `json:"hasMore"`
+	TotalResults int  `json:"totalResults"`
+}
+
+// pagedResponse 分页接口的响应体
+type pagedResponse[T any] struct {
+	Data       []T        `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// ParseCursor 解析 NextCursor，空字符串表示第一页
+// Gamma 使用 offset 分页，游标即下一页的 offset
+func ParseCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(cursor)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return offset, nil
+}
+
+// pageOffset 计算本次请求的 offset：cursor 非空时优先使用 cursor
+func pageOffset(cursor string, offset int) (int, error) {
+	if cursor == "" {
+		return offset, nil
+	}
+	return ParseCursor(cursor)
+}
+
+// nextCursor 下一页的游标，没有更多数据时为空
+func nextCursor(offset, count int, hasMore bool) string {
+	if !hasMore {
+		return ""
+	}
+	return strconv.Itoa(offset + count)
+}
+
+// decodePage 解析列表响应，兼容纯数组与 {data, pagination} 两种格式
+// 纯数组没有总数，total 为 0，hasMore 在返回数量达到 limit 时视为 true（limit 为 0 时无法判断，视为 false）
+func decodePage[T any](raw json.RawMessage, limit int) (data []T, hasMore bool, total int, err error) {
+	if len(raw) == 0 {
+		return nil, false, 0, nil
+	}
+	if raw[0] == '{' {
+		var page pagedResponse[T]
+		if err := common.DecodeJSON(raw, &page, common.DefaultJSONDecodeOptions()); err != nil {
+			return nil, false, 0, err
+		}
+		return page.Data, page.Pagination.HasMore, page.Pagination.TotalResults, nil
+	}
+
+	if err := common.DecodeJSON(raw, &data, common.DefaultJSONDecodeOptions()); err != nil {
+		return nil, false, 0, err
+	}
+	return data, limit > 0 && len(data) >= limit, 0, nil
+}
@@ -0,0 +1,113 @@
+package gamma
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// RelationType 关联市场的关联方式
+type RelationType string
+
+const (
+	// RelationSameEvent 同一事件下的其他市场（如多候选人选举）
+	RelationSameEvent RelationType = "same_event"
+	// RelationSharedTag 共享标签的市场
+	RelationSharedTag RelationType = "shared_tag"
+)
+
+// RelatedMarket 关联市场
+type RelatedMarket struct {
+	Market     Market
+	Relation   RelationType
+	SharedTags []string // 共享的标签 slug（RelationSharedTag 时填充）
+}
+
+// RelatedMarketsOptions 关联市场查询选项
+type RelatedMarketsOptions struct {
+	IncludeEvent bool // 包含同一事件下的市场
+	IncludeTags  bool // 包含共享标签的市场
+	TagLimit     int  // 每个标签查询的市场数量
+	MaxResults   int  // 最多返回数量，0 表示不限制
+}
+
+// DefaultRelatedMarketsOptions 默认选项
+func DefaultRelatedMarketsOptions() *RelatedMarketsOptions {
+	return &RelatedMarketsOptions{
+		IncludeEvent: true,
+		IncludeTags:  true,
+		TagLimit:     50,
+		MaxResults:   20,
+	}
+}
+
+// GetRelatedMarkets 获取与指定市场关联的活跃市场
+// 同一事件下的市场排在前面，其余按共享标签数、24 小时交易量降序；结果按 conditionID 去重且不包含市场本身
+func (c *Client) GetRelatedMarkets(ctx context.Context, market *Market, opts *RelatedMarketsOptions) ([]RelatedMarket, error) {
+	if market == nil {
+		return nil, fmt.Errorf("market is required")
+	}
+	if opts == nil {
+		opts = DefaultRelatedMarketsOptions()
+	}
+
+	seen := map[string]int{market.ConditionID: -1}
+	var related []RelatedMarket
+
+	if opts.IncludeEvent {
+		for _, summary := range market.Events {
+			event, err := c.GetEvent(ctx, summary.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range event.Markets {
+				if _, ok := seen[m.ConditionID]; ok || !m.IsActive() {
+					continue
+				}
+				seen[m.ConditionID] = len(related)
+				related = append(related, RelatedMarket{Market: m, Relation: RelationSameEvent})
+			}
+		}
+	}
+
+	if opts.IncludeTags {
+		for _, tag := range market.Tags {
+			if tag.Slug == "" || tag.ForceHide {
+				continue
+			}
+			markets, err := c.GetMarketsByTag(ctx, tag.Slug, opts.TagLimit)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range markets {
+				if idx, ok := seen[m.ConditionID]; ok {
+					if idx >= 0 && related[idx].Relation == RelationSharedTag {
+						related[idx].SharedTags = append(related[idx].SharedTags, tag.Slug)
+					}
+					continue
+				}
+				if !m.IsActive() {
+					continue
+				}
+				seen[m.ConditionID] = len(related)
+				related = append(related, RelatedMarket{Market: m, Relation: RelationSharedTag, SharedTags: []string{tag.Slug}})
+			}
+		}
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		a, b := related[i], related[j]
+		if a.Relation != b.Relation {
+			return a.Relation == RelationSameEvent
+		}
+		if len(a.SharedTags) != len(b.SharedTags) {
+			return len(a.SharedTags) > len(b.SharedTags)
+		}
+		return a.Market.Volume24hr > b.Market.Volume24hr
+	})
+
+	if opts.MaxResults > 0 && len(related) > opts.MaxResults {
+		related = related[:opts.MaxResults]
+	}
+	return related, nil
+}
@@ -0,0 +1,81 @@
+package gamma
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetRelatedMarkets(t *testing.T) {
+	active := func(conditionID string, volume float64) Market {
+		return Market{ConditionID: conditionID, Active: true, Volume24hr: volume}
+	}
+
+	server, client := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/events/e1":
+			json.NewEncoder(w).Encode(Event{ID: "e1", Markets: []Market{
+				active("self", 0),
+				active("sibling", 1),
+				{ConditionID: "closed-sibling", Active: true, Closed: true},
+			}})
+		case r.URL.Query().Get("tag_slug") == "politics":
+			json.NewEncoder(w).Encode([]Market{active("self", 0), active("sibling", 0), active("p1", 10), active("p2", 500)})
+		case r.URL.Query().Get("tag_slug") == "us":
+			json.NewEncoder(w).Encode([]Market{active("p1", 10)})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+	defer server.Close()
+
+	market := &Market{
+		ConditionID: "self",
+		Events:      []Event{{ID: "e1"}},
+		Tags:        []Tag{{Slug: "politics"}, {Slug: "us"}, {Slug: "hidden", ForceHide: true}},
+	}
+
+	related, err := client.GetRelatedMarkets(context.Background(), market, nil)
+	if err != nil {
+		t.Fatalf("GetRelatedMarkets() error: %v", err)
+	}
+
+	want := []struct {
+		conditionID string
+		relation    RelationType
+		tags        int
+	}{
+		{"sibling", RelationSameEvent, 0},
+		{"p1", RelationSharedTag, 2},
+		{"p2", RelationSharedTag, 1},
+	}
+	if len(related) != len(want) {
+		t.Fatalf("got %d related markets, expected %d: %+v", len(related), len(want), related)
+	}
+	for i, w := range want {
+		r := related[i]
+		if r.Market.ConditionID != w.conditionID || r.Relation != w.relation || len(r.SharedTags) != w.tags {
+			t.Errorf("related[%d] = %s/%s/%v, expected %s/%s/%d tags", i, r.Market.ConditionID, r.Relation, r.SharedTags, w.conditionID, w.relation, w.tags)
+		}
+	}
+
+	opts := DefaultRelatedMarketsOptions()
+	opts.IncludeTags = false
+	related, err = client.GetRelatedMarkets(context.Background(), market, opts)
+	if err != nil || len(related) != 1 {
+		t.Errorf("event-only related = %+v, %v", related, err)
+	}
+}
+
+func TestMarket_EventsJSON(t *testing.T) {
+	var m Market
+	data := `{"conditionId":"0xabc","events":[{"id":"903","slug":"election","title":"Election"}]}`
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Events) != 1 || m.Events[0].ID != "903" {
+		t.Errorf("Events = %+v", m.Events)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 // Market 市场信息
@@ -55,10 +57,16 @@ type Market struct {
 	CreatedAt    string `json:"createdAt,omitempty"`
 	UpdatedAt    string `json:"updatedAt,omitempty"`
 
+	// 体育等定时事件的开赛时间，如 "2025-01-05 18:00:00+00"
+	GameStartTime string `json:"gameStartTime,omitempty"`
+
 	// 分类
 	Category string `json:"category"`
 	Tags     []Tag  `json:"tags,omitempty"`
 
+	// 所属事件（列表接口只返回事件摘要，不含下属市场）
+	Events []Event `json:"events,omitempty"`
+
 	// 市场类型
 	MarketType       string `json:"marketType"` // "binary" 等
 	NegRisk          bool   `json:"negRisk"`
@@ -99,10 +107,53 @@ type Tag struct {
 	ForceHide bool   `json:"forceHide"`
 }
 
+// Event 事件信息（一个事件包含多个市场，例如选举）
+type Event struct {
+	ID          string `json:"id"`
+	Ticker      string `json:"ticker"`
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	// 状态
+	Active   bool `json:"active"`
+	Closed   bool `json:"closed"`
+	Archived bool `json:"archived"`
+
+	// 市场类型
+	NegRisk         bool   `json:"negRisk"`
+	NegRiskMarketID string `json:"negRiskMarketID,omitempty"`
+
+	// 流动性
+	Volume    float64 `json:"volume"`
+	Liquidity float64 `json:"liquidity"`
+
+	// 时间
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+	StartTime string `json:"startTime,omitempty"` // 定时事件的开始时间（体育比赛开赛）
+
+	// 分类
+	Tags []Tag `json:"tags,omitempty"`
+
+	// 图片
+	Image string `json:"image,omitempty"`
+	Icon  string `json:"icon,omitempty"`
+
+	// 下属市场
+	Markets []Market `json:"markets"`
+}
+
+// IsActive 判断事件是否活跃
+func (e *Event) IsActive() bool {
+	return e.Active && !e.Closed && !e.Archived
+}
+
 // MarketListParams 市场列表查询参数
 type MarketListParams struct {
-	Limit  int `url:"limit,omitempty"`
-	Offset int `url:"offset,omitempty"`
+	Limit  int    `url:"limit,omitempty"`
+	Offset int    `url:"offset,omitempty"`
+	Cursor string `url:"-"` // 上一页的 NextCursor，非空时覆盖 Offset
 
 	// 筛选条件
 	Active   *bool `url:"active,omitempty"`
@@ -134,14 +185,53 @@ type MarketListParams struct {
 
 	// 批量 ID 查询 (生成 ?id=xxx&id=yyy 格式)
 	Ids []string `url:"id,omitempty"`
+
+	// 批量 slug 查询 (生成 ?slug=xxx&slug=yyy 格式)
+	Slugs []string `url:"slug,omitempty"`
+
+	// 批量 conditionID 查询 (生成 ?condition_ids=xxx&condition_ids=yyy 格式)
+	ConditionIDs []string `url:"condition_ids,omitempty"`
+
+	// 批量 CLOB token ID 查询 (生成 ?clob_token_ids=xxx&clob_token_ids=yyy 格式)
+	ClobTokenIDs []string `url:"clob_token_ids,omitempty"`
 }
 
 // MarketListResponse 市场列表响应
 type MarketListResponse struct {
 	Data       []Market `json:"data,omitempty"`
-	NextCursor string   `json:"next_cursor,omitempty"`
+	NextCursor string   `json:"next_cursor,omitempty"` // 下一页游标，为空表示没有更多数据
 	Limit      int      `json:"limit,omitempty"`
-	Count      int      `json:"count,omitempty"`
+	Count      int      `json:"count,omitempty"` // 本页数量
+	HasMore    bool     `json:"has_more,omitempty"`
+	Total      int      `json:"total,omitempty"` // 符合条件的总数，接口未返回时为 0
+}
+
+// EventListParams 事件列表查询参数
+type EventListParams struct {
+	Limit  int    `url:"limit,omitempty"`
+	Offset int    `url:"offset,omitempty"`
+	Cursor string `url:"-"` // 上一页的 NextCursor，非空时覆盖 Offset
+
+	// 筛选条件
+	Active   *bool  `url:"active,omitempty"`
+	Closed   *bool  `url:"closed,omitempty"`
+	Archived *bool  `url:"archived,omitempty"`
+	Featured *bool  `url:"featured,omitempty"`
+	TagSlug  string `url:"tag_slug,omitempty"`
+	TagId    int    `url:"tag_id,omitempty"`
+
+	// 排序
+	Order     string `url:"order,omitempty"` // volume, liquidity, start_date, end_date, id
+	Ascending bool   `url:"ascending,omitempty"`
+}
+
+// EventListResponse 事件列表响应
+type EventListResponse struct {
+	Data       []Event `json:"data,omitempty"`
+	NextCursor string  `json:"next_cursor,omitempty"` // 下一页游标，为空表示没有更多数据
+	Count      int     `json:"count,omitempty"`
+	HasMore    bool    `json:"has_more,omitempty"`
+	Total      int     `json:"total,omitempty"` // 符合条件的总数
 }
 
 // GetOutcomePrices 解析 outcomePrices 字符串
@@ -173,14 +263,34 @@ func (m *Market) GetClobTokenIDs() []string {
 		return nil
 	}
 
-	var ids []string
-	if err := json.Unmarshal([]byte(m.ClobTokenIds), &ids); err != nil {
+	// 解析为 common.TokenID，兼容字符串与数字两种元素，避免大整数经过 float64
+	var tokenIDs []common.TokenID
+	if err := json.Unmarshal([]byte(m.ClobTokenIds), &tokenIDs); err != nil {
 		// 尝试解析为逗号分隔格式
-		ids = splitString(m.ClobTokenIds, ",")
+		return splitString(m.ClobTokenIds, ",")
+	}
+
+	ids := make([]string, len(tokenIDs))
+	for i, id := range tokenIDs {
+		ids[i] = id.String()
 	}
 	return ids
 }
 
+// GetOutcomes 解析 outcomes 字符串，顺序与 GetClobTokenIDs 一致
+func (m *Market) GetOutcomes() []string {
+	if m.Outcomes == "" {
+		return nil
+	}
+
+	var outcomes []string
+	if err := json.Unmarshal([]byte(m.Outcomes), &outcomes); err != nil {
+		// 尝试解析为逗号分隔格式
+		outcomes = splitString(m.Outcomes, ",")
+	}
+	return outcomes
+}
+
 // GetEndDate 解析结束日期
 func (m *Market) GetEndDate() (time.Time, error) {
 	if m.EndDateIso != "" {
@@ -89,6 +89,38 @@ func TestMarketGetClobTokenIDs(t *testing.T) {
 	}
 }
 
+func TestMarketGetClobTokenIDsFullPrecision(t *testing.T) {
+	const yes = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	const no = "52114319501245915516055106046884209969926127482827954674443846427813813222426"
+
+	for _, raw := range []string{
+		`["` + yes + `","` + no + `"]`,
+		`[` + yes + `,` + no + `]`,
+	} {
+		m := &Market{ClobTokenIds: raw}
+		ids := m.GetClobTokenIDs()
+		if len(ids) != 2 || ids[0] != yes || ids[1] != no {
+			t.Errorf("GetClobTokenIDs(%s) = %v", raw, ids)
+		}
+	}
+}
+
+func TestMarketGetOutcomes(t *testing.T) {
+	m := &Market{Outcomes: `["Yes","No"]`}
+	if outcomes := m.GetOutcomes(); len(outcomes) != 2 || outcomes[0] != "Yes" {
+		t.Errorf("GetOutcomes() = %v, expected [Yes No]", outcomes)
+	}
+
+	m = &Market{Outcomes: "Trump, Harris"}
+	if outcomes := m.GetOutcomes(); len(outcomes) != 2 || outcomes[1] != "Harris" {
+		t.Errorf("GetOutcomes() = %v, expected [Trump Harris]", outcomes)
+	}
+
+	if outcomes := (&Market{}).GetOutcomes(); outcomes != nil {
+		t.Errorf("GetOutcomes() = %v, expected nil", outcomes)
+	}
+}
+
 func TestMarketGetEndDate(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,56 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"fmt"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/userws"
+)
+
+// NewOrderTracker 创建订单生命周期跟踪器，使用交易客户端轮询 GetOrder
+// 同时使用 USER 频道时，将收到的事件传给 ApplyUserEvent 可更快得到成交与撤单事件
+// 检测到的成交同时写入 Trading.RecentEvents 的事件日志
+func (s *SDK) NewOrderTracker(config *clob.OrderTrackerConfig) (*clob.OrderTracker, error) {
+	if s.Trading == nil {
+		return nil, fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+	return clob.NewOrderTracker(s.Trading, config), nil
+}
+
+// ApplyUserEvent 将 USER 频道的订单事件应用到跟踪器，返回产生的生命周期事件
+// 成交事件与连接事件被忽略：订单的 UPDATE 推送已包含累计成交数量，重连后的对账由轮询完成
+func ApplyUserEvent(tracker *clob.OrderTracker, event userws.Event) []clob.OrderTrackerEvent {
+	update, ok := OrderUpdateFromUserEvent(event)
+	if !ok {
+		return nil
+	}
+	return tracker.Apply(update)
+}
+
+// OrderUpdateFromUserEvent 将 USER 频道的订单事件转换为跟踪器的状态更新，非订单事件返回 false
+func OrderUpdateFromUserEvent(event userws.Event) (clob.OrderUpdate, bool) {
+	if event.Type != userws.EventTypeOrder || event.Order == nil {
+		return clob.OrderUpdate{}, false
+	}
+
+	order := event.Order
+	update := clob.OrderUpdate{
+		OrderID:      order.ID,
+		Status:       clob.OrderStatusLive,
+		AssetID:      order.AssetID,
+		Side:         order.Side,
+		Price:        order.Price,
+		OriginalSize: order.OriginalSize,
+		SizeMatched:  order.SizeMatched,
+		Time:         order.Time(),
+	}
+	if order.Type == userws.OrderCancellation {
+		update.Status = clob.OrderStatusCanceled
+	}
+	if update.Time.IsZero() {
+		update.Time = event.ReceivedAt
+	}
+	return update, true
+}
@@ -0,0 +1,105 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/userws"
+)
+
+func TestSDKNewOrderTracker(t *testing.T) {
+	public := NewPublicSDK(nil)
+	defer public.Close()
+	if _, err := public.NewOrderTracker(nil); err == nil {
+		t.Error("NewOrderTracker() should fail for public SDK")
+	}
+
+	sdk, err := NewSDK(nil, sdkTestPrivateKey)
+	if err != nil {
+		t.Fatalf("NewSDK() error: %v", err)
+	}
+	defer sdk.Close()
+	if tracker, err := sdk.NewOrderTracker(nil); err != nil || tracker == nil {
+		t.Errorf("NewOrderTracker() = %v, %v", tracker, err)
+	}
+}
+
+func TestApplyUserEvent(t *testing.T) {
+	tracker := clob.NewOrderTracker(nil, nil)
+	tracker.Track("0xorder")
+	received := time.Unix(1700000000, 0)
+
+	orderEvent := func(typ userws.OrderEventType, matched int64) userws.Event {
+		return userws.Event{
+			Type: userws.EventTypeOrder,
+			Order: &userws.OrderEvent{
+				ID:           "0xorder",
+				Type:         typ,
+				AssetID:      "token-1",
+				Price:        decimal.RequireFromString("0.4"),
+				OriginalSize: decimal.NewFromInt(10),
+				SizeMatched:  decimal.NewFromInt(matched),
+			},
+			ReceivedAt: received,
+		}
+	}
+
+	events := ApplyUserEvent(tracker, orderEvent(userws.OrderPlacement, 0))
+	if len(events) != 1 || events[0].Type != clob.OrderAccepted || !events[0].Time.Equal(received) {
+		t.Fatalf("Unexpected placement events: %+v", events)
+	}
+	events = ApplyUserEvent(tracker, orderEvent(userws.OrderUpdate, 4))
+	if len(events) != 1 || events[0].Type != clob.OrderPartiallyFilled || !events[0].Filled.Equal(decimal.NewFromInt(4)) {
+		t.Fatalf("Unexpected update events: %+v", events)
+	}
+
+	// Trade and connection events are ignored
+	if events := ApplyUserEvent(tracker, userws.Event{Type: userws.EventTypeTrade, Trade: &userws.TradeEvent{}}); events != nil {
+		t.Errorf("Trade event produced events: %+v", events)
+	}
+	if events := ApplyUserEvent(tracker, userws.Event{Type: userws.EventTypeConnected}); events != nil {
+		t.Errorf("Connected event produced events: %+v", events)
+	}
+
+	events = ApplyUserEvent(tracker, orderEvent(userws.OrderCancellation, 4))
+	if len(events) != 1 || events[0].Type != clob.OrderCanceled {
+		t.Fatalf("Unexpected cancellation events: %+v", events)
+	}
+}
+
+func TestApplyUserEvent_RecordsFills(t *testing.T) {
+	sdk, err := NewSDK(nil, sdkTestPrivateKey)
+	if err != nil {
+		t.Fatalf("NewSDK() error: %v", err)
+	}
+	defer sdk.Close()
+	tracker, err := sdk.NewOrderTracker(&clob.OrderTrackerConfig{})
+	if err != nil {
+		t.Fatalf("NewOrderTracker() error: %v", err)
+	}
+	tracker.Track("0xorder")
+
+	ApplyUserEvent(tracker, userws.Event{
+		Type: userws.EventTypeOrder,
+		Order: &userws.OrderEvent{
+			ID:           "0xorder",
+			Type:         userws.OrderUpdate,
+			AssetID:      "token-1",
+			Side:         clob.OrderSideBuy,
+			Price:        decimal.RequireFromString("0.4"),
+			OriginalSize: decimal.NewFromInt(10),
+			SizeMatched:  decimal.NewFromInt(3),
+		},
+		ReceivedAt: time.Unix(1700000000, 0),
+	})
+
+	events := sdk.Trading.RecentEvents()
+	if len(events) != 1 || events[0].Type != clob.TradingEventFill || events[0].OrderID != "0xorder" || !events[0].Size.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("Unexpected event log: %+v", events)
+	}
+}
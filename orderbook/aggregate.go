@@ -0,0 +1,44 @@
+package orderbook
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// GetAggregatedDepth 按价格桶聚合全部档位，用于热力图等展示
+// 买单向下归入桶下沿、卖单向上归入桶上沿，聚合后买一不高于原买一、卖一不低于原卖一；
+// 买单按价格降序、卖单按价格升序，未初始化时返回 nil
+func (ob *OrderBook) GetAggregatedDepth(bucket decimal.Decimal) (bids []OrderSummary, asks []OrderSummary) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if !ob.initialized {
+		return nil, nil
+	}
+
+	ob.rebuildSortedBids()
+	ob.rebuildSortedAsks()
+
+	return AggregateLevels(ob.sortedBids, bucket, false), AggregateLevels(ob.sortedAsks, bucket, true)
+}
+
+// AggregateLevels 将已排序的档位按 bucket 宽度合并，up 为 true 时价格向上取整到桶边界（卖单），否则向下取整（买单）
+// 输入顺序保持不变，bucket 必须为正
+func AggregateLevels(levels []OrderSummary, bucket decimal.Decimal, up bool) []OrderSummary {
+	result := make([]OrderSummary, 0)
+	for _, level := range levels {
+		steps := level.Price.Div(bucket)
+		if up {
+			steps = steps.Ceil()
+		} else {
+			steps = steps.Floor()
+		}
+		price := steps.Mul(bucket)
+
+		if n := len(result); n > 0 && result[n-1].Price.Equal(price) {
+			result[n-1].Size = result[n-1].Size.Add(level.Size)
+			continue
+		}
+		result = append(result, OrderSummary{Price: price, Size: level.Size})
+	}
+	return result
+}
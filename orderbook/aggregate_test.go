@@ -0,0 +1,68 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func levelsString(levels []OrderSummary) string {
+	s := ""
+	for _, l := range levels {
+		s += l.Price.String() + "x" + l.Size.String() + " "
+	}
+	return s
+}
+
+func TestOrderBook_GetAggregatedDepth(t *testing.T) {
+	if bids, asks := NewOrderBook("tok").GetAggregatedDepth(decimal.RequireFromString("0.01")); bids != nil || asks != nil {
+		t.Error("uninitialized book should return nil levels")
+	}
+
+	ob := NewOrderBook("tok")
+	ob.ApplyBookSnapshot(&BookMessage{
+		AssetID: "tok",
+		Bids: []RawOrderSummary{
+			{Price: "0.455", Size: "5"}, {Price: "0.451", Size: "10"}, {Price: "0.449", Size: "20"}, {Price: "0.42", Size: "1"},
+		},
+		Asks: []RawOrderSummary{
+			{Price: "0.461", Size: "3"}, {Price: "0.469", Size: "4"}, {Price: "0.47", Size: "6"}, {Price: "0.5", Size: "2"},
+		},
+	}, 1)
+
+	bids, asks := ob.GetAggregatedDepth(decimal.RequireFromString("0.01"))
+
+	// Bids floor to the bucket, asks ceil, so the aggregated spread never looks tighter
+	if got, want := levelsString(bids), "0.45x15 0.44x20 0.42x1 "; got != want {
+		t.Errorf("bids = %q, want %q", got, want)
+	}
+	if got, want := levelsString(asks), "0.47x13 0.5x2 "; got != want {
+		t.Errorf("asks = %q, want %q", got, want)
+	}
+}
+
+func TestAggregateLevels_CoarseBucket(t *testing.T) {
+	levels := []OrderSummary{
+		{Price: decimal.RequireFromString("0.51"), Size: decimal.NewFromInt(1)},
+		{Price: decimal.RequireFromString("0.54"), Size: decimal.NewFromInt(2)},
+		{Price: decimal.RequireFromString("0.56"), Size: decimal.NewFromInt(3)},
+	}
+
+	got := levelsString(AggregateLevels(levels, decimal.RequireFromString("0.05"), true))
+	if want := "0.55x3 0.6x3 "; got != want {
+		t.Errorf("AggregateLevels() = %q, want %q", got, want)
+	}
+}
+
+func TestSDK_GetAggregatedDepth_InvalidBucket(t *testing.T) {
+	sdk := NewSDK(DefaultConfig())
+	for _, bucket := range []string{"0", "-0.01", "2"} {
+		_, _, err := sdk.GetAggregatedDepth("tok", decimal.RequireFromString(bucket))
+		if !errors.Is(err, common.ErrInvalidPrice) {
+			t.Errorf("bucket %s: expected ErrInvalidPrice, got %v", bucket, err)
+		}
+	}
+}
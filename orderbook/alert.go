@@ -0,0 +1,368 @@
+package orderbook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// AlertCondition 告警条件
+type AlertCondition string
+
+const (
+	// AlertPriceAbove 价格升至阈值及以上
+	AlertPriceAbove AlertCondition = "price_above"
+	// AlertPriceBelow 价格跌至阈值及以下
+	AlertPriceBelow AlertCondition = "price_below"
+	// AlertSpreadAbove 价差扩大至阈值及以上
+	AlertSpreadAbove AlertCondition = "spread_above"
+	// AlertBidDepthBelow 买盘前 N 档总量降至阈值及以下
+	AlertBidDepthBelow AlertCondition = "bid_depth_below"
+	// AlertAskDepthBelow 卖盘前 N 档总量降至阈值及以下
+	AlertAskDepthBelow AlertCondition = "ask_depth_below"
+)
+
+// AlertPriceSource 价格告警使用的价格
+type AlertPriceSource string
+
+const (
+	// AlertPriceMid 中间价（默认）
+	AlertPriceMid AlertPriceSource = "mid"
+	// AlertPriceBid 最优买价
+	AlertPriceBid AlertPriceSource = "bid"
+	// AlertPriceAsk 最优卖价
+	AlertPriceAsk AlertPriceSource = "ask"
+)
+
+// defaultAlertDepthLevels 深度告警默认统计档数
+const defaultAlertDepthLevels = 5
+
+// AlertRule 告警规则
+// 触发后规则进入未就绪状态，指标需回到阈值另一侧超过 Hysteresis 才会重新就绪；
+// Cooldown 内即使重新就绪也不再触发
+type AlertRule struct {
+	ID          string
+	TokenID     string
+	Condition   AlertCondition
+	Threshold   decimal.Decimal
+	Hysteresis  decimal.Decimal  // 回滞区间，0 表示回到阈值另一侧即重新就绪
+	Cooldown    time.Duration    // 两次触发的最小间隔
+	PriceSource AlertPriceSource // 价格告警使用的价格，默认中间价
+	DepthLevels int              // 深度告警统计档数，默认 5
+}
+
+// Alert 告警事件
+type Alert struct {
+	RuleID    string          `json:"rule_id"`
+	TokenID   string          `json:"token_id"`
+	Condition AlertCondition  `json:"condition"`
+	Threshold decimal.Decimal `json:"threshold"`
+	Value     decimal.Decimal `json:"value"`
+	Time      time.Time       `json:"time"`
+	Metadata  *TokenMetadata  `json:"metadata,omitempty"`
+}
+
+// BookReader 告警引擎读取订单簿的接口（*SDK 已实现）
+type BookReader interface {
+	GetBBO(tokenID string) (*BBO, error)
+	GetDepth(tokenID string, depth int) (bids []OrderSummary, asks []OrderSummary, err error)
+}
+
+// AlertConfig 告警引擎配置
+type AlertConfig struct {
+	ChannelSize    int           // 告警 channel 缓冲区大小，满时丢弃新告警（计入 DeliveryStats）
+	WebhookURL     string        // 可选，告警以 JSON POST 到该地址，失败记录日志并计入 DeliveryStats
+	WebhookTimeout time.Duration // webhook 请求超时
+}
+
+// DefaultAlertConfig 默认告警配置
+func DefaultAlertConfig() *AlertConfig {
+	return &AlertConfig{
+		ChannelSize:    100,
+		WebhookTimeout: 5 * time.Second,
+	}
+}
+
+// alertState 规则运行状态
+type alertState struct {
+	rule      AlertRule
+	armed     bool
+	lastFired time.Time
+}
+
+// AlertEngine 告警引擎，在订单簿更新流上评估按 token 注册的告警规则
+type AlertEngine struct {
+	mu     sync.Mutex
+	books  BookReader
+	config *AlertConfig
+	rules  map[string]map[string]*alertState // tokenID -> ruleID -> state
+
+	alertChan  chan Alert
+	httpClient *http.Client
+	now        func() time.Time
+
+	dropped       atomic.Int64 // channel 满时丢弃的告警数
+	webhookFailed atomic.Int64 // webhook 发送失败的告警数
+}
+
+// AlertDeliveryStats 告警投递统计
+type AlertDeliveryStats struct {
+	Dropped       int64 // Alerts() channel 满时丢弃的告警数
+	WebhookFailed int64 // webhook 请求失败或返回非 2xx 的告警数
+}
+
+// NewAlertEngine 创建告警引擎
+func NewAlertEngine(books BookReader, config *AlertConfig) *AlertEngine {
+	if config == nil {
+		config = DefaultAlertConfig()
+	}
+
+	return &AlertEngine{
+		books:      books,
+		config:     config,
+		rules:      make(map[string]map[string]*alertState),
+		alertChan:  make(chan Alert, config.ChannelSize),
+		httpClient: &http.Client{Timeout: config.WebhookTimeout},
+		now:        time.Now,
+	}
+}
+
+// AddRule 注册告警规则，同一 token 下相同 ID 的规则会被替换
+func (e *AlertEngine) AddRule(rule AlertRule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("alert rule ID is required")
+	}
+	if rule.TokenID == "" {
+		return fmt.Errorf("alert rule token ID is required")
+	}
+	if rule.Hysteresis.IsNegative() {
+		return fmt.Errorf("alert rule hysteresis must not be negative")
+	}
+
+	switch rule.Condition {
+	case AlertPriceAbove, AlertPriceBelow:
+		if rule.PriceSource == "" {
+			rule.PriceSource = AlertPriceMid
+		}
+	case AlertSpreadAbove:
+	case AlertBidDepthBelow, AlertAskDepthBelow:
+		if rule.DepthLevels <= 0 {
+			rule.DepthLevels = defaultAlertDepthLevels
+		}
+	default:
+		return fmt.Errorf("unknown alert condition: %s", rule.Condition)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.rules[rule.TokenID] == nil {
+		e.rules[rule.TokenID] = make(map[string]*alertState)
+	}
+	e.rules[rule.TokenID][rule.ID] = &alertState{rule: rule, armed: true}
+	return nil
+}
+
+// RemoveRule 移除告警规则
+func (e *AlertEngine) RemoveRule(tokenID, ruleID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.rules[tokenID], ruleID)
+	if len(e.rules[tokenID]) == 0 {
+		delete(e.rules, tokenID)
+	}
+}
+
+// Alerts 获取告警 channel
+func (e *AlertEngine) Alerts() <-chan Alert {
+	return e.alertChan
+}
+
+// Run 持续消费更新流并评估规则，直到 ctx 取消或 updates 关闭
+func (e *AlertEngine) Run(ctx context.Context, updates <-chan OrderBookUpdate) {
+	common.Supervise(ctx, "orderbook.alert", nil, func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				e.Process(update)
+			}
+		}
+	})
+}
+
+// Process 评估指定更新所属 token 的全部规则
+func (e *AlertEngine) Process(update OrderBookUpdate) {
+	e.mu.Lock()
+	states := make([]*alertState, 0, len(e.rules[update.TokenID]))
+	for _, st := range e.rules[update.TokenID] {
+		states = append(states, st)
+	}
+	e.mu.Unlock()
+
+	for _, st := range states {
+		value, ok := e.evaluate(st.rule)
+		if !ok {
+			continue
+		}
+
+		if alert, fired := e.transition(st, value); fired {
+			alert.Metadata = update.Metadata
+			e.deliver(alert)
+		}
+	}
+}
+
+// transition 根据当前指标值更新规则状态，返回是否触发
+func (e *AlertEngine) transition(st *alertState, value decimal.Decimal) (Alert, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rule := st.rule
+	above := rule.Condition == AlertPriceAbove || rule.Condition == AlertSpreadAbove
+
+	var breached, rearm bool
+	if above {
+		breached = value.GreaterThanOrEqual(rule.Threshold)
+		rearm = value.LessThan(rule.Threshold.Sub(rule.Hysteresis))
+	} else {
+		breached = value.LessThanOrEqual(rule.Threshold)
+		rearm = value.GreaterThan(rule.Threshold.Add(rule.Hysteresis))
+	}
+
+	if !st.armed {
+		if rearm {
+			st.armed = true
+		}
+		return Alert{}, false
+	}
+
+	now := e.now()
+	if !breached || (!st.lastFired.IsZero() && now.Sub(st.lastFired) < rule.Cooldown) {
+		return Alert{}, false
+	}
+
+	st.armed = false
+	st.lastFired = now
+	return Alert{
+		RuleID:    rule.ID,
+		TokenID:   rule.TokenID,
+		Condition: rule.Condition,
+		Threshold: rule.Threshold,
+		Value:     value,
+		Time:      now,
+	}, true
+}
+
+// evaluate 计算规则对应的指标值，数据不足时返回 false
+func (e *AlertEngine) evaluate(rule AlertRule) (decimal.Decimal, bool) {
+	switch rule.Condition {
+	case AlertBidDepthBelow, AlertAskDepthBelow:
+		bids, asks, err := e.books.GetDepth(rule.TokenID, rule.DepthLevels)
+		if err != nil {
+			return decimal.Zero, false
+		}
+		levels := bids
+		if rule.Condition == AlertAskDepthBelow {
+			levels = asks
+		}
+		total := decimal.Zero
+		for _, level := range levels {
+			total = total.Add(level.Size)
+		}
+		return total, true
+	}
+
+	bbo, err := e.books.GetBBO(rule.TokenID)
+	if err != nil || bbo == nil {
+		return decimal.Zero, false
+	}
+
+	if rule.Condition == AlertSpreadAbove {
+		if bbo.BestBid == nil || bbo.BestAsk == nil {
+			return decimal.Zero, false
+		}
+		return bbo.BestAsk.Price.Sub(bbo.BestBid.Price), true
+	}
+
+	switch rule.PriceSource {
+	case AlertPriceBid:
+		if bbo.BestBid == nil {
+			return decimal.Zero, false
+		}
+		return bbo.BestBid.Price, true
+	case AlertPriceAsk:
+		if bbo.BestAsk == nil {
+			return decimal.Zero, false
+		}
+		return bbo.BestAsk.Price, true
+	default:
+		if bbo.BestBid == nil || bbo.BestAsk == nil {
+			return decimal.Zero, false
+		}
+		return bbo.BestBid.Price.Add(bbo.BestAsk.Price).Div(decimal.NewFromInt(2)), true
+	}
+}
+
+// DeliveryStats 获取告警投递统计（丢弃与 webhook 失败次数）
+func (e *AlertEngine) DeliveryStats() AlertDeliveryStats {
+	return AlertDeliveryStats{
+		Dropped:       e.dropped.Load(),
+		WebhookFailed: e.webhookFailed.Load(),
+	}
+}
+
+// deliver 投递告警：channel 满时丢弃并计数，webhook 异步发送，失败时记录日志并计数
+func (e *AlertEngine) deliver(alert Alert) {
+	select {
+	case e.alertChan <- alert:
+	default:
+		if n := e.dropped.Add(1); n == 1 || n%100 == 0 {
+			log.Printf("[Polymarket Alert] alert channel full, %d alerts dropped", n)
+		}
+	}
+
+	if e.config.WebhookURL != "" {
+		go func() {
+			defer common.RecoverPanic("orderbook.alert.webhook", nil)
+			if err := e.postWebhook(alert); err != nil {
+				n := e.webhookFailed.Add(1)
+				log.Printf("[Polymarket Alert] webhook for rule %s on %s failed (total %d): %v", alert.RuleID, alert.TokenID, n, err)
+			}
+		}()
+	}
+}
+
+// postWebhook 以 JSON POST 告警到 webhook
+func (e *AlertEngine) postWebhook(alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.httpClient.Post(e.config.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// fakeBooks is a BookReader backed by fixed values
+type fakeBooks struct {
+	bid, ask decimal.Decimal
+	bids     []OrderSummary
+	asks     []OrderSummary
+}
+
+func (f *fakeBooks) GetBBO(tokenID string) (*BBO, error) {
+	return &BBO{
+		BestBid: &BestPrice{Price: f.bid},
+		BestAsk: &BestPrice{Price: f.ask},
+	}, nil
+}
+
+func (f *fakeBooks) GetDepth(tokenID string, depth int) ([]OrderSummary, []OrderSummary, error) {
+	return f.bids, f.asks, nil
+}
+
+func (f *fakeBooks) setMid(mid string) {
+	m := decimal.RequireFromString(mid)
+	f.bid = m.Sub(decimal.NewFromFloat(0.01))
+	f.ask = m.Add(decimal.NewFromFloat(0.01))
+}
+
+func drainAlerts(e *AlertEngine) []Alert {
+	var alerts []Alert
+	for {
+		select {
+		case a := <-e.Alerts():
+			alerts = append(alerts, a)
+		default:
+			return alerts
+		}
+	}
+}
+
+func TestAlertEngine_PriceAboveHysteresis(t *testing.T) {
+	books := &fakeBooks{}
+	engine := NewAlertEngine(books, nil)
+	err := engine.AddRule(AlertRule{
+		ID:         "above",
+		TokenID:    "token",
+		Condition:  AlertPriceAbove,
+		Threshold:  decimal.NewFromFloat(0.60),
+		Hysteresis: decimal.NewFromFloat(0.05),
+	})
+	if err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+
+	update := OrderBookUpdate{TokenID: "token"}
+	steps := []struct {
+		mid  string
+		fire bool
+	}{
+		{"0.50", false},
+		{"0.61", true},
+		{"0.62", false}, // still breached, not re-armed
+		{"0.58", false}, // inside hysteresis band
+		{"0.61", false},
+		{"0.54", false}, // re-armed
+		{"0.60", true},
+	}
+
+	for i, step := range steps {
+		books.setMid(step.mid)
+		engine.Process(update)
+		alerts := drainAlerts(engine)
+		if fired := len(alerts) == 1; fired != step.fire {
+			t.Errorf("Step %d (mid %s): expected fire=%v, got %d alerts", i, step.mid, step.fire, len(alerts))
+		}
+	}
+}
+
+func TestAlertEngine_Cooldown(t *testing.T) {
+	books := &fakeBooks{}
+	engine := NewAlertEngine(books, nil)
+	now := time.Unix(1000, 0)
+	engine.now = func() time.Time { return now }
+
+	engine.AddRule(AlertRule{
+		ID:        "below",
+		TokenID:   "token",
+		Condition: AlertPriceBelow,
+		Threshold: decimal.NewFromFloat(0.40),
+		Cooldown:  time.Minute,
+	})
+
+	update := OrderBookUpdate{TokenID: "token"}
+
+	books.setMid("0.35")
+	engine.Process(update)
+	if len(drainAlerts(engine)) != 1 {
+		t.Fatal("Expected first alert")
+	}
+
+	// Re-arm and breach again within cooldown
+	books.setMid("0.45")
+	engine.Process(update)
+	books.setMid("0.35")
+	engine.Process(update)
+	if len(drainAlerts(engine)) != 0 {
+		t.Error("Expected alert to be suppressed during cooldown")
+	}
+
+	now = now.Add(2 * time.Minute)
+	engine.Process(update)
+	if len(drainAlerts(engine)) != 1 {
+		t.Error("Expected alert after cooldown")
+	}
+}
+
+func TestAlertEngine_SpreadAndDepth(t *testing.T) {
+	books := &fakeBooks{
+		bid:  decimal.NewFromFloat(0.40),
+		ask:  decimal.NewFromFloat(0.50),
+		bids: []OrderSummary{{Price: decimal.NewFromFloat(0.40), Size: decimal.NewFromInt(30)}},
+		asks: []OrderSummary{{Price: decimal.NewFromFloat(0.50), Size: decimal.NewFromInt(500)}},
+	}
+	engine := NewAlertEngine(books, nil)
+	engine.AddRule(AlertRule{ID: "spread", TokenID: "token", Condition: AlertSpreadAbove, Threshold: decimal.NewFromFloat(0.05)})
+	engine.AddRule(AlertRule{ID: "bid-depth", TokenID: "token", Condition: AlertBidDepthBelow, Threshold: decimal.NewFromInt(50)})
+	engine.AddRule(AlertRule{ID: "ask-depth", TokenID: "token", Condition: AlertAskDepthBelow, Threshold: decimal.NewFromInt(50)})
+
+	meta := &TokenMetadata{MarketSlug: "slug"}
+	engine.Process(OrderBookUpdate{TokenID: "token", Metadata: meta})
+
+	fired := make(map[string]Alert)
+	for _, a := range drainAlerts(engine) {
+		fired[a.RuleID] = a
+	}
+
+	if len(fired) != 2 {
+		t.Fatalf("Expected 2 alerts, got %d", len(fired))
+	}
+	if a, ok := fired["spread"]; !ok || !a.Value.Equal(decimal.NewFromFloat(0.10)) {
+		t.Errorf("Unexpected spread alert: %+v", a)
+	}
+	if a, ok := fired["bid-depth"]; !ok || a.Metadata != meta {
+		t.Errorf("Unexpected bid depth alert: %+v", a)
+	}
+}
+
+func TestAlertEngine_AddRuleValidation(t *testing.T) {
+	engine := NewAlertEngine(&fakeBooks{}, nil)
+
+	if err := engine.AddRule(AlertRule{TokenID: "token", Condition: AlertPriceAbove}); err == nil {
+		t.Error("Expected error for missing ID")
+	}
+	if err := engine.AddRule(AlertRule{ID: "x", TokenID: "token", Condition: "bogus"}); err == nil {
+		t.Error("Expected error for unknown condition")
+	}
+	if err := engine.AddRule(AlertRule{ID: "x", TokenID: "token", Condition: AlertPriceAbove, Hysteresis: decimal.NewFromInt(-1)}); err == nil {
+		t.Error("Expected error for negative hysteresis")
+	}
+}
+
+func TestAlertEngine_RemoveRule(t *testing.T) {
+	books := &fakeBooks{}
+	books.setMid("0.70")
+	engine := NewAlertEngine(books, nil)
+	engine.AddRule(AlertRule{ID: "above", TokenID: "token", Condition: AlertPriceAbove, Threshold: decimal.NewFromFloat(0.60)})
+	engine.RemoveRule("token", "above")
+
+	engine.Process(OrderBookUpdate{TokenID: "token"})
+	if len(drainAlerts(engine)) != 0 {
+		t.Error("Expected no alerts after rule removal")
+	}
+}
+
+func TestAlertEngine_Webhook(t *testing.T) {
+	received := make(chan Alert, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var a Alert
+		json.NewDecoder(r.Body).Decode(&a)
+		received <- a
+	}))
+	defer server.Close()
+
+	books := &fakeBooks{}
+	books.setMid("0.70")
+	config := DefaultAlertConfig()
+	config.WebhookURL = server.URL
+	engine := NewAlertEngine(books, config)
+	engine.AddRule(AlertRule{ID: "above", TokenID: "token", Condition: AlertPriceAbove, Threshold: decimal.NewFromFloat(0.60)})
+
+	engine.Process(OrderBookUpdate{TokenID: "token"})
+
+	select {
+	case a := <-received:
+		if a.RuleID != "above" || a.TokenID != "token" {
+			t.Errorf("Unexpected webhook payload: %+v", a)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for webhook")
+	}
+}
+
+func TestAlertEngine_DeliveryStats(t *testing.T) {
+	failed := make(chan struct{}, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		failed <- struct{}{}
+	}))
+	defer server.Close()
+
+	books := &fakeBooks{}
+	config := DefaultAlertConfig()
+	config.ChannelSize = 1
+	config.WebhookURL = server.URL
+	engine := NewAlertEngine(books, config)
+	engine.AddRule(AlertRule{ID: "above", TokenID: "token", Condition: AlertPriceAbove, Threshold: decimal.NewFromFloat(0.60)})
+
+	// Two firings with a one-slot channel: the second alert is dropped
+	books.setMid("0.70")
+	engine.Process(OrderBookUpdate{TokenID: "token"})
+	books.setMid("0.50")
+	engine.Process(OrderBookUpdate{TokenID: "token"})
+	books.setMid("0.70")
+	engine.Process(OrderBookUpdate{TokenID: "token"})
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-failed:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timed out waiting for webhook")
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for engine.DeliveryStats().WebhookFailed < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	stats := engine.DeliveryStats()
+	if stats.Dropped != 1 || stats.WebhookFailed != 2 {
+		t.Errorf("DeliveryStats() = %+v, expected 1 dropped and 2 webhook failures", stats)
+	}
+}
@@ -0,0 +1,82 @@
+package orderbook
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// ArbEdgeResult 同时买入 YES 与 NO 的可实现套利边际
+// 成交价按盘口深度逐档计算，手续费按 feeBps * min(p, 1-p) * 数量 逐档累加（与 Polymarket taker 费率公式一致）
+type ArbEdgeResult struct {
+	Size         decimal.Decimal // 请求的份数
+	FilledSize   decimal.Decimal // 两边都能成交的份数（取较小深度）
+	YesAvgPrice  decimal.Decimal // YES 加权平均成交价
+	NoAvgPrice   decimal.Decimal // NO 加权平均成交价
+	YesFee       decimal.Decimal // YES 腿手续费（USDC）
+	NoFee        decimal.Decimal // NO 腿手续费（USDC）
+	TotalCost    decimal.Decimal // 两腿成交金额与手续费之和（USDC）
+	CostPerShare decimal.Decimal // 每份 YES+NO 组合的含费成本
+	EdgePerShare decimal.Decimal // 每份净边际：1 - CostPerShare，大于 0 表示有利可图
+	NetProfit    decimal.Decimal // 按 FilledSize 兑付 1 USDC/份后的净利润
+	IsFullFill   bool            // 两边深度是否都足以成交 Size
+}
+
+// Profitable 是否存在扣除手续费与滑点后的正边际
+func (r *ArbEdgeResult) Profitable() bool {
+	return r != nil && r.FilledSize.IsPositive() && r.EdgePerShare.IsPositive()
+}
+
+// ArbEdge 计算在两个订单簿上各吃单买入 size 份后的每份净边际
+// 替代简单的 bestAsk(YES) + bestAsk(NO) < 1 判断：计入逐档深度造成的滑点与 taker 手续费
+// 任一订单簿未初始化、没有卖单或 size 非正时返回 nil；深度不足时按两边可成交的较小数量计算，IsFullFill 为 false
+func ArbEdge(yesBook, noBook *OrderBook, size decimal.Decimal, feeBps int) *ArbEdgeResult {
+	if yesBook == nil || noBook == nil || !size.IsPositive() {
+		return nil
+	}
+
+	yesFill := yesBook.SimulateBuyAsks(size)
+	noFill := noBook.SimulateBuyAsks(size)
+	if yesFill == nil || noFill == nil {
+		return nil
+	}
+
+	filled := decimal.Min(yesFill.FilledSize, noFill.FilledSize)
+	if !filled.IsPositive() {
+		return nil
+	}
+
+	feeRate := decimal.NewFromInt(int64(feeBps)).Div(decimal.NewFromInt(10000))
+	yesCost, yesFee := legCost(yesFill.Orders, filled, feeRate)
+	noCost, noFee := legCost(noFill.Orders, filled, feeRate)
+
+	total := yesCost.Add(noCost).Add(yesFee).Add(noFee)
+	costPerShare := total.Div(filled)
+	return &ArbEdgeResult{
+		Size:         size,
+		FilledSize:   filled,
+		YesAvgPrice:  yesCost.Div(filled),
+		NoAvgPrice:   noCost.Div(filled),
+		YesFee:       yesFee,
+		NoFee:        noFee,
+		TotalCost:    total,
+		CostPerShare: costPerShare,
+		EdgePerShare: decimal.NewFromInt(1).Sub(costPerShare),
+		NetProfit:    filled.Sub(total),
+		IsFullFill:   yesFill.IsFullFill && noFill.IsFullFill,
+	}
+}
+
+// legCost 按档位成交前 size 份的成交金额与手续费
+func legCost(levels []OrderSummary, size, feeRate decimal.Decimal) (cost, fee decimal.Decimal) {
+	one := decimal.NewFromInt(1)
+	remaining := size
+	for _, level := range levels {
+		if !remaining.IsPositive() {
+			break
+		}
+		qty := decimal.Min(level.Size, remaining)
+		cost = cost.Add(level.Price.Mul(qty))
+		fee = fee.Add(feeRate.Mul(decimal.Min(level.Price, one.Sub(level.Price))).Mul(qty))
+		remaining = remaining.Sub(qty)
+	}
+	return cost, fee
+}
@@ -0,0 +1,59 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestArbEdge(t *testing.T) {
+	d := decimal.RequireFromString
+	yes := newTestOrderBook(t, nil, []RawOrderSummary{{Price: "0.48", Size: "50"}, {Price: "0.50", Size: "50"}})
+	no := newTestOrderBook(t, nil, []RawOrderSummary{{Price: "0.49", Size: "30"}, {Price: "0.51", Size: "100"}})
+
+	// Without fees: 50 @ 0.48 + (30 @ 0.49 + 20 @ 0.51) = 48.9
+	edge := ArbEdge(yes, no, d("50"), 0)
+	if edge == nil || !edge.IsFullFill || !edge.TotalCost.Equal(d("48.9")) || !edge.EdgePerShare.Equal(d("0.022")) {
+		t.Fatalf("fee-free edge = %+v", edge)
+	}
+	if !edge.NoAvgPrice.Equal(d("0.498")) || !edge.NetProfit.Equal(d("1.1")) || !edge.Profitable() {
+		t.Errorf("unexpected legs %+v", edge)
+	}
+
+	// 200 bps on min(p, 1-p): 0.48 + 0.49 in fees still leaves a thin edge
+	edge = ArbEdge(yes, no, d("50"), 200)
+	if !edge.YesFee.Equal(d("0.48")) || !edge.NoFee.Equal(d("0.49")) || !edge.EdgePerShare.Equal(d("0.0026")) {
+		t.Errorf("200bps edge = %+v", edge)
+	}
+
+	// 300 bps wipes it out even though the ask sum is below 1
+	if edge = ArbEdge(yes, no, d("50"), 300); edge.Profitable() || !edge.EdgePerShare.IsNegative() {
+		t.Errorf("300bps edge should be negative, got %s", edge.EdgePerShare)
+	}
+
+	// Depth-limited by the YES book: only 100 shares fill on both legs
+	edge = ArbEdge(yes, no, d("200"), 0)
+	if edge.IsFullFill || !edge.FilledSize.Equal(d("100")) || !edge.TotalCost.Equal(d("99.4")) || !edge.EdgePerShare.Equal(d("0.006")) {
+		t.Errorf("partial edge = %+v", edge)
+	}
+}
+
+func TestArbEdge_Unavailable(t *testing.T) {
+	d := decimal.RequireFromString
+	yes := newTestOrderBook(t, nil, []RawOrderSummary{{Price: "0.48", Size: "50"}})
+	empty := newTestOrderBook(t, []RawOrderSummary{{Price: "0.40", Size: "10"}}, nil)
+
+	if ArbEdge(yes, NewOrderBook("tok"), d("10"), 0) != nil {
+		t.Error("expected nil for an uninitialized book")
+	}
+	if ArbEdge(yes, empty, d("10"), 0) != nil {
+		t.Error("expected nil when one side has no asks")
+	}
+	if ArbEdge(yes, yes, decimal.Zero, 0) != nil {
+		t.Error("expected nil for zero size")
+	}
+	var none *ArbEdgeResult
+	if none.Profitable() {
+		t.Error("nil result must not be profitable")
+	}
+}
@@ -0,0 +1,236 @@
+package orderbook
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// dec parses a decimal literal in test tables
+var dec = decimal.RequireFromString
+
+// deltaGoldens pin the wire format; a change here breaks every deployed consumer and needs a new deltaVersion
+var deltaGoldens = []struct {
+	name  string
+	delta *BookDelta
+	hex   string
+}{
+	{
+		name: "price change",
+		delta: &BookDelta{
+			TokenID:   "1234567890",
+			Timestamp: 1700000000123,
+			Hash:      "0a1b2c",
+			Levels: []DeltaLevel{
+				{Side: SideBuy, Price: dec("0.5"), Size: dec("12.5")},
+				{Side: SideBuy, Price: dec("0.49"), Size: dec("100")},
+				{Side: SideSell, Price: dec("0.515"), Size: dec("3")},
+				{Side: SideSell, Price: dec("0.52"), Size: dec("0")},
+			},
+		},
+		// version 1, flags numeric token | hex hash, decimals 4/6, token 0x499602d2, timestamp,
+		// hash 0a1b2c, 2 bids (5000 then -100), 2 asks (5150 then +50)
+		hex: "0106040604499602d2fbd095ffbc31030a1b2c02904ea0f8fa05c70180c2d72f02bc50c08db7016400",
+	},
+	{
+		name:  "empty snapshot with raw token and hash",
+		delta: &BookDelta{TokenID: "tok", Timestamp: 5, Hash: "not-hex", Snapshot: true},
+		hex:   "0101040603746f6b05076e6f742d6865780000",
+	},
+}
+
+func TestDeltaEncoder_Golden(t *testing.T) {
+	enc, err := NewDeltaEncoder(nil)
+	if err != nil {
+		t.Fatalf("NewDeltaEncoder() error: %v", err)
+	}
+	for _, tt := range deltaGoldens {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := enc.Encode(nil, tt.delta)
+			if err != nil {
+				t.Fatalf("Encode() error: %v", err)
+			}
+			if hex.EncodeToString(got) != tt.hex {
+				t.Errorf("Encode() = %x\nexpected   %s", got, tt.hex)
+			}
+
+			golden, _ := hex.DecodeString(tt.hex)
+			decoded, err := DecodeBookDelta(golden)
+			if err != nil {
+				t.Fatalf("DecodeBookDelta() error: %v", err)
+			}
+			assertDeltaEqual(t, decoded, tt.delta)
+		})
+	}
+}
+
+// assertDeltaEqual compares deltas with levels in wire order (bids descending, then asks ascending)
+func assertDeltaEqual(t *testing.T, got, want *BookDelta) {
+	t.Helper()
+	if got.TokenID != want.TokenID || got.Timestamp != want.Timestamp || got.Hash != want.Hash || got.Snapshot != want.Snapshot {
+		t.Errorf("header = %+v, expected %+v", got, want)
+	}
+	if len(got.Levels) != len(want.Levels) {
+		t.Fatalf("got %d levels, expected %d", len(got.Levels), len(want.Levels))
+	}
+	for i := range want.Levels {
+		g, w := got.Levels[i], want.Levels[i]
+		if g.Side != w.Side || !g.Price.Equal(w.Price) || !g.Size.Equal(w.Size) {
+			t.Errorf("level %d = %s %s x %s, expected %s %s x %s", i, g.Side, g.Price, g.Size, w.Side, w.Price, w.Size)
+		}
+	}
+}
+
+func TestDeltaEncoder_RoundTripSnapshot(t *testing.T) {
+	ob := newTestOrderBook(t,
+		[]RawOrderSummary{{Price: "0.45", Size: "5"}, {Price: "0.001", Size: "1000000"}},
+		[]RawOrderSummary{{Price: "0.999", Size: "0.000001"}, {Price: "0.55", Size: "7.25"}},
+	)
+	snap := ob.Snapshot(0)
+	snap.TokenID = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	snap.Hash = "5f1c7a3cbe4a6a0d3e0c2f8a0f6a5b7c1d2e3f40"
+
+	enc, _ := NewDeltaEncoder(nil)
+	delta := SnapshotDelta(snap)
+	data, err := enc.Encode(nil, delta)
+	if err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+	decoded, err := DecodeBookDelta(data)
+	if err != nil {
+		t.Fatalf("DecodeBookDelta() error: %v", err)
+	}
+	assertDeltaEqual(t, decoded, delta)
+
+	// Encode appends to dst
+	prefixed, _ := enc.Encode([]byte{0xff}, delta)
+	if prefixed[0] != 0xff || hex.EncodeToString(prefixed[1:]) != hex.EncodeToString(data) {
+		t.Error("Encode() should append to dst")
+	}
+}
+
+func TestDeltaEncoder_CompressionRatio(t *testing.T) {
+	const tokenID = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	msg := &BookMessage{
+		EventType: EventTypeBook,
+		AssetID:   tokenID,
+		Market:    "0x5f65177b394277fd294cd75650044e32ba009a95022d88a0c1d565897d72f8f1",
+		Timestamp: "1700000000123",
+		Hash:      "5f1c7a3cbe4a6a0d3e0c2f8a0f6a5b7c1d2e3f40",
+	}
+	delta := &BookDelta{TokenID: tokenID, Timestamp: 1700000000123, Hash: msg.Hash, Snapshot: true}
+	for i := 1; i <= 40; i++ {
+		bid := fmt.Sprintf("0.%02d", 50-i)
+		ask := fmt.Sprintf("0.%02d", 50+i)
+		size := fmt.Sprintf("%d.%02d", 100+i*37, i)
+		msg.Bids = append(msg.Bids, RawOrderSummary{Price: bid, Size: size})
+		msg.Asks = append(msg.Asks, RawOrderSummary{Price: ask, Size: size})
+		delta.Levels = append(delta.Levels,
+			DeltaLevel{Side: SideBuy, Price: dec(bid), Size: dec(size)},
+			DeltaLevel{Side: SideSell, Price: dec(ask), Size: dec(size)})
+	}
+
+	jsonBytes, _ := json.Marshal(msg)
+	// book sizes are normally in 0.01 share units, which is what publishers tune SizeDecimals to
+	enc, _ := NewDeltaEncoder(&DeltaCodecConfig{PriceDecimals: 3, SizeDecimals: 2})
+	binBytes, err := enc.Encode(nil, delta)
+	if err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+	if ratio := float64(len(jsonBytes)) / float64(len(binBytes)); ratio < 5 {
+		t.Errorf("compression ratio %.1fx (json %d bytes, binary %d bytes), expected at least 5x", ratio, len(jsonBytes), len(binBytes))
+	}
+}
+
+func TestDeltaEncoder_RejectsLossyValues(t *testing.T) {
+	enc, _ := NewDeltaEncoder(&DeltaCodecConfig{PriceDecimals: 2, SizeDecimals: 0})
+
+	tests := []struct {
+		level DeltaLevel
+		want  error
+	}{
+		{DeltaLevel{Side: SideBuy, Price: dec("0.505"), Size: dec("1")}, common.ErrInvalidPrice},
+		{DeltaLevel{Side: SideBuy, Price: dec("-0.5"), Size: dec("1")}, common.ErrInvalidPrice},
+		{DeltaLevel{Side: SideSell, Price: dec("0.5"), Size: dec("1.5")}, common.ErrInvalidSize},
+		{DeltaLevel{Side: "HOLD", Price: dec("0.5"), Size: dec("1")}, ErrMalformedDelta},
+	}
+	for _, tt := range tests {
+		dst := []byte{1, 2}
+		out, err := enc.Encode(dst, &BookDelta{TokenID: "1", Levels: []DeltaLevel{tt.level}})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Encode(%+v) error = %v, expected %v", tt.level, err, tt.want)
+		}
+		if len(out) != len(dst) {
+			t.Errorf("Encode() should return dst unchanged on error, got %d bytes", len(out))
+		}
+	}
+
+	if _, err := NewDeltaEncoder(&DeltaCodecConfig{PriceDecimals: 19}); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestDecodeBookDelta_Malformed(t *testing.T) {
+	golden, _ := hex.DecodeString(deltaGoldens[0].hex)
+
+	for n := 0; n < len(golden); n++ {
+		if _, err := DecodeBookDelta(golden[:n]); !errors.Is(err, ErrMalformedDelta) {
+			t.Errorf("truncated to %d bytes: expected ErrMalformedDelta, got %v", n, err)
+		}
+	}
+	if _, err := DecodeBookDelta(append(golden[:len(golden):len(golden)], 0)); !errors.Is(err, ErrMalformedDelta) {
+		t.Errorf("trailing bytes: expected ErrMalformedDelta, got %v", err)
+	}
+	bad := append([]byte{2}, golden[1:]...)
+	if _, err := DecodeBookDelta(bad); !errors.Is(err, ErrMalformedDelta) {
+		t.Errorf("unknown version: expected ErrMalformedDelta, got %v", err)
+	}
+	huge := []byte{1, 0, 4, 6, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0x0f}
+	if _, err := DecodeBookDelta(huge); !errors.Is(err, ErrMalformedDelta) {
+		t.Errorf("oversized level count: expected ErrMalformedDelta, got %v", err)
+	}
+}
+
+func TestPriceChangeDeltas(t *testing.T) {
+	deltas, err := PriceChangeDeltas(&PriceChangeMessage{
+		Timestamp: "1700000000500",
+		PriceChanges: []PriceChange{
+			{AssetID: "2", Price: "0.6", Size: "10", Side: SideSell, Hash: "a1"},
+			{AssetID: "1", Price: "0.4", Size: "0", Side: SideBuy, Hash: "b1"},
+			{AssetID: "2", Price: "0.59", Size: "5", Side: SideSell, Hash: "a2"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("PriceChangeDeltas() error: %v", err)
+	}
+	if len(deltas) != 2 || deltas[0].TokenID != "2" || deltas[1].TokenID != "1" {
+		t.Fatalf("unexpected deltas: %+v", deltas)
+	}
+	if len(deltas[0].Levels) != 2 || deltas[0].Hash != "a2" || deltas[0].Timestamp != 1700000000500 || deltas[0].Snapshot {
+		t.Errorf("unexpected merged delta: %+v", deltas[0])
+	}
+
+	if _, err := PriceChangeDeltas(&PriceChangeMessage{Timestamp: "x"}); !errors.Is(err, ErrMalformedDelta) {
+		t.Errorf("expected ErrMalformedDelta for bad timestamp, got %v", err)
+	}
+	bad := &PriceChangeMessage{Timestamp: "1", PriceChanges: []PriceChange{{AssetID: "1", Price: "abc", Size: "1", Side: SideBuy}}}
+	if _, err := PriceChangeDeltas(bad); !errors.Is(err, common.ErrInvalidPrice) {
+		t.Errorf("expected ErrInvalidPrice, got %v", err)
+	}
+}
+
+func BenchmarkDeltaEncoder_Encode(b *testing.B) {
+	enc, _ := NewDeltaEncoder(nil)
+	delta := deltaGoldens[0].delta
+	buf := make([]byte, 0, 256)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = enc.Encode(buf[:0], delta)
+	}
+}
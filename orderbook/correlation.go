@@ -0,0 +1,232 @@
+package orderbook
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// CorrelationConfig 滚动相关性配置
+type CorrelationConfig struct {
+	Window         int           // 滚动窗口样本数
+	SampleInterval time.Duration // 最小采样间隔，两个 token 的更新都会触发采样
+}
+
+// DefaultCorrelationConfig 默认配置
+func DefaultCorrelationConfig() *CorrelationConfig {
+	return &CorrelationConfig{
+		Window:         300,
+		SampleInterval: time.Second,
+	}
+}
+
+// CorrelationStats 两个 token 的滚动统计
+type CorrelationStats struct {
+	TokenA, TokenB   string
+	Samples          int       // 窗口内样本数
+	Correlation      float64   // 中间价变动的 Pearson 相关系数
+	LevelCorrelation float64   // 中间价水平的 Pearson 相关系数
+	HedgeRatio       float64   // A 对 B 的回归系数（A ≈ HedgeRatio * B + c），用于配对交易
+	Spread           float64   // 最新的 A - HedgeRatio * B
+	Ready            bool      // 样本数达到窗口大小
+	LastSample       time.Time // 最近一次采样时间
+}
+
+// CorrelationTracker 在订单簿更新流上采样两个 token 的中间价并计算滚动相关性
+type CorrelationTracker struct {
+	mu     sync.Mutex
+	books  BookReader
+	config *CorrelationConfig
+
+	tokenA, tokenB string
+	a, b           []float64 // 中间价序列（滑动窗口，保留 Window+1 个以计算 Window 个变动）
+	lastSample     time.Time
+
+	now func() time.Time
+}
+
+// NewCorrelationTracker 创建滚动相关性跟踪器，两个 token 需已订阅
+func NewCorrelationTracker(books BookReader, tokenA, tokenB string, config *CorrelationConfig) *CorrelationTracker {
+	if config == nil {
+		config = DefaultCorrelationConfig()
+	}
+	if config.Window < 2 {
+		config.Window = 2
+	}
+
+	return &CorrelationTracker{
+		books:  books,
+		config: config,
+		tokenA: tokenA,
+		tokenB: tokenB,
+		now:    time.Now,
+	}
+}
+
+// Run 持续消费更新流并采样，直到 ctx 取消或 updates 关闭
+func (c *CorrelationTracker) Run(ctx context.Context, updates <-chan OrderBookUpdate) {
+	common.Supervise(ctx, "orderbook.correlation", nil, func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				c.Process(update)
+			}
+		}
+	})
+}
+
+// Process 处理一条更新，属于跟踪的 token 且距上次采样超过 SampleInterval 时采样，返回是否采样
+func (c *CorrelationTracker) Process(update OrderBookUpdate) bool {
+	if update.TokenID != c.tokenA && update.TokenID != c.tokenB {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !c.lastSample.IsZero() && now.Sub(c.lastSample) < c.config.SampleInterval {
+		return false
+	}
+
+	midA, ok := c.mid(c.tokenA)
+	if !ok {
+		return false
+	}
+	midB, ok := c.mid(c.tokenB)
+	if !ok {
+		return false
+	}
+
+	c.a = append(c.a, midA)
+	c.b = append(c.b, midB)
+	if over := len(c.a) - (c.config.Window + 1); over > 0 {
+		c.a = c.a[over:]
+		c.b = c.b[over:]
+	}
+	c.lastSample = now
+	return true
+}
+
+// mid 读取中间价，单边或无行情时返回 false
+func (c *CorrelationTracker) mid(tokenID string) (float64, bool) {
+	bbo, err := c.books.GetBBO(tokenID)
+	if err != nil || bbo == nil || bbo.BestBid == nil || bbo.BestAsk == nil {
+		return 0, false
+	}
+	mid, _ := bbo.BestBid.Price.Add(bbo.BestAsk.Price).Div(decimal.NewFromInt(2)).Float64()
+	return mid, true
+}
+
+// Stats 计算当前窗口的统计值，样本不足时相关系数为 NaN
+func (c *CorrelationTracker) Stats() CorrelationStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := CorrelationStats{
+		TokenA:           c.tokenA,
+		TokenB:           c.tokenB,
+		Correlation:      math.NaN(),
+		LevelCorrelation: math.NaN(),
+		HedgeRatio:       math.NaN(),
+		Spread:           math.NaN(),
+		LastSample:       c.lastSample,
+	}
+
+	n := len(c.a)
+	if n == 0 {
+		return stats
+	}
+
+	// 变动序列
+	da := make([]float64, 0, n-1)
+	db := make([]float64, 0, n-1)
+	for i := 1; i < n; i++ {
+		da = append(da, c.a[i]-c.a[i-1])
+		db = append(db, c.b[i]-c.b[i-1])
+	}
+	stats.Samples = len(da)
+	stats.Ready = stats.Samples >= c.config.Window
+
+	stats.Correlation = pearson(da, db)
+
+	// 水平相关与回归只使用窗口内的后 Window 个价格
+	levelsA, levelsB := c.a, c.b
+	if len(levelsA) > c.config.Window {
+		levelsA, levelsB = levelsA[1:], levelsB[1:]
+	}
+	stats.LevelCorrelation = pearson(levelsA, levelsB)
+	if beta, ok := regressionSlope(levelsA, levelsB); ok {
+		stats.HedgeRatio = beta
+		stats.Spread = c.a[n-1] - beta*c.b[n-1]
+	}
+	return stats
+}
+
+// Reset 清空样本
+func (c *CorrelationTracker) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.a, c.b = nil, nil
+	c.lastSample = time.Time{}
+}
+
+// pearson Pearson 相关系数，样本少于 2 个或任一序列方差为 0 时返回 NaN
+func pearson(x, y []float64) float64 {
+	n := len(x)
+	if n < 2 || len(y) != n {
+		return math.NaN()
+	}
+
+	meanX, meanY := mean(x), mean(y)
+	var cov, varX, varY float64
+	for i := 0; i < n; i++ {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return math.NaN()
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+// regressionSlope y 对 x 的最小二乘斜率（此处 y 为 A，x 为 B）
+func regressionSlope(y, x []float64) (float64, bool) {
+	n := len(x)
+	if n < 2 || len(y) != n {
+		return 0, false
+	}
+
+	meanX, meanY := mean(x), mean(y)
+	var cov, varX float64
+	for i := 0; i < n; i++ {
+		dx := x[i] - meanX
+		cov += dx * (y[i] - meanY)
+		varX += dx * dx
+	}
+	if varX == 0 {
+		return 0, false
+	}
+	return cov / varX, true
+}
+
+// mean 算术平均
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,83 @@
+package orderbook
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// midBooks is a BookReader with a settable mid per token
+type midBooks map[string]float64
+
+func (m midBooks) GetBBO(tokenID string) (*BBO, error) {
+	mid, ok := m[tokenID]
+	if !ok {
+		return nil, ErrNotInitialized
+	}
+	return &BBO{
+		BestBid: &BestPrice{Price: decimal.NewFromFloat(mid - 0.005)},
+		BestAsk: &BestPrice{Price: decimal.NewFromFloat(mid + 0.005)},
+	}, nil
+}
+
+func (m midBooks) GetDepth(tokenID string, depth int) ([]OrderSummary, []OrderSummary, error) {
+	return nil, nil, nil
+}
+
+func TestCorrelationTracker(t *testing.T) {
+	books := midBooks{}
+	tracker := NewCorrelationTracker(books, "a", "b", &CorrelationConfig{Window: 5, SampleInterval: time.Second})
+	now := time.Unix(1000, 0)
+	tracker.now = func() time.Time { return now }
+
+	if tracker.Process(OrderBookUpdate{TokenID: "a"}) {
+		t.Error("should not sample without books")
+	}
+	if !math.IsNaN(tracker.Stats().Correlation) {
+		t.Error("correlation should be NaN without samples")
+	}
+
+	// b moves exactly half as much as a in the opposite direction: corr = -1, hedge ratio = -2
+	for _, mid := range []float64{0.50, 0.52, 0.51, 0.55, 0.54, 0.58, 0.60} {
+		books["a"], books["b"] = mid, 0.5-(mid-0.5)/2
+		if !tracker.Process(OrderBookUpdate{TokenID: "b"}) {
+			t.Fatalf("expected sample at mid %.2f", mid)
+		}
+		// Within the sample interval updates are ignored
+		if tracker.Process(OrderBookUpdate{TokenID: "a"}) {
+			t.Error("sampled within interval")
+		}
+		now = now.Add(time.Second)
+	}
+
+	if tracker.Process(OrderBookUpdate{TokenID: "other"}) {
+		t.Error("untracked token should be ignored")
+	}
+
+	stats := tracker.Stats()
+	if stats.Samples != 5 || !stats.Ready {
+		t.Errorf("samples = %d, ready = %v", stats.Samples, stats.Ready)
+	}
+	if math.Abs(stats.Correlation+1) > 1e-9 || math.Abs(stats.LevelCorrelation+1) > 1e-9 {
+		t.Errorf("correlation = %v, level = %v, expected -1", stats.Correlation, stats.LevelCorrelation)
+	}
+	if math.Abs(stats.HedgeRatio+2) > 1e-9 || math.Abs(stats.Spread-1.5) > 1e-9 {
+		t.Errorf("hedge ratio = %v, spread = %v", stats.HedgeRatio, stats.Spread)
+	}
+
+	tracker.Reset()
+	if tracker.Stats().Samples != 0 {
+		t.Error("Reset should clear samples")
+	}
+}
+
+func TestPearson(t *testing.T) {
+	if got := pearson([]float64{1, 2, 3}, []float64{2, 4, 6}); math.Abs(got-1) > 1e-12 {
+		t.Errorf("pearson = %v, expected 1", got)
+	}
+	if got := pearson([]float64{1, 1, 1}, []float64{1, 2, 3}); !math.IsNaN(got) {
+		t.Errorf("pearson with zero variance = %v, expected NaN", got)
+	}
+}
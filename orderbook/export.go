@@ -0,0 +1,240 @@
+package orderbook
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// ExchangeName 导出数据中的交易所标识
+const ExchangeName = "polymarket"
+
+// L2Snapshot 某一时刻的 L2 订单簿快照
+type L2Snapshot struct {
+	TokenID           string
+	Market            string
+	Hash              string
+	ExchangeTimestamp int64          // 交易所消息时间戳（毫秒）
+	LocalTimestamp    time.Time      // 本地应用该消息的时间
+	Sequence          uint64         // 本地序号，见 OrderBook.Sequence
+	SDKVersion        string         // 生成快照的 SDK 版本，见 common.SDKVersion
+	Bids              []OrderSummary // 价格降序
+	Asks              []OrderSummary // 价格升序
+}
+
+// Snapshot 在同一把锁下复制订单簿，depth <= 0 时包含全部档位，未初始化时返回 nil
+func (ob *OrderBook) Snapshot(depth int) *L2Snapshot {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if !ob.initialized {
+		return nil
+	}
+
+	ob.rebuildSortedBids()
+	ob.rebuildSortedAsks()
+
+	return &L2Snapshot{
+		TokenID:           ob.tokenID,
+		Market:            ob.market,
+		Hash:              ob.hash,
+		ExchangeTimestamp: ob.timestamp,
+		LocalTimestamp:    ob.receivedAt,
+		Sequence:          ob.sequence,
+		SDKVersion:        common.SDKVersion(),
+		Bids:              copyLevels(ob.sortedBids, depth),
+		Asks:              copyLevels(ob.sortedAsks, depth),
+	}
+}
+
+// copyLevels 复制前 depth 档，depth <= 0 时复制全部
+func copyLevels(levels []OrderSummary, depth int) []OrderSummary {
+	n := len(levels)
+	if depth > 0 && depth < n {
+		n = depth
+	}
+	out := make([]OrderSummary, n)
+	copy(out, levels[:n])
+	return out
+}
+
+// SnapshotFormat 快照导出格式
+type SnapshotFormat string
+
+const (
+	// SnapshotFormatCSV 宽表 CSV，列与 Tardis book_snapshot_N 一致：
+	// exchange,symbol,timestamp,local_timestamp,asks[0].price,asks[0].amount,bids[0].price,bids[0].amount,...
+	// timestamp / local_timestamp 为微秒级 Unix 时间，档位不足时留空
+	SnapshotFormatCSV SnapshotFormat = "csv"
+
+	// SnapshotFormatJSONL 每行一个 JSON 对象（JSON Lines），字段见 L2SnapshotRecord
+	SnapshotFormatJSONL SnapshotFormat = "jsonl"
+)
+
+// L2SnapshotRecord JSON 导出格式
+// 价格和数量以字符串保存以避免精度损失，档位为 [price, amount] 数组，时间戳为微秒级 Unix 时间；
+// hash / timestamp / local_seq / sdk_version 为溯源水印，下游可校验数据来源并发现混用不同 SDK 版本的管线
+type L2SnapshotRecord struct {
+	Exchange       string      `json:"exchange"`
+	Symbol         string      `json:"symbol"` // token ID
+	Market         string      `json:"market"` // condition ID
+	Hash           string      `json:"hash"`
+	Timestamp      int64       `json:"timestamp"`       // 交易所时间戳（微秒）
+	LocalTimestamp int64       `json:"local_timestamp"` // 本地接收时间（微秒）
+	Sequence       uint64      `json:"local_seq"`       // 本地序号
+	SDKVersion     string      `json:"sdk_version"`
+	Bids           [][2]string `json:"bids"`
+	Asks           [][2]string `json:"asks"`
+}
+
+// Record 转换为 JSON 导出格式
+func (s *L2Snapshot) Record() L2SnapshotRecord {
+	return L2SnapshotRecord{
+		Exchange:       ExchangeName,
+		Symbol:         s.TokenID,
+		Market:         s.Market,
+		Hash:           s.Hash,
+		Timestamp:      s.ExchangeTimestamp * 1000,
+		LocalTimestamp: localMicros(s.LocalTimestamp),
+		Sequence:       s.Sequence,
+		SDKVersion:     s.SDKVersion,
+		Bids:           levelPairs(s.Bids),
+		Asks:           levelPairs(s.Asks),
+	}
+}
+
+// levelPairs 档位转为 [price, amount] 字符串对
+func levelPairs(levels []OrderSummary) [][2]string {
+	pairs := make([][2]string, len(levels))
+	for i, level := range levels {
+		pairs[i] = [2]string{level.Price.String(), level.Size.String()}
+	}
+	return pairs
+}
+
+// localMicros 本地时间转微秒，零值输出 0
+func localMicros(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMicro()
+}
+
+// SnapshotWriter 将快照按指定格式写入 io.Writer，便于直接接入已有研究数据管线
+// 非并发安全
+type SnapshotWriter struct {
+	depth     int
+	watermark bool
+
+	csv         *csv.Writer
+	json        *json.Encoder
+	wroteHeader bool
+}
+
+// NewSnapshotWriter 创建快照导出器
+// depth 为每侧导出的档位数：CSV 为固定列宽，必须大于 0；JSONL 中 depth <= 0 表示导出全部档位
+func NewSnapshotWriter(w io.Writer, format SnapshotFormat, depth int) (*SnapshotWriter, error) {
+	sw := &SnapshotWriter{depth: depth}
+	switch format {
+	case SnapshotFormatCSV:
+		if depth <= 0 {
+			return nil, fmt.Errorf("%w: csv export requires depth > 0", common.ErrInvalidConfig)
+		}
+		sw.csv = csv.NewWriter(w)
+	case SnapshotFormatJSONL:
+		sw.json = json.NewEncoder(w)
+	default:
+		return nil, fmt.Errorf("%w: unsupported snapshot format %q", common.ErrInvalidConfig, format)
+	}
+	return sw, nil
+}
+
+// SetWatermark CSV 是否在档位列之后追加 hash,local_seq,sdk_version 水印列，需在首次 Write 前调用
+// 默认关闭以保持与 Tardis book_snapshot_N 列完全一致；JSONL 始终包含水印字段
+func (w *SnapshotWriter) SetWatermark(enabled bool) {
+	w.watermark = enabled
+}
+
+// Write 写入一条快照（超过 depth 的档位被截断）
+func (w *SnapshotWriter) Write(snap *L2Snapshot) error {
+	if snap == nil {
+		return fmt.Errorf("snapshot is nil")
+	}
+
+	if w.json != nil {
+		record := snap.Record()
+		if w.depth > 0 {
+			record.Bids = record.Bids[:min(len(record.Bids), w.depth)]
+			record.Asks = record.Asks[:min(len(record.Asks), w.depth)]
+		}
+		if err := w.json.Encode(record); err != nil {
+			return fmt.Errorf("failed to write snapshot: %w", err)
+		}
+		return nil
+	}
+
+	if !w.wroteHeader {
+		if err := w.csv.Write(w.csvHeader()); err != nil {
+			return fmt.Errorf("failed to write csv header: %w", err)
+		}
+		w.wroteHeader = true
+	}
+	if err := w.csv.Write(w.csvRow(snap)); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Flush 刷新缓冲区（CSV 格式需在结束时调用）
+func (w *SnapshotWriter) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// csvHeader Tardis book_snapshot_N 列名
+func (w *SnapshotWriter) csvHeader() []string {
+	header := []string{"exchange", "symbol", "timestamp", "local_timestamp"}
+	for i := 0; i < w.depth; i++ {
+		header = append(header,
+			fmt.Sprintf("asks[%d].price", i), fmt.Sprintf("asks[%d].amount", i),
+			fmt.Sprintf("bids[%d].price", i), fmt.Sprintf("bids[%d].amount", i))
+	}
+	if w.watermark {
+		header = append(header, "hash", "local_seq", "sdk_version")
+	}
+	return header
+}
+
+// csvRow 快照转为一行，档位不足时留空
+func (w *SnapshotWriter) csvRow(snap *L2Snapshot) []string {
+	row := []string{
+		ExchangeName,
+		snap.TokenID,
+		strconv.FormatInt(snap.ExchangeTimestamp*1000, 10),
+		strconv.FormatInt(localMicros(snap.LocalTimestamp), 10),
+	}
+	for i := 0; i < w.depth; i++ {
+		row = append(row, levelCells(snap.Asks, i)...)
+		row = append(row, levelCells(snap.Bids, i)...)
+	}
+	if w.watermark {
+		row = append(row, snap.Hash, strconv.FormatUint(snap.Sequence, 10), snap.SDKVersion)
+	}
+	return row
+}
+
+// levelCells 第 i 档的价格与数量，不存在时为空
+func levelCells(levels []OrderSummary, i int) []string {
+	if i >= len(levels) {
+		return []string{"", ""}
+	}
+	return []string{levels[i].Price.String(), levels[i].Size.String()}
+}
@@ -0,0 +1,152 @@
+package orderbook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func newExportBook(t *testing.T) *OrderBook {
+	t.Helper()
+	ob := NewOrderBook("tok")
+	ok := ob.ApplyBookSnapshot(&BookMessage{
+		AssetID: "tok",
+		Market:  "0xmarket",
+		Hash:    "h1",
+		Bids:    []RawOrderSummary{{Price: "0.48", Size: "10"}, {Price: "0.49", Size: "20"}},
+		Asks:    []RawOrderSummary{{Price: "0.51", Size: "30"}},
+	}, 1700000000123)
+	if !ok {
+		t.Fatal("failed to apply snapshot")
+	}
+	return ob
+}
+
+func TestOrderBook_Snapshot(t *testing.T) {
+	if NewOrderBook("tok").Snapshot(0) != nil {
+		t.Error("uninitialized book should return nil snapshot")
+	}
+
+	before := time.Now()
+	snap := newExportBook(t).Snapshot(1)
+	if snap.ExchangeTimestamp != 1700000000123 || snap.LocalTimestamp.Before(before) {
+		t.Errorf("timestamps = %d, %v", snap.ExchangeTimestamp, snap.LocalTimestamp)
+	}
+	if len(snap.Bids) != 1 || snap.Bids[0].Price.String() != "0.49" || len(snap.Asks) != 1 {
+		t.Errorf("unexpected levels: %+v", snap)
+	}
+}
+
+func TestSnapshotWriter_CSV(t *testing.T) {
+	snap := newExportBook(t).Snapshot(0)
+	snap.LocalTimestamp = time.UnixMicro(1700000000200500)
+
+	var buf bytes.Buffer
+	w, err := NewSnapshotWriter(&buf, SnapshotFormatCSV, 2)
+	if err != nil {
+		t.Fatalf("NewSnapshotWriter() error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := w.Write(snap); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d lines", len(lines))
+	}
+	wantHeader := "exchange,symbol,timestamp,local_timestamp,asks[0].price,asks[0].amount,bids[0].price,bids[0].amount," +
+		"asks[1].price,asks[1].amount,bids[1].price,bids[1].amount"
+	if lines[0] != wantHeader {
+		t.Errorf("header = %s", lines[0])
+	}
+	if want := "polymarket,tok,1700000000123000,1700000000200500,0.51,30,0.49,20,,,0.48,10"; lines[1] != want {
+		t.Errorf("row = %s, expected %s", lines[1], want)
+	}
+}
+
+func TestSnapshotWriter_JSONL(t *testing.T) {
+	snap := newExportBook(t).Snapshot(0)
+
+	var buf bytes.Buffer
+	w, err := NewSnapshotWriter(&buf, SnapshotFormatJSONL, 1)
+	if err != nil {
+		t.Fatalf("NewSnapshotWriter() error: %v", err)
+	}
+	if err := w.Write(snap); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	var record L2SnapshotRecord
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if record.Exchange != "polymarket" || record.Symbol != "tok" || record.Market != "0xmarket" || record.Timestamp != 1700000000123000 {
+		t.Errorf("unexpected record: %+v", record)
+	}
+	if len(record.Bids) != 1 || record.Bids[0] != [2]string{"0.49", "20"} || record.LocalTimestamp == 0 {
+		t.Errorf("unexpected levels: %+v", record)
+	}
+}
+
+func TestSnapshotWriter_Watermark(t *testing.T) {
+	ob := newExportBook(t)
+	ob.ApplyPriceChange(&PriceChange{Price: "0.50", Size: "5", Side: SideBuy, Hash: "h2"}, 1700000000124)
+	ob.Reset()
+	ob.ApplyBookSnapshot(&BookMessage{AssetID: "tok", Hash: "h3", Bids: []RawOrderSummary{{Price: "0.4", Size: "1"}}}, 1700000000125)
+
+	snap := ob.Snapshot(0)
+	// the sequence keeps counting across Reset so downstream can order records from one process
+	if snap.Sequence != 3 || ob.Sequence() != 3 || snap.Hash != "h3" {
+		t.Fatalf("sequence = %d, hash = %s", snap.Sequence, snap.Hash)
+	}
+	if snap.SDKVersion != common.SDKVersion() || snap.SDKVersion == "" {
+		t.Errorf("sdk version = %q", snap.SDKVersion)
+	}
+
+	var jsonl bytes.Buffer
+	jw, _ := NewSnapshotWriter(&jsonl, SnapshotFormatJSONL, 0)
+	if err := jw.Write(snap); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	var record map[string]any
+	if err := json.Unmarshal(jsonl.Bytes(), &record); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if record["hash"] != "h3" || record["local_seq"] != float64(3) || record["sdk_version"] != snap.SDKVersion || record["timestamp"] != float64(1700000000125000) {
+		t.Errorf("unexpected watermark: %v", record)
+	}
+
+	var csvBuf bytes.Buffer
+	cw, _ := NewSnapshotWriter(&csvBuf, SnapshotFormatCSV, 1)
+	cw.SetWatermark(true)
+	if err := cw.Write(snap); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	cw.Flush()
+	lines := strings.Split(strings.TrimSpace(csvBuf.String()), "\n")
+	if !strings.HasSuffix(lines[0], ",hash,local_seq,sdk_version") {
+		t.Errorf("header = %s", lines[0])
+	}
+	if want := ",0.4,1,h3,3," + snap.SDKVersion; !strings.HasSuffix(lines[1], want) {
+		t.Errorf("row = %s, expected suffix %s", lines[1], want)
+	}
+}
+
+func TestNewSnapshotWriter_Invalid(t *testing.T) {
+	if _, err := NewSnapshotWriter(&bytes.Buffer{}, SnapshotFormatCSV, 0); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig for zero csv depth, got %v", err)
+	}
+	if _, err := NewSnapshotWriter(&bytes.Buffer{}, "parquet", 5); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig for unknown format, got %v", err)
+	}
+}
@@ -0,0 +1,327 @@
+package orderbook
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FeedLatencyPercentile 行情延迟告警检查的百分位
+type FeedLatencyPercentile string
+
+const (
+	FeedLatencyP50 FeedLatencyPercentile = "p50"
+	FeedLatencyP99 FeedLatencyPercentile = "p99"
+)
+
+// feedLatencyPercentiles 评估告警的固定顺序
+var feedLatencyPercentiles = []FeedLatencyPercentile{FeedLatencyP50, FeedLatencyP99}
+
+// FeedLatencyConfig 行情延迟统计配置
+// 延迟为本地收到帧的时间减去交易所消息时间戳，两端时钟不同源，绝对值包含时钟偏差，阈值应按部署环境的基线设置
+type FeedLatencyConfig struct {
+	Window           int             // 每个 token 保留的最近样本数，百分位按窗口计算
+	MinSamples       int             // 窗口样本数达到该值后才评估告警，避免订阅初期少量样本误报
+	WarnP50          time.Duration   // P50 超过该值时告警，0 表示不检查
+	WarnP99          time.Duration   // P99 超过该值时告警，0 表示不检查
+	EvaluateInterval time.Duration   // 每个 token 评估告警的最小间隔，评估在记录样本时进行
+	Buckets          []time.Duration // 直方图各桶的上界（升序），超过最大上界的样本计入最后的溢出桶
+	ChannelSize      int             // 告警 channel 缓冲区大小，满时丢弃新告警
+}
+
+// DefaultFeedLatencyConfig 默认配置
+func DefaultFeedLatencyConfig() *FeedLatencyConfig {
+	return &FeedLatencyConfig{
+		Window:           1000,
+		MinSamples:       20,
+		WarnP50:          500 * time.Millisecond,
+		WarnP99:          2 * time.Second,
+		EvaluateInterval: time.Second,
+		Buckets: []time.Duration{
+			10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond,
+			100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+			time.Second, 2500 * time.Millisecond, 5 * time.Second,
+		},
+		ChannelSize: 100,
+	}
+}
+
+// FeedLatencyBucket 直方图桶，Count 为延迟不超过 UpperBound（且超过上一个桶上界）的累计样本数
+// 溢出桶的 UpperBound 为 math.MaxInt64
+type FeedLatencyBucket struct {
+	UpperBound time.Duration
+	Count      int64
+}
+
+// FeedLatencyStats 单个 token 的行情延迟统计
+type FeedLatencyStats struct {
+	TokenID        string
+	Samples        int64         // 累计样本数
+	Last           time.Duration // 最近一条消息的延迟
+	LastReceivedAt time.Time     // 最近一条消息的本地接收时间
+	// Latency 窗口内最近 Window 个样本的分布
+	Latency LatencyDistribution
+	// Histogram 开始统计以来的全部样本
+	Histogram []FeedLatencyBucket
+	// Degraded 当前超过阈值的百分位
+	Degraded []FeedLatencyPercentile
+}
+
+// FeedLatencyWarning 行情延迟告警，百分位从达标变为超阈值时发送一次，恢复时再发送一次 Resolved 事件
+type FeedLatencyWarning struct {
+	TokenID    string
+	Percentile FeedLatencyPercentile
+	Value      time.Duration
+	Threshold  time.Duration
+	Resolved   bool      // true 表示恢复（token 被移除时同样发送）
+	Since      time.Time // 本次超阈值开始的时间
+	Time       time.Time
+}
+
+// feedLatencyState 单个 token 的统计状态
+type feedLatencyState struct {
+	samples        []time.Duration
+	total          int64
+	last           time.Duration
+	lastReceivedAt time.Time
+	buckets        []int64 // 与 Buckets 对应，最后一个为溢出桶
+	evaluatedAt    time.Time
+	active         map[FeedLatencyPercentile]time.Time // 超阈值中的百分位 -> 开始时间
+}
+
+// FeedLatencyMonitor 按 token 统计交易所时间戳到本地接收的行情延迟，提供百分位与直方图，
+// 延迟恶化超过阈值时发出告警；Config.FeedLatency 非 nil 时由 Manager 记录每条已应用的消息
+type FeedLatencyMonitor struct {
+	mu          sync.Mutex
+	config      *FeedLatencyConfig
+	states      map[string]*feedLatencyState
+	warningChan chan FeedLatencyWarning
+	now         func() time.Time
+}
+
+// NewFeedLatencyMonitor 创建行情延迟统计
+func NewFeedLatencyMonitor(config *FeedLatencyConfig) *FeedLatencyMonitor {
+	if config == nil {
+		config = DefaultFeedLatencyConfig()
+	}
+	if config.Window <= 0 {
+		config.Window = DefaultFeedLatencyConfig().Window
+	}
+	if !sort.SliceIsSorted(config.Buckets, func(i, j int) bool { return config.Buckets[i] < config.Buckets[j] }) {
+		buckets := append([]time.Duration(nil), config.Buckets...)
+		sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+		config.Buckets = buckets
+	}
+
+	return &FeedLatencyMonitor{
+		config:      config,
+		states:      make(map[string]*feedLatencyState),
+		warningChan: make(chan FeedLatencyWarning, config.ChannelSize),
+		now:         time.Now,
+	}
+}
+
+// Warnings 获取告警 channel
+func (f *FeedLatencyMonitor) Warnings() <-chan FeedLatencyWarning {
+	return f.warningChan
+}
+
+// Process 记录一条更新的延迟，未接入 Manager 时可在自己的更新循环中调用
+// 只统计行情类事件，时间戳缺失的更新被忽略；EventTypeRemoved 清除该 token 的统计
+func (f *FeedLatencyMonitor) Process(update OrderBookUpdate) {
+	switch update.EventType {
+	case EventTypeBook, EventTypePriceChange, EventTypeTickSizeChange, EventTypeLastTradePrice:
+		f.Record(update.TokenID, update.Timestamp, update.ReceivedAt)
+	case EventTypeRemoved:
+		f.Remove(update.TokenID)
+	}
+}
+
+// Record 记录一条消息：timestamp 为交易所时间戳（毫秒），receivedAt 为本地接收时间，任一缺失时忽略
+func (f *FeedLatencyMonitor) Record(tokenID string, timestamp int64, receivedAt time.Time) {
+	if tokenID == "" || timestamp == 0 || receivedAt.IsZero() {
+		return
+	}
+	delay := receivedAt.Sub(TimeFromMillis(timestamp))
+
+	f.mu.Lock()
+	st := f.states[tokenID]
+	if st == nil {
+		st = &feedLatencyState{
+			buckets: make([]int64, len(f.config.Buckets)+1),
+			active:  make(map[FeedLatencyPercentile]time.Time),
+		}
+		f.states[tokenID] = st
+	}
+	st.samples = appendWindow(st.samples, delay, f.config.Window)
+	st.total++
+	st.last = delay
+	st.lastReceivedAt = receivedAt
+	st.buckets[sort.Search(len(f.config.Buckets), func(i int) bool { return delay <= f.config.Buckets[i] })]++
+
+	var events []FeedLatencyWarning
+	if now := f.now(); now.Sub(st.evaluatedAt) >= f.config.EvaluateInterval {
+		st.evaluatedAt = now
+		events = f.evaluateLocked(tokenID, st, latencyDistribution(st.samples), now)
+	}
+	f.mu.Unlock()
+
+	f.deliver(events)
+}
+
+// Evaluate 立即评估全部 token 并投递状态变化事件，返回本次产生的事件
+func (f *FeedLatencyMonitor) Evaluate() []FeedLatencyWarning {
+	f.mu.Lock()
+	now := f.now()
+	var events []FeedLatencyWarning
+	for tokenID, st := range f.states {
+		st.evaluatedAt = now
+		e := f.evaluateLocked(tokenID, st, latencyDistribution(st.samples), now)
+		events = append(events, e...)
+	}
+	f.mu.Unlock()
+
+	f.deliver(events)
+	return events
+}
+
+// Stats 返回全部 token 的统计（按 token 排序）
+func (f *FeedLatencyMonitor) Stats() []FeedLatencyStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stats := make([]FeedLatencyStats, 0, len(f.states))
+	for tokenID, st := range f.states {
+		stats = append(stats, f.statsLocked(tokenID, st))
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].TokenID < stats[j].TokenID })
+	return stats
+}
+
+// TokenStats 返回单个 token 的统计，尚无样本时返回 false
+func (f *FeedLatencyMonitor) TokenStats(tokenID string) (FeedLatencyStats, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	st, ok := f.states[tokenID]
+	if !ok {
+		return FeedLatencyStats{}, false
+	}
+	return f.statsLocked(tokenID, st), true
+}
+
+// Remove 清除 token 的统计，超阈值中的百分位发送恢复事件
+func (f *FeedLatencyMonitor) Remove(tokenID string) {
+	f.mu.Lock()
+	var events []FeedLatencyWarning
+	if st, ok := f.states[tokenID]; ok {
+		now := f.now()
+		for _, p := range feedLatencyPercentiles {
+			if since, active := st.active[p]; active {
+				events = append(events, FeedLatencyWarning{
+					TokenID:    tokenID,
+					Percentile: p,
+					Value:      st.last,
+					Threshold:  f.threshold(p),
+					Resolved:   true,
+					Since:      since,
+					Time:       now,
+				})
+			}
+		}
+		delete(f.states, tokenID)
+	}
+	f.mu.Unlock()
+
+	f.deliver(events)
+}
+
+// Reset 清空全部统计（不发送恢复事件）
+func (f *FeedLatencyMonitor) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.states = make(map[string]*feedLatencyState)
+}
+
+// statsLocked 生成 token 的统计（需持有 f.mu）
+func (f *FeedLatencyMonitor) statsLocked(tokenID string, st *feedLatencyState) FeedLatencyStats {
+	histogram := make([]FeedLatencyBucket, len(st.buckets))
+	for i, count := range st.buckets {
+		upper := time.Duration(math.MaxInt64)
+		if i < len(f.config.Buckets) {
+			upper = f.config.Buckets[i]
+		}
+		histogram[i] = FeedLatencyBucket{UpperBound: upper, Count: count}
+	}
+
+	stats := FeedLatencyStats{
+		TokenID:        tokenID,
+		Samples:        st.total,
+		Last:           st.last,
+		LastReceivedAt: st.lastReceivedAt,
+		Latency:        latencyDistribution(st.samples),
+		Histogram:      histogram,
+	}
+	for _, p := range feedLatencyPercentiles {
+		if _, active := st.active[p]; active {
+			stats.Degraded = append(stats.Degraded, p)
+		}
+	}
+	return stats
+}
+
+// evaluateLocked 按窗口分布更新 token 的告警状态，返回状态变化事件（需持有 f.mu）
+func (f *FeedLatencyMonitor) evaluateLocked(tokenID string, st *feedLatencyState, dist LatencyDistribution, now time.Time) []FeedLatencyWarning {
+	if dist.Count < f.config.MinSamples {
+		return nil
+	}
+
+	values := map[FeedLatencyPercentile]time.Duration{
+		FeedLatencyP50: dist.P50,
+		FeedLatencyP99: dist.P99,
+	}
+	var events []FeedLatencyWarning
+	for _, p := range feedLatencyPercentiles {
+		threshold := f.threshold(p)
+		breached := threshold > 0 && values[p] > threshold
+		since, active := st.active[p]
+		if breached == active {
+			continue
+		}
+		if breached {
+			since = now
+			st.active[p] = since
+		} else {
+			delete(st.active, p)
+		}
+		events = append(events, FeedLatencyWarning{
+			TokenID:    tokenID,
+			Percentile: p,
+			Value:      values[p],
+			Threshold:  threshold,
+			Resolved:   !breached,
+			Since:      since,
+			Time:       now,
+		})
+	}
+	return events
+}
+
+// threshold 百分位的告警阈值
+func (f *FeedLatencyMonitor) threshold(p FeedLatencyPercentile) time.Duration {
+	if p == FeedLatencyP50 {
+		return f.config.WarnP50
+	}
+	return f.config.WarnP99
+}
+
+// deliver 投递事件，channel 满时丢弃
+func (f *FeedLatencyMonitor) deliver(events []FeedLatencyWarning) {
+	for _, event := range events {
+		select {
+		case f.warningChan <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package orderbook
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func newTestFeedLatencyMonitor(config *FeedLatencyConfig) (*FeedLatencyMonitor, *fakeClock) {
+	clock := newFakeClock()
+	monitor := NewFeedLatencyMonitor(config)
+	monitor.now = clock.now
+	return monitor, clock
+}
+
+func TestFeedLatencyMonitor_Stats(t *testing.T) {
+	config := DefaultFeedLatencyConfig()
+	config.Window = 4
+	config.Buckets = []time.Duration{100 * time.Millisecond, 10 * time.Millisecond}
+	monitor, clock := newTestFeedLatencyMonitor(config)
+
+	// server timestamp 1000ms, received 5ms..400ms later
+	for _, delay := range []time.Duration{5, 50, 150, 200, 400} {
+		monitor.Record("token", 1000, time.UnixMilli(1000).Add(delay*time.Millisecond))
+	}
+	monitor.Record("token", 0, clock.now())            // missing timestamp is ignored
+	monitor.Process(OrderBookUpdate{TokenID: "other"}) // non-market events are ignored
+
+	stats := monitor.Stats()
+	if len(stats) != 1 || stats[0].TokenID != "token" {
+		t.Fatalf("Stats() = %+v", stats)
+	}
+	s := stats[0]
+	if s.Samples != 5 || s.Last != 400*time.Millisecond {
+		t.Errorf("samples %d last %s", s.Samples, s.Last)
+	}
+	// percentiles cover the window of the 4 most recent samples
+	if s.Latency.Count != 4 || s.Latency.Min != 50*time.Millisecond || s.Latency.P50 != 150*time.Millisecond || s.Latency.P99 != 400*time.Millisecond {
+		t.Errorf("unexpected distribution: %+v", s.Latency)
+	}
+	// histogram covers all samples; unsorted buckets are sorted
+	want := []FeedLatencyBucket{
+		{UpperBound: 10 * time.Millisecond, Count: 1},
+		{UpperBound: 100 * time.Millisecond, Count: 1},
+		{UpperBound: time.Duration(math.MaxInt64), Count: 3},
+	}
+	if len(s.Histogram) != len(want) {
+		t.Fatalf("histogram = %+v", s.Histogram)
+	}
+	for i := range want {
+		if s.Histogram[i] != want[i] {
+			t.Errorf("bucket %d = %+v, expected %+v", i, s.Histogram[i], want[i])
+		}
+	}
+
+	monitor.Process(OrderBookUpdate{TokenID: "token", EventType: EventTypeRemoved})
+	if _, ok := monitor.TokenStats("token"); ok {
+		t.Error("removed token should have no stats")
+	}
+}
+
+func TestFeedLatencyMonitor_Warnings(t *testing.T) {
+	config := DefaultFeedLatencyConfig()
+	config.Window = 10
+	config.MinSamples = 5
+	config.WarnP50 = 0
+	config.WarnP99 = time.Second
+	monitor, clock := newTestFeedLatencyMonitor(config)
+
+	record := func(delay time.Duration, n int) {
+		for i := 0; i < n; i++ {
+			received := clock.now()
+			monitor.Record("token", received.Add(-delay).UnixMilli(), received)
+		}
+	}
+
+	// too few samples to evaluate
+	record(3*time.Second, 4)
+	if events := monitor.Evaluate(); len(events) != 0 {
+		t.Fatalf("expected no warnings below MinSamples, got %+v", events)
+	}
+
+	record(3*time.Second, 1)
+	events := monitor.Evaluate()
+	if len(events) != 1 || events[0].Percentile != FeedLatencyP99 || events[0].Resolved || events[0].Threshold != time.Second {
+		t.Fatalf("expected p99 warning, got %+v", events)
+	}
+	if warning := <-monitor.Warnings(); warning.TokenID != "token" || warning.Value != 3*time.Second {
+		t.Errorf("unexpected delivered warning: %+v", warning)
+	}
+	if stats, _ := monitor.TokenStats("token"); len(stats.Degraded) != 1 || stats.Degraded[0] != FeedLatencyP99 {
+		t.Errorf("Degraded = %v", stats.Degraded)
+	}
+
+	// still degraded: no repeated warning, and Record is throttled by EvaluateInterval
+	record(3*time.Second, 1)
+	if events := monitor.Evaluate(); len(events) != 0 {
+		t.Errorf("expected no repeated warnings, got %+v", events)
+	}
+
+	// recovery is evaluated from Record once EvaluateInterval has passed
+	record(10*time.Millisecond, 10)
+	if len(monitor.Warnings()) != 0 {
+		t.Fatal("Record should not evaluate within EvaluateInterval")
+	}
+	clock.advance(time.Second)
+	record(10*time.Millisecond, 1)
+	select {
+	case warning := <-monitor.Warnings():
+		if !warning.Resolved || warning.Percentile != FeedLatencyP99 {
+			t.Errorf("expected resolved p99 warning, got %+v", warning)
+		}
+	default:
+		t.Fatal("expected resolved warning after recovery")
+	}
+
+	// removing a degraded token resolves its warnings
+	record(3*time.Second, 10)
+	monitor.Evaluate()
+	<-monitor.Warnings()
+	monitor.Remove("token")
+	if warning := <-monitor.Warnings(); !warning.Resolved {
+		t.Errorf("expected resolved warning on remove, got %+v", warning)
+	}
+}
+
+func TestManager_FeedLatency(t *testing.T) {
+	if NewManager(nil).FeedLatency() != nil {
+		t.Error("feed latency should be disabled by default")
+	}
+
+	config := DefaultConfig()
+	config.FeedLatency = DefaultFeedLatencyConfig()
+	m := NewManager(config)
+	m.orderBooks["token-1"] = NewOrderBook("token-1")
+
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"1000","bids":[{"price":"0.5","size":"10"}],"asks":[]}`), time.UnixMilli(1250))
+	// stale snapshots are not applied and not recorded
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"900","bids":[],"asks":[]}`), time.UnixMilli(1300))
+
+	stats, ok := m.FeedLatency().TokenStats("token-1")
+	if !ok || stats.Samples != 1 || stats.Last != 250*time.Millisecond {
+		t.Fatalf("TokenStats() = %+v, %v", stats, ok)
+	}
+
+	if err := m.Unsubscribe([]string{"token-1"}); err != nil {
+		t.Fatalf("Unsubscribe() error: %v", err)
+	}
+	if _, ok := m.FeedLatency().TokenStats("token-1"); ok {
+		t.Error("Unsubscribe should clear feed latency stats")
+	}
+}
@@ -0,0 +1,88 @@
+package orderbook
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// fixedDecimals 订单簿内部定点数的小数位数
+// 价格最小 tick 为 0.0001，数量最小单位为 0.000001（USDC 与条件代币均为 6 位精度），6 位可精确表示全部行情数据
+const fixedDecimals = 6
+
+// fixedPow10 10 的 0..fixedDecimals 次幂
+var fixedPow10 = [fixedDecimals + 1]int64{1, 10, 100, 1_000, 10_000, 100_000, 1_000_000}
+
+// fixed 订单簿内部存储使用的定点数（值 × 10^6）
+// 档位的写入、比较与排序都在 int64 上完成，只在对外返回 OrderSummary 等 API 边界转换为 decimal.Decimal
+type fixed int64
+
+// parseFixed 解析行情消息中的十进制字符串，常见格式（可带符号的整数或小数）不分配内存
+// 其他格式（指数形式、超过 6 位小数等）交给 decimal 解析，超过 6 位的小数四舍五入；无法解析或溢出时返回 false
+func parseFixed(s string) (fixed, bool) {
+	i, neg := 0, false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		i = 1
+	}
+
+	var v int64
+	digits, frac := 0, -1 // frac 为已读取的小数位数，-1 表示尚未遇到小数点
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			if frac == fixedDecimals || digits == 18 {
+				return parseFixedSlow(s)
+			}
+			if frac >= 0 {
+				frac++
+			}
+			v = v*10 + int64(c-'0')
+			digits++
+		case c == '.' && frac < 0:
+			frac = 0
+		default:
+			return parseFixedSlow(s)
+		}
+	}
+	if digits == 0 {
+		return 0, false
+	}
+	if frac < 0 {
+		frac = 0
+	}
+
+	scale := fixedPow10[fixedDecimals-frac]
+	if v > math.MaxInt64/scale {
+		return parseFixedSlow(s)
+	}
+	v *= scale
+	if neg {
+		v = -v
+	}
+	return fixed(v), true
+}
+
+// parseFixedSlow 经 decimal 解析
+func parseFixedSlow(s string) (fixed, bool) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return 0, false
+	}
+	return fixedFromDecimal(d)
+}
+
+// fixedFromDecimal decimal 转换为定点数，超过 6 位的小数四舍五入，超出 int64 范围时返回 false
+func fixedFromDecimal(d decimal.Decimal) (fixed, bool) {
+	scaled := d.Shift(fixedDecimals).Round(0)
+	if !scaled.BigInt().IsInt64() {
+		return 0, false
+	}
+	return fixed(scaled.IntPart()), true
+}
+
+// Decimal 转换为 decimal.Decimal
+func (f fixed) Decimal() decimal.Decimal {
+	return decimal.New(int64(f), -fixedDecimals)
+}
@@ -0,0 +1,85 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParseFixed(t *testing.T) {
+	tests := []struct {
+		input string
+		want  fixed
+		ok    bool
+	}{
+		{"0.5", 500_000, true},
+		{"0.50", 500_000, true},
+		{".5", 500_000, true},
+		{"0.001", 1_000, true},
+		{"0.0001", 100, true},
+		{"1234.567891", 1_234_567_891, true},
+		{"100", 100_000_000, true},
+		{"-0.25", -250_000, true},
+		{"+3", 3_000_000, true},
+		{"0", 0, true},
+		// slow path: more than 6 decimals is rounded, exponents are accepted
+		{"0.1234565", 123_457, true},
+		{"5e-1", 500_000, true},
+		// invalid or out of range
+		{"", 0, false},
+		{".", 0, false},
+		{"-", 0, false},
+		{"abc", 0, false},
+		{"0.5.1", 0, false},
+		{"99999999999999999999", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseFixed(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseFixed(%q) = %d, %v; want %d, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseFixed_MatchesDecimal(t *testing.T) {
+	for _, s := range []string{"0.999", "0.01", "12.34", "1000000", "0.000001", "9223372036854.775807", "123456789012.5"} {
+		f, ok := parseFixed(s)
+		if !ok {
+			t.Errorf("parseFixed(%q) failed", s)
+			continue
+		}
+		if want := decimal.RequireFromString(s); !f.Decimal().Equal(want) {
+			t.Errorf("parseFixed(%q).Decimal() = %s, want %s", s, f.Decimal(), want)
+		}
+		if f.Decimal().String() != decimal.RequireFromString(s).String() {
+			t.Errorf("canonical string of %q = %s", s, f.Decimal().String())
+		}
+	}
+}
+
+func TestOrderBook_FixedPointLevels(t *testing.T) {
+	ob := NewOrderBook("token")
+	ob.ApplyBookSnapshot(&BookMessage{
+		Bids: []RawOrderSummary{{Price: "0.48", Size: "10"}, {Price: "0.5", Size: "1.5"}, {Price: "0.50", Size: "2.25"}},
+		Asks: []RawOrderSummary{{Price: "0.52", Size: "7"}, {Price: "bad", Size: "1"}, {Price: "0.55", Size: "0"}},
+	}, 1)
+
+	// "0.5" and "0.50" are the same level; invalid and empty levels are skipped
+	bids := ob.GetAllBids()
+	if len(bids) != 2 || !bids[0].Price.Equal(decimal.RequireFromString("0.5")) || !bids[0].Size.Equal(decimal.RequireFromString("2.25")) {
+		t.Fatalf("bids = %v", bids)
+	}
+	if asks := ob.GetAllAsks(); len(asks) != 1 {
+		t.Fatalf("asks = %v", asks)
+	}
+	if total := ob.GetTotalBidSize(); !total.Equal(decimal.RequireFromString("12.25")) {
+		t.Errorf("GetTotalBidSize() = %s", total)
+	}
+
+	// removing the best bid rescans the remaining levels
+	ob.ApplyPriceChange(&PriceChange{Price: "0.500", Size: "0", Side: SideBuy}, 2)
+	if best := ob.GetBestBid(); best == nil || !best.Price.Equal(decimal.RequireFromString("0.48")) {
+		t.Errorf("best bid after removal = %+v", best)
+	}
+}
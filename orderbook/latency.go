@@ -0,0 +1,390 @@
+package orderbook
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// LatencyConfig 成交延迟测量配置
+type LatencyConfig struct {
+	Window  int           // 每个分布保留的最近样本数
+	Timeout time.Duration // 提交后超过该时长仍未匹配到成交推送的订单计为未匹配并丢弃
+	Depth   int           // 计算对手盘可成交深度时读取的档位数
+}
+
+// DefaultLatencyConfig 默认配置
+func DefaultLatencyConfig() *LatencyConfig {
+	return &LatencyConfig{
+		Window:  1000,
+		Timeout: 10 * time.Second,
+		Depth:   50,
+	}
+}
+
+// LatencyDistribution 延迟分布（基于窗口内最近的样本）
+type LatencyDistribution struct {
+	Count int
+	Min   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+}
+
+// SizeDistribution 数量分布（基于窗口内最近的样本）
+type SizeDistribution struct {
+	Count int
+	Max   float64
+	Mean  float64
+	P50   float64
+	P90   float64
+}
+
+// LatencyStats 成交延迟统计
+type LatencyStats struct {
+	Submitted    int64 // 已记录的提交数
+	BookObserved int64 // 观察到对手盘深度减少的提交数
+	TradeMatched int64 // 匹配到成交推送的提交数
+	Expired      int64 // 超时仍未匹配到成交推送的提交数
+	Pending      int   // 仍在等待匹配的提交数
+
+	// BookLatency 提交到对手盘限价以内深度首次减少的本地耗时
+	BookLatency LatencyDistribution
+	// TradeLatency 提交到首条匹配成交推送的本地耗时，即有效交易所延迟
+	TradeLatency LatencyDistribution
+	// AheadSize 提交后、匹配成交前同方向限价以内、且数量大于本单而不可能是本单的成交量之和
+	// 作为排队位置的近似：值越大说明越多同向吃单抢在前面
+	AheadSize SizeDistribution
+}
+
+// pendingSubmit 等待匹配的提交
+type pendingSubmit struct {
+	id          uint64
+	tokenID     string
+	side        Side
+	price       decimal.Decimal
+	size        decimal.Decimal
+	submittedAt time.Time
+
+	baseDepth    decimal.Decimal // 提交时对手盘限价以内的深度
+	hasBaseDepth bool
+	bookSeen     bool
+	aheadSize    decimal.Decimal
+}
+
+// LatencyTracker 将自己提交的可成交订单与随后的订单簿变化和成交推送关联，测量有效交易所延迟
+// 延迟均为本地时钟测量（提交时刻到收到推送时刻），不受与交易所时钟偏差的影响
+// 成交推送需通过 SubscribeWithEvents 订阅 EventTypeLastTradePrice 才会出现在更新流中；
+// 推送中的 side 视为吃单方向，按 token、方向、限价与数量匹配最早的未匹配提交
+type LatencyTracker struct {
+	mu     sync.Mutex
+	books  BookReader
+	config *LatencyConfig
+
+	nextID  uint64
+	pending []*pendingSubmit
+
+	submitted, bookObserved, tradeMatched, expired int64
+	bookSamples, tradeSamples                      []time.Duration
+	aheadSamples                                   []float64
+
+	now func() time.Time
+}
+
+// NewLatencyTracker 创建成交延迟跟踪器
+func NewLatencyTracker(books BookReader, config *LatencyConfig) *LatencyTracker {
+	if config == nil {
+		config = DefaultLatencyConfig()
+	}
+	if config.Window < 1 {
+		config.Window = 1
+	}
+	if config.Depth < 1 {
+		config.Depth = DefaultLatencyConfig().Depth
+	}
+
+	return &LatencyTracker{
+		books:  books,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// RecordSubmit 记录一笔即将发出的可成交订单（应在发送请求前调用），返回提交 ID
+// price 为限价（买单最高价、卖单最低价），size 为份额数量
+func (t *LatencyTracker) RecordSubmit(tokenID string, side Side, price, size decimal.Decimal) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.nextID++
+	p := &pendingSubmit{
+		id:          t.nextID,
+		tokenID:     tokenID,
+		side:        side,
+		price:       price,
+		size:        size,
+		submittedAt: t.now(),
+	}
+	p.baseDepth, p.hasBaseDepth = t.fillableDepth(p)
+	t.pending = append(t.pending, p)
+	t.submitted++
+	return p.id
+}
+
+// Discard 丢弃一笔提交（如请求失败或被拒），不计入统计
+func (t *LatencyTracker) Discard(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, p := range t.pending {
+		if p.id == id {
+			t.pending = append(t.pending[:i], t.pending[i+1:]...)
+			t.submitted--
+			return
+		}
+	}
+}
+
+// Run 持续消费更新流记录各阶段延迟，直到 ctx 取消或 updates 关闭
+func (t *LatencyTracker) Run(ctx context.Context, updates <-chan OrderBookUpdate) {
+	common.Supervise(ctx, "orderbook.latency", nil, func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				t.Process(update)
+			}
+		}
+	})
+}
+
+// Process 处理一条更新，返回是否产生了新的延迟样本
+func (t *LatencyTracker) Process(update OrderBookUpdate) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.expireLocked(now)
+
+	switch update.EventType {
+	case EventTypeBook, EventTypePriceChange:
+		return t.processBookLocked(update.TokenID, now)
+	case EventTypeLastTradePrice:
+		if update.LastTrade == nil {
+			return false
+		}
+		return t.processTradeLocked(update.TokenID, update.LastTrade, now)
+	}
+	return false
+}
+
+// processBookLocked 对手盘限价以内深度低于提交时即视为订单簿已反映本单
+func (t *LatencyTracker) processBookLocked(tokenID string, now time.Time) bool {
+	sampled := false
+	for _, p := range t.pending {
+		if p.tokenID != tokenID || p.bookSeen || !p.hasBaseDepth {
+			continue
+		}
+		depth, ok := t.fillableDepth(p)
+		if !ok || !depth.LessThan(p.baseDepth) {
+			continue
+		}
+		p.bookSeen = true
+		t.bookObserved++
+		t.bookSamples = appendWindow(t.bookSamples, now.Sub(p.submittedAt), t.config.Window)
+		sampled = true
+	}
+	return sampled
+}
+
+// processTradeLocked 将成交推送匹配到最早的符合条件的提交
+func (t *LatencyTracker) processTradeLocked(tokenID string, trade *LastTradePriceMessage, now time.Time) bool {
+	price, err := decimal.NewFromString(trade.Price)
+	if err != nil {
+		return false
+	}
+	size, err := decimal.NewFromString(trade.Size)
+	if err != nil {
+		return false
+	}
+
+	for i, p := range t.pending {
+		if p.tokenID != tokenID || p.side != trade.Side || !withinLimit(p.side, price, p.price) {
+			continue
+		}
+		if size.GreaterThan(p.size) {
+			// 不可能是本单，记为排在前面的同向成交
+			p.aheadSize = p.aheadSize.Add(size)
+			continue
+		}
+
+		t.pending = append(t.pending[:i], t.pending[i+1:]...)
+		t.tradeMatched++
+		t.tradeSamples = appendWindow(t.tradeSamples, now.Sub(p.submittedAt), t.config.Window)
+		ahead, _ := p.aheadSize.Float64()
+		t.aheadSamples = appendWindow(t.aheadSamples, ahead, t.config.Window)
+		// 其余提交同样被这笔成交抢在前面
+		for _, other := range t.pending[i:] {
+			if other.tokenID == tokenID && other.side == trade.Side && withinLimit(other.side, price, other.price) {
+				other.aheadSize = other.aheadSize.Add(size)
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// expireLocked 丢弃超时未匹配的提交
+func (t *LatencyTracker) expireLocked(now time.Time) {
+	if t.config.Timeout <= 0 {
+		return
+	}
+	kept := t.pending[:0]
+	for _, p := range t.pending {
+		if now.Sub(p.submittedAt) > t.config.Timeout {
+			t.expired++
+			continue
+		}
+		kept = append(kept, p)
+	}
+	for i := len(kept); i < len(t.pending); i++ {
+		t.pending[i] = nil
+	}
+	t.pending = kept
+}
+
+// fillableDepth 对手盘限价以内的总数量
+func (t *LatencyTracker) fillableDepth(p *pendingSubmit) (decimal.Decimal, bool) {
+	bids, asks, err := t.books.GetDepth(p.tokenID, t.config.Depth)
+	if err != nil {
+		return decimal.Zero, false
+	}
+	levels := asks
+	if p.side == SideSell {
+		levels = bids
+	}
+
+	total := decimal.Zero
+	for _, level := range levels {
+		if !withinLimit(p.side, level.Price, p.price) {
+			break
+		}
+		total = total.Add(level.Size)
+	}
+	return total, true
+}
+
+// Stats 返回当前统计
+func (t *LatencyTracker) Stats() LatencyStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.expireLocked(t.now())
+	return LatencyStats{
+		Submitted:    t.submitted,
+		BookObserved: t.bookObserved,
+		TradeMatched: t.tradeMatched,
+		Expired:      t.expired,
+		Pending:      len(t.pending),
+		BookLatency:  latencyDistribution(t.bookSamples),
+		TradeLatency: latencyDistribution(t.tradeSamples),
+		AheadSize:    sizeDistribution(t.aheadSamples),
+	}
+}
+
+// Reset 清空提交与样本
+func (t *LatencyTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending = nil
+	t.submitted, t.bookObserved, t.tradeMatched, t.expired = 0, 0, 0, 0
+	t.bookSamples, t.tradeSamples, t.aheadSamples = nil, nil, nil
+}
+
+// withinLimit 成交价是否在限价以内（买单不高于限价，卖单不低于限价）
+func withinLimit(side Side, price, limit decimal.Decimal) bool {
+	if side == SideSell {
+		return price.GreaterThanOrEqual(limit)
+	}
+	return price.LessThanOrEqual(limit)
+}
+
+// appendWindow 追加样本并只保留最近 window 个
+func appendWindow[T any](samples []T, v T, window int) []T {
+	samples = append(samples, v)
+	if over := len(samples) - window; over > 0 {
+		samples = samples[over:]
+	}
+	return samples
+}
+
+// latencyDistribution 计算延迟分布
+func latencyDistribution(samples []time.Duration) LatencyDistribution {
+	n := len(samples)
+	if n == 0 {
+		return LatencyDistribution{}
+	}
+
+	sorted := make([]time.Duration, n)
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, v := range sorted {
+		sum += v
+	}
+	return LatencyDistribution{
+		Count: n,
+		Min:   sorted[0],
+		Max:   sorted[n-1],
+		Mean:  sum / time.Duration(n),
+		P50:   sorted[percentileIndex(n, 0.50)],
+		P90:   sorted[percentileIndex(n, 0.90)],
+		P99:   sorted[percentileIndex(n, 0.99)],
+	}
+}
+
+// sizeDistribution 计算数量分布
+func sizeDistribution(samples []float64) SizeDistribution {
+	n := len(samples)
+	if n == 0 {
+		return SizeDistribution{}
+	}
+
+	sorted := make([]float64, n)
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+
+	return SizeDistribution{
+		Count: n,
+		Max:   sorted[n-1],
+		Mean:  mean(sorted),
+		P50:   sorted[percentileIndex(n, 0.50)],
+		P90:   sorted[percentileIndex(n, 0.90)],
+	}
+}
+
+// percentileIndex 最近秩法的分位数下标
+func percentileIndex(n int, q float64) int {
+	idx := int(math.Ceil(q*float64(n))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= n {
+		idx = n - 1
+	}
+	return idx
+}
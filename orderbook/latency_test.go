@@ -0,0 +1,116 @@
+package orderbook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// depthBooks is a BookReader returning fixed ask levels per token
+type depthBooks map[string][]OrderSummary
+
+func (d depthBooks) GetBBO(tokenID string) (*BBO, error) {
+	return nil, ErrNotInitialized
+}
+
+func (d depthBooks) GetDepth(tokenID string, depth int) ([]OrderSummary, []OrderSummary, error) {
+	asks, ok := d[tokenID]
+	if !ok {
+		return nil, nil, ErrNotInitialized
+	}
+	return nil, asks, nil
+}
+
+func level(price, size string) OrderSummary {
+	return OrderSummary{Price: decimal.RequireFromString(price), Size: decimal.RequireFromString(size)}
+}
+
+func tradeUpdate(tokenID string, side Side, price, size string) OrderBookUpdate {
+	return OrderBookUpdate{
+		TokenID:   tokenID,
+		EventType: EventTypeLastTradePrice,
+		LastTrade: &LastTradePriceMessage{AssetID: tokenID, Side: side, Price: price, Size: size},
+	}
+}
+
+func TestLatencyTracker(t *testing.T) {
+	books := depthBooks{"tok": {level("0.50", "100"), level("0.51", "100"), level("0.60", "100")}}
+	tracker := NewLatencyTracker(books, &LatencyConfig{Window: 10, Timeout: 5 * time.Second})
+	now := time.Unix(1000, 0)
+	tracker.now = func() time.Time { return now }
+
+	d := decimal.RequireFromString
+	tracker.RecordSubmit("tok", SideBuy, d("0.51"), d("20"))
+
+	// Book change that does not reduce fillable depth is ignored
+	now = now.Add(10 * time.Millisecond)
+	books["tok"] = []OrderSummary{level("0.50", "100"), level("0.51", "100"), level("0.60", "50")}
+	if tracker.Process(OrderBookUpdate{TokenID: "tok", EventType: EventTypePriceChange}) {
+		t.Error("depth beyond the limit should not count")
+	}
+
+	// A larger buy print cannot be ours and counts as ahead
+	now = now.Add(10 * time.Millisecond)
+	if tracker.Process(tradeUpdate("tok", SideBuy, "0.50", "30")) {
+		t.Error("larger print should not match")
+	}
+
+	now = now.Add(20 * time.Millisecond)
+	books["tok"] = []OrderSummary{level("0.51", "80")}
+	if !tracker.Process(OrderBookUpdate{TokenID: "tok", EventType: EventTypePriceChange}) {
+		t.Error("expected book latency sample")
+	}
+
+	// Wrong side and above-limit prints are ignored
+	now = now.Add(10 * time.Millisecond)
+	if tracker.Process(tradeUpdate("tok", SideSell, "0.50", "20")) || tracker.Process(tradeUpdate("tok", SideBuy, "0.52", "20")) {
+		t.Error("non-matching prints should be ignored")
+	}
+	if !tracker.Process(tradeUpdate("tok", SideBuy, "0.51", "20")) {
+		t.Error("expected trade match")
+	}
+
+	stats := tracker.Stats()
+	if stats.Submitted != 1 || stats.TradeMatched != 1 || stats.BookObserved != 1 || stats.Pending != 0 {
+		t.Errorf("unexpected counters: %+v", stats)
+	}
+	if stats.BookLatency.P50 != 40*time.Millisecond || stats.TradeLatency.P50 != 50*time.Millisecond {
+		t.Errorf("book = %v, trade = %v", stats.BookLatency.P50, stats.TradeLatency.P50)
+	}
+	if stats.AheadSize.Count != 1 || stats.AheadSize.Max != 30 {
+		t.Errorf("ahead size = %+v", stats.AheadSize)
+	}
+
+	// Unmatched submissions expire; discarded ones are not counted
+	tracker.RecordSubmit("tok", SideBuy, d("0.51"), d("5"))
+	id := tracker.RecordSubmit("tok", SideBuy, d("0.51"), d("5"))
+	tracker.Discard(id)
+	now = now.Add(6 * time.Second)
+	stats = tracker.Stats()
+	if stats.Submitted != 2 || stats.Expired != 1 || stats.Pending != 0 {
+		t.Errorf("unexpected counters after expiry: %+v", stats)
+	}
+
+	tracker.Reset()
+	if stats := tracker.Stats(); stats.Submitted != 0 || stats.TradeLatency.Count != 0 {
+		t.Error("Reset should clear stats")
+	}
+}
+
+func TestLatencyDistribution(t *testing.T) {
+	var samples []time.Duration
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	dist := latencyDistribution(samples)
+	if dist.Count != 100 || dist.Min != time.Millisecond || dist.Max != 100*time.Millisecond {
+		t.Errorf("unexpected distribution: %+v", dist)
+	}
+	if dist.P50 != 50*time.Millisecond || dist.P90 != 90*time.Millisecond || dist.P99 != 99*time.Millisecond {
+		t.Errorf("percentiles = %v %v %v", dist.P50, dist.P90, dist.P99)
+	}
+	if (latencyDistribution(nil) != LatencyDistribution{}) {
+		t.Error("empty samples should yield zero distribution")
+	}
+}
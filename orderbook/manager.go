@@ -2,9 +2,20 @@ package orderbook
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 // Manager 订单簿管理器
@@ -17,6 +28,10 @@ type Manager struct {
 	// tokenID -> OrderBook
 	orderBooks map[string]*OrderBook
 
+	// books orderBooks 的只读副本，订阅变化时整体替换，GetOrderBook 无锁读取，
+	// 行情查询不必等待持有 mu 的消息处理
+	books atomic.Pointer[map[string]*OrderBook]
+
 	// 已订阅的 token 集合
 	subscribedTokens map[string]bool
 
@@ -26,9 +41,65 @@ type Manager struct {
 	// 待处理的price_change消息（订单簿初始化前）
 	pendingChanges map[string][]*pendingPriceChange
 
+	// 自身挂单：tokenID -> 方向 -> 价格 -> 数量（用于计算外部订单簿视图）
+	ownOrders map[string]map[Side]map[string]decimal.Decimal
+
+	// token 元数据（填充到更新事件中）
+	tokenMetadata map[string]TokenMetadata
+
+	// 按 token 的事件类型过滤（未设置时使用 DefaultEventTypes）
+	eventFilters map[string]map[EventType]bool
+
+	// 时间戳间隔统计
+	gapStats map[string]*GapStats
+
+	// 最近一次推送时的最优价（BBOOnly 模式下用于判断是否需要推送）
+	lastBBO map[string]*topOfBook
+
+	// 每个 token 最近处理过的消息键，用于丢弃重复消息
+	recentMessages map[string]*recentKeys
+
+	// 等待初始快照的批次（Subscribe 与 WaitForSnapshots 创建）
+	snapshotBatches []*snapshotBatch
+
+	// 原始帧录制（Config.RecordWindow > 0 时创建）
+	recorder *FrameRecorder
+
+	// 行情延迟统计（Config.FeedLatency 非 nil 时创建）
+	feedLatency *FeedLatencyMonitor
+
+	// 最近一次收到行情消息的时间（UnixNano，原子访问）
+	lastMessageAt int64
+
 	// 关闭控制
 	closeChan chan struct{}
 	closeOnce sync.Once
+	closed    bool          // 已关闭，不再发送更新（受 mu 保护）
+	doneChan  chan struct{} // updateChan 关闭后关闭
+}
+
+// dedupWindow 每个 token 保留的最近消息键数量
+const dedupWindow = 64
+
+// recentKeys 固定容量的最近消息键集合
+type recentKeys struct {
+	keys [dedupWindow]string
+	set  map[string]struct{}
+	next int
+}
+
+// seen 记录消息键，已存在时返回 true
+func (r *recentKeys) seen(key string) bool {
+	if _, ok := r.set[key]; ok {
+		return true
+	}
+	if old := r.keys[r.next]; old != "" {
+		delete(r.set, old)
+	}
+	r.keys[r.next] = key
+	r.set[key] = struct{}{}
+	r.next = (r.next + 1) % dedupWindow
+	return false
 }
 
 // pendingPriceChange 待处理的价格变动
@@ -49,12 +120,35 @@ func NewManager(config *Config) *Manager {
 		subscribedTokens: make(map[string]bool),
 		updateChan:       make(chan OrderBookUpdate, config.UpdateChannelSize),
 		pendingChanges:   make(map[string][]*pendingPriceChange),
+		ownOrders:        make(map[string]map[Side]map[string]decimal.Decimal),
+		tokenMetadata:    make(map[string]TokenMetadata),
+		eventFilters:     make(map[string]map[EventType]bool),
+		gapStats:         make(map[string]*GapStats),
+		lastBBO:          make(map[string]*topOfBook),
+		recentMessages:   make(map[string]*recentKeys),
 		closeChan:        make(chan struct{}),
+		doneChan:         make(chan struct{}),
+	}
+	if config.RecordWindow > 0 {
+		m.recorder = NewFrameRecorder(time.Duration(config.RecordWindow)*time.Second, config.RecordMaxBytes)
+	}
+	if config.FeedLatency != nil {
+		m.feedLatency = NewFeedLatencyMonitor(config.FeedLatency)
 	}
+	m.publishBooksLocked()
 
 	return m
 }
 
+// publishBooksLocked 发布 orderBooks 的只读副本（需持有写锁）
+func (m *Manager) publishBooksLocked() {
+	books := make(map[string]*OrderBook, len(m.orderBooks))
+	for tokenID, ob := range m.orderBooks {
+		books[tokenID] = ob
+	}
+	m.books.Store(&books)
+}
+
 // Connect 建立 WebSocket 连接（不订阅任何 token）
 // 这是 "Connect first, Subscribe later" 模式的第一步
 func (m *Manager) Connect() error {
@@ -66,6 +160,7 @@ func (m *Manager) Connect() error {
 		m.pool = NewWSPool(m.config)
 
 		// 设置消息处理回调
+		m.pool.SetFrameRecorder(m.recorder)
 		m.pool.SetMessageHandler(m.handleMessage)
 
 		// 设置状态变更回调
@@ -120,12 +215,14 @@ func (m *Manager) Subscribe(tokenIDs []string) error {
 			m.pendingChanges[tokenID] = make([]*pendingPriceChange, 0)
 		}
 	}
+	m.publishBooksLocked()
 
 	// 如果连接池不存在，创建并设置回调
 	if m.pool == nil {
 		m.pool = NewWSPool(m.config)
 
 		// 设置消息处理回调
+		m.pool.SetFrameRecorder(m.recorder)
 		m.pool.SetMessageHandler(m.handleMessage)
 
 		// 设置状态变更回调
@@ -140,27 +237,73 @@ func (m *Manager) Subscribe(tokenIDs []string) error {
 	}
 
 	// 向连接池添加订阅
-	return m.pool.Subscribe(newTokens)
+	if err := m.pool.Subscribe(newTokens); err != nil {
+		return err
+	}
+
+	// 本批 token 的快照全部到达后推送 EventTypeSnapshotBatchComplete
+	m.addSnapshotBatchLocked(newTokens, true)
+	return nil
 }
 
 // Unsubscribe 取消订阅指定的 token
 func (m *Manager) Unsubscribe(tokenIDs []string) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
 	for _, tokenID := range tokenIDs {
+		// 在删除元数据之前推送，使事件仍带有 Metadata
+		if m.subscribedTokens[tokenID] {
+			m.sendUpdate(OrderBookUpdate{
+				TokenID:    tokenID,
+				EventType:  EventTypeRemoved,
+				ReceivedAt: time.Now(),
+			})
+		}
 		delete(m.subscribedTokens, tokenID)
 		delete(m.orderBooks, tokenID)
 		delete(m.pendingChanges, tokenID)
+		delete(m.ownOrders, tokenID)
+		delete(m.tokenMetadata, tokenID)
+		delete(m.eventFilters, tokenID)
+		delete(m.gapStats, tokenID)
+		delete(m.recentMessages, tokenID)
+		delete(m.lastBBO, tokenID)
+		if m.feedLatency != nil {
+			m.feedLatency.Remove(tokenID)
+		}
+		m.snapshotReadyLocked(tokenID, 0, time.Now())
 	}
+	m.publishBooksLocked()
+	pool := m.pool
+	m.mu.Unlock()
 
-	if m.pool != nil {
-		return m.pool.Unsubscribe(tokenIDs)
+	// 在锁外调用连接池：重平衡关闭连接时会等待其读协程退出，而读协程处理消息需要获取 m.mu
+	if pool != nil {
+		return pool.Unsubscribe(tokenIDs)
 	}
 
 	return nil
 }
 
+// Rebalance 重新平衡 token 在各 WebSocket 连接间的分布
+func (m *Manager) Rebalance() error {
+	m.mu.RLock()
+	pool := m.pool
+	m.mu.RUnlock()
+
+	if pool == nil {
+		return nil
+	}
+	return pool.Rebalance()
+}
+
+// Pool 获取 WebSocket 连接池，Connect 或首次订阅前为 nil
+func (m *Manager) Pool() *WSPool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.pool
+}
+
 // GetSubscribedTokens 获取已订阅的 token 列表
 func (m *Manager) GetSubscribedTokens() []string {
 	m.mu.RLock()
@@ -187,8 +330,14 @@ func (m *Manager) handleClientDisconnect(clientID string) {
 		c := m.pool.GetClientForToken(tokenID)
 		if c != nil && c.ID() == clientID {
 			// 重置订单簿状态（保留对象引用，避免外部持有旧引用的问题）
+			wasInitialized := ob.IsInitialized()
 			ob.Reset()
 			m.pendingChanges[tokenID] = make([]*pendingPriceChange, 0)
+			delete(m.recentMessages, tokenID)
+			delete(m.lastBBO, tokenID)
+			if wasInitialized {
+				m.sendResetLocked(tokenID, ResetReasonDisconnect)
+			}
 			log.Printf("[Manager] reset orderbook for token %s due to client %s disconnect", tokenID, clientID)
 		}
 	}
@@ -199,18 +348,22 @@ func (m *Manager) handleClientDisconnect(clientID string) {
 // 1. 数组格式（初始化订阅时批量发送）：[{event_type: "book", ...}, ...]
 // 2. 单个对象格式（后续增量更新）：{event_type: "book", ...}
 func (m *Manager) handleMessage(data []byte) {
+	// 每帧只取一次本地时间，同一帧内的消息共享接收时间；time.Now 带单调时钟读数，可用于本地排序与计时
+	receivedAt := time.Now()
+	atomic.StoreInt64(&m.lastMessageAt, receivedAt.UnixNano())
+
 	// 检查是否是数组格式（以 '[' 开头）
 	if len(data) > 0 && data[0] == '[' {
-		m.handleMessageArray(data)
+		m.handleMessageArray(data, receivedAt)
 		return
 	}
 
 	// 单个对象格式
-	m.handleSingleMessage(data)
+	m.handleSingleMessage(data, receivedAt)
 }
 
 // handleMessageArray 处理消息数组
-func (m *Manager) handleMessageArray(data []byte) {
+func (m *Manager) handleMessageArray(data []byte, receivedAt time.Time) {
 	var rawMessages []json.RawMessage
 	if err := json.Unmarshal(data, &rawMessages); err != nil {
 		log.Printf("[Manager] failed to unmarshal message array: %v", err)
@@ -220,12 +373,12 @@ func (m *Manager) handleMessageArray(data []byte) {
 	//log.Printf("[Manager] received array of %d messages", len(rawMessages))
 
 	for _, rawMsg := range rawMessages {
-		m.handleSingleMessage(rawMsg)
+		m.handleSingleMessage(rawMsg, receivedAt)
 	}
 }
 
 // handleSingleMessage 处理单条消息
-func (m *Manager) handleSingleMessage(data []byte) {
+func (m *Manager) handleSingleMessage(data []byte, receivedAt time.Time) {
 	// 首先解析消息类型
 	var raw RawMessage
 	if err := json.Unmarshal(data, &raw); err != nil {
@@ -236,22 +389,20 @@ func (m *Manager) handleSingleMessage(data []byte) {
 
 	switch raw.EventType {
 	case EventTypeBook:
-		m.handleBookMessage(data)
+		m.handleBookMessage(data, receivedAt)
 	case EventTypePriceChange:
-		m.handlePriceChangeMessage(data)
+		m.handlePriceChangeMessage(data, receivedAt)
 	case EventTypeTickSizeChange:
-		// 暂不处理tick size变更
-		//log.Printf("[Manager] received tick_size_change message")
+		m.handleTickSizeChangeMessage(data, receivedAt)
 	case EventTypeLastTradePrice:
-		// 暂不处理最后成交价
-		//log.Printf("[Manager] received last_trade_price message")
+		m.handleLastTradePriceMessage(data, receivedAt)
 	default:
 		//log.Printf("[Manager] unknown event type: %s", raw.EventType)
 	}
 }
 
 // handleBookMessage 处理订单簿快照消息
-func (m *Manager) handleBookMessage(data []byte) {
+func (m *Manager) handleBookMessage(data []byte, receivedAt time.Time) {
 	var msg BookMessage
 	if err := json.Unmarshal(data, &msg); err != nil {
 		log.Printf("[Manager] failed to unmarshal book message: %v", err)
@@ -273,8 +424,30 @@ func (m *Manager) handleBookMessage(data []byte) {
 		return
 	}
 
+	// 不需要订单簿的 token（如只订阅成交）跳过维护
+	if !m.tracksBookLocked(msg.AssetID) {
+		return
+	}
+
+	// 未初始化（刚重置）的订单簿总是接受快照，即使与之前的快照相同
+	if m.isDuplicateLocked(msg.AssetID, EventTypeBook, msg.Hash, ts, "") && ob.IsInitialized() {
+		m.gapStatsLocked(msg.AssetID).Duplicates++
+		return
+	}
+
+	if ts < ob.Timestamp() {
+		m.gapStatsLocked(msg.AssetID).OutOfOrder++
+		return
+	}
+
 	// 应用快照
+	wasInitialized := ob.IsInitialized()
 	if ob.ApplyBookSnapshot(&msg, ts) {
+		stats := m.gapStatsLocked(msg.AssetID)
+		stats.Updates++
+		stats.LastTimestamp = ts
+		m.recordLatencyLocked(msg.AssetID, ts, receivedAt)
+
 		//log.Printf("[Manager] applied book snapshot for token %s, bids: %d, asks: %d",
 		//	msg.AssetID, len(msg.Bids), len(msg.Asks))
 
@@ -296,16 +469,31 @@ func (m *Manager) handleBookMessage(data []byte) {
 		m.pendingChanges[msg.AssetID] = make([]*pendingPriceChange, 0)
 
 		// 发送更新通知
-		m.sendUpdate(OrderBookUpdate{
-			TokenID:   msg.AssetID,
-			EventType: EventTypeBook,
-			Timestamp: ts,
-		})
+		if m.wantsEventLocked(msg.AssetID, EventTypeBook) && m.bboChangedLocked(msg.AssetID, ob) {
+			m.sendUpdate(OrderBookUpdate{
+				TokenID:    msg.AssetID,
+				EventType:  EventTypeBook,
+				Timestamp:  ts,
+				ReceivedAt: receivedAt,
+			})
+		}
+
+		if !wasInitialized {
+			m.sendUpdate(OrderBookUpdate{
+				TokenID:    msg.AssetID,
+				EventType:  EventTypeInitialized,
+				Timestamp:  ts,
+				ReceivedAt: receivedAt,
+			})
+		}
+
+		// 在快照事件之后推送批次完成事件
+		m.snapshotReadyLocked(msg.AssetID, ts, receivedAt)
 	}
 }
 
 // handlePriceChangeMessage 处理价格变动消息
-func (m *Manager) handlePriceChangeMessage(data []byte) {
+func (m *Manager) handlePriceChangeMessage(data []byte, receivedAt time.Time) {
 	var msg PriceChangeMessage
 	if err := json.Unmarshal(data, &msg); err != nil {
 		log.Printf("[Manager] failed to unmarshal price_change message: %v", err)
@@ -332,6 +520,26 @@ func (m *Manager) handlePriceChangeMessage(data []byte) {
 			continue
 		}
 
+		if !m.tracksBookLocked(change.AssetID) {
+			continue
+		}
+
+		if m.isDuplicateLocked(change.AssetID, EventTypePriceChange, change.Hash, ts, string(change.Side)+"|"+change.Price) {
+			m.gapStatsLocked(change.AssetID).Duplicates++
+			continue
+		}
+
+		// 间隔过大视为可能丢消息，重置订单簿并重新订阅，本条增量进入待处理缓存
+		if ob.IsInitialized() {
+			if ts < ob.Timestamp() {
+				m.gapStatsLocked(change.AssetID).OutOfOrder++
+				continue
+			}
+			if m.checkGapLocked(change.AssetID, ts) {
+				m.resyncLocked(change.AssetID)
+			}
+		}
+
 		// 如果订单簿未初始化，缓存消息
 		if !ob.IsInitialized() {
 			m.pendingChanges[change.AssetID] = append(m.pendingChanges[change.AssetID], &pendingPriceChange{
@@ -342,46 +550,493 @@ func (m *Manager) handlePriceChangeMessage(data []byte) {
 		}
 
 		// 应用价格变动
-		if ob.ApplyPriceChange(&changeCopy, ts) {
+		if !ob.ApplyPriceChange(&changeCopy, ts) {
+			continue
+		}
+		stats := m.gapStatsLocked(change.AssetID)
+		stats.Updates++
+		stats.LastTimestamp = ts
+		m.recordLatencyLocked(change.AssetID, ts, receivedAt)
+
+		if m.wantsEventLocked(change.AssetID, EventTypePriceChange) && m.bboChangedLocked(change.AssetID, ob) {
 			// 发送更新通知
 			m.sendUpdate(OrderBookUpdate{
-				TokenID:   change.AssetID,
-				EventType: EventTypePriceChange,
-				Timestamp: ts,
+				TokenID:    change.AssetID,
+				EventType:  EventTypePriceChange,
+				Timestamp:  ts,
+				ReceivedAt: receivedAt,
 			})
 		}
 	}
 }
 
-// sendUpdate 发送更新通知
+// gapStatsLocked 获取或创建 token 的间隔统计（调用者需持有锁）
+func (m *Manager) gapStatsLocked(tokenID string) *GapStats {
+	stats, ok := m.gapStats[tokenID]
+	if !ok {
+		stats = &GapStats{}
+		m.gapStats[tokenID] = stats
+	}
+	return stats
+}
+
+// isDuplicateLocked 按 (事件类型, hash, timestamp, level) 判断消息是否已收到过并记录本条（调用者需持有锁）
+// 同一 token 被多个连接订阅时相同消息会到达多次；hash 为空时无法判断，视为不重复
+// level 区分同一帧内同一 token 的多个价位变动（共享 hash 与 timestamp），price_change 传入 side|price
+func (m *Manager) isDuplicateLocked(tokenID string, eventType EventType, hash string, ts int64, level string) bool {
+	if hash == "" {
+		return false
+	}
+	recent, ok := m.recentMessages[tokenID]
+	if !ok {
+		recent = &recentKeys{set: make(map[string]struct{}, dedupWindow)}
+		m.recentMessages[tokenID] = recent
+	}
+	return recent.seen(string(eventType) + "|" + hash + "|" + strconv.FormatInt(ts, 10) + "|" + level)
+}
+
+// checkGapLocked 记录增量与上次应用之间的间隔，返回是否超过阈值（调用者需持有锁）
+func (m *Manager) checkGapLocked(tokenID string, ts int64) bool {
+	stats := m.gapStatsLocked(tokenID)
+	if stats.LastTimestamp == 0 {
+		return false
+	}
+
+	gap := ts - stats.LastTimestamp
+	stats.LastGapMs = gap
+	if gap > stats.MaxGapMs {
+		stats.MaxGapMs = gap
+	}
+
+	if maxGap := m.config.maxTimestampGap(); maxGap <= 0 || gap <= maxGap {
+		return false
+	}
+	stats.Gaps++
+	return true
+}
+
+// resetForSnapshotLocked 重置订单簿并清空待处理增量，等待重新订阅后的快照（调用者需持有锁）
+func (m *Manager) resetForSnapshotLocked(tokenID string) {
+	if ob, ok := m.orderBooks[tokenID]; ok {
+		wasInitialized := ob.IsInitialized()
+		ob.Reset()
+		if wasInitialized {
+			m.sendResetLocked(tokenID, ResetReasonResync)
+		}
+	}
+	m.pendingChanges[tokenID] = make([]*pendingPriceChange, 0)
+	delete(m.recentMessages, tokenID) // 重新订阅后的快照不能被当作重复丢弃
+	delete(m.lastBBO, tokenID)
+}
+
+// resyncLocked 重置订单簿并重新订阅以获取新快照（调用者需持有锁）
+func (m *Manager) resyncLocked(tokenID string) {
+	m.resetForSnapshotLocked(tokenID)
+	m.gapStatsLocked(tokenID).Resyncs++
+
+	if m.pool == nil {
+		return
+	}
+	client := m.pool.GetClientForToken(tokenID)
+	if client == nil {
+		return
+	}
+
+	// 在锁外发送，避免写通道阻塞消息处理
+	go func() {
+		defer common.RecoverPanic("orderbook.resync", nil)
+		if err := client.Resubscribe([]string{tokenID}); err != nil {
+			log.Printf("[Manager] failed to resync token %s: %v", tokenID, err)
+		}
+	}()
+	log.Printf("[Manager] resyncing token %s", tokenID)
+}
+
+// Resync 重置指定 token 的订单簿并重新订阅
+func (m *Manager) Resync(tokenIDs []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tokenID := range tokenIDs {
+		if m.subscribedTokens[tokenID] {
+			m.resyncLocked(tokenID)
+		}
+	}
+}
+
+// Resubscribe 重置已订阅 token 的订单簿并批量重新订阅以获取新快照，同一连接上的 token 合并为一次请求
+// 已初始化的订单簿推送 EventTypeReset，新快照全部到达后推送 EventTypeSnapshotBatchComplete；
+// 所在连接不可用的 token 在重连后自动获取快照。未订阅的 token 不处理，返回 ErrTokenNotFound
+func (m *Manager) Resubscribe(tokenIDs []string) error {
+	m.mu.Lock()
+	var tokens, unknown []string
+	groups := make(map[*WSClient][]string)
+	for _, tokenID := range tokenIDs {
+		if !m.subscribedTokens[tokenID] {
+			unknown = append(unknown, tokenID)
+			continue
+		}
+		if slices.Contains(tokens, tokenID) {
+			continue
+		}
+		tokens = append(tokens, tokenID)
+		m.resetForSnapshotLocked(tokenID)
+		if m.pool == nil {
+			continue
+		}
+		if client := m.pool.GetClientForToken(tokenID); client != nil {
+			groups[client] = append(groups[client], tokenID)
+		}
+	}
+	if len(tokens) > 0 {
+		m.addSnapshotBatchLocked(tokens, true)
+	}
+	m.mu.Unlock()
+
+	// 在锁外发送，避免写通道阻塞消息处理
+	var errs []error
+	for client, batch := range groups {
+		if err := client.Resubscribe(batch); err != nil {
+			errs = append(errs, fmt.Errorf("failed to resubscribe %d tokens: %w", len(batch), err))
+		}
+	}
+	if len(unknown) > 0 {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrTokenNotFound, strings.Join(unknown, ",")))
+	}
+	return errors.Join(errs...)
+}
+
+// GetGapStats 获取 token 的时间戳间隔统计
+func (m *Manager) GetGapStats(tokenID string) (GapStats, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats, ok := m.gapStats[tokenID]
+	if !ok {
+		return GapStats{}, false
+	}
+	return *stats, true
+}
+
+// handleTickSizeChangeMessage 处理 tick size 变更消息（仅推送给订阅了该事件的 token）
+func (m *Manager) handleTickSizeChangeMessage(data []byte, receivedAt time.Time) {
+	var msg TickSizeChangeMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		log.Printf("[Manager] failed to unmarshal tick_size_change message: %v", err)
+		return
+	}
+
+	ts, _ := strconv.ParseInt(msg.Timestamp, 10, 64)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.subscribedTokens[msg.AssetID] || !m.wantsEventLocked(msg.AssetID, EventTypeTickSizeChange) {
+		return
+	}
+	m.recordLatencyLocked(msg.AssetID, ts, receivedAt)
+
+	m.sendUpdate(OrderBookUpdate{
+		TokenID:        msg.AssetID,
+		EventType:      EventTypeTickSizeChange,
+		Timestamp:      ts,
+		ReceivedAt:     receivedAt,
+		TickSizeChange: &msg,
+	})
+}
+
+// handleLastTradePriceMessage 处理最后成交价消息（仅推送给订阅了该事件的 token）
+func (m *Manager) handleLastTradePriceMessage(data []byte, receivedAt time.Time) {
+	var msg LastTradePriceMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		log.Printf("[Manager] failed to unmarshal last_trade_price message: %v", err)
+		return
+	}
+
+	ts, _ := strconv.ParseInt(msg.Timestamp, 10, 64)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.subscribedTokens[msg.AssetID] || !m.wantsEventLocked(msg.AssetID, EventTypeLastTradePrice) {
+		return
+	}
+	m.recordLatencyLocked(msg.AssetID, ts, receivedAt)
+
+	m.sendUpdate(OrderBookUpdate{
+		TokenID:    msg.AssetID,
+		EventType:  EventTypeLastTradePrice,
+		Timestamp:  ts,
+		ReceivedAt: receivedAt,
+		LastTrade:  &msg,
+	})
+}
+
+// wantsEventLocked token 是否需要推送该类型事件（调用者需持有锁）
+func (m *Manager) wantsEventLocked(tokenID string, eventType EventType) bool {
+	filter, ok := m.eventFilters[tokenID]
+	if !ok {
+		return eventType == EventTypeBook || eventType == EventTypePriceChange
+	}
+	return filter[eventType]
+}
+
+// tracksBookLocked token 是否需要维护本地订单簿（调用者需持有锁）
+func (m *Manager) tracksBookLocked(tokenID string) bool {
+	return m.wantsEventLocked(tokenID, EventTypeBook) || m.wantsEventLocked(tokenID, EventTypePriceChange)
+}
+
+// SetEventFilter 设置 token 推送的事件类型，不传类型时恢复默认（book + price_change）
+// 过滤掉 book 和 price_change 的 token 不再维护本地订单簿
+func (m *Manager) SetEventFilter(tokenID string, eventTypes ...EventType) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(eventTypes) == 0 {
+		delete(m.eventFilters, tokenID)
+		return
+	}
+
+	filter := make(map[EventType]bool, len(eventTypes))
+	for _, et := range eventTypes {
+		filter[et] = true
+	}
+
+	wasTracking := m.tracksBookLocked(tokenID)
+	m.eventFilters[tokenID] = filter
+
+	// 停止维护订单簿时清空旧数据，避免读到过期快照
+	if wasTracking && !m.tracksBookLocked(tokenID) {
+		if ob, ok := m.orderBooks[tokenID]; ok {
+			wasInitialized := ob.IsInitialized()
+			ob.Reset()
+			if wasInitialized {
+				m.sendResetLocked(tokenID, ResetReasonFilter)
+			}
+		}
+		m.pendingChanges[tokenID] = make([]*pendingPriceChange, 0)
+		delete(m.lastBBO, tokenID)
+		m.snapshotReadyLocked(tokenID, 0, time.Now())
+	}
+}
+
+// GetEventFilter 获取 token 推送的事件类型
+func (m *Manager) GetEventFilter(tokenID string) []EventType {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	filter, ok := m.eventFilters[tokenID]
+	if !ok {
+		return append([]EventType(nil), DefaultEventTypes...)
+	}
+
+	result := make([]EventType, 0, len(filter))
+	for _, et := range []EventType{EventTypeBook, EventTypePriceChange, EventTypeTickSizeChange, EventTypeLastTradePrice} {
+		if filter[et] {
+			result = append(result, et)
+		}
+	}
+	return result
+}
+
+// bboChangedLocked 订单簿更新是否需要推送（调用者需持有锁）
+// BBOOnly 模式下只有最优买卖价或其数量与上次推送时不同才推送，并记录本次推送的最优价
+func (m *Manager) bboChangedLocked(tokenID string, ob *OrderBook) bool {
+	if !m.config.BBOOnly {
+		return true
+	}
+	top := ob.top.Load()
+	if last, ok := m.lastBBO[tokenID]; ok && sameLevel(last.bid, top.bid) && sameLevel(last.ask, top.ask) {
+		return false
+	}
+	m.lastBBO[tokenID] = top
+	return true
+}
+
+// sameLevel 两个最优价位的价格与数量是否相同
+func sameLevel(a, b *OrderSummary) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Price.Equal(b.Price) && a.Size.Equal(b.Size)
+}
+
+// sendResetLocked 推送订单簿被清空事件（调用者需持有锁）
+func (m *Manager) sendResetLocked(tokenID string, reason ResetReason) {
+	m.sendUpdate(OrderBookUpdate{
+		TokenID:     tokenID,
+		EventType:   EventTypeReset,
+		ReceivedAt:  time.Now(),
+		ResetReason: reason,
+	})
+}
+
+// isLifecycleEvent 是否为不可丢弃的生命周期事件
+func isLifecycleEvent(eventType EventType) bool {
+	switch eventType {
+	case EventTypeInitialized, EventTypeReset, EventTypeRemoved, EventTypeSnapshotBatchComplete:
+		return true
+	}
+	return false
+}
+
+// sendUpdate 发送更新通知（调用者需持有锁），关闭后直接丢弃
+// channel 满时丢弃最旧的行情更新（book、price_change 等），生命周期事件
+// （Initialized、Reset、Removed、SnapshotBatchComplete）保证送达且顺序不变：
+// 缓冲区内全部为生命周期事件时，新的行情更新被丢弃，新的生命周期事件阻塞等待消费者读取或 Close
 func (m *Manager) sendUpdate(update OrderBookUpdate) {
+	if m.closed {
+		return
+	}
+	if meta, ok := m.tokenMetadata[update.TokenID]; ok {
+		update.Metadata = &meta
+	}
+
 	select {
 	case m.updateChan <- update:
+		return
 	default:
-		// channel满了，丢弃旧消息
+	}
+
+	// channel满了，丢弃最旧的行情更新腾出位置
+	if !m.makeRoomLocked() && !isLifecycleEvent(update.EventType) {
+		return
+	}
+	select {
+	case m.updateChan <- update:
+	case <-m.closeChan:
+	}
+}
+
+// makeRoomLocked 从缓冲区移除最旧的非生命周期更新，其余更新按原顺序放回（调用者需持有锁），返回是否有空位
+// 所有发送都在持有 mu 时进行，放回时不会与其他发送者竞争
+func (m *Manager) makeRoomLocked() bool {
+	buffered := make([]OrderBookUpdate, 0, len(m.updateChan))
+	for drained := false; !drained; {
 		select {
-		case <-m.updateChan:
+		case u := <-m.updateChan:
+			buffered = append(buffered, u)
 		default:
+			drained = true
 		}
-		select {
-		case m.updateChan <- update:
-		default:
+	}
+
+	evicted := false
+	for _, u := range buffered {
+		if !evicted && !isLifecycleEvent(u.EventType) {
+			evicted = true
+			continue
 		}
+		m.updateChan <- u
+	}
+	return evicted || len(buffered) < cap(m.updateChan)
+}
+
+// FlushRecent 将录制窗口内的原始帧写入 w，未启用录制（Config.RecordWindow 为 0）时返回错误
+func (m *Manager) FlushRecent(w io.Writer) error {
+	if m.recorder == nil {
+		return fmt.Errorf("%w: frame recording disabled, set Config.RecordWindow", common.ErrInvalidConfig)
+	}
+	return m.recorder.FlushRecent(w)
+}
+
+// recordLatencyLocked 记录已应用消息的行情延迟（调用者需持有锁）
+func (m *Manager) recordLatencyLocked(tokenID string, ts int64, receivedAt time.Time) {
+	if m.feedLatency != nil {
+		m.feedLatency.Record(tokenID, ts, receivedAt)
+	}
+}
+
+// FeedLatency 获取行情延迟统计，未配置 Config.FeedLatency 时返回 nil
+func (m *Manager) FeedLatency() *FeedLatencyMonitor {
+	return m.feedLatency
+}
+
+// LastMessageTime 获取最近一次收到行情消息的时间，未收到过时返回零值
+func (m *Manager) LastMessageTime() time.Time {
+	ns := atomic.LoadInt64(&m.lastMessageAt)
+	if ns == 0 {
+		return time.Time{}
 	}
+	return time.Unix(0, ns)
 }
 
 // Updates 获取更新通知channel
+// Close 后 channel 会被关闭：缓冲中的更新仍可读出，读完后接收返回 ok == false，
+// 因此 for range Updates() 会在关闭后自然退出
+// 消费跟不上时只丢弃行情更新，生命周期事件不会丢失（见 sendUpdate）
 func (m *Manager) Updates() <-chan OrderBookUpdate {
 	return m.updateChan
 }
 
+// Done 返回在管理器关闭且 Updates channel 已关闭后关闭的 channel
+// 用于在 select 中区分"暂无更新"与"已关闭"
+func (m *Manager) Done() <-chan struct{} {
+	return m.doneChan
+}
+
 // GetOrderBook 获取指定token的订单簿
 func (m *Manager) GetOrderBook(tokenID string) *OrderBook {
+	if ob := (*m.books.Load())[tokenID]; ob != nil {
+		return ob
+	}
+
+	// 未命中（未订阅的 token）时回退到加锁查询
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return m.orderBooks[tokenID]
 }
 
+// SetOwnOrders 设置指定 token 的自身挂单（整体替换）
+// 同一价格的多笔挂单会合并，传入空列表等同于清除
+func (m *Manager) SetOwnOrders(tokenID string, orders []OwnOrder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(orders) == 0 {
+		delete(m.ownOrders, tokenID)
+		return
+	}
+
+	levels := map[Side]map[string]decimal.Decimal{
+		SideBuy:  make(map[string]decimal.Decimal),
+		SideSell: make(map[string]decimal.Decimal),
+	}
+	for _, order := range orders {
+		sideLevels, ok := levels[order.Side]
+		if !ok || !order.Size.IsPositive() {
+			continue
+		}
+		key := priceKey(order.Price)
+		sideLevels[key] = sideLevels[key].Add(order.Size)
+	}
+	m.ownOrders[tokenID] = levels
+}
+
+// SetTokenMetadata 注册 token 元数据，之后该 token 的更新事件会携带这些信息
+func (m *Manager) SetTokenMetadata(tokenID string, meta TokenMetadata) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokenMetadata[tokenID] = meta
+}
+
+// GetTokenMetadata 获取 token 元数据
+func (m *Manager) GetTokenMetadata(tokenID string) (TokenMetadata, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	meta, ok := m.tokenMetadata[tokenID]
+	return meta, ok
+}
+
+// GetOwnOrderLevels 获取指定 token 的自身挂单档位（价格 -> 数量）
+func (m *Manager) GetOwnOrderLevels(tokenID string) (bids, asks map[string]decimal.Decimal) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	levels := m.ownOrders[tokenID]
+	return levels[SideBuy], levels[SideSell]
+}
+
 // GetAllOrderBooks 获取所有订单簿
 func (m *Manager) GetAllOrderBooks() map[string]*OrderBook {
 	m.mu.RLock()
@@ -437,6 +1092,12 @@ func (m *Manager) Close() {
 			m.pool.Close()
 		}
 
+		// 所有发送都在持有 mu 时进行，加锁后标记关闭即可保证不会向已关闭的 channel 发送
+		m.mu.Lock()
+		m.closed = true
 		close(m.updateChan)
+		m.mu.Unlock()
+
+		close(m.doneChan)
 	})
 }
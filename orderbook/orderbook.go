@@ -3,6 +3,8 @@ package orderbook
 import (
 	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -14,30 +16,51 @@ type OrderBook struct {
 	tokenID     string
 	market      string
 	hash        string
-	timestamp   int64 // 上次更新时间戳（毫秒）
-	initialized bool  // 是否已初始化（收到过book消息）
+	timestamp   int64     // 上次更新时间戳（毫秒）
+	initialized bool      // 是否已初始化（收到过book消息）
+	receivedAt  time.Time // 最近一次应用消息的本地时间
+	sequence    uint64    // 本地序号，每应用一条消息加 1，Reset 不清零
 
 	// 买单：按价格降序排列，使用map存储便于O(1)更新
-	bids map[string]decimal.Decimal // price -> size
+	// 价格与数量以定点数存储（见 fixed），对外的 OrderSummary 在排序缓存重建时转换
+	bids map[fixed]fixed // price -> size
 	// 卖单：按价格升序排列
-	asks map[string]decimal.Decimal // price -> size
+	asks map[fixed]fixed // price -> size
 
 	// 缓存的排序后的价格档位
 	sortedBids []OrderSummary
 	sortedAsks []OrderSummary
 	bidsDirty  bool
 	asksDirty  bool
+
+	// 最优档增量维护，每次写入后发布到 top
+	bestBid bestLevel
+	bestAsk bestLevel
+
+	// top 最优价只读快照，写入方持锁整体替换，GetBestBid/GetBestAsk/GetBBO 无锁读取，
+	// 高频读取不与消息处理协程争用 mu
+	top atomic.Pointer[topOfBook]
+}
+
+// topOfBook 不可变的最优价快照，发布后不再修改
+type topOfBook struct {
+	initialized bool
+	timestamp   int64
+	bid         *OrderSummary // 无买单时为 nil
+	ask         *OrderSummary // 无卖单时为 nil
 }
 
 // NewOrderBook 创建新的订单簿
 func NewOrderBook(tokenID string) *OrderBook {
-	return &OrderBook{
+	ob := &OrderBook{
 		tokenID:   tokenID,
-		bids:      make(map[string]decimal.Decimal),
-		asks:      make(map[string]decimal.Decimal),
+		bids:      make(map[fixed]fixed),
+		asks:      make(map[fixed]fixed),
 		bidsDirty: true,
 		asksDirty: true,
 	}
+	ob.publishTopLocked()
+	return ob
 }
 
 // Reset 重置订单簿状态（保留tokenID，清空其他数据）
@@ -49,12 +72,90 @@ func (ob *OrderBook) Reset() {
 	ob.hash = ""
 	ob.timestamp = 0
 	ob.initialized = false
-	ob.bids = make(map[string]decimal.Decimal)
-	ob.asks = make(map[string]decimal.Decimal)
+	ob.receivedAt = time.Time{}
+	ob.bids = make(map[fixed]fixed)
+	ob.asks = make(map[fixed]fixed)
 	ob.sortedBids = nil
 	ob.sortedAsks = nil
 	ob.bidsDirty = true
 	ob.asksDirty = true
+	ob.bestBid = bestLevel{}
+	ob.bestAsk = bestLevel{}
+	ob.publishTopLocked()
+}
+
+// publishTopLocked 发布当前最优价快照（需持有写锁）
+func (ob *OrderBook) publishTopLocked() {
+	ob.top.Store(&topOfBook{
+		initialized: ob.initialized,
+		timestamp:   ob.timestamp,
+		bid:         ob.bestBid.summary,
+		ask:         ob.bestAsk.summary,
+	})
+}
+
+// priceKey 自身挂单 map 的键：规范化的十进制字符串，"0.50" 与 "0.5" 对应同一档位
+func priceKey(price decimal.Decimal) string {
+	return price.String()
+}
+
+// bestLevel 增量维护的最优档：price 用于比较，summary 为发布到 top 的副本，没有档位时为 nil
+type bestLevel struct {
+	price   fixed
+	summary *OrderSummary
+}
+
+// newBestLevel 创建最优档
+func newBestLevel(price, size fixed) bestLevel {
+	return bestLevel{price: price, summary: &OrderSummary{Price: price.Decimal(), Size: size.Decimal()}}
+}
+
+// higher 买单价格 a 优于 b
+func higher(a, b fixed) bool { return a > b }
+
+// lower 卖单价格 a 优于 b
+func lower(a, b fixed) bool { return a < b }
+
+// nextBest 档位 price 的数量变为 size 后的最优档，better(a, b) 表示价格 a 优于 b
+// 最优档被删除时返回 rescan=true，由调用方从完整档位重新计算
+func nextBest(best bestLevel, price, size fixed, better func(a, b fixed) bool) (next bestLevel, rescan bool) {
+	if size == 0 {
+		return best, best.summary != nil && price == best.price
+	}
+	if best.summary == nil || better(price, best.price) || price == best.price {
+		return newBestLevel(price, size), false
+	}
+	return best, false
+}
+
+// bestOf 从完整档位中找出最优档
+func bestOf(levels map[fixed]fixed, better func(a, b fixed) bool) bestLevel {
+	var best fixed
+	found := false
+	for price := range levels {
+		if !found || better(price, best) {
+			best, found = price, true
+		}
+	}
+	if !found {
+		return bestLevel{}
+	}
+	return newBestLevel(best, levels[best])
+}
+
+// sortLevels 按 better 排序档位并转换为 OrderSummary
+func sortLevels(levels map[fixed]fixed, better func(a, b fixed) bool) []OrderSummary {
+	prices := make([]fixed, 0, len(levels))
+	for price := range levels {
+		prices = append(prices, price)
+	}
+	sort.Slice(prices, func(i, j int) bool { return better(prices[i], prices[j]) })
+
+	sorted := make([]OrderSummary, len(prices))
+	for i, price := range prices {
+		sorted[i] = OrderSummary{Price: price.Decimal(), Size: levels[price].Decimal()}
+	}
+	return sorted
 }
 
 // TokenID 获取token ID
@@ -85,6 +186,26 @@ func (ob *OrderBook) Timestamp() int64 {
 	return ob.timestamp
 }
 
+// Time 获取上次更新的交易所时间戳
+func (ob *OrderBook) Time() time.Time {
+	return TimeFromMillis(ob.Timestamp())
+}
+
+// ReceivedAt 获取最近一次应用消息的本地时间
+func (ob *OrderBook) ReceivedAt() time.Time {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.receivedAt
+}
+
+// Sequence 本地序号：每应用一条快照或价格变动加 1，在同一进程内单调递增（Reset 后继续累加）
+// 与服务端 hash 一起标识订单簿状态，下游可据此检测遗漏或乱序的导出记录
+func (ob *OrderBook) Sequence() uint64 {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.sequence
+}
+
 // IsInitialized 检查订单簿是否已初始化
 func (ob *OrderBook) IsInitialized() bool {
 	ob.mu.RLock()
@@ -103,47 +224,50 @@ func (ob *OrderBook) ApplyBookSnapshot(msg *BookMessage, ts int64) bool {
 	}
 
 	// 清空现有数据
-	ob.bids = make(map[string]decimal.Decimal)
-	ob.asks = make(map[string]decimal.Decimal)
+	ob.bids = make(map[fixed]fixed, len(msg.Bids))
+	ob.asks = make(map[fixed]fixed, len(msg.Asks))
 
 	// 应用买单
 	for _, bid := range msg.Bids {
-		price, err := decimal.NewFromString(bid.Price)
-		if err != nil {
+		price, ok := parseFixed(bid.Price)
+		if !ok {
 			continue
 		}
-		size, err := decimal.NewFromString(bid.Size)
-		if err != nil {
+		size, ok := parseFixed(bid.Size)
+		if !ok {
 			continue
 		}
-		if size.IsPositive() {
-			ob.bids[bid.Price] = size
+		if size > 0 {
+			ob.bids[price] = size
 		}
-		_ = price // 用于验证价格格式
 	}
 
 	// 应用卖单
 	for _, ask := range msg.Asks {
-		price, err := decimal.NewFromString(ask.Price)
-		if err != nil {
+		price, ok := parseFixed(ask.Price)
+		if !ok {
 			continue
 		}
-		size, err := decimal.NewFromString(ask.Size)
-		if err != nil {
+		size, ok := parseFixed(ask.Size)
+		if !ok {
 			continue
 		}
-		if size.IsPositive() {
-			ob.asks[ask.Price] = size
+		if size > 0 {
+			ob.asks[price] = size
 		}
-		_ = price
 	}
+	ob.bestBid = bestOf(ob.bids, higher)
+	ob.bestAsk = bestOf(ob.asks, lower)
 
 	ob.market = msg.Market
 	ob.hash = msg.Hash
 	ob.timestamp = ts
+	ob.receivedAt = time.Now()
+	ob.sequence++
 	ob.initialized = true
 	ob.bidsDirty = true
 	ob.asksDirty = true
+	ob.publishTopLocked()
 
 	return true
 }
@@ -163,29 +287,46 @@ func (ob *OrderBook) ApplyPriceChange(change *PriceChange, ts int64) bool {
 		return false
 	}
 
-	size, err := decimal.NewFromString(change.Size)
-	if err != nil {
+	size, ok := parseFixed(change.Size)
+	if !ok || size < 0 {
+		return false
+	}
+	price, ok := parseFixed(change.Price)
+	if !ok {
 		return false
 	}
 
 	if change.Side == SideBuy {
-		if size.IsZero() {
-			delete(ob.bids, change.Price)
+		if size == 0 {
+			delete(ob.bids, price)
 		} else {
-			ob.bids[change.Price] = size
+			ob.bids[price] = size
 		}
 		ob.bidsDirty = true
+
+		var rescan bool
+		if ob.bestBid, rescan = nextBest(ob.bestBid, price, size, higher); rescan {
+			ob.bestBid = bestOf(ob.bids, higher)
+		}
 	} else if change.Side == SideSell {
-		if size.IsZero() {
-			delete(ob.asks, change.Price)
+		if size == 0 {
+			delete(ob.asks, price)
 		} else {
-			ob.asks[change.Price] = size
+			ob.asks[price] = size
 		}
 		ob.asksDirty = true
+
+		var rescan bool
+		if ob.bestAsk, rescan = nextBest(ob.bestAsk, price, size, lower); rescan {
+			ob.bestAsk = bestOf(ob.asks, lower)
+		}
 	}
 
 	ob.hash = change.Hash
 	ob.timestamp = ts
+	ob.receivedAt = time.Now()
+	ob.sequence++
+	ob.publishTopLocked()
 
 	return true
 }
@@ -196,20 +337,8 @@ func (ob *OrderBook) rebuildSortedBids() {
 		return
 	}
 
-	ob.sortedBids = make([]OrderSummary, 0, len(ob.bids))
-	for priceStr, size := range ob.bids {
-		price, _ := decimal.NewFromString(priceStr)
-		ob.sortedBids = append(ob.sortedBids, OrderSummary{
-			Price: price,
-			Size:  size,
-		})
-	}
-
 	// 按价格降序排列
-	sort.Slice(ob.sortedBids, func(i, j int) bool {
-		return ob.sortedBids[i].Price.GreaterThan(ob.sortedBids[j].Price)
-	})
-
+	ob.sortedBids = sortLevels(ob.bids, higher)
 	ob.bidsDirty = false
 }
 
@@ -219,89 +348,62 @@ func (ob *OrderBook) rebuildSortedAsks() {
 		return
 	}
 
-	ob.sortedAsks = make([]OrderSummary, 0, len(ob.asks))
-	for priceStr, size := range ob.asks {
-		price, _ := decimal.NewFromString(priceStr)
-		ob.sortedAsks = append(ob.sortedAsks, OrderSummary{
-			Price: price,
-			Size:  size,
-		})
-	}
-
 	// 按价格升序排列
-	sort.Slice(ob.sortedAsks, func(i, j int) bool {
-		return ob.sortedAsks[i].Price.LessThan(ob.sortedAsks[j].Price)
-	})
-
+	ob.sortedAsks = sortLevels(ob.asks, lower)
 	ob.asksDirty = false
 }
 
-// GetBestBid 获取最优买价（包括量）
+// GetBestBid 获取最优买价（包括量），无锁读取最优价快照
 func (ob *OrderBook) GetBestBid() *BestPrice {
-	ob.mu.Lock()
-	defer ob.mu.Unlock()
-
-	if !ob.initialized || len(ob.bids) == 0 {
-		return nil
-	}
-
-	ob.rebuildSortedBids()
-	if len(ob.sortedBids) == 0 {
+	top := ob.top.Load()
+	if !top.initialized || top.bid == nil {
 		return nil
 	}
 
 	return &BestPrice{
-		Price: ob.sortedBids[0].Price,
-		Size:  ob.sortedBids[0].Size,
+		Side:  SideBuy,
+		Price: top.bid.Price,
+		Size:  top.bid.Size,
 	}
 }
 
-// GetBestAsk 获取最优卖价（包括量）
+// GetBestAsk 获取最优卖价（包括量），无锁读取最优价快照
 func (ob *OrderBook) GetBestAsk() *BestPrice {
-	ob.mu.Lock()
-	defer ob.mu.Unlock()
-
-	if !ob.initialized || len(ob.asks) == 0 {
-		return nil
-	}
-
-	ob.rebuildSortedAsks()
-	if len(ob.sortedAsks) == 0 {
+	top := ob.top.Load()
+	if !top.initialized || top.ask == nil {
 		return nil
 	}
 
 	return &BestPrice{
-		Price:     ob.sortedAsks[0].Price,
-		Size:      ob.sortedAsks[0].Size,
-		Timestamp: ob.timestamp,
+		Side:      SideSell,
+		Price:     top.ask.Price,
+		Size:      top.ask.Size,
+		Timestamp: top.timestamp,
 	}
 }
 
-// GetBBO 获取最优买卖价
+// GetBBO 获取最优买卖价，买卖两侧来自同一个快照
 func (ob *OrderBook) GetBBO() *BBO {
-	ob.mu.Lock()
-	defer ob.mu.Unlock()
-
-	if !ob.initialized {
+	top := ob.top.Load()
+	if !top.initialized {
 		return nil
 	}
 
-	ob.rebuildSortedBids()
-	ob.rebuildSortedAsks()
-
 	bbo := &BBO{}
 
-	if len(ob.sortedBids) > 0 {
+	if top.bid != nil {
 		bbo.BestBid = &BestPrice{
-			Price: ob.sortedBids[0].Price,
-			Size:  ob.sortedBids[0].Size,
+			Side:  SideBuy,
+			Price: top.bid.Price,
+			Size:  top.bid.Size,
 		}
 	}
 
-	if len(ob.sortedAsks) > 0 {
+	if top.ask != nil {
 		bbo.BestAsk = &BestPrice{
-			Price: ob.sortedAsks[0].Price,
-			Size:  ob.sortedAsks[0].Size,
+			Side:  SideSell,
+			Price: top.ask.Price,
+			Size:  top.ask.Size,
 		}
 	}
 
@@ -379,6 +481,47 @@ func (ob *OrderBook) GetDepth(depth int) (bids []OrderSummary, asks []OrderSumma
 	return bids, asks
 }
 
+// GetDepthExcluding 获取剔除指定挂单数量后的订单簿深度
+// ownBids/ownAsks: 价格（decimal 规范字符串）-> 需要剔除的数量
+// 剔除后数量小于等于 0 的档位不计入深度
+func (ob *OrderBook) GetDepthExcluding(depth int, ownBids, ownAsks map[string]decimal.Decimal) (bids []OrderSummary, asks []OrderSummary) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if !ob.initialized {
+		return nil, nil
+	}
+
+	ob.rebuildSortedBids()
+	ob.rebuildSortedAsks()
+
+	return excludeOwnLevels(ob.sortedBids, ownBids, depth), excludeOwnLevels(ob.sortedAsks, ownAsks, depth)
+}
+
+// excludeOwnLevels 从已排序档位中扣除自身挂单数量，最多返回 depth 档
+func excludeOwnLevels(levels []OrderSummary, own map[string]decimal.Decimal, depth int) []OrderSummary {
+	result := make([]OrderSummary, 0)
+	for _, level := range levels {
+		if len(result) >= depth {
+			break
+		}
+
+		size := level.Size
+		if ownSize, ok := own[priceKey(level.Price)]; ok {
+			size = size.Sub(ownSize)
+		}
+		if !size.IsPositive() {
+			continue
+		}
+
+		result = append(result, OrderSummary{
+			Price: level.Price,
+			Size:  size,
+		})
+	}
+	return result
+}
+
 // GetTotalBidSize 获取买单总量
 func (ob *OrderBook) GetTotalBidSize() decimal.Decimal {
 	ob.mu.RLock()
@@ -388,11 +531,11 @@ func (ob *OrderBook) GetTotalBidSize() decimal.Decimal {
 		return decimal.Zero
 	}
 
-	total := decimal.Zero
+	var total fixed
 	for _, size := range ob.bids {
-		total = total.Add(size)
+		total += size
 	}
-	return total
+	return total.Decimal()
 }
 
 // GetTotalAskSize 获取卖单总量
@@ -404,11 +547,11 @@ func (ob *OrderBook) GetTotalAskSize() decimal.Decimal {
 		return decimal.Zero
 	}
 
-	total := decimal.Zero
+	var total fixed
 	for _, size := range ob.asks {
-		total = total.Add(size)
+		total += size
 	}
-	return total
+	return total.Decimal()
 }
 
 // GetAllAsks 获取所有卖单（按价格升序）
@@ -443,8 +586,47 @@ func (ob *OrderBook) GetAllBids() []OrderSummary {
 	return result
 }
 
+// IterateBids 按价格降序遍历买单，fn 返回 false 时停止，未初始化时不调用 fn
+// 排序后的价位列表每次变化都重建为新切片，遍历的是取出时的不可变快照：不复制、不持锁，
+// fn 中可以继续访问订单簿，遍历期间的更新不影响本次遍历
+func (ob *OrderBook) IterateBids(fn func(level OrderSummary) bool) {
+	levels, _ := ob.sortedLevels(SideBuy)
+	iterateLevels(levels, fn)
+}
+
+// IterateAsks 按价格升序遍历卖单，fn 返回 false 时停止，未初始化时不调用 fn
+func (ob *OrderBook) IterateAsks(fn func(level OrderSummary) bool) {
+	levels, _ := ob.sortedLevels(SideSell)
+	iterateLevels(levels, fn)
+}
+
+// sortedLevels 获取一侧排序后价位列表的快照（调用者不得修改），未初始化时返回 false
+func (ob *OrderBook) sortedLevels(side Side) ([]OrderSummary, bool) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if !ob.initialized {
+		return nil, false
+	}
+	if side == SideBuy {
+		ob.rebuildSortedBids()
+		return ob.sortedBids, true
+	}
+	ob.rebuildSortedAsks()
+	return ob.sortedAsks, true
+}
+
+// iterateLevels 依次对价位调用 fn，返回 false 时停止
+func iterateLevels(levels []OrderSummary, fn func(level OrderSummary) bool) {
+	for _, level := range levels {
+		if !fn(level) {
+			return
+		}
+	}
+}
+
 // ScanAsksBelow 扫描价格低于等于 maxPrice 的所有卖单
-// 返回可成交的订单列表 + 总数量 + 加权平均价格
+// 返回可成交的订单列表 + 总数量 + 加权平均价格 + 累计金额 + 价位数
 func (ob *OrderBook) ScanAsksBelow(maxPrice decimal.Decimal) *ScanResult {
 	ob.mu.Lock()
 	defer ob.mu.Unlock()
@@ -456,6 +638,7 @@ func (ob *OrderBook) ScanAsksBelow(maxPrice decimal.Decimal) *ScanResult {
 	ob.rebuildSortedAsks()
 
 	result := &ScanResult{
+		Side:      SideSell,
 		Orders:    make([]OrderSummary, 0),
 		TotalSize: decimal.Zero,
 		AvgPrice:  decimal.Zero,
@@ -474,15 +657,18 @@ func (ob *OrderBook) ScanAsksBelow(maxPrice decimal.Decimal) *ScanResult {
 		}
 	}
 
+	result.Notional = totalValue
+	result.Levels = len(result.Orders)
 	if result.TotalSize.IsPositive() {
 		result.AvgPrice = totalValue.Div(result.TotalSize)
+		result.WorstPrice = result.Orders[len(result.Orders)-1].Price
 	}
 
 	return result
 }
 
 // ScanBidsAbove 扫描价格高于等于 minPrice 的所有买单
-// 返回可成交的订单列表 + 总数量 + 加权平均价格
+// 返回可成交的订单列表 + 总数量 + 加权平均价格 + 累计金额 + 价位数
 func (ob *OrderBook) ScanBidsAbove(minPrice decimal.Decimal) *ScanResult {
 	ob.mu.Lock()
 	defer ob.mu.Unlock()
@@ -494,6 +680,7 @@ func (ob *OrderBook) ScanBidsAbove(minPrice decimal.Decimal) *ScanResult {
 	ob.rebuildSortedBids()
 
 	result := &ScanResult{
+		Side:      SideBuy,
 		Orders:    make([]OrderSummary, 0),
 		TotalSize: decimal.Zero,
 		AvgPrice:  decimal.Zero,
@@ -512,8 +699,11 @@ func (ob *OrderBook) ScanBidsAbove(minPrice decimal.Decimal) *ScanResult {
 		}
 	}
 
+	result.Notional = totalValue
+	result.Levels = len(result.Orders)
 	if result.TotalSize.IsPositive() {
 		result.AvgPrice = totalValue.Div(result.TotalSize)
+		result.WorstPrice = result.Orders[len(result.Orders)-1].Price
 	}
 
 	return result
@@ -584,3 +774,87 @@ func (ob *OrderBook) SimulateBuyAsks(requiredSize decimal.Decimal) *FillResult {
 
 	return result
 }
+
+// ImpactEstimate 吃单冲击估算结果
+type ImpactEstimate struct {
+	Side        Side            // 吃单方向（BUY 吃卖单，SELL 吃买单）
+	FilledSize  decimal.Decimal // 可成交数量
+	RemainSize  decimal.Decimal // 深度不足时剩余未成交数量
+	IsFullFill  bool            // 是否完全成交
+	AvgPrice    decimal.Decimal // 加权平均成交价
+	WorstPrice  decimal.Decimal // 吃到的最差价位
+	MidPrice    decimal.Decimal // 成交前中间价，单边订单簿时为被吃一侧的最优价
+	SlippageBps float64         // 平均成交价相对 MidPrice 的不利偏移（基点），越大越差
+	PostBBO     *BBO            // 成交后的最优买卖价，被吃空的一侧为 nil
+}
+
+// EstimateImpact 估算以 size 数量立即吃单的冲击
+// 返回平均成交价、最差成交价位、成交后的 BBO 以及相对中间价的滑点，用于在主动吃单与被动挂单之间做选择
+// 订单簿未初始化、被吃一侧为空或 size 非正时返回 nil
+func (ob *OrderBook) EstimateImpact(side Side, size decimal.Decimal) *ImpactEstimate {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if !ob.initialized || !size.IsPositive() {
+		return nil
+	}
+
+	ob.rebuildSortedBids()
+	ob.rebuildSortedAsks()
+
+	levels, other := ob.sortedAsks, ob.sortedBids
+	if side == SideSell {
+		levels, other = ob.sortedBids, ob.sortedAsks
+	}
+	if len(levels) == 0 {
+		return nil
+	}
+
+	est := &ImpactEstimate{Side: side, MidPrice: levels[0].Price}
+	if len(other) > 0 {
+		est.MidPrice = levels[0].Price.Add(other[0].Price).Div(decimal.NewFromInt(2))
+	}
+
+	remaining := size
+	cost := decimal.Zero
+	var post *BestPrice
+	for _, level := range levels {
+		if !remaining.IsPositive() {
+			post = &BestPrice{Price: level.Price, Size: level.Size}
+			break
+		}
+		fill := decimal.Min(level.Size, remaining)
+		est.FilledSize = est.FilledSize.Add(fill)
+		cost = cost.Add(level.Price.Mul(fill))
+		remaining = remaining.Sub(fill)
+		est.WorstPrice = level.Price
+
+		// 本档未吃完，剩余部分成为新的最优价
+		if left := level.Size.Sub(fill); left.IsPositive() {
+			post = &BestPrice{Price: level.Price, Size: left}
+			break
+		}
+	}
+
+	est.RemainSize = remaining
+	est.IsFullFill = !remaining.IsPositive()
+	est.AvgPrice = cost.Div(est.FilledSize)
+
+	slippage := est.AvgPrice.Sub(est.MidPrice)
+	if side == SideSell {
+		slippage = slippage.Neg()
+	}
+	est.SlippageBps, _ = slippage.Div(est.MidPrice).Mul(decimal.NewFromInt(10000)).Float64()
+
+	var untouched *BestPrice
+	if len(other) > 0 {
+		untouched = &BestPrice{Price: other[0].Price, Size: other[0].Size}
+	}
+	if side == SideSell {
+		est.PostBBO = &BBO{BestBid: post, BestAsk: untouched}
+	} else {
+		est.PostBBO = &BBO{BestBid: untouched, BestAsk: post}
+	}
+
+	return est
+}
@@ -0,0 +1,839 @@
+package orderbook
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// newTestOrderBook 创建一个已应用快照的测试订单簿
+func newTestOrderBook(t *testing.T, bids, asks []RawOrderSummary) *OrderBook {
+	t.Helper()
+
+	ob := NewOrderBook("token-1")
+	msg := &BookMessage{
+		EventType: EventTypeBook,
+		AssetID:   "token-1",
+		Market:    "market-1",
+		Hash:      "hash-1",
+		Bids:      bids,
+		Asks:      asks,
+	}
+	if !ob.ApplyBookSnapshot(msg, 1000) {
+		t.Fatal("ApplyBookSnapshot() returned false")
+	}
+	return ob
+}
+
+func TestOrderBook_GetDepthExcluding(t *testing.T) {
+	ob := newTestOrderBook(t,
+		[]RawOrderSummary{{Price: "0.50", Size: "100"}, {Price: "0.49", Size: "20"}, {Price: "0.48", Size: "30"}},
+		[]RawOrderSummary{{Price: "0.52", Size: "40"}, {Price: "0.53", Size: "60"}},
+	)
+
+	ownBids := map[string]decimal.Decimal{
+		decimal.RequireFromString("0.5").String():  decimal.NewFromInt(40),
+		decimal.RequireFromString("0.49").String(): decimal.NewFromInt(20),
+	}
+	ownAsks := map[string]decimal.Decimal{
+		decimal.RequireFromString("0.52").String(): decimal.NewFromInt(50),
+	}
+
+	bids, asks := ob.GetDepthExcluding(2, ownBids, ownAsks)
+
+	if len(bids) != 2 {
+		t.Fatalf("len(bids) = %d, expected 2", len(bids))
+	}
+	if !bids[0].Price.Equal(decimal.RequireFromString("0.50")) || !bids[0].Size.Equal(decimal.NewFromInt(60)) {
+		t.Errorf("bids[0] = %s@%s, expected 60@0.50", bids[0].Size, bids[0].Price)
+	}
+	// 0.49 档位全部是自己的挂单，应被跳过
+	if !bids[1].Price.Equal(decimal.RequireFromString("0.48")) {
+		t.Errorf("bids[1].Price = %s, expected 0.48", bids[1].Price)
+	}
+
+	if len(asks) != 1 {
+		t.Fatalf("len(asks) = %d, expected 1", len(asks))
+	}
+	if !asks[0].Price.Equal(decimal.RequireFromString("0.53")) {
+		t.Errorf("asks[0].Price = %s, expected 0.53", asks[0].Price)
+	}
+}
+
+func TestOrderBook_GetDepthExcluding_NoOwnOrders(t *testing.T) {
+	ob := newTestOrderBook(t,
+		[]RawOrderSummary{{Price: "0.50", Size: "100"}},
+		[]RawOrderSummary{{Price: "0.52", Size: "40"}},
+	)
+
+	bids, asks := ob.GetDepthExcluding(10, nil, nil)
+	if len(bids) != 1 || len(asks) != 1 {
+		t.Fatalf("expected 1 bid and 1 ask, got %d and %d", len(bids), len(asks))
+	}
+	if !bids[0].Size.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("bids[0].Size = %s, expected 100", bids[0].Size)
+	}
+}
+
+func TestOrderBook_GetDepthExcluding_NotInitialized(t *testing.T) {
+	ob := NewOrderBook("token-1")
+	bids, asks := ob.GetDepthExcluding(10, nil, nil)
+	if bids != nil || asks != nil {
+		t.Error("GetDepthExcluding() should return nil before initialization")
+	}
+}
+
+func TestManager_SetOwnOrders(t *testing.T) {
+	m := NewManager(nil)
+
+	m.SetOwnOrders("token-1", []OwnOrder{
+		{Side: SideBuy, Price: decimal.RequireFromString("0.50"), Size: decimal.NewFromInt(10)},
+		{Side: SideBuy, Price: decimal.RequireFromString("0.5"), Size: decimal.NewFromInt(5)},
+		{Side: SideSell, Price: decimal.RequireFromString("0.60"), Size: decimal.NewFromInt(7)},
+	})
+
+	bids, asks := m.GetOwnOrderLevels("token-1")
+	if !bids["0.5"].Equal(decimal.NewFromInt(15)) {
+		t.Errorf("own bid at 0.5 = %s, expected 15", bids["0.5"])
+	}
+	if !asks["0.6"].Equal(decimal.NewFromInt(7)) {
+		t.Errorf("own ask at 0.6 = %s, expected 7", asks["0.6"])
+	}
+
+	m.SetOwnOrders("token-1", nil)
+	bids, asks = m.GetOwnOrderLevels("token-1")
+	if len(bids) != 0 || len(asks) != 0 {
+		t.Error("SetOwnOrders(nil) should clear own orders")
+	}
+}
+
+func TestManager_TokenMetadataInUpdates(t *testing.T) {
+	m := NewManager(nil)
+	m.orderBooks["token-1"] = NewOrderBook("token-1")
+	m.orderBooks["token-2"] = NewOrderBook("token-2")
+
+	m.SetTokenMetadata("token-1", TokenMetadata{MarketSlug: "will-it-rain", Outcome: "Yes", NegRisk: true})
+
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"1000","bids":[{"price":"0.5","size":"10"}],"asks":[]}`), time.Time{})
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-2","timestamp":"1000","bids":[{"price":"0.5","size":"10"}],"asks":[]}`), time.Time{})
+
+	update := <-m.Updates()
+	if update.Metadata == nil {
+		t.Fatal("update for token-1 should carry metadata")
+	}
+	if update.Metadata.MarketSlug != "will-it-rain" || update.Metadata.Outcome != "Yes" || !update.Metadata.NegRisk {
+		t.Errorf("unexpected metadata: %+v", update.Metadata)
+	}
+
+	if update = <-m.Updates(); update.EventType != EventTypeInitialized || update.Metadata == nil {
+		t.Errorf("initialized event for token-1 should carry metadata, got %+v", update)
+	}
+
+	update = <-m.Updates()
+	if update.Metadata != nil {
+		t.Errorf("update for token-2 should not carry metadata, got %+v", update.Metadata)
+	}
+
+	if err := m.Unsubscribe([]string{"token-1"}); err != nil {
+		t.Fatalf("Unsubscribe() error: %v", err)
+	}
+	if _, ok := m.GetTokenMetadata("token-1"); ok {
+		t.Error("Unsubscribe should clear token metadata")
+	}
+}
+
+func TestManager_EventFilter(t *testing.T) {
+	m := NewManager(nil)
+	for _, id := range []string{"book-token", "trade-token"} {
+		m.orderBooks[id] = NewOrderBook(id)
+		m.subscribedTokens[id] = true
+	}
+
+	m.SetEventFilter("trade-token", EventTypeLastTradePrice)
+
+	if got := m.GetEventFilter("book-token"); len(got) != 2 {
+		t.Errorf("default filter should be book + price_change, got %v", got)
+	}
+	if got := m.GetEventFilter("trade-token"); len(got) != 1 || got[0] != EventTypeLastTradePrice {
+		t.Errorf("unexpected filter: %v", got)
+	}
+
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"trade-token","timestamp":"1000","bids":[{"price":"0.5","size":"10"}],"asks":[]}`), time.Time{})
+	m.handleLastTradePriceMessage([]byte(`{"event_type":"last_trade_price","asset_id":"book-token","price":"0.5","size":"1","side":"BUY","timestamp":"1001"}`), time.Time{})
+	m.handleLastTradePriceMessage([]byte(`{"event_type":"last_trade_price","asset_id":"trade-token","price":"0.55","size":"3","side":"SELL","timestamp":"1002"}`), time.Time{})
+
+	select {
+	case update := <-m.Updates():
+		if update.TokenID != "trade-token" || update.EventType != EventTypeLastTradePrice {
+			t.Fatalf("unexpected update: %+v", update)
+		}
+		if update.LastTrade == nil || update.LastTrade.Price != "0.55" || update.Timestamp != 1002 {
+			t.Errorf("unexpected last trade: %+v", update.LastTrade)
+		}
+	default:
+		t.Fatal("expected last_trade_price update")
+	}
+
+	select {
+	case update := <-m.Updates():
+		t.Errorf("unexpected extra update: %+v", update)
+	default:
+	}
+
+	if m.GetOrderBook("trade-token").IsInitialized() {
+		t.Error("trade-only token should not maintain an order book")
+	}
+
+	// Restoring the default filter resumes book updates
+	m.SetEventFilter("trade-token")
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"trade-token","timestamp":"1003","bids":[{"price":"0.5","size":"10"}],"asks":[]}`), time.Time{})
+	if update := <-m.Updates(); update.EventType != EventTypeBook {
+		t.Errorf("expected book update, got %+v", update)
+	}
+}
+
+func TestOrderBook_EstimateImpact(t *testing.T) {
+	d := decimal.RequireFromString
+	ob := newTestOrderBook(t,
+		[]RawOrderSummary{{Price: "0.50", Size: "100"}, {Price: "0.49", Size: "20"}},
+		[]RawOrderSummary{{Price: "0.52", Size: "40"}, {Price: "0.53", Size: "60"}},
+	)
+
+	// 40 @ 0.52 + 10 @ 0.53 -> avg 0.522, mid 0.51
+	est := ob.EstimateImpact(SideBuy, d("50"))
+	if est == nil || !est.IsFullFill || !est.AvgPrice.Equal(d("0.522")) || !est.WorstPrice.Equal(d("0.53")) || !est.MidPrice.Equal(d("0.51")) {
+		t.Fatalf("buy estimate = %+v", est)
+	}
+	if est.SlippageBps < 235.2 || est.SlippageBps > 235.3 {
+		t.Errorf("SlippageBps = %f, expected ~235.29", est.SlippageBps)
+	}
+	if ask := est.PostBBO.BestAsk; ask == nil || !ask.Price.Equal(d("0.53")) || !ask.Size.Equal(d("50")) {
+		t.Errorf("post-trade ask = %+v", ask)
+	}
+	if bid := est.PostBBO.BestBid; bid == nil || !bid.Price.Equal(d("0.50")) {
+		t.Errorf("post-trade bid = %+v", bid)
+	}
+
+	// Consuming a level exactly exposes the next one
+	est = ob.EstimateImpact(SideBuy, d("40"))
+	if ask := est.PostBBO.BestAsk; ask == nil || !ask.Price.Equal(d("0.53")) || !ask.Size.Equal(d("60")) || est.SlippageBps <= 0 {
+		t.Errorf("exact level estimate = %+v, ask %+v", est, ask)
+	}
+
+	// Selling through the whole bid side
+	est = ob.EstimateImpact(SideSell, d("130"))
+	if est.IsFullFill || !est.RemainSize.Equal(d("10")) || !est.WorstPrice.Equal(d("0.49")) || est.PostBBO.BestBid != nil {
+		t.Errorf("sell estimate = %+v", est)
+	}
+	if est.SlippageBps < 228.7 || est.SlippageBps > 228.8 {
+		t.Errorf("SlippageBps = %f, expected ~228.76", est.SlippageBps)
+	}
+
+	if ob.EstimateImpact(SideBuy, decimal.Zero) != nil {
+		t.Error("expected nil for non-positive size")
+	}
+}
+
+func TestManager_GapStatsAndResync(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxTimestampGapMs = 5000
+	m := NewManager(config)
+	m.orderBooks["token-1"] = NewOrderBook("token-1")
+	m.subscribedTokens["token-1"] = true
+
+	priceChange := func(ts int, price string) []byte {
+		return []byte(`{"event_type":"price_change","timestamp":"` + strconv.Itoa(ts) + `","price_changes":[{"asset_id":"token-1","price":"` + price + `","size":"5","side":"BUY"}]}`)
+	}
+
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"1000","bids":[{"price":"0.5","size":"10"}],"asks":[]}`), time.Time{})
+	m.handlePriceChangeMessage(priceChange(2000, "0.45"), time.Time{})
+	m.handlePriceChangeMessage(priceChange(1500, "0.44"), time.Time{}) // out of order
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"1200","bids":[],"asks":[]}`), time.Time{}) // stale snapshot
+
+	stats, ok := m.GetGapStats("token-1")
+	if !ok {
+		t.Fatal("expected gap stats")
+	}
+	if stats.Updates != 2 || stats.OutOfOrder != 2 || stats.MaxGapMs != 1000 || stats.Gaps != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// A gap above the bound resets the book and buffers the delta until a new snapshot
+	m.handlePriceChangeMessage(priceChange(9000, "0.46"), time.Time{})
+
+	stats, _ = m.GetGapStats("token-1")
+	if stats.Gaps != 1 || stats.Resyncs != 1 || stats.LastGapMs != 7000 {
+		t.Errorf("unexpected stats after gap: %+v", stats)
+	}
+	if m.IsInitialized("token-1") {
+		t.Error("book should be reset after a resync")
+	}
+	if len(m.pendingChanges["token-1"]) != 1 {
+		t.Errorf("expected the delta to be buffered, got %d pending", len(m.pendingChanges["token-1"]))
+	}
+
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"8900","bids":[{"price":"0.5","size":"10"}],"asks":[]}`), time.Time{})
+	if !m.IsInitialized("token-1") {
+		t.Error("book should be initialized after the new snapshot")
+	}
+	if bids, _ := m.GetOrderBook("token-1").GetDepth(10); len(bids) != 2 {
+		t.Errorf("expected buffered delta to be applied, got bids %v", bids)
+	}
+}
+
+func TestManager_DropsDuplicateMessages(t *testing.T) {
+	m := NewManager(nil)
+	m.orderBooks["token-1"] = NewOrderBook("token-1")
+	m.subscribedTokens["token-1"] = true
+
+	book := []byte(`{"event_type":"book","asset_id":"token-1","hash":"h1","timestamp":"1000","bids":[{"price":"0.5","size":"10"}],"asks":[]}`)
+	change := []byte(`{"event_type":"price_change","timestamp":"1100","price_changes":[{"asset_id":"token-1","price":"0.45","size":"5","side":"BUY","hash":"h2"}]}`)
+
+	// The same messages delivered by two connections are applied once
+	m.handleBookMessage(book, time.Time{})
+	m.handleBookMessage(book, time.Time{})
+	m.handlePriceChangeMessage(change, time.Time{})
+	m.handlePriceChangeMessage(change, time.Time{})
+
+	stats, _ := m.GetGapStats("token-1")
+	if stats.Duplicates != 2 || stats.Updates != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if bids, _ := m.GetOrderBook("token-1").GetDepth(10); len(bids) != 2 {
+		t.Errorf("expected 2 bid levels, got %v", bids)
+	}
+
+	// A reset book accepts the same snapshot again
+	m.GetOrderBook("token-1").Reset()
+	delete(m.recentMessages, "token-1")
+	m.handleBookMessage(book, time.Time{})
+	if !m.IsInitialized("token-1") {
+		t.Error("book should be initialized from the repeated snapshot after reset")
+	}
+}
+
+func TestManager_PriceChangeLevelsInOneFrame(t *testing.T) {
+	m := NewManager(nil)
+	m.orderBooks["token-1"] = NewOrderBook("token-1")
+	m.subscribedTokens["token-1"] = true
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","hash":"h1","timestamp":"1000","bids":[{"price":"0.5","size":"10"}],"asks":[{"price":"0.6","size":"10"}]}`), time.Time{})
+
+	// Two levels of the same asset share the frame's hash and timestamp
+	change := []byte(`{"event_type":"price_change","timestamp":"1100","price_changes":[` +
+		`{"asset_id":"token-1","price":"0.45","size":"5","side":"BUY","hash":"h2"},` +
+		`{"asset_id":"token-1","price":"0.44","size":"7","side":"BUY","hash":"h2"},` +
+		`{"asset_id":"token-1","price":"0.65","size":"3","side":"SELL","hash":"h2"}]}`)
+	m.handlePriceChangeMessage(change, time.Time{})
+
+	stats, _ := m.GetGapStats("token-1")
+	if stats.Duplicates != 0 {
+		t.Errorf("distinct levels counted as duplicates: %+v", stats)
+	}
+	if bids, _ := m.GetOrderBook("token-1").GetDepth(10); len(bids) != 3 {
+		t.Errorf("expected 3 bid levels, got %v", bids)
+	}
+
+	// Replaying the whole frame is still dropped
+	m.handlePriceChangeMessage(change, time.Time{})
+	stats, _ = m.GetGapStats("token-1")
+	if stats.Duplicates != 3 {
+		t.Errorf("Duplicates = %d, expected 3", stats.Duplicates)
+	}
+}
+
+func TestManager_GapStatsWithoutResync(t *testing.T) {
+	m := NewManager(nil)
+	m.orderBooks["token-1"] = NewOrderBook("token-1")
+	m.subscribedTokens["token-1"] = true
+
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"1000","bids":[{"price":"0.5","size":"10"}],"asks":[]}`), time.Time{})
+	m.handlePriceChangeMessage([]byte(`{"event_type":"price_change","timestamp":"100000","price_changes":[{"asset_id":"token-1","price":"0.4","size":"5","side":"BUY"}]}`), time.Time{})
+
+	stats, _ := m.GetGapStats("token-1")
+	if stats.Resyncs != 0 || stats.MaxGapMs != 99000 || !m.IsInitialized("token-1") {
+		t.Errorf("gap tracking should not resync when disabled: %+v", stats)
+	}
+}
+
+func TestManager_CloseWhileHandling(t *testing.T) {
+	config := DefaultConfig()
+	config.UpdateChannelSize = 4
+	m := NewManager(config)
+	m.orderBooks["token-1"] = NewOrderBook("token-1")
+	m.subscribedTokens["token-1"] = true
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				ts := strconv.Itoa(1000 + worker*1000 + j)
+				m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"` + ts + `","bids":[{"price":"0.5","size":"10"}],"asks":[]}`), time.Time{})
+			}
+		}(i)
+	}
+
+	consumed := make(chan struct{})
+	go func() {
+		defer close(consumed)
+		for range m.Updates() {
+		}
+	}()
+
+	m.Close()
+	m.Close() // idempotent
+	wg.Wait()
+
+	select {
+	case <-m.Done():
+	default:
+		t.Fatal("Done() should be closed after Close")
+	}
+	select {
+	case <-consumed:
+	case <-time.After(time.Second):
+		t.Fatal("range over Updates() should end after Close")
+	}
+	if _, ok := <-m.Updates(); ok {
+		t.Error("Updates() should report closed")
+	}
+}
+
+func TestManager_UpdateReceiveTime(t *testing.T) {
+	m := NewManager(nil)
+	m.orderBooks["token-1"] = NewOrderBook("token-1")
+	m.subscribedTokens["token-1"] = true
+
+	exchangeTs := time.Now().Add(-250 * time.Millisecond).UnixMilli()
+	before := time.Now()
+	m.handleMessage([]byte(`[{"event_type":"book","asset_id":"token-1","timestamp":"` + strconv.FormatInt(exchangeTs, 10) + `","bids":[{"price":"0.5","size":"10"}],"asks":[]},` +
+		`{"event_type":"price_change","timestamp":"` + strconv.FormatInt(exchangeTs+1, 10) + `","price_changes":[{"asset_id":"token-1","price":"0.4","size":"5","side":"BUY"}]}]`))
+
+	book := <-m.Updates()
+	<-m.Updates() // initialized
+	change := <-m.Updates()
+
+	// Messages in one frame share the frame's receive time
+	if book.ReceivedAt.Before(before) || !book.ReceivedAt.Equal(change.ReceivedAt) {
+		t.Errorf("ReceivedAt = %v / %v, expected a shared time after %v", book.ReceivedAt, change.ReceivedAt, before)
+	}
+	if !book.Time().Equal(time.UnixMilli(exchangeTs)) {
+		t.Errorf("Time() = %v, expected %v", book.Time(), time.UnixMilli(exchangeTs))
+	}
+	if delay := book.NetworkDelay(); delay < 250*time.Millisecond || delay > 5*time.Second {
+		t.Errorf("NetworkDelay() = %v, expected about 250ms", delay)
+	}
+	if !m.GetOrderBook("token-1").Time().Equal(change.Time()) {
+		t.Errorf("order book Time() = %v, expected %v", m.GetOrderBook("token-1").Time(), change.Time())
+	}
+
+	if !TimeFromMillis(0).IsZero() || (OrderBookUpdate{ReceivedAt: time.Now()}).NetworkDelay() != 0 {
+		t.Error("missing exchange timestamp should yield zero time and delay")
+	}
+}
+
+func TestManager_LifecycleEvents(t *testing.T) {
+	m := NewManager(nil)
+	subscribeLocally(m, "token-1")
+
+	next := func() OrderBookUpdate {
+		t.Helper()
+		select {
+		case update := <-m.Updates():
+			return update
+		default:
+			t.Fatal("expected an update")
+			return OrderBookUpdate{}
+		}
+	}
+	expectNone := func() {
+		t.Helper()
+		select {
+		case update := <-m.Updates():
+			t.Fatalf("unexpected update %+v", update)
+		default:
+		}
+	}
+
+	m.handleBookMessage(bookFor("token-1"), time.Time{})
+	if update := next(); update.EventType != EventTypeBook {
+		t.Fatalf("expected book, got %s", update.EventType)
+	}
+	if update := next(); update.EventType != EventTypeInitialized || update.TokenID != "token-1" || update.Timestamp != 1000 {
+		t.Fatalf("expected initialized, got %+v", update)
+	}
+	next() // snapshot batch complete
+
+	// A resync clears the book; resetting an already empty book is silent
+	m.Resync([]string{"token-1"})
+	if update := next(); update.EventType != EventTypeReset || update.ResetReason != ResetReasonResync {
+		t.Fatalf("expected reset by resync, got %+v", update)
+	}
+	m.Resync([]string{"token-1"})
+	expectNone()
+
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"2000","bids":[],"asks":[]}`), time.Time{})
+	next()
+	if update := next(); update.EventType != EventTypeInitialized {
+		t.Fatalf("expected initialized after the resync snapshot, got %+v", update)
+	}
+
+	m.SetEventFilter("token-1", EventTypeLastTradePrice)
+	if update := next(); update.EventType != EventTypeReset || update.ResetReason != ResetReasonFilter {
+		t.Fatalf("expected reset by filter, got %+v", update)
+	}
+
+	if err := m.Unsubscribe([]string{"token-1", "never-subscribed"}); err != nil {
+		t.Fatal(err)
+	}
+	if update := next(); update.EventType != EventTypeRemoved || update.TokenID != "token-1" {
+		t.Fatalf("expected removed, got %+v", update)
+	}
+	expectNone()
+}
+
+func TestManager_LifecycleEventsSurviveFullChannel(t *testing.T) {
+	config := DefaultConfig()
+	config.UpdateChannelSize = 3
+	m := NewManager(config)
+	send := func(tokenID string, eventType EventType) {
+		m.mu.Lock()
+		m.sendUpdate(OrderBookUpdate{TokenID: tokenID, EventType: eventType})
+		m.mu.Unlock()
+	}
+
+	// A full channel evicts the oldest market update, never a lifecycle event
+	send("token-1", EventTypeReset)
+	send("token-1", EventTypeBook)
+	send("token-1", EventTypePriceChange)
+	send("token-1", EventTypeInitialized)
+	send("token-2", EventTypeRemoved)
+	// Only lifecycle events are buffered now: market updates are dropped
+	send("token-3", EventTypeBook)
+
+	// A lifecycle event waits for the consumer instead of being dropped
+	sent := make(chan struct{})
+	go func() {
+		send("token-3", EventTypeSnapshotBatchComplete)
+		close(sent)
+	}()
+	select {
+	case <-sent:
+		t.Fatal("lifecycle event should wait while the channel is full of lifecycle events")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	expected := []struct {
+		tokenID   string
+		eventType EventType
+	}{
+		{"token-1", EventTypeReset},
+		{"token-1", EventTypeInitialized},
+		{"token-2", EventTypeRemoved},
+		{"token-3", EventTypeSnapshotBatchComplete},
+	}
+	for i, want := range expected {
+		update := <-m.Updates()
+		if update.TokenID != want.tokenID || update.EventType != want.eventType {
+			t.Fatalf("update %d = %s/%s, expected %s/%s", i, update.TokenID, update.EventType, want.tokenID, want.eventType)
+		}
+	}
+	<-sent
+
+	// Close releases a blocked lifecycle send
+	send("token-4", EventTypeReset)
+	send("token-4", EventTypeReset)
+	send("token-4", EventTypeReset)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		m.Close()
+	}()
+	send("token-4", EventTypeRemoved)
+}
+
+func TestOrderBook_TopOfBookTracksWrites(t *testing.T) {
+	ob := newTestOrderBook(t,
+		[]RawOrderSummary{{Price: "0.40", Size: "10"}, {Price: "0.45", Size: "5"}},
+		[]RawOrderSummary{{Price: "0.55", Size: "7"}, {Price: "0.60", Size: "3"}},
+	)
+
+	steps := []struct {
+		change  PriceChange
+		bestBid string
+		bestAsk string
+	}{
+		{PriceChange{Price: "0.46", Size: "1", Side: SideBuy}, "0.46", "0.55"},
+		{PriceChange{Price: "0.55", Size: "2", Side: SideSell}, "0.46", "0.55"},
+		{PriceChange{Price: "0.55", Size: "0", Side: SideSell}, "0.46", "0.6"},
+		{PriceChange{Price: "0.46", Size: "0", Side: SideBuy}, "0.45", "0.6"},
+		{PriceChange{Price: "0.50", Size: "0", Side: SideBuy}, "0.45", "0.6"},
+	}
+	for i, step := range steps {
+		if !ob.ApplyPriceChange(&step.change, int64(2000+i)) {
+			t.Fatalf("step %d: change rejected", i)
+		}
+		bbo := ob.GetBBO()
+		if bbo.BestBid.Price.String() != step.bestBid || bbo.BestAsk.Price.String() != step.bestAsk {
+			t.Errorf("step %d: BBO = %s/%s, want %s/%s", i, bbo.BestBid.Price, bbo.BestAsk.Price, step.bestBid, step.bestAsk)
+		}
+	}
+	if ask := ob.GetBestAsk(); !ask.Size.Equal(decimal.NewFromInt(3)) || ask.Timestamp != 2004 {
+		t.Errorf("best ask = %+v", ask)
+	}
+
+	ob.ApplyPriceChange(&PriceChange{Price: "0.60", Size: "0", Side: SideSell}, 2010)
+	if ask := ob.GetBestAsk(); ask != nil {
+		t.Errorf("empty ask side should have no best ask, got %+v", ask)
+	}
+
+	ob.Reset()
+	if ob.GetBestBid() != nil || ob.GetBBO() != nil {
+		t.Error("reset book should have no top of book")
+	}
+}
+
+func TestOrderBook_TopOfBookConcurrentReads(t *testing.T) {
+	ob := newTestOrderBook(t,
+		[]RawOrderSummary{{Price: "0.40", Size: "10"}},
+		[]RawOrderSummary{{Price: "0.60", Size: "10"}},
+	)
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				// Both sides come from one snapshot, so the book is never crossed
+				if bbo := ob.GetBBO(); bbo != nil && bbo.BestBid != nil && bbo.BestAsk != nil && !bbo.BestBid.Price.LessThan(bbo.BestAsk.Price) {
+					t.Errorf("crossed BBO %s/%s", bbo.BestBid.Price, bbo.BestAsk.Price)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 1000; i++ {
+		price := "0.5" + strconv.Itoa(i%10)
+		ob.ApplyPriceChange(&PriceChange{Price: price, Size: "1", Side: SideSell}, int64(2000+2*i))
+		ob.ApplyPriceChange(&PriceChange{Price: price, Size: "0", Side: SideSell}, int64(2001+2*i))
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestOrderBook_NormalizesPriceKeys(t *testing.T) {
+	// The snapshot repeats one bid level with a different formatting; the later entry wins
+	ob := newTestOrderBook(t,
+		[]RawOrderSummary{{Price: "0.50", Size: "10"}, {Price: ".5", Size: "12"}, {Price: "0.4", Size: "5"}},
+		[]RawOrderSummary{{Price: "0.6", Size: "7"}},
+	)
+	if bids := ob.GetAllBids(); len(bids) != 2 || !bids[0].Size.Equal(decimal.NewFromInt(12)) {
+		t.Fatalf("bids = %v", bids)
+	}
+
+	steps := []PriceChange{
+		{Price: "0.5", Size: "20", Side: SideBuy},    // updates the "0.50" level
+		{Price: "0.400", Size: "0", Side: SideBuy},   // removes the "0.4" level
+		{Price: "0.60000", Size: "9", Side: SideSell}, // updates the "0.6" level
+	}
+	for i, change := range steps {
+		if !ob.ApplyPriceChange(&change, int64(2000+i)) {
+			t.Fatalf("step %d: change rejected", i)
+		}
+	}
+
+	bids, asks := ob.GetAllBids(), ob.GetAllAsks()
+	if len(bids) != 1 || bids[0].Price.String() != "0.5" || !bids[0].Size.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("bids = %v", bids)
+	}
+	if len(asks) != 1 || !asks[0].Size.Equal(decimal.NewFromInt(9)) {
+		t.Errorf("asks = %v", asks)
+	}
+
+	// Removing the best level under another formatting rescans the side
+	ob.ApplyPriceChange(&PriceChange{Price: "0.500", Size: "0", Side: SideBuy}, 2010)
+	if bid := ob.GetBestBid(); bid != nil {
+		t.Errorf("best bid should be gone, got %+v", bid)
+	}
+
+	// Own orders are matched against book levels by value as well
+	ob.ApplyPriceChange(&PriceChange{Price: "0.45", Size: "30", Side: SideBuy}, 2011)
+	bids, _ = ob.GetDepthExcluding(5, map[string]decimal.Decimal{priceKey(decimal.RequireFromString("0.450")): decimal.NewFromInt(10)}, nil)
+	if len(bids) != 1 || !bids[0].Size.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("depth excluding own = %v", bids)
+	}
+}
+
+func TestManager_BBOOnly(t *testing.T) {
+	config := DefaultConfig()
+	config.BBOOnly = true
+	m := NewManager(config)
+	m.orderBooks["token-1"] = NewOrderBook("token-1")
+	m.subscribedTokens["token-1"] = true
+
+	change := func(ts int, price, size, side string) {
+		m.handlePriceChangeMessage([]byte(`{"event_type":"price_change","timestamp":"`+strconv.Itoa(ts)+`","price_changes":[{"asset_id":"token-1","price":"`+price+`","size":"`+size+`","side":"`+side+`"}]}`), time.Time{})
+	}
+	drain := func() []EventType {
+		var events []EventType
+		for {
+			select {
+			case update := <-m.Updates():
+				events = append(events, update.EventType)
+			default:
+				return events
+			}
+		}
+	}
+
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"1000","bids":[{"price":"0.50","size":"10"},{"price":"0.49","size":"20"}],"asks":[{"price":"0.52","size":"10"}]}`), time.Time{})
+	if got := drain(); len(got) != 2 || got[0] != EventTypeBook || got[1] != EventTypeInitialized {
+		t.Fatalf("first snapshot should always be emitted, got %v", got)
+	}
+
+	change(1001, "0.49", "30", "BUY") // deeper level
+	change(1002, "0.45", "5", "BUY")  // new deeper level
+	if got := drain(); len(got) != 0 {
+		t.Errorf("depth-only changes should not be emitted, got %v", got)
+	}
+
+	change(1003, "0.50", "15", "BUY") // best bid size
+	change(1004, "0.51", "5", "SELL") // best ask price
+	if got := drain(); len(got) != 2 || got[0] != EventTypePriceChange || got[1] != EventTypePriceChange {
+		t.Errorf("BBO changes should be emitted, got %v", got)
+	}
+
+	// An identical snapshot after a resync is emitted again
+	m.Resync([]string{"token-1"})
+	drain()
+	m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"token-1","timestamp":"1005","bids":[{"price":"0.50","size":"15"}],"asks":[{"price":"0.51","size":"5"}]}`), time.Time{})
+	if got := drain(); len(got) == 0 || got[0] != EventTypeBook {
+		t.Errorf("snapshot after reset should be emitted, got %v", got)
+	}
+}
+
+func TestOrderBook_IterateLevels(t *testing.T) {
+	ob := newTestOrderBook(t,
+		[]RawOrderSummary{{Price: "0.48", Size: "30"}, {Price: "0.50", Size: "10"}, {Price: "0.49", Size: "20"}},
+		[]RawOrderSummary{{Price: "0.53", Size: "60"}, {Price: "0.52", Size: "40"}},
+	)
+
+	var bids []string
+	ob.IterateBids(func(level OrderSummary) bool {
+		bids = append(bids, level.Price.String())
+		return len(bids) < 2
+	})
+	if len(bids) != 2 || bids[0] != "0.5" || bids[1] != "0.49" {
+		t.Errorf("IterateBids() = %v, expected [0.5 0.49]", bids)
+	}
+
+	// The callback may read and update the book, the running iteration keeps its snapshot
+	var asks []string
+	ob.IterateAsks(func(level OrderSummary) bool {
+		asks = append(asks, level.Price.String())
+		if len(asks) == 1 {
+			ob.ApplyPriceChange(&PriceChange{Price: "0.53", Size: "0", Side: SideSell}, 1001)
+			ob.GetAllAsks()
+		}
+		return true
+	})
+	if len(asks) != 2 || asks[1] != "0.53" {
+		t.Errorf("IterateAsks() = %v, expected [0.52 0.53]", asks)
+	}
+	if all := ob.GetAllAsks(); len(all) != 1 {
+		t.Errorf("GetAllAsks() after removal = %v", all)
+	}
+
+	ob.Reset()
+	ob.IterateBids(func(OrderSummary) bool {
+		t.Error("uninitialized book should not be iterated")
+		return false
+	})
+}
+
+func TestBestPrice_CarriesSide(t *testing.T) {
+	ob := newTestOrderBook(t,
+		[]RawOrderSummary{{Price: "0.45", Size: "5"}},
+		[]RawOrderSummary{{Price: "0.55", Size: "7"}},
+	)
+
+	bbo := ob.GetBBO()
+	if bbo.BestBid.Side != SideBuy || bbo.BestAsk.Side != SideSell {
+		t.Errorf("BBO sides = %s/%s", bbo.BestBid.Side, bbo.BestAsk.Side)
+	}
+	if ob.GetBestBid().Side != SideBuy || ob.GetBestAsk().Side != SideSell {
+		t.Error("best prices should carry their side")
+	}
+	if s := ob.summary(); s.BestBid.Side != SideBuy || s.BestAsk.Side != SideSell {
+		t.Errorf("summary sides = %s/%s", s.BestBid.Side, s.BestAsk.Side)
+	}
+
+	// a taker order consumes the opposite side of the book
+	if bbo.BestAsk.Side != SideBuy.Opposite() {
+		t.Error("buy orders should match against asks")
+	}
+}
+
+func TestScanResult_DepthAndMarginalPrice(t *testing.T) {
+	ob := newTestOrderBook(t,
+		[]RawOrderSummary{{Price: "0.48", Size: "100"}, {Price: "0.47", Size: "50"}, {Price: "0.40", Size: "10"}},
+		[]RawOrderSummary{{Price: "0.52", Size: "10"}, {Price: "0.53", Size: "20"}, {Price: "0.60", Size: "500"}},
+	)
+
+	asks := ob.ScanAsksBelow(decimal.RequireFromString("0.55"))
+	if asks.Side != SideSell || asks.Levels != 2 || !asks.TotalSize.Equal(decimal.NewFromInt(30)) {
+		t.Fatalf("unexpected ask scan: %+v", asks)
+	}
+	// 0.52*10 + 0.53*20
+	if !asks.Notional.Equal(decimal.RequireFromString("15.8")) || !asks.WorstPrice.Equal(decimal.RequireFromString("0.53")) {
+		t.Errorf("Notional = %s, WorstPrice = %s", asks.Notional, asks.WorstPrice)
+	}
+
+	tests := []struct {
+		size  string
+		price string
+		ok    bool
+	}{
+		{"5", "0.52", true},
+		{"10", "0.52", true},
+		{"10.5", "0.53", true},
+		{"30", "0.53", true},
+		{"31", "0", false},
+		{"0", "0", false},
+	}
+	for _, tt := range tests {
+		price, ok := asks.MarginalPrice(decimal.RequireFromString(tt.size))
+		if ok != tt.ok || !price.Equal(decimal.RequireFromString(tt.price)) {
+			t.Errorf("MarginalPrice(%s) = %s, %v, expected %s, %v", tt.size, price, ok, tt.price, tt.ok)
+		}
+	}
+
+	fill := asks.Fill(decimal.NewFromInt(20))
+	if !fill.IsFullFill || len(fill.Orders) != 2 || !fill.TotalCost.Equal(decimal.RequireFromString("10.5")) || !fill.AvgPrice.Equal(decimal.RequireFromString("0.525")) {
+		t.Errorf("unexpected fill: %+v", fill)
+	}
+	if partial := asks.Fill(decimal.NewFromInt(40)); partial.IsFullFill || !partial.RemainSize.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("expected partial fill, got %+v", partial)
+	}
+
+	bids := ob.ScanBidsAbove(decimal.RequireFromString("0.45"))
+	if bids.Side != SideBuy || bids.Levels != 2 || !bids.Notional.Equal(decimal.RequireFromString("71.5")) || !bids.WorstPrice.Equal(decimal.RequireFromString("0.47")) {
+		t.Errorf("unexpected bid scan: %+v", bids)
+	}
+
+	empty := ob.ScanBidsAbove(decimal.RequireFromString("0.9"))
+	if empty.Levels != 0 || !empty.Notional.IsZero() || !empty.WorstPrice.IsZero() {
+		t.Errorf("unexpected empty scan: %+v", empty)
+	}
+}
@@ -0,0 +1,236 @@
+package orderbook
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ConnLoad 连接当前承载的 token，供放置策略参考
+type ConnLoad struct {
+	ClientID string
+	Tokens   []string
+}
+
+// PlacementPlan 放置策略的结果，每个待放置的 token 必须恰好出现一次
+type PlacementPlan struct {
+	Existing map[string][]string // clientID -> 追加到该现有连接的 token
+	New      [][]string          // 每组新建一个连接
+}
+
+// PlacementStrategy token 到连接的放置策略
+// 连接池在订阅、重平衡和修复分配时调用，每个连接的 token 总数不得超过 maxPerConn，
+// 不合法的结果会被记录日志并回退到 PackPlacement
+type PlacementStrategy interface {
+	Place(tokens []string, conns []ConnLoad, maxPerConn int) PlacementPlan
+}
+
+// PackPlacement 默认放置策略：按连接创建顺序填满现有连接，剩余 token 每 maxPerConn 个新建一个连接
+type PackPlacement struct{}
+
+// Place 实现 PlacementStrategy
+func (PackPlacement) Place(tokens []string, conns []ConnLoad, maxPerConn int) PlacementPlan {
+	plan := PlacementPlan{Existing: make(map[string][]string)}
+
+	for _, conn := range conns {
+		if len(tokens) == 0 {
+			break
+		}
+
+		available := maxPerConn - len(conn.Tokens)
+		if available <= 0 {
+			continue
+		}
+		if available > len(tokens) {
+			available = len(tokens)
+		}
+
+		plan.Existing[conn.ClientID] = tokens[:available]
+		tokens = tokens[available:]
+	}
+
+	plan.New = chunkTokens(tokens, maxPerConn)
+	return plan
+}
+
+// GroupPlacement 按分组键放置：同组 token 尽量放在同一连接上，一次断线只影响同一组相关市场
+// 已有连接承载的组先放置并优先放入该连接，其余组按首次适配放入能容纳整组的连接，都放不下时新建连接；
+// 超过 maxPerConn 的组按 maxPerConn 拆分；分组键为空的 token 单独放置
+type GroupPlacement struct {
+	groupOf func(tokenID string) string
+}
+
+// NewGroupPlacement 创建分组放置策略，groupOf 返回 token 的分组键（如所属 event ID）
+// 分组键通常来自 gamma 市场信息，需在订阅前准备好，groupOf 会在连接池持有锁时调用，不应阻塞
+func NewGroupPlacement(groupOf func(tokenID string) string) *GroupPlacement {
+	return &GroupPlacement{groupOf: groupOf}
+}
+
+// placementBin 放置过程中的连接（现有或待新建）
+type placementBin struct {
+	clientID string // 为空表示新建连接
+	tokens   []string
+	free     int
+	groups   map[string]bool
+}
+
+// Place 实现 PlacementStrategy
+func (g *GroupPlacement) Place(tokens []string, conns []ConnLoad, maxPerConn int) PlacementPlan {
+	bins := make([]*placementBin, 0, len(conns))
+	for _, conn := range conns {
+		bin := &placementBin{
+			clientID: conn.ClientID,
+			free:     maxPerConn - len(conn.Tokens),
+			groups:   make(map[string]bool),
+		}
+		for _, tokenID := range conn.Tokens {
+			if key := g.key(tokenID); key != "" {
+				bin.groups[key] = true
+			}
+		}
+		bins = append(bins, bin)
+	}
+
+	// 按首次出现顺序分组，分组键为空的 token 各自成组
+	var order []string
+	members := make(map[string][]string)
+	for _, tokenID := range tokens {
+		key := g.key(tokenID)
+		if key == "" {
+			key = "\x00" + tokenID
+		}
+		if _, ok := members[key]; !ok {
+			order = append(order, key)
+		}
+		members[key] = append(members[key], tokenID)
+	}
+
+	// 已有连接承载的组先放置，避免其连接的剩余容量被新组占用
+	sort.SliceStable(order, func(i, j int) bool {
+		return placedGroup(bins, order[i]) && !placedGroup(bins, order[j])
+	})
+
+	for _, key := range order {
+		pending := members[key]
+		for len(pending) > 0 {
+			piece := pending
+			if len(piece) > maxPerConn {
+				piece = piece[:maxPerConn]
+			}
+			pending = pending[len(piece):]
+
+			target := firstFit(bins, len(piece), func(b *placementBin) bool { return b.groups[key] })
+			if target == nil {
+				target = firstFit(bins, len(piece), nil)
+			}
+			if target == nil {
+				target = &placementBin{free: maxPerConn, groups: make(map[string]bool)}
+				bins = append(bins, target)
+			}
+			target.tokens = append(target.tokens, piece...)
+			target.free -= len(piece)
+			target.groups[key] = true
+		}
+	}
+
+	plan := PlacementPlan{Existing: make(map[string][]string)}
+	for _, bin := range bins {
+		switch {
+		case len(bin.tokens) == 0:
+		case bin.clientID != "":
+			plan.Existing[bin.clientID] = bin.tokens
+		default:
+			plan.New = append(plan.New, bin.tokens)
+		}
+	}
+	return plan
+}
+
+// key 获取 token 的分组键
+func (g *GroupPlacement) key(tokenID string) string {
+	if g.groupOf == nil {
+		return ""
+	}
+	return g.groupOf(tokenID)
+}
+
+// placedGroup 检查是否有现有连接承载该组
+func placedGroup(bins []*placementBin, key string) bool {
+	for _, bin := range bins {
+		if bin.groups[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// firstFit 返回第一个剩余容量足够且满足 match 的连接
+func firstFit(bins []*placementBin, size int, match func(*placementBin) bool) *placementBin {
+	for _, bin := range bins {
+		if bin.free >= size && (match == nil || match(bin)) {
+			return bin
+		}
+	}
+	return nil
+}
+
+// validatePlacement 检查放置结果：每个 token 恰好放置一次、连接存在且不超过容量
+func validatePlacement(plan PlacementPlan, tokens []string, conns []ConnLoad, maxPerConn int) error {
+	pending := make(map[string]bool, len(tokens))
+	for _, tokenID := range tokens {
+		pending[tokenID] = true
+	}
+	take := func(group []string) error {
+		for _, tokenID := range group {
+			if !pending[tokenID] {
+				return fmt.Errorf("token %s is not pending or placed twice", tokenID)
+			}
+			delete(pending, tokenID)
+		}
+		return nil
+	}
+
+	load := make(map[string]int, len(conns))
+	for _, conn := range conns {
+		load[conn.ClientID] = len(conn.Tokens)
+	}
+	for clientID, group := range plan.Existing {
+		current, ok := load[clientID]
+		if !ok {
+			return fmt.Errorf("unknown client %s", clientID)
+		}
+		if current+len(group) > maxPerConn {
+			return fmt.Errorf("client %s would hold %d tokens, max %d", clientID, current+len(group), maxPerConn)
+		}
+		if err := take(group); err != nil {
+			return err
+		}
+	}
+	for _, group := range plan.New {
+		if len(group) == 0 || len(group) > maxPerConn {
+			return fmt.Errorf("new connection with %d tokens, max %d", len(group), maxPerConn)
+		}
+		if err := take(group); err != nil {
+			return err
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%d tokens not placed", len(pending))
+	}
+	return nil
+}
+
+// chunkTokens 将 token 列表按 maxPerConn 分组
+func chunkTokens(tokenIDs []string, maxPerConn int) [][]string {
+	groups := make([][]string, 0)
+
+	for i := 0; i < len(tokenIDs); i += maxPerConn {
+		end := i + maxPerConn
+		if end > len(tokenIDs) {
+			end = len(tokenIDs)
+		}
+		groups = append(groups, tokenIDs[i:end])
+	}
+
+	return groups
+}
@@ -0,0 +1,120 @@
+package orderbook
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// eventOf groups test tokens by the prefix before "-" (e.g. "e1-yes" -> "e1")
+func eventOf(tokenID string) string {
+	if i := strings.Index(tokenID, "-"); i > 0 {
+		return tokenID[:i]
+	}
+	return ""
+}
+
+func TestPackPlacement(t *testing.T) {
+	conns := []ConnLoad{
+		{ClientID: "client-0", Tokens: []string{"a", "b"}},
+		{ClientID: "client-1", Tokens: []string{"c"}},
+	}
+	plan := PackPlacement{}.Place([]string{"t1", "t2", "t3", "t4", "t5", "t6"}, conns, 3)
+
+	wantExisting := map[string][]string{"client-0": {"t1"}, "client-1": {"t2", "t3"}}
+	if !reflect.DeepEqual(plan.Existing, wantExisting) {
+		t.Errorf("Existing = %v, expected %v", plan.Existing, wantExisting)
+	}
+	if !reflect.DeepEqual(plan.New, [][]string{{"t4", "t5", "t6"}}) {
+		t.Errorf("New = %v", plan.New)
+	}
+}
+
+func TestGroupPlacement(t *testing.T) {
+	strategy := NewGroupPlacement(eventOf)
+	conns := []ConnLoad{
+		{ClientID: "client-0", Tokens: []string{"e1-yes", "x"}},
+		{ClientID: "client-1", Tokens: nil},
+	}
+	tokens := []string{"e2-yes", "e1-no", "e2-no", "e3-a", "e3-b", "e3-c", "e3-d", "e3-e", "loose"}
+	plan := strategy.Place(tokens, conns, 4)
+
+	if err := validatePlacement(plan, tokens, conns, 4); err != nil {
+		t.Fatalf("invalid plan: %v", err)
+	}
+	// e1-no joins its sibling before e2 can take the remaining room; e2 stays whole on the idle connection
+	wantExisting := map[string][]string{
+		"client-0": {"e1-no", "e3-e"},
+		"client-1": {"e2-yes", "e2-no", "loose"},
+	}
+	if !reflect.DeepEqual(plan.Existing, wantExisting) {
+		t.Errorf("Existing = %v, expected %v", plan.Existing, wantExisting)
+	}
+	// e3 is larger than a connection: one full chunk, the remainder goes wherever it fits
+	if !reflect.DeepEqual(plan.New, [][]string{{"e3-a", "e3-b", "e3-c", "e3-d"}}) {
+		t.Errorf("New = %v", plan.New)
+	}
+}
+
+func TestValidatePlacement(t *testing.T) {
+	conns := []ConnLoad{{ClientID: "client-0", Tokens: []string{"a"}}}
+	tokens := []string{"t1", "t2"}
+
+	tests := []struct {
+		name string
+		plan PlacementPlan
+	}{
+		{"missing token", PlacementPlan{New: [][]string{{"t1"}}}},
+		{"duplicate token", PlacementPlan{New: [][]string{{"t1", "t2"}, {"t1"}}}},
+		{"unknown token", PlacementPlan{New: [][]string{{"t1", "t2", "zz"}}}},
+		{"unknown client", PlacementPlan{Existing: map[string][]string{"client-9": tokens}}},
+		{"over capacity", PlacementPlan{Existing: map[string][]string{"client-0": tokens}}},
+		{"empty group", PlacementPlan{New: [][]string{tokens, {}}}},
+	}
+	for _, tt := range tests {
+		if err := validatePlacement(tt.plan, tokens, conns, 2); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+
+	ok := PlacementPlan{Existing: map[string][]string{"client-0": {"t1"}}, New: [][]string{{"t2"}}}
+	if err := validatePlacement(ok, tokens, conns, 2); err != nil {
+		t.Errorf("valid plan rejected: %v", err)
+	}
+}
+
+// badPlacement places only the first token
+type badPlacement struct{}
+
+func (badPlacement) Place(tokens []string, conns []ConnLoad, maxPerConn int) PlacementPlan {
+	return PlacementPlan{New: [][]string{tokens[:1]}}
+}
+
+func TestWSPool_Placement(t *testing.T) {
+	pool := newTestPool(t, 3, func(c *Config) { c.Placement = NewGroupPlacement(eventOf) })
+
+	if err := pool.Subscribe([]string{"e1-yes", "e2-yes", "e2-no", "e1-no"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	if pool.GetClientForToken("e1-yes") != pool.GetClientForToken("e1-no") {
+		t.Error("tokens of the same event should share a connection")
+	}
+	if pool.GetClientForToken("e2-yes") != pool.GetClientForToken("e2-no") {
+		t.Error("tokens of the same event should share a connection")
+	}
+	if pool.GetClientForToken("e1-yes") == pool.GetClientForToken("e2-yes") {
+		t.Error("events that do not fit together should use separate connections")
+	}
+
+	// an invalid plan falls back to packing so no token is lost
+	pool.SetPlacement(badPlacement{})
+	if err := pool.Subscribe([]string{"t1", "t2", "t3"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	if got := pool.GetTokenCount(); got != 7 {
+		t.Errorf("GetTokenCount() = %d, expected 7", got)
+	}
+	if issues := pool.CheckAssignments(); len(issues) != 0 {
+		t.Errorf("unexpected assignment issues: %+v", issues)
+	}
+}
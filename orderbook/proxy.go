@@ -0,0 +1,35 @@
+package orderbook
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// ParseProxyURL 解析 WebSocket 代理地址
+// 支持 socks5://[user:pass@]host:port 与 http://[user:pass@]host:port（HTTP CONNECT，带认证时使用 Basic 认证）
+// SOCKS5 代理由代理端解析目标域名
+func ParseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid proxy URL: %v", common.ErrInvalidConfig, err)
+	}
+	switch u.Scheme {
+	case "socks5", "http":
+	default:
+		return nil, fmt.Errorf("%w: unsupported proxy scheme %q, must be socks5 or http", common.ErrInvalidConfig, u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("%w: proxy URL %q has no host", common.ErrInvalidConfig, u.Redacted())
+	}
+	return u, nil
+}
+
+// proxyFor 按连接序号轮流选择 Config.ProxyURLs 中的代理，未配置时返回 nil（直连）
+func (c *Config) proxyFor(n int) (*url.URL, error) {
+	if len(c.ProxyURLs) == 0 {
+		return nil, nil
+	}
+	return ParseProxyURL(c.ProxyURLs[n%len(c.ProxyURLs)])
+}
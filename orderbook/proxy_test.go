@@ -0,0 +1,199 @@
+package orderbook
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// pipeConns copies data both ways until either side closes
+func pipeConns(a, b net.Conn) {
+	go func() {
+		io.Copy(a, b)
+		a.Close()
+	}()
+	io.Copy(b, a)
+	b.Close()
+}
+
+// newSOCKS5Proxy starts a SOCKS5 proxy requiring username/password auth
+func newSOCKS5Proxy(t *testing.T, user, pass string) (string, *int32) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var accepted int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				buf := make([]byte, 2)
+
+				// Greeting: only username/password auth is offered back
+				if _, err := io.ReadFull(r, buf); err != nil {
+					return
+				}
+				if _, err := io.ReadFull(r, make([]byte, buf[1])); err != nil {
+					return
+				}
+				conn.Write([]byte{5, 2})
+
+				readField := func() string {
+					n, _ := r.ReadByte()
+					b := make([]byte, n)
+					io.ReadFull(r, b)
+					return string(b)
+				}
+				r.ReadByte() // auth version
+				if readField() != user || readField() != pass {
+					conn.Write([]byte{1, 1})
+					return
+				}
+				conn.Write([]byte{1, 0})
+
+				// Connect request
+				header := make([]byte, 4)
+				if _, err := io.ReadFull(r, header); err != nil {
+					return
+				}
+				var host string
+				switch header[3] {
+				case 1:
+					ip := make([]byte, 4)
+					io.ReadFull(r, ip)
+					host = net.IP(ip).String()
+				case 3:
+					host = readField()
+				default:
+					return
+				}
+				portBuf := make([]byte, 2)
+				io.ReadFull(r, portBuf)
+				target, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(portBuf)))))
+				if err != nil {
+					conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+					return
+				}
+				atomic.AddInt32(&accepted, 1)
+				conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+				pipeConns(conn, target)
+			}()
+		}
+	}()
+
+	return "socks5://" + user + ":" + pass + "@" + ln.Addr().String(), &accepted
+}
+
+// newHTTPConnectProxy starts an HTTP CONNECT proxy requiring basic auth
+func newHTTPConnectProxy(t *testing.T, user, pass string) (string, *int32) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var accepted int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				req, err := http.ReadRequest(bufio.NewReader(conn))
+				if err != nil || req.Method != http.MethodConnect {
+					return
+				}
+				probe := &http.Request{Header: http.Header{"Authorization": req.Header["Proxy-Authorization"]}}
+				if u, p, ok := probe.BasicAuth(); !ok || u != user || p != pass {
+					conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+					return
+				}
+				target, err := net.Dial("tcp", req.Host)
+				if err != nil {
+					conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+					return
+				}
+				atomic.AddInt32(&accepted, 1)
+				conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+				pipeConns(conn, target)
+			}()
+		}
+	}()
+
+	return "http://" + user + ":" + pass + "@" + ln.Addr().String(), &accepted
+}
+
+func TestWSPool_Proxies(t *testing.T) {
+	socksURL, socksHits := newSOCKS5Proxy(t, "alice", "s3cret")
+	httpURL, httpHits := newHTTPConnectProxy(t, "bob", "hunter2")
+
+	pool := newTestPool(t, 1, func(config *Config) {
+		config.ProxyURLs = []string{socksURL, httpURL}
+	})
+
+	// client-0 (socks) is created by Connect, t1 joins it, t2 opens client-1 (http)
+	if err := pool.Subscribe([]string{"t1", "t2"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	if got := atomic.LoadInt32(socksHits); got != 1 {
+		t.Errorf("SOCKS5 proxy connections = %d, expected 1", got)
+	}
+	if got := atomic.LoadInt32(httpHits); got != 1 {
+		t.Errorf("HTTP proxy connections = %d, expected 1", got)
+	}
+	if proxy := pool.GetClientForToken("t2").Proxy(); proxy == nil || proxy.Scheme != "http" {
+		t.Errorf("t2 proxy = %v, expected http", proxy)
+	}
+}
+
+func TestWSPool_ProxyAuthRejected(t *testing.T) {
+	socksURL, _ := newSOCKS5Proxy(t, "alice", "s3cret")
+	proxy, err := ParseProxyURL(socksURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy.User = nil
+
+	server := newTestWSServer(t)
+	client := NewWSClient("c1", "ws"+strings.TrimPrefix(server.URL, "http"), nil, DefaultConfig())
+	client.SetProxy(proxy)
+	defer client.Close()
+	if err := client.Connect(); err == nil {
+		t.Error("expected connect to fail without proxy credentials")
+	}
+}
+
+func TestParseProxyURL(t *testing.T) {
+	for _, raw := range []string{"socks5://127.0.0.1:1080", "http://u:p@proxy:3128"} {
+		if _, err := ParseProxyURL(raw); err != nil {
+			t.Errorf("ParseProxyURL(%q) error: %v", raw, err)
+		}
+	}
+	for _, raw := range []string{"https://proxy:443", "socks5://", "::bad"} {
+		if _, err := ParseProxyURL(raw); !errors.Is(err, common.ErrInvalidConfig) {
+			t.Errorf("ParseProxyURL(%q) = %v, expected ErrInvalidConfig", raw, err)
+		}
+	}
+}
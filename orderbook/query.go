@@ -0,0 +1,177 @@
+package orderbook
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// BookSummary 单个订单簿的顶部摘要，用于跨 token 的扫描查询
+type BookSummary struct {
+	TokenID  string
+	Metadata *TokenMetadata // 通过 SetTokenMetadata 注册后才会填充
+	BestBid  *BestPrice     // 无买单时为 nil
+	BestAsk  *BestPrice     // 无卖单时为 nil
+	Spread   decimal.Decimal
+	BidDepth decimal.Decimal // 买单总量
+	AskDepth decimal.Decimal // 卖单总量
+}
+
+// TwoSided 是否同时有买卖报价（Spread 仅在此时有效）
+func (b *BookSummary) TwoSided() bool {
+	return b.BestBid != nil && b.BestAsk != nil
+}
+
+// Depth 买卖双边总量
+func (b *BookSummary) Depth() decimal.Decimal {
+	return b.BidDepth.Add(b.AskDepth)
+}
+
+// MarketAskSum 同一市场全部结果 token 的最优卖价之和
+// 和小于 1 时同时买入每个结果各一份的成本低于必然兑付的 1 USDC
+type MarketAskSum struct {
+	MarketSlug string
+	Outcomes   []BookSummary   // 按 TokenID 排序
+	Sum        decimal.Decimal // 各结果最优卖价之和
+	Size       decimal.Decimal // 各结果最优卖价数量的最小值，即按最优价可成套买入的份数
+}
+
+// summary 在同一把锁下计算订单簿摘要，未初始化时返回 nil
+func (ob *OrderBook) summary() *BookSummary {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if !ob.initialized {
+		return nil
+	}
+
+	ob.rebuildSortedBids()
+	ob.rebuildSortedAsks()
+
+	s := &BookSummary{TokenID: ob.tokenID}
+	if len(ob.sortedBids) > 0 {
+		s.BestBid = &BestPrice{Side: SideBuy, Price: ob.sortedBids[0].Price, Size: ob.sortedBids[0].Size, Timestamp: ob.timestamp}
+	}
+	if len(ob.sortedAsks) > 0 {
+		s.BestAsk = &BestPrice{Side: SideSell, Price: ob.sortedAsks[0].Price, Size: ob.sortedAsks[0].Size, Timestamp: ob.timestamp}
+	}
+	if s.TwoSided() {
+		s.Spread = s.BestAsk.Price.Sub(s.BestBid.Price)
+	}
+	for _, level := range ob.sortedBids {
+		s.BidDepth = s.BidDepth.Add(level.Size)
+	}
+	for _, level := range ob.sortedAsks {
+		s.AskDepth = s.AskDepth.Add(level.Size)
+	}
+	return s
+}
+
+// Summaries 获取全部已初始化订单簿的摘要，按 TokenID 排序
+// 只在复制订单簿列表时持有管理器锁，之后逐个订单簿加锁计算
+func (m *Manager) Summaries() []BookSummary {
+	type entry struct {
+		ob   *OrderBook
+		meta *TokenMetadata
+	}
+
+	m.mu.RLock()
+	entries := make([]entry, 0, len(m.orderBooks))
+	for tokenID, ob := range m.orderBooks {
+		if !m.tracksBookLocked(tokenID) {
+			continue
+		}
+		e := entry{ob: ob}
+		if meta, ok := m.tokenMetadata[tokenID]; ok {
+			e.meta = &meta
+		}
+		entries = append(entries, e)
+	}
+	m.mu.RUnlock()
+
+	result := make([]BookSummary, 0, len(entries))
+	for _, e := range entries {
+		if s := e.ob.summary(); s != nil {
+			s.Metadata = e.meta
+			result = append(result, *s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TokenID < result[j].TokenID
+	})
+	return result
+}
+
+// topSpreads 价差最大的 n 个双边报价订单簿，价差降序，n <= 0 时返回全部
+func topSpreads(summaries []BookSummary, n int) []BookSummary {
+	result := make([]BookSummary, 0, len(summaries))
+	for _, s := range summaries {
+		if s.TwoSided() {
+			result = append(result, s)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Spread.GreaterThan(result[j].Spread)
+	})
+	return firstN(result, n)
+}
+
+// sortedByDepth 双边总量最大的 n 个订单簿，总量降序，n <= 0 时返回全部
+func sortedByDepth(summaries []BookSummary, n int) []BookSummary {
+	result := append([]BookSummary(nil), summaries...)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Depth().GreaterThan(result[j].Depth())
+	})
+	return firstN(result, n)
+}
+
+// marketsWithAskSumBelow 按 Metadata.MarketSlug 分组，返回全部结果最优卖价之和小于 threshold 的市场，和升序
+// 未注册元数据的 token、只有一个结果的市场以及任一结果没有卖单的市场会被跳过
+func marketsWithAskSumBelow(summaries []BookSummary, threshold decimal.Decimal) []MarketAskSum {
+	groups := make(map[string][]BookSummary)
+	for _, s := range summaries {
+		if s.Metadata == nil || s.Metadata.MarketSlug == "" {
+			continue
+		}
+		groups[s.Metadata.MarketSlug] = append(groups[s.Metadata.MarketSlug], s)
+	}
+
+	var result []MarketAskSum
+	for slug, outcomes := range groups {
+		if len(outcomes) < 2 {
+			continue
+		}
+
+		sum := MarketAskSum{MarketSlug: slug, Outcomes: outcomes}
+		complete := true
+		for i, o := range outcomes {
+			if o.BestAsk == nil {
+				complete = false
+				break
+			}
+			sum.Sum = sum.Sum.Add(o.BestAsk.Price)
+			if i == 0 || o.BestAsk.Size.LessThan(sum.Size) {
+				sum.Size = o.BestAsk.Size
+			}
+		}
+		if complete && sum.Sum.LessThan(threshold) {
+			result = append(result, sum)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Sum.Equal(result[j].Sum) {
+			return result[i].Sum.LessThan(result[j].Sum)
+		}
+		return result[i].MarketSlug < result[j].MarketSlug
+	})
+	return result
+}
+
+// firstN 截取前 n 个，n <= 0 时返回全部
+func firstN(summaries []BookSummary, n int) []BookSummary {
+	if n > 0 && n < len(summaries) {
+		return summaries[:n]
+	}
+	return summaries
+}
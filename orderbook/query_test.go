@@ -0,0 +1,106 @@
+package orderbook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func newQueryManager(t *testing.T) *Manager {
+	t.Helper()
+
+	m := NewManager(nil)
+	books := map[string]string{
+		"a-yes": `"bids":[{"price":"0.40","size":"10"}],"asks":[{"price":"0.45","size":"30"}]`,
+		"a-no":  `"bids":[{"price":"0.50","size":"5"}],"asks":[{"price":"0.52","size":"20"},{"price":"0.60","size":"100"}]`,
+		"b-yes": `"bids":[{"price":"0.10","size":"1"}],"asks":[{"price":"0.30","size":"2"}]`,
+		"b-no":  `"bids":[],"asks":[{"price":"0.75","size":"8"}]`,
+		"c-yes": `"bids":[{"price":"0.20","size":"1000"}],"asks":[]`,
+	}
+	for id, levels := range books {
+		m.orderBooks[id] = NewOrderBook(id)
+		m.subscribedTokens[id] = true
+		m.handleBookMessage([]byte(`{"event_type":"book","asset_id":"`+id+`","timestamp":"1000",`+levels+`}`), time.Time{})
+	}
+	m.orderBooks["pending"] = NewOrderBook("pending")
+	m.subscribedTokens["pending"] = true
+
+	for id, slug := range map[string]string{"a-yes": "a", "a-no": "a", "b-yes": "b", "b-no": "b", "c-yes": "c"} {
+		m.SetTokenMetadata(id, TokenMetadata{MarketSlug: slug})
+	}
+	return m
+}
+
+func summaryIDs(summaries []BookSummary) []string {
+	ids := make([]string, len(summaries))
+	for i, s := range summaries {
+		ids[i] = s.TokenID
+	}
+	return ids
+}
+
+func TestManager_Summaries(t *testing.T) {
+	summaries := newQueryManager(t).Summaries()
+
+	// Uninitialized books are skipped, results are sorted by token ID
+	ids := summaryIDs(summaries)
+	expected := []string{"a-no", "a-yes", "b-no", "b-yes", "c-yes"}
+	if len(ids) != len(expected) {
+		t.Fatalf("Summaries() = %v, expected %v", ids, expected)
+	}
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Fatalf("Summaries() = %v, expected %v", ids, expected)
+		}
+	}
+
+	aNo := summaries[0]
+	if !aNo.Spread.Equal(decimal.RequireFromString("0.02")) || !aNo.AskDepth.Equal(decimal.NewFromInt(120)) || aNo.Metadata.MarketSlug != "a" {
+		t.Errorf("unexpected a-no summary: %+v", aNo)
+	}
+	if summaries[2].TwoSided() {
+		t.Error("b-no has no bids and should not be two-sided")
+	}
+}
+
+func TestQuery_TopSpreadsAndDepth(t *testing.T) {
+	summaries := newQueryManager(t).Summaries()
+
+	spreads := summaryIDs(topSpreads(summaries, 2))
+	if len(spreads) != 2 || spreads[0] != "b-yes" || spreads[1] != "a-yes" {
+		t.Errorf("topSpreads() = %v, expected [b-yes a-yes]", spreads)
+	}
+
+	depth := summaryIDs(sortedByDepth(summaries, 0))
+	if len(depth) != 5 || depth[0] != "c-yes" || depth[1] != "a-no" || depth[4] != "b-yes" {
+		t.Errorf("sortedByDepth() = %v", depth)
+	}
+}
+
+func TestQuery_MarketsWithAskSumBelow(t *testing.T) {
+	summaries := newQueryManager(t).Summaries()
+
+	// a: 0.45 + 0.52 = 0.97, b: 0.30 + 0.75 = 1.05, c has a single outcome
+	sums := marketsWithAskSumBelow(summaries, decimal.NewFromInt(1))
+	if len(sums) != 1 || sums[0].MarketSlug != "a" {
+		t.Fatalf("expected only market a, got %+v", sums)
+	}
+	if !sums[0].Sum.Equal(decimal.RequireFromString("0.97")) || !sums[0].Size.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("unexpected sum: %s x %s", sums[0].Sum, sums[0].Size)
+	}
+
+	if got := marketsWithAskSumBelow(summaries, decimal.RequireFromString("1.1")); len(got) != 2 || got[1].MarketSlug != "b" {
+		t.Errorf("expected markets a and b, got %+v", got)
+	}
+}
+
+func TestSDK_QueriesRequireStart(t *testing.T) {
+	sdk := NewSDK(nil)
+	if _, err := sdk.TopSpreads(1); err != ErrNotStarted {
+		t.Errorf("TopSpreads() error = %v, expected ErrNotStarted", err)
+	}
+	if _, err := sdk.TokensWithSumBelow(decimal.NewFromInt(1)); err != ErrNotStarted {
+		t.Errorf("TokensWithSumBelow() error = %v, expected ErrNotStarted", err)
+	}
+}
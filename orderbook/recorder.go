@@ -0,0 +1,117 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RecordedFrame 录制的原始 WebSocket 帧
+type RecordedFrame struct {
+	ConnID     string    `json:"conn"`        // 连接 ID，如 client-0
+	ReceivedAt time.Time `json:"received_at"` // 本地收到该帧的时间
+	Data       string    `json:"data"`        // 原始帧内容（服务端也会推送 PONG 等非 JSON 文本，按字符串保存）
+}
+
+// FrameRecorder 按连接保存最近一段时间的原始 WebSocket 帧（滚动窗口）
+// 平时只在内存中保留窗口内的帧，检测到异常时调用 FlushRecent 落盘此前的上下文，供离线分析，无需全量录制
+type FrameRecorder struct {
+	mu       sync.Mutex
+	window   time.Duration
+	maxBytes int
+	conns    map[string]*frameRing
+
+	now func() time.Time
+}
+
+// frameRing 单个连接的帧队列，frames[head:] 为有效数据
+type frameRing struct {
+	frames []RecordedFrame
+	head   int
+	bytes  int
+}
+
+// NewFrameRecorder 创建帧录制器
+// window 为保留时长；maxBytes 为每个连接保留的帧内容总字节数上限，<= 0 表示只按时长淘汰
+func NewFrameRecorder(window time.Duration, maxBytes int) *FrameRecorder {
+	return &FrameRecorder{
+		window:   window,
+		maxBytes: maxBytes,
+		conns:    make(map[string]*frameRing),
+		now:      time.Now,
+	}
+}
+
+// Record 记录一帧，同时淘汰该连接超出窗口的旧帧
+func (r *FrameRecorder) Record(connID string, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	ring := r.conns[connID]
+	if ring == nil {
+		ring = &frameRing{}
+		r.conns[connID] = ring
+	}
+	ring.frames = append(ring.frames, RecordedFrame{ConnID: connID, ReceivedAt: now, Data: string(data)})
+	ring.bytes += len(data)
+	ring.prune(now.Add(-r.window), r.maxBytes)
+}
+
+// prune 淘汰早于 cutoff 或超出字节上限的帧（至少保留最新一帧）
+func (ring *frameRing) prune(cutoff time.Time, maxBytes int) {
+	for ring.head < len(ring.frames)-1 {
+		oldest := ring.frames[ring.head]
+		if !oldest.ReceivedAt.Before(cutoff) && (maxBytes <= 0 || ring.bytes <= maxBytes) {
+			break
+		}
+		ring.bytes -= len(oldest.Data)
+		ring.frames[ring.head] = RecordedFrame{}
+		ring.head++
+	}
+	if ring.head > 0 && ring.head >= len(ring.frames)/2 {
+		n := copy(ring.frames, ring.frames[ring.head:])
+		clear(ring.frames[n:])
+		ring.frames = ring.frames[:n]
+		ring.head = 0
+	}
+}
+
+// Recent 窗口内全部连接的帧，按接收时间排序
+func (r *FrameRecorder) Recent() []RecordedFrame {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cutoff := r.now().Add(-r.window)
+	var frames []RecordedFrame
+	for connID, ring := range r.conns {
+		for _, frame := range ring.frames[ring.head:] {
+			if !frame.ReceivedAt.Before(cutoff) {
+				frames = append(frames, frame)
+			}
+		}
+		// 已关闭的连接不再有新帧，窗口过后移除
+		if last := len(ring.frames) - 1; last < ring.head || ring.frames[last].ReceivedAt.Before(cutoff) {
+			delete(r.conns, connID)
+		}
+	}
+	sort.SliceStable(frames, func(i, j int) bool {
+		return frames[i].ReceivedAt.Before(frames[j].ReceivedAt)
+	})
+	return frames
+}
+
+// FlushRecent 将窗口内的帧按接收时间顺序写入 w，每行一个 JSON 对象（字段见 RecordedFrame）
+// 写入后不清空窗口，连续多次异常可各自保存完整的上下文
+func (r *FrameRecorder) FlushRecent(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, frame := range r.Recent() {
+		if err := enc.Encode(frame); err != nil {
+			return fmt.Errorf("failed to write recorded frame: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package orderbook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestFrameRecorder_RollingWindow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	r := NewFrameRecorder(10*time.Second, 0)
+	r.now = func() time.Time { return now }
+
+	r.Record("client-0", []byte(`{"event_type":"book"}`))
+	now = now.Add(5 * time.Second)
+	r.Record("client-1", []byte("PONG"))
+	now = now.Add(6 * time.Second)
+	r.Record("client-0", []byte(`{"event_type":"price_change"}`))
+
+	// the first frame is now 11s old and falls outside the window
+	var buf bytes.Buffer
+	if err := r.FlushRecent(&buf); err != nil {
+		t.Fatalf("FlushRecent() error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 frames, got %d: %s", len(lines), buf.String())
+	}
+	var first, second RecordedFrame
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	json.Unmarshal([]byte(lines[1]), &second)
+	if first.ConnID != "client-1" || first.Data != "PONG" || second.ConnID != "client-0" || !second.ReceivedAt.Equal(now) {
+		t.Errorf("unexpected frames: %+v, %+v", first, second)
+	}
+
+	// flushing keeps the window so a second anomaly captures the same context
+	if frames := r.Recent(); len(frames) != 2 {
+		t.Errorf("expected window to be kept after flush, got %d frames", len(frames))
+	}
+
+	// a connection without new frames is dropped once its frames age out
+	now = now.Add(time.Minute)
+	if frames := r.Recent(); len(frames) != 0 || len(r.conns) != 0 {
+		t.Errorf("expected empty window, got %d frames, %d conns", len(frames), len(r.conns))
+	}
+}
+
+func TestFrameRecorder_MaxBytes(t *testing.T) {
+	r := NewFrameRecorder(time.Hour, 10)
+	for i := 0; i < 100; i++ {
+		r.Record("client-0", []byte("0123"))
+	}
+	ring := r.conns["client-0"]
+	if ring.bytes > 10 || len(ring.frames)-ring.head != 2 {
+		t.Errorf("expected 2 frames within 10 bytes, got %d frames, %d bytes", len(ring.frames)-ring.head, ring.bytes)
+	}
+	if cap(ring.frames) > 16 {
+		t.Errorf("ring should be compacted, cap = %d", cap(ring.frames))
+	}
+
+	// a single frame larger than the limit is still kept
+	r.Record("client-0", []byte("a frame larger than the limit"))
+	if frames := r.Recent(); len(frames) != 1 {
+		t.Errorf("expected the newest frame to be kept, got %d", len(frames))
+	}
+}
+
+func TestSDK_FlushRecent(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewSDK(nil).FlushRecent(&buf); !errors.Is(err, ErrNotStarted) {
+		t.Errorf("expected ErrNotStarted, got %v", err)
+	}
+	if err := NewManager(nil).FlushRecent(&buf); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig when recording is disabled, got %v", err)
+	}
+
+	config := DefaultConfig()
+	config.RecordWindow = 60
+	m := NewManager(config)
+	m.recorder.Record("client-0", []byte(`{"event_type":"book"}`))
+	if err := m.FlushRecent(&buf); err != nil || !strings.Contains(buf.String(), `"conn":"client-0"`) {
+		t.Errorf("FlushRecent() = %v, output %s", err, buf.String())
+	}
+}
@@ -0,0 +1,94 @@
+package orderbook
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// runtimeMu 保护 Config 中可在运行时调整的字段（心跳、重连、时间戳间隔）
+// Config 以指针在 Manager、WSPool、WSClient 之间共享，运行中读取这些字段需通过下方的访问方法
+var runtimeMu sync.RWMutex
+
+// ConfigUpdate 运行时可调整的订单簿参数，nil 字段保持不变
+type ConfigUpdate struct {
+	PingInterval         *int // ping 间隔（秒）
+	PongTimeout          *int // pong 超时（秒）
+	ReconnectMinInterval *int // 最小重连间隔（毫秒）
+	ReconnectMaxInterval *int // 最大重连间隔（毫秒）
+	ReconnectMaxAttempts *int // 最大重连次数，0 表示无限
+	MaxTimestampGapMs    *int // 时间戳间隔阈值（毫秒），0 表示只统计不重订阅
+}
+
+// Apply 校验并应用运行时参数，任一字段不合法时不做任何修改
+// 已建立的连接无需重连：心跳间隔在下一次心跳时生效，重连参数在下一次重连时生效，间隔阈值对之后的增量生效
+func (c *Config) Apply(update *ConfigUpdate) error {
+	if update == nil {
+		return nil
+	}
+
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+
+	next := *c
+	set := func(dst *int, src *int) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	set(&next.PingInterval, update.PingInterval)
+	set(&next.PongTimeout, update.PongTimeout)
+	set(&next.ReconnectMinInterval, update.ReconnectMinInterval)
+	set(&next.ReconnectMaxInterval, update.ReconnectMaxInterval)
+	set(&next.ReconnectMaxAttempts, update.ReconnectMaxAttempts)
+	set(&next.MaxTimestampGapMs, update.MaxTimestampGapMs)
+
+	// 只校验本次更新涉及的字段，避免未设置默认值的旧字段导致无关更新失败
+	switch {
+	case update.PingInterval != nil && next.PingInterval <= 0:
+		return fmt.Errorf("%w: PingInterval must be positive, got %d", common.ErrInvalidConfig, next.PingInterval)
+	case update.PongTimeout != nil && next.PongTimeout <= 0:
+		return fmt.Errorf("%w: PongTimeout must be positive, got %d", common.ErrInvalidConfig, next.PongTimeout)
+	case update.ReconnectMinInterval != nil && next.ReconnectMinInterval <= 0:
+		return fmt.Errorf("%w: ReconnectMinInterval must be positive, got %d", common.ErrInvalidConfig, next.ReconnectMinInterval)
+	case (update.ReconnectMinInterval != nil || update.ReconnectMaxInterval != nil) && next.ReconnectMaxInterval < next.ReconnectMinInterval:
+		return fmt.Errorf("%w: ReconnectMaxInterval %d is below ReconnectMinInterval %d", common.ErrInvalidConfig, next.ReconnectMaxInterval, next.ReconnectMinInterval)
+	case next.ReconnectMaxAttempts < 0:
+		return fmt.Errorf("%w: ReconnectMaxAttempts must not be negative, got %d", common.ErrInvalidConfig, next.ReconnectMaxAttempts)
+	case next.MaxTimestampGapMs < 0:
+		return fmt.Errorf("%w: MaxTimestampGapMs must not be negative, got %d", common.ErrInvalidConfig, next.MaxTimestampGapMs)
+	}
+
+	c.PingInterval = next.PingInterval
+	c.PongTimeout = next.PongTimeout
+	c.ReconnectMinInterval = next.ReconnectMinInterval
+	c.ReconnectMaxInterval = next.ReconnectMaxInterval
+	c.ReconnectMaxAttempts = next.ReconnectMaxAttempts
+	c.MaxTimestampGapMs = next.MaxTimestampGapMs
+	return nil
+}
+
+// heartbeat 获取 ping 间隔与 pong 超时
+func (c *Config) heartbeat() (ping, pong time.Duration) {
+	runtimeMu.RLock()
+	defer runtimeMu.RUnlock()
+	return time.Duration(c.PingInterval) * time.Second, time.Duration(c.PongTimeout) * time.Second
+}
+
+// reconnectPolicy 获取重连退避区间与最大重连次数
+func (c *Config) reconnectPolicy() (minInterval, maxInterval time.Duration, maxAttempts int) {
+	runtimeMu.RLock()
+	defer runtimeMu.RUnlock()
+	return time.Duration(c.ReconnectMinInterval) * time.Millisecond,
+		time.Duration(c.ReconnectMaxInterval) * time.Millisecond,
+		c.ReconnectMaxAttempts
+}
+
+// maxTimestampGap 获取时间戳间隔阈值（毫秒）
+func (c *Config) maxTimestampGap() int64 {
+	runtimeMu.RLock()
+	defer runtimeMu.RUnlock()
+	return int64(c.MaxTimestampGapMs)
+}
@@ -0,0 +1,45 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestConfig_Apply(t *testing.T) {
+	config := DefaultConfig()
+	ping, gap := 15, 2000
+
+	if err := config.Apply(&ConfigUpdate{PingInterval: &ping, MaxTimestampGapMs: &gap}); err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	if p, pong := config.heartbeat(); p != 15*time.Second || pong != 10*time.Second {
+		t.Errorf("heartbeat() = %v, %v", p, pong)
+	}
+	if config.maxTimestampGap() != 2000 {
+		t.Errorf("maxTimestampGap() = %d", config.maxTimestampGap())
+	}
+
+	// An invalid field rejects the whole update
+	zero, maxInterval := 0, 500
+	err := config.Apply(&ConfigUpdate{PongTimeout: &zero, PingInterval: &gap})
+	if !errors.Is(err, common.ErrInvalidConfig) || config.PingInterval != 15 {
+		t.Errorf("Apply() = %v, PingInterval = %d", err, config.PingInterval)
+	}
+	if err := config.Apply(&ConfigUpdate{ReconnectMaxInterval: &maxInterval}); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected max below min to be rejected, got %v", err)
+	}
+}
+
+func TestSDK_ApplyConfigBeforeStart(t *testing.T) {
+	sdk := NewSDK(nil)
+	attempts := 3
+	if err := sdk.ApplyConfig(&ConfigUpdate{ReconnectMaxAttempts: &attempts}); err != nil {
+		t.Fatalf("ApplyConfig() error: %v", err)
+	}
+	if _, _, maxAttempts := sdk.config.reconnectPolicy(); maxAttempts != 3 {
+		t.Errorf("reconnect attempts = %d, expected 3", maxAttempts)
+	}
+}
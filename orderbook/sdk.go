@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
+	"time"
 
 	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 var (
@@ -14,16 +18,19 @@ var (
 	ErrTokenNotFound  = errors.New("token not found")
 	ErrNoData         = errors.New("no data available")
 	ErrNotStarted     = errors.New("sdk not started, call Start first")
+	ErrMalformedDelta = errors.New("malformed book delta")
 )
 
 // SDK 订单簿SDK对外接口
 type SDK struct {
 	mu      sync.RWMutex
 	manager *Manager
-	config  *Config
-	started bool
-	ctx     context.Context
-	cancel  context.CancelFunc
+
+	persistMu sync.Mutex // 串行化订阅状态的持久化
+	config    *Config
+	started   bool
+	ctx       context.Context
+	cancel    context.CancelFunc
 }
 
 // NewSDK 创建新的SDK实例
@@ -79,10 +86,63 @@ func (s *SDK) Subscribe(tokenIDs []string) error {
 		return err
 	}
 
-	return nil
+	return s.persistSubscriptions(s.manager)
+}
+
+// SubscribeWithEvents 订阅 token 并只推送指定类型的事件
+// Polymarket 市场频道不支持服务端按事件类型过滤，过滤在客户端完成：
+// 不包含 book/price_change 的 token 不维护本地订单簿，节省解析与内存开销，
+// 例如只需成交价的消费者可传入 EventTypeLastTradePrice
+func (s *SDK) SubscribeWithEvents(tokenIDs []string, eventTypes ...EventType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		return ErrNotStarted
+	}
+
+	if len(tokenIDs) == 0 {
+		return errors.New("tokenIDs cannot be empty")
+	}
+
+	// 先设置过滤，避免订阅后的首个快照被处理
+	for _, tokenID := range tokenIDs {
+		s.manager.SetEventFilter(tokenID, eventTypes...)
+	}
+
+	if err := s.manager.Subscribe(tokenIDs); err != nil {
+		return err
+	}
+	return s.persistSubscriptions(s.manager)
+}
+
+// SetEventFilter 修改 token 推送的事件类型，不传类型时恢复默认（book + price_change）
+func (s *SDK) SetEventFilter(tokenID string, eventTypes ...EventType) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return ErrNotStarted
+	}
+
+	s.manager.SetEventFilter(tokenID, eventTypes...)
+	return s.persistSubscriptions(s.manager)
+}
+
+// GetEventFilter 获取 token 推送的事件类型
+func (s *SDK) GetEventFilter(tokenID string) []EventType {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return append([]EventType(nil), DefaultEventTypes...)
+	}
+	return s.manager.GetEventFilter(tokenID)
 }
 
-// Unsubscribe 取消订阅指定的 token
+// Unsubscribe 批量取消订阅指定的 token
+// 订单簿、待处理增量、事件过滤与元数据等状态一并清除，仍订阅的 token 推送 EventTypeRemoved，
+// 可与 Subscribe 配合轮换 token 集合而无需重启进程
 func (s *SDK) Unsubscribe(tokenIDs []string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -91,10 +151,63 @@ func (s *SDK) Unsubscribe(tokenIDs []string) error {
 		return ErrNotStarted
 	}
 
-	return s.manager.Unsubscribe(tokenIDs)
+	if len(tokenIDs) == 0 {
+		return errors.New("tokenIDs cannot be empty")
+	}
+
+	if err := s.manager.Unsubscribe(tokenIDs); err != nil {
+		return err
+	}
+	return s.persistSubscriptions(s.manager)
+}
+
+// Resubscribe 批量重新订阅已订阅的 token，丢弃本地订单簿并获取新快照
+// 同一连接上的 token 合并为一次请求；新快照全部到达后推送 EventTypeSnapshotBatchComplete，
+// 也可通过 WaitForSnapshots 等待。包含未订阅的 token 时其余 token 照常处理，并返回 ErrTokenNotFound
+func (s *SDK) Resubscribe(tokenIDs []string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.started {
+		return ErrNotStarted
+	}
+
+	if len(tokenIDs) == 0 {
+		return errors.New("tokenIDs cannot be empty")
+	}
+
+	return s.manager.Resubscribe(tokenIDs)
+}
+
+// Rebalance 重新平衡 token 在各 WebSocket 连接间的分布
+// 将失效连接上的 token 迁移到可用连接，并合并近乎空闲的连接
+// 取消订阅后会在需要时自动执行，通常无需手动调用
+func (s *SDK) Rebalance() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		return ErrNotStarted
+	}
+
+	return s.manager.Rebalance()
+}
+
+// Pool 获取 WebSocket 连接池（高级用法），未启动时返回 nil
+// 适合查看各连接承载的 token、通过 SetPlacement 调整放置策略后调用 Rebalance；
+// 订阅与取消订阅应通过 SDK 进行，直接调用连接池的 Subscribe/Unsubscribe/Close 会绕过订单簿管理
+func (s *SDK) Pool() *WSPool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return nil
+	}
+	return s.manager.Pool()
 }
 
-// Updates 获取更新通知channel
+// Updates 获取更新通知channel，Close 后该 channel 被关闭
+// 缓冲区满时丢弃最旧的行情更新，Initialized、Reset、Removed 与 SnapshotBatchComplete 保证送达
 func (s *SDK) Updates() <-chan OrderBookUpdate {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -105,6 +218,26 @@ func (s *SDK) Updates() <-chan OrderBookUpdate {
 	return s.manager.Updates()
 }
 
+// Done 返回在 SDK 关闭且 Updates channel 已关闭后关闭的 channel，未启动时返回 nil
+// 应在 Start 之后获取并持有，Close 之后再调用将返回 nil
+func (s *SDK) Done() <-chan struct{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return nil
+	}
+	return s.manager.Done()
+}
+
+// ApplyConfig 运行时调整心跳、重连与时间戳间隔参数，无需重连或重建 SDK
+// 未启动时同样生效，Start 时使用调整后的参数
+func (s *SDK) ApplyConfig(update *ConfigUpdate) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config.Apply(update)
+}
+
 // Close 关闭SDK
 func (s *SDK) Close() {
 	s.mu.Lock()
@@ -128,6 +261,65 @@ func (s *SDK) IsStarted() bool {
 	return s.started
 }
 
+// LastMessageTime 获取最近一次收到行情消息的时间，未启动或未收到过时返回零值
+// 可用于判断行情是否停滞
+func (s *SDK) LastMessageTime() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return time.Time{}
+	}
+	return s.manager.LastMessageTime()
+}
+
+// FlushRecent 将最近 Config.RecordWindow 秒内各连接收到的原始 WebSocket 帧按接收顺序写入 w（JSON Lines）
+// 用于在检测到异常（如价格跳变、hash 不一致）时保存此前的行情上下文
+func (s *SDK) FlushRecent(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return ErrNotStarted
+	}
+	return s.manager.FlushRecent(w)
+}
+
+// FeedLatency 获取行情延迟统计，未启动或未配置 Config.FeedLatency 时返回 nil
+func (s *SDK) FeedLatency() *FeedLatencyMonitor {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return nil
+	}
+	return s.manager.FeedLatency()
+}
+
+// GetGapStats 获取 token 的消息时间戳间隔统计（乱序、间隔过大、重新订阅次数）
+func (s *SDK) GetGapStats(tokenID string) (GapStats, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return GapStats{}, false
+	}
+	return s.manager.GetGapStats(tokenID)
+}
+
+// Resync 重置指定 token 的订单簿并重新订阅以获取新快照
+func (s *SDK) Resync(tokenIDs []string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.started {
+		return ErrNotStarted
+	}
+
+	s.manager.Resync(tokenIDs)
+	return nil
+}
+
 // IsInitialized 检查指定token的订单簿是否已初始化
 func (s *SDK) IsInitialized(tokenID string) bool {
 	s.mu.RLock()
@@ -150,6 +342,19 @@ func (s *SDK) IsAllInitialized() bool {
 	return s.manager.IsAllInitialized()
 }
 
+// WaitForSnapshots 阻塞直到 tokenIDs 的订单簿全部收到初始快照，可在 Subscribe 后调用以替代轮询等待
+// 也可以消费 Updates() 中的 EventTypeSnapshotBatchComplete 事件获得同样的信号
+func (s *SDK) WaitForSnapshots(ctx context.Context, tokenIDs []string) error {
+	s.mu.RLock()
+	manager := s.manager
+	s.mu.RUnlock()
+
+	if manager == nil {
+		return ErrNotStarted
+	}
+	return manager.WaitForSnapshots(ctx, tokenIDs)
+}
+
 // GetConnectionStatus 获取连接状态
 func (s *SDK) GetConnectionStatus() map[string]ConnectionState {
 	s.mu.RLock()
@@ -295,6 +500,65 @@ func (s *SDK) GetDepth(tokenID string, depth int) (bids []OrderSummary, asks []O
 	return bids, asks, nil
 }
 
+// SetOwnOrders 设置指定 token 上自身的挂单（整体替换，传入空列表等同于清除）
+// 用于 GetDepthExcludingOwn 计算剔除自身挂单后的外部订单簿视图
+func (s *SDK) SetOwnOrders(tokenID string, orders []OwnOrder) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return ErrNotStarted
+	}
+
+	s.manager.SetOwnOrders(tokenID, orders)
+	return nil
+}
+
+// SetTokenMetadata 注册 token 元数据（市场 slug、结果标签、NegRisk 标识）
+// 注册后该 token 的 OrderBookUpdate.Metadata 会被填充，取消订阅时自动清除
+func (s *SDK) SetTokenMetadata(tokenID string, meta TokenMetadata) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return ErrNotStarted
+	}
+
+	s.manager.SetTokenMetadata(tokenID, meta)
+	return nil
+}
+
+// GetTokenMetadata 获取已注册的 token 元数据
+func (s *SDK) GetTokenMetadata(tokenID string) (TokenMetadata, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return TokenMetadata{}, false
+	}
+	return s.manager.GetTokenMetadata(tokenID)
+}
+
+// GetDepthExcludingOwn 获取剔除自身挂单后的订单簿深度
+// 自身挂单通过 SetOwnOrders 设置，剔除后数量为 0 的档位不计入深度
+func (s *SDK) GetDepthExcludingOwn(tokenID string, depth int) (bids []OrderSummary, asks []OrderSummary, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ob, err := s.getOrderBookLocked(tokenID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ownBids, ownAsks := s.manager.GetOwnOrderLevels(tokenID)
+	bids, asks = ob.GetDepthExcluding(depth, ownBids, ownAsks)
+	if bids == nil && asks == nil {
+		return nil, nil, ErrNotInitialized
+	}
+
+	return bids, asks, nil
+}
+
 // GetTotalBidSize 获取买单总量
 func (s *SDK) GetTotalBidSize(tokenID string) (decimal.Decimal, error) {
 	s.mu.RLock()
@@ -365,6 +629,34 @@ func (s *SDK) GetAllBids(tokenID string) ([]OrderSummary, error) {
 	return result, nil
 }
 
+// IterateBids 按价格降序遍历买单，fn 返回 false 时停止
+// 与 GetAllBids 不同，不复制价位列表，适合每次更新都要遍历深度的分析循环
+func (s *SDK) IterateBids(tokenID string, fn func(level OrderSummary) bool) error {
+	return s.iterate(tokenID, SideBuy, fn)
+}
+
+// IterateAsks 按价格升序遍历卖单，fn 返回 false 时停止
+func (s *SDK) IterateAsks(tokenID string, fn func(level OrderSummary) bool) error {
+	return s.iterate(tokenID, SideSell, fn)
+}
+
+// iterate 遍历一侧价位，订单簿快照在 SDK 锁外遍历，fn 中可以调用 SDK
+func (s *SDK) iterate(tokenID string, side Side, fn func(level OrderSummary) bool) error {
+	s.mu.RLock()
+	ob, err := s.getOrderBookLocked(tokenID)
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	levels, ok := ob.sortedLevels(side)
+	if !ok {
+		return ErrNotInitialized
+	}
+	iterateLevels(levels, fn)
+	return nil
+}
+
 // ScanAsksBelow 扫描价格低于等于 maxPrice 的所有卖单
 // 返回可成交的订单列表 + 总数量 + 加权平均价格
 func (s *SDK) ScanAsksBelow(tokenID string, maxPrice decimal.Decimal) (*ScanResult, error) {
@@ -437,6 +729,86 @@ func (s *SDK) GetOrderBookHash(tokenID string) (string, error) {
 	return ob.Hash(), nil
 }
 
+// GetSnapshot 获取 L2 快照（含交易所时间戳与本地接收时间），depth <= 0 时包含全部档位
+// 可交给 SnapshotWriter 导出为 CSV / JSONL
+func (s *SDK) GetSnapshot(tokenID string, depth int) (*L2Snapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ob, err := s.getOrderBookLocked(tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := ob.Snapshot(depth)
+	if snap == nil {
+		return nil, ErrNotInitialized
+	}
+	return snap, nil
+}
+
+// GetAggregatedDepth 获取按价格桶聚合的订单簿（如 bucket=0.01 为 1 美分一档）
+// Polymarket 目前只推送逐价位的完整订单簿，聚合在客户端完成
+func (s *SDK) GetAggregatedDepth(tokenID string, bucket decimal.Decimal) (bids []OrderSummary, asks []OrderSummary, err error) {
+	if !bucket.IsPositive() || bucket.GreaterThan(decimal.NewFromInt(1)) {
+		return nil, nil, fmt.Errorf("%w: bucket must be in (0, 1], got %s", common.ErrInvalidPrice, bucket)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ob, err := s.getOrderBookLocked(tokenID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bids, asks = ob.GetAggregatedDepth(bucket)
+	if bids == nil && asks == nil {
+		return nil, nil, ErrNotInitialized
+	}
+	return bids, asks, nil
+}
+
+// GetBookSummaries 获取全部已初始化订单簿的顶部摘要（最优价、价差、总量），按 TokenID 排序
+// 扫描器可以基于摘要自行筛选，避免逐个 token 调用查询方法
+func (s *SDK) GetBookSummaries() ([]BookSummary, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.manager == nil {
+		return nil, ErrNotStarted
+	}
+	return s.manager.Summaries(), nil
+}
+
+// TopSpreads 价差最大的 n 个双边报价订单簿，价差降序，n <= 0 时返回全部
+func (s *SDK) TopSpreads(n int) ([]BookSummary, error) {
+	summaries, err := s.GetBookSummaries()
+	if err != nil {
+		return nil, err
+	}
+	return topSpreads(summaries, n), nil
+}
+
+// SortedByDepth 买卖双边总量最大的 n 个订单簿，总量降序，n <= 0 时返回全部
+func (s *SDK) SortedByDepth(n int) ([]BookSummary, error) {
+	summaries, err := s.GetBookSummaries()
+	if err != nil {
+		return nil, err
+	}
+	return sortedByDepth(summaries, n), nil
+}
+
+// TokensWithSumBelow 返回各结果（如 YES + NO）最优卖价之和小于 threshold 的市场，和升序
+// 结果 token 按 SetTokenMetadata 注册的 MarketSlug 归组，未注册元数据的 token 不参与
+func (s *SDK) TokensWithSumBelow(threshold decimal.Decimal) ([]MarketAskSum, error) {
+	summaries, err := s.GetBookSummaries()
+	if err != nil {
+		return nil, err
+	}
+	return marketsWithAskSumBelow(summaries, threshold), nil
+}
+
 // SimulateBuyAsks 模拟买入卖单（吃单）
 // 根据所需数量，从最优卖价开始累加，计算加权平均成交价格
 // requiredSize: 需要买入的数量
@@ -457,3 +829,59 @@ func (s *SDK) SimulateBuyAsks(tokenID string, requiredSize decimal.Decimal) (*Fi
 
 	return result, nil
 }
+
+// EstimateImpact 估算以 size 数量立即吃单的冲击（平均成交价、最差价位、成交后 BBO、相对中间价滑点）
+// side 为 BUY 时吃卖单，为 SELL 时吃买单；深度不足时 IsFullFill 为 false
+func (s *SDK) EstimateImpact(tokenID string, side Side, size decimal.Decimal) (*ImpactEstimate, error) {
+	if !size.IsPositive() {
+		return nil, fmt.Errorf("size must be positive, got %s", size)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ob, err := s.getOrderBookLocked(tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	est := ob.EstimateImpact(side, size)
+	if est == nil {
+		if !ob.IsInitialized() {
+			return nil, ErrNotInitialized
+		}
+		return nil, ErrNoData
+	}
+
+	return est, nil
+}
+
+// ArbEdge 计算同时吃单买入 size 份 YES 与 NO 的每份净边际（含逐档滑点与 taker 手续费，feeBps 为基点）
+// EdgePerShare > 0 表示扣除成本后仍有利可图；深度不足时按两边可成交的较小数量计算
+func (s *SDK) ArbEdge(yesTokenID, noTokenID string, size decimal.Decimal, feeBps int) (*ArbEdgeResult, error) {
+	if !size.IsPositive() {
+		return nil, fmt.Errorf("size must be positive, got %s", size)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	yesBook, err := s.getOrderBookLocked(yesTokenID)
+	if err != nil {
+		return nil, err
+	}
+	noBook, err := s.getOrderBookLocked(noTokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := ArbEdge(yesBook, noBook, size, feeBps)
+	if result == nil {
+		if !yesBook.IsInitialized() || !noBook.IsInitialized() {
+			return nil, ErrNotInitialized
+		}
+		return nil, ErrNoData
+	}
+
+	return result, nil
+}
@@ -0,0 +1,443 @@
+package orderbook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// maxSLOSamples 每个 token 窗口内保留的计数器采样上限，Interval 过小时丢弃最旧的采样（窗口起点随之后移）
+const maxSLOSamples = 4096
+
+// SLOMetric 行情质量 SLO 指标
+type SLOMetric string
+
+const (
+	// SLOStaleness 距最近一次行情更新的时长
+	SLOStaleness SLOMetric = "staleness"
+	// SLODroppedUpdates 窗口内被丢弃或疑似丢失的消息数
+	SLODroppedUpdates SLOMetric = "dropped_updates"
+	// SLOResyncs 窗口内因间隔过大触发的重新订阅次数
+	SLOResyncs SLOMetric = "resyncs"
+)
+
+// sloMetrics 评估与汇总中指标的固定顺序
+var sloMetrics = []SLOMetric{SLOStaleness, SLODroppedUpdates, SLOResyncs}
+
+// SLO 单个 token 的行情质量目标，阈值为 0 表示不检查对应指标
+type SLO struct {
+	// MaxStaleness 距最近一次行情更新（book、price_change、tick_size_change、last_trade_price）的最长时间
+	// 冷门市场可能长时间没有变化，应按市场活跃度设置
+	MaxStaleness time.Duration
+	// MaxDroppedUpdates 窗口内丢弃或疑似丢失的消息数上限，即 GapStats.OutOfOrder + GapStats.Gaps 的增量
+	MaxDroppedUpdates int64
+	// MaxResyncs 窗口内重新订阅次数上限，即 GapStats.Resyncs 的增量
+	MaxResyncs int64
+}
+
+// threshold 指标阈值，staleness 以秒为单位
+func (s SLO) threshold(metric SLOMetric) float64 {
+	switch metric {
+	case SLOStaleness:
+		return s.MaxStaleness.Seconds()
+	case SLODroppedUpdates:
+		return float64(s.MaxDroppedUpdates)
+	default:
+		return float64(s.MaxResyncs)
+	}
+}
+
+// SLOSource SLO 监控读取计数器与订阅列表的接口，*SDK 与 *Manager 均满足
+type SLOSource interface {
+	GetGapStats(tokenID string) (GapStats, bool)
+	GetSubscribedTokens() []string
+}
+
+// SLOConfig SLO 监控配置
+type SLOConfig struct {
+	// Default 未通过 SetSLO 单独设置的已订阅 token 使用的目标，nil 表示只监控单独设置的 token
+	Default        *SLO
+	Window         time.Duration // 丢弃与重新订阅的计数窗口
+	Interval       time.Duration // Run 中的评估间隔
+	ChannelSize    int           // 违约事件 channel 缓冲区大小，满时丢弃新事件
+	WebhookURL     string        // 可选，违约与恢复事件以 JSON POST 到该地址，可直接对接告警平台
+	WebhookTimeout time.Duration // webhook 请求超时
+}
+
+// DefaultSLOConfig 默认配置：按小时计数，每 5 秒评估一次
+func DefaultSLOConfig() *SLOConfig {
+	return &SLOConfig{
+		Window:         time.Hour,
+		Interval:       5 * time.Second,
+		ChannelSize:    100,
+		WebhookTimeout: 5 * time.Second,
+	}
+}
+
+// SLOViolation 违约事件，每个指标从达标变为违约时发送一次，恢复达标时再发送一次 Resolved 事件
+type SLOViolation struct {
+	TokenID   string         `json:"token_id"`
+	Metric    SLOMetric      `json:"metric"`
+	Value     float64        `json:"value"` // 指标值，staleness 以秒为单位
+	Threshold float64        `json:"threshold"`
+	Resolved  bool           `json:"resolved"` // true 表示恢复达标（token 停止监控时同样发送）
+	Since     time.Time      `json:"since"`    // 本次违约开始的时间
+	Time      time.Time      `json:"time"`
+	Metadata  *TokenMetadata `json:"metadata,omitempty"`
+}
+
+// SLOStatus 单个 token 的 SLO 状态
+type SLOStatus struct {
+	TokenID        string
+	SLO            SLO
+	LastUpdate     time.Time     // 最近一次行情更新，尚未收到时为零值
+	Staleness      time.Duration // 距最近一次更新（尚未收到时距开始监控）的时长
+	DroppedUpdates int64         // 窗口内丢弃或疑似丢失的消息数
+	Resyncs        int64         // 窗口内重新订阅次数
+	Violations     []SLOMetric   // 当前违约的指标
+	Healthy        bool          // 全部指标达标
+}
+
+// sloSample 计数器采样（累计值）
+type sloSample struct {
+	at      time.Time
+	dropped int64
+	resyncs int64
+}
+
+// sloState 单个 token 的监控状态
+type sloState struct {
+	startedAt time.Time // 开始监控的时间
+	samples   []sloSample
+	active    map[SLOMetric]time.Time // 违约中的指标 -> 违约开始时间
+	last      map[SLOMetric]float64   // 最近一次评估的指标值
+}
+
+// SLOMonitor 持续评估每个 token 的行情质量目标（数据新鲜度、丢消息数、重新订阅频率），
+// 违约与恢复时发出事件，Summary 返回全部 token 的当前状态，可直接对接值班告警
+type SLOMonitor struct {
+	mu         sync.Mutex
+	source     SLOSource
+	config     *SLOConfig
+	slos       map[string]SLO
+	states     map[string]*sloState
+	lastUpdate map[string]time.Time
+	metadata   map[string]*TokenMetadata
+
+	violationChan chan SLOViolation
+	httpClient    *http.Client
+	now           func() time.Time
+}
+
+// NewSLOMonitor 创建 SLO 监控
+func NewSLOMonitor(source SLOSource, config *SLOConfig) *SLOMonitor {
+	if config == nil {
+		config = DefaultSLOConfig()
+	}
+	if config.Window <= 0 {
+		config.Window = time.Hour
+	}
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+
+	return &SLOMonitor{
+		source:        source,
+		config:        config,
+		slos:          make(map[string]SLO),
+		states:        make(map[string]*sloState),
+		lastUpdate:    make(map[string]time.Time),
+		metadata:      make(map[string]*TokenMetadata),
+		violationChan: make(chan SLOViolation, config.ChannelSize),
+		httpClient:    &http.Client{Timeout: config.WebhookTimeout},
+		now:           time.Now,
+	}
+}
+
+// SetSLO 为 token 单独设置目标，覆盖 Default
+func (m *SLOMonitor) SetSLO(tokenID string, slo SLO) error {
+	if tokenID == "" {
+		return fmt.Errorf("SLO token ID is required")
+	}
+	if slo.MaxStaleness < 0 || slo.MaxDroppedUpdates < 0 || slo.MaxResyncs < 0 {
+		return fmt.Errorf("SLO thresholds must not be negative")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.slos[tokenID] = slo
+	return nil
+}
+
+// RemoveSLO 移除 token 的单独目标，之后按 Default 监控（Default 为 nil 时停止监控）
+func (m *SLOMonitor) RemoveSLO(tokenID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.slos, tokenID)
+}
+
+// Violations 获取违约事件 channel
+func (m *SLOMonitor) Violations() <-chan SLOViolation {
+	return m.violationChan
+}
+
+// Run 消费更新流记录数据新鲜度，并按 Interval 评估全部 token，直到 ctx 取消或 updates 关闭
+// updates 为 nil 时只做定时评估（此时 staleness 无法感知更新，需在自己的循环中调用 Process）
+func (m *SLOMonitor) Run(ctx context.Context, updates <-chan OrderBookUpdate) {
+	common.Supervise(ctx, "orderbook.slo", nil, func(ctx context.Context) {
+		ticker := time.NewTicker(m.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.Evaluate()
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				m.Process(update)
+			}
+		}
+	})
+}
+
+// Process 记录一条更新，行情类事件刷新该 token 的最近更新时间
+func (m *SLOMonitor) Process(update OrderBookUpdate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch update.EventType {
+	case EventTypeBook, EventTypePriceChange, EventTypeTickSizeChange, EventTypeLastTradePrice:
+		m.lastUpdate[update.TokenID] = m.now()
+		if update.Metadata != nil {
+			m.metadata[update.TokenID] = update.Metadata
+		}
+	case EventTypeRemoved:
+		delete(m.lastUpdate, update.TokenID)
+		delete(m.metadata, update.TokenID)
+	}
+}
+
+// Evaluate 立即评估全部 token 并投递状态变化事件，返回本次产生的事件
+func (m *SLOMonitor) Evaluate() []SLOViolation {
+	_, events := m.evaluate()
+	for _, event := range events {
+		m.deliver(event)
+	}
+	return events
+}
+
+// Summary 评估全部 token 并返回当前状态（按 token 排序），评估中产生的事件同样会投递
+func (m *SLOMonitor) Summary() []SLOStatus {
+	statuses, events := m.evaluate()
+	for _, event := range events {
+		m.deliver(event)
+	}
+	return statuses
+}
+
+// evaluate 采样计数器、计算各指标并更新违约状态
+func (m *SLOMonitor) evaluate() ([]SLOStatus, []SLOViolation) {
+	targets := m.targets()
+
+	// 在锁外读取计数器，避免与 Manager 的锁嵌套
+	stats := make(map[string]GapStats, len(targets))
+	for tokenID := range targets {
+		if s, ok := m.source.GetGapStats(tokenID); ok {
+			stats[tokenID] = s
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	var events []SLOViolation
+
+	// 停止监控的 token 对违约中的指标发送恢复事件，避免告警平台一直挂起
+	for tokenID, st := range m.states {
+		if _, ok := targets[tokenID]; ok {
+			continue
+		}
+		for _, metric := range sloMetrics {
+			if since, active := st.active[metric]; active {
+				events = append(events, SLOViolation{
+					TokenID:  tokenID,
+					Metric:   metric,
+					Value:    st.last[metric],
+					Resolved: true,
+					Since:    since,
+					Time:     now,
+					Metadata: m.metadata[tokenID],
+				})
+			}
+		}
+		delete(m.states, tokenID)
+	}
+
+	statuses := make([]SLOStatus, 0, len(targets))
+	for tokenID, slo := range targets {
+		st := m.states[tokenID]
+		if st == nil {
+			st = &sloState{
+				startedAt: now,
+				active:    make(map[SLOMetric]time.Time),
+				last:      make(map[SLOMetric]float64),
+			}
+			m.states[tokenID] = st
+		}
+
+		gap := stats[tokenID]
+		dropped, resyncs := m.sampleLocked(st, now, gap.OutOfOrder+gap.Gaps, gap.Resyncs)
+
+		lastUpdate := m.lastUpdate[tokenID]
+		from := lastUpdate
+		if from.IsZero() || from.Before(st.startedAt) {
+			from = st.startedAt
+		}
+		staleness := now.Sub(from)
+
+		status := SLOStatus{
+			TokenID:        tokenID,
+			SLO:            slo,
+			LastUpdate:     lastUpdate,
+			Staleness:      staleness,
+			DroppedUpdates: dropped,
+			Resyncs:        resyncs,
+		}
+		values := map[SLOMetric]float64{
+			SLOStaleness:      staleness.Seconds(),
+			SLODroppedUpdates: float64(dropped),
+			SLOResyncs:        float64(resyncs),
+		}
+		breached := map[SLOMetric]bool{
+			SLOStaleness:      slo.MaxStaleness > 0 && staleness > slo.MaxStaleness,
+			SLODroppedUpdates: slo.MaxDroppedUpdates > 0 && dropped > slo.MaxDroppedUpdates,
+			SLOResyncs:        slo.MaxResyncs > 0 && resyncs > slo.MaxResyncs,
+		}
+
+		for _, metric := range sloMetrics {
+			st.last[metric] = values[metric]
+			since, active := st.active[metric]
+			switch {
+			case breached[metric] && !active:
+				since = now
+				st.active[metric] = since
+			case !breached[metric] && active:
+				delete(st.active, metric)
+			default:
+				if breached[metric] {
+					status.Violations = append(status.Violations, metric)
+				}
+				continue
+			}
+
+			events = append(events, SLOViolation{
+				TokenID:   tokenID,
+				Metric:    metric,
+				Value:     values[metric],
+				Threshold: slo.threshold(metric),
+				Resolved:  !breached[metric],
+				Since:     since,
+				Time:      now,
+				Metadata:  m.metadata[tokenID],
+			})
+			if breached[metric] {
+				status.Violations = append(status.Violations, metric)
+			}
+		}
+		status.Healthy = len(status.Violations) == 0
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].TokenID < statuses[j].TokenID })
+	return statuses, events
+}
+
+// targets 当前需要监控的 token 及其目标：单独设置的 token，以及 Default 非 nil 时的全部已订阅 token
+func (m *SLOMonitor) targets() map[string]SLO {
+	var subscribed []string
+	if m.config.Default != nil {
+		subscribed = m.source.GetSubscribedTokens()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	targets := make(map[string]SLO, len(m.slos)+len(subscribed))
+	for _, tokenID := range subscribed {
+		targets[tokenID] = *m.config.Default
+	}
+	for tokenID, slo := range m.slos {
+		targets[tokenID] = slo
+	}
+	return targets
+}
+
+// sampleLocked 记录一次累计计数采样并返回窗口内的增量（需持有 m.mu）
+// 以窗口起点之前的最后一次采样为基线；计数器回退（token 被移除后重新订阅）时重新开始计数
+func (m *SLOMonitor) sampleLocked(st *sloState, now time.Time, dropped, resyncs int64) (int64, int64) {
+	if n := len(st.samples); n > 0 && (dropped < st.samples[n-1].dropped || resyncs < st.samples[n-1].resyncs) {
+		st.samples = st.samples[:0]
+	}
+	st.samples = append(st.samples, sloSample{at: now, dropped: dropped, resyncs: resyncs})
+
+	cutoff := now.Add(-m.config.Window)
+	drop := 0
+	for drop+1 < len(st.samples) && !st.samples[drop+1].at.After(cutoff) {
+		drop++
+	}
+	if excess := len(st.samples) - drop - maxSLOSamples; excess > 0 {
+		drop += excess
+	}
+	if drop > 0 {
+		st.samples = append(st.samples[:0], st.samples[drop:]...)
+	}
+
+	base := st.samples[0]
+	return dropped - base.dropped, resyncs - base.resyncs
+}
+
+// deliver 投递事件：channel 满时丢弃，webhook 异步发送
+func (m *SLOMonitor) deliver(event SLOViolation) {
+	select {
+	case m.violationChan <- event:
+	default:
+	}
+
+	if m.config.WebhookURL != "" {
+		go func() {
+			defer common.RecoverPanic("orderbook.slo.webhook", nil)
+			_ = m.postWebhook(event)
+		}()
+	}
+}
+
+// postWebhook 以 JSON POST 事件到 webhook
+func (m *SLOMonitor) postWebhook(event SLOViolation) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := m.httpClient.Post(m.config.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package orderbook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSLOSource is an SLOSource backed by mutable counters
+type fakeSLOSource struct {
+	mu         sync.Mutex
+	stats      map[string]GapStats
+	subscribed []string
+}
+
+func (f *fakeSLOSource) GetGapStats(tokenID string) (GapStats, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.stats[tokenID]
+	return s, ok
+}
+
+func (f *fakeSLOSource) GetSubscribedTokens() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.subscribed...)
+}
+
+func (f *fakeSLOSource) set(tokenID string, stats GapStats) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stats[tokenID] = stats
+}
+
+func newTestSLOMonitor(source *fakeSLOSource, config *SLOConfig) (*SLOMonitor, *fakeClock) {
+	clock := newFakeClock()
+	monitor := NewSLOMonitor(source, config)
+	monitor.now = clock.now
+	return monitor, clock
+}
+
+func TestSLOMonitor_Staleness(t *testing.T) {
+	source := &fakeSLOSource{stats: map[string]GapStats{}}
+	monitor, clock := newTestSLOMonitor(source, nil)
+	if err := monitor.SetSLO("token", SLO{MaxStaleness: 30 * time.Second}); err != nil {
+		t.Fatalf("SetSLO() error: %v", err)
+	}
+	update := OrderBookUpdate{TokenID: "token", EventType: EventTypePriceChange}
+
+	// a token that never updates is stale relative to when monitoring started
+	monitor.Evaluate()
+	clock.advance(31 * time.Second)
+	events := monitor.Evaluate()
+	if len(events) != 1 || events[0].Metric != SLOStaleness || events[0].Resolved || events[0].Threshold != 30 {
+		t.Fatalf("expected staleness violation, got %+v", events)
+	}
+	since := events[0].Since
+
+	// still violating: no repeated event
+	clock.advance(10 * time.Second)
+	if events := monitor.Evaluate(); len(events) != 0 {
+		t.Errorf("expected no repeated events, got %+v", events)
+	}
+
+	monitor.Process(update)
+	events = monitor.Evaluate()
+	if len(events) != 1 || !events[0].Resolved || !events[0].Since.Equal(since) {
+		t.Fatalf("expected resolved staleness event, got %+v", events)
+	}
+
+	// resets and batch markers do not refresh staleness
+	clock.advance(20 * time.Second)
+	monitor.Process(OrderBookUpdate{TokenID: "token", EventType: EventTypeReset, ResetReason: ResetReasonDisconnect})
+	clock.advance(11 * time.Second)
+	if events := monitor.Evaluate(); len(events) != 1 || events[0].Resolved {
+		t.Errorf("expected staleness violation after reset, got %+v", events)
+	}
+}
+
+func TestSLOMonitor_WindowedCounters(t *testing.T) {
+	source := &fakeSLOSource{
+		stats: map[string]GapStats{"token": {OutOfOrder: 40, Gaps: 10, Resyncs: 7}},
+	}
+	config := DefaultSLOConfig()
+	config.Window = 10 * time.Minute
+	monitor, clock := newTestSLOMonitor(source, config)
+	monitor.SetSLO("token", SLO{MaxDroppedUpdates: 5, MaxResyncs: 2})
+	monitor.Process(OrderBookUpdate{TokenID: "token", EventType: EventTypeBook})
+
+	// counters accumulated before monitoring started do not count
+	if events := monitor.Evaluate(); len(events) != 0 {
+		t.Fatalf("expected no events for pre-existing counters, got %+v", events)
+	}
+
+	clock.advance(time.Minute)
+	source.set("token", GapStats{OutOfOrder: 44, Gaps: 12, Resyncs: 10})
+	events := monitor.Evaluate()
+	if len(events) != 2 {
+		t.Fatalf("expected dropped and resync violations, got %+v", events)
+	}
+	if events[0].Metric != SLODroppedUpdates || events[0].Value != 6 || events[1].Metric != SLOResyncs || events[1].Value != 3 {
+		t.Errorf("unexpected violations: %+v", events)
+	}
+
+	summary := monitor.Summary()
+	if len(summary) != 1 || summary[0].Healthy || len(summary[0].Violations) != 2 || summary[0].DroppedUpdates != 6 || summary[0].Resyncs != 3 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	// once the burst falls out of the window both metrics recover
+	clock.advance(9 * time.Minute)
+	if events := monitor.Evaluate(); len(events) != 0 {
+		t.Fatalf("burst is still inside the window, got %+v", events)
+	}
+	clock.advance(time.Minute)
+	events = monitor.Evaluate()
+	if len(events) != 2 || !events[0].Resolved || !events[1].Resolved {
+		t.Fatalf("expected both metrics to resolve, got %+v", events)
+	}
+	if summary := monitor.Summary(); !summary[0].Healthy || summary[0].DroppedUpdates != 0 {
+		t.Errorf("expected healthy summary, got %+v", summary)
+	}
+
+	// counters going backwards (token removed and resubscribed) restart the window
+	clock.advance(time.Minute)
+	source.set("token", GapStats{Resyncs: 1})
+	if events := monitor.Evaluate(); len(events) != 0 {
+		t.Errorf("expected counter reset to restart the window, got %+v", events)
+	}
+}
+
+func TestSLOMonitor_DefaultAndUnsubscribe(t *testing.T) {
+	source := &fakeSLOSource{stats: map[string]GapStats{}, subscribed: []string{"a", "b"}}
+	config := DefaultSLOConfig()
+	config.Default = &SLO{MaxStaleness: time.Minute}
+	monitor, clock := newTestSLOMonitor(source, config)
+	monitor.SetSLO("b", SLO{MaxStaleness: 10 * time.Minute})
+
+	monitor.Evaluate()
+	clock.advance(2 * time.Minute)
+	monitor.Process(OrderBookUpdate{TokenID: "a", EventType: EventTypeBook, Metadata: &TokenMetadata{MarketSlug: "m"}})
+	monitor.Process(OrderBookUpdate{TokenID: "c", EventType: EventTypeBook})
+	clock.advance(2 * time.Minute)
+
+	summary := monitor.Summary()
+	if len(summary) != 2 || summary[0].TokenID != "a" || summary[1].TokenID != "b" {
+		t.Fatalf("expected subscribed tokens only, got %+v", summary)
+	}
+	if summary[0].Healthy || summary[0].SLO.MaxStaleness != time.Minute || summary[0].Staleness != 2*time.Minute {
+		t.Errorf("token a should use the default SLO and be stale: %+v", summary[0])
+	}
+	if !summary[1].Healthy || summary[1].SLO.MaxStaleness != 10*time.Minute {
+		t.Errorf("token b should use its own SLO: %+v", summary[1])
+	}
+
+	drainSLO(monitor)
+	source.mu.Lock()
+	source.subscribed = []string{"b"}
+	source.mu.Unlock()
+	events := monitor.Evaluate()
+	if len(events) != 1 || events[0].TokenID != "a" || !events[0].Resolved || events[0].Metadata == nil {
+		t.Errorf("expected resolved event for unsubscribed token, got %+v", events)
+	}
+	if got := drainSLO(monitor); len(got) != 1 {
+		t.Errorf("expected event on channel, got %d", len(got))
+	}
+}
+
+func TestSLOMonitor_SetSLOValidation(t *testing.T) {
+	monitor := NewSLOMonitor(&fakeSLOSource{}, nil)
+	if err := monitor.SetSLO("", SLO{}); err == nil {
+		t.Error("expected error for empty token ID")
+	}
+	if err := monitor.SetSLO("token", SLO{MaxResyncs: -1}); err == nil {
+		t.Error("expected error for negative threshold")
+	}
+}
+
+func TestSLOMonitor_RunAndWebhook(t *testing.T) {
+	received := make(chan SLOViolation, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v SLOViolation
+		json.NewDecoder(r.Body).Decode(&v)
+		received <- v
+	}))
+	defer server.Close()
+
+	source := &fakeSLOSource{stats: map[string]GapStats{}}
+	config := DefaultSLOConfig()
+	config.Interval = 5 * time.Millisecond
+	config.WebhookURL = server.URL
+	monitor := NewSLOMonitor(source, config)
+	monitor.SetSLO("token", SLO{MaxStaleness: 20 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go monitor.Run(ctx, nil)
+
+	select {
+	case v := <-received:
+		if v.TokenID != "token" || v.Metric != SLOStaleness || v.Resolved {
+			t.Errorf("unexpected webhook payload: %+v", v)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook not called")
+	}
+}
+
+func drainSLO(m *SLOMonitor) []SLOViolation {
+	var events []SLOViolation
+	for {
+		select {
+		case v := <-m.Violations():
+			events = append(events, v)
+		default:
+			return events
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package orderbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// snapshotBatch 一批等待初始快照的 token
+type snapshotBatch struct {
+	tokens  []string            // 批次内全部 token（按订阅顺序）
+	pending map[string]struct{} // 尚未收到快照的 token
+	notify  bool                // 完成时推送 EventTypeSnapshotBatchComplete（Subscribe 创建的批次）
+	done    chan struct{}       // 完成时关闭
+}
+
+// addSnapshotBatchLocked 为尚未初始化的 token 创建快照批次（调用者需持有锁）
+// 全部已初始化或不维护订单簿时返回 nil，notify 批次会立即推送完成事件
+func (m *Manager) addSnapshotBatchLocked(tokenIDs []string, notify bool) *snapshotBatch {
+	batch := &snapshotBatch{
+		tokens:  append([]string(nil), tokenIDs...),
+		pending: make(map[string]struct{}, len(tokenIDs)),
+		notify:  notify,
+		done:    make(chan struct{}),
+	}
+	for _, tokenID := range tokenIDs {
+		if ob, ok := m.orderBooks[tokenID]; ok && m.tracksBookLocked(tokenID) && !ob.IsInitialized() {
+			batch.pending[tokenID] = struct{}{}
+		}
+	}
+
+	if len(batch.pending) == 0 {
+		m.finishSnapshotBatchLocked(batch, 0, time.Now())
+		return nil
+	}
+	m.snapshotBatches = append(m.snapshotBatches, batch)
+	return batch
+}
+
+// snapshotReadyLocked token 已有快照或不再需要快照，从各批次中移除并结束已完成的批次（调用者需持有锁）
+func (m *Manager) snapshotReadyLocked(tokenID string, ts int64, receivedAt time.Time) {
+	if len(m.snapshotBatches) == 0 {
+		return
+	}
+
+	remaining := m.snapshotBatches[:0]
+	for _, batch := range m.snapshotBatches {
+		delete(batch.pending, tokenID)
+		if len(batch.pending) == 0 {
+			m.finishSnapshotBatchLocked(batch, ts, receivedAt)
+			continue
+		}
+		remaining = append(remaining, batch)
+	}
+	clear(m.snapshotBatches[len(remaining):])
+	m.snapshotBatches = remaining
+}
+
+// removeSnapshotBatchLocked 移除未完成的批次（等待方放弃时调用，调用者需持有锁）
+func (m *Manager) removeSnapshotBatchLocked(target *snapshotBatch) {
+	for i, batch := range m.snapshotBatches {
+		if batch == target {
+			m.snapshotBatches = append(m.snapshotBatches[:i], m.snapshotBatches[i+1:]...)
+			return
+		}
+	}
+}
+
+// finishSnapshotBatchLocked 结束批次：唤醒等待方，Subscribe 创建的批次推送完成事件（调用者需持有锁）
+func (m *Manager) finishSnapshotBatchLocked(batch *snapshotBatch, ts int64, receivedAt time.Time) {
+	close(batch.done)
+	if !batch.notify || len(batch.tokens) == 0 {
+		return
+	}
+	m.sendUpdate(OrderBookUpdate{
+		TokenID:       batch.tokens[len(batch.tokens)-1],
+		EventType:     EventTypeSnapshotBatchComplete,
+		Timestamp:     ts,
+		ReceivedAt:    receivedAt,
+		SnapshotBatch: append([]string(nil), batch.tokens...),
+	})
+}
+
+// WaitForSnapshots 等待 tokenIDs 的订单簿全部收到初始快照，替代轮询 IsAllInitialized 的等待循环
+// 不维护订单簿的 token（事件过滤排除了 book/price_change）视为已就绪；
+// 未订阅的 token 返回 ErrTokenNotFound，ctx 取消或管理器关闭时返回错误并附带仍在等待的数量
+func (m *Manager) WaitForSnapshots(ctx context.Context, tokenIDs []string) error {
+	m.mu.Lock()
+	for _, tokenID := range tokenIDs {
+		if !m.subscribedTokens[tokenID] {
+			m.mu.Unlock()
+			return fmt.Errorf("%w: %s", ErrTokenNotFound, tokenID)
+		}
+	}
+	batch := m.addSnapshotBatchLocked(tokenIDs, false)
+	m.mu.Unlock()
+
+	if batch == nil {
+		return nil
+	}
+
+	var cause error
+	select {
+	case <-batch.done:
+		return nil
+	case <-ctx.Done():
+		cause = ctx.Err()
+	case <-m.closeChan:
+		cause = fmt.Errorf("orderbook manager closed")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	select {
+	case <-batch.done:
+		return nil
+	default:
+	}
+	m.removeSnapshotBatchLocked(batch)
+	return fmt.Errorf("%w: %d of %d snapshots still pending", cause, len(batch.pending), len(tokenIDs))
+}
@@ -0,0 +1,103 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// subscribeLocally registers tokens on the manager and opens a snapshot batch without a pool
+func subscribeLocally(m *Manager, tokenIDs ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, tokenID := range tokenIDs {
+		m.orderBooks[tokenID] = NewOrderBook(tokenID)
+		m.subscribedTokens[tokenID] = true
+	}
+	m.addSnapshotBatchLocked(tokenIDs, true)
+}
+
+func bookFor(tokenID string) []byte {
+	return []byte(`{"event_type":"book","asset_id":"` + tokenID + `","timestamp":"1000","bids":[{"price":"0.5","size":"10"}],"asks":[]}`)
+}
+
+func TestManager_SnapshotBatchComplete(t *testing.T) {
+	m := NewManager(nil)
+	subscribeLocally(m, "token-1", "token-2")
+
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- m.WaitForSnapshots(context.Background(), []string{"token-1", "token-2"}) }()
+
+	m.handleBookMessage(bookFor("token-1"), time.Time{})
+	if update := <-m.Updates(); update.EventType != EventTypeBook {
+		t.Fatalf("expected the book update first, got %s", update.EventType)
+	}
+	<-m.Updates() // initialized
+	select {
+	case err := <-waitErr:
+		t.Fatalf("WaitForSnapshots returned early: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	m.handleBookMessage(bookFor("token-2"), time.Time{})
+	<-m.Updates()
+	<-m.Updates()
+	update := <-m.Updates()
+	if update.EventType != EventTypeSnapshotBatchComplete || len(update.SnapshotBatch) != 2 || update.TokenID != "token-2" || update.Timestamp != 1000 {
+		t.Fatalf("unexpected batch event %+v", update)
+	}
+	select {
+	case err := <-waitErr:
+		if err != nil {
+			t.Fatalf("WaitForSnapshots() error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WaitForSnapshots did not return after the last snapshot")
+	}
+
+	// Resnapshots do not repeat the batch event; waiting on ready books returns immediately
+	m.handleBookMessage(bookFor("token-1"), time.Time{})
+	<-m.Updates()
+	select {
+	case update := <-m.Updates():
+		t.Errorf("unexpected update %+v", update)
+	default:
+	}
+	if err := m.WaitForSnapshots(context.Background(), []string{"token-1"}); err != nil {
+		t.Errorf("WaitForSnapshots() on ready books error: %v", err)
+	}
+}
+
+func TestManager_WaitForSnapshotsErrors(t *testing.T) {
+	m := NewManager(nil)
+	subscribeLocally(m, "token-1", "token-2")
+
+	if err := m.WaitForSnapshots(context.Background(), []string{"unknown"}); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected ErrTokenNotFound, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := m.WaitForSnapshots(ctx, []string{"token-1", "token-2"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	m.mu.RLock()
+	batches := len(m.snapshotBatches)
+	m.mu.RUnlock()
+	if batches != 1 {
+		t.Errorf("abandoned waits must be removed, %d batches left", batches)
+	}
+
+	// Unsubscribing and filtering out books settle the subscribe batch
+	m.SetEventFilter("token-1", EventTypeLastTradePrice)
+	if err := m.Unsubscribe([]string{"token-2"}); err != nil {
+		t.Fatal(err)
+	}
+	if update := <-m.Updates(); update.EventType != EventTypeRemoved || update.TokenID != "token-2" {
+		t.Errorf("expected a removed event, got %+v", update)
+	}
+	if update := <-m.Updates(); update.EventType != EventTypeSnapshotBatchComplete {
+		t.Errorf("expected a batch event, got %+v", update)
+	}
+}
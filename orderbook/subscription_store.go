@@ -0,0 +1,161 @@
+package orderbook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// SavedSubscription 持久化的单个订阅
+type SavedSubscription struct {
+	TokenID    string      `json:"token_id"`
+	EventTypes []EventType `json:"event_types,omitempty"` // 自定义事件过滤，为空表示默认（book + price_change）
+}
+
+// SubscriptionStore 订阅状态持久化接口，Save 整体替换已保存的订阅
+type SubscriptionStore interface {
+	Load(ctx context.Context) ([]SavedSubscription, error)
+	Save(ctx context.Context, subscriptions []SavedSubscription) error
+}
+
+// FileSubscriptionStore 基于本地文件的订阅存储（JSON 格式）
+type FileSubscriptionStore struct {
+	path string
+}
+
+// NewFileSubscriptionStore 创建文件订阅存储
+func NewFileSubscriptionStore(path string) *FileSubscriptionStore {
+	return &FileSubscriptionStore{path: path}
+}
+
+// Load 读取订阅，文件不存在时返回空列表
+func (s *FileSubscriptionStore) Load(ctx context.Context) ([]SavedSubscription, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read subscription file: %w", err)
+	}
+
+	var subscriptions []SavedSubscription
+	if err := json.Unmarshal(data, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to parse subscription file: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// Save 写入订阅（先写临时文件再重命名，避免写入中断导致文件损坏）
+func (s *FileSubscriptionStore) Save(ctx context.Context, subscriptions []SavedSubscription) error {
+	data, err := json.MarshalIndent(subscriptions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal subscriptions: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".subscriptions-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write subscriptions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write subscriptions: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to save subscription file: %w", err)
+	}
+	return nil
+}
+
+// savedSubscriptions 当前订阅及其事件过滤，按 tokenID 排序
+func (m *Manager) savedSubscriptions() []SavedSubscription {
+	m.mu.RLock()
+	tokens := make([]string, 0, len(m.subscribedTokens))
+	for tokenID := range m.subscribedTokens {
+		tokens = append(tokens, tokenID)
+	}
+	custom := make(map[string]bool, len(m.eventFilters))
+	for tokenID := range m.eventFilters {
+		custom[tokenID] = true
+	}
+	m.mu.RUnlock()
+
+	sort.Strings(tokens)
+	subscriptions := make([]SavedSubscription, 0, len(tokens))
+	for _, tokenID := range tokens {
+		sub := SavedSubscription{TokenID: tokenID}
+		if custom[tokenID] {
+			sub.EventTypes = m.GetEventFilter(tokenID)
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+	return subscriptions
+}
+
+// persistSubscriptions 将当前订阅写入 Config.SubscriptionStore，未配置时不做任何事
+// 快照与写入在 persistMu 内完成，保证并发修改时最后写入的是最新状态
+func (s *SDK) persistSubscriptions(manager *Manager) error {
+	store := s.config.SubscriptionStore
+	if store == nil || manager == nil {
+		return nil
+	}
+
+	s.persistMu.Lock()
+	defer s.persistMu.Unlock()
+
+	if err := store.Save(context.Background(), manager.savedSubscriptions()); err != nil {
+		return fmt.Errorf("failed to persist subscriptions: %w", err)
+	}
+	return nil
+}
+
+// RestoreSubscriptions 从 Config.SubscriptionStore 恢复上次保存的订阅（包括事件过滤），
+// 使重启后的行情服务无需上游重新下发配置即可恢复完全相同的订阅；需在 Start 之后调用
+// 未配置存储时返回错误，存储为空时不做任何事
+func (s *SDK) RestoreSubscriptions(ctx context.Context) error {
+	store := s.config.SubscriptionStore
+	if store == nil {
+		return errors.New("subscription store not configured")
+	}
+
+	subscriptions, err := store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load subscriptions: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		return ErrNotStarted
+	}
+
+	tokens := make([]string, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		if sub.TokenID == "" {
+			continue
+		}
+		// 先设置过滤，避免订阅后的首个快照被处理
+		if len(sub.EventTypes) > 0 {
+			s.manager.SetEventFilter(sub.TokenID, sub.EventTypes...)
+		}
+		tokens = append(tokens, sub.TokenID)
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	if err := s.manager.Subscribe(tokens); err != nil {
+		return err
+	}
+	return s.persistSubscriptions(s.manager)
+}
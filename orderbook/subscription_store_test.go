@@ -0,0 +1,124 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newStoreTestSDK(t *testing.T, store SubscriptionStore) *SDK {
+	t.Helper()
+
+	server := newTestWSServer(t)
+	config := DefaultConfig()
+	config.WSEndpoint = "ws" + strings.TrimPrefix(server.URL, "http")
+	config.SubscriptionStore = store
+
+	sdk := NewSDK(config)
+	if err := sdk.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	t.Cleanup(sdk.Close)
+	return sdk
+}
+
+func TestFileSubscriptionStore(t *testing.T) {
+	store := NewFileSubscriptionStore(filepath.Join(t.TempDir(), "subscriptions.json"))
+
+	loaded, err := store.Load(context.Background())
+	if err != nil || loaded != nil {
+		t.Fatalf("missing file should load as empty, got %v, %v", loaded, err)
+	}
+
+	want := []SavedSubscription{
+		{TokenID: "a"},
+		{TokenID: "b", EventTypes: []EventType{EventTypeLastTradePrice}},
+	}
+	if err := store.Save(context.Background(), want); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err = store.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, want) {
+		t.Errorf("Load() = %+v, want %+v", loaded, want)
+	}
+}
+
+func TestSDK_PersistsSubscriptions(t *testing.T) {
+	store := NewFileSubscriptionStore(filepath.Join(t.TempDir(), "subscriptions.json"))
+	sdk := newStoreTestSDK(t, store)
+
+	if err := sdk.Subscribe([]string{"b", "a", "c"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	if err := sdk.SubscribeWithEvents([]string{"d"}, EventTypeLastTradePrice); err != nil {
+		t.Fatalf("SubscribeWithEvents() error: %v", err)
+	}
+	if err := sdk.Unsubscribe([]string{"c"}); err != nil {
+		t.Fatalf("Unsubscribe() error: %v", err)
+	}
+
+	saved, err := store.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	want := []SavedSubscription{
+		{TokenID: "a"},
+		{TokenID: "b"},
+		{TokenID: "d", EventTypes: []EventType{EventTypeLastTradePrice}},
+	}
+	if !reflect.DeepEqual(saved, want) {
+		t.Errorf("saved subscriptions = %+v, want %+v", saved, want)
+	}
+}
+
+func TestSDK_RestoreSubscriptions(t *testing.T) {
+	store := NewFileSubscriptionStore(filepath.Join(t.TempDir(), "subscriptions.json"))
+	first := newStoreTestSDK(t, store)
+	if err := first.Subscribe([]string{"a"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	if err := first.SubscribeWithEvents([]string{"b"}, EventTypeTickSizeChange); err != nil {
+		t.Fatalf("SubscribeWithEvents() error: %v", err)
+	}
+	first.Close()
+
+	// A fresh SDK sharing the store resumes the same subscriptions and filters.
+	restarted := newStoreTestSDK(t, store)
+	if err := restarted.RestoreSubscriptions(context.Background()); err != nil {
+		t.Fatalf("RestoreSubscriptions() error: %v", err)
+	}
+
+	tokens := restarted.GetSubscribedTokens()
+	if len(tokens) != 2 {
+		t.Fatalf("expected 2 restored tokens, got %v", tokens)
+	}
+	if filter := restarted.GetEventFilter("a"); !reflect.DeepEqual(filter, DefaultEventTypes) {
+		t.Errorf("token a filter = %v, want default", filter)
+	}
+	if filter := restarted.GetEventFilter("b"); len(filter) != 1 || filter[0] != EventTypeTickSizeChange {
+		t.Errorf("token b filter = %v, want [tick_size_change]", filter)
+	}
+}
+
+func TestSDK_RestoreSubscriptionsRequiresStore(t *testing.T) {
+	sdk := NewSDK(nil)
+	if err := sdk.RestoreSubscriptions(context.Background()); err == nil {
+		t.Error("expected error without a subscription store")
+	}
+
+	store := NewFileSubscriptionStore(filepath.Join(t.TempDir(), "subscriptions.json"))
+	if err := store.Save(context.Background(), []SavedSubscription{{TokenID: "a"}}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	config := DefaultConfig()
+	config.SubscriptionStore = store
+	if err := NewSDK(config).RestoreSubscriptions(context.Background()); !errors.Is(err, ErrNotStarted) {
+		t.Errorf("expected ErrNotStarted before Start, got %v", err)
+	}
+}
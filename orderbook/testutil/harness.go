@@ -0,0 +1,468 @@
+// Package testutil 订单簿引擎一致性测试工具
+// 随机生成快照与增量消息并施加到 orderbook.OrderBook，同时维护一个参考模型，
+// 每一步校验不变量，供修改订单簿引擎的下游 fork 验证改动
+package testutil
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// Config 随机测试配置
+type Config struct {
+	Seed         int64   // 随机种子，相同种子生成相同的消息序列
+	Steps        int     // 施加的消息数
+	MaxLevels    int     // 快照每侧最大档位数
+	PriceTicks   int     // 价格网格数（价格为 1/PriceTicks 的整数倍，位于 (0, 1) 内）
+	SnapshotRate float64 // 生成快照的概率，其余为增量
+	RemoveRate   float64 // 增量为删除档位（size=0）的概率
+	StaleRate    float64 // 生成过期时间戳消息的概率
+	InvalidRate  float64 // 生成非法消息（负数量/非法价格）的概率
+}
+
+// DefaultConfig 默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Seed:         1,
+		Steps:        2000,
+		MaxLevels:    20,
+		PriceTicks:   100,
+		SnapshotRate: 0.05,
+		RemoveRate:   0.3,
+		StaleRate:    0.05,
+		InvalidRate:  0.02,
+	}
+}
+
+// InvariantError 不变量违反
+type InvariantError struct {
+	Step   int    // 出错的步骤（从 0 开始），-1 表示独立校验
+	Op     string // 触发的操作描述
+	Reason string
+}
+
+// Error 实现 error 接口
+func (e *InvariantError) Error() string {
+	if e.Step < 0 {
+		return "invariant violated: " + e.Reason
+	}
+	return fmt.Sprintf("invariant violated at step %d (%s): %s", e.Step, e.Op, e.Reason)
+}
+
+// Harness 订单簿随机一致性测试器
+type Harness struct {
+	config *Config
+	rng    *rand.Rand
+
+	// 参考模型
+	bids        map[string]decimal.Decimal
+	asks        map[string]decimal.Decimal
+	hash        string
+	timestamp   int64
+	initialized bool
+
+	nextTS   int64
+	hashSeq  int
+	tokenID  string
+	marketID string
+}
+
+// NewHarness 创建测试器
+func NewHarness(config *Config) *Harness {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if config.PriceTicks < 2 {
+		config.PriceTicks = 100
+	}
+	if config.MaxLevels <= 0 {
+		config.MaxLevels = 20
+	}
+
+	return &Harness{
+		config:   config,
+		rng:      rand.New(rand.NewSource(config.Seed)),
+		bids:     make(map[string]decimal.Decimal),
+		asks:     make(map[string]decimal.Decimal),
+		nextTS:   1_000_000,
+		tokenID:  "fuzz-token",
+		marketID: "fuzz-market",
+	}
+}
+
+// TokenID 测试使用的 token ID
+func (h *Harness) TokenID() string {
+	return h.tokenID
+}
+
+// Run 在订单簿上施加 Steps 条随机消息，返回首个不变量违反
+// ob 应为新建或已 Reset 的订单簿
+func (h *Harness) Run(ob *orderbook.OrderBook) error {
+	for step := 0; step < h.config.Steps; step++ {
+		if err := h.Step(ob, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Step 施加一条随机消息并校验
+func (h *Harness) Step(ob *orderbook.OrderBook, step int) error {
+	prevTS, prevHash := ob.Timestamp(), ob.Hash()
+
+	var op string
+	var applied, expected bool
+
+	if !h.initialized || h.rng.Float64() < h.config.SnapshotRate {
+		msg, ts := h.randomSnapshot()
+		op = fmt.Sprintf("snapshot ts=%d bids=%d asks=%d", ts, len(msg.Bids), len(msg.Asks))
+		expected = h.applySnapshot(msg, ts)
+		applied = ob.ApplyBookSnapshot(msg, ts)
+	} else {
+		change, ts := h.randomChange()
+		op = fmt.Sprintf("change ts=%d %s %s@%s", ts, change.Side, change.Size, change.Price)
+		expected = h.applyChange(change, ts)
+		applied = ob.ApplyPriceChange(change, ts)
+	}
+
+	fail := func(format string, args ...interface{}) error {
+		return &InvariantError{Step: step, Op: op, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	if applied != expected {
+		return fail("apply returned %v, expected %v", applied, expected)
+	}
+
+	// 时间戳单调；被拒绝的消息不得修改状态
+	if ob.Timestamp() < prevTS {
+		return fail("timestamp went backwards: %d -> %d", prevTS, ob.Timestamp())
+	}
+	if !applied && (ob.Timestamp() != prevTS || ob.Hash() != prevHash) {
+		return fail("rejected message mutated timestamp/hash")
+	}
+	if ob.Timestamp() != h.timestamp {
+		return fail("timestamp %d, expected %d", ob.Timestamp(), h.timestamp)
+	}
+	if ob.Hash() != h.hash {
+		return fail("hash %q, expected %q", ob.Hash(), h.hash)
+	}
+
+	if err := CheckInvariants(ob); err != nil {
+		return fail("%s", err.(*InvariantError).Reason)
+	}
+
+	// 与参考模型逐档比较
+	if err := compareLevels("bid", ob.GetAllBids(), h.bids); err != "" {
+		return fail("%s", err)
+	}
+	if err := compareLevels("ask", ob.GetAllAsks(), h.asks); err != "" {
+		return fail("%s", err)
+	}
+
+	return nil
+}
+
+// CheckInvariants 校验订单簿自身的不变量（不依赖参考模型）：
+// 数量为正、买单严格降序、卖单严格升序、BBO/中间价/价差/深度/总量与排序结果一致
+func CheckInvariants(ob *orderbook.OrderBook) error {
+	fail := func(format string, args ...interface{}) error {
+		return &InvariantError{Step: -1, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	if !ob.IsInitialized() {
+		if ob.GetBBO() != nil {
+			return fail("uninitialized book returned BBO")
+		}
+		return nil
+	}
+
+	bids, asks := ob.GetAllBids(), ob.GetAllAsks()
+
+	for i, l := range bids {
+		if !l.Size.IsPositive() {
+			return fail("bid level %s has non-positive size %s", l.Price, l.Size)
+		}
+		if i > 0 && !bids[i-1].Price.GreaterThan(l.Price) {
+			return fail("bids not strictly descending at %d: %s then %s", i, bids[i-1].Price, l.Price)
+		}
+	}
+	for i, l := range asks {
+		if !l.Size.IsPositive() {
+			return fail("ask level %s has non-positive size %s", l.Price, l.Size)
+		}
+		if i > 0 && !asks[i-1].Price.LessThan(l.Price) {
+			return fail("asks not strictly ascending at %d: %s then %s", i, asks[i-1].Price, l.Price)
+		}
+	}
+
+	bbo := ob.GetBBO()
+	if bbo == nil {
+		return fail("initialized book returned nil BBO")
+	}
+	if err := checkBest("bid", bbo.BestBid, bids); err != "" {
+		return fail("%s", err)
+	}
+	if err := checkBest("ask", bbo.BestAsk, asks); err != "" {
+		return fail("%s", err)
+	}
+
+	mid, spread := ob.GetMidPrice(), ob.GetSpread()
+	if len(bids) > 0 && len(asks) > 0 {
+		if mid == nil || spread == nil {
+			return fail("two-sided book returned nil mid/spread")
+		}
+		if !mid.Equal(bids[0].Price.Add(asks[0].Price).Div(decimal.NewFromInt(2))) {
+			return fail("mid %s inconsistent with BBO", mid)
+		}
+		if !spread.Equal(asks[0].Price.Sub(bids[0].Price)) {
+			return fail("spread %s inconsistent with BBO", spread)
+		}
+	} else if mid != nil || spread != nil {
+		return fail("one-sided book returned mid/spread")
+	}
+
+	depthBids, depthAsks := ob.GetDepth(5)
+	if err := checkPrefix("bid", depthBids, bids, 5); err != "" {
+		return fail("%s", err)
+	}
+	if err := checkPrefix("ask", depthAsks, asks, 5); err != "" {
+		return fail("%s", err)
+	}
+
+	if !ob.GetTotalBidSize().Equal(sumSizes(bids)) {
+		return fail("total bid size %s != sum of levels %s", ob.GetTotalBidSize(), sumSizes(bids))
+	}
+	if !ob.GetTotalAskSize().Equal(sumSizes(asks)) {
+		return fail("total ask size %s != sum of levels %s", ob.GetTotalAskSize(), sumSizes(asks))
+	}
+
+	return nil
+}
+
+// randomSnapshot 生成随机快照（偶尔带过期时间戳）
+func (h *Harness) randomSnapshot() (*orderbook.BookMessage, int64) {
+	msg := &orderbook.BookMessage{
+		EventType: orderbook.EventTypeBook,
+		AssetID:   h.tokenID,
+		Market:    h.marketID,
+		Hash:      h.newHash(),
+	}
+
+	// 买单在价格网格下半部分，卖单在上半部分，允许少量交叉
+	mid := h.config.PriceTicks / 2
+	for _, tick := range h.randomTicks(1, mid+1) {
+		msg.Bids = append(msg.Bids, orderbook.RawOrderSummary{Price: h.priceString(tick), Size: h.sizeString()})
+	}
+	for _, tick := range h.randomTicks(mid-1, h.config.PriceTicks) {
+		msg.Asks = append(msg.Asks, orderbook.RawOrderSummary{Price: h.priceString(tick), Size: h.sizeString()})
+	}
+
+	ts := h.nextTimestamp()
+	msg.Timestamp = strconv.FormatInt(ts, 10)
+	return msg, ts
+}
+
+// randomChange 生成随机增量
+func (h *Harness) randomChange() (*orderbook.PriceChange, int64) {
+	change := &orderbook.PriceChange{
+		AssetID: h.tokenID,
+		Price:   h.priceString(1 + h.rng.Intn(h.config.PriceTicks-1)),
+		Size:    h.sizeString(),
+		Side:    orderbook.SideBuy,
+		Hash:    h.newHash(),
+	}
+	if h.rng.Intn(2) == 0 {
+		change.Side = orderbook.SideSell
+	}
+
+	// 删除时优先选择已存在的档位
+	if h.rng.Float64() < h.config.RemoveRate {
+		levels := h.bids
+		if change.Side == orderbook.SideSell {
+			levels = h.asks
+		}
+		for price := range levels {
+			change.Price = price
+			break
+		}
+		change.Size = "0"
+	}
+
+	if h.rng.Float64() < h.config.InvalidRate {
+		if h.rng.Intn(2) == 0 {
+			change.Size = "-" + h.sizeString()
+		} else {
+			change.Price = "not-a-price"
+		}
+	}
+
+	return change, h.nextTimestamp()
+}
+
+// applySnapshot 在参考模型上应用快照，返回是否应被接受
+func (h *Harness) applySnapshot(msg *orderbook.BookMessage, ts int64) bool {
+	if ts < h.timestamp {
+		return false
+	}
+
+	h.bids = levelsFromRaw(msg.Bids)
+	h.asks = levelsFromRaw(msg.Asks)
+	h.hash = msg.Hash
+	h.timestamp = ts
+	h.initialized = true
+	return true
+}
+
+// applyChange 在参考模型上应用增量，返回是否应被接受
+func (h *Harness) applyChange(change *orderbook.PriceChange, ts int64) bool {
+	if !h.initialized || ts < h.timestamp {
+		return false
+	}
+
+	size, err := decimal.NewFromString(change.Size)
+	if err != nil || size.IsNegative() {
+		return false
+	}
+	price, err := decimal.NewFromString(change.Price)
+	if err != nil {
+		return false
+	}
+
+	levels := h.bids
+	if change.Side == orderbook.SideSell {
+		levels = h.asks
+	}
+	if size.IsZero() {
+		delete(levels, price.String())
+	} else {
+		levels[price.String()] = size
+	}
+
+	h.hash = change.Hash
+	h.timestamp = ts
+	return true
+}
+
+// nextTimestamp 生成下一个时间戳；按 StaleRate 返回早于当前状态的时间戳
+func (h *Harness) nextTimestamp() int64 {
+	if h.initialized && h.rng.Float64() < h.config.StaleRate {
+		return h.timestamp - 1 - int64(h.rng.Intn(1000))
+	}
+	// 允许与上一条相同的时间戳
+	h.nextTS += int64(h.rng.Intn(3))
+	return h.nextTS
+}
+
+// randomTicks 在 [from, to) 内随机选取不重复的价格刻度
+func (h *Harness) randomTicks(from, to int) []int {
+	if to <= from {
+		return nil
+	}
+	n := h.rng.Intn(h.config.MaxLevels + 1)
+	if n > to-from {
+		n = to - from
+	}
+	perm := h.rng.Perm(to - from)[:n]
+	for i := range perm {
+		perm[i] += from
+	}
+	return perm
+}
+
+// priceString 价格刻度转为规范化字符串，保证同一价格只有一种写法
+func (h *Harness) priceString(tick int) string {
+	return decimal.NewFromInt(int64(tick)).Div(decimal.NewFromInt(int64(h.config.PriceTicks))).String()
+}
+
+// sizeString 生成随机正数量
+func (h *Harness) sizeString() string {
+	return decimal.New(int64(1+h.rng.Intn(100000)), -2).String()
+}
+
+// newHash 生成唯一 hash
+func (h *Harness) newHash() string {
+	h.hashSeq++
+	return fmt.Sprintf("hash-%d", h.hashSeq)
+}
+
+// levelsFromRaw 与引擎相同的快照解析规则：跳过非法价格/数量及非正数量，价格按规范化写法作为键
+func levelsFromRaw(raw []orderbook.RawOrderSummary) map[string]decimal.Decimal {
+	levels := make(map[string]decimal.Decimal, len(raw))
+	for _, l := range raw {
+		price, err := decimal.NewFromString(l.Price)
+		if err != nil {
+			continue
+		}
+		size, err := decimal.NewFromString(l.Size)
+		if err != nil || !size.IsPositive() {
+			continue
+		}
+		levels[price.String()] = size
+	}
+	return levels
+}
+
+// compareLevels 比较引擎档位与参考模型
+func compareLevels(side string, got []orderbook.OrderSummary, want map[string]decimal.Decimal) string {
+	if len(got) != len(want) {
+		return fmt.Sprintf("%s levels: got %d, expected %d", side, len(got), len(want))
+	}
+	for _, l := range got {
+		size, ok := want[l.Price.String()]
+		if !ok {
+			return fmt.Sprintf("unexpected %s level %s", side, l.Price)
+		}
+		if !size.Equal(l.Size) {
+			return fmt.Sprintf("%s level %s size %s, expected %s", side, l.Price, l.Size, size)
+		}
+	}
+	return ""
+}
+
+// checkBest 校验最优价与排序结果首档一致
+func checkBest(side string, best *orderbook.BestPrice, levels []orderbook.OrderSummary) string {
+	if len(levels) == 0 {
+		if best != nil {
+			return fmt.Sprintf("empty %s side returned best price %s", side, best.Price)
+		}
+		return ""
+	}
+	if best == nil {
+		return fmt.Sprintf("non-empty %s side returned nil best price", side)
+	}
+	if !best.Price.Equal(levels[0].Price) || !best.Size.Equal(levels[0].Size) {
+		return fmt.Sprintf("best %s %s@%s != top level %s@%s", side, best.Size, best.Price, levels[0].Size, levels[0].Price)
+	}
+	return ""
+}
+
+// checkPrefix 校验深度结果是完整档位的前缀
+func checkPrefix(side string, depth, all []orderbook.OrderSummary, n int) string {
+	expected := n
+	if len(all) < n {
+		expected = len(all)
+	}
+	if len(depth) != expected {
+		return fmt.Sprintf("%s depth(%d) returned %d levels, expected %d", side, n, len(depth), expected)
+	}
+	for i := range depth {
+		if !depth[i].Price.Equal(all[i].Price) || !depth[i].Size.Equal(all[i].Size) {
+			return fmt.Sprintf("%s depth level %d differs from sorted levels", side, i)
+		}
+	}
+	return ""
+}
+
+// sumSizes 计算总量
+func sumSizes(levels []orderbook.OrderSummary) decimal.Decimal {
+	total := decimal.Zero
+	for _, l := range levels {
+		total = total.Add(l.Size)
+	}
+	return total
+}
@@ -0,0 +1,96 @@
+package testutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+func TestHarness_DefaultEngine(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		config := DefaultConfig()
+		config.Seed = seed
+		h := NewHarness(config)
+
+		if err := h.Run(orderbook.NewOrderBook(h.TokenID())); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+	}
+}
+
+func TestHarness_Deterministic(t *testing.T) {
+	config := DefaultConfig()
+	config.Steps = 200
+
+	a, b := orderbook.NewOrderBook("a"), orderbook.NewOrderBook("b")
+	if err := NewHarness(config).Run(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewHarness(config).Run(b); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Hash() != b.Hash() || a.Timestamp() != b.Timestamp() {
+		t.Errorf("same seed produced different books: %s/%d vs %s/%d", a.Hash(), a.Timestamp(), b.Hash(), b.Timestamp())
+	}
+}
+
+func TestHarness_DetectsDivergence(t *testing.T) {
+	h := NewHarness(DefaultConfig())
+	ob := orderbook.NewOrderBook(h.TokenID())
+	if err := h.Step(ob, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Mutate the book behind the harness's back
+	ob.ApplyPriceChange(&orderbook.PriceChange{Price: "0.01", Size: "12345", Side: orderbook.SideBuy, Hash: "x"}, ob.Timestamp())
+
+	var invErr *InvariantError
+	if err := h.Step(ob, 1); !errors.As(err, &invErr) {
+		t.Fatalf("expected InvariantError, got %v", err)
+	}
+}
+
+func TestCheckInvariants(t *testing.T) {
+	ob := orderbook.NewOrderBook("token")
+	if err := CheckInvariants(ob); err != nil {
+		t.Errorf("uninitialized book: %v", err)
+	}
+
+	ob.ApplyBookSnapshot(&orderbook.BookMessage{
+		Bids: []orderbook.RawOrderSummary{{Price: "0.4", Size: "10"}, {Price: "0.45", Size: "5"}},
+		Asks: []orderbook.RawOrderSummary{{Price: "0.5", Size: "7"}},
+	}, 1)
+	if err := CheckInvariants(ob); err != nil {
+		t.Errorf("valid book: %v", err)
+	}
+
+	if ob.ApplyPriceChange(&orderbook.PriceChange{Price: "0.3", Size: "-1", Side: orderbook.SideBuy}, 2) {
+		t.Error("negative size should be rejected")
+	}
+	if ob.ApplyPriceChange(&orderbook.PriceChange{Price: "bad", Size: "1", Side: orderbook.SideSell}, 2) {
+		t.Error("invalid price should be rejected")
+	}
+	if got := ob.GetTotalBidSize(); !got.Equal(decimal.NewFromInt(15)) {
+		t.Errorf("total bid size = %s, expected 15", got)
+	}
+}
+
+func FuzzOrderBook(f *testing.F) {
+	f.Add(int64(1))
+	f.Add(int64(42))
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		config := DefaultConfig()
+		config.Seed = seed
+		config.Steps = 300
+		h := NewHarness(config)
+
+		if err := h.Run(orderbook.NewOrderBook(h.TokenID())); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
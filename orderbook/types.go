@@ -1,7 +1,11 @@
 package orderbook
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 // ConnectionState WebSocket连接状态
@@ -46,14 +50,37 @@ const (
 	EventTypePriceChange    EventType = "price_change"
 	EventTypeTickSizeChange EventType = "tick_size_change"
 	EventTypeLastTradePrice EventType = "last_trade_price"
+
+	// EventTypeSnapshotBatchComplete 一次 Subscribe 新增的 token 全部收到初始快照（本地合成事件，不受事件过滤影响）
+	EventTypeSnapshotBatchComplete EventType = "snapshot_batch_complete"
+
+	// 订单簿生命周期事件（本地合成事件，不受事件过滤影响），下游缓存可据此区分数据暂时失效与 token 被移除
+	// EventTypeInitialized 订单簿收到订阅后或重置后的首个快照，数据重新可用
+	EventTypeInitialized EventType = "initialized"
+	// EventTypeReset 订单簿被清空（原因见 OrderBookUpdate.ResetReason），token 仍在订阅中，收到新快照后推送 EventTypeInitialized
+	EventTypeReset EventType = "reset"
+	// EventTypeRemoved token 已取消订阅，订单簿被删除，之后不再推送该 token 的事件
+	EventTypeRemoved EventType = "removed"
+)
+
+// ResetReason 订单簿被清空的原因
+type ResetReason string
+
+const (
+	ResetReasonDisconnect ResetReason = "disconnect" // 所在连接断开，重连后重新获取快照
+	ResetReasonResync     ResetReason = "resync"     // 检测到丢消息或手动 Resync/Resubscribe，重新订阅获取快照
+	ResetReasonFilter     ResetReason = "filter"     // 事件过滤不再包含 book/price_change，停止维护订单簿
 )
 
-// Side 买卖方向
-type Side string
+// DefaultEventTypes 未设置事件过滤时推送的事件类型
+var DefaultEventTypes = []EventType{EventTypeBook, EventTypePriceChange}
+
+// Side 买卖方向，与 clob.OrderSide 同为 common.Side
+type Side = common.Side
 
 const (
-	SideBuy  Side = "BUY"
-	SideSell Side = "SELL"
+	SideBuy  = common.SideBuy
+	SideSell = common.SideSell
 )
 
 // OrderSummary 订单摘要（价格档位）
@@ -139,29 +166,149 @@ type DynamicSubscribeRequest struct {
 
 // OrderBookUpdate 订单簿更新事件（通过channel通知）
 type OrderBookUpdate struct {
-	TokenID   string
-	EventType EventType
-	Timestamp int64
+	TokenID    string
+	EventType  EventType
+	Timestamp  int64          // 交易所消息时间戳（毫秒），见 Time
+	ReceivedAt time.Time      // 本地收到该 WebSocket 帧的时间，带单调时钟读数，可跨连接排序本地事件
+	Metadata   *TokenMetadata // 通过 SetTokenMetadata 注册后才会填充，否则为 nil
+
+	// LastTrade 最后成交价（仅 EventTypeLastTradePrice 事件填充）
+	LastTrade *LastTradePriceMessage
+	// TickSizeChange tick size 变更（仅 EventTypeTickSizeChange 事件填充）
+	TickSizeChange *TickSizeChangeMessage
+	// SnapshotBatch 本批订阅的全部 token（仅 EventTypeSnapshotBatchComplete 事件填充，TokenID 为批次最后一个 token）
+	SnapshotBatch []string
+	// ResetReason 订单簿被清空的原因（仅 EventTypeReset 事件填充）
+	ResetReason ResetReason
+}
+
+// Time 交易所消息时间戳，时间戳缺失时为零值
+func (u OrderBookUpdate) Time() time.Time {
+	return TimeFromMillis(u.Timestamp)
+}
+
+// NetworkDelay 交易所时间戳到本地接收的时间差
+// 两端时钟不同源，结果包含本地与交易所的时钟偏差，适合观察趋势而非绝对值；任一时间缺失时返回 0
+func (u OrderBookUpdate) NetworkDelay() time.Duration {
+	if u.Timestamp == 0 || u.ReceivedAt.IsZero() {
+		return 0
+	}
+	return u.ReceivedAt.Sub(u.Time())
+}
+
+// GapStats 单个 token 的消息时间戳间隔统计（用于发现丢消息）
+type GapStats struct {
+	Updates       int64 // 已应用的快照与增量数
+	OutOfOrder    int64 // 时间戳早于当前订单簿而被丢弃的消息数
+	Gaps          int64 // 间隔超过 MaxTimestampGapMs 的次数
+	Resyncs       int64 // 因间隔过大触发的重新订阅次数
+	Duplicates    int64 // 相同 (hash, timestamp) 重复到达而被丢弃的消息数（如同一 token 被多个连接订阅）
+	MaxGapMs      int64 // 相邻增量的最大时间戳间隔
+	LastGapMs     int64 // 最近一次增量的时间戳间隔
+	LastTimestamp int64 // 最近一次应用的时间戳
+}
+
+// TokenMetadata token 所属市场的可读信息（用于日志、告警）
+type TokenMetadata struct {
+	MarketSlug string
+	Outcome    string // 结果标签，如 "Yes" / "No"
+	NegRisk    bool
 }
 
 // BestPrice 最优价格（包含价格和数量）
 type BestPrice struct {
+	Side      Side // SideBuy 为最优买价（bid），SideSell 为最优卖价（ask）
 	Price     decimal.Decimal
 	Size      decimal.Decimal
 	Timestamp int64
 }
 
+// Time 价格所在订单簿的交易所时间戳
+func (p *BestPrice) Time() time.Time {
+	return TimeFromMillis(p.Timestamp)
+}
+
+// TimeFromMillis 将交易所毫秒时间戳转为 time.Time，0 返回零值
+func TimeFromMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
 // BBO 最优买卖价（Best Bid and Offer）
 type BBO struct {
 	BestBid *BestPrice
 	BestAsk *BestPrice
 }
 
+// OwnOrder 自己挂在订单簿上的订单（用于计算剔除自身挂单后的外部订单簿视图）
+type OwnOrder struct {
+	Side  Side            // 买卖方向
+	Price decimal.Decimal // 挂单价格
+	Size  decimal.Decimal // 剩余挂单数量
+}
+
 // ScanResult 扫描结果
 type ScanResult struct {
-	Orders    []OrderSummary  // 符合条件的订单列表
-	TotalSize decimal.Decimal // 总数量
-	AvgPrice  decimal.Decimal // 加权平均价格
+	Side       Side            // 被扫描的一侧：SideSell 为卖单（ScanAsksBelow），SideBuy 为买单（ScanBidsAbove）
+	Orders     []OrderSummary  // 符合条件的订单列表，按成交优先顺序（最优价在前）
+	TotalSize  decimal.Decimal // 总数量
+	AvgPrice   decimal.Decimal // 加权平均价格
+	Notional   decimal.Decimal // 累计金额（USDC），即全部档位 价格 * 数量 之和
+	Levels     int             // 符合条件的价位数
+	WorstPrice decimal.Decimal // 吃完全部档位的边际价格（最后一档价格），无订单时为 0
+}
+
+// MarginalPrice 吃入 size 数量需要触及的最差价位（即限价单需要挂出的价格）
+// 扫描范围内数量不足或 size 非正时返回 false
+func (r *ScanResult) MarginalPrice(size decimal.Decimal) (decimal.Decimal, bool) {
+	if !size.IsPositive() {
+		return decimal.Zero, false
+	}
+
+	cumulative := decimal.Zero
+	for _, order := range r.Orders {
+		cumulative = cumulative.Add(order.Size)
+		if cumulative.GreaterThanOrEqual(size) {
+			return order.Price, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// Fill 在扫描到的档位上吃入 size 数量，返回逐档成交、金额与平均价，数量不足时 IsFullFill 为 false
+func (r *ScanResult) Fill(size decimal.Decimal) *FillResult {
+	result := &FillResult{
+		Orders:     make([]OrderSummary, 0),
+		FilledSize: decimal.Zero,
+		TotalCost:  decimal.Zero,
+		AvgPrice:   decimal.Zero,
+		RemainSize: size,
+	}
+
+	remaining := size
+	for _, order := range r.Orders {
+		if !remaining.IsPositive() {
+			break
+		}
+
+		fillSize := order.Size
+		if fillSize.GreaterThan(remaining) {
+			fillSize = remaining
+		}
+		result.Orders = append(result.Orders, OrderSummary{Price: order.Price, Size: fillSize})
+		result.FilledSize = result.FilledSize.Add(fillSize)
+		result.TotalCost = result.TotalCost.Add(order.Price.Mul(fillSize))
+		remaining = remaining.Sub(fillSize)
+	}
+
+	result.RemainSize = remaining
+	result.IsFullFill = !remaining.IsPositive()
+	if result.FilledSize.IsPositive() {
+		result.AvgPrice = result.TotalCost.Div(result.FilledSize)
+	}
+	return result
 }
 
 // Config SDK配置
@@ -181,6 +328,28 @@ type Config struct {
 	MessageBufferSize int
 	// 更新通知channel缓冲区大小
 	UpdateChannelSize int
+	// 出口地址池（可选），每次连接/重连轮询绑定本地地址
+	LocalAddrs *common.LocalAddrPool
+	// 代理列表（可选），socks5:// 或 http:// 地址，可带 user:pass@ 认证（见 ParseProxyURL）
+	// 每个连接按创建顺序轮流分配一个代理，同时配置 LocalAddrs 时经本地地址连接代理
+	ProxyURLs []string
+	// 相邻两次增量的时间戳间隔超过该值（毫秒）时视为可能丢消息并重新订阅，0 表示只统计不重订阅
+	// 冷门市场本身可能长时间无更新，需按市场活跃度设置
+	MaxTimestampGapMs int
+	// 订阅状态存储（可选），配置后每次订阅变更都会写入，重启后可通过 RestoreSubscriptions 恢复
+	SubscriptionStore SubscriptionStore
+	// 只在最优买卖价或其数量变化时推送 book / price_change 更新，深层价位的变化不推送
+	// 适合只关心 BBO 的行情类消费者；其他事件（初始化、重置、成交、tick size 等）不受影响
+	BBOOnly bool
+	// 原始帧录制窗口（秒），> 0 时按连接在内存中保留最近的 WebSocket 帧，可通过 FlushRecent 导出，0 表示不录制
+	RecordWindow int
+	// 每个连接录制的帧内容字节数上限，0 表示只按 RecordWindow 淘汰
+	RecordMaxBytes int
+	// token 到连接的放置策略（可选），nil 使用 PackPlacement；如 NewGroupPlacement 按 event 分组放置
+	Placement PlacementStrategy
+	// 行情延迟统计（可选），非 nil 时按 token 记录每条已应用消息的交易所时间戳到本地接收的延迟，
+	// 通过 FeedLatency().Stats() 查看百分位与直方图，延迟超过阈值时从 Warnings() 收到告警
+	FeedLatency *FeedLatencyConfig
 }
 
 // DefaultConfig 默认配置
@@ -0,0 +1,88 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TokenStatus 订阅前校验得到的 token 状态
+type TokenStatus string
+
+const (
+	TokenStatusActive  TokenStatus = "active"  // 市场开放且启用订单簿，可以订阅
+	TokenStatusUnknown TokenStatus = "unknown" // 不存在的 token
+	TokenStatusClosed  TokenStatus = "closed"  // 市场已关闭或未启用订单簿，订阅后不会收到数据
+)
+
+// TokenValidator 订阅前校验 token，返回每个 token 的状态，未出现在结果中的 token 视为 TokenStatusUnknown
+// polymarket.SDK 基于 Gamma 市场信息实现了该接口
+type TokenValidator interface {
+	ValidateTokens(ctx context.Context, tokenIDs []string) (map[string]TokenStatus, error)
+}
+
+// InvalidTokensError 订阅前校验未通过的 token
+type InvalidTokensError struct {
+	Unknown []string
+	Closed  []string
+}
+
+// Error 实现 error 接口
+func (e *InvalidTokensError) Error() string {
+	var parts []string
+	if len(e.Unknown) > 0 {
+		parts = append(parts, fmt.Sprintf("unknown: %s", strings.Join(e.Unknown, ",")))
+	}
+	if len(e.Closed) > 0 {
+		parts = append(parts, fmt.Sprintf("closed: %s", strings.Join(e.Closed, ",")))
+	}
+	return "invalid tokens (" + strings.Join(parts, "; ") + ")"
+}
+
+// SubscribeValidated 先用 validator 校验 token，只订阅状态为 active 的 token
+// 存在未通过校验的 token 时返回 *InvalidTokensError（可用 errors.As 获取），此时其余 token 已正常订阅；
+// 避免订阅不存在或已关闭的 token 后收不到任何数据、订单簿永远停留在未初始化状态
+func (s *SDK) SubscribeValidated(ctx context.Context, tokenIDs []string, validator TokenValidator) error {
+	if len(tokenIDs) == 0 {
+		return errors.New("tokenIDs cannot be empty")
+	}
+	if validator == nil {
+		return errors.New("token validator is required")
+	}
+
+	s.mu.RLock()
+	started := s.started
+	s.mu.RUnlock()
+	if !started {
+		return ErrNotStarted
+	}
+
+	statuses, err := validator.ValidateTokens(ctx, tokenIDs)
+	if err != nil {
+		return fmt.Errorf("failed to validate tokens: %w", err)
+	}
+
+	var valid []string
+	invalid := &InvalidTokensError{}
+	for _, tokenID := range tokenIDs {
+		switch statuses[tokenID] {
+		case TokenStatusActive:
+			valid = append(valid, tokenID)
+		case TokenStatusClosed:
+			invalid.Closed = append(invalid.Closed, tokenID)
+		default:
+			invalid.Unknown = append(invalid.Unknown, tokenID)
+		}
+	}
+
+	if len(valid) > 0 {
+		if err := s.Subscribe(valid); err != nil {
+			return err
+		}
+	}
+	if len(invalid.Unknown) > 0 || len(invalid.Closed) > 0 {
+		return invalid
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type staticValidator map[string]TokenStatus
+
+func (v staticValidator) ValidateTokens(ctx context.Context, tokenIDs []string) (map[string]TokenStatus, error) {
+	return v, nil
+}
+
+func TestSDK_SubscribeValidated(t *testing.T) {
+	server := newTestWSServer(t)
+	config := DefaultConfig()
+	config.WSEndpoint = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	sdk := NewSDK(config)
+	validator := staticValidator{"open": TokenStatusActive, "closed": TokenStatusClosed}
+	if err := sdk.SubscribeValidated(context.Background(), []string{"open"}, validator); !errors.Is(err, ErrNotStarted) {
+		t.Fatalf("expected ErrNotStarted before Start, got %v", err)
+	}
+
+	if err := sdk.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer sdk.Close()
+
+	err := sdk.SubscribeValidated(context.Background(), []string{"open", "closed", "missing"}, validator)
+	var invalid *InvalidTokensError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected InvalidTokensError, got %v", err)
+	}
+	if len(invalid.Unknown) != 1 || invalid.Unknown[0] != "missing" || len(invalid.Closed) != 1 || invalid.Closed[0] != "closed" {
+		t.Errorf("unexpected invalid tokens: %+v", invalid)
+	}
+
+	subscribed := sdk.GetSubscribedTokens()
+	if len(subscribed) != 1 || subscribed[0] != "open" {
+		t.Errorf("only the active token should be subscribed, got %v", subscribed)
+	}
+}
@@ -0,0 +1,368 @@
+package orderbook
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// maxVolatilitySamples 每个 token 窗口内保留的中间价样本与更新时间上限，防止高频行情占用过多内存
+const maxVolatilitySamples = 4096
+
+// VolatilityBreachKind 波动熔断信号类型
+type VolatilityBreachKind string
+
+const (
+	// VolatilityPriceMove 窗口内中间价变动超过阈值
+	VolatilityPriceMove VolatilityBreachKind = "price_move"
+	// VolatilityUpdateRate 窗口内更新频率超过阈值
+	VolatilityUpdateRate VolatilityBreachKind = "update_rate"
+)
+
+// VolatilityConfig 波动熔断配置，阈值为 0 表示不检查对应条件
+type VolatilityConfig struct {
+	Window        time.Duration // 统计窗口
+	MaxMovePct    float64       // 窗口内中间价相对变动上限，如 0.05 表示 5%
+	MaxMoveAbs    float64       // 窗口内中间价绝对变动上限（价格单位），低价 token 相对变动失真时使用
+	MaxUpdateRate float64       // 窗口内每秒更新数上限
+	HoldTime      time.Duration // 条件恢复后 Breached 继续返回 true 的时长，避免行情刚平静就恢复报价
+	ChannelSize   int           // 熔断事件 channel 缓冲区大小，满时丢弃新事件
+}
+
+// DefaultVolatilityConfig 默认配置：10 秒内中间价变动超过 10% 或 5 个价位（0.05）时触发
+func DefaultVolatilityConfig() *VolatilityConfig {
+	return &VolatilityConfig{
+		Window:      10 * time.Second,
+		MaxMovePct:  0.10,
+		MaxMoveAbs:  0.05,
+		HoldTime:    30 * time.Second,
+		ChannelSize: 100,
+	}
+}
+
+// VolatilityBreach 熔断事件，每种条件从未触发变为触发时发送一次，条件恢复后才会再次发送
+type VolatilityBreach struct {
+	TokenID   string
+	Kind      VolatilityBreachKind
+	Value     float64 // 触发时的指标值（相对变动、绝对变动或每秒更新数）
+	Threshold float64
+	From      float64 // 窗口内与当前价差距最大的中间价（价格变动时填充）
+	To        float64 // 当前中间价（价格变动时填充）
+	Window    time.Duration
+	Time      time.Time
+	Metadata  *TokenMetadata
+}
+
+// VolatilityStats 单个 token 当前窗口的统计
+type VolatilityStats struct {
+	TokenID    string
+	Samples    int       // 窗口内中间价样本数
+	Mid        float64   // 最新中间价
+	MoveAbs    float64   // 窗口内中间价最大绝对变动（相对当前价）
+	MovePct    float64   // 窗口内中间价最大相对变动
+	Volatility float64   // 已实现波动率：窗口内相邻样本对数收益率平方和的平方根
+	UpdateRate float64   // 窗口内每秒更新数
+	Breached   bool      // 是否处于熔断状态（含 HoldTime）
+	LastBreach time.Time // 最近一次触发熔断的时间
+}
+
+// volatilitySample 中间价样本
+type volatilitySample struct {
+	at  time.Time
+	mid float64
+}
+
+// volatilityState 单个 token 的窗口状态
+type volatilityState struct {
+	samples    []volatilitySample
+	updates    []time.Time
+	active     map[VolatilityBreachKind]bool
+	lastBreach time.Time
+	clearedAt  time.Time // 全部条件恢复的时间
+}
+
+// VolatilityMonitor 在订单簿更新流上计算每个 token 的已实现波动率与更新频率，
+// 价格急剧变动或更新异常频繁时发出熔断事件，风控模块与策略可据此在新闻冲击期间撤回报价
+type VolatilityMonitor struct {
+	mu     sync.Mutex
+	books  BookReader
+	config *VolatilityConfig
+	states map[string]*volatilityState
+
+	breachChan chan VolatilityBreach
+	now        func() time.Time
+}
+
+// NewVolatilityMonitor 创建波动熔断监控
+func NewVolatilityMonitor(books BookReader, config *VolatilityConfig) *VolatilityMonitor {
+	if config == nil {
+		config = DefaultVolatilityConfig()
+	}
+	if config.Window <= 0 {
+		config.Window = 10 * time.Second
+	}
+
+	return &VolatilityMonitor{
+		books:      books,
+		config:     config,
+		states:     make(map[string]*volatilityState),
+		breachChan: make(chan VolatilityBreach, config.ChannelSize),
+		now:        time.Now,
+	}
+}
+
+// Breaches 获取熔断事件 channel
+func (m *VolatilityMonitor) Breaches() <-chan VolatilityBreach {
+	return m.breachChan
+}
+
+// Run 持续消费更新流采样中间价并检测熔断，直到 ctx 取消或 updates 关闭
+func (m *VolatilityMonitor) Run(ctx context.Context, updates <-chan OrderBookUpdate) {
+	common.Supervise(ctx, "orderbook.volatility", nil, func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				m.Process(update)
+			}
+		}
+	})
+}
+
+// Process 处理一条更新，返回本次触发的熔断事件
+// 订单簿重置或移除时清空该 token 的窗口，重置前的价格不再参与计算
+func (m *VolatilityMonitor) Process(update OrderBookUpdate) []VolatilityBreach {
+	switch update.EventType {
+	case EventTypeReset, EventTypeRemoved:
+		m.mu.Lock()
+		delete(m.states, update.TokenID)
+		m.mu.Unlock()
+		return nil
+	case EventTypeSnapshotBatchComplete:
+		return nil
+	}
+
+	mid, hasMid := m.mid(update.TokenID)
+
+	m.mu.Lock()
+	now := m.now()
+	st := m.states[update.TokenID]
+	if st == nil {
+		st = &volatilityState{active: make(map[VolatilityBreachKind]bool)}
+		m.states[update.TokenID] = st
+	}
+	st.updates = append(st.updates, now)
+	if hasMid {
+		st.samples = append(st.samples, volatilitySample{at: now, mid: mid})
+	}
+	m.evictLocked(st, now)
+
+	var breaches []VolatilityBreach
+	check := func(kind VolatilityBreachKind, breached bool, value, threshold float64, fill func(*VolatilityBreach)) {
+		if !breached {
+			st.active[kind] = false
+			return
+		}
+		if st.active[kind] {
+			return
+		}
+		st.active[kind] = true
+		breach := VolatilityBreach{
+			TokenID:   update.TokenID,
+			Kind:      kind,
+			Value:     value,
+			Threshold: threshold,
+			Window:    m.config.Window,
+			Time:      now,
+			Metadata:  update.Metadata,
+		}
+		if fill != nil {
+			fill(&breach)
+		}
+		breaches = append(breaches, breach)
+	}
+
+	if hasMid {
+		moveAbs, movePct, from := priceMove(st.samples)
+		fill := func(b *VolatilityBreach) { b.From, b.To = from, mid }
+		// 相对与绝对阈值共享同一信号，任一超过即触发，事件中报告超过的那一项
+		switch {
+		case m.config.MaxMovePct > 0 && movePct >= m.config.MaxMovePct:
+			check(VolatilityPriceMove, true, movePct, m.config.MaxMovePct, fill)
+		case m.config.MaxMoveAbs > 0 && moveAbs >= m.config.MaxMoveAbs:
+			check(VolatilityPriceMove, true, moveAbs, m.config.MaxMoveAbs, fill)
+		default:
+			check(VolatilityPriceMove, false, 0, 0, nil)
+		}
+	}
+	if m.config.MaxUpdateRate > 0 {
+		rate := m.updateRate(st)
+		check(VolatilityUpdateRate, rate >= m.config.MaxUpdateRate, rate, m.config.MaxUpdateRate, nil)
+	}
+
+	if len(breaches) > 0 {
+		st.lastBreach = now
+		st.clearedAt = time.Time{}
+	} else if !st.lastBreach.IsZero() && st.clearedAt.IsZero() && !anyActive(st.active) {
+		st.clearedAt = now
+	}
+	m.mu.Unlock()
+
+	for _, breach := range breaches {
+		select {
+		case m.breachChan <- breach:
+		default:
+		}
+	}
+	return breaches
+}
+
+// Breached token 是否处于熔断状态：任一条件仍超过阈值，或恢复后未满 HoldTime
+func (m *VolatilityMonitor) Breached(tokenID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st := m.states[tokenID]
+	return st != nil && m.breachedLocked(st, m.now())
+}
+
+// BreachedTokens 处于熔断状态的 token，已排序
+func (m *VolatilityMonitor) BreachedTokens() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	var tokens []string
+	for tokenID, st := range m.states {
+		if m.breachedLocked(st, now) {
+			tokens = append(tokens, tokenID)
+		}
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// Stats 计算 token 当前窗口的统计，没有收到过该 token 的更新时返回 false
+func (m *VolatilityMonitor) Stats(tokenID string) (VolatilityStats, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	st := m.states[tokenID]
+	if st == nil {
+		return VolatilityStats{}, false
+	}
+	now := m.now()
+	m.evictLocked(st, now)
+
+	stats := VolatilityStats{
+		TokenID:    tokenID,
+		Samples:    len(st.samples),
+		UpdateRate: m.updateRate(st),
+		Volatility: realizedVolatility(st.samples),
+		Breached:   m.breachedLocked(st, now),
+		LastBreach: st.lastBreach,
+	}
+	if n := len(st.samples); n > 0 {
+		stats.Mid = st.samples[n-1].mid
+		stats.MoveAbs, stats.MovePct, _ = priceMove(st.samples)
+	}
+	return stats, true
+}
+
+// Reset 清空 token 的窗口与熔断状态
+func (m *VolatilityMonitor) Reset(tokenID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.states, tokenID)
+}
+
+// breachedLocked 熔断状态判断（调用者需持有锁）
+func (m *VolatilityMonitor) breachedLocked(st *volatilityState, now time.Time) bool {
+	if anyActive(st.active) {
+		return true
+	}
+	return !st.clearedAt.IsZero() && now.Sub(st.clearedAt) < m.config.HoldTime
+}
+
+// evictLocked 移除窗口外的样本（调用者需持有锁）
+func (m *VolatilityMonitor) evictLocked(st *volatilityState, now time.Time) {
+	cutoff := now.Add(-m.config.Window)
+
+	i := sort.Search(len(st.samples), func(i int) bool { return st.samples[i].at.After(cutoff) })
+	i = max(i, len(st.samples)-maxVolatilitySamples)
+	if i > 0 {
+		st.samples = append(st.samples[:0], st.samples[i:]...)
+	}
+
+	j := sort.Search(len(st.updates), func(j int) bool { return st.updates[j].After(cutoff) })
+	j = max(j, len(st.updates)-maxVolatilitySamples)
+	if j > 0 {
+		st.updates = append(st.updates[:0], st.updates[j:]...)
+	}
+}
+
+// updateRate 窗口内每秒更新数
+func (m *VolatilityMonitor) updateRate(st *volatilityState) float64 {
+	return float64(len(st.updates)) / m.config.Window.Seconds()
+}
+
+// mid 读取中间价，单边或无行情时返回 false
+func (m *VolatilityMonitor) mid(tokenID string) (float64, bool) {
+	bbo, err := m.books.GetBBO(tokenID)
+	if err != nil || bbo == nil || bbo.BestBid == nil || bbo.BestAsk == nil {
+		return 0, false
+	}
+	mid, _ := bbo.BestBid.Price.Add(bbo.BestAsk.Price).Float64()
+	return mid / 2, true
+}
+
+// priceMove 最新样本相对窗口内其他样本的最大绝对与相对变动，以及对应的起点价格
+func priceMove(samples []volatilitySample) (moveAbs, movePct, from float64) {
+	n := len(samples)
+	if n == 0 {
+		return 0, 0, 0
+	}
+	last := samples[n-1].mid
+	from = last
+	for _, s := range samples[:n-1] {
+		diff := math.Abs(last - s.mid)
+		if diff > moveAbs {
+			moveAbs, from = diff, s.mid
+		}
+		if s.mid > 0 {
+			movePct = math.Max(movePct, diff/s.mid)
+		}
+	}
+	return moveAbs, movePct, from
+}
+
+// realizedVolatility 相邻样本对数收益率平方和的平方根
+func realizedVolatility(samples []volatilitySample) float64 {
+	var sum float64
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1].mid, samples[i].mid
+		if prev <= 0 || cur <= 0 {
+			continue
+		}
+		r := math.Log(cur / prev)
+		sum += r * r
+	}
+	return math.Sqrt(sum)
+}
+
+// anyActive 是否有条件处于触发状态
+func anyActive(active map[VolatilityBreachKind]bool) bool {
+	for _, v := range active {
+		if v {
+			return true
+		}
+	}
+	return false
+}
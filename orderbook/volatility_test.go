@@ -0,0 +1,128 @@
+package orderbook
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for monitors
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+func newFakeClock() *fakeClock               { return &fakeClock{t: time.Unix(1700000000, 0)} }
+
+func TestVolatilityMonitor_PriceMoveBreach(t *testing.T) {
+	books := &fakeBooks{}
+	clock := newFakeClock()
+	config := DefaultVolatilityConfig()
+	config.MaxMoveAbs = 0
+	config.HoldTime = 5 * time.Second
+	monitor := NewVolatilityMonitor(books, config)
+	monitor.now = clock.now
+	update := OrderBookUpdate{TokenID: "token", EventType: EventTypePriceChange}
+
+	steps := []struct {
+		mid  string
+		fire bool
+	}{
+		{"0.50", false},
+		{"0.52", false},
+		{"0.56", true},  // 12% above 0.50
+		{"0.57", false}, // still breached, fires once
+	}
+	for i, step := range steps {
+		books.setMid(step.mid)
+		clock.advance(time.Second)
+		breaches := monitor.Process(update)
+		if (len(breaches) == 1) != step.fire {
+			t.Fatalf("step %d (mid %s): breaches = %+v", i, step.mid, breaches)
+		}
+		if step.fire {
+			b := breaches[0]
+			if b.Kind != VolatilityPriceMove || math.Abs(b.From-0.50) > 1e-9 || math.Abs(b.To-0.56) > 1e-9 {
+				t.Errorf("unexpected breach %+v", b)
+			}
+		}
+	}
+	if !monitor.Breached("token") || len(monitor.Breaches()) != 1 {
+		t.Fatal("token should be breached with one queued event")
+	}
+
+	// The move leaves the window: the condition clears, the hold keeps the token breached
+	clock.advance(config.Window)
+	monitor.Process(update)
+	if !monitor.Breached("token") {
+		t.Error("token should stay breached during the hold time")
+	}
+	clock.advance(config.HoldTime)
+	if monitor.Breached("token") || len(monitor.BreachedTokens()) != 0 {
+		t.Error("token should no longer be breached after the hold time")
+	}
+
+	stats, ok := monitor.Stats("token")
+	if !ok || stats.Samples != 1 || stats.MovePct != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestVolatilityMonitor_AbsoluteMoveAndRealizedVolatility(t *testing.T) {
+	books := &fakeBooks{}
+	clock := newFakeClock()
+	config := DefaultVolatilityConfig()
+	config.MaxMovePct = 0
+	config.MaxMoveAbs = 0.05
+	monitor := NewVolatilityMonitor(books, config)
+	monitor.now = clock.now
+	update := OrderBookUpdate{TokenID: "token", EventType: EventTypeBook}
+
+	for _, mid := range []string{"0.80", "0.82", "0.78"} {
+		books.setMid(mid)
+		clock.advance(100 * time.Millisecond)
+		if breaches := monitor.Process(update); len(breaches) != 0 {
+			t.Fatalf("unexpected breach at %s: %+v", mid, breaches)
+		}
+	}
+	stats, _ := monitor.Stats("token")
+	want := math.Sqrt(math.Pow(math.Log(0.82/0.80), 2) + math.Pow(math.Log(0.78/0.82), 2))
+	if math.Abs(stats.Volatility-want) > 1e-9 {
+		t.Errorf("Volatility = %v, want %v", stats.Volatility, want)
+	}
+
+	books.setMid("0.86")
+	breaches := monitor.Process(update)
+	if len(breaches) != 1 || math.Abs(breaches[0].Value-0.08) > 1e-9 || breaches[0].Threshold != 0.05 {
+		t.Errorf("unexpected breaches %+v", breaches)
+	}
+}
+
+func TestVolatilityMonitor_UpdateRateAndReset(t *testing.T) {
+	books := &fakeBooks{}
+	books.setMid("0.50")
+	clock := newFakeClock()
+	config := DefaultVolatilityConfig()
+	config.Window = time.Second
+	config.MaxUpdateRate = 5
+	monitor := NewVolatilityMonitor(books, config)
+	monitor.now = clock.now
+	update := OrderBookUpdate{TokenID: "token", EventType: EventTypePriceChange}
+
+	var fired []VolatilityBreach
+	for i := 0; i < 6; i++ {
+		clock.advance(10 * time.Millisecond)
+		fired = append(fired, monitor.Process(update)...)
+	}
+	if len(fired) != 1 || fired[0].Kind != VolatilityUpdateRate || fired[0].Value != 5 {
+		t.Fatalf("unexpected breaches %+v", fired)
+	}
+
+	// A book reset drops the window and the breach state
+	monitor.Process(OrderBookUpdate{TokenID: "token", EventType: EventTypeReset})
+	if monitor.Breached("token") {
+		t.Error("reset should clear the breach state")
+	}
+	if _, ok := monitor.Stats("token"); ok {
+		t.Error("reset should drop the token window")
+	}
+}
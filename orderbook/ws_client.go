@@ -6,13 +6,39 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// localAddrBanDuration 本地地址握手被拒绝后的跳过时长
+const localAddrBanDuration = 5 * time.Minute
+
+// writeLane 出站消息优先级队列，数值越小越先发送
+type writeLane int
+
+const (
+	writeLaneControl      writeLane = iota // ping 等控制帧，保持连接存活
+	writeLaneSubscription                  // 订阅与取消订阅，共用一个队列保证按调用顺序发送
+	writeLaneCount
 )
 
+// controlLaneSize 控制帧队列容量，心跳每个间隔只入队一次，少量即可
+const controlLaneSize = 4
+
+// outboundMessage 待发送的 WebSocket 消息
+type outboundMessage struct {
+	messageType int
+	data        []byte
+}
+
 // WSClient WebSocket客户端（单连接）
 type WSClient struct {
 	mu sync.RWMutex
@@ -21,6 +47,7 @@ type WSClient struct {
 	endpoint string   // WebSocket端点
 	tokenIDs []string // 订阅的token列表
 	config   *Config
+	proxy    *url.URL // 代理地址，nil 表示直连
 
 	conn  *websocket.Conn
 	state ConnectionState
@@ -31,11 +58,11 @@ type WSClient struct {
 	onStateChange func(ConnectionState)
 
 	// 控制通道
-	ctx       context.Context
-	cancel    context.CancelFunc
-	writeChan chan []byte
-	closeChan chan struct{}
-	closeOnce sync.Once
+	ctx        context.Context
+	cancel     context.CancelFunc
+	writeLanes [writeLaneCount]chan outboundMessage // 按优先级划分的出站队列，见 writeLoop
+	closeChan  chan struct{}
+	closeOnce  sync.Once
 
 	// goroutine 生命周期控制
 	loopCtx    context.Context
@@ -54,7 +81,7 @@ type WSClient struct {
 func NewWSClient(id string, endpoint string, tokenIDs []string, config *Config) *WSClient {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &WSClient{
+	c := &WSClient{
 		id:        id,
 		endpoint:  endpoint,
 		tokenIDs:  tokenIDs,
@@ -62,10 +89,12 @@ func NewWSClient(id string, endpoint string, tokenIDs []string, config *Config)
 		state:     StateDisconnected,
 		ctx:       ctx,
 		cancel:    cancel,
-		writeChan: make(chan []byte, config.MessageBufferSize),
 		closeChan: make(chan struct{}),
 		lastPong:  time.Now(),
 	}
+	c.writeLanes[writeLaneControl] = make(chan outboundMessage, controlLaneSize)
+	c.writeLanes[writeLaneSubscription] = make(chan outboundMessage, config.MessageBufferSize)
+	return c
 }
 
 // SetMessageHandler 设置消息处理回调
@@ -82,6 +111,20 @@ func (c *WSClient) SetStateChangeHandler(handler func(ConnectionState)) {
 	c.onStateChange = handler
 }
 
+// SetProxy 设置该连接使用的代理（见 ParseProxyURL），nil 表示直连，下次连接/重连时生效
+func (c *WSClient) SetProxy(proxy *url.URL) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.proxy = proxy
+}
+
+// Proxy 获取该连接使用的代理，nil 表示直连
+func (c *WSClient) Proxy() *url.URL {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.proxy
+}
+
 // GetState 获取当前连接状态
 func (c *WSClient) GetState() ConnectionState {
 	c.mu.RLock()
@@ -113,8 +156,25 @@ func (c *WSClient) Connect() error {
 		HandshakeTimeout: 10 * time.Second,
 	}
 
-	conn, _, err := dialer.DialContext(c.ctx, c.endpoint, nil)
+	// 多出口 IP 时绑定本地地址，重连会轮换到下一个地址
+	var localIP net.IP
+	if pool := c.config.LocalAddrs; pool != nil {
+		localIP = pool.Next()
+		dialer.NetDialContext = pool.Dialer(localIP, dialer.HandshakeTimeout).DialContext
+	}
+	if proxy := c.Proxy(); proxy != nil {
+		dialer.Proxy = http.ProxyURL(proxy)
+	}
+
+	conn, resp, err := dialer.DialContext(c.ctx, c.endpoint, nil)
 	if err != nil {
+		// 握手被拒绝（疑似 IP 被封禁或限流），暂时跳过该地址
+		if localIP != nil && resp != nil &&
+			(resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) {
+			log.Printf("[Polymarket WSClient %s] local address %s rejected with status %d, banning for %v",
+				c.id, localIP, resp.StatusCode, localAddrBanDuration)
+			c.config.LocalAddrs.Ban(localIP, localAddrBanDuration)
+		}
 		c.setState(StateDisconnected)
 		return err
 	}
@@ -175,20 +235,7 @@ func (c *WSClient) sendInitialSubscribe(tokenIDs []string) error {
 		return err
 	}
 
-	c.mu.RLock()
-	loopCtx := c.loopCtx
-	c.mu.RUnlock()
-
-	select {
-	case c.writeChan <- data:
-		return nil
-	case <-c.ctx.Done():
-		return c.ctx.Err()
-	case <-loopCtx.Done():
-		return loopCtx.Err()
-	case <-time.After(5 * time.Second):
-		return context.DeadlineExceeded
-	}
+	return c.enqueue(writeLaneSubscription, outboundMessage{messageType: websocket.TextMessage, data: data})
 }
 
 // sendDynamicSubscribe 发送动态订阅请求（连接后添加订阅使用 operation: "subscribe"）
@@ -201,7 +248,7 @@ func (c *WSClient) sendDynamicUnsubscribe(tokenIDs []string) error {
 	return c.sendDynamicOperation(tokenIDs, "unsubscribe")
 }
 
-// sendDynamicOperation 发送动态操作请求
+// sendDynamicOperation 发送动态操作请求，订阅与取消订阅走同一队列，先调用的先发送
 func (c *WSClient) sendDynamicOperation(tokenIDs []string, operation string) error {
 	if len(tokenIDs) == 0 {
 		return nil
@@ -217,12 +264,17 @@ func (c *WSClient) sendDynamicOperation(tokenIDs []string, operation string) err
 		return err
 	}
 
+	return c.enqueue(writeLaneSubscription, outboundMessage{messageType: websocket.TextMessage, data: data})
+}
+
+// enqueue 将消息放入对应优先级的出站队列，队列满时最多等待 5 秒
+func (c *WSClient) enqueue(lane writeLane, msg outboundMessage) error {
 	c.mu.RLock()
 	loopCtx := c.loopCtx
 	c.mu.RUnlock()
 
 	select {
-	case c.writeChan <- data:
+	case c.writeLanes[lane] <- msg:
 		return nil
 	case <-c.ctx.Done():
 		return c.ctx.Err()
@@ -249,6 +301,8 @@ func (c *WSClient) stopLoops() {
 func (c *WSClient) readLoop() {
 	defer c.loopWg.Done()
 	defer c.triggerReconnect()
+	// 最先执行：消息处理中的 panic 被恢复后由 triggerReconnect 按退避策略重建连接
+	defer common.RecoverPanic("orderbook.ws.read", nil)
 
 	c.mu.RLock()
 	loopCtx := c.loopCtx
@@ -297,45 +351,80 @@ func (c *WSClient) readLoop() {
 	}
 }
 
-// writeLoop 写入消息循环
+// writeLoop 写入消息循环，连接上唯一的写入者
+// 每次优先取控制帧，订阅操作大量积压时 ping 不会排在队尾导致心跳超时；
+// 订阅与取消订阅之间不分优先级，避免后发的订阅越过先发的取消订阅
 func (c *WSClient) writeLoop() {
 	defer c.loopWg.Done()
+	defer common.RecoverPanic("orderbook.ws.write", c.reconnectAfterPanic)
 
 	c.mu.RLock()
 	loopCtx := c.loopCtx
 	c.mu.RUnlock()
 
 	for {
-		select {
-		case <-c.ctx.Done():
+		msg, ok := c.nextOutbound(loopCtx)
+		if !ok {
 			return
-		case <-c.closeChan:
-			return
-		case <-loopCtx.Done():
+		}
+
+		c.mu.RLock()
+		conn := c.conn
+		c.mu.RUnlock()
+
+		if conn == nil {
+			continue
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		if err := conn.WriteMessage(msg.messageType, msg.data); err != nil {
+			log.Printf("[ Polymarket WSClient %s] write error: %v", c.id, err)
 			return
-		case data := <-c.writeChan:
-			c.mu.RLock()
-			conn := c.conn
-			c.mu.RUnlock()
+		}
+	}
+}
 
-			if conn == nil {
-				continue
-			}
+// nextOutbound 取出优先级最高的待发送消息，队列全空时阻塞等待，连接关闭时返回 false
+func (c *WSClient) nextOutbound(loopCtx context.Context) (outboundMessage, bool) {
+	select {
+	case <-c.ctx.Done():
+		return outboundMessage{}, false
+	case <-c.closeChan:
+		return outboundMessage{}, false
+	case <-loopCtx.Done():
+		return outboundMessage{}, false
+	default:
+	}
 
-			conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
-				log.Printf("[ Polymarket WSClient %s] write error: %v", c.id, err)
-				return
-			}
+	for _, lane := range c.writeLanes {
+		select {
+		case msg := <-lane:
+			return msg, true
+		default:
 		}
 	}
+
+	select {
+	case <-c.ctx.Done():
+		return outboundMessage{}, false
+	case <-c.closeChan:
+		return outboundMessage{}, false
+	case <-loopCtx.Done():
+		return outboundMessage{}, false
+	case msg := <-c.writeLanes[writeLaneControl]:
+		return msg, true
+	case msg := <-c.writeLanes[writeLaneSubscription]:
+		return msg, true
+	}
 }
 
 // heartbeatLoop 心跳循环
 func (c *WSClient) heartbeatLoop() {
 	defer c.loopWg.Done()
+	defer common.RecoverPanic("orderbook.ws.heartbeat", c.reconnectAfterPanic)
 
-	ticker := time.NewTicker(time.Duration(c.config.PingInterval) * time.Second)
+	pingInterval, _ := c.config.heartbeat()
+	ticker := time.NewTicker(pingInterval)
 	defer ticker.Stop()
 
 	c.mu.RLock()
@@ -360,22 +449,30 @@ func (c *WSClient) heartbeatLoop() {
 				return
 			}
 
+			// 心跳间隔可在运行时调整，变化时重置 ticker
+			interval, pongTimeout := c.config.heartbeat()
+			if interval != pingInterval {
+				pingInterval = interval
+				ticker.Reset(pingInterval)
+			}
+
 			// 检查pong超时
 			timeSinceLastPong := time.Since(lastPong)
-			timeout := time.Duration(c.config.PingInterval+c.config.PongTimeout) * time.Second
+			timeout := pingInterval + pongTimeout
 			if timeSinceLastPong > timeout {
 				log.Printf("[ Polymarket WSClient %s] pong timeout, last pong: %v ago (timeout: %v), reconnecting...",
 					c.id, timeSinceLastPong.Round(time.Second), timeout)
 				return
 			}
 
-			// 发送ping
-			conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				log.Printf("[ Polymarket WSClient %s] ping error: %v", c.id, err)
-				return
+			// 发送ping：交给 writeLoop 以最高优先级写出，避免与其他消息并发写连接
+			select {
+			case c.writeLanes[writeLaneControl] <- outboundMessage{messageType: websocket.PingMessage}:
+				log.Printf("[ Polymarket WSClient %s] queued ping, last pong: %v ago", c.id, timeSinceLastPong.Round(time.Second))
+			default:
+				// 控制队列已满说明写入停滞，由 pong 超时检测处理
+				log.Printf("[ Polymarket WSClient %s] control queue full, ping skipped", c.id)
 			}
-			log.Printf("[ Polymarket WSClient %s] sent ping, last pong: %v ago", c.id, timeSinceLastPong.Round(time.Second))
 		}
 	}
 }
@@ -418,6 +515,12 @@ func (c *WSClient) triggerReconnect() {
 	go c.reconnect()
 }
 
+// reconnectAfterPanic 协程 panic 被恢复后重建连接，保证 token 的数据流不会静默中断
+func (c *WSClient) reconnectAfterPanic(err error) {
+	log.Printf("[ Polymarket WSClient %s] %v, reconnecting", c.id, err)
+	c.triggerReconnect()
+}
+
 // closeConnection 关闭当前连接（不触发重连）
 func (c *WSClient) closeConnection() {
 	c.mu.Lock()
@@ -432,11 +535,17 @@ func (c *WSClient) closeConnection() {
 
 // reconnect 重连逻辑
 func (c *WSClient) reconnect() {
+	defer common.RecoverPanic("orderbook.ws.reconnect", func(err error) {
+		// 释放重连标记，否则之后的 triggerReconnect 都会被跳过
+		atomic.StoreInt32(&c.reconnecting, 0)
+		c.reconnectAfterPanic(err)
+	})
+
 	// 等待旧的 goroutine 退出
 	c.loopWg.Wait()
 
-	// 清空 writeChan 中的旧消息
-	c.drainWriteChan()
+	// 清空出站队列中的旧消息
+	c.drainWriteLanes()
 
 	for {
 		select {
@@ -450,7 +559,7 @@ func (c *WSClient) reconnect() {
 		attempts := atomic.AddInt32(&c.reconnectAttempts, 1)
 
 		// 检查最大重连次数
-		if c.config.ReconnectMaxAttempts > 0 && int(attempts) > c.config.ReconnectMaxAttempts {
+		if _, _, maxAttempts := c.config.reconnectPolicy(); maxAttempts > 0 && int(attempts) > maxAttempts {
 			log.Printf("[ Polymarket WSClient %s] max reconnect attempts reached", c.id)
 			c.setState(StateDisconnected)
 			atomic.StoreInt32(&c.reconnecting, 0)
@@ -480,21 +589,23 @@ func (c *WSClient) reconnect() {
 	}
 }
 
-// drainWriteChan 清空写入通道中的旧消息
-func (c *WSClient) drainWriteChan() {
-	for {
-		select {
-		case <-c.writeChan:
-		default:
-			return
+// drainWriteLanes 清空各出站队列中的旧消息
+func (c *WSClient) drainWriteLanes() {
+	for _, lane := range c.writeLanes {
+	drain:
+		for {
+			select {
+			case <-lane:
+			default:
+				break drain
+			}
 		}
 	}
 }
 
 // calculateBackoff 计算退避时间
 func (c *WSClient) calculateBackoff(attempts int) time.Duration {
-	minInterval := time.Duration(c.config.ReconnectMinInterval) * time.Millisecond
-	maxInterval := time.Duration(c.config.ReconnectMaxInterval) * time.Millisecond
+	minInterval, maxInterval, _ := c.config.reconnectPolicy()
 
 	// 指数退避
 	backoff := minInterval * time.Duration(1<<uint(attempts-1))
@@ -519,11 +630,22 @@ func (c *WSClient) Close() {
 		c.setState(StateClosed)
 		c.cancel()
 		close(c.closeChan)
-		c.stopLoops()
+		// 先关闭连接，使阻塞在 ReadMessage 的 readLoop 立即返回，再等待 goroutine 退出
 		c.closeConnection()
+		c.stopLoops()
 	})
 }
 
+// IsDead 检查客户端是否已失效（已关闭，或重连次数耗尽后放弃重连）
+// 重连过程中的短暂 Disconnected 状态不算失效
+func (c *WSClient) IsDead() bool {
+	state := c.GetState()
+	if state == StateClosed {
+		return true
+	}
+	return state == StateDisconnected && atomic.LoadInt32(&c.reconnecting) == 0
+}
+
 // ID 获取客户端ID
 func (c *WSClient) ID() string {
 	return c.id
@@ -583,3 +705,38 @@ func (c *WSClient) RemoveTokens(tokenIDs []string) error {
 	// 发送动态取消订阅请求
 	return c.sendDynamicUnsubscribe(tokenIDs)
 }
+
+// dropTokens 仅从本地 token 列表移除，不发送取消订阅请求
+// 用于连接不可用时清理，避免重连后重新订阅已迁移到其他连接的 token
+func (c *WSClient) dropTokens(tokenIDs []string) {
+	toRemove := make(map[string]bool, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		toRemove[tokenID] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	newTokenIDs := make([]string, 0, len(c.tokenIDs))
+	for _, tokenID := range c.tokenIDs {
+		if !toRemove[tokenID] {
+			newTokenIDs = append(newTokenIDs, tokenID)
+		}
+	}
+	c.tokenIDs = newTokenIDs
+}
+
+// Resubscribe 对已订阅的 token 重新发送取消订阅+订阅请求，以获取新的订单簿快照
+func (c *WSClient) Resubscribe(tokenIDs []string) error {
+	c.mu.RLock()
+	state := c.state
+	c.mu.RUnlock()
+
+	if state != StateActive && state != StateConnected {
+		return fmt.Errorf("client not active, current state: %s", state)
+	}
+
+	if err := c.sendDynamicUnsubscribe(tokenIDs); err != nil {
+		return err
+	}
+	return c.sendDynamicSubscribe(tokenIDs)
+}
@@ -0,0 +1,212 @@
+package orderbook
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestWSClient_Connect_LocalAddrRotation(t *testing.T) {
+	var mu sync.Mutex
+	var remotes []string
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, _ := net.SplitHostPort(r.RemoteAddr)
+		mu.Lock()
+		remotes = append(remotes, host)
+		rejected := host == "127.0.0.2"
+		mu.Unlock()
+
+		if rejected {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	addrs, err := common.NewLocalAddrPool([]string{"127.0.0.1", "127.0.0.2"})
+	if err != nil {
+		t.Fatalf("NewLocalAddrPool() error: %v", err)
+	}
+	config := DefaultConfig()
+	config.LocalAddrs = addrs
+	endpoint := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	first := NewWSClient("c1", endpoint, nil, config)
+	if err := first.Connect(); err != nil {
+		t.Fatalf("first Connect() error: %v", err)
+	}
+	defer first.Close()
+
+	// Second connection uses 127.0.0.2, which is rejected and banned
+	second := NewWSClient("c2", endpoint, nil, config)
+	if err := second.Connect(); err == nil {
+		t.Fatal("second Connect() should fail with 429")
+	}
+
+	// Retry skips the banned address
+	if err := second.Connect(); err != nil {
+		t.Fatalf("retry Connect() error: %v", err)
+	}
+	defer second.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{"127.0.0.1", "127.0.0.2", "127.0.0.1"}
+	if strings.Join(remotes, ",") != strings.Join(expected, ",") {
+		t.Errorf("remote addresses = %v, expected %v", remotes, expected)
+	}
+}
+
+func TestWSClient_HandlerPanicReconnects(t *testing.T) {
+	var conns int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		atomic.AddInt32(&conns, 1)
+		if err := conn.WriteMessage(websocket.TextMessage, []byte("[]")); err != nil {
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.ReconnectMinInterval = 10
+	config.ReconnectMaxInterval = 10
+	client := NewWSClient("c1", "ws"+strings.TrimPrefix(server.URL, "http"), nil, config)
+
+	var messages int32
+	handled := make(chan struct{})
+	client.SetMessageHandler(func([]byte) {
+		// The first message panics; the feed must come back on a new connection
+		if atomic.AddInt32(&messages, 1) == 1 {
+			panic("handler bug")
+		}
+		close(handled)
+	})
+
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+	defer client.Close()
+
+	select {
+	case <-handled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("client did not reconnect after handler panic")
+	}
+	if got := atomic.LoadInt32(&conns); got != 2 {
+		t.Errorf("expected 2 connections, got %d", got)
+	}
+	if common.PanicCount("orderbook.ws.read") == 0 {
+		t.Error("expected the read loop panic to be counted")
+	}
+}
+
+func TestWSClient_NextOutboundPriority(t *testing.T) {
+	client := NewWSClient("c1", "ws://unused", nil, DefaultConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client.loopCtx = ctx
+
+	// Control jumps the queue; subscription operations keep their call order,
+	// so a later subscribe never overtakes an earlier unsubscribe of the same token
+	if err := client.sendDynamicUnsubscribe([]string{"t1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.sendDynamicSubscribe([]string{"t1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.sendDynamicUnsubscribe([]string{"t2"}); err != nil {
+		t.Fatal(err)
+	}
+	client.writeLanes[writeLaneControl] <- outboundMessage{messageType: websocket.PingMessage}
+
+	if msg, ok := client.nextOutbound(ctx); !ok || msg.messageType != websocket.PingMessage {
+		t.Fatalf("first message = %+v (ok=%v), expected ping", msg, ok)
+	}
+	for i, want := range []string{
+		`{"assets_ids":["t1"],"operation":"unsubscribe"}`,
+		`{"assets_ids":["t1"],"operation":"subscribe"}`,
+		`{"assets_ids":["t2"],"operation":"unsubscribe"}`,
+	} {
+		msg, ok := client.nextOutbound(ctx)
+		if !ok || string(msg.data) != want {
+			t.Fatalf("message %d = %q (ok=%v), expected %q", i, msg.data, ok, want)
+		}
+	}
+
+	cancel()
+	if _, ok := client.nextOutbound(ctx); ok {
+		t.Error("nextOutbound() should stop once the loop context is cancelled")
+	}
+}
+
+func TestWSClient_ResubscribeKeepsOrder(t *testing.T) {
+	received := make(chan string, 4)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- string(data)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWSClient("c1", "ws"+strings.TrimPrefix(server.URL, "http"), nil, DefaultConfig())
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Resubscribe([]string{"t1"}); err != nil {
+		t.Fatalf("Resubscribe() error: %v", err)
+	}
+	for _, op := range []string{"unsubscribe", "subscribe"} {
+		select {
+		case msg := <-received:
+			if !strings.Contains(msg, `"operation":"`+op+`"`) {
+				t.Errorf("expected %s, got %s", op, msg)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %s", op)
+		}
+	}
+}
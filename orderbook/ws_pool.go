@@ -3,9 +3,14 @@ package orderbook
 import (
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 )
 
+// rebalanceSlack 自动重平衡允许的冗余连接数
+// 连接数超过 ceil(token数/MaxTokensPerConn) + rebalanceSlack 时触发自动重平衡
+const rebalanceSlack = 1
+
 // WSPool WebSocket连接池
 type WSPool struct {
 	mu sync.RWMutex
@@ -23,6 +28,10 @@ type WSPool struct {
 	onMessage func([]byte)
 	// 状态变更回调
 	onStateChange func(string, ConnectionState)
+	// 原始帧录制（可选）
+	recorder *FrameRecorder
+	// token 放置策略
+	placement PlacementStrategy
 
 	// 是否已连接
 	connected bool
@@ -30,10 +39,16 @@ type WSPool struct {
 
 // NewWSPool 创建新的连接池
 func NewWSPool(config *Config) *WSPool {
+	var placement PlacementStrategy = PackPlacement{}
+	if config.Placement != nil {
+		placement = config.Placement
+	}
+
 	return &WSPool{
 		config:        config,
 		clients:       make([]*WSClient, 0),
 		tokenToClient: make(map[string]*WSClient),
+		placement:     placement,
 	}
 }
 
@@ -44,6 +59,24 @@ func (p *WSPool) SetMessageHandler(handler func([]byte)) {
 	p.onMessage = handler
 }
 
+// SetFrameRecorder 设置原始帧录制器，之后创建的连接收到的每一帧都会先记录再交给消息处理回调
+func (p *WSPool) SetFrameRecorder(recorder *FrameRecorder) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.recorder = recorder
+}
+
+// SetPlacement 设置 token 放置策略，nil 恢复默认的 PackPlacement
+// 只影响之后的放置（订阅、重平衡与修复），已有连接上的 token 不会迁移，需要按新策略重新分布时调用 Rebalance
+func (p *WSPool) SetPlacement(strategy PlacementStrategy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if strategy == nil {
+		strategy = PackPlacement{}
+	}
+	p.placement = strategy
+}
+
 // SetStateChangeHandler 设置状态变更回调
 func (p *WSPool) SetStateChangeHandler(handler func(string, ConnectionState)) {
 	p.mu.Lock()
@@ -62,15 +95,24 @@ func (p *WSPool) Connect() error {
 	}
 
 	// 创建一个空的客户端（不带 token）
+	proxy, err := p.config.proxyFor(p.nextClientID)
+	if err != nil {
+		return err
+	}
 	clientID := fmt.Sprintf("client-%d", p.nextClientID)
 	p.nextClientID++
 
 	client := NewWSClient(clientID, p.config.WSEndpoint, nil, p.config)
+	client.SetProxy(proxy)
 
 	// 设置消息处理回调
 	if p.onMessage != nil {
 		handler := p.onMessage
+		recorder, cid := p.recorder, clientID
 		client.SetMessageHandler(func(data []byte) {
+			if recorder != nil {
+				recorder.Record(cid, data)
+			}
 			handler(data)
 		})
 	}
@@ -129,80 +171,83 @@ func (p *WSPool) Subscribe(tokenIDs []string) error {
 		p.mu.Lock()
 	}
 
-	// 尝试将新 token 添加到现有连接中
-	remaining := p.addToExistingClients(newTokens)
-
-	// 如果还有剩余的 token，创建新连接
-	if len(remaining) > 0 {
-		if err := p.createNewClients(remaining); err != nil {
-			return err
-		}
+	// 按放置策略分配到现有连接或新建连接
+	if err := p.placeLocked(newTokens); err != nil {
+		return err
 	}
 
-	return nil
+	return p.repairAssignmentsLocked()
 }
 
-// addToExistingClients 尝试将 token 添加到现有连接
-// 返回无法添加的 token 列表
-func (p *WSPool) addToExistingClients(tokenIDs []string) []string {
-	remaining := make([]string, 0)
-	tokensToAdd := tokenIDs
+// placeLocked 按放置策略将 token 分配到现有连接或新建连接（调用者需持有锁）
+// 添加到现有连接失败的 token 改为新建连接承载
+func (p *WSPool) placeLocked(tokenIDs []string) error {
+	seen := make(map[string]bool, len(tokenIDs))
+	tokens := make([]string, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if !seen[tokenID] {
+			seen[tokenID] = true
+			tokens = append(tokens, tokenID)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
 
+	maxPerConn := p.config.MaxTokensPerConn
+	conns := make([]ConnLoad, 0, len(p.clients))
 	for _, client := range p.clients {
-		if len(tokensToAdd) == 0 {
-			break
-		}
+		conns = append(conns, ConnLoad{ClientID: client.ID(), Tokens: client.TokenIDs()})
+	}
 
-		// 检查客户端是否还有空间
-		currentCount := len(client.TokenIDs())
-		available := p.config.MaxTokensPerConn - currentCount
+	plan := p.placement.Place(append([]string(nil), tokens...), conns, maxPerConn)
+	if err := validatePlacement(plan, tokens, conns, maxPerConn); err != nil {
+		log.Printf("[WSPool] invalid placement (%v), falling back to packing", err)
+		plan = PackPlacement{}.Place(tokens, conns, maxPerConn)
+	}
 
-		if available <= 0 {
+	var failed []string
+	for _, client := range p.clients {
+		group := plan.Existing[client.ID()]
+		if len(group) == 0 {
 			continue
 		}
-
-		// 计算可以添加多少个 token
-		addCount := available
-		if addCount > len(tokensToAdd) {
-			addCount = len(tokensToAdd)
+		if err := client.AddTokens(group); err != nil {
+			// 如果添加失败，从该连接本地列表中移除（避免重连后重复订阅），改为新建连接
+			client.dropTokens(group)
+			failed = append(failed, group...)
+			continue
 		}
-
-		// 添加 token 到这个客户端
-		tokensForClient := tokensToAdd[:addCount]
-		if err := client.AddTokens(tokensForClient); err != nil {
-			// 如果添加失败，保留到 remaining
-			remaining = append(remaining, tokensForClient...)
-		} else {
-			// 更新映射
-			for _, tokenID := range tokensForClient {
-				p.tokenToClient[tokenID] = client
-			}
+		for _, tokenID := range group {
+			p.tokenToClient[tokenID] = client
 		}
-
-		// 更新剩余列表
-		tokensToAdd = tokensToAdd[addCount:]
 	}
 
-	// 加上未处理的
-	remaining = append(remaining, tokensToAdd...)
-	return remaining
+	groups := append(plan.New, chunkTokens(failed, maxPerConn)...)
+	return p.createNewClients(groups)
 }
 
-// createNewClients 为剩余的 token 创建新连接
-func (p *WSPool) createNewClients(tokenIDs []string) error {
-	// 按 MaxTokensPerConn 分组
-	groups := p.groupTokens(tokenIDs)
-
+// createNewClients 为每组 token 创建一个新连接
+func (p *WSPool) createNewClients(groups [][]string) error {
 	for _, group := range groups {
+		proxy, err := p.config.proxyFor(p.nextClientID)
+		if err != nil {
+			return err
+		}
 		clientID := fmt.Sprintf("client-%d", p.nextClientID)
 		p.nextClientID++
 
 		client := NewWSClient(clientID, p.config.WSEndpoint, group, p.config)
+		client.SetProxy(proxy)
 
 		// 设置消息处理回调
 		if p.onMessage != nil {
 			handler := p.onMessage
+			recorder, cid := p.recorder, clientID
 			client.SetMessageHandler(func(data []byte) {
+				if recorder != nil {
+					recorder.Record(cid, data)
+				}
 				handler(data)
 			})
 		}
@@ -235,7 +280,15 @@ func (p *WSPool) createNewClients(tokenIDs []string) error {
 // Unsubscribe 取消订阅指定的 token
 func (p *WSPool) Unsubscribe(tokenIDs []string) error {
 	p.mu.Lock()
-	defer p.mu.Unlock()
+	retired, err := p.unsubscribeLocked(tokenIDs)
+	p.mu.Unlock()
+
+	closeClients(retired)
+	return err
+}
+
+// unsubscribeLocked 取消订阅并在需要时重平衡，返回被淘汰、待关闭的连接（调用者需持有锁）
+func (p *WSPool) unsubscribeLocked(tokenIDs []string) ([]*WSClient, error) {
 
 	// 按客户端分组需要取消的 token
 	clientTokens := make(map[*WSClient][]string)
@@ -250,40 +303,243 @@ func (p *WSPool) Unsubscribe(tokenIDs []string) error {
 	for client, tokens := range clientTokens {
 		if err := client.RemoveTokens(tokens); err != nil {
 			log.Printf("[WSPool] failed to unsubscribe tokens from client %s: %v", client.ID(), err)
+			client.dropTokens(tokens)
 		}
 
 		// 如果客户端没有任何 token 了，但保留连接（作为空闲连接）
 		// 不关闭它，以便后续可以复用
 	}
 
-	return nil
+	// 大量取消订阅后可能残留许多近乎空闲的连接，自动合并
+	if p.needsRebalanceLocked() {
+		return p.rebalanceLocked()
+	}
+
+	return nil, p.repairAssignmentsLocked()
 }
 
-// removeClient 从客户端列表中移除指定客户端
-func (p *WSPool) removeClient(client *WSClient) {
-	newClients := make([]*WSClient, 0, len(p.clients)-1)
-	for _, c := range p.clients {
-		if c != client {
-			newClients = append(newClients, c)
+// Rebalance 重新平衡 token 在各连接间的分布
+// 1. 已失效连接（已关闭或放弃重连）上的 token 迁移到可用连接
+// 2. 合并近乎空闲的连接，使连接数收敛到 ceil(token数/MaxTokensPerConn)
+// 被迁移的 token 会在新连接上重新订阅，并收到新的订单簿快照
+func (p *WSPool) Rebalance() error {
+	p.mu.Lock()
+	retired, err := p.rebalanceLocked()
+	p.mu.Unlock()
+
+	closeClients(retired)
+	return err
+}
+
+// closeClients 关闭被淘汰的连接，需在释放 p.mu 后调用：
+// Close 会等待读协程退出，而读协程的消息回调可能正在等待调用方持有的其他锁
+func closeClients(clients []*WSClient) {
+	for _, client := range clients {
+		client.Close()
+	}
+}
+
+// needsRebalanceLocked 检查是否需要重平衡（调用者需持有锁）
+func (p *WSPool) needsRebalanceLocked() bool {
+	for _, client := range p.clients {
+		if client.IsDead() {
+			return true
 		}
 	}
-	p.clients = newClients
+	return len(p.clients) > p.requiredClientsLocked(len(p.tokenToClient))+rebalanceSlack
 }
 
-// groupTokens 将token列表按MaxTokensPerConn分组
-func (p *WSPool) groupTokens(tokenIDs []string) [][]string {
+// requiredClientsLocked 计算承载指定数量 token 所需的最少连接数（至少保留一个空闲连接）
+func (p *WSPool) requiredClientsLocked(tokenCount int) int {
 	maxPerConn := p.config.MaxTokensPerConn
-	groups := make([][]string, 0)
+	required := (tokenCount + maxPerConn - 1) / maxPerConn
+	if required == 0 {
+		required = 1
+	}
+	return required
+}
+
+// rebalanceLocked 执行重平衡，返回被淘汰的连接，由调用者释放锁后关闭（调用者需持有锁）
+func (p *WSPool) rebalanceLocked() ([]*WSClient, error) {
+	if len(p.clients) == 0 {
+		return nil, nil
+	}
+
+	// 区分可用连接和失效连接，失效连接上的 token 需要重新分配
+	alive := make([]*WSClient, 0, len(p.clients))
+	orphans := make([]string, 0)
+	var retired []*WSClient
+	for _, client := range p.clients {
+		if client.IsDead() {
+			orphans = append(orphans, client.TokenIDs()...)
+			retired = append(retired, client)
+			continue
+		}
+		alive = append(alive, client)
+	}
+
+	// 按 token 数降序排列，优先保留负载高的连接，减少迁移量
+	sort.SliceStable(alive, func(i, j int) bool {
+		return len(alive[i].TokenIDs()) > len(alive[j].TokenIDs())
+	})
+
+	total := len(orphans)
+	for _, client := range alive {
+		total += len(client.TokenIDs())
+	}
+	required := p.requiredClientsLocked(total)
+
+	// 超出所需数量的连接：取消其订阅并关闭，token 迁移到保留的连接
+	kept := alive
+	if len(alive) > required {
+		kept = alive[:required]
+		for _, client := range alive[required:] {
+			tokens := client.TokenIDs()
+			if len(tokens) > 0 {
+				if err := client.RemoveTokens(tokens); err != nil {
+					log.Printf("[WSPool] failed to unsubscribe tokens from client %s during rebalance: %v", client.ID(), err)
+					client.dropTokens(tokens)
+				}
+				orphans = append(orphans, tokens...)
+			}
+			retired = append(retired, client)
+		}
+	}
+
+	p.clients = kept
+	p.connected = len(p.clients) > 0
+
+	if len(orphans) == 0 {
+		return retired, p.repairAssignmentsLocked()
+	}
+
+	log.Printf("[WSPool] rebalancing %d tokens across %d clients", len(orphans), len(p.clients))
+
+	for _, tokenID := range orphans {
+		delete(p.tokenToClient, tokenID)
+	}
+
+	if err := p.placeLocked(orphans); err != nil {
+		return retired, err
+	}
+
+	return retired, p.repairAssignmentsLocked()
+}
+
+// AssignmentIssue token 分配不变量违反
+type AssignmentIssue struct {
+	TokenID   string
+	ClientIDs []string // 实际订阅该 token 的连接
+	Mapped    string   // 映射记录的连接，为空表示该 token 未订阅却仍挂在连接上
+}
+
+// CheckAssignments 校验 token 分配不变量：每个已订阅 token 恰好由映射记录的那一个连接订阅
+// 返回按 token 排序的违反列表，无问题时返回 nil
+func (p *WSPool) CheckAssignments() []AssignmentIssue {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.checkAssignmentsLocked()
+}
+
+// checkAssignmentsLocked 校验 token 分配（调用者需持有锁）
+func (p *WSPool) checkAssignmentsLocked() []AssignmentIssue {
+	holders := make(map[string][]*WSClient)
+	for _, client := range p.clients {
+		for _, tokenID := range client.TokenIDs() {
+			holders[tokenID] = append(holders[tokenID], client)
+		}
+	}
+
+	var issues []AssignmentIssue
+	check := func(tokenID string) {
+		clients := holders[tokenID]
+		mapped := p.tokenToClient[tokenID]
+		if len(clients) == 1 && clients[0] == mapped {
+			return
+		}
+		issue := AssignmentIssue{TokenID: tokenID}
+		if mapped != nil {
+			issue.Mapped = mapped.ID()
+		}
+		for _, c := range clients {
+			issue.ClientIDs = append(issue.ClientIDs, c.ID())
+		}
+		issues = append(issues, issue)
+	}
+	for tokenID := range holders {
+		check(tokenID)
+	}
+	for tokenID := range p.tokenToClient {
+		if _, ok := holders[tokenID]; !ok {
+			check(tokenID)
+		}
+	}
 
-	for i := 0; i < len(tokenIDs); i += maxPerConn {
-		end := i + maxPerConn
-		if end > len(tokenIDs) {
-			end = len(tokenIDs)
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].TokenID < issues[j].TokenID
+	})
+	return issues
+}
+
+// repairAssignmentsLocked 修复 token 分配（调用者需持有锁）
+// 重复订阅的 token 只保留映射记录的连接（映射缺失或失效时保留第一个），已取消订阅的 token 从连接移除，
+// 映射连接上丢失的 token 重新分配
+func (p *WSPool) repairAssignmentsLocked() error {
+	issues := p.checkAssignmentsLocked()
+	if len(issues) == 0 {
+		return nil
+	}
+
+	byClient := make(map[string]*WSClient, len(p.clients))
+	for _, client := range p.clients {
+		byClient[client.ID()] = client
+	}
+
+	var lost []string
+	for _, issue := range issues {
+		log.Printf("[WSPool] token %s assigned to clients %v (mapped: %q), repairing", issue.TokenID, issue.ClientIDs, issue.Mapped)
+
+		_, subscribed := p.tokenToClient[issue.TokenID]
+		if subscribed && len(issue.ClientIDs) == 0 {
+			delete(p.tokenToClient, issue.TokenID)
+			lost = append(lost, issue.TokenID)
+			continue
+		}
+
+		keep := ""
+		if subscribed {
+			keep = issue.ClientIDs[0]
+			for _, id := range issue.ClientIDs {
+				if id == issue.Mapped {
+					keep = id
+				}
+			}
+			p.tokenToClient[issue.TokenID] = byClient[keep]
+		}
+
+		for _, id := range issue.ClientIDs {
+			if id == keep {
+				continue
+			}
+			client := byClient[id]
+			if err := client.RemoveTokens([]string{issue.TokenID}); err != nil {
+				client.dropTokens([]string{issue.TokenID})
+			}
 		}
-		groups = append(groups, tokenIDs[i:end])
 	}
 
-	return groups
+	return p.placeLocked(lost)
+}
+
+// removeClient 从客户端列表中移除指定客户端
+func (p *WSPool) removeClient(client *WSClient) {
+	newClients := make([]*WSClient, 0, len(p.clients)-1)
+	for _, c := range p.clients {
+		if c != client {
+			newClients = append(newClients, c)
+		}
+	}
+	p.clients = newClients
 }
 
 // GetClientForToken 获取负责指定token的客户端
@@ -0,0 +1,350 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestWSServer 创建一个只读取并丢弃消息的 WebSocket 测试服务器
+func newTestWSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestPool(t *testing.T, maxPerConn int, opts ...func(*Config)) *WSPool {
+	t.Helper()
+
+	server := newTestWSServer(t)
+	config := DefaultConfig()
+	config.WSEndpoint = "ws" + strings.TrimPrefix(server.URL, "http")
+	config.MaxTokensPerConn = maxPerConn
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	pool := NewWSPool(config)
+	if err := pool.Connect(); err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestWSPool_Subscribe_Sharding(t *testing.T) {
+	pool := newTestPool(t, 2)
+
+	if err := pool.Subscribe([]string{"t1", "t2", "t3", "t4", "t5"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+
+	if got := pool.GetClientCount(); got != 3 {
+		t.Errorf("GetClientCount() = %d, expected 3", got)
+	}
+	if got := pool.GetTokenCount(); got != 5 {
+		t.Errorf("GetTokenCount() = %d, expected 5", got)
+	}
+}
+
+func TestWSPool_Unsubscribe_AutoRebalance(t *testing.T) {
+	pool := newTestPool(t, 2)
+
+	if err := pool.Subscribe([]string{"t1", "t2", "t3", "t4", "t5", "t6"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	if got := pool.GetClientCount(); got != 3 {
+		t.Fatalf("GetClientCount() = %d, expected 3", got)
+	}
+
+	// 剩余 2 个 token 分散在 2 个连接上，连接数 3 > ceil(2/2) + slack，应自动合并
+	if err := pool.Unsubscribe([]string{"t1", "t2", "t3", "t5"}); err != nil {
+		t.Fatalf("Unsubscribe() error: %v", err)
+	}
+
+	if got := pool.GetClientCount(); got != 1 {
+		t.Errorf("GetClientCount() = %d, expected 1 after auto rebalance", got)
+	}
+	if got := pool.GetTokenCount(); got != 2 {
+		t.Errorf("GetTokenCount() = %d, expected 2", got)
+	}
+	for _, tokenID := range []string{"t4", "t6"} {
+		if pool.GetClientForToken(tokenID) == nil {
+			t.Errorf("token %s should still be assigned to a client", tokenID)
+		}
+	}
+}
+
+// newStreamingWSServer 创建一个持续推送 book 消息的 WebSocket 测试服务器
+func newStreamingWSServer(t *testing.T, tokenIDs []string) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+		for i := 0; ; i++ {
+			msg := fmt.Sprintf(`{"event_type":"book","asset_id":%q,"market":"m","timestamp":"%d","hash":"h",`+
+				`"bids":[{"price":"0.4","size":"10"}],"asks":[{"price":"0.6","size":"10"}]}`,
+				tokenIDs[i%len(tokenIDs)], 1700000000000+i)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// 回归测试：自动重平衡关闭连接时会等待读协程退出，读协程处理消息需要 Manager 的锁，
+// Manager.Unsubscribe 不能在持有锁时调用连接池
+func TestManager_Unsubscribe_RebalanceWhileStreaming(t *testing.T) {
+	tokens := []string{"t1", "t2", "t3", "t4", "t5", "t6"}
+	server := newStreamingWSServer(t, tokens)
+	config := DefaultConfig()
+	config.WSEndpoint = "ws" + strings.TrimPrefix(server.URL, "http")
+	config.MaxTokensPerConn = 2
+
+	// 不使用 defer Close：死锁时 Close 也会阻塞，测试应超时失败而不是挂起
+	m := NewManager(config)
+	go func() {
+		for range m.Updates() {
+		}
+	}()
+
+	for round := 0; round < 3; round++ {
+		if err := m.Subscribe(tokens); err != nil {
+			t.Fatalf("Subscribe() error: %v", err)
+		}
+		if got := m.Pool().GetClientCount(); got != 3 {
+			t.Fatalf("GetClientCount() = %d, expected 3", got)
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		done := make(chan error, 1)
+		go func() { done <- m.Unsubscribe([]string{"t1", "t2", "t3", "t5"}) }()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Unsubscribe() error: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Unsubscribe() deadlocked while messages were being delivered")
+		}
+		if got := m.Pool().GetClientCount(); got != 1 {
+			t.Errorf("GetClientCount() = %d, expected 1 after auto rebalance", got)
+		}
+		if err := m.Unsubscribe([]string{"t4", "t6"}); err != nil {
+			t.Fatalf("Unsubscribe() error: %v", err)
+		}
+	}
+	m.Close()
+}
+
+func TestWSPool_Rebalance_DeadClient(t *testing.T) {
+	pool := newTestPool(t, 2)
+
+	if err := pool.Subscribe([]string{"t1", "t2", "t3"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+
+	dead := pool.GetClientForToken("t3")
+	dead.Close()
+	if !dead.IsDead() {
+		t.Fatal("closed client should be dead")
+	}
+
+	if err := pool.Rebalance(); err != nil {
+		t.Fatalf("Rebalance() error: %v", err)
+	}
+
+	client := pool.GetClientForToken("t3")
+	if client == nil || client == dead {
+		t.Fatal("token t3 should be moved off the dead client")
+	}
+	for _, c := range pool.GetAllClients() {
+		if c.IsDead() {
+			t.Errorf("client %s is dead but still in pool", c.ID())
+		}
+	}
+}
+
+func TestWSPool_Rebalance_Empty(t *testing.T) {
+	pool := NewWSPool(DefaultConfig())
+	if err := pool.Rebalance(); err != nil {
+		t.Errorf("Rebalance() on empty pool error: %v", err)
+	}
+}
+
+func TestWSPool_CheckAssignments_RepairsDuplicates(t *testing.T) {
+	pool := newTestPool(t, 2)
+
+	if err := pool.Subscribe([]string{"t1", "t2", "t3"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	if issues := pool.CheckAssignments(); issues != nil {
+		t.Fatalf("unexpected issues after Subscribe: %+v", issues)
+	}
+
+	// Simulate a token leaking onto a second connection
+	owner := pool.GetClientForToken("t1")
+	other := pool.GetClientForToken("t3")
+	if err := other.AddTokens([]string{"t1"}); err != nil {
+		t.Fatalf("AddTokens() error: %v", err)
+	}
+
+	issues := pool.CheckAssignments()
+	if len(issues) != 1 || issues[0].TokenID != "t1" || len(issues[0].ClientIDs) != 2 || issues[0].Mapped != owner.ID() {
+		t.Fatalf("CheckAssignments() = %+v", issues)
+	}
+
+	if err := pool.Rebalance(); err != nil {
+		t.Fatalf("Rebalance() error: %v", err)
+	}
+	if issues := pool.CheckAssignments(); issues != nil {
+		t.Errorf("issues remain after repair: %+v", issues)
+	}
+	if pool.GetClientForToken("t1") != owner {
+		t.Error("token t1 should stay on its mapped client")
+	}
+	if got := pool.GetTokenCount(); got != 3 {
+		t.Errorf("GetTokenCount() = %d, expected 3", got)
+	}
+}
+
+func TestWSPool_CheckAssignments_StaleToken(t *testing.T) {
+	pool := newTestPool(t, 2)
+
+	if err := pool.Subscribe([]string{"t1"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	client := pool.GetClientForToken("t1")
+	client.dropTokens([]string{"t1"})
+	if err := client.AddTokens([]string{"t9"}); err != nil {
+		t.Fatalf("AddTokens() error: %v", err)
+	}
+
+	// t1 is lost from its connection, t9 is held without a subscription
+	if issues := pool.CheckAssignments(); len(issues) != 2 {
+		t.Fatalf("CheckAssignments() = %+v", issues)
+	}
+
+	if err := pool.Subscribe([]string{"t2"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	if issues := pool.CheckAssignments(); issues != nil {
+		t.Errorf("issues remain after repair: %+v", issues)
+	}
+	if pool.GetClientForToken("t1") == nil || pool.GetClientForToken("t9") != nil {
+		t.Error("expected t1 reassigned and t9 removed")
+	}
+}
+
+func TestSDK_ResubscribeBatchesPerConnection(t *testing.T) {
+	var mu sync.Mutex
+	var requests []DynamicSubscribeRequest
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var req DynamicSubscribeRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			mu.Lock()
+			requests = append(requests, req)
+			mu.Unlock()
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	config := DefaultConfig()
+	config.WSEndpoint = "ws" + strings.TrimPrefix(server.URL, "http")
+	sdk := NewSDK(config)
+	if err := sdk.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	t.Cleanup(sdk.Close)
+
+	if err := sdk.Subscribe([]string{"a", "b"}); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	sdk.manager.handleBookMessage(bookFor("a"), time.Now())
+	sdk.manager.handleBookMessage(bookFor("b"), time.Now())
+
+	err := sdk.Resubscribe([]string{"a", "b", "x"})
+	if !errors.Is(err, ErrTokenNotFound) || !strings.Contains(err.Error(), "x") {
+		t.Errorf("expected ErrTokenNotFound for x, got %v", err)
+	}
+	if sdk.IsInitialized("a") || sdk.IsInitialized("b") {
+		t.Error("resubscribed books should wait for new snapshots")
+	}
+
+	// Both tokens share a connection, so they are resubscribed in one request pair
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		got := append([]DynamicSubscribeRequest(nil), requests...)
+		mu.Unlock()
+		if n := len(got); n >= 3 {
+			unsub, sub := got[n-2], got[n-1]
+			if unsub.Operation != "unsubscribe" || sub.Operation != "subscribe" || strings.Join(sub.AssetsIDs, ",") != "a,b" {
+				t.Errorf("unexpected resubscribe requests %+v", got)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("resubscribe requests not received, got %+v", got)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	sdk.manager.handleBookMessage(bookFor("a"), time.Now())
+	sdk.manager.handleBookMessage(bookFor("b"), time.Now())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := sdk.WaitForSnapshots(ctx, []string{"a", "b"}); err != nil {
+		t.Errorf("WaitForSnapshots() after resubscribe: %v", err)
+	}
+
+	if err := sdk.Unsubscribe(nil); err == nil {
+		t.Error("expected error for an empty unsubscribe")
+	}
+}
@@ -0,0 +1,125 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// pyCredsKeys py-clob-client 凭证字典中各字段可能使用的键（ApiCreds 属性名、CLOB REST 返回的键与常见环境变量写法）
+var pyCredsKeys = struct {
+	apiKey, secret, passphrase []string
+}{
+	apiKey:     []string{"api_key", "apiKey", "key"},
+	secret:     []string{"api_secret", "secret"},
+	passphrase: []string{"api_passphrase", "passphrase", "pass_phrase"},
+}
+
+// PyClobParams py-clob-client 的 ClobClient 构造参数，字段与 Python 参数一一对应，便于从 Python 迁移机器人：
+//
+//	ClobClient(host, key=key, chain_id=137, creds=ApiCreds(...), signature_type=1, funder=funder)
+//
+// JSON 标签与 Python 参数名一致，Python 侧的配置字典可直接 json.Unmarshal 到该结构
+type PyClobParams struct {
+	Host          string            `json:"host"`           // CLOB 端点，如 https://clob.polymarket.com
+	Key           string            `json:"key"`            // 私钥（可带 0x 前缀），为空时只创建公共行情 SDK
+	ChainID       int               `json:"chain_id"`       // 137 或 80002（Amoy），0 表示 137
+	Creds         map[string]string `json:"creds"`          // ApiCreds：api_key / api_secret / api_passphrase
+	SignatureType int               `json:"signature_type"` // 0=EOA, 1=POLY_PROXY, 2=GNOSIS_SAFE
+	Funder        string            `json:"funder"`         // 代理钱包地址，签名类型非 EOA 时必填
+}
+
+// Config 转换为等价的 SDK 配置，并校验 Python 迁移中常见的配置错误
+func (p *PyClobParams) Config() (*Config, error) {
+	config := DefaultConfig()
+	switch p.ChainID {
+	case 0, ChainID:
+	case AmoyChainID:
+		config = AmoyConfig()
+	default:
+		return nil, fmt.Errorf("%w: unsupported chain_id %d, expected %d or %d", common.ErrInvalidConfig, p.ChainID, ChainID, AmoyChainID)
+	}
+
+	// Python 端常写成带尾部斜杠的地址，请求路径拼接后会出现双斜杠
+	if host := strings.TrimRight(strings.TrimSpace(p.Host), "/"); host != "" {
+		if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+			return nil, fmt.Errorf("%w: host %q must start with http:// or https://", common.ErrInvalidConfig, p.Host)
+		}
+		config.CLOBEndpoint = host
+	}
+	config.SignatureType = p.SignatureType
+	config.FunderAddress = strings.TrimSpace(p.Funder)
+
+	if err := config.validateAccount(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Credentials 转换 creds 字典，未提供凭证时返回 nil；只提供部分字段时返回错误
+func (p *PyClobParams) Credentials() (*auth.Credentials, error) {
+	if len(p.Creds) == 0 {
+		return nil, nil
+	}
+	creds := &auth.Credentials{
+		APIKey:     pyCredsValue(p.Creds, pyCredsKeys.apiKey),
+		Secret:     pyCredsValue(p.Creds, pyCredsKeys.secret),
+		Passphrase: pyCredsValue(p.Creds, pyCredsKeys.passphrase),
+	}
+	if err := auth.ValidateCredentials(creds); err != nil {
+		return nil, fmt.Errorf("%w: creds: %v", common.ErrInvalidConfig, err)
+	}
+	return creds, nil
+}
+
+// pyCredsValue 按候选键读取凭证字段
+func pyCredsValue(creds map[string]string, keys []string) string {
+	for _, key := range keys {
+		if v := strings.TrimSpace(creds[key]); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// NewSDKFromPyClob 按 py-clob-client 参数创建 SDK
+// 未提供 key 时与 Python 的 Level 0 客户端一样只能访问公共接口（返回 NewPublicSDK）；
+// 提供 creds 时直接设置 L2 凭证，否则需调用 CreateOrDeriveAPICredentials
+func NewSDKFromPyClob(params *PyClobParams) (*SDK, error) {
+	if params == nil {
+		return nil, fmt.Errorf("%w: params are required", common.ErrInvalidConfig)
+	}
+	config, err := params.Config()
+	if err != nil {
+		return nil, err
+	}
+	creds, err := params.Credentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Key == "" {
+		if creds != nil {
+			return nil, fmt.Errorf("%w: creds require key, L2 requests are signed with the wallet address", common.ErrInvalidConfig)
+		}
+		return NewPublicSDK(config), nil
+	}
+
+	// EOA 模式下资金在签名地址，funder 与签名地址不同说明漏设了 signature_type
+	if config.SignatureType == 0 && config.FunderAddress != "" {
+		signer, err := auth.NewL1Signer(params.Key, config.ChainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create L1 signer: %w", err)
+		}
+		if !strings.EqualFold(signer.GetAddress(), config.FunderAddress) {
+			return nil, fmt.Errorf("%w: funder %s differs from signer %s with EOA signature_type, set signature_type 1 (POLY_PROXY) or 2 (GNOSIS_SAFE)",
+				common.ErrInvalidConfig, config.FunderAddress, signer.GetAddress())
+		}
+	}
+
+	return NewTradingSDK(config, params.Key, creds)
+}
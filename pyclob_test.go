@@ -0,0 +1,85 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+func TestNewSDKFromPyClob(t *testing.T) {
+	// A py-clob-client config dict dumped as JSON
+	data := `{
+		"host": "https://clob.polymarket.com/",
+		"key": "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+		"chain_id": 137,
+		"creds": {"api_key": "key-1", "api_secret": "c2VjcmV0", "api_passphrase": "pass-1"},
+		"signature_type": 2,
+		"funder": "0x1234567890abcdef1234567890abcdef12345678"
+	}`
+	var params PyClobParams
+	if err := json.Unmarshal([]byte(data), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	sdk, err := NewSDKFromPyClob(&params)
+	if err != nil {
+		t.Fatalf("NewSDKFromPyClob() error: %v", err)
+	}
+	defer sdk.Close()
+
+	config := sdk.GetConfig()
+	if config.CLOBEndpoint != "https://clob.polymarket.com" || config.SignatureType != 2 || config.FunderAddress != params.Funder {
+		t.Errorf("unexpected config: %s %d %s", config.CLOBEndpoint, config.SignatureType, config.FunderAddress)
+	}
+	if creds := sdk.GetCredentials(); creds == nil || creds.APIKey != "key-1" || creds.Passphrase != "pass-1" {
+		t.Errorf("unexpected credentials: %+v", creds)
+	}
+	if !sdk.IsTradingEnabled() {
+		t.Error("trading should be enabled with a key")
+	}
+
+	// Level 0 client: host only
+	public, err := NewSDKFromPyClob(&PyClobParams{Host: "https://clob.polymarket.com", ChainID: AmoyChainID})
+	if err != nil {
+		t.Fatalf("public SDK error: %v", err)
+	}
+	defer public.Close()
+	if public.IsTradingEnabled() || public.GetConfig().ChainID != AmoyChainID {
+		t.Error("expected a public Amoy SDK")
+	}
+}
+
+func TestNewSDKFromPyClob_CatchesSetupMistakes(t *testing.T) {
+	key := "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	funder := "0x1234567890abcdef1234567890abcdef12345678"
+
+	tests := []struct {
+		name   string
+		params PyClobParams
+	}{
+		{"proxy without funder", PyClobParams{Key: key, SignatureType: 1}},
+		{"funder with EOA signature type", PyClobParams{Key: key, Funder: funder}},
+		{"partial creds", PyClobParams{Key: key, Creds: map[string]string{"api_key": "key-1"}}},
+		{"creds without key", PyClobParams{Creds: map[string]string{"api_key": "k", "api_secret": "s", "api_passphrase": "p"}}},
+		{"unknown chain", PyClobParams{Key: key, ChainID: 1}},
+		{"host without scheme", PyClobParams{Host: "clob.polymarket.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSDKFromPyClob(&tt.params); !errors.Is(err, common.ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig, got %v", err)
+			}
+		})
+	}
+
+	// A funder equal to the signer address is a valid EOA setup
+	sdk, err := NewSDKFromPyClob(&PyClobParams{Key: key, Funder: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"})
+	if err != nil {
+		t.Fatalf("EOA funder matching the signer: %v", err)
+	}
+	sdk.Close()
+}
@@ -0,0 +1,94 @@
+package polymarket
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// ConfigDelta 运行时可调整的参数，nil 字段保持不变
+// 端点、合约地址、连接池大小等需要重建客户端的参数不在此列
+type ConfigDelta struct {
+	// HTTP 重试（Gamma 与 CLOB）
+	MaxRetries   *int
+	RetryDelayMs *int
+
+	// CLOB 连接保活间隔，0 表示停止保活
+	KeepAliveInterval *time.Duration
+
+	// WebSocket（订单簿）
+	PingInterval         *int
+	PongTimeout          *int
+	ReconnectMinInterval *int
+	ReconnectMaxInterval *int
+	ReconnectMaxAttempts *int
+	MaxTimestampGapMs    *int
+}
+
+// ApplyConfig 在运行中调整参数，无需重连或重建客户端，适用于由运维接口控制的常驻进程
+// 先整体校验，任一字段不合法时返回 common.ErrInvalidConfig 且不做任何修改；成功后 GetConfig 反映新值
+func (s *SDK) ApplyConfig(delta *ConfigDelta) error {
+	if delta == nil {
+		return nil
+	}
+
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	maxRetries, retryDelayMs := s.config.MaxRetries, s.config.RetryDelayMs
+	if delta.MaxRetries != nil {
+		maxRetries = *delta.MaxRetries
+	}
+	if delta.RetryDelayMs != nil {
+		retryDelayMs = *delta.RetryDelayMs
+	}
+	if maxRetries < 0 || retryDelayMs < 0 {
+		return fmt.Errorf("%w: MaxRetries and RetryDelayMs must not be negative", common.ErrInvalidConfig)
+	}
+	if delta.KeepAliveInterval != nil && *delta.KeepAliveInterval < 0 {
+		return fmt.Errorf("%w: KeepAliveInterval must not be negative", common.ErrInvalidConfig)
+	}
+
+	// 订单簿参数的校验与应用是原子的，放在最前面，失败时其余参数保持不变
+	if s.OrderBook != nil {
+		update := &orderbook.ConfigUpdate{
+			PingInterval:         delta.PingInterval,
+			PongTimeout:          delta.PongTimeout,
+			ReconnectMinInterval: delta.ReconnectMinInterval,
+			ReconnectMaxInterval: delta.ReconnectMaxInterval,
+			ReconnectMaxAttempts: delta.ReconnectMaxAttempts,
+			MaxTimestampGapMs:    delta.MaxTimestampGapMs,
+		}
+		if err := s.OrderBook.ApplyConfig(update); err != nil {
+			return err
+		}
+	}
+
+	if delta.MaxRetries != nil || delta.RetryDelayMs != nil {
+		if s.Markets != nil {
+			s.Markets.SetRetryPolicy(maxRetries, retryDelayMs)
+		}
+		s.setTradingRetryPolicy(maxRetries, retryDelayMs)
+		s.config.MaxRetries, s.config.RetryDelayMs = maxRetries, retryDelayMs
+	}
+	if delta.KeepAliveInterval != nil {
+		s.setTradingKeepAlive(*delta.KeepAliveInterval)
+		s.config.KeepAliveInterval = *delta.KeepAliveInterval
+	}
+
+	assign := func(dst *int, src *int) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	assign(&s.config.PingInterval, delta.PingInterval)
+	assign(&s.config.PongTimeout, delta.PongTimeout)
+	assign(&s.config.ReconnectMinInterval, delta.ReconnectMinInterval)
+	assign(&s.config.ReconnectMaxInterval, delta.ReconnectMaxInterval)
+	assign(&s.config.ReconnectMaxAttempts, delta.ReconnectMaxAttempts)
+	assign(&s.config.MaxTimestampGapMs, delta.MaxTimestampGapMs)
+
+	return nil
+}
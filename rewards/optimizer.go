@@ -0,0 +1,361 @@
+// Package rewards 做市流动性奖励优化
+//
+// 按 Polymarket 流动性奖励规则给挂单计分：距调整中间价 s 的挂单得分为 ((v-s)/v)^2 * size，v 为最大计分价差；
+// 中间价在 [0.10, 0.90] 内时单边挂单按 1/3 计分，区间外必须双边挂单才计分。
+// Optimizer 根据实时订单簿估算其他做市商的得分，在给定资金下选择单位资金奖励最高的挂单价位与数量。
+package rewards
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// 奖励规则常量
+const (
+	singleSidedDivisor = 3.0  // 单边挂单得分折算系数
+	twoSidedLow        = 0.10 // 允许单边计分的中间价下限
+	twoSidedHigh       = 0.90 // 允许单边计分的中间价上限
+)
+
+// Params 奖励周期参数
+type Params struct {
+	MaxSpread decimal.Decimal // 距中间价的最大计分距离（价格单位，如 0.03）
+	MinSize   decimal.Decimal // 计分的最小挂单数量（份额）
+	DailyRate decimal.Decimal // 该市场每日奖励总额（USDC），用于估算收益
+	TickSize  decimal.Decimal // 最小价格变动单位，为 0 时使用 0.01
+}
+
+// ParamsFromMarket 从 CLOB 市场信息读取奖励参数，max_spread 由美分换算为价格
+func ParamsFromMarket(market *clob.Market) *Params {
+	params := &Params{
+		MaxSpread: market.Rewards.MaxSpread.Div(decimal.NewFromInt(100)),
+		MinSize:   market.Rewards.MinSize,
+		TickSize:  market.MinimumTickSize,
+	}
+	for _, rate := range market.Rewards.Rates {
+		params.DailyRate = params.DailyRate.Add(rate.RewardsDailyRate)
+	}
+	return params
+}
+
+// Config 优化器配置
+type Config struct {
+	Budget        decimal.Decimal // 可承担风险的资金（USDC）
+	LevelsPerSide int             // 每边最多挂单档数
+	MinDistance   decimal.Decimal // 距中间价的最小距离，避开最容易被吃的价位
+	DepthLevels   int             // 读取订单簿的档数
+}
+
+// DefaultConfig 默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Budget:        decimal.NewFromInt(1000),
+		LevelsPerSide: 3,
+		DepthLevels:   50,
+	}
+}
+
+// Quote 建议挂单
+type Quote struct {
+	Side    clob.OrderSide
+	Price   decimal.Decimal
+	Size    decimal.Decimal
+	Score   float64         // 该挂单的奖励得分
+	Capital decimal.Decimal // 占用资金：BUY 为 price*size，SELL 为 (1-price)*size
+}
+
+// Plan 优化结果
+type Plan struct {
+	TokenID             string
+	Midpoint            decimal.Decimal
+	Quotes              []Quote         // 先 BUY 后 SELL，各自按距中间价由近到远
+	Score               float64         // 我方得分（已应用单边/双边规则）
+	CompetingScore      float64         // 订单簿上其他挂单的得分
+	Capital             decimal.Decimal // 占用资金合计
+	ExpectedDailyReward float64         // 按当前得分占比估算的每日奖励（USDC）
+	RewardPerDollar     float64         // 每日奖励 / 占用资金
+}
+
+// Orders 转换为可直接通过 CreateOrders 提交的 post-only GTC 订单
+// SELL 挂单需要持有对应 token
+func (p *Plan) Orders() []*clob.CreateOrderRequest {
+	orders := make([]*clob.CreateOrderRequest, 0, len(p.Quotes))
+	for _, q := range p.Quotes {
+		orders = append(orders, quoteOrder(p.TokenID, q.Side, q.Price, q.Size))
+	}
+	return orders
+}
+
+// ownExcluder 可剔除自身挂单的订单簿（orderbook.SDK 实现）
+type ownExcluder interface {
+	GetDepthExcludingOwn(tokenID string, depth int) (bids []orderbook.OrderSummary, asks []orderbook.OrderSummary, err error)
+}
+
+// Optimizer 流动性奖励挂单优化器
+type Optimizer struct {
+	books  orderbook.BookReader
+	config *Config
+}
+
+// NewOptimizer 创建优化器
+// books 实现 GetDepthExcludingOwn（如 orderbook.SDK）时，竞争得分按剔除自身挂单后的订单簿计算
+func NewOptimizer(books orderbook.BookReader, config *Config) *Optimizer {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if config.LevelsPerSide <= 0 {
+		config.LevelsPerSide = 1
+	}
+	if config.DepthLevels <= 0 {
+		config.DepthLevels = 50
+	}
+	return &Optimizer{books: books, config: config}
+}
+
+// candidate 候选挂单价位
+type candidate struct {
+	price      decimal.Decimal
+	score      float64 // 单位份额得分
+	cost       float64 // 单位份额占用资金
+	efficiency float64 // score / cost
+}
+
+// Optimize 根据当前订单簿生成挂单建议
+func (o *Optimizer) Optimize(tokenID string, params *Params) (*Plan, error) {
+	if params == nil || !params.MaxSpread.IsPositive() {
+		return nil, fmt.Errorf("%w: max spread must be positive", common.ErrInvalidConfig)
+	}
+	if !o.config.Budget.IsPositive() {
+		return nil, fmt.Errorf("%w: budget must be positive", common.ErrInvalidConfig)
+	}
+	tick := params.TickSize
+	if !tick.IsPositive() {
+		tick = decimal.NewFromFloat(0.01)
+	}
+
+	bids, asks, err := o.depth(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	bestBid, bestAsk, ok := adjustedTop(bids, asks, params.MinSize)
+	if !ok {
+		return nil, fmt.Errorf("order book for %s is not two-sided", tokenID)
+	}
+	mid := bestBid.Add(bestAsk).Div(decimal.NewFromInt(2))
+	midF := mid.InexactFloat64()
+	maxSpread := params.MaxSpread.InexactFloat64()
+
+	plan := &Plan{TokenID: tokenID, Midpoint: mid}
+
+	// 其他挂单的得分
+	var otherBid, otherAsk float64
+	for _, level := range bids {
+		otherBid += spreadScore(midF-level.Price.InexactFloat64(), maxSpread) * level.Size.InexactFloat64()
+	}
+	for _, level := range asks {
+		otherAsk += spreadScore(level.Price.InexactFloat64()-midF, maxSpread) * level.Size.InexactFloat64()
+	}
+	plan.CompetingScore = qMin(otherBid, otherAsk, midF)
+
+	bidCands := o.candidates(clob.OrderSideBuy, mid, bestAsk, tick, maxSpread)
+	askCands := o.candidates(clob.OrderSideSell, mid, bestBid, tick, maxSpread)
+	bidEff, askEff := sideEfficiency(bidCands), sideEfficiency(askCands)
+
+	// 双边按得分相等分配资金；区间内单边得分更高时只挂单边
+	budget := o.config.Budget
+	var bidBudget, askBudget decimal.Decimal
+	if bidEff > 0 && askEff > 0 {
+		bidBudget = budget.Mul(decimal.NewFromFloat(askEff / (bidEff + askEff)))
+		askBudget = budget.Sub(bidBudget)
+	}
+	if midF >= twoSidedLow && midF <= twoSidedHigh {
+		twoSided := 0.0
+		if bidEff > 0 && askEff > 0 {
+			twoSided = bidEff * askEff / (bidEff + askEff)
+		}
+		if math.Max(bidEff, askEff)/singleSidedDivisor > twoSided {
+			bidBudget, askBudget = decimal.Zero, decimal.Zero
+			if bidEff >= askEff {
+				bidBudget = budget
+			} else {
+				askBudget = budget
+			}
+		}
+	}
+
+	bidQuotes := allocate(clob.OrderSideBuy, bidCands, bidBudget, params.MinSize)
+	askQuotes := allocate(clob.OrderSideSell, askCands, askBudget, params.MinSize)
+	plan.Quotes = append(bidQuotes, askQuotes...)
+
+	var ourBid, ourAsk float64
+	for _, q := range plan.Quotes {
+		if q.Side == clob.OrderSideBuy {
+			ourBid += q.Score
+		} else {
+			ourAsk += q.Score
+		}
+		plan.Capital = plan.Capital.Add(q.Capital)
+	}
+	plan.Score = qMin(ourBid, ourAsk, midF)
+
+	if total := plan.Score + plan.CompetingScore; plan.Score > 0 && total > 0 {
+		plan.ExpectedDailyReward = params.DailyRate.InexactFloat64() * plan.Score / total
+		plan.RewardPerDollar = plan.ExpectedDailyReward / plan.Capital.InexactFloat64()
+	}
+	return plan, nil
+}
+
+// depth 读取订单簿，优先剔除自身挂单
+func (o *Optimizer) depth(tokenID string) ([]orderbook.OrderSummary, []orderbook.OrderSummary, error) {
+	if excluder, ok := o.books.(ownExcluder); ok {
+		return excluder.GetDepthExcludingOwn(tokenID, o.config.DepthLevels)
+	}
+	return o.books.GetDepth(tokenID, o.config.DepthLevels)
+}
+
+// candidates 生成一侧可计分且不会立即成交的价位（不含中间价本身），按单位资金得分降序
+func (o *Optimizer) candidates(side clob.OrderSide, mid, opposite, tick decimal.Decimal, maxSpread float64) []candidate {
+	one := decimal.NewFromInt(1)
+	var price, step decimal.Decimal
+	if side == clob.OrderSideBuy {
+		price = mid.Sub(o.config.MinDistance).Div(tick).Floor().Mul(tick)
+		step = tick.Neg()
+	} else {
+		price = mid.Add(o.config.MinDistance).Div(tick).Ceil().Mul(tick)
+		step = tick
+	}
+
+	var cands []candidate
+	for price.IsPositive() && price.LessThan(one) {
+		distance := mid.Sub(price).Abs().InexactFloat64()
+		score := spreadScore(distance, maxSpread)
+		if score == 0 {
+			break
+		}
+		// 中间价本身不挂单，避免自己的买卖单互相成交；post-only：BUY 低于最优卖价，SELL 高于最优买价
+		if distance == 0 {
+			price = price.Add(step)
+			continue
+		}
+		if (side == clob.OrderSideBuy && price.LessThan(opposite)) || (side == clob.OrderSideSell && price.GreaterThan(opposite)) {
+			cost := price.InexactFloat64()
+			if side == clob.OrderSideSell {
+				cost = 1 - cost
+			}
+			cands = append(cands, candidate{price: price, score: score, cost: cost, efficiency: score / cost})
+		}
+		price = price.Add(step)
+	}
+
+	sort.SliceStable(cands, func(i, j int) bool {
+		return cands[i].efficiency > cands[j].efficiency
+	})
+	if len(cands) > o.config.LevelsPerSide {
+		cands = cands[:o.config.LevelsPerSide]
+	}
+	return cands
+}
+
+// sideEfficiency 按得分加权分配资金时一侧的单位资金得分
+func sideEfficiency(cands []candidate) float64 {
+	var weighted, weights float64
+	for _, c := range cands {
+		weighted += c.score * c.efficiency
+		weights += c.score
+	}
+	if weights == 0 {
+		return 0
+	}
+	return weighted / weights
+}
+
+// allocate 按得分加权把资金分配到各价位，不足最小计分数量的价位从效率最低的开始剔除
+func allocate(side clob.OrderSide, cands []candidate, budget, minSize decimal.Decimal) []Quote {
+	if !budget.IsPositive() {
+		return nil
+	}
+
+	for n := len(cands); n > 0; n-- {
+		chosen := cands[:n]
+		var weights float64
+		for _, c := range chosen {
+			weights += c.score
+		}
+
+		quotes := make([]Quote, 0, n)
+		for _, c := range chosen {
+			dollars := budget.InexactFloat64() * c.score / weights
+			size := decimal.NewFromFloat(dollars / c.cost).RoundDown(2)
+			if size.LessThan(minSize) || !size.IsPositive() {
+				break
+			}
+			capital := c.price.Mul(size)
+			if side == clob.OrderSideSell {
+				capital = decimal.NewFromInt(1).Sub(c.price).Mul(size)
+			}
+			quotes = append(quotes, Quote{
+				Side:    side,
+				Price:   c.price,
+				Size:    size,
+				Score:   c.score * size.InexactFloat64(),
+				Capital: capital,
+			})
+		}
+		if len(quotes) == n {
+			sort.Slice(quotes, func(i, j int) bool {
+				if side == clob.OrderSideBuy {
+					return quotes[i].Price.GreaterThan(quotes[j].Price)
+				}
+				return quotes[i].Price.LessThan(quotes[j].Price)
+			})
+			return quotes
+		}
+	}
+	return nil
+}
+
+// adjustedTop 忽略小于最小计分数量的档位后的最优买卖价，没有达标档位时退回原始最优价
+func adjustedTop(bids, asks []orderbook.OrderSummary, minSize decimal.Decimal) (bid, ask decimal.Decimal, ok bool) {
+	if len(bids) == 0 || len(asks) == 0 {
+		return decimal.Zero, decimal.Zero, false
+	}
+	bid, ask = bids[0].Price, asks[0].Price
+	for _, level := range bids {
+		if level.Size.GreaterThanOrEqual(minSize) {
+			bid = level.Price
+			break
+		}
+	}
+	for _, level := range asks {
+		if level.Size.GreaterThanOrEqual(minSize) {
+			ask = level.Price
+			break
+		}
+	}
+	return bid, ask, true
+}
+
+// spreadScore 距中间价 distance 的单位份额得分 ((v-s)/v)^2，超出最大价差时为 0
+func spreadScore(distance, maxSpread float64) float64 {
+	if distance < 0 || distance >= maxSpread {
+		return 0
+	}
+	r := (maxSpread - distance) / maxSpread
+	return r * r
+}
+
+// qMin 按单边/双边规则合并两侧得分
+func qMin(bid, ask, mid float64) float64 {
+	lo := math.Min(bid, ask)
+	if mid >= twoSidedLow && mid <= twoSidedHigh {
+		return math.Max(lo, math.Max(bid, ask)/singleSidedDivisor)
+	}
+	return lo
+}
@@ -0,0 +1,156 @@
+package rewards
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+type fakeBooks struct {
+	bids, asks []orderbook.OrderSummary
+}
+
+func (f *fakeBooks) GetBBO(tokenID string) (*orderbook.BBO, error) {
+	return nil, orderbook.ErrNotInitialized
+}
+
+func (f *fakeBooks) GetDepth(tokenID string, depth int) ([]orderbook.OrderSummary, []orderbook.OrderSummary, error) {
+	return f.bids, f.asks, nil
+}
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+func levels(pairs ...string) []orderbook.OrderSummary {
+	out := make([]orderbook.OrderSummary, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		out = append(out, orderbook.OrderSummary{Price: d(pairs[i]), Size: d(pairs[i+1])})
+	}
+	return out
+}
+
+func testParams() *Params {
+	return &Params{MaxSpread: d("0.03"), MinSize: d("20"), DailyRate: d("100"), TickSize: d("0.01")}
+}
+
+func TestOptimizer_TwoSidedLadder(t *testing.T) {
+	books := &fakeBooks{bids: levels("0.48", "100", "0.47", "200"), asks: levels("0.52", "100", "0.53", "200")}
+	config := DefaultConfig()
+	config.Budget = d("100")
+	config.LevelsPerSide = 2
+
+	plan, err := NewOptimizer(books, config).Optimize("token", testParams())
+	if err != nil {
+		t.Fatalf("Optimize() error: %v", err)
+	}
+	if !plan.Midpoint.Equal(d("0.5")) {
+		t.Errorf("Midpoint = %s", plan.Midpoint)
+	}
+
+	want := []struct {
+		side  clob.OrderSide
+		price string
+		size  string
+	}{
+		{clob.OrderSideBuy, "0.49", "81.63"},
+		{clob.OrderSideBuy, "0.48", "20.83"},
+		{clob.OrderSideSell, "0.51", "81.63"},
+		{clob.OrderSideSell, "0.52", "20.83"},
+	}
+	if len(plan.Quotes) != len(want) {
+		t.Fatalf("got %d quotes: %+v", len(plan.Quotes), plan.Quotes)
+	}
+	for i, w := range want {
+		q := plan.Quotes[i]
+		if q.Side != w.side || !q.Price.Equal(d(w.price)) || !q.Size.Equal(d(w.size)) {
+			t.Errorf("quote[%d] = %s %s@%s, expected %s %s@%s", i, q.Side, q.Size, q.Price, w.side, w.size, w.price)
+		}
+	}
+
+	// Competing score: 100 shares two cents from mid on each side -> (1/3)^2 * 100
+	if plan.CompetingScore < 11.1 || plan.CompetingScore > 11.2 {
+		t.Errorf("CompetingScore = %f", plan.CompetingScore)
+	}
+	if plan.ExpectedDailyReward <= 70 || plan.ExpectedDailyReward >= 100 {
+		t.Errorf("ExpectedDailyReward = %f", plan.ExpectedDailyReward)
+	}
+	if plan.Capital.GreaterThan(config.Budget) || plan.RewardPerDollar <= 0 {
+		t.Errorf("Capital = %s, RewardPerDollar = %f", plan.Capital, plan.RewardPerDollar)
+	}
+
+	orders := plan.Orders()
+	if len(orders) != 4 || !orders[0].PostOnly || orders[0].Type != clob.OrderTypeGTC || orders[0].TokenID != "token" {
+		t.Errorf("Orders() = %+v", orders[0])
+	}
+}
+
+func TestOptimizer_DropsLevelsBelowMinSize(t *testing.T) {
+	books := &fakeBooks{bids: levels("0.48", "100"), asks: levels("0.52", "100")}
+	config := DefaultConfig()
+	config.Budget = d("20")
+	config.LevelsPerSide = 2
+
+	plan, err := NewOptimizer(books, config).Optimize("token", testParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 10 USDC per side only reaches min size at a single level
+	if len(plan.Quotes) != 2 || !plan.Quotes[0].Price.Equal(d("0.49")) || !plan.Quotes[1].Price.Equal(d("0.51")) {
+		t.Errorf("quotes = %+v", plan.Quotes)
+	}
+	for _, q := range plan.Quotes {
+		if q.Size.LessThan(d("20")) {
+			t.Errorf("quote size %s below min size", q.Size)
+		}
+	}
+}
+
+func TestOptimizer_MinDistanceAndDustMidpoint(t *testing.T) {
+	// A dust bid at 0.50 is ignored when computing the adjusted midpoint
+	books := &fakeBooks{bids: levels("0.50", "1", "0.48", "100"), asks: levels("0.52", "100")}
+	config := DefaultConfig()
+	config.MinDistance = d("0.02")
+
+	plan, err := NewOptimizer(books, config).Optimize("token", testParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Midpoint.Equal(d("0.5")) {
+		t.Errorf("Midpoint = %s, expected 0.5", plan.Midpoint)
+	}
+	for _, q := range plan.Quotes {
+		if q.Price.Sub(plan.Midpoint).Abs().LessThan(d("0.02")) {
+			t.Errorf("quote %s within min distance", q.Price)
+		}
+	}
+}
+
+func TestOptimizer_Errors(t *testing.T) {
+	books := &fakeBooks{bids: levels("0.48", "100")}
+	opt := NewOptimizer(books, nil)
+
+	if _, err := opt.Optimize("token", &Params{}); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+	if _, err := opt.Optimize("token", testParams()); err == nil {
+		t.Error("expected error for one-sided book")
+	}
+}
+
+func TestParamsFromMarket(t *testing.T) {
+	var market clob.Market
+	data := `{"minimum_tick_size":0.001,"rewards":{"rates":[{"asset_address":"0x1","rewards_daily_rate":25},{"asset_address":"0x2","rewards_daily_rate":5}],"min_size":50,"max_spread":3.5}}`
+	if err := json.Unmarshal([]byte(data), &market); err != nil {
+		t.Fatal(err)
+	}
+
+	params := ParamsFromMarket(&market)
+	if !params.MaxSpread.Equal(d("0.035")) || !params.MinSize.Equal(d("50")) || !params.DailyRate.Equal(d("30")) || !params.TickSize.Equal(d("0.001")) {
+		t.Errorf("params = %+v", params)
+	}
+}
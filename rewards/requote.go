@@ -0,0 +1,111 @@
+package rewards
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+)
+
+// RequoteConfig 重新报价配置
+type RequoteConfig struct {
+	// SizeTolerance 挂单剩余数量与目标数量的偏差不超过目标的该比例时保留原挂单（如 0.1 表示 ±10%）
+	SizeTolerance decimal.Decimal
+
+	// PreservePriority 为 true 时，部分成交的挂单只要价位仍在计划中就保留以维持排队优先级，
+	// 剩余数量不足的部分在同价位另挂新单补足；为 false 时按普通挂单处理，偏差超出容忍度即撤单重挂
+	PreservePriority bool
+}
+
+// DefaultRequoteConfig 默认配置
+func DefaultRequoteConfig() *RequoteConfig {
+	return &RequoteConfig{
+		SizeTolerance:    decimal.NewFromFloat(0.1),
+		PreservePriority: true,
+	}
+}
+
+// RequoteActions 将当前挂单调整为计划挂单所需的操作
+type RequoteActions struct {
+	Keep   []clob.Order               // 保留的挂单
+	Cancel []string                   // 需要撤销的订单 ID
+	Create []*clob.CreateOrderRequest // 需要新挂的 post-only GTC 订单
+}
+
+// Requote 比较当前挂单与计划挂单，生成撤单与新挂单操作
+// 同价位的挂单按创建时间由早到晚依次匹配计划数量：数量在容忍度内的保留，超出计划数量的撤单重挂；
+// 只处理 plan.TokenID 的未完结挂单，其他 token 的挂单原样忽略
+func Requote(open []clob.Order, plan *Plan, config *RequoteConfig) *RequoteActions {
+	if config == nil {
+		config = DefaultRequoteConfig()
+	}
+
+	orders := make([]clob.Order, 0, len(open))
+	for _, o := range open {
+		if o.AssetID == plan.TokenID && o.GetRemainingSize().IsPositive() {
+			orders = append(orders, o)
+		}
+	}
+	// 越早的挂单排队越靠前，优先保留
+	sort.SliceStable(orders, func(i, j int) bool { return orders[i].CreatedAt < orders[j].CreatedAt })
+
+	need := make([]decimal.Decimal, len(plan.Quotes))
+	for i, q := range plan.Quotes {
+		need[i] = q.Size
+	}
+
+	actions := &RequoteActions{}
+	for _, o := range orders {
+		i := quoteIndex(plan.Quotes, o.Side, o.Price)
+		if i < 0 || !need[i].IsPositive() {
+			actions.Cancel = append(actions.Cancel, o.ID)
+			continue
+		}
+
+		remaining := o.GetRemainingSize()
+		tolerance := plan.Quotes[i].Size.Mul(config.SizeTolerance)
+		switch {
+		case remaining.GreaterThan(need[i].Add(tolerance)):
+			// 挂单数量超出计划，无法减量只能撤单重挂
+			actions.Cancel = append(actions.Cancel, o.ID)
+		case remaining.GreaterThanOrEqual(need[i].Sub(tolerance)):
+			actions.Keep = append(actions.Keep, o)
+			need[i] = decimal.Zero
+		case config.PreservePriority && o.SizeMatched.IsPositive():
+			actions.Keep = append(actions.Keep, o)
+			need[i] = need[i].Sub(remaining)
+		default:
+			actions.Cancel = append(actions.Cancel, o.ID)
+		}
+	}
+
+	for i, q := range plan.Quotes {
+		if need[i].IsPositive() {
+			actions.Create = append(actions.Create, quoteOrder(plan.TokenID, q.Side, q.Price, need[i]))
+		}
+	}
+	return actions
+}
+
+// quoteIndex 计划中与挂单方向、价格相同的报价下标，不存在时返回 -1
+func quoteIndex(quotes []Quote, side clob.OrderSide, price decimal.Decimal) int {
+	for i, q := range quotes {
+		if q.Side == side && q.Price.Equal(price) {
+			return i
+		}
+	}
+	return -1
+}
+
+// quoteOrder 构造 post-only GTC 挂单
+func quoteOrder(tokenID string, side clob.OrderSide, price, size decimal.Decimal) *clob.CreateOrderRequest {
+	return &clob.CreateOrderRequest{
+		TokenID:  tokenID,
+		Side:     side,
+		Price:    price,
+		Size:     size,
+		Type:     clob.OrderTypeGTC,
+		PostOnly: true,
+	}
+}
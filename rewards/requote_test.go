@@ -0,0 +1,84 @@
+package rewards
+
+import (
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+)
+
+func requotePlan() *Plan {
+	return &Plan{
+		TokenID: "token",
+		Quotes: []Quote{
+			{Side: clob.OrderSideBuy, Price: d("0.49"), Size: d("100")},
+			{Side: clob.OrderSideSell, Price: d("0.51"), Size: d("100")},
+		},
+	}
+}
+
+func openOrder(id string, side clob.OrderSide, price, original, matched string, created int64) clob.Order {
+	return clob.Order{
+		ID:           id,
+		AssetID:      "token",
+		Side:         side,
+		Price:        d(price),
+		OriginalSize: d(original),
+		SizeMatched:  d(matched),
+		CreatedAt:    clob.Timestamp(created),
+	}
+}
+
+func TestRequote_PreservesPartialFills(t *testing.T) {
+	open := []clob.Order{
+		openOrder("partial", clob.OrderSideBuy, "0.49", "100", "60", 1), // 40 left at a desired level
+		openOrder("stale", clob.OrderSideBuy, "0.48", "100", "0", 2),    // level no longer desired
+		openOrder("close", clob.OrderSideSell, "0.51", "95", "0", 3),    // within 10% tolerance
+		openOrder("other", clob.OrderSideBuy, "0.30", "10", "0", 4),
+	}
+	open[3].AssetID = "other-token"
+
+	actions := Requote(open, requotePlan(), nil)
+	if len(actions.Keep) != 2 || actions.Keep[0].ID != "partial" || actions.Keep[1].ID != "close" {
+		t.Errorf("Keep = %+v", actions.Keep)
+	}
+	if len(actions.Cancel) != 1 || actions.Cancel[0] != "stale" {
+		t.Errorf("Cancel = %v", actions.Cancel)
+	}
+	// The partial fill keeps its queue position; the missing 60 are topped up
+	if len(actions.Create) != 1 || !actions.Create[0].Price.Equal(d("0.49")) || !actions.Create[0].Size.Equal(d("60")) || !actions.Create[0].PostOnly {
+		t.Fatalf("Create = %+v", actions.Create)
+	}
+}
+
+func TestRequote_WithoutPriorityPreservation(t *testing.T) {
+	open := []clob.Order{openOrder("partial", clob.OrderSideBuy, "0.49", "100", "60", 1)}
+	config := DefaultRequoteConfig()
+	config.PreservePriority = false
+
+	actions := Requote(open, requotePlan(), config)
+	if len(actions.Keep) != 0 || len(actions.Cancel) != 1 || actions.Cancel[0] != "partial" {
+		t.Errorf("expected the partial fill to be replaced, got %+v", actions)
+	}
+	if len(actions.Create) != 2 || !actions.Create[0].Size.Equal(d("100")) {
+		t.Errorf("Create = %+v", actions.Create)
+	}
+}
+
+func TestRequote_DuplicatesAndOversized(t *testing.T) {
+	open := []clob.Order{
+		openOrder("newer", clob.OrderSideBuy, "0.49", "100", "0", 5),
+		openOrder("older", clob.OrderSideBuy, "0.49", "100", "0", 1),
+		openOrder("big", clob.OrderSideSell, "0.51", "200", "50", 2), // 150 left, above plan even though partially filled
+	}
+
+	actions := Requote(open, requotePlan(), nil)
+	if len(actions.Keep) != 1 || actions.Keep[0].ID != "older" {
+		t.Errorf("expected the oldest duplicate to be kept, got %+v", actions.Keep)
+	}
+	if len(actions.Cancel) != 2 || actions.Cancel[0] != "big" || actions.Cancel[1] != "newer" {
+		t.Errorf("Cancel = %v", actions.Cancel)
+	}
+	if len(actions.Create) != 1 || actions.Create[0].Side != clob.OrderSideSell || !actions.Create[0].Size.Equal(d("100")) {
+		t.Errorf("Create = %+v", actions.Create)
+	}
+}
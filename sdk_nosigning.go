@@ -0,0 +1,47 @@
+//go:build nosigning
+
+package polymarket
+
+import "time"
+
+// 以 nosigning 构建标签编译时，根包只包含行情部分（订单簿、Gamma 与 Data API），
+// 不依赖 auth、clob 与 go-ethereum；交易相关的 API（NewSDK、Trading、Session 等）不可用
+
+// defaultEventLogSize 交易事件日志默认容量，与 clob.DefaultEventLogSize 相同
+const defaultEventLogSize = 1000
+
+// tradingComponents 无交易部分
+type tradingComponents struct{}
+
+// TradingOptions 交易配置，nosigning 构建中为空
+type TradingOptions struct{}
+
+// validate 无交易配置需要校验
+func (o *TradingOptions) validate() error {
+	return nil
+}
+
+// applyAmoyContracts 合约地址只用于签名，nosigning 构建中保持不变
+func applyAmoyContracts(config *Config) {}
+
+// tradingOptions 忽略会话风控与下单限流变量
+func (r *envReader) tradingOptions(options *TradingOptions) {}
+
+// GetAddress 获取钱包地址，nosigning 构建中始终为空
+func (s *SDK) GetAddress() string {
+	return ""
+}
+
+// IsTradingEnabled 是否启用交易功能，nosigning 构建中始终为 false
+func (s *SDK) IsTradingEnabled() bool {
+	return false
+}
+
+// closeTrading 无交易客户端需要关闭
+func (s *SDK) closeTrading() {}
+
+// setTradingRetryPolicy 无交易客户端，忽略
+func (s *SDK) setTradingRetryPolicy(maxRetries, retryDelayMs int) {}
+
+// setTradingKeepAlive 无交易客户端，忽略
+func (s *SDK) setTradingKeepAlive(interval time.Duration) {}
@@ -1,10 +1,18 @@
+//go:build !nosigning
+
 package polymarket
 
 import (
+	"context"
 	"encoding/base64"
+	"errors"
 	"testing"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
 )
 
 // 测试用私钥（请勿在生产环境使用）
@@ -257,6 +265,89 @@ func TestSDKCreateOrDeriveAPICredentialsWithoutTrading(t *testing.T) {
 	}
 }
 
+func TestSDKNewUserStream(t *testing.T) {
+	public := NewPublicSDK(nil)
+	defer public.Close()
+	if _, err := public.NewUserStream(nil); err == nil {
+		t.Error("NewUserStream() should fail for public SDK")
+	}
+
+	sdk, err := NewTradingSDK(nil, sdkTestPrivateKey, nil)
+	if err != nil {
+		t.Fatalf("NewTradingSDK() error: %v", err)
+	}
+	defer sdk.Close()
+	if _, err := sdk.NewUserStream(nil); !errors.Is(err, common.ErrCredentialsNotFound) {
+		t.Errorf("NewUserStream() without credentials: err = %v", err)
+	}
+
+	sdk.SetCredentials(&auth.Credentials{
+		APIKey:     "test-api-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("test-secret")),
+		Passphrase: "test-passphrase",
+	})
+	stream, err := sdk.NewUserStream(nil)
+	if err != nil {
+		t.Fatalf("NewUserStream() error: %v", err)
+	}
+	stream.Close()
+}
+
+func TestSDKBookLevels(t *testing.T) {
+	sdk, err := NewSDK(nil, sdkTestPrivateKey)
+	if err != nil {
+		t.Fatalf("NewSDK() error: %v", err)
+	}
+	defer sdk.Close()
+
+	// tokens without a local book are reported as not ready
+	if _, ok := sdk.bookLevels("unknown", clob.OrderSideBuy); ok {
+		t.Error("bookLevels() should not be ready for an unsubscribed token")
+	}
+	_, err = sdk.Trading.CreateMarketOrderFromBook(context.Background(), &clob.BookMarketOrderRequest{
+		TokenID: "unknown",
+		Side:    clob.OrderSideBuy,
+		Amount:  decimal.NewFromInt(10),
+	})
+	if !errors.Is(err, common.ErrNotInitialized) {
+		t.Errorf("CreateMarketOrderFromBook() without a book: err = %v", err)
+	}
+}
+
+func TestSDKNewTreasury(t *testing.T) {
+	public := NewPublicSDK(nil)
+	defer public.Close()
+	if _, err := public.NewTreasury(nil); err == nil {
+		t.Error("NewTreasury() should fail for public SDK")
+	}
+
+	sdk, err := NewSDK(nil, sdkTestPrivateKey)
+	if err != nil {
+		t.Fatalf("NewSDK() error: %v", err)
+	}
+	defer sdk.Close()
+	if _, err := sdk.NewTreasury(nil); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("NewTreasury() without RPC endpoint: err = %v", err)
+	}
+
+	// The RPC endpoint falls back to the SDK config
+	sdk.GetConfig().RPCEndpoint = "https://polygon-rpc.example.com"
+	if _, err := sdk.NewTreasury(&clob.TreasuryConfig{MinTipGwei: 50}); err != nil {
+		t.Errorf("NewTreasury() error: %v", err)
+	}
+}
+
+func TestSDKGetMyPositions(t *testing.T) {
+	public := NewPublicSDK(nil)
+	defer public.Close()
+	if public.Data == nil {
+		t.Fatal("Data should be initialized for public SDK")
+	}
+	if _, err := public.GetMyPositions(context.Background(), nil); err == nil {
+		t.Error("GetMyPositions() should fail for public SDK")
+	}
+}
+
 func TestSDKComponentsIndependence(t *testing.T) {
 	sdk, _ := NewSDK(nil, sdkTestPrivateKey)
 	defer sdk.Close()
@@ -283,6 +374,9 @@ func TestSDKConfigAppliedToComponents(t *testing.T) {
 	if sdk.Trading.GetConfig().Endpoint != "https://custom-clob.example.com" {
 		t.Error("Trading should use custom CLOBEndpoint")
 	}
+	if sdk.Data.GetConfig().Endpoint != DataEndpoint {
+		t.Error("Data should default to DataEndpoint")
+	}
 }
 
 func TestSDKMultipleClose(t *testing.T) {
@@ -311,3 +405,40 @@ func TestSDKNilComponents(t *testing.T) {
 		// This is expected to be false
 	}
 }
+
+func TestSDKNewCancelWatchdog(t *testing.T) {
+	sdk := NewPublicSDK(nil)
+	if _, err := sdk.NewCancelWatchdog(nil); err == nil {
+		t.Error("Expected error for public SDK without trading client")
+	}
+}
+
+func TestSDKApplyConfig(t *testing.T) {
+	sdk, err := NewSDK(nil, sdkTestPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sdk.Close()
+
+	retries, ping := 5, 20
+	if err := sdk.ApplyConfig(&ConfigDelta{MaxRetries: &retries, PingInterval: &ping}); err != nil {
+		t.Fatalf("ApplyConfig() error: %v", err)
+	}
+	config := sdk.GetConfig()
+	if config.MaxRetries != 5 || config.PingInterval != 20 || sdk.Trading.GetConfig().MaxRetries != 5 {
+		t.Errorf("config not updated: retries=%d ping=%d", config.MaxRetries, config.PingInterval)
+	}
+
+	// Invalid values leave everything unchanged
+	badRetries, badPing := -1, 0
+	if err := sdk.ApplyConfig(&ConfigDelta{MaxRetries: &badRetries}); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+	retries = 1
+	if err := sdk.ApplyConfig(&ConfigDelta{MaxRetries: &retries, PingInterval: &badPing}); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+	if config.MaxRetries != 5 || config.PingInterval != 20 {
+		t.Errorf("config changed by rejected delta: retries=%d ping=%d", config.MaxRetries, config.PingInterval)
+	}
+}
@@ -0,0 +1,323 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/data"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+	"github.com/binary-jerry/polymarket-sdk/userws"
+)
+
+// tradingComponents SDK 的交易部分，依赖签名模块（auth、clob 与 go-ethereum）
+type tradingComponents struct {
+	Trading *clob.Client // 交易 (CLOB API)
+
+	l1Signer *auth.L1Signer
+	sessions sessionRegistry // 策略会话与订阅引用计数
+}
+
+// NewSDK 创建完整 SDK 实例（需要私钥）
+func NewSDK(config *Config, privateKey string) (*SDK, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	config.Validate()
+
+	// 创建 L1 签名器
+	l1Signer, err := auth.NewL1Signer(privateKey, config.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create L1 signer: %w", err)
+	}
+
+	// 创建 CLOB 客户端
+	clobConfig := &clob.Config{
+		Endpoint:                 config.CLOBEndpoint,
+		ChainID:                  config.ChainID,
+		Timeout:                  config.HTTPTimeout,
+		MaxRetries:               config.MaxRetries,
+		RetryDelayMs:             config.RetryDelayMs,
+		WriteTimeout:             config.WriteTimeout,
+		WriteMaxRetries:          config.WriteMaxRetries,
+		ExchangeAddress:          config.CTFExchangeAddress,
+		NegRiskExchangeAddress:   config.NegRiskCTFExchangeAddress,
+		NegRiskAdapterAddress:    config.NegRiskAdapterAddress,
+		CollateralAddress:        config.CollateralAddress,
+		ConditionalTokensAddress: config.ConditionalTokensAddress,
+		LocalAddrs:               config.LocalAddrs,
+		EventLogSize:             config.EventLogSize,
+		Throttle:                 config.OrderThrottle,
+		IdempotentCancel:         config.IdempotentCancel,
+		Audit:                    config.Audit,
+		BuyingPower:              config.BuyingPower,
+		L2HeaderCache:            config.L2HeaderCache,
+		WarmConns:                config.WarmConns,
+		KeepAliveInterval:        config.KeepAliveInterval,
+	}
+	clobClient, err := clob.NewClient(clobConfig, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CLOB client: %w", err)
+	}
+	if config.SignatureType != 0 {
+		clobClient.SetSignatureType(config.SignatureType)
+	}
+	if config.FunderAddress != "" {
+		clobClient.SetFunderAddress(config.FunderAddress)
+	}
+
+	sdk := newPublicSDK(config)
+	sdk.Trading = clobClient
+	sdk.l1Signer = l1Signer
+	// 按订单簿定价的市价单（CreateMarketOrderFromBook）使用本地订单簿
+	clobClient.SetBookSource(sdk.bookLevels)
+	return sdk, nil
+}
+
+// NewTradingSDK 创建带交易功能的 SDK（需要私钥和凭证）
+func NewTradingSDK(config *Config, privateKey string, creds *auth.Credentials) (*SDK, error) {
+	sdk, err := NewSDK(config, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if creds != nil {
+		sdk.Trading.SetCredentials(creds)
+	}
+
+	return sdk, nil
+}
+
+// GetAddress 获取钱包地址
+func (s *SDK) GetAddress() string {
+	if s.l1Signer != nil {
+		return s.l1Signer.GetAddress()
+	}
+	return ""
+}
+
+// CreateOrDeriveAPICredentials 创建或衍生 API 凭证
+func (s *SDK) CreateOrDeriveAPICredentials(ctx context.Context) (*auth.Credentials, error) {
+	if s.Trading == nil {
+		return nil, fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+	return s.Trading.CreateOrDeriveAPICredentials(ctx)
+}
+
+// GetCredentials 获取当前凭证
+func (s *SDK) GetCredentials() *auth.Credentials {
+	if s.Trading == nil {
+		return nil
+	}
+	return s.Trading.GetCredentials()
+}
+
+// SetCredentials 设置凭证
+func (s *SDK) SetCredentials(creds *auth.Credentials) {
+	if s.Trading != nil {
+		s.Trading.SetCredentials(creds)
+	}
+}
+
+// SetCredentialsWithAddress 设置凭证（指定账户地址）
+func (s *SDK) SetCredentialsWithAddress(creds *auth.Credentials, address string) {
+	if s.Trading != nil {
+		s.Trading.SetCredentialsWithAddress(creds, address)
+	}
+}
+
+// SetFunderAddress 设置代理钱包地址（用于代理钱包模式）
+// funderAddress: 代理钱包地址（持有资金的地址）
+func (s *SDK) SetFunderAddress(funderAddress string) {
+	if s.Trading != nil {
+		s.Trading.SetFunderAddress(funderAddress)
+	}
+}
+
+// SetSignatureType 设置签名类型
+// signatureType: 0=EOA, 1=POLY_PROXY, 2=GNOSIS_SAFE
+func (s *SDK) SetSignatureType(signatureType int) {
+	if s.Trading != nil {
+		s.Trading.SetSignatureType(signatureType)
+	}
+}
+
+// SyncOwnOrders 从 CLOB 拉取当前活跃订单，同步到订单簿的自身挂单视图
+// 之后可通过 OrderBook.GetDepthExcludingOwn 获取剔除自身挂单后的深度
+// 仅同步已订阅的 token，没有活跃订单的 token 会被清除
+func (s *SDK) SyncOwnOrders(ctx context.Context) error {
+	if s.Trading == nil {
+		return fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+
+	orders, err := s.Trading.GetOpenOrders(ctx)
+	if err != nil {
+		return err
+	}
+
+	byToken := make(map[string][]orderbook.OwnOrder)
+	for _, order := range orders {
+		if !order.IsActive() {
+			continue
+		}
+		byToken[order.AssetID] = append(byToken[order.AssetID], orderbook.OwnOrder{
+			Side:  order.Side,
+			Price: order.Price,
+			Size:  order.GetRemainingSize(),
+		})
+	}
+
+	for _, tokenID := range s.OrderBook.GetSubscribedTokens() {
+		if err := s.OrderBook.SetOwnOrders(tokenID, byToken[tokenID]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewCancelWatchdog 创建撤单看门狗，行情停滞检测使用订单簿最近收到消息的时间
+// 策略需定期调用 Heartbeat，并调用 Start 启动
+func (s *SDK) NewCancelWatchdog(config *clob.WatchdogConfig) (*clob.CancelWatchdog, error) {
+	if s.Trading == nil {
+		return nil, fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+
+	watchdog := clob.NewCancelWatchdog(s.Trading, config)
+	if s.OrderBook != nil {
+		watchdog.SetFeedSource(s.OrderBook.LastMessageTime)
+	}
+	return watchdog, nil
+}
+
+// NewTreasury 创建链上资金划转与授权工具，config.RPCEndpoint 为空时使用 Config.RPCEndpoint
+// 首次交易前可调用 SetTradingAllowances 授权 USDC.e 与条件代币
+func (s *SDK) NewTreasury(config *clob.TreasuryConfig) (*clob.Treasury, error) {
+	if s.Trading == nil {
+		return nil, fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+
+	treasuryConfig := clob.DefaultTreasuryConfig()
+	if config != nil {
+		*treasuryConfig = *config
+	}
+	if treasuryConfig.RPCEndpoint == "" {
+		treasuryConfig.RPCEndpoint = s.config.RPCEndpoint
+	}
+	return s.Trading.NewTreasury(treasuryConfig)
+}
+
+// GetMyPositions 通过 Data API 获取交易账户（funder 地址，即代理钱包或签名钱包）的全部持仓
+func (s *SDK) GetMyPositions(ctx context.Context, params *data.PositionsParams) ([]data.Position, error) {
+	if s.Trading == nil {
+		return nil, fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+	return s.Data.GetAllPositions(ctx, s.Trading.GetFunderAddress(), params)
+}
+
+// NewUserStream 使用当前 API 凭证创建 USER 频道客户端，实时接收自己账户的订单与成交事件
+// 调用 Start 后从 Events 读取；凭证轮换后需调用客户端的 SetCredentials
+func (s *SDK) NewUserStream(config *userws.Config) (*userws.Client, error) {
+	if s.Trading == nil {
+		return nil, fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+
+	creds := s.Trading.GetCredentials()
+	if creds == nil {
+		return nil, fmt.Errorf("user stream: %w", common.ErrCredentialsNotFound)
+	}
+	return userws.NewClient(creds, config)
+}
+
+// EnablePassiveOrders 启用本地仅挂单检查，使用订单簿的最优价判断 Passive/PostOnly 订单是否会立即成交
+// config 为 nil 时拒绝会吃单的订单；config.Quotes 为空时使用 OrderBook，未订阅的 token 不做检查
+func (s *SDK) EnablePassiveOrders(config *clob.PassiveConfig) error {
+	if s.Trading == nil {
+		return fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+
+	passive := &clob.PassiveConfig{}
+	if config != nil {
+		*passive = *config
+	}
+	if passive.Quotes == nil {
+		passive.Quotes = s.bookQuotes
+	}
+	s.Trading.SetPassive(passive)
+	return nil
+}
+
+// bookQuotes 订单簿的最优买卖价，无挂单的一侧为零
+func (s *SDK) bookQuotes(tokenID string) (bid, ask decimal.Decimal, ok bool) {
+	if s.OrderBook == nil {
+		return decimal.Zero, decimal.Zero, false
+	}
+	bbo, err := s.OrderBook.GetBBO(tokenID)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, false
+	}
+	if bbo.BestBid != nil {
+		bid = bbo.BestBid.Price
+	}
+	if bbo.BestAsk != nil {
+		ask = bbo.BestAsk.Price
+	}
+	return bid, ask, true
+}
+
+// bookLevels 订单簿中 side 方向订单的对手盘档位，最优价在前
+func (s *SDK) bookLevels(tokenID string, side clob.OrderSide) ([]clob.BookLevel, bool) {
+	if s.OrderBook == nil {
+		return nil, false
+	}
+
+	var result *orderbook.ScanResult
+	var err error
+	if side == clob.OrderSideBuy {
+		result, err = s.OrderBook.ScanAsksBelow(tokenID, decimal.NewFromInt(1))
+	} else {
+		result, err = s.OrderBook.ScanBidsAbove(tokenID, decimal.Zero)
+	}
+	if err != nil || result == nil {
+		return nil, false
+	}
+
+	levels := make([]clob.BookLevel, 0, len(result.Orders))
+	for _, order := range result.Orders {
+		levels = append(levels, clob.BookLevel{Price: order.Price, Size: order.Size})
+	}
+	return levels, true
+}
+
+// IsTradingEnabled 是否启用交易功能
+func (s *SDK) IsTradingEnabled() bool {
+	return s.Trading != nil && s.l1Signer != nil
+}
+
+// closeTrading 关闭交易客户端
+func (s *SDK) closeTrading() {
+	if s.Trading != nil {
+		s.Trading.Close()
+	}
+}
+
+// setTradingRetryPolicy 同步交易客户端的重试参数（ApplyConfig 使用）
+func (s *SDK) setTradingRetryPolicy(maxRetries, retryDelayMs int) {
+	if s.Trading != nil {
+		s.Trading.SetRetryPolicy(maxRetries, retryDelayMs)
+	}
+}
+
+// setTradingKeepAlive 同步交易客户端的保活间隔（ApplyConfig 使用）
+func (s *SDK) setTradingKeepAlive(interval time.Duration) {
+	if s.Trading != nil {
+		s.Trading.SetKeepAliveInterval(interval)
+	}
+}
@@ -0,0 +1,628 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// SessionLimits 会话级风控限制，零值表示不限制
+// 名义金额按 price * size 计算
+type SessionLimits struct {
+	MaxOrderSize     decimal.Decimal // 单笔订单最大份数
+	MaxOrderNotional decimal.Decimal // 单笔订单最大名义金额（USDC）
+	MaxOpenOrders    int             // 会话同时挂单数上限
+	MaxOpenNotional  decimal.Decimal // 会话挂单名义金额合计上限（USDC）
+}
+
+// SessionMetrics 会话运行统计
+type SessionMetrics struct {
+	Name              string
+	Subscriptions     int             // 会话订阅的 token 数
+	OpenOrders        int             // 会话记录的挂单数
+	OpenNotional      decimal.Decimal // 挂单名义金额合计
+	OrdersSubmitted   int             // 提交到交易所的订单数
+	OrdersRejected    int             // 请求失败或被交易所拒绝的订单数
+	RiskRejections    int             // 被会话风控拒绝、未提交的订单数
+	Cancels           int             // 撤单成功的订单数
+	SubmittedNotional decimal.Decimal // 已提交订单名义金额合计
+}
+
+// maxQueuedFills 未被 PollFills 取走的成交记录上限，超出时丢弃最早的记录
+const maxQueuedFills = 1024
+
+// SessionFill 会话订单的一次成交，来自下单响应中的立即撮合或 SyncOrders 检测到的成交数量变化
+type SessionFill struct {
+	OrderID string
+	TokenID string
+	Side    clob.OrderSide
+	Price   decimal.Decimal // 订单限价
+	Size    decimal.Decimal // 本次新增成交份数
+	Filled  decimal.Decimal // 订单累计成交份数
+	Done    bool            // 订单已不在订单簿上（全部成交、撤销或过期），之后不再产生成交记录
+}
+
+// sessionOrder 会话记录的挂单
+type sessionOrder struct {
+	tokenID  string
+	side     clob.OrderSide
+	price    decimal.Decimal
+	matched  decimal.Decimal // 已记录的累计成交份数
+	notional decimal.Decimal // 剩余部分的名义金额
+}
+
+// Session 单个策略的 SDK 门面
+// 共享 SDK 的 WebSocket 连接池与 HTTP 客户端，但独立维护订阅、订单、风控限制与统计，可单独关闭；
+// 适用于一个进程内运行多个策略，而无需为每个策略创建完整的 SDK
+type Session struct {
+	name string
+	sdk  *SDK
+
+	mu      sync.Mutex
+	limits  SessionLimits
+	tokens  map[string]struct{}
+	orders  map[string]*sessionOrder
+	fills   []SessionFill   // 等待 PollFills 取走的成交
+	pending int             // 已通过风控、等待交易所响应的订单数
+	held    decimal.Decimal // 等待响应订单的名义金额
+	metrics SessionMetrics
+	closed  bool
+}
+
+// sessionRegistry 会话注册表与订阅引用计数
+type sessionRegistry struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+	refs     map[string]*tokenRef
+}
+
+// tokenRef token 被会话引用的情况
+type tokenRef struct {
+	count    int
+	external bool // 首个会话订阅前 SDK 已订阅该 token，会话释放后不退订
+}
+
+// NewSession 创建名为 name 的策略会话，名称在 SDK 内唯一（会话关闭后可复用）
+// limits 为 nil 时使用 Config.SessionLimits
+func (s *SDK) NewSession(name string, limits *SessionLimits) (*Session, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: session name is required", common.ErrInvalidConfig)
+	}
+
+	s.sessions.mu.Lock()
+	defer s.sessions.mu.Unlock()
+
+	if _, exists := s.sessions.sessions[name]; exists {
+		return nil, fmt.Errorf("%w: session %q already exists", common.ErrInvalidConfig, name)
+	}
+	if s.sessions.sessions == nil {
+		s.sessions.sessions = make(map[string]*Session)
+		s.sessions.refs = make(map[string]*tokenRef)
+	}
+
+	session := &Session{
+		name:    name,
+		sdk:     s,
+		tokens:  make(map[string]struct{}),
+		orders:  make(map[string]*sessionOrder),
+		metrics: SessionMetrics{Name: name},
+	}
+	if limits == nil && s.config != nil {
+		limits = s.config.SessionLimits
+	}
+	if limits != nil {
+		session.limits = *limits
+	}
+	s.sessions.sessions[name] = session
+	return session, nil
+}
+
+// Sessions 当前未关闭的会话，按名称排序
+func (s *SDK) Sessions() []*Session {
+	s.sessions.mu.Lock()
+	defer s.sessions.mu.Unlock()
+
+	result := make([]*Session, 0, len(s.sessions.sessions))
+	for _, session := range s.sessions.sessions {
+		result = append(result, session)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].name < result[j].name })
+	return result
+}
+
+// Name 会话名称
+func (s *Session) Name() string {
+	return s.name
+}
+
+// OrderBook 共享的订单簿，读取行情使用；订阅请通过会话的 Subscribe 以便关闭时释放
+func (s *Session) OrderBook() *orderbook.SDK {
+	return s.sdk.OrderBook
+}
+
+// SetLimits 替换会话风控限制，只影响之后提交的订单
+func (s *Session) SetLimits(limits SessionLimits) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limits = limits
+}
+
+// Limits 当前会话风控限制
+func (s *Session) Limits() SessionLimits {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.limits
+}
+
+// Subscribe 订阅 token，多个会话可以订阅同一个 token，共享同一份订单簿
+func (s *Session) Subscribe(tokenIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return common.ErrSessionClosed
+	}
+
+	newTokens := make([]string, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if _, ok := s.tokens[tokenID]; !ok && !slices.Contains(newTokens, tokenID) {
+			newTokens = append(newTokens, tokenID)
+		}
+	}
+	if len(newTokens) == 0 {
+		return nil
+	}
+
+	if err := s.sdk.sessions.acquire(s.sdk.OrderBook, newTokens); err != nil {
+		return err
+	}
+	for _, tokenID := range newTokens {
+		s.tokens[tokenID] = struct{}{}
+	}
+	s.metrics.Subscriptions = len(s.tokens)
+	return nil
+}
+
+// Unsubscribe 取消会话对 token 的订阅，其他会话仍在使用的 token 保持订阅
+func (s *Session) Unsubscribe(tokenIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return common.ErrSessionClosed
+	}
+
+	owned := make([]string, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if _, ok := s.tokens[tokenID]; ok {
+			owned = append(owned, tokenID)
+			delete(s.tokens, tokenID)
+		}
+	}
+	s.metrics.Subscriptions = len(s.tokens)
+	return s.sdk.sessions.release(s.sdk.OrderBook, owned)
+}
+
+// Tokens 会话订阅的 token，已排序
+func (s *Session) Tokens() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]string, 0, len(s.tokens))
+	for tokenID := range s.tokens {
+		result = append(result, tokenID)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Owns 更新或订单的 token 是否属于本会话，可用于在共享的 Updates() 循环中分发事件
+func (s *Session) Owns(tokenID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.tokens[tokenID]
+	return ok
+}
+
+// CreateOrder 经会话风控检查后下单，挂单成功的订单由会话记录
+// 超出限制时返回 common.ErrRiskLimitExceeded，订单不会提交
+func (s *Session) CreateOrder(ctx context.Context, req *clob.CreateOrderRequest) (*clob.OrderResponse, error) {
+	if s.sdk.Trading == nil {
+		return nil, fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+	if req == nil {
+		return nil, fmt.Errorf("%w: order request is nil", common.ErrInvalidOrder)
+	}
+
+	notional := req.Price.Mul(req.Size)
+	if err := s.reserve(req.Size, notional); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.sdk.Trading.CreateOrder(ctx, req)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending--
+	s.held = s.held.Sub(notional)
+	s.metrics.OrdersSubmitted++
+	if err != nil || resp == nil || !resp.Success {
+		s.metrics.OrdersRejected++
+		return resp, err
+	}
+	s.metrics.SubmittedNotional = s.metrics.SubmittedNotional.Add(notional)
+
+	matched := immediateFill(req.Side, resp)
+	resting := resp.OrderID != "" && isRestingStatus(resp.Status)
+	if resting {
+		s.orders[resp.OrderID] = &sessionOrder{
+			tokenID:  req.TokenID,
+			side:     req.Side,
+			price:    req.Price,
+			matched:  matched,
+			notional: req.Price.Mul(req.Size.Sub(matched)),
+		}
+		s.refreshOrderMetricsLocked()
+	}
+	if matched.IsPositive() {
+		s.queueFillLocked(SessionFill{
+			OrderID: resp.OrderID,
+			TokenID: req.TokenID,
+			Side:    req.Side,
+			Price:   req.Price,
+			Size:    matched,
+			Filled:  matched,
+			Done:    !resting,
+		})
+	}
+	return resp, nil
+}
+
+// immediateFill 下单响应中立即撮合的份数：BUY 为获得的数量，SELL 为付出的数量
+func immediateFill(side clob.OrderSide, resp *clob.OrderResponse) decimal.Decimal {
+	if side == clob.OrderSideSell {
+		return resp.MakingAmount
+	}
+	return resp.TakingAmount
+}
+
+// queueFillLocked 记录一次成交，等待 PollFills 取走
+func (s *Session) queueFillLocked(fill SessionFill) {
+	if len(s.fills) >= maxQueuedFills {
+		s.fills = s.fills[1:]
+	}
+	s.fills = append(s.fills, fill)
+}
+
+// reserve 检查风控限制并预占挂单额度，等待中的订单也计入限制
+func (s *Session) reserve(size, notional decimal.Decimal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return common.ErrSessionClosed
+	}
+
+	limits := s.limits
+	var reason string
+	switch {
+	case limits.MaxOrderSize.IsPositive() && size.GreaterThan(limits.MaxOrderSize):
+		reason = fmt.Sprintf("size %s exceeds %s", size, limits.MaxOrderSize)
+	case limits.MaxOrderNotional.IsPositive() && notional.GreaterThan(limits.MaxOrderNotional):
+		reason = fmt.Sprintf("notional %s exceeds %s", notional, limits.MaxOrderNotional)
+	case limits.MaxOpenOrders > 0 && len(s.orders)+s.pending >= limits.MaxOpenOrders:
+		reason = fmt.Sprintf("%d open orders reached the limit", len(s.orders)+s.pending)
+	case limits.MaxOpenNotional.IsPositive() && s.openNotionalLocked().Add(s.held).Add(notional).GreaterThan(limits.MaxOpenNotional):
+		reason = fmt.Sprintf("open notional would exceed %s", limits.MaxOpenNotional)
+	}
+	if reason != "" {
+		s.metrics.RiskRejections++
+		return fmt.Errorf("%w: session %s: %s", common.ErrRiskLimitExceeded, s.name, reason)
+	}
+
+	s.pending++
+	s.held = s.held.Add(notional)
+	return nil
+}
+
+// CancelOrder 撤销会话自己的订单，其他会话或外部下的订单返回 common.ErrOrderNotFound
+func (s *Session) CancelOrder(ctx context.Context, orderID string) error {
+	s.mu.Lock()
+	_, ok := s.orders[orderID]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s is not an open order of session %s", common.ErrOrderNotFound, orderID, s.name)
+	}
+
+	if err := s.sdk.Trading.CancelOrder(ctx, orderID); err != nil {
+		// 交易所上已不活跃或不存在的订单不再跟踪
+		if clob.CancelStatusOf(err) != clob.CancelStatusFailed {
+			s.forget([]string{orderID})
+		}
+		return err
+	}
+	s.forget([]string{orderID})
+	return nil
+}
+
+// CancelAll 撤销会话的全部挂单，不影响其他会话
+func (s *Session) CancelAll(ctx context.Context) error {
+	orderIDs := s.OpenOrders()
+	if len(orderIDs) == 0 {
+		return nil
+	}
+
+	resp, err := s.sdk.Trading.CancelOrders(ctx, orderIDs)
+	if err != nil {
+		return err
+	}
+
+	// 已不活跃或不存在的订单视为撤单完成，只有失败的订单保留并返回错误
+	var done, failed []string
+	for _, result := range resp.Results(orderIDs) {
+		if result.Status == clob.CancelStatusFailed {
+			failed = append(failed, result.OrderID)
+		} else {
+			done = append(done, result.OrderID)
+		}
+	}
+	s.forget(done)
+	if len(failed) > 0 {
+		return fmt.Errorf("session %s: %d orders not canceled: %s", s.name, len(failed), strings.Join(failed, ","))
+	}
+	return nil
+}
+
+// forget 移除已撤销的订单
+func (s *Session) forget(orderIDs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, orderID := range orderIDs {
+		if _, ok := s.orders[orderID]; ok {
+			delete(s.orders, orderID)
+			s.metrics.Cancels++
+		}
+	}
+	s.refreshOrderMetricsLocked()
+}
+
+// OpenOrders 会话记录的挂单 ID，已排序
+func (s *Session) OpenOrders() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]string, 0, len(s.orders))
+	for orderID := range s.orders {
+		result = append(result, orderID)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// SyncOrders 从 CLOB 拉取活跃订单，移除会话中已成交或已撤销的订单，并按剩余数量更新名义金额
+// 成交数量的变化记录为 SessionFill，由 PollFills 取走；已离开订单簿的订单会逐个查询最终成交数量
+func (s *Session) SyncOrders(ctx context.Context) error {
+	if s.sdk.Trading == nil {
+		return fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+
+	orders, err := s.sdk.Trading.GetOpenOrders(ctx)
+	if err != nil {
+		return err
+	}
+	live := make(map[string]*clob.Order, len(orders))
+	for _, order := range orders {
+		if order.IsActive() {
+			live[order.ID] = order
+		}
+	}
+
+	s.mu.Lock()
+	gone := make(map[string]*sessionOrder)
+	for orderID, tracked := range s.orders {
+		order, ok := live[orderID]
+		if !ok {
+			gone[orderID] = tracked
+			delete(s.orders, orderID)
+			continue
+		}
+		s.recordMatchedLocked(orderID, tracked, order.SizeMatched, false)
+		tracked.notional = order.Price.Mul(order.GetRemainingSize())
+	}
+	s.refreshOrderMetricsLocked()
+	s.mu.Unlock()
+
+	// 最终成交数量尽力查询，查询失败时只移除订单
+	for orderID, tracked := range gone {
+		order, err := s.sdk.Trading.GetOrder(ctx, orderID)
+		if err != nil {
+			continue
+		}
+
+		s.mu.Lock()
+		if order.IsActive() && !s.closed {
+			// 下单发生在拉取活跃订单之后，订单仍在订单簿上
+			s.recordMatchedLocked(orderID, tracked, order.SizeMatched, false)
+			tracked.notional = order.Price.Mul(order.GetRemainingSize())
+			s.orders[orderID] = tracked
+			s.refreshOrderMetricsLocked()
+		} else {
+			s.recordMatchedLocked(orderID, tracked, order.SizeMatched, true)
+		}
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// recordMatchedLocked 累计成交数量增加时记录一次成交
+func (s *Session) recordMatchedLocked(orderID string, tracked *sessionOrder, matched decimal.Decimal, done bool) {
+	if !matched.GreaterThan(tracked.matched) {
+		return
+	}
+	s.queueFillLocked(SessionFill{
+		OrderID: orderID,
+		TokenID: tracked.tokenID,
+		Side:    tracked.side,
+		Price:   tracked.price,
+		Size:    matched.Sub(tracked.matched),
+		Filled:  matched,
+		Done:    done,
+	})
+	tracked.matched = matched
+}
+
+// PollFills 同步订单状态（SyncOrders）并取走此前记录的全部成交，按发现顺序排列
+// 同步失败时仍返回已记录的成交和错误
+func (s *Session) PollFills(ctx context.Context) ([]SessionFill, error) {
+	err := s.SyncOrders(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fills := s.fills
+	s.fills = nil
+	return fills, err
+}
+
+// Metrics 会话统计快照
+func (s *Session) Metrics() SessionMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.metrics
+}
+
+// Close 关闭会话：撤销会话挂单、释放订阅并从 SDK 注销，不影响其他会话与共享连接
+// 撤单失败时仍会释放订阅并关闭会话，返回撤单错误；重复调用返回 nil
+func (s *Session) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	var cancelErr error
+	if s.sdk.Trading != nil {
+		cancelErr = s.CancelAll(ctx)
+	}
+
+	s.mu.Lock()
+	tokens := make([]string, 0, len(s.tokens))
+	for tokenID := range s.tokens {
+		tokens = append(tokens, tokenID)
+	}
+	s.tokens = make(map[string]struct{})
+	s.metrics.Subscriptions = 0
+	s.mu.Unlock()
+
+	registry := &s.sdk.sessions
+	releaseErr := registry.release(s.sdk.OrderBook, tokens)
+
+	registry.mu.Lock()
+	if registry.sessions[s.name] == s {
+		delete(registry.sessions, s.name)
+	}
+	registry.mu.Unlock()
+
+	if cancelErr != nil {
+		return fmt.Errorf("failed to cancel orders of session %s: %w", s.name, cancelErr)
+	}
+	return releaseErr
+}
+
+// refreshOrderMetricsLocked 更新挂单统计（调用者需持有锁）
+func (s *Session) refreshOrderMetricsLocked() {
+	s.metrics.OpenOrders = len(s.orders)
+	s.metrics.OpenNotional = s.openNotionalLocked()
+}
+
+// openNotionalLocked 挂单名义金额合计（调用者需持有锁）
+func (s *Session) openNotionalLocked() decimal.Decimal {
+	total := decimal.Zero
+	for _, order := range s.orders {
+		total = total.Add(order.notional)
+	}
+	return total
+}
+
+// acquire 增加 token 的会话引用，首次引用且 SDK 尚未订阅的 token 会被订阅
+func (r *sessionRegistry) acquire(books *orderbook.SDK, tokenIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subscribed := make(map[string]bool)
+	for _, tokenID := range books.GetSubscribedTokens() {
+		subscribed[tokenID] = true
+	}
+
+	var toSubscribe []string
+	for _, tokenID := range tokenIDs {
+		if r.refs[tokenID] == nil && !subscribed[tokenID] {
+			toSubscribe = append(toSubscribe, tokenID)
+		}
+	}
+	if len(toSubscribe) > 0 {
+		if err := books.Subscribe(toSubscribe); err != nil {
+			return err
+		}
+	}
+
+	for _, tokenID := range tokenIDs {
+		ref := r.refs[tokenID]
+		if ref == nil {
+			ref = &tokenRef{external: subscribed[tokenID]}
+			r.refs[tokenID] = ref
+		}
+		ref.count++
+	}
+	return nil
+}
+
+// release 减少 token 的会话引用，最后一个会话释放时退订由会话订阅的 token
+func (r *sessionRegistry) release(books *orderbook.SDK, tokenIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var toUnsubscribe []string
+	for _, tokenID := range tokenIDs {
+		ref := r.refs[tokenID]
+		if ref == nil {
+			continue
+		}
+		ref.count--
+		if ref.count > 0 {
+			continue
+		}
+		delete(r.refs, tokenID)
+		if !ref.external {
+			toUnsubscribe = append(toUnsubscribe, tokenID)
+		}
+	}
+
+	if len(toUnsubscribe) == 0 {
+		return nil
+	}
+	return books.Unsubscribe(toUnsubscribe)
+}
+
+// isRestingStatus 下单响应状态是否表示订单仍挂在订单簿上（立即全部成交或未成交的 FOK/FAK 不记录）
+func isRestingStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "matched", "unmatched":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,307 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// fakeExchange serves the CLOB order endpoints used by sessions
+type fakeExchange struct {
+	mu       sync.Mutex
+	nextID   int
+	canceled []string
+	fill     string                 // takingAmount reported by POST /order, empty for no immediate fill
+	orders   map[string]*clob.Order // served by GET /orders (active ones) and GET /data/order/{id}
+}
+
+func (f *fakeExchange) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/order":
+		f.nextID++
+		fmt.Fprintf(w, `{"success":true,"orderID":"0x%d","status":"live","takingAmount":%q}`, f.nextID, f.fill)
+	case r.Method == http.MethodDelete && r.URL.Path == "/order":
+		var body struct {
+			OrderID string `json:"orderID"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.canceled = append(f.canceled, body.OrderID)
+		w.Write([]byte(`{}`))
+	case r.Method == http.MethodDelete && r.URL.Path == "/orders":
+		var body clob.BatchCancelRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		f.canceled = append(f.canceled, body.OrderIDs...)
+		json.NewEncoder(w).Encode(clob.CancelResponse{Canceled: body.OrderIDs})
+	case r.Method == http.MethodGet && r.URL.Path == "/orders":
+		open := []*clob.Order{}
+		for _, order := range f.orders {
+			if order.IsActive() {
+				open = append(open, order)
+			}
+		}
+		json.NewEncoder(w).Encode(open)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/data/order/"):
+		if order, ok := f.orders[strings.TrimPrefix(r.URL.Path, "/data/order/")]; ok {
+			json.NewEncoder(w).Encode(order)
+			return
+		}
+		w.Write([]byte(`{}`))
+	default:
+		w.Write([]byte(`{}`))
+	}
+}
+
+// newSessionTestSDK returns a started SDK backed by a silent WebSocket server and a fake exchange
+func newSessionTestSDK(t *testing.T) (*SDK, *fakeExchange) {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	ws := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(ws.Close)
+
+	exchange := &fakeExchange{}
+	api := httptest.NewServer(exchange)
+	t.Cleanup(api.Close)
+
+	config := DefaultConfig()
+	config.WSEndpoint = "ws" + strings.TrimPrefix(ws.URL, "http")
+	config.CLOBEndpoint = api.URL
+	config.MaxRetries = 0
+	creds := &auth.Credentials{
+		APIKey:     "test-api-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("test-secret")),
+		Passphrase: "test-passphrase",
+	}
+	sdk, err := NewTradingSDK(config, sdkTestPrivateKey, creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(sdk.Close)
+	if err := sdk.OrderBook.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return sdk, exchange
+}
+
+func limitBuy(tokenID string, price, size string) *clob.CreateOrderRequest {
+	return &clob.CreateOrderRequest{
+		TokenID: tokenID,
+		Side:    clob.OrderSideBuy,
+		Price:   decimal.RequireFromString(price),
+		Size:    decimal.RequireFromString(size),
+		Type:    clob.OrderTypeGTC,
+	}
+}
+
+func TestSession_SharedSubscriptions(t *testing.T) {
+	sdk, _ := newSessionTestSDK(t)
+	ctx := context.Background()
+
+	if err := sdk.OrderBook.Subscribe([]string{"external"}); err != nil {
+		t.Fatal(err)
+	}
+	a, err := sdk.NewSession("alpha", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := sdk.NewSession("beta", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sdk.NewSession("alpha", nil); !errors.Is(err, common.ErrInvalidConfig) {
+		t.Errorf("duplicate session name should fail, got %v", err)
+	}
+
+	if err := a.Subscribe([]string{"shared", "only-a", "external"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Subscribe([]string{"shared"}); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Owns("only-a") || b.Owns("only-a") || len(a.Tokens()) != 3 {
+		t.Errorf("unexpected ownership: a=%v b=%v", a.Tokens(), b.Tokens())
+	}
+
+	subscribed := func() map[string]bool {
+		result := map[string]bool{}
+		for _, tokenID := range sdk.OrderBook.GetSubscribedTokens() {
+			result[tokenID] = true
+		}
+		return result
+	}
+
+	// Closing alpha keeps tokens still used by beta and tokens subscribed outside sessions
+	if err := a.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := subscribed(); !got["shared"] || got["only-a"] || !got["external"] {
+		t.Errorf("after closing alpha: %v", got)
+	}
+	if err := a.Subscribe([]string{"x"}); !errors.Is(err, common.ErrSessionClosed) {
+		t.Errorf("closed session should reject Subscribe, got %v", err)
+	}
+	if len(sdk.Sessions()) != 1 || sdk.Sessions()[0].Name() != "beta" {
+		t.Errorf("Sessions() = %v", sdk.Sessions())
+	}
+
+	if err := b.Unsubscribe([]string{"shared"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := subscribed(); got["shared"] || !got["external"] {
+		t.Errorf("after beta unsubscribed: %v", got)
+	}
+
+	// The name can be reused once the session is closed
+	if _, err := sdk.NewSession("alpha", nil); err != nil {
+		t.Errorf("reusing a closed session name: %v", err)
+	}
+}
+
+func TestSession_OrdersAndLimits(t *testing.T) {
+	sdk, exchange := newSessionTestSDK(t)
+	ctx := context.Background()
+
+	a, _ := sdk.NewSession("alpha", &SessionLimits{
+		MaxOrderSize:    decimal.NewFromInt(100),
+		MaxOpenOrders:   2,
+		MaxOpenNotional: decimal.NewFromInt(60),
+	})
+	b, _ := sdk.NewSession("beta", nil)
+
+	if _, err := a.CreateOrder(ctx, limitBuy("12345", "0.50", "200")); !errors.Is(err, common.ErrRiskLimitExceeded) {
+		t.Errorf("expected size limit, got %v", err)
+	}
+	if _, err := a.CreateOrder(ctx, limitBuy("12345", "0.50", "100")); err != nil {
+		t.Fatal(err)
+	}
+	// 50 open + 20 would exceed the 60 USDC notional cap
+	if _, err := a.CreateOrder(ctx, limitBuy("12345", "0.40", "50")); !errors.Is(err, common.ErrRiskLimitExceeded) {
+		t.Errorf("expected notional limit, got %v", err)
+	}
+	if _, err := a.CreateOrder(ctx, limitBuy("12345", "0.10", "50")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.CreateOrder(ctx, limitBuy("12345", "0.01", "10")); !errors.Is(err, common.ErrRiskLimitExceeded) {
+		t.Errorf("expected open order limit, got %v", err)
+	}
+
+	resp, err := b.CreateOrder(ctx, limitBuy("12345", "0.50", "500"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sessions cannot cancel each other's orders
+	if err := a.CancelOrder(ctx, resp.OrderID); !errors.Is(err, common.ErrOrderNotFound) {
+		t.Errorf("expected ErrOrderNotFound, got %v", err)
+	}
+
+	metrics := a.Metrics()
+	if metrics.OpenOrders != 2 || !metrics.OpenNotional.Equal(decimal.NewFromInt(55)) || metrics.RiskRejections != 3 || metrics.OrdersSubmitted != 2 {
+		t.Errorf("unexpected alpha metrics %+v", metrics)
+	}
+
+	// Closing alpha cancels only alpha's orders
+	if err := a.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	exchange.mu.Lock()
+	canceled := append([]string(nil), exchange.canceled...)
+	exchange.mu.Unlock()
+	if len(canceled) != 2 || canceled[0] != "0x1" || canceled[1] != "0x2" {
+		t.Errorf("canceled = %v, expected alpha's orders only", canceled)
+	}
+	if metrics := a.Metrics(); metrics.OpenOrders != 0 || metrics.Cancels != 2 {
+		t.Errorf("metrics after close %+v", metrics)
+	}
+	if got := b.OpenOrders(); len(got) != 1 || got[0] != resp.OrderID {
+		t.Errorf("beta orders = %v", got)
+	}
+	if _, err := a.CreateOrder(ctx, limitBuy("12345", "0.50", "1")); !errors.Is(err, common.ErrSessionClosed) {
+		t.Errorf("closed session should reject orders, got %v", err)
+	}
+}
+
+func TestSession_PollFills(t *testing.T) {
+	sdk, exchange := newSessionTestSDK(t)
+	ctx := context.Background()
+	session, _ := sdk.NewSession("fills", nil)
+
+	exchange.mu.Lock()
+	exchange.fill = "4"
+	exchange.mu.Unlock()
+	if _, err := session.CreateOrder(ctx, limitBuy("12345", "0.50", "10")); err != nil {
+		t.Fatal(err)
+	}
+	exchange.mu.Lock()
+	exchange.fill = ""
+	exchange.mu.Unlock()
+	if _, err := session.CreateOrder(ctx, limitBuy("12345", "0.40", "5")); err != nil {
+		t.Fatal(err)
+	}
+	if metrics := session.Metrics(); !metrics.OpenNotional.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("open notional = %s, expected the unfilled remainder 3 + 2", metrics.OpenNotional)
+	}
+
+	// 0x1 fills another 2 shares and stays live; 0x2 fills completely and leaves the book
+	exchange.mu.Lock()
+	exchange.orders = map[string]*clob.Order{
+		"0x1": {ID: "0x1", Status: clob.OrderStatusLive, OriginalSize: decimal.NewFromInt(10), SizeMatched: decimal.NewFromInt(6), Price: decimal.RequireFromString("0.50")},
+		"0x2": {ID: "0x2", Status: clob.OrderStatusMatched, OriginalSize: decimal.NewFromInt(5), SizeMatched: decimal.NewFromInt(5), Price: decimal.RequireFromString("0.40")},
+	}
+	exchange.mu.Unlock()
+
+	fills, err := session.PollFills(ctx)
+	if err != nil {
+		t.Fatalf("PollFills() error: %v", err)
+	}
+	if len(fills) != 3 {
+		t.Fatalf("expected 3 fills, got %+v", fills)
+	}
+	if fills[0].OrderID != "0x1" || !fills[0].Size.Equal(decimal.NewFromInt(4)) || fills[0].Done {
+		t.Errorf("immediate fill = %+v", fills[0])
+	}
+	if fills[1].OrderID != "0x1" || !fills[1].Size.Equal(decimal.NewFromInt(2)) || !fills[1].Filled.Equal(decimal.NewFromInt(6)) {
+		t.Errorf("partial fill = %+v", fills[1])
+	}
+	if fills[2].OrderID != "0x2" || !fills[2].Size.Equal(decimal.NewFromInt(5)) || !fills[2].Done || fills[2].TokenID != "12345" {
+		t.Errorf("final fill = %+v", fills[2])
+	}
+	if got := session.OpenOrders(); len(got) != 1 || got[0] != "0x1" {
+		t.Errorf("open orders = %v", got)
+	}
+
+	if fills, err := session.PollFills(ctx); err != nil || len(fills) != 0 {
+		t.Errorf("second poll should report nothing new, got %+v, %v", fills, err)
+	}
+}
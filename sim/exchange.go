@@ -0,0 +1,788 @@
+// Package sim 模拟盘交易所
+//
+// Exchange 实现 clob.TradingClient，在实时订单簿行情上撮合自己的模拟订单：
+// 下单时按当前盘口吃单，剩余部分挂单；之后真实订单簿越过挂单价，或最新成交价穿过挂单价时成交。
+// 策略依赖 clob.TradingClient 即可不改代码地在模拟盘运行。
+package sim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// 与 CLOB 一致的下单响应状态
+const (
+	statusMatched   = "matched"
+	statusLive      = "live"
+	statusUnmatched = "unmatched"
+)
+
+// Config 模拟盘配置
+type Config struct {
+	InitialBalance decimal.Decimal // 初始 USDC 余额
+	TakerFeeBps    int             // 吃单费率（基点），fee = rate * min(p, 1-p) * size
+	DepthLevels    int             // 撮合时读取的订单簿档数
+}
+
+// DefaultConfig 默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		InitialBalance: decimal.NewFromInt(1000),
+		DepthLevels:    50,
+	}
+}
+
+// Position 模拟持仓
+type Position struct {
+	TokenID       string
+	Market        string
+	Size          decimal.Decimal
+	AvgPrice      decimal.Decimal // 持仓均价
+	RealizedPnL   decimal.Decimal // 已实现盈亏（不含手续费）
+	MarkPrice     decimal.Decimal // 估值价格（订单簿中间价，无行情时为均价）
+	UnrealizedPnL decimal.Decimal
+}
+
+// PnL 账户盈亏汇总
+type PnL struct {
+	Cash       decimal.Decimal // 现金余额
+	Equity     decimal.Decimal // 现金 + 持仓市值
+	Realized   decimal.Decimal
+	Unrealized decimal.Decimal
+	Fees       decimal.Decimal
+	Total      decimal.Decimal // Realized + Unrealized - Fees
+}
+
+// simOrder 模拟订单
+type simOrder struct {
+	order    *clob.Order
+	seq      int64
+	expires  int64                      // GTD 过期时间（秒），0 表示不过期
+	reserved decimal.Decimal            // 冻结资金（买单，USDC）或冻结持仓（卖单，份额）
+	consumed map[string]decimal.Decimal // 价格 -> 已消耗的对手盘数量，避免同一挂单量被重复撮合
+}
+
+// Exchange 模拟盘交易所
+// 模拟订单不会进入真实订单簿，因此吃单不会真正移除对手盘；
+// 每个订单记录已消耗的各档对手盘数量，只有新增的越价数量才会继续成交
+type Exchange struct {
+	mu     sync.Mutex
+	books  orderbook.BookReader
+	config *Config
+
+	cash      decimal.Decimal
+	fees      decimal.Decimal
+	orders    map[string]*simOrder
+	positions map[string]*Position
+	markets   map[string]string // tokenID -> market
+	trades    []*clob.Trade
+	nextSeq   int64
+
+	now func() time.Time
+}
+
+var _ clob.TradingClient = (*Exchange)(nil)
+
+// NewExchange 创建模拟盘交易所，books 通常为 orderbook.SDK
+func NewExchange(books orderbook.BookReader, config *Config) *Exchange {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if config.DepthLevels <= 0 {
+		config.DepthLevels = 50
+	}
+
+	return &Exchange{
+		books:     books,
+		config:    config,
+		cash:      config.InitialBalance,
+		orders:    make(map[string]*simOrder),
+		positions: make(map[string]*Position),
+		markets:   make(map[string]string),
+		now:       time.Now,
+	}
+}
+
+// SetMarket 登记 token 所属市场，用于填充订单/成交的 Market 字段及按市场撤单
+func (e *Exchange) SetMarket(tokenID, market string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.markets[tokenID] = market
+}
+
+// CreateOrder 创建模拟订单，按当前盘口立即撮合，剩余部分按订单类型挂单或撤销
+// 余额不足、FOK 无法全部成交、PostOnly 会吃单等情况返回 Success=false
+func (e *Exchange) CreateOrder(ctx context.Context, req *clob.CreateOrderRequest) (*clob.OrderResponse, error) {
+	if req != nil && req.Type == "" {
+		return nil, fmt.Errorf("order type is required, must be GTC/FOK/GTD/FAK")
+	}
+	if err := clob.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.createOrderLocked(req), nil
+}
+
+// CreateOrders 批量创建模拟订单
+func (e *Exchange) CreateOrders(ctx context.Context, reqs []*clob.CreateOrderRequest) ([]*clob.OrderResponse, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	if len(reqs) > 15 {
+		return nil, fmt.Errorf("maximum 15 orders per batch, got %d", len(reqs))
+	}
+	for i, req := range reqs {
+		if err := clob.ValidateOrderRequest(req); err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	results := make([]*clob.OrderResponse, 0, len(reqs))
+	for _, req := range reqs {
+		results = append(results, e.createOrderLocked(req))
+	}
+	return results, nil
+}
+
+// createOrderLocked 创建订单并撮合（需持有锁）
+func (e *Exchange) createOrderLocked(req *clob.CreateOrderRequest) *clob.OrderResponse {
+	orderType := req.Type
+	if orderType == "" {
+		orderType = clob.OrderTypeGTC
+	}
+
+	// 冻结资金或持仓
+	var reserved decimal.Decimal
+	if req.Side == clob.OrderSideBuy {
+		reserved = req.Price.Mul(req.Size)
+		if reserved.GreaterThan(e.availableCashLocked()) {
+			return &clob.OrderResponse{ErrorMsg: "not enough balance / allowance"}
+		}
+	} else {
+		reserved = req.Size
+		if reserved.GreaterThan(e.availableSizeLocked(req.TokenID)) {
+			return &clob.OrderResponse{ErrorMsg: "not enough balance / allowance"}
+		}
+	}
+
+	bids, asks := e.depthLocked(req.TokenID)
+	levels := asks
+	if req.Side == clob.OrderSideSell {
+		levels = bids
+	}
+	fillable := crossingSize(req.Side, req.Price, levels)
+
+	if req.PostOnly && fillable.IsPositive() {
+		return &clob.OrderResponse{ErrorMsg: "invalid post-only order: order crosses book"}
+	}
+	if orderType == clob.OrderTypeFOK && fillable.LessThan(req.Size) {
+		return &clob.OrderResponse{ErrorMsg: "order couldn't be fully filled. FOK orders are fully filled or killed."}
+	}
+	if orderType == clob.OrderTypeFAK && !fillable.IsPositive() {
+		return &clob.OrderResponse{ErrorMsg: "no orders found to match with FAK order. FAK orders are partially filled or killed if no match is found."}
+	}
+
+	e.nextSeq++
+	now := e.now()
+	o := &simOrder{
+		order: &clob.Order{
+			ID:           fmt.Sprintf("sim-%d", e.nextSeq),
+			Status:       clob.OrderStatusLive,
+			Market:       e.markets[req.TokenID],
+			AssetID:      req.TokenID,
+			Side:         req.Side,
+			OriginalSize: req.Size,
+			Price:        req.Price,
+			Expiration:   strconv.FormatInt(req.ExpiresAt, 10),
+			OrderType:    orderType,
+			CreatedAt:    clob.Timestamp(now.Unix()),
+		},
+		seq:      e.nextSeq,
+		expires:  req.ExpiresAt,
+		reserved: reserved,
+		consumed: make(map[string]decimal.Decimal),
+	}
+	e.orders[o.order.ID] = o
+
+	// 吃单：按对手盘价格成交
+	var notional decimal.Decimal
+	for _, level := range levels {
+		if !crosses(req.Side, req.Price, level.Price) || !o.order.GetRemainingSize().IsPositive() {
+			break
+		}
+		qty := e.takeLevel(o, level)
+		e.fillLocked(o, level.Price, qty, req.Side == clob.OrderSideBuy, true)
+		if qty.IsPositive() {
+			notional = notional.Add(level.Price.Mul(qty))
+		}
+	}
+
+	resp := &clob.OrderResponse{Success: true, OrderID: o.order.ID, Status: statusLive}
+	if req.Side == clob.OrderSideBuy {
+		resp.MakingAmount, resp.TakingAmount = notional, o.order.SizeMatched
+	} else {
+		resp.MakingAmount, resp.TakingAmount = o.order.SizeMatched, notional
+	}
+	if o.order.SizeMatched.IsPositive() {
+		resp.Status = statusMatched
+	}
+
+	if o.order.IsFilled() {
+		o.order.Status = clob.OrderStatusMatched
+	} else if orderType == clob.OrderTypeFOK || orderType == clob.OrderTypeFAK {
+		e.closeLocked(o, clob.OrderStatusCanceled)
+		if !o.order.SizeMatched.IsPositive() {
+			resp.Status = statusUnmatched
+		}
+	}
+	return resp
+}
+
+// GetOrder 查询模拟订单（包括已成交和已撤销的订单）
+func (e *Exchange) GetOrder(ctx context.Context, orderID string) (*clob.Order, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o, ok := e.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", common.ErrOrderNotFound, orderID)
+	}
+	order := *o.order
+	return &order, nil
+}
+
+// GetOrders 查询活跃的模拟订单，按创建顺序返回
+func (e *Exchange) GetOrders(ctx context.Context, params *clob.OrdersQueryParams) ([]*clob.Order, error) {
+	if params == nil {
+		params = &clob.OrdersQueryParams{}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var result []*clob.Order
+	for _, o := range e.sortedOrdersLocked() {
+		if !o.order.IsActive() {
+			continue
+		}
+		if (params.Market != "" && o.order.Market != params.Market) ||
+			(params.AssetID != "" && o.order.AssetID != params.AssetID) ||
+			(params.Side != "" && string(o.order.Side) != params.Side) ||
+			(params.Status != "" && string(o.order.Status) != params.Status) {
+			continue
+		}
+		order := *o.order
+		result = append(result, &order)
+	}
+
+	if params.Offset > 0 {
+		if params.Offset >= len(result) {
+			return nil, nil
+		}
+		result = result[params.Offset:]
+	}
+	if params.Limit > 0 && len(result) > params.Limit {
+		result = result[:params.Limit]
+	}
+	return result, nil
+}
+
+// GetOpenOrders 获取所有活跃的模拟订单
+func (e *Exchange) GetOpenOrders(ctx context.Context) ([]*clob.Order, error) {
+	return e.GetOrders(ctx, nil)
+}
+
+// CancelOrder 撤销单个模拟订单
+func (e *Exchange) CancelOrder(ctx context.Context, orderID string) error {
+	if orderID == "" {
+		return fmt.Errorf("order ID is required")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o, ok := e.orders[orderID]
+	if !ok {
+		return fmt.Errorf("failed to cancel order: %w: %s", common.ErrOrderNotFound, orderID)
+	}
+	if !o.order.IsActive() {
+		return fmt.Errorf("failed to cancel order: %w: %s", common.ErrOrderAlreadyCanceled, orderID)
+	}
+	e.closeLocked(o, clob.OrderStatusCanceled)
+	return nil
+}
+
+// CancelOrders 批量撤销模拟订单
+func (e *Exchange) CancelOrders(ctx context.Context, orderIDs []string) (*clob.CancelResponse, error) {
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := &clob.CancelResponse{NotCanceledReasons: make(map[string]string)}
+	for _, id := range orderIDs {
+		o, ok := e.orders[id]
+		if !ok || !o.order.IsActive() {
+			result.NotCanceled = append(result.NotCanceled, id)
+			if ok {
+				result.NotCanceledReasons[id] = common.ErrOrderAlreadyCanceled.Error()
+			} else {
+				result.NotCanceledReasons[id] = common.ErrOrderNotFound.Error()
+			}
+			continue
+		}
+		e.closeLocked(o, clob.OrderStatusCanceled)
+		result.Canceled = append(result.Canceled, id)
+	}
+	return result, nil
+}
+
+// CancelOrdersByMarket 撤销指定市场的所有模拟订单
+func (e *Exchange) CancelOrdersByMarket(ctx context.Context, marketID string) (*clob.CancelResponse, error) {
+	if marketID == "" {
+		return nil, fmt.Errorf("market ID is required")
+	}
+	return e.cancelWhere(func(o *clob.Order) bool { return o.Market == marketID }), nil
+}
+
+// CancelOrdersByAsset 撤销指定 token 的所有模拟订单
+func (e *Exchange) CancelOrdersByAsset(ctx context.Context, assetID string) (*clob.CancelResponse, error) {
+	if assetID == "" {
+		return nil, fmt.Errorf("asset ID is required")
+	}
+	return e.cancelWhere(func(o *clob.Order) bool { return o.AssetID == assetID }), nil
+}
+
+// CancelAllOrders 撤销所有模拟订单
+func (e *Exchange) CancelAllOrders(ctx context.Context) error {
+	e.cancelWhere(func(*clob.Order) bool { return true })
+	return nil
+}
+
+// cancelWhere 撤销满足条件的活跃订单
+func (e *Exchange) cancelWhere(match func(o *clob.Order) bool) *clob.CancelResponse {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := &clob.CancelResponse{}
+	for _, o := range e.sortedOrdersLocked() {
+		if o.order.IsActive() && match(o.order) {
+			e.closeLocked(o, clob.OrderStatusCanceled)
+			result.Canceled = append(result.Canceled, o.order.ID)
+		}
+	}
+	return result
+}
+
+// GetTrades 获取模拟成交记录，按成交顺序返回
+// Before/After 为 Unix 秒时间戳字符串
+func (e *Exchange) GetTrades(ctx context.Context, params *clob.TradesQueryParams) ([]*clob.Trade, error) {
+	if params == nil {
+		params = &clob.TradesQueryParams{}
+	}
+
+	var before, after int64
+	if params.Before != "" {
+		v, err := strconv.ParseInt(params.Before, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid before timestamp: %w", err)
+		}
+		before = v
+	}
+	if params.After != "" {
+		v, err := strconv.ParseInt(params.After, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid after timestamp: %w", err)
+		}
+		after = v
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var result []*clob.Trade
+	for _, t := range e.trades {
+		if (params.Market != "" && t.Market != params.Market) ||
+			(params.AssetID != "" && t.AssetID != params.AssetID) {
+			continue
+		}
+		matchTime, _ := strconv.ParseInt(t.MatchTime, 10, 64)
+		if (before > 0 && matchTime >= before) || (after > 0 && matchTime <= after) {
+			continue
+		}
+		trade := *t
+		result = append(result, &trade)
+		if params.Limit > 0 && len(result) >= params.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetBalanceAllowance 获取模拟余额，与 CLOB 一致返回总余额（不扣除挂单冻结），授权视为无限
+func (e *Exchange) GetBalanceAllowance(ctx context.Context, params *clob.BalanceAllowanceParams) (*clob.BalanceAllowance, error) {
+	if params == nil {
+		params = &clob.BalanceAllowanceParams{AssetType: clob.AssetTypeCollateral}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var balance decimal.Decimal
+	switch params.AssetType {
+	case clob.AssetTypeCollateral:
+		balance = e.cash
+	case clob.AssetTypeConditional:
+		if params.TokenID == "" {
+			return nil, fmt.Errorf("token ID is required")
+		}
+		if pos, ok := e.positions[params.TokenID]; ok {
+			balance = pos.Size
+		}
+	default:
+		return nil, fmt.Errorf("unknown asset type: %s", params.AssetType)
+	}
+
+	return &clob.BalanceAllowance{Balance: balance, Allowance: balance}, nil
+}
+
+// GetCollateralBalance 获取模拟 USDC 余额
+func (e *Exchange) GetCollateralBalance(ctx context.Context) (*clob.BalanceAllowance, error) {
+	return e.GetBalanceAllowance(ctx, &clob.BalanceAllowanceParams{AssetType: clob.AssetTypeCollateral})
+}
+
+// GetConditionalBalance 获取模拟条件代币余额
+func (e *Exchange) GetConditionalBalance(ctx context.Context, tokenID string) (*clob.BalanceAllowance, error) {
+	if tokenID == "" {
+		return nil, fmt.Errorf("token ID is required")
+	}
+	return e.GetBalanceAllowance(ctx, &clob.BalanceAllowanceParams{AssetType: clob.AssetTypeConditional, TokenID: tokenID})
+}
+
+// Run 持续消费订单簿更新流并撮合挂单，直到 ctx 取消或 updates 关闭
+func (e *Exchange) Run(ctx context.Context, updates <-chan orderbook.OrderBookUpdate) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			e.Process(update)
+		}
+	}
+}
+
+// Process 处理一条订单簿更新：撤销过期的 GTD 订单，并撮合该 token 的挂单
+func (e *Exchange) Process(update orderbook.OrderBookUpdate) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if update.LastTrade != nil && update.LastTrade.Market != "" {
+		if _, ok := e.markets[update.TokenID]; !ok {
+			e.markets[update.TokenID] = update.LastTrade.Market
+		}
+	}
+
+	nowUnix := e.now().Unix()
+	var resting []*simOrder
+	for _, o := range e.sortedOrdersLocked() {
+		if !o.order.IsActive() {
+			continue
+		}
+		if o.expires > 0 && nowUnix >= o.expires {
+			e.closeLocked(o, clob.OrderStatusCanceled)
+			continue
+		}
+		if o.order.AssetID == update.TokenID {
+			resting = append(resting, o)
+		}
+	}
+	if len(resting) == 0 {
+		return
+	}
+
+	bids, asks := e.depthLocked(update.TokenID)
+	for _, o := range resting {
+		levels := asks
+		if o.order.Side == clob.OrderSideSell {
+			levels = bids
+		}
+		for _, level := range levels {
+			if !crosses(o.order.Side, o.order.Price, level.Price) || !o.order.GetRemainingSize().IsPositive() {
+				break
+			}
+			// 挂单被动成交，按挂单价格
+			e.fillLocked(o, o.order.Price, e.takeLevel(o, level), o.order.Side == clob.OrderSideBuy, false)
+		}
+		if update.LastTrade != nil {
+			e.fillOnTradeLocked(o, update.LastTrade)
+		}
+		if o.order.IsFilled() {
+			o.order.Status = clob.OrderStatusMatched
+		}
+	}
+}
+
+// fillOnTradeLocked 最新成交价严格穿过挂单价时，视为挂单已被成交（需持有锁）
+func (e *Exchange) fillOnTradeLocked(o *simOrder, trade *orderbook.LastTradePriceMessage) {
+	price, err := decimal.NewFromString(trade.Price)
+	if err != nil {
+		return
+	}
+	size, err := decimal.NewFromString(trade.Size)
+	if err != nil || !size.IsPositive() {
+		return
+	}
+
+	through := price.LessThan(o.order.Price)
+	if o.order.Side == clob.OrderSideSell {
+		through = price.GreaterThan(o.order.Price)
+	}
+	if !through {
+		return
+	}
+
+	qty := decimal.Min(size, o.order.GetRemainingSize())
+	e.fillLocked(o, o.order.Price, qty, o.order.Side == clob.OrderSideBuy, false)
+}
+
+// takeLevel 计算订单能从该档对手盘中新消耗的数量，并记录已消耗量
+func (e *Exchange) takeLevel(o *simOrder, level orderbook.OrderSummary) decimal.Decimal {
+	key := level.Price.String()
+	available := level.Size.Sub(o.consumed[key])
+	if !available.IsPositive() {
+		return decimal.Zero
+	}
+	qty := decimal.Min(available, o.order.GetRemainingSize())
+	o.consumed[key] = o.consumed[key].Add(qty)
+	return qty
+}
+
+// fillLocked 成交 qty 份，更新订单、冻结、现金、持仓与成交记录（需持有锁）
+func (e *Exchange) fillLocked(o *simOrder, price, qty decimal.Decimal, isBuy, taker bool) {
+	if !qty.IsPositive() {
+		return
+	}
+
+	fee := decimal.Zero
+	if taker && e.config.TakerFeeBps > 0 {
+		fee = decimal.NewFromInt(int64(e.config.TakerFeeBps)).Div(decimal.NewFromInt(10000)).
+			Mul(decimal.Min(price, decimal.NewFromInt(1).Sub(price))).Mul(qty)
+	}
+	e.fees = e.fees.Add(fee)
+
+	o.order.SizeMatched = o.order.SizeMatched.Add(qty)
+
+	pos := e.positionLocked(o.order.AssetID)
+	if isBuy {
+		// 释放按挂单价冻结的资金，扣除实际成交金额
+		o.reserved = o.reserved.Sub(o.order.Price.Mul(qty))
+		e.cash = e.cash.Sub(price.Mul(qty)).Sub(fee)
+
+		cost := pos.AvgPrice.Mul(pos.Size).Add(price.Mul(qty))
+		pos.Size = pos.Size.Add(qty)
+		pos.AvgPrice = cost.Div(pos.Size)
+	} else {
+		o.reserved = o.reserved.Sub(qty)
+		e.cash = e.cash.Add(price.Mul(qty)).Sub(fee)
+
+		pos.RealizedPnL = pos.RealizedPnL.Add(price.Sub(pos.AvgPrice).Mul(qty))
+		pos.Size = pos.Size.Sub(qty)
+		if pos.Size.IsZero() {
+			pos.AvgPrice = decimal.Zero
+		}
+	}
+
+	traderSide := "MAKER"
+	if taker {
+		traderSide = "TAKER"
+	}
+	now := e.now()
+	trade := &clob.Trade{
+		ID:         fmt.Sprintf("sim-trade-%d", len(e.trades)+1),
+		Market:     o.order.Market,
+		AssetID:    o.order.AssetID,
+		Side:       o.order.Side,
+		Price:      price,
+		Size:       qty,
+		Status:     "CONFIRMED",
+		MatchTime:  strconv.FormatInt(now.Unix(), 10),
+		LastUpdate: strconv.FormatInt(now.Unix(), 10),
+		TraderSide: traderSide,
+	}
+	if taker {
+		trade.TakerOrderID = o.order.ID
+		trade.FeeRateBPS = strconv.Itoa(e.config.TakerFeeBps)
+	} else {
+		trade.FeeRateBPS = "0"
+		trade.MakerOrders = []clob.MakerOrder{{
+			OrderID:       o.order.ID,
+			MatchedAmount: qty.String(),
+			Price:         price.String(),
+			AssetID:       o.order.AssetID,
+			Side:          string(o.order.Side),
+		}}
+	}
+	o.order.AssociateTrades = append(o.order.AssociateTrades, trade.ID)
+	e.trades = append(e.trades, trade)
+}
+
+// closeLocked 结束订单并释放剩余冻结（需持有锁）
+func (e *Exchange) closeLocked(o *simOrder, status clob.OrderStatus) {
+	o.order.Status = status
+	o.reserved = decimal.Zero
+}
+
+// availableCashLocked 可用资金 = 现金 - 买单冻结（需持有锁）
+func (e *Exchange) availableCashLocked() decimal.Decimal {
+	available := e.cash
+	for _, o := range e.orders {
+		if o.order.IsActive() && o.order.Side == clob.OrderSideBuy {
+			available = available.Sub(o.reserved)
+		}
+	}
+	return available
+}
+
+// availableSizeLocked 可卖数量 = 持仓 - 卖单冻结（需持有锁）
+func (e *Exchange) availableSizeLocked(tokenID string) decimal.Decimal {
+	pos, ok := e.positions[tokenID]
+	if !ok {
+		return decimal.Zero
+	}
+	available := pos.Size
+	for _, o := range e.orders {
+		if o.order.IsActive() && o.order.Side == clob.OrderSideSell && o.order.AssetID == tokenID {
+			available = available.Sub(o.reserved)
+		}
+	}
+	return available
+}
+
+// positionLocked 获取或创建持仓（需持有锁）
+func (e *Exchange) positionLocked(tokenID string) *Position {
+	pos, ok := e.positions[tokenID]
+	if !ok {
+		pos = &Position{TokenID: tokenID, Market: e.markets[tokenID]}
+		e.positions[tokenID] = pos
+	}
+	return pos
+}
+
+// depthLocked 读取订单簿深度，订单簿不可用时视为无对手盘
+func (e *Exchange) depthLocked(tokenID string) (bids, asks []orderbook.OrderSummary) {
+	if e.books == nil {
+		return nil, nil
+	}
+	bids, asks, err := e.books.GetDepth(tokenID, e.config.DepthLevels)
+	if err != nil {
+		return nil, nil
+	}
+	return bids, asks
+}
+
+// sortedOrdersLocked 按创建顺序返回全部订单（需持有锁）
+func (e *Exchange) sortedOrdersLocked() []*simOrder {
+	orders := make([]*simOrder, 0, len(e.orders))
+	for _, o := range e.orders {
+		orders = append(orders, o)
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].seq < orders[j].seq })
+	return orders
+}
+
+// markPriceLocked 估值价格：订单簿中间价，单边行情时取该边价格，无行情时为 fallback
+func (e *Exchange) markPriceLocked(tokenID string, fallback decimal.Decimal) decimal.Decimal {
+	if e.books == nil {
+		return fallback
+	}
+	bbo, err := e.books.GetBBO(tokenID)
+	if err != nil || bbo == nil {
+		return fallback
+	}
+	switch {
+	case bbo.BestBid != nil && bbo.BestAsk != nil:
+		return bbo.BestBid.Price.Add(bbo.BestAsk.Price).Div(decimal.NewFromInt(2))
+	case bbo.BestBid != nil:
+		return bbo.BestBid.Price
+	case bbo.BestAsk != nil:
+		return bbo.BestAsk.Price
+	}
+	return fallback
+}
+
+// Positions 获取模拟持仓（含已平仓但有已实现盈亏的 token），按 tokenID 排序
+func (e *Exchange) Positions() []*Position {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make([]*Position, 0, len(e.positions))
+	for _, pos := range e.positions {
+		p := *pos
+		p.MarkPrice = e.markPriceLocked(p.TokenID, p.AvgPrice)
+		p.UnrealizedPnL = p.MarkPrice.Sub(p.AvgPrice).Mul(p.Size)
+		result = append(result, &p)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].TokenID < result[j].TokenID })
+	return result
+}
+
+// PnL 获取账户盈亏汇总
+func (e *Exchange) PnL() PnL {
+	positions := e.Positions()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	summary := PnL{Cash: e.cash, Equity: e.cash, Fees: e.fees}
+	for _, p := range positions {
+		summary.Realized = summary.Realized.Add(p.RealizedPnL)
+		summary.Unrealized = summary.Unrealized.Add(p.UnrealizedPnL)
+		summary.Equity = summary.Equity.Add(p.MarkPrice.Mul(p.Size))
+	}
+	summary.Total = summary.Realized.Add(summary.Unrealized).Sub(summary.Fees)
+	return summary
+}
+
+// crosses 对手盘价格是否越过订单价格
+func crosses(side clob.OrderSide, limit, levelPrice decimal.Decimal) bool {
+	if side == clob.OrderSideBuy {
+		return levelPrice.LessThanOrEqual(limit)
+	}
+	return levelPrice.GreaterThanOrEqual(limit)
+}
+
+// crossingSize 对手盘中越过订单价格的总数量（levels 按最优价在前排序）
+func crossingSize(side clob.OrderSide, limit decimal.Decimal, levels []orderbook.OrderSummary) decimal.Decimal {
+	total := decimal.Zero
+	for _, level := range levels {
+		if !crosses(side, limit, level.Price) {
+			break
+		}
+		total = total.Add(level.Size)
+	}
+	return total
+}
@@ -0,0 +1,234 @@
+package sim
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// fakeBooks is a BookReader with a single mutable book
+type fakeBooks struct {
+	bids []orderbook.OrderSummary
+	asks []orderbook.OrderSummary
+}
+
+func (f *fakeBooks) GetBBO(tokenID string) (*orderbook.BBO, error) {
+	bbo := &orderbook.BBO{}
+	if len(f.bids) > 0 {
+		bbo.BestBid = &orderbook.BestPrice{Price: f.bids[0].Price, Size: f.bids[0].Size}
+	}
+	if len(f.asks) > 0 {
+		bbo.BestAsk = &orderbook.BestPrice{Price: f.asks[0].Price, Size: f.asks[0].Size}
+	}
+	return bbo, nil
+}
+
+func (f *fakeBooks) GetDepth(tokenID string, depth int) ([]orderbook.OrderSummary, []orderbook.OrderSummary, error) {
+	return f.bids, f.asks, nil
+}
+
+func levels(pairs ...string) []orderbook.OrderSummary {
+	var result []orderbook.OrderSummary
+	for i := 0; i+1 < len(pairs); i += 2 {
+		result = append(result, orderbook.OrderSummary{
+			Price: decimal.RequireFromString(pairs[i]),
+			Size:  decimal.RequireFromString(pairs[i+1]),
+		})
+	}
+	return result
+}
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func order(side clob.OrderSide, price, size string, orderType clob.OrderType) *clob.CreateOrderRequest {
+	return &clob.CreateOrderRequest{TokenID: "token", Side: side, Price: d(price), Size: d(size), Type: orderType}
+}
+
+func TestExchange_TakerFillAndRest(t *testing.T) {
+	books := &fakeBooks{bids: levels("0.48", "100"), asks: levels("0.50", "10", "0.52", "10", "0.55", "100")}
+	ex := NewExchange(books, nil)
+	ctx := context.Background()
+
+	resp, err := ex.CreateOrder(ctx, order(clob.OrderSideBuy, "0.52", "30", clob.OrderTypeGTC))
+	if err != nil || !resp.Success {
+		t.Fatalf("CreateOrder() = %+v, %v", resp, err)
+	}
+	if resp.Status != "matched" {
+		t.Errorf("Status = %s, expected matched", resp.Status)
+	}
+	if !resp.MakingAmount.Equal(d("10.2")) || !resp.TakingAmount.Equal(d("20")) {
+		t.Errorf("making/taking = %s/%s, expected 10.2/20", resp.MakingAmount, resp.TakingAmount)
+	}
+
+	o, _ := ex.GetOrder(ctx, resp.OrderID)
+	if !o.SizeMatched.Equal(d("20")) || o.Status != clob.OrderStatusLive {
+		t.Errorf("order = matched %s status %s, expected 20 LIVE", o.SizeMatched, o.Status)
+	}
+
+	// 10@0.50 + 10@0.52 = 10.2
+	bal, _ := ex.GetCollateralBalance(ctx)
+	if !bal.Balance.Equal(d("989.8")) {
+		t.Errorf("balance = %s, expected 989.8", bal.Balance)
+	}
+	pos, _ := ex.GetConditionalBalance(ctx, "token")
+	if !pos.Balance.Equal(d("20")) {
+		t.Errorf("position = %s, expected 20", pos.Balance)
+	}
+
+	// Same book again must not refill against already consumed liquidity
+	ex.Process(orderbook.OrderBookUpdate{TokenID: "token"})
+	if o, _ := ex.GetOrder(ctx, resp.OrderID); !o.SizeMatched.Equal(d("20")) {
+		t.Errorf("matched after unchanged book = %s, expected 20", o.SizeMatched)
+	}
+
+	// New ask crosses the resting order: maker fill at our price
+	books.asks = levels("0.51", "4", "0.55", "100")
+	ex.Process(orderbook.OrderBookUpdate{TokenID: "token"})
+	o, _ = ex.GetOrder(ctx, resp.OrderID)
+	if !o.SizeMatched.Equal(d("24")) {
+		t.Errorf("matched = %s, expected 24", o.SizeMatched)
+	}
+
+	trades, _ := ex.GetTrades(ctx, nil)
+	if len(trades) != 3 {
+		t.Fatalf("trades = %d, expected 3", len(trades))
+	}
+	last := trades[2]
+	if last.TraderSide != "MAKER" || !last.Price.Equal(d("0.52")) || !last.Size.Equal(d("4")) {
+		t.Errorf("maker trade = %s %s@%s", last.TraderSide, last.Size, last.Price)
+	}
+}
+
+func TestExchange_LastTradeThroughPrice(t *testing.T) {
+	books := &fakeBooks{asks: levels("0.60", "100")}
+	ex := NewExchange(books, nil)
+	ctx := context.Background()
+
+	resp, _ := ex.CreateOrder(ctx, order(clob.OrderSideBuy, "0.50", "10", clob.OrderTypeGTC))
+
+	// Trade at our price does not fill (queue position unknown)
+	ex.Process(orderbook.OrderBookUpdate{TokenID: "token", LastTrade: &orderbook.LastTradePriceMessage{Price: "0.50", Size: "5"}})
+	if o, _ := ex.GetOrder(ctx, resp.OrderID); !o.SizeMatched.IsZero() {
+		t.Errorf("trade at limit should not fill, matched %s", o.SizeMatched)
+	}
+
+	ex.Process(orderbook.OrderBookUpdate{TokenID: "token", LastTrade: &orderbook.LastTradePriceMessage{Price: "0.49", Size: "50", Market: "m"}})
+	o, _ := ex.GetOrder(ctx, resp.OrderID)
+	if !o.IsFilled() || o.Status != clob.OrderStatusMatched {
+		t.Errorf("order = matched %s status %s, expected filled", o.SizeMatched, o.Status)
+	}
+}
+
+func TestExchange_OrderTypes(t *testing.T) {
+	books := &fakeBooks{bids: levels("0.40", "5"), asks: levels("0.50", "10")}
+	ex := NewExchange(books, nil)
+	ctx := context.Background()
+
+	resp, _ := ex.CreateOrder(ctx, order(clob.OrderSideBuy, "0.50", "20", clob.OrderTypeFOK))
+	if resp.Success {
+		t.Error("FOK larger than book should be rejected")
+	}
+
+	resp, _ = ex.CreateOrder(ctx, order(clob.OrderSideBuy, "0.50", "20", clob.OrderTypeFAK))
+	o, _ := ex.GetOrder(ctx, resp.OrderID)
+	if !resp.Success || !o.SizeMatched.Equal(d("10")) || o.Status != clob.OrderStatusCanceled {
+		t.Errorf("FAK = %+v, order matched %s status %s", resp, o.SizeMatched, o.Status)
+	}
+
+	post := order(clob.OrderSideBuy, "0.55", "1", clob.OrderTypeGTC)
+	post.PostOnly = true
+	if resp, _ := ex.CreateOrder(ctx, post); resp.Success {
+		t.Error("crossing post-only order should be rejected")
+	}
+
+	if _, err := ex.CreateOrder(ctx, order(clob.OrderSideBuy, "1.5", "1", clob.OrderTypeGTC)); !errors.Is(err, common.ErrInvalidPrice) {
+		t.Errorf("expected ErrInvalidPrice, got %v", err)
+	}
+
+	gtd := order(clob.OrderSideBuy, "0.30", "1", clob.OrderTypeGTD)
+	gtd.ExpiresAt = ex.now().Unix() - 1
+	resp, _ = ex.CreateOrder(ctx, gtd)
+	ex.Process(orderbook.OrderBookUpdate{TokenID: "other"})
+	if o, _ := ex.GetOrder(ctx, resp.OrderID); o.Status != clob.OrderStatusCanceled {
+		t.Errorf("expired GTD status = %s, expected CANCELED", o.Status)
+	}
+}
+
+func TestExchange_BalanceChecksAndCancel(t *testing.T) {
+	books := &fakeBooks{}
+	ex := NewExchange(books, &Config{InitialBalance: d("10")})
+	ctx := context.Background()
+
+	if resp, _ := ex.CreateOrder(ctx, order(clob.OrderSideSell, "0.50", "1", clob.OrderTypeGTC)); resp.Success {
+		t.Error("sell without position should be rejected")
+	}
+
+	ex.SetMarket("token", "market")
+	first, _ := ex.CreateOrder(ctx, order(clob.OrderSideBuy, "0.50", "15", clob.OrderTypeGTC))
+	if !first.Success {
+		t.Fatalf("first order rejected: %s", first.ErrorMsg)
+	}
+	// 7.5 of 10 reserved
+	if resp, _ := ex.CreateOrder(ctx, order(clob.OrderSideBuy, "0.50", "6", clob.OrderTypeGTC)); resp.Success {
+		t.Error("order exceeding available balance should be rejected")
+	}
+
+	open, _ := ex.GetOpenOrders(ctx)
+	if len(open) != 1 || open[0].Market != "market" {
+		t.Fatalf("open orders = %+v", open)
+	}
+
+	resp, _ := ex.CancelOrdersByMarket(ctx, "market")
+	if len(resp.Canceled) != 1 {
+		t.Errorf("canceled = %v", resp.Canceled)
+	}
+	if err := ex.CancelOrder(ctx, first.OrderID); !errors.Is(err, common.ErrOrderAlreadyCanceled) {
+		t.Errorf("expected ErrOrderAlreadyCanceled, got %v", err)
+	}
+	if err := ex.CancelOrder(ctx, "missing"); !errors.Is(err, common.ErrOrderNotFound) {
+		t.Errorf("expected ErrOrderNotFound, got %v", err)
+	}
+
+	// Reservation released
+	if resp, _ := ex.CreateOrder(ctx, order(clob.OrderSideBuy, "0.50", "20", clob.OrderTypeGTC)); !resp.Success {
+		t.Errorf("order after cancel rejected: %s", resp.ErrorMsg)
+	}
+}
+
+func TestExchange_PnL(t *testing.T) {
+	books := &fakeBooks{bids: levels("0.39", "100"), asks: levels("0.40", "100")}
+	ex := NewExchange(books, &Config{InitialBalance: d("100"), TakerFeeBps: 100})
+	ctx := context.Background()
+
+	ex.CreateOrder(ctx, order(clob.OrderSideBuy, "0.40", "50", clob.OrderTypeFOK))
+
+	books.bids, books.asks = levels("0.60", "100"), levels("0.62", "100")
+	ex.CreateOrder(ctx, order(clob.OrderSideSell, "0.60", "20", clob.OrderTypeFOK))
+
+	positions := ex.Positions()
+	if len(positions) != 1 {
+		t.Fatalf("positions = %d, expected 1", len(positions))
+	}
+	p := positions[0]
+	if !p.Size.Equal(d("30")) || !p.RealizedPnL.Equal(d("4")) || !p.MarkPrice.Equal(d("0.61")) || !p.UnrealizedPnL.Equal(d("6.3")) {
+		t.Errorf("position = size %s realized %s mark %s unrealized %s", p.Size, p.RealizedPnL, p.MarkPrice, p.UnrealizedPnL)
+	}
+
+	// fees: 0.01*0.40*50 = 0.2, 0.01*0.40*20 = 0.08
+	pnl := ex.PnL()
+	if !pnl.Fees.Equal(d("0.28")) || !pnl.Total.Equal(d("10.02")) {
+		t.Errorf("pnl = fees %s total %s", pnl.Fees, pnl.Total)
+	}
+	// cash: 100 - 20 - 0.2 + 12 - 0.08 = 91.72, equity = cash + 30*0.61
+	if !pnl.Cash.Equal(d("91.72")) || !pnl.Equity.Equal(d("110.02")) {
+		t.Errorf("pnl = cash %s equity %s", pnl.Cash, pnl.Equity)
+	}
+}
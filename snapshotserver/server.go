@@ -0,0 +1,370 @@
+// Package snapshotserver 以只读 REST 接口提供实时订单簿快照
+//
+// 数据直接读取 orderbook.SDK 的内存状态，不请求交易所，可作为 sidecar 部署给 Web 前端或其他语言的服务使用：
+//
+//	GET /book/{token}?depth=N   L2 快照（格式同 orderbook.L2SnapshotRecord）
+//	GET /bbo/{token}            最优买卖价、中间价与价差
+//	GET /markets/summary        全部已订阅订单簿的摘要
+//
+// 响应带 ETag（响应体哈希），客户端携带 If-None-Match 且内容未变时返回 304，轮询时无需重复传输。
+package snapshotserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// maxCacheEntries 响应缓存条目数达到该值时清理过期条目
+const maxCacheEntries = 1024
+
+// Source 服务读取订单簿的接口（*orderbook.SDK 已实现）
+type Source interface {
+	GetSnapshot(tokenID string, depth int) (*orderbook.L2Snapshot, error)
+	GetBBO(tokenID string) (*orderbook.BBO, error)
+	GetBookSummaries() ([]orderbook.BookSummary, error)
+}
+
+// Config 服务配置
+type Config struct {
+	DefaultDepth      int           // /book 未指定 depth 时返回的档位数，<= 0 表示全部档位
+	MaxDepth          int           // depth 参数上限，<= 0 表示不限制
+	CacheTTL          time.Duration // 相同请求在该时长内复用已编码的响应（含 ETag），多个前端轮询时只序列化一次，<= 0 表示每次读取最新状态
+	CacheControl      string        // Cache-Control 响应头，为空时不设置
+	AllowOrigin       string        // Access-Control-Allow-Origin 响应头（跨域访问的前端），为空时不设置
+	ReadHeaderTimeout time.Duration // ListenAndServe 读取请求头超时
+	ShutdownTimeout   time.Duration // ListenAndServe 在 ctx 取消后等待进行中请求的时长
+}
+
+// DefaultConfig 默认配置：默认返回 20 档，编码后的响应复用 100 毫秒，客户端每次使用前需用 ETag 重新验证
+func DefaultConfig() *Config {
+	return &Config{
+		DefaultDepth:      20,
+		CacheTTL:          100 * time.Millisecond,
+		CacheControl:      "no-cache",
+		ReadHeaderTimeout: 5 * time.Second,
+		ShutdownTimeout:   5 * time.Second,
+	}
+}
+
+// Server 订单簿快照 REST 服务，实现 http.Handler，可挂载到已有的 mux 上
+type Server struct {
+	source Source
+	config *Config
+	mux    *http.ServeMux
+
+	mu    sync.Mutex
+	cache map[string]*cachedResponse // 请求键 -> 已编码的响应
+	now   func() time.Time
+}
+
+// cachedResponse 已编码的成功响应
+type cachedResponse struct {
+	body      []byte
+	etag      string
+	expiresAt time.Time
+}
+
+// NewServer 创建快照服务
+func NewServer(source Source, config *Config) *Server {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	s := &Server{
+		source: source,
+		config: config,
+		mux:    http.NewServeMux(),
+		cache:  make(map[string]*cachedResponse),
+		now:    time.Now,
+	}
+	s.mux.HandleFunc("GET /book/{token}", s.handleBook)
+	s.mux.HandleFunc("GET /bbo/{token}", s.handleBBO)
+	s.mux.HandleFunc("GET /markets/summary", s.handleSummary)
+	return s
+}
+
+// ServeHTTP 实现 http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe 在 addr 上监听，直到 ctx 取消后优雅关闭
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shutdown snapshot server: %w", err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// PriceLevel 最优价位
+type PriceLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+}
+
+// BBOResponse /bbo/{token} 响应，单边无报价时对应字段为 null，mid / spread 仅在双边报价时填充
+type BBOResponse struct {
+	TokenID   string      `json:"token_id"`
+	Bid       *PriceLevel `json:"bid"`
+	Ask       *PriceLevel `json:"ask"`
+	Mid       string      `json:"mid,omitempty"`
+	Spread    string      `json:"spread,omitempty"`
+	Timestamp int64       `json:"timestamp"` // 交易所时间戳（毫秒）
+}
+
+// MarketSummary /markets/summary 中单个订单簿的摘要
+type MarketSummary struct {
+	TokenID    string      `json:"token_id"`
+	MarketSlug string      `json:"market_slug,omitempty"` // 通过 SetTokenMetadata 注册后才会填充
+	Outcome    string      `json:"outcome,omitempty"`
+	Bid        *PriceLevel `json:"bid"`
+	Ask        *PriceLevel `json:"ask"`
+	Spread     string      `json:"spread,omitempty"`
+	BidDepth   string      `json:"bid_depth"`
+	AskDepth   string      `json:"ask_depth"`
+}
+
+// SummaryResponse /markets/summary 响应
+type SummaryResponse struct {
+	Markets []MarketSummary `json:"markets"`
+}
+
+// errInvalidDepth depth 参数不合法
+var errInvalidDepth = errors.New("invalid depth")
+
+// errorResponse 错误响应
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// handleBook GET /book/{token}
+func (s *Server) handleBook(w http.ResponseWriter, r *http.Request) {
+	tokenID := r.PathValue("token")
+	depth := s.config.DefaultDepth
+	if v := r.URL.Query().Get("depth"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			s.writeError(w, fmt.Errorf("%w: %q", errInvalidDepth, v))
+			return
+		}
+		depth = n
+	}
+	if s.config.MaxDepth > 0 && (depth <= 0 || depth > s.config.MaxDepth) {
+		depth = s.config.MaxDepth
+	}
+
+	s.serve(w, r, "book/"+tokenID+"/"+strconv.Itoa(depth), func() (interface{}, error) {
+		snap, err := s.source.GetSnapshot(tokenID, depth)
+		if err != nil {
+			return nil, err
+		}
+		return snap.Record(), nil
+	})
+}
+
+// handleBBO GET /bbo/{token}
+func (s *Server) handleBBO(w http.ResponseWriter, r *http.Request) {
+	tokenID := r.PathValue("token")
+	s.serve(w, r, "bbo/"+tokenID, func() (interface{}, error) {
+		bbo, err := s.source.GetBBO(tokenID)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := BBOResponse{
+			TokenID: tokenID,
+			Bid:     priceLevel(bbo.BestBid),
+			Ask:     priceLevel(bbo.BestAsk),
+		}
+		for _, p := range []*orderbook.BestPrice{bbo.BestBid, bbo.BestAsk} {
+			if p != nil && p.Timestamp > resp.Timestamp {
+				resp.Timestamp = p.Timestamp
+			}
+		}
+		if bbo.BestBid != nil && bbo.BestAsk != nil {
+			resp.Mid = bbo.BestBid.Price.Add(bbo.BestAsk.Price).Div(decimal.NewFromInt(2)).String()
+			resp.Spread = bbo.BestAsk.Price.Sub(bbo.BestBid.Price).String()
+		}
+		return resp, nil
+	})
+}
+
+// handleSummary GET /markets/summary
+func (s *Server) handleSummary(w http.ResponseWriter, r *http.Request) {
+	s.serve(w, r, "markets/summary", func() (interface{}, error) {
+		summaries, err := s.source.GetBookSummaries()
+		if err != nil {
+			return nil, err
+		}
+
+		resp := SummaryResponse{Markets: make([]MarketSummary, 0, len(summaries))}
+		for _, summary := range summaries {
+			market := MarketSummary{
+				TokenID:  summary.TokenID,
+				Bid:      priceLevel(summary.BestBid),
+				Ask:      priceLevel(summary.BestAsk),
+				BidDepth: summary.BidDepth.String(),
+				AskDepth: summary.AskDepth.String(),
+			}
+			if summary.Metadata != nil {
+				market.MarketSlug = summary.Metadata.MarketSlug
+				market.Outcome = summary.Metadata.Outcome
+			}
+			if summary.TwoSided() {
+				market.Spread = summary.Spread.String()
+			}
+			resp.Markets = append(resp.Markets, market)
+		}
+		return resp, nil
+	})
+}
+
+// serve 返回 CacheTTL 内已编码的响应，否则调用 build 读取最新状态并编码
+// ETag 与请求的 If-None-Match 相同时返回 304
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, key string, build func() (interface{}, error)) {
+	cached, err := s.cached(key, build)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	if s.config.CacheControl != "" {
+		w.Header().Set("Cache-Control", s.config.CacheControl)
+	}
+	if s.config.AllowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", s.config.AllowOrigin)
+	}
+	w.Header().Set("ETag", cached.etag)
+	if etagMatches(r.Header.Get("If-None-Match"), cached.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(cached.body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		w.Write(cached.body)
+	}
+}
+
+// cached 获取或生成已编码的响应，错误不缓存
+func (s *Server) cached(key string, build func() (interface{}, error)) (*cachedResponse, error) {
+	ttl := s.config.CacheTTL
+	now := s.now()
+	if ttl > 0 {
+		s.mu.Lock()
+		entry := s.cache[key]
+		s.mu.Unlock()
+		if entry != nil && now.Before(entry.expiresAt) {
+			return entry, nil
+		}
+	}
+
+	v, err := build()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	h := fnv.New64a()
+	h.Write(body)
+	entry := &cachedResponse{
+		body:      body,
+		etag:      fmt.Sprintf(`"%016x"`, h.Sum64()),
+		expiresAt: now.Add(ttl),
+	}
+
+	if ttl > 0 {
+		s.mu.Lock()
+		// 顺带清理过期条目，避免请求过已取消订阅 token 的键一直保留
+		if len(s.cache) >= maxCacheEntries {
+			for k, e := range s.cache {
+				if !now.Before(e.expiresAt) {
+					delete(s.cache, k)
+				}
+			}
+		}
+		s.cache[key] = entry
+		s.mu.Unlock()
+	}
+	return entry, nil
+}
+
+// priceLevel 转换最优价位，nil 表示该侧无报价
+func priceLevel(p *orderbook.BestPrice) *PriceLevel {
+	if p == nil {
+		return nil
+	}
+	return &PriceLevel{Price: p.Price.String(), Size: p.Size.String()}
+}
+
+// writeError 写出 JSON 错误响应，错误响应不缓存
+// 参数错误 400，未订阅 404，尚未收到快照或 SDK 未启动 503
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errInvalidDepth):
+		status = http.StatusBadRequest
+	case errors.Is(err, orderbook.ErrTokenNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, orderbook.ErrNotInitialized), errors.Is(err, orderbook.ErrNotStarted):
+		status = http.StatusServiceUnavailable
+		w.Header().Set("Retry-After", "1")
+	}
+
+	if s.config.AllowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", s.config.AllowOrigin)
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
+}
+
+// etagMatches 检查 If-None-Match 是否包含 etag（支持逗号分隔的多个值、弱校验前缀 W/ 与 *）
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag || candidate == "W/"+etag {
+			return true
+		}
+	}
+	return false
+}
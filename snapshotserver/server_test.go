@@ -0,0 +1,232 @@
+package snapshotserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+// fakeSource serves a fixed book for token "yes" and counts snapshot reads
+type fakeSource struct {
+	bidPrice  atomic.Value
+	snapshots atomic.Int32
+	depths    []int
+}
+
+func newFakeSource() *fakeSource {
+	f := &fakeSource{}
+	f.bidPrice.Store("0.45")
+	return f
+}
+
+func (f *fakeSource) GetSnapshot(tokenID string, depth int) (*orderbook.L2Snapshot, error) {
+	switch tokenID {
+	case "yes":
+	case "pending":
+		return nil, orderbook.ErrNotInitialized
+	default:
+		return nil, orderbook.ErrTokenNotFound
+	}
+	f.snapshots.Add(1)
+	f.depths = append(f.depths, depth)
+	return &orderbook.L2Snapshot{
+		TokenID:           tokenID,
+		Market:            "0xmarket",
+		Hash:              "abc",
+		ExchangeTimestamp: 1700000000123,
+		Bids:              []orderbook.OrderSummary{{Price: d(f.bidPrice.Load().(string)), Size: d("100")}},
+		Asks:              []orderbook.OrderSummary{{Price: d("0.55"), Size: d("50")}},
+	}, nil
+}
+
+func (f *fakeSource) GetBBO(tokenID string) (*orderbook.BBO, error) {
+	if tokenID != "yes" {
+		return nil, orderbook.ErrTokenNotFound
+	}
+	return &orderbook.BBO{
+		BestBid: &orderbook.BestPrice{Side: orderbook.SideBuy, Price: d("0.45"), Size: d("100"), Timestamp: 1700000000123},
+		BestAsk: &orderbook.BestPrice{Side: orderbook.SideSell, Price: d("0.55"), Size: d("50"), Timestamp: 1700000000100},
+	}, nil
+}
+
+func (f *fakeSource) GetBookSummaries() ([]orderbook.BookSummary, error) {
+	return []orderbook.BookSummary{
+		{
+			TokenID:  "no",
+			Metadata: &orderbook.TokenMetadata{MarketSlug: "will-it-rain", Outcome: "No"},
+			BestBid:  &orderbook.BestPrice{Price: d("0.4"), Size: d("10")},
+			BidDepth: d("10"),
+			AskDepth: decimal.Zero,
+		},
+		{
+			TokenID:  "yes",
+			BestBid:  &orderbook.BestPrice{Price: d("0.45"), Size: d("100")},
+			BestAsk:  &orderbook.BestPrice{Price: d("0.55"), Size: d("50")},
+			Spread:   d("0.1"),
+			BidDepth: d("100"),
+			AskDepth: d("50"),
+		},
+	}, nil
+}
+
+func get(t *testing.T, handler http.Handler, path string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Book(t *testing.T) {
+	source := newFakeSource()
+	config := DefaultConfig()
+	config.CacheTTL = 0
+	config.MaxDepth = 50
+	config.AllowOrigin = "*"
+	server := NewServer(source, config)
+
+	rec := get(t, server, "/book/yes?depth=5", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var record orderbook.L2SnapshotRecord
+	if err := json.Unmarshal(rec.Body.Bytes(), &record); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if record.Symbol != "yes" || record.Timestamp != 1700000000123000 || record.Bids[0] != [2]string{"0.45", "100"} {
+		t.Errorf("unexpected record: %+v", record)
+	}
+	if rec.Header().Get("Access-Control-Allow-Origin") != "*" || rec.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("unexpected headers: %v", rec.Header())
+	}
+
+	// default depth, and depth capped by MaxDepth
+	get(t, server, "/book/yes", nil)
+	get(t, server, "/book/yes?depth=0", nil)
+	if want := []int{5, 20, 50}; len(source.depths) != 3 || source.depths[0] != want[0] || source.depths[1] != want[1] || source.depths[2] != want[2] {
+		t.Errorf("depths = %v, expected %v", source.depths, want)
+	}
+}
+
+func TestServer_ETag(t *testing.T) {
+	source := newFakeSource()
+	config := DefaultConfig()
+	config.CacheTTL = 0
+	server := NewServer(source, config)
+
+	first := get(t, server, "/book/yes", nil)
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag")
+	}
+
+	rec := get(t, server, "/book/yes", http.Header{"If-None-Match": {`"other", ` + etag}})
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("expected 304 with empty body, got %d (%d bytes)", rec.Code, rec.Body.Len())
+	}
+
+	source.bidPrice.Store("0.46")
+	rec = get(t, server, "/book/yes", http.Header{"If-None-Match": {etag}})
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("expected new content after the book changed, got %d etag %s", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestServer_CacheTTL(t *testing.T) {
+	source := newFakeSource()
+	server := NewServer(source, DefaultConfig())
+	now := time.Unix(1700000000, 0)
+	server.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		get(t, server, "/book/yes", nil)
+	}
+	if n := source.snapshots.Load(); n != 1 {
+		t.Errorf("expected one snapshot within CacheTTL, got %d", n)
+	}
+
+	// a different depth is cached separately
+	get(t, server, "/book/yes?depth=1", nil)
+	if n := source.snapshots.Load(); n != 2 {
+		t.Errorf("expected separate cache entry per depth, got %d reads", n)
+	}
+
+	now = now.Add(DefaultConfig().CacheTTL)
+	get(t, server, "/book/yes", nil)
+	if n := source.snapshots.Load(); n != 3 {
+		t.Errorf("expected snapshot to be re-read after CacheTTL, got %d reads", n)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	server := NewServer(newFakeSource(), nil)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/book/missing", http.StatusNotFound},
+		{"/book/pending", http.StatusServiceUnavailable},
+		{"/book/yes?depth=-1", http.StatusBadRequest},
+		{"/book/yes?depth=x", http.StatusBadRequest},
+		{"/bbo/missing", http.StatusNotFound},
+		{"/unknown", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := get(t, server, tt.path, nil)
+		if rec.Code != tt.status {
+			t.Errorf("GET %s = %d, expected %d", tt.path, rec.Code, tt.status)
+		}
+		if tt.path != "/unknown" && rec.Header().Get("Cache-Control") != "no-store" {
+			t.Errorf("GET %s: error responses should not be cached", tt.path)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/book/yes", nil)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST = %d, expected 405", rec.Code)
+	}
+}
+
+func TestServer_BBO(t *testing.T) {
+	rec := get(t, NewServer(newFakeSource(), nil), "/bbo/yes", nil)
+	var resp BBOResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Bid.Price != "0.45" || resp.Ask.Size != "50" || resp.Mid != "0.5" || resp.Spread != "0.1" || resp.Timestamp != 1700000000123 {
+		t.Errorf("unexpected BBO: %+v", resp)
+	}
+}
+
+func TestServer_Summary(t *testing.T) {
+	rec := get(t, NewServer(newFakeSource(), nil), "/markets/summary", nil)
+	var resp SummaryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Markets) != 2 {
+		t.Fatalf("expected 2 markets, got %+v", resp)
+	}
+	no, yes := resp.Markets[0], resp.Markets[1]
+	if no.MarketSlug != "will-it-rain" || no.Outcome != "No" || no.Ask != nil || no.Spread != "" {
+		t.Errorf("unexpected one-sided summary: %+v", no)
+	}
+	if yes.Spread != "0.1" || yes.BidDepth != "100" || yes.Ask.Price != "0.55" {
+		t.Errorf("unexpected two-sided summary: %+v", yes)
+	}
+}
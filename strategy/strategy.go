@@ -0,0 +1,263 @@
+//go:build !nosigning
+
+// Package strategy 声明式策略框架
+//
+// 实现 Strategy 接口（OnStart、OnBookUpdate、OnFill、OnTimer、OnStop）后交给 Run 运行：
+// Run 为策略创建独立的 polymarket.Session（订阅、订单跟踪与风控限制），订阅配置的 token，
+// 将订单簿更新、成交与定时器事件依次分发给策略，并在退出时撤销策略挂单、释放订阅。
+//
+// 所有回调都在 Run 所在的协程中串行调用，策略内部状态无需加锁；回调应尽快返回，
+// 耗时操作会延迟后续事件的处理。回调 panic 时 Run 按正常流程关闭策略并返回错误。
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	polymarket "github.com/binary-jerry/polymarket-sdk"
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// Strategy 策略生命周期回调
+type Strategy interface {
+	// OnStart 订阅完成后调用一次，返回错误时策略不会运行
+	OnStart(rt *Runtime) error
+	// OnBookUpdate 策略订阅的 token 的订单簿更新
+	OnBookUpdate(rt *Runtime, update orderbook.OrderBookUpdate)
+	// OnFill 策略订单的成交
+	OnFill(rt *Runtime, fill polymarket.SessionFill)
+	// OnTimer 按 Config.TimerInterval 周期调用
+	OnTimer(rt *Runtime, now time.Time)
+	// OnStop 退出前调用一次，此时仍可下单或撤单；返回后会撤销策略的全部挂单
+	OnStop(rt *Runtime)
+}
+
+// Base 所有回调的空实现，嵌入后只需实现关心的回调
+type Base struct{}
+
+func (Base) OnStart(*Runtime) error                           { return nil }
+func (Base) OnBookUpdate(*Runtime, orderbook.OrderBookUpdate) {}
+func (Base) OnFill(*Runtime, polymarket.SessionFill)          {}
+func (Base) OnTimer(*Runtime, time.Time)                      {}
+func (Base) OnStop(*Runtime)                                  {}
+
+// Config 策略运行配置
+type Config struct {
+	Name             string                           // 策略名称，用作会话名称，在 SDK 内唯一
+	Tokens           []string                         // 启动时订阅的 token，运行中可通过 Runtime.Subscribe 追加
+	Limits           *polymarket.SessionLimits        // 风控限制，nil 时使用 SDK Config.SessionLimits
+	TimerInterval    time.Duration                    // OnTimer 周期，为 0 时不触发
+	FillPollInterval time.Duration                    // 成交轮询（Session.PollFills）周期
+	WaitForSnapshots bool                             // OnStart 前等待订阅 token 的订单簿快照
+	SnapshotTimeout  time.Duration                    // 等待快照超时
+	ShutdownTimeout  time.Duration                    // 退出时撤单与释放订阅的超时
+	Updates          <-chan orderbook.OrderBookUpdate // 订单簿更新来源，nil 时读取 sdk.OrderBook.Updates()
+}
+
+// DefaultConfig 默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		FillPollInterval: 2 * time.Second,
+		WaitForSnapshots: true,
+		SnapshotTimeout:  30 * time.Second,
+		ShutdownTimeout:  10 * time.Second,
+	}
+}
+
+// Runtime 策略运行环境，在回调中使用；下单与撤单经由策略会话，受会话风控限制
+type Runtime struct {
+	ctx     context.Context
+	session *polymarket.Session
+	stop    context.CancelFunc
+
+	mu      sync.Mutex
+	stopErr error
+}
+
+// Context 策略运行的上下文，Stop 或 Run 的 ctx 取消后结束；OnStop 中已结束，请求应使用新的上下文
+func (r *Runtime) Context() context.Context {
+	return r.ctx
+}
+
+// Session 策略会话
+func (r *Runtime) Session() *polymarket.Session {
+	return r.session
+}
+
+// OrderBook 共享的订单簿，用于读取行情
+func (r *Runtime) OrderBook() *orderbook.SDK {
+	return r.session.OrderBook()
+}
+
+// Subscribe 追加订阅 token，之后的订单簿更新同样分发给策略
+func (r *Runtime) Subscribe(tokenIDs []string) error {
+	return r.session.Subscribe(tokenIDs)
+}
+
+// CreateOrder 经会话风控检查后下单
+func (r *Runtime) CreateOrder(ctx context.Context, req *clob.CreateOrderRequest) (*clob.OrderResponse, error) {
+	return r.session.CreateOrder(ctx, req)
+}
+
+// CancelOrder 撤销策略自己的订单
+func (r *Runtime) CancelOrder(ctx context.Context, orderID string) error {
+	return r.session.CancelOrder(ctx, orderID)
+}
+
+// CancelAll 撤销策略的全部挂单
+func (r *Runtime) CancelAll(ctx context.Context) error {
+	return r.session.CancelAll(ctx)
+}
+
+// Stop 请求策略退出，当前回调返回后依次调用 OnStop 并关闭会话；err 非 nil 时作为 Run 的返回值
+func (r *Runtime) Stop(err error) {
+	r.mu.Lock()
+	if r.stopErr == nil {
+		r.stopErr = err
+	}
+	r.mu.Unlock()
+	r.stop()
+}
+
+// err Stop 记录的错误
+func (r *Runtime) err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stopErr
+}
+
+// Run 运行策略直到 ctx 取消或策略调用 Runtime.Stop，阻塞调用方
+// 退出时调用 OnStop，随后撤销策略挂单并释放订阅（受 ShutdownTimeout 限制）；
+// ctx 取消或 Stop(nil) 视为正常退出返回 nil，启动失败、回调 panic 或 Stop(err) 时返回对应错误
+//
+// Config.Updates 为 nil 时直接读取 sdk.OrderBook.Updates()：该 channel 只有一个消费者，
+// 同一 SDK 运行多个策略或另有读取者时，应自行分发更新并为每个策略传入独立的 channel
+func Run(ctx context.Context, sdk *polymarket.SDK, strategy Strategy, config *Config) error {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if config.FillPollInterval <= 0 {
+		config.FillPollInterval = 2 * time.Second
+	}
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = 10 * time.Second
+	}
+	if sdk == nil || sdk.OrderBook == nil {
+		return fmt.Errorf("%w: sdk is required", common.ErrInvalidConfig)
+	}
+
+	updates := config.Updates
+	if updates == nil {
+		updates = sdk.OrderBook.Updates()
+	}
+
+	session, err := sdk.NewSession(config.Name, config.Limits)
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	rt := &Runtime{ctx: runCtx, session: session, stop: cancel}
+	component := "strategy." + config.Name
+
+	if err := start(rt, strategy, config, component); err != nil {
+		closeSession(session, config.ShutdownTimeout)
+		return err
+	}
+
+	loop(rt, strategy, config, updates, component)
+
+	call(rt, component, func() { strategy.OnStop(rt) })
+	if err := closeSession(session, config.ShutdownTimeout); err != nil {
+		if stopErr := rt.err(); stopErr != nil {
+			return errors.Join(stopErr, err)
+		}
+		return err
+	}
+	return rt.err()
+}
+
+// start 订阅 token、等待快照并调用 OnStart
+func start(rt *Runtime, strategy Strategy, config *Config, component string) error {
+	if len(config.Tokens) > 0 {
+		if err := rt.session.Subscribe(config.Tokens); err != nil {
+			return fmt.Errorf("failed to subscribe strategy tokens: %w", err)
+		}
+		if config.WaitForSnapshots {
+			waitCtx := rt.ctx
+			if config.SnapshotTimeout > 0 {
+				var cancel context.CancelFunc
+				waitCtx, cancel = context.WithTimeout(rt.ctx, config.SnapshotTimeout)
+				defer cancel()
+			}
+			if err := rt.session.OrderBook().WaitForSnapshots(waitCtx, config.Tokens); err != nil {
+				return fmt.Errorf("failed to wait for strategy snapshots: %w", err)
+			}
+		}
+	}
+
+	var startErr error
+	call(rt, component, func() { startErr = strategy.OnStart(rt) })
+	if startErr != nil {
+		return startErr
+	}
+	return rt.err()
+}
+
+// loop 串行分发事件直到运行上下文结束
+func loop(rt *Runtime, strategy Strategy, config *Config, updates <-chan orderbook.OrderBookUpdate, component string) {
+	fillTicker := time.NewTicker(config.FillPollInterval)
+	defer fillTicker.Stop()
+
+	var timer <-chan time.Time
+	if config.TimerInterval > 0 {
+		ticker := time.NewTicker(config.TimerInterval)
+		defer ticker.Stop()
+		timer = ticker.C
+	}
+
+	for {
+		select {
+		case <-rt.ctx.Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				rt.Stop(fmt.Errorf("orderbook updates channel closed"))
+				return
+			}
+			if rt.session.Owns(update.TokenID) {
+				call(rt, component, func() { strategy.OnBookUpdate(rt, update) })
+			}
+		case <-fillTicker.C:
+			fills, err := rt.session.PollFills(rt.ctx)
+			if err != nil && rt.ctx.Err() == nil {
+				log.Printf("[Polymarket Strategy] %s failed to poll fills: %v", component, err)
+			}
+			for _, fill := range fills {
+				call(rt, component, func() { strategy.OnFill(rt, fill) })
+			}
+		case now := <-timer:
+			call(rt, component, func() { strategy.OnTimer(rt, now) })
+		}
+	}
+}
+
+// call 调用回调，panic 时停止策略
+func call(rt *Runtime, component string, fn func()) {
+	defer common.RecoverPanic(component, rt.Stop)
+	fn()
+}
+
+// closeSession 在 timeout 内撤销策略挂单并释放订阅
+func closeSession(session *polymarket.Session, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return session.Close(ctx)
+}
@@ -0,0 +1,225 @@
+//go:build !nosigning
+
+package strategy
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+
+	polymarket "github.com/binary-jerry/polymarket-sdk"
+	"github.com/binary-jerry/polymarket-sdk/auth"
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// newTestSDK returns a started SDK backed by a silent WebSocket server and an
+// exchange where every order rests and has filled completely by the next sync
+func newTestSDK(t *testing.T) *polymarket.SDK {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	ws := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(ws.Close)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/order":
+			w.Write([]byte(`{"success":true,"orderID":"0x1","status":"live"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/orders":
+			w.Write([]byte(`[]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/data/order/0x1":
+			json.NewEncoder(w).Encode(clob.Order{ID: "0x1", Status: clob.OrderStatusMatched, OriginalSize: decimal.NewFromInt(10), SizeMatched: decimal.NewFromInt(10)})
+		case r.Method == http.MethodDelete && r.URL.Path == "/orders":
+			var body clob.BatchCancelRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			json.NewEncoder(w).Encode(clob.CancelResponse{Canceled: body.OrderIDs})
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	config := polymarket.DefaultConfig()
+	config.WSEndpoint = "ws" + strings.TrimPrefix(ws.URL, "http")
+	config.CLOBEndpoint = api.URL
+	config.MaxRetries = 0
+	creds := &auth.Credentials{
+		APIKey:     "test-api-key",
+		Secret:     base64.StdEncoding.EncodeToString([]byte("test-secret")),
+		Passphrase: "test-passphrase",
+	}
+	sdk, err := polymarket.NewTradingSDK(config, testPrivateKey, creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(sdk.Close)
+	if err := sdk.OrderBook.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return sdk
+}
+
+// recorder logs callbacks; it places one order on start and stops after the first fill
+type recorder struct {
+	Base
+
+	mu         sync.Mutex
+	events     []string
+	panicOn    string
+	startErr   error
+	openOnStop []string
+}
+
+func (r *recorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) OnStart(rt *Runtime) error {
+	r.record("start")
+	if r.startErr != nil {
+		return r.startErr
+	}
+	_, err := rt.CreateOrder(rt.Context(), &clob.CreateOrderRequest{
+		TokenID: "12345",
+		Side:    clob.OrderSideBuy,
+		Price:   decimal.RequireFromString("0.5"),
+		Size:    decimal.NewFromInt(10),
+		Type:    clob.OrderTypeGTC,
+	})
+	return err
+}
+
+func (r *recorder) OnBookUpdate(rt *Runtime, update orderbook.OrderBookUpdate) {
+	if update.TokenID == r.panicOn {
+		panic("boom")
+	}
+	r.record("book:" + update.TokenID)
+}
+
+func (r *recorder) OnFill(rt *Runtime, fill polymarket.SessionFill) {
+	r.record("fill:" + fill.OrderID + ":" + fill.Size.String())
+	rt.Stop(nil)
+}
+
+func (r *recorder) OnStop(rt *Runtime) {
+	r.openOnStop = rt.Session().OpenOrders()
+	r.record("stop")
+}
+
+func testConfig(updates chan orderbook.OrderBookUpdate) *Config {
+	config := DefaultConfig()
+	config.Name = "test"
+	config.Tokens = []string{"12345"}
+	config.WaitForSnapshots = false
+	config.FillPollInterval = 20 * time.Millisecond
+	config.Updates = updates
+	return config
+}
+
+func TestRun_Lifecycle(t *testing.T) {
+	sdk := newTestSDK(t)
+	updates := make(chan orderbook.OrderBookUpdate, 2)
+	// Updates of tokens outside the strategy are skipped
+	updates <- orderbook.OrderBookUpdate{TokenID: "67890", EventType: orderbook.EventTypeBook}
+	updates <- orderbook.OrderBookUpdate{TokenID: "12345", EventType: orderbook.EventTypeBook}
+
+	strategy := &recorder{}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := Run(ctx, sdk, strategy, testConfig(updates)); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+
+	want := []string{"start", "book:12345", "fill:0x1:10", "stop"}
+	if strings.Join(strategy.events, ",") != strings.Join(want, ",") {
+		t.Errorf("events = %v, want %v", strategy.events, want)
+	}
+	if len(strategy.openOnStop) != 0 {
+		t.Errorf("filled order should no longer be open, got %v", strategy.openOnStop)
+	}
+	if sessions := sdk.Sessions(); len(sessions) != 0 {
+		t.Errorf("session should be closed after Run, got %d", len(sessions))
+	}
+	if tokens := sdk.OrderBook.GetSubscribedTokens(); len(tokens) != 0 {
+		t.Errorf("subscriptions should be released, got %v", tokens)
+	}
+}
+
+func TestRun_CallbackPanicStopsStrategy(t *testing.T) {
+	sdk := newTestSDK(t)
+	updates := make(chan orderbook.OrderBookUpdate, 1)
+	updates <- orderbook.OrderBookUpdate{TokenID: "12345", EventType: orderbook.EventTypeBook}
+
+	strategy := &recorder{panicOn: "12345"}
+	config := testConfig(updates)
+	config.FillPollInterval = time.Hour
+
+	err := Run(context.Background(), sdk, strategy, config)
+	if !errors.Is(err, common.ErrPanicRecovered) {
+		t.Fatalf("expected ErrPanicRecovered, got %v", err)
+	}
+	if events := strategy.events; len(events) != 2 || events[1] != "stop" {
+		t.Errorf("OnStop should run after the panic, events = %v", events)
+	}
+	// The resting order is canceled on shutdown
+	if len(strategy.openOnStop) != 1 || len(sdk.Sessions()) != 0 {
+		t.Errorf("open orders on stop = %v, sessions = %d", strategy.openOnStop, len(sdk.Sessions()))
+	}
+}
+
+func TestRun_StartError(t *testing.T) {
+	sdk := newTestSDK(t)
+	startErr := errors.New("not ready")
+	strategy := &recorder{startErr: startErr}
+
+	if err := Run(context.Background(), sdk, strategy, testConfig(make(chan orderbook.OrderBookUpdate))); !errors.Is(err, startErr) {
+		t.Fatalf("expected start error, got %v", err)
+	}
+	if len(strategy.events) != 1 || len(sdk.Sessions()) != 0 {
+		t.Errorf("strategy should not run after a failed start, events = %v", strategy.events)
+	}
+}
+
+func TestRun_ContextCancel(t *testing.T) {
+	sdk := newTestSDK(t)
+	strategy := &recorder{}
+	config := testConfig(make(chan orderbook.OrderBookUpdate))
+	config.FillPollInterval = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := Run(ctx, sdk, strategy, config); err != nil {
+		t.Fatalf("context cancellation should be a clean stop, got %v", err)
+	}
+	if events := strategy.events; len(events) != 2 || events[1] != "stop" {
+		t.Errorf("events = %v", events)
+	}
+}
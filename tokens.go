@@ -0,0 +1,44 @@
+package polymarket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// ValidateTokens 通过 Gamma 市场信息校验 token 是否可以订阅，实现 orderbook.TokenValidator
+// 市场开放且启用订单簿时为 active；市场已关闭、未激活或未启用订单簿时为 closed；查不到所属市场时为 unknown
+func (s *SDK) ValidateTokens(ctx context.Context, tokenIDs []string) (map[string]orderbook.TokenStatus, error) {
+	if s.Markets == nil {
+		return nil, fmt.Errorf("markets client not initialized")
+	}
+
+	statuses := make(map[string]orderbook.TokenStatus, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		statuses[tokenID] = orderbook.TokenStatusUnknown
+	}
+
+	markets, err := s.Markets.GetMarketsByTokenIDs(ctx, tokenIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range markets {
+		status := orderbook.TokenStatusActive
+		if markets[i].Closed || !markets[i].Active || !markets[i].EnableOrderBook {
+			status = orderbook.TokenStatusClosed
+		}
+		for _, tokenID := range markets[i].GetClobTokenIDs() {
+			if _, ok := statuses[tokenID]; ok {
+				statuses[tokenID] = status
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// SubscribeValidated 校验后订阅 token，不存在或已关闭的 token 不会被订阅
+// 存在未通过校验的 token 时返回 *orderbook.InvalidTokensError，其余 token 已正常订阅
+func (s *SDK) SubscribeValidated(ctx context.Context, tokenIDs []string) error {
+	return s.OrderBook.SubscribeValidated(ctx, tokenIDs, s)
+}
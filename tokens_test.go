@@ -0,0 +1,53 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/gamma"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+func TestSDK_ValidateTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query()["clob_token_ids"]; len(got) != 5 {
+			t.Errorf("expected 5 clob_token_ids, got %v", got)
+		}
+		markets := []gamma.Market{
+			{ConditionID: "0xopen", Active: true, EnableOrderBook: true, ClobTokenIds: `["yes-open","no-open"]`},
+			{ConditionID: "0xclosed", Active: true, Closed: true, EnableOrderBook: true, ClobTokenIds: `["yes-closed","no-closed"]`},
+			{ConditionID: "0xnobook", Active: true, ClobTokenIds: `["yes-nobook","no-nobook"]`},
+		}
+		json.NewEncoder(w).Encode(markets)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.GammaEndpoint = server.URL
+	sdk := NewPublicSDK(config)
+	defer sdk.Close()
+
+	statuses, err := sdk.ValidateTokens(context.Background(), []string{"yes-open", "no-open", "yes-closed", "yes-nobook", "missing"})
+	if err != nil {
+		t.Fatalf("ValidateTokens() error: %v", err)
+	}
+
+	expected := map[string]orderbook.TokenStatus{
+		"yes-open":   orderbook.TokenStatusActive,
+		"no-open":    orderbook.TokenStatusActive,
+		"yes-closed": orderbook.TokenStatusClosed,
+		"yes-nobook": orderbook.TokenStatusClosed,
+		"missing":    orderbook.TokenStatusUnknown,
+	}
+	if len(statuses) != len(expected) {
+		t.Errorf("expected %d statuses, got %v", len(expected), statuses)
+	}
+	for tokenID, want := range expected {
+		if statuses[tokenID] != want {
+			t.Errorf("%s: status = %q, want %q", tokenID, statuses[tokenID], want)
+		}
+	}
+}
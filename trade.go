@@ -0,0 +1,179 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/gamma"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// TradeOptions Buy/Sell 的可选参数
+type TradeOptions struct {
+	MaxSlippage decimal.Decimal // 相对最优价允许的最大价格偏移（如 0.02 表示 2 美分）
+	OrderType   clob.OrderType  // FOK（默认，全部成交或取消）或 FAK（能成交多少成交多少）
+	BookTimeout time.Duration   // token 未订阅时等待首个订单簿快照的超时
+}
+
+// DefaultTradeOptions 默认选项
+func DefaultTradeOptions() *TradeOptions {
+	return &TradeOptions{
+		MaxSlippage: decimal.NewFromFloat(0.02),
+		OrderType:   clob.OrderTypeFOK,
+		BookTimeout: 5 * time.Second,
+	}
+}
+
+// TradeResult Buy/Sell 的结果，提交价格、预计与实际成交见嵌入的 BookMarketOrderResult
+type TradeResult struct {
+	Market  *gamma.Market
+	Outcome string
+	TokenID string
+	Order   *clob.BookMarketOrderRequest // 实际提交的请求
+	*clob.BookMarketOrderResult
+}
+
+// Buy 按市场 slug 与结果标签（如 "Yes"）花费 usdcAmount USDC 市价买入
+// 经由 Trading.CreateMarketOrderFromBook 按本地订单簿定价，价格保护为最优价加 MaxSlippage（按 tick 取整）：
+// 保护内深度不足时 FOK 返回 common.ErrInsufficientLiquidity，FAK 接受部分成交
+// 订单簿需已启动（OrderBook.Start），token 未订阅时会自动订阅并等待快照
+func (s *SDK) Buy(ctx context.Context, slug, outcome string, usdcAmount decimal.Decimal, opts *TradeOptions) (*TradeResult, error) {
+	return s.trade(ctx, clob.OrderSideBuy, slug, outcome, usdcAmount, opts)
+}
+
+// Sell 按市场 slug 与结果标签市价卖出 shares 份额，价格保护为最优价减 MaxSlippage，规则同 Buy
+func (s *SDK) Sell(ctx context.Context, slug, outcome string, shares decimal.Decimal, opts *TradeOptions) (*TradeResult, error) {
+	return s.trade(ctx, clob.OrderSideSell, slug, outcome, shares, opts)
+}
+
+// trade Buy/Sell 的公共流程：解析市场 -> 选择 token -> 等待订单簿 -> 按订单簿定价并提交
+func (s *SDK) trade(ctx context.Context, side clob.OrderSide, slug, outcome string, amount decimal.Decimal, opts *TradeOptions) (*TradeResult, error) {
+	if !s.IsTradingEnabled() {
+		return nil, fmt.Errorf("trading client not initialized, use NewSDK with private key")
+	}
+	if opts == nil {
+		opts = DefaultTradeOptions()
+	}
+	if opts.OrderType != clob.OrderTypeFOK && opts.OrderType != clob.OrderTypeFAK {
+		return nil, fmt.Errorf("%w: market orders must be FOK or FAK, got %q", common.ErrInvalidOrderType, opts.OrderType)
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive, got %s", common.ErrInvalidSize, amount)
+	}
+
+	market, err := s.Markets.GetMarketBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !market.IsActive() {
+		return nil, fmt.Errorf("%w: %s", common.ErrMarketClosed, slug)
+	}
+	tokenID, label, err := resolveOutcomeToken(market, outcome)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.waitForBook(ctx, tokenID, opts.BookTimeout); err != nil {
+		return nil, err
+	}
+	bid, ask, _ := s.bookQuotes(tokenID)
+	best := ask
+	if side == clob.OrderSideSell {
+		best = bid
+	}
+	if !best.IsPositive() {
+		return nil, fmt.Errorf("%s %s: %w: no %s liquidity in order book", slug, label, common.ErrInsufficientLiquidity, side)
+	}
+
+	req := tradeRequest(tokenID, side, amount, best, decimal.NewFromFloat(market.OrderPriceMinTickSize), opts)
+	req.IsNegRisk = market.IsNegRisk()
+	result, err := s.Trading.CreateMarketOrderFromBook(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", slug, label, err)
+	}
+
+	return &TradeResult{
+		Market:                market,
+		Outcome:               label,
+		TokenID:               tokenID,
+		Order:                 req,
+		BookMarketOrderResult: result,
+	}, nil
+}
+
+// tradeRequest 构造按订单簿定价的市价单：价格保护为最优价 ± MaxSlippage，按 tick 向内取整并限制在 (0, 1) 内
+func tradeRequest(tokenID string, side clob.OrderSide, amount, best, tick decimal.Decimal, opts *TradeOptions) *clob.BookMarketOrderRequest {
+	if !tick.IsPositive() {
+		tick = decimal.NewFromFloat(0.01)
+	}
+
+	var worst decimal.Decimal
+	if side == clob.OrderSideBuy {
+		worst = best.Add(opts.MaxSlippage).Div(tick).Floor().Mul(tick)
+		if maxPrice := decimal.NewFromInt(1).Sub(tick); worst.GreaterThan(maxPrice) {
+			worst = maxPrice
+		}
+	} else {
+		worst = best.Sub(opts.MaxSlippage).Div(tick).Ceil().Mul(tick)
+		if worst.LessThan(tick) {
+			worst = tick
+		}
+	}
+
+	return &clob.BookMarketOrderRequest{
+		TokenID:    tokenID,
+		Side:       side,
+		Amount:     amount,
+		Type:       opts.OrderType,
+		Slippage:   opts.MaxSlippage,
+		WorstPrice: worst,
+		TickSize:   tick,
+	}
+}
+
+// resolveOutcomeToken 按结果标签（不区分大小写）查找 token
+func resolveOutcomeToken(market *gamma.Market, outcome string) (tokenID, label string, err error) {
+	labels := market.GetOutcomes()
+	tokenIDs := market.GetClobTokenIDs()
+	for i, l := range labels {
+		if strings.EqualFold(strings.TrimSpace(l), strings.TrimSpace(outcome)) && i < len(tokenIDs) {
+			return tokenIDs[i], l, nil
+		}
+	}
+	return "", "", fmt.Errorf("outcome %q not found in market %s, available: %s", outcome, market.Slug, strings.Join(labels, ", "))
+}
+
+// waitForBook 确保 token 的订单簿已初始化，未初始化时订阅并等待首个快照
+func (s *SDK) waitForBook(ctx context.Context, tokenID string, timeout time.Duration) error {
+	if !s.OrderBook.IsStarted() {
+		return orderbook.ErrNotStarted
+	}
+	if s.OrderBook.IsInitialized(tokenID) {
+		return nil
+	}
+
+	if err := s.OrderBook.Subscribe([]string{tokenID}); err != nil {
+		return fmt.Errorf("failed to subscribe %s: %w", tokenID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for !s.OrderBook.IsInitialized(tokenID) {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for order book %s: %w", tokenID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
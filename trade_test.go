@@ -0,0 +1,86 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/clob"
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/gamma"
+)
+
+func TestTradeRequest(t *testing.T) {
+	d := decimal.RequireFromString
+	opts := DefaultTradeOptions()
+
+	tests := []struct {
+		name  string
+		side  clob.OrderSide
+		best  string
+		tick  string
+		worst string
+	}{
+		{"buy", clob.OrderSideBuy, "0.50", "0.01", "0.52"},
+		{"buy floors to tick", clob.OrderSideBuy, "0.505", "0.01", "0.52"},
+		{"buy clamped below 1", clob.OrderSideBuy, "0.99", "0.01", "0.99"},
+		{"sell", clob.OrderSideSell, "0.48", "0.01", "0.46"},
+		{"sell ceils to tick", clob.OrderSideSell, "0.475", "0.01", "0.46"},
+		{"sell clamped above 0", clob.OrderSideSell, "0.01", "0.01", "0.01"},
+		{"coarse tick", clob.OrderSideBuy, "0.5", "0.1", "0.5"},
+		{"zero tick defaults", clob.OrderSideSell, "0.48", "0", "0.46"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tradeRequest("tok", tt.side, d("15"), d(tt.best), d(tt.tick), opts)
+			if !req.WorstPrice.Equal(d(tt.worst)) {
+				t.Errorf("WorstPrice = %s, expected %s", req.WorstPrice, tt.worst)
+			}
+			if req.TokenID != "tok" || req.Side != tt.side || !req.Amount.Equal(d("15")) {
+				t.Errorf("request = %+v", req)
+			}
+			if req.Type != clob.OrderTypeFOK || !req.Slippage.Equal(opts.MaxSlippage) || !req.TickSize.IsPositive() {
+				t.Errorf("request options = %+v", req)
+			}
+		})
+	}
+}
+
+func TestResolveOutcomeToken(t *testing.T) {
+	market := &gamma.Market{Slug: "rain", Outcomes: `["Yes","No"]`, ClobTokenIds: `["t1","t2"]`}
+
+	tokenID, label, err := resolveOutcomeToken(market, "no")
+	if err != nil || tokenID != "t2" || label != "No" {
+		t.Errorf("resolveOutcomeToken(no) = %s, %s, %v", tokenID, label, err)
+	}
+	if _, _, err := resolveOutcomeToken(market, "maybe"); err == nil {
+		t.Error("expected error for unknown outcome")
+	}
+}
+
+func TestSDKBuyValidation(t *testing.T) {
+	sdk, err := NewSDK(nil, sdkTestPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sdk.Close()
+	ctx := context.Background()
+
+	if _, err := sdk.Buy(ctx, "rain", "Yes", decimal.Zero, nil); !errors.Is(err, common.ErrInvalidSize) {
+		t.Errorf("expected ErrInvalidSize, got %v", err)
+	}
+	opts := DefaultTradeOptions()
+	opts.OrderType = clob.OrderTypeGTC
+	if _, err := sdk.Sell(ctx, "rain", "Yes", decimal.NewFromInt(1), opts); !errors.Is(err, common.ErrInvalidOrderType) {
+		t.Errorf("expected ErrInvalidOrderType, got %v", err)
+	}
+
+	if _, err := NewPublicSDK(nil).Buy(ctx, "rain", "Yes", decimal.NewFromInt(1), nil); err == nil {
+		t.Error("expected error without trading client")
+	}
+}
@@ -0,0 +1,348 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/gamma"
+)
+
+// UniverseConfig 流动性市场集合轮换配置
+//
+// 滞回规则：市场进入集合需要排进前 TopN，已跟踪的市场跌出前 TopN + Hysteresis 才移出，
+// 因此集合大小在 TopN 与 TopN + Hysteresis 之间，排名在边界附近波动的市场不会被反复订阅与退订
+type UniverseConfig struct {
+	Name         string                   // 会话名称，在 SDK 内唯一
+	TopN         int                      // 按流动性排名跟踪的市场数
+	Hysteresis   int                      // 已跟踪市场的排名缓冲区
+	Interval     time.Duration            // 刷新周期
+	MinLiquidity float64                  // 流动性下限（LiquidityNum），低于该值的市场不进入排名
+	Filter       *gamma.MarketListParams  // 额外的查询条件（如 TagSlug），Active、Closed、Order、Limit 和分页参数由轮换器设置
+	Match        func(*gamma.Market) bool // 客户端筛选，返回 false 的市场不进入排名
+	Timeout      time.Duration            // 单次刷新（查询与订阅）的超时
+	Changes      int                      // 变更通知 channel 的缓冲大小
+}
+
+// DefaultUniverseConfig 默认配置
+func DefaultUniverseConfig() *UniverseConfig {
+	return &UniverseConfig{
+		Name:       "universe",
+		TopN:       50,
+		Hysteresis: 10,
+		Interval:   10 * time.Minute,
+		Timeout:    30 * time.Second,
+		Changes:    16,
+	}
+}
+
+// UniverseChange 一次刷新对集合的修改
+type UniverseChange struct {
+	Added         []gamma.Market // 新加入的市场，按流动性排名
+	Removed       []gamma.Market // 移出的市场（为上次刷新时的数据）
+	AddedTokens   []string       // 新订阅的 token
+	RemovedTokens []string       // 退订的 token
+	Size          int            // 刷新后集合中的市场数
+	At            time.Time
+}
+
+// Empty 本次刷新是否没有修改集合
+func (c *UniverseChange) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// Universe 按流动性自动轮换的市场集合
+// 定期从 Gamma 查询流动性最高的活跃市场，与当前集合比较后订阅新市场、退订跌出的市场；
+// 订阅经由独立的 Session，与其他会话共享的 token 不会被退订
+type Universe struct {
+	sdk     *SDK
+	config  *UniverseConfig
+	session *Session
+
+	refreshMu sync.Mutex // 串行化刷新
+
+	mu      sync.Mutex
+	tracked map[string]gamma.Market // 市场 ID -> 市场
+	ranks   map[string]int          // 市场 ID -> 最近一次刷新的排名（从 0 开始）
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	changes chan UniverseChange
+}
+
+// NewUniverse 创建流动性市场集合，调用 Start 开始定期刷新，或手动调用 Refresh
+func (s *SDK) NewUniverse(config *UniverseConfig) (*Universe, error) {
+	if config == nil {
+		config = DefaultUniverseConfig()
+	}
+	if config.TopN <= 0 {
+		return nil, fmt.Errorf("%w: universe TopN must be positive", common.ErrInvalidConfig)
+	}
+	if config.Hysteresis < 0 {
+		return nil, fmt.Errorf("%w: universe Hysteresis must not be negative", common.ErrInvalidConfig)
+	}
+	if s.Markets == nil || s.OrderBook == nil {
+		return nil, fmt.Errorf("%w: universe requires market data and orderbook clients", common.ErrInvalidConfig)
+	}
+	if config.Interval <= 0 {
+		config.Interval = 10 * time.Minute
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.Name == "" {
+		config.Name = "universe"
+	}
+
+	session, err := s.NewSession(config.Name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Universe{
+		sdk:     s,
+		config:  config,
+		session: session,
+		tracked: make(map[string]gamma.Market),
+		ranks:   make(map[string]int),
+		changes: make(chan UniverseChange, max(config.Changes, 1)),
+	}, nil
+}
+
+// Session 集合使用的会话，可用 Owns 在共享的 Updates() 循环中识别集合内的 token
+func (u *Universe) Session() *Session {
+	return u.session
+}
+
+// Changes 获取集合变更通知 channel（满时丢弃），只有修改了集合的刷新会发送
+func (u *Universe) Changes() <-chan UniverseChange {
+	return u.changes
+}
+
+// Markets 当前集合中的市场，按最近一次刷新的流动性排名排序
+func (u *Universe) Markets() []gamma.Market {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	result := make([]gamma.Market, 0, len(u.tracked))
+	for _, market := range u.tracked {
+		result = append(result, market)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return u.ranks[result[i].ID] < u.ranks[result[j].ID]
+	})
+	return result
+}
+
+// Tokens 当前集合订阅的 token，已排序
+func (u *Universe) Tokens() []string {
+	return u.session.Tokens()
+}
+
+// Start 立即刷新一次，之后按 Interval 定期刷新；首次刷新失败时返回错误且不启动
+func (u *Universe) Start(ctx context.Context) error {
+	u.mu.Lock()
+	if u.cancel != nil {
+		u.mu.Unlock()
+		return nil
+	}
+	u.mu.Unlock()
+
+	if _, err := u.Refresh(ctx); err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.cancel != nil {
+		return nil
+	}
+	loopCtx, cancel := context.WithCancel(context.Background())
+	u.cancel = cancel
+
+	u.wg.Add(1)
+	go func() {
+		defer u.wg.Done()
+		common.Supervise(loopCtx, "polymarket.universe", nil, u.loop)
+	}()
+	return nil
+}
+
+// Stop 停止定期刷新，集合与订阅保持不变
+func (u *Universe) Stop() {
+	u.mu.Lock()
+	cancel := u.cancel
+	u.cancel = nil
+	u.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		u.wg.Wait()
+	}
+}
+
+// Close 停止刷新并关闭会话，释放集合的全部订阅
+func (u *Universe) Close(ctx context.Context) error {
+	u.Stop()
+
+	u.mu.Lock()
+	u.tracked = make(map[string]gamma.Market)
+	u.ranks = make(map[string]int)
+	u.mu.Unlock()
+
+	return u.session.Close(ctx)
+}
+
+// loop 定期刷新
+func (u *Universe) loop(ctx context.Context) {
+	ticker := time.NewTicker(u.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.Refresh(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("[Polymarket Universe] %s refresh failed: %v", u.config.Name, err)
+			}
+		}
+	}
+}
+
+// Refresh 查询流动性排名并更新集合：先订阅新市场，再退订跌出的市场
+// 查询失败或没有符合条件的市场时保持当前集合不变并返回错误
+func (u *Universe) Refresh(ctx context.Context) (*UniverseChange, error) {
+	u.refreshMu.Lock()
+	defer u.refreshMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, u.config.Timeout)
+	defer cancel()
+
+	candidates, err := u.rank(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("universe %s: no markets matched the filters, keeping current set", u.config.Name)
+	}
+
+	u.mu.Lock()
+	selected, ranks := u.selectLocked(candidates)
+	change := &UniverseChange{At: time.Now()}
+	for _, market := range candidates {
+		if _, ok := selected[market.ID]; ok {
+			if _, tracked := u.tracked[market.ID]; !tracked {
+				change.Added = append(change.Added, market)
+				change.AddedTokens = append(change.AddedTokens, market.GetClobTokenIDs()...)
+			}
+		}
+	}
+	for id, market := range u.tracked {
+		if _, ok := selected[id]; !ok {
+			change.Removed = append(change.Removed, market)
+			change.RemovedTokens = append(change.RemovedTokens, market.GetClobTokenIDs()...)
+		}
+	}
+	u.mu.Unlock()
+	sort.Slice(change.Removed, func(i, j int) bool { return change.Removed[i].ID < change.Removed[j].ID })
+	sort.Strings(change.RemovedTokens)
+
+	if len(change.AddedTokens) > 0 {
+		if err := u.session.Subscribe(change.AddedTokens); err != nil {
+			return nil, fmt.Errorf("universe %s: failed to subscribe: %w", u.config.Name, err)
+		}
+		for i := range change.Added {
+			// 元数据只用于日志与告警展示，失败不影响轮换
+			if err := u.sdk.RegisterMarketMetadata(&change.Added[i]); err != nil {
+				log.Printf("[Polymarket Universe] %s failed to register metadata of %s: %v", u.config.Name, change.Added[i].Slug, err)
+			}
+		}
+	}
+	var unsubscribeErr error
+	if len(change.RemovedTokens) > 0 {
+		if err := u.session.Unsubscribe(change.RemovedTokens); err != nil {
+			unsubscribeErr = fmt.Errorf("universe %s: failed to unsubscribe: %w", u.config.Name, err)
+		}
+	}
+
+	u.mu.Lock()
+	u.tracked = selected
+	u.ranks = ranks
+	change.Size = len(selected)
+	u.mu.Unlock()
+
+	if !change.Empty() {
+		select {
+		case u.changes <- *change:
+		default:
+		}
+	}
+	return change, unsubscribeErr
+}
+
+// rank 查询按流动性降序排列、符合筛选条件的活跃市场，最多 TopN + Hysteresis 个
+func (u *Universe) rank(ctx context.Context) ([]gamma.Market, error) {
+	limit := u.config.TopN + u.config.Hysteresis
+
+	params := gamma.MarketListParams{}
+	if u.config.Filter != nil {
+		params = *u.config.Filter
+	}
+	params.Active = gamma.BoolPtr(true)
+	params.Closed = gamma.BoolPtr(false)
+	params.Order = "liquidity"
+	params.Ascending = false
+	params.Cursor = ""
+	params.Offset = 0
+	// 客户端筛选会过滤掉部分市场，多取一些以填满排名
+	params.Limit = limit * 2
+
+	resp, err := u.sdk.Markets.GetMarkets(ctx, &params)
+	if err != nil {
+		return nil, fmt.Errorf("universe %s: %w", u.config.Name, err)
+	}
+
+	candidates := make([]gamma.Market, 0, limit)
+	for i := range resp.Data {
+		market := &resp.Data[i]
+		if market.Closed || len(market.GetClobTokenIDs()) == 0 {
+			continue
+		}
+		if market.LiquidityNum < u.config.MinLiquidity {
+			continue
+		}
+		if u.config.Match != nil && !u.config.Match(market) {
+			continue
+		}
+		candidates = append(candidates, *market)
+		if len(candidates) == limit {
+			break
+		}
+	}
+	// 接口已按流动性排序，这里保证排名稳定
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LiquidityNum > candidates[j].LiquidityNum
+	})
+	return candidates, nil
+}
+
+// selectLocked 按滞回规则选出新集合（调用者需持有锁）
+func (u *Universe) selectLocked(candidates []gamma.Market) (map[string]gamma.Market, map[string]int) {
+	selected := make(map[string]gamma.Market)
+	ranks := make(map[string]int)
+	for rank, market := range candidates {
+		_, tracked := u.tracked[market.ID]
+		if rank < u.config.TopN || (tracked && rank < u.config.TopN+u.config.Hysteresis) {
+			selected[market.ID] = market
+			ranks[market.ID] = rank
+		}
+	}
+	return selected, ranks
+}
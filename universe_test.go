@@ -0,0 +1,201 @@
+//go:build !nosigning
+
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/binary-jerry/polymarket-sdk/gamma"
+)
+
+// fakeGamma serves /markets from a mutable liquidity ranking
+type fakeGamma struct {
+	mu        sync.Mutex
+	liquidity map[string]float64 // market ID -> liquidity
+	queries   []string
+}
+
+func (g *fakeGamma) set(liquidity map[string]float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.liquidity = liquidity
+}
+
+func (g *fakeGamma) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.queries = append(g.queries, r.URL.RawQuery)
+
+	markets := make([]gamma.Market, 0, len(g.liquidity))
+	for id, liquidity := range g.liquidity {
+		markets = append(markets, gamma.Market{
+			ID:           id,
+			Slug:         "market-" + id,
+			Active:       true,
+			ClobTokenIds: fmt.Sprintf(`["%s1","%s2"]`, id, id),
+			LiquidityNum: liquidity,
+		})
+	}
+	slices.SortFunc(markets, func(a, b gamma.Market) int {
+		switch {
+		case a.LiquidityNum > b.LiquidityNum:
+			return -1
+		case a.LiquidityNum < b.LiquidityNum:
+			return 1
+		}
+		return 0
+	})
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(markets)
+}
+
+func newUniverseTestSDK(t *testing.T) (*SDK, *fakeGamma) {
+	t.Helper()
+	sdk, _ := newSessionTestSDK(t)
+	markets := &fakeGamma{}
+	server := httptest.NewServer(markets)
+	t.Cleanup(server.Close)
+
+	config := gamma.DefaultConfig()
+	config.Endpoint = server.URL
+	config.MaxRetries = 0
+	sdk.Markets = gamma.NewClient(config)
+	return sdk, markets
+}
+
+func marketIDs(markets []gamma.Market) []string {
+	ids := make([]string, len(markets))
+	for i, market := range markets {
+		ids[i] = market.ID
+	}
+	return ids
+}
+
+func TestUniverse_RefreshWithHysteresis(t *testing.T) {
+	sdk, markets := newUniverseTestSDK(t)
+	config := DefaultUniverseConfig()
+	config.TopN = 2
+	config.Hysteresis = 1
+	config.MinLiquidity = 10
+	universe, err := sdk.NewUniverse(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	markets.set(map[string]float64{"1": 100, "2": 90, "3": 80, "4": 5})
+	change, err := universe.Refresh(ctx)
+	if err != nil {
+		t.Fatalf("Refresh() error: %v", err)
+	}
+	if ids := marketIDs(change.Added); !slices.Equal(ids, []string{"1", "2"}) {
+		t.Errorf("added = %v", ids)
+	}
+	if tokens := universe.Tokens(); !slices.Equal(tokens, []string{"11", "12", "21", "22"}) {
+		t.Errorf("tokens = %v", tokens)
+	}
+	if query := markets.queries[0]; !containsAll(query, "order=liquidity", "active=true", "closed=false") {
+		t.Errorf("unexpected query %s", query)
+	}
+
+	// Market 2 drops to rank 3, within the hysteresis buffer: it is kept and 3 joins
+	markets.set(map[string]float64{"1": 100, "3": 95, "2": 90})
+	change, err = universe.Refresh(ctx)
+	if err != nil {
+		t.Fatalf("Refresh() error: %v", err)
+	}
+	if len(change.Removed) != 0 || !slices.Equal(marketIDs(change.Added), []string{"3"}) || change.Size != 3 {
+		t.Errorf("unexpected change %+v", change)
+	}
+	if ids := marketIDs(universe.Markets()); !slices.Equal(ids, []string{"1", "3", "2"}) {
+		t.Errorf("markets = %v", ids)
+	}
+
+	// Market 2 falls out of the buffer and is unsubscribed
+	markets.set(map[string]float64{"1": 100, "5": 97, "3": 95, "2": 90})
+	change, err = universe.Refresh(ctx)
+	if err != nil {
+		t.Fatalf("Refresh() error: %v", err)
+	}
+	if !slices.Equal(marketIDs(change.Removed), []string{"2"}) || !slices.Equal(change.RemovedTokens, []string{"21", "22"}) {
+		t.Errorf("unexpected change %+v", change)
+	}
+	if universe.Session().Owns("21") || !universe.Session().Owns("51") {
+		t.Errorf("tokens = %v", universe.Tokens())
+	}
+
+	// An unchanged ranking does not notify
+	if change, err := universe.Refresh(ctx); err != nil || !change.Empty() {
+		t.Errorf("Refresh() = %+v, %v", change, err)
+	}
+	if n := len(universe.Changes()); n != 3 {
+		t.Errorf("expected 3 change notifications, got %d", n)
+	}
+}
+
+func TestUniverse_KeepsSetWhenNothingMatches(t *testing.T) {
+	sdk, markets := newUniverseTestSDK(t)
+	config := DefaultUniverseConfig()
+	config.TopN = 1
+	universe, err := sdk.NewUniverse(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	markets.set(map[string]float64{"1": 100})
+	if err := universe.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer universe.Stop()
+
+	markets.set(map[string]float64{})
+	if _, err := universe.Refresh(ctx); err == nil {
+		t.Error("expected an error for an empty ranking")
+	}
+	if tokens := universe.Tokens(); len(tokens) != 2 {
+		t.Errorf("current set should be kept, tokens = %v", tokens)
+	}
+
+	// Tokens also held by another session stay subscribed after the universe closes
+	other, err := sdk.NewSession("other", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Subscribe([]string{"11"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := universe.Close(ctx); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if tokens := sdk.OrderBook.GetSubscribedTokens(); !slices.Equal(tokens, []string{"11"}) {
+		t.Errorf("subscribed tokens = %v", tokens)
+	}
+}
+
+func TestNewUniverse_InvalidConfig(t *testing.T) {
+	sdk, _ := newUniverseTestSDK(t)
+	config := DefaultUniverseConfig()
+	config.TopN = 0
+	if _, err := sdk.NewUniverse(config); err == nil {
+		t.Error("expected an error for TopN = 0")
+	}
+}
+
+func containsAll(query string, parts ...string) bool {
+	fields := strings.Split(query, "&")
+	for _, part := range parts {
+		if !slices.Contains(fields, part) {
+			return false
+		}
+	}
+	return true
+}
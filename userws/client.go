@@ -0,0 +1,506 @@
+// Package userws 订阅 Polymarket 认证 USER WebSocket 频道，实时接收自己账户的订单挂出、成交和撤销事件
+package userws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+// DefaultEndpoint USER 频道地址
+const DefaultEndpoint = "wss://ws-subscriptions-clob.polymarket.com/ws/user"
+
+// ErrAlreadyStarted 客户端已启动
+var ErrAlreadyStarted = errors.New("user stream already started")
+
+// Config USER 频道客户端配置
+type Config struct {
+	Endpoint string   // WebSocket 端点，默认 DefaultEndpoint
+	Markets  []string // 只接收这些市场（condition ID）的事件，为空表示接收全部
+
+	PingInterval         time.Duration // 心跳间隔
+	PongTimeout          time.Duration // 心跳间隔之外等待 pong 或任意消息的时长，超时视为断线
+	HandshakeTimeout     time.Duration // 握手超时
+	ReconnectMinInterval time.Duration // 最小重连间隔
+	ReconnectMaxInterval time.Duration // 最大重连间隔
+	ChannelSize          int           // 事件通道缓冲，满时丢弃新事件并计入 Dropped
+}
+
+// DefaultConfig 默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Endpoint:             DefaultEndpoint,
+		PingInterval:         10 * time.Second,
+		PongTimeout:          10 * time.Second,
+		HandshakeTimeout:     10 * time.Second,
+		ReconnectMinInterval: time.Second,
+		ReconnectMaxInterval: 30 * time.Second,
+		ChannelSize:          1000,
+	}
+}
+
+// subscribeMessage 连接后发送的认证订阅消息
+type subscribeMessage struct {
+	Auth    authPayload `json:"auth"`
+	Markets []string    `json:"markets"`
+	Type    string      `json:"type"`
+}
+
+// authPayload L2 凭证
+type authPayload struct {
+	APIKey     string `json:"apiKey"`
+	Secret     string `json:"secret"`
+	Passphrase string `json:"passphrase"`
+}
+
+// operationMessage 连接建立后追加或取消订阅市场
+type operationMessage struct {
+	Markets   []string `json:"markets"`
+	Operation string   `json:"operation"`
+}
+
+// Client USER 频道客户端（单连接，断线自动重连）
+// 事件通过 Events 读取，重连成功会推送 EventTypeConnected；Close 或 ctx 结束后 Events 通道关闭
+type Client struct {
+	config *Config
+
+	mu      sync.RWMutex
+	creds   *common.Credentials
+	markets []string
+	conn    *websocket.Conn
+	state   orderbook.ConnectionState
+	started bool
+
+	writeMu sync.Mutex // 串行化数据帧写入
+
+	events  chan Event
+	dropped atomic.Int64
+
+	closeChan chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+
+	now func() time.Time
+}
+
+// NewClient 创建 USER 频道客户端，creds 为 L2 API 凭证
+func NewClient(creds *common.Credentials, config *Config) (*Client, error) {
+	if creds == nil || creds.APIKey == "" || creds.Secret == "" || creds.Passphrase == "" {
+		return nil, fmt.Errorf("user stream: %w", common.ErrInvalidCredentials)
+	}
+
+	cfg := DefaultConfig()
+	if config != nil {
+		*cfg = *config
+	}
+	defaults := DefaultConfig()
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defaults.Endpoint
+	}
+	if cfg.PingInterval <= 0 {
+		cfg.PingInterval = defaults.PingInterval
+	}
+	if cfg.PongTimeout <= 0 {
+		cfg.PongTimeout = defaults.PongTimeout
+	}
+	if cfg.HandshakeTimeout <= 0 {
+		cfg.HandshakeTimeout = defaults.HandshakeTimeout
+	}
+	if cfg.ReconnectMinInterval <= 0 {
+		cfg.ReconnectMinInterval = defaults.ReconnectMinInterval
+	}
+	if cfg.ReconnectMaxInterval < cfg.ReconnectMinInterval {
+		cfg.ReconnectMaxInterval = cfg.ReconnectMinInterval
+	}
+	if cfg.ChannelSize <= 0 {
+		cfg.ChannelSize = defaults.ChannelSize
+	}
+
+	return &Client{
+		config:    cfg,
+		creds:     creds,
+		markets:   append([]string(nil), cfg.Markets...),
+		state:     orderbook.StateDisconnected,
+		events:    make(chan Event, cfg.ChannelSize),
+		closeChan: make(chan struct{}),
+		done:      make(chan struct{}),
+		now:       time.Now,
+	}, nil
+}
+
+// Start 建立连接并发送认证订阅，首次连接失败直接返回错误（可重试），之后断线自动重连
+// ctx 结束等同于 Close
+func (c *Client) Start(ctx context.Context) error {
+	c.mu.Lock()
+	if c.started {
+		c.mu.Unlock()
+		return ErrAlreadyStarted
+	}
+	c.state = orderbook.StateConnecting
+	c.mu.Unlock()
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		c.setState(orderbook.StateDisconnected)
+		return err
+	}
+
+	c.mu.Lock()
+	if c.closed(ctx) {
+		c.mu.Unlock()
+		conn.Close()
+		return common.ErrSessionClosed
+	}
+	c.started = true
+	c.mu.Unlock()
+
+	go c.run(ctx, conn)
+	return nil
+}
+
+// Events 事件通道
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+// Dropped 因事件通道已满丢弃的事件数
+func (c *Client) Dropped() int64 {
+	return c.dropped.Load()
+}
+
+// State 连接状态
+func (c *Client) State() orderbook.ConnectionState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// Markets 当前订阅的市场，为空表示接收全部
+func (c *Client) Markets() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.markets...)
+}
+
+// SetCredentials 更新凭证（如轮换 API key 后），在下次重连时生效
+func (c *Client) SetCredentials(creds *common.Credentials) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.creds = creds
+}
+
+// Subscribe 追加订阅市场，已连接时立即发送，重连时随认证消息一起发送
+func (c *Client) Subscribe(markets ...string) error {
+	c.mu.Lock()
+	added := make([]string, 0, len(markets))
+	for _, market := range markets {
+		if !contains(c.markets, market) {
+			c.markets = append(c.markets, market)
+			added = append(added, market)
+		}
+	}
+	c.mu.Unlock()
+
+	if len(added) == 0 {
+		return nil
+	}
+	return c.sendOperation(added, "subscribe")
+}
+
+// Unsubscribe 取消订阅市场
+// 注意：取消全部市场后重连时 markets 为空，服务端会推送全部市场的事件
+func (c *Client) Unsubscribe(markets ...string) error {
+	c.mu.Lock()
+	removed := make([]string, 0, len(markets))
+	kept := c.markets[:0]
+	for _, market := range c.markets {
+		if contains(markets, market) {
+			removed = append(removed, market)
+		} else {
+			kept = append(kept, market)
+		}
+	}
+	c.markets = kept
+	c.mu.Unlock()
+
+	if len(removed) == 0 {
+		return nil
+	}
+	return c.sendOperation(removed, "unsubscribe")
+}
+
+// Close 关闭客户端，等待后台 goroutine 退出
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closeChan)
+
+		c.mu.Lock()
+		c.state = orderbook.StateClosed
+		conn := c.conn
+		started := c.started
+		c.mu.Unlock()
+
+		if conn != nil {
+			conn.Close()
+		}
+		if started {
+			<-c.done
+		} else {
+			close(c.events)
+		}
+	})
+}
+
+// sendOperation 已连接时发送订阅变更，未连接时只更新本地列表
+func (c *Client) sendOperation(markets []string, operation string) error {
+	c.mu.RLock()
+	conn := c.conn
+	c.mu.RUnlock()
+	if conn == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(operationMessage{Markets: markets, Operation: operation})
+	if err != nil {
+		return fmt.Errorf("marshal %s message: %w", operation, err)
+	}
+	if err := c.write(conn, data); err != nil {
+		return fmt.Errorf("send %s message: %w", operation, err)
+	}
+	return nil
+}
+
+// dial 建立连接并发送认证订阅消息
+func (c *Client) dial(ctx context.Context) (*websocket.Conn, error) {
+	dialer := websocket.Dialer{HandshakeTimeout: c.config.HandshakeTimeout}
+	conn, resp, err := dialer.DialContext(ctx, c.config.Endpoint, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dial user channel: %w", err)
+	}
+
+	c.mu.RLock()
+	creds := c.creds
+	msg := subscribeMessage{
+		Markets: append([]string{}, c.markets...),
+		Type:    "user",
+	}
+	c.mu.RUnlock()
+	if creds != nil {
+		msg.Auth = authPayload{APIKey: creds.APIKey, Secret: creds.Secret, Passphrase: creds.Passphrase}
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("marshal subscribe message: %w", err)
+	}
+	if err := c.write(conn, data); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("send subscribe message: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed(ctx) {
+		// Close 可能已在握手期间执行，不再保留新连接
+		conn.Close()
+		return nil, common.ErrSessionClosed
+	}
+	c.conn = conn
+	c.state = orderbook.StateConnected
+	return conn, nil
+}
+
+// run 读取消息并在断线后重连，退出时关闭事件通道
+func (c *Client) run(ctx context.Context, conn *websocket.Conn) {
+	defer close(c.done)
+	defer close(c.events)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.Close()
+		case <-c.closeChan:
+		}
+	}()
+
+	for attempts := 0; ; {
+		c.emit(Event{Type: EventTypeConnected, ReceivedAt: c.now()})
+		c.serve(conn)
+
+		c.mu.Lock()
+		c.conn = nil
+		c.mu.Unlock()
+
+		for {
+			if c.closed(ctx) {
+				return
+			}
+			c.setState(orderbook.StateReconnecting)
+
+			attempts++
+			backoff := c.backoff(attempts)
+			log.Printf("[ Polymarket UserWS] reconnecting in %v (attempt %d)", backoff, attempts)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			case <-c.closeChan:
+				return
+			}
+
+			var err error
+			if conn, err = c.dial(ctx); err != nil {
+				log.Printf("[ Polymarket UserWS] reconnect failed: %v", err)
+				continue
+			}
+			log.Printf("[ Polymarket UserWS] reconnected successfully")
+			attempts = 0
+			break
+		}
+	}
+}
+
+// serve 在单个连接上读取消息，连接断开时返回
+func (c *Client) serve(conn *websocket.Conn) {
+	defer conn.Close()
+	defer common.RecoverPanic("userws.read", nil)
+
+	deadline := c.config.PingInterval + c.config.PongTimeout
+	conn.SetReadDeadline(c.now().Add(deadline))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(c.now().Add(deadline))
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go c.heartbeat(conn, stop)
+
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-c.closeChan:
+			default:
+				log.Printf("[ Polymarket UserWS] read error: %v", err)
+			}
+			return
+		}
+		conn.SetReadDeadline(c.now().Add(deadline))
+
+		if messageType != websocket.TextMessage || string(data) == "PONG" {
+			continue
+		}
+
+		events, err := parseEvents(data, c.now())
+		if err != nil {
+			log.Printf("[ Polymarket UserWS] %v", err)
+			continue
+		}
+		for _, event := range events {
+			c.emit(event)
+		}
+	}
+}
+
+// heartbeat 定期发送 ping 控制帧
+func (c *Client) heartbeat(conn *websocket.Conn, stop <-chan struct{}) {
+	defer common.RecoverPanic("userws.heartbeat", nil)
+
+	ticker := time.NewTicker(c.config.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			// WriteControl 可与其他写入并发调用
+			if err := conn.WriteControl(websocket.PingMessage, nil, c.now().Add(c.config.PongTimeout)); err != nil {
+				log.Printf("[ Polymarket UserWS] ping failed: %v", err)
+				conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// write 发送文本消息
+func (c *Client) write(conn *websocket.Conn, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// emit 推送事件，通道满时丢弃
+func (c *Client) emit(event Event) {
+	select {
+	case c.events <- event:
+	default:
+		if n := c.dropped.Add(1); n == 1 || n%100 == 0 {
+			log.Printf("[ Polymarket UserWS] event channel full, %d events dropped", n)
+		}
+	}
+}
+
+// backoff 指数退避，带 ±20% 抖动
+func (c *Client) backoff(attempts int) time.Duration {
+	backoff := c.config.ReconnectMinInterval
+	for i := 1; i < attempts && backoff < c.config.ReconnectMaxInterval; i++ {
+		backoff *= 2
+	}
+	if backoff > c.config.ReconnectMaxInterval {
+		backoff = c.config.ReconnectMaxInterval
+	}
+
+	backoff += time.Duration((rand.Float64()*0.4 - 0.2) * float64(backoff))
+	if backoff < c.config.ReconnectMinInterval {
+		backoff = c.config.ReconnectMinInterval
+	}
+	return backoff
+}
+
+// closed 客户端是否已关闭
+func (c *Client) closed(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	case <-c.closeChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// setState 更新连接状态，关闭后不再变化
+func (c *Client) setState(state orderbook.ConnectionState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.state != orderbook.StateClosed {
+		c.state = state
+	}
+}
+
+// contains 检查列表是否包含 s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
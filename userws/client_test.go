@@ -0,0 +1,273 @@
+package userws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+	"github.com/binary-jerry/polymarket-sdk/orderbook"
+)
+
+const orderMessage = `{"asset_id":"yes","associate_trades":null,"event_type":"order","id":"0xorder","market":"0xmarket",
+"order_owner":"owner-1","original_size":"10","outcome":"YES","owner":"owner-1","price":"0.57","side":"SELL",
+"size_matched":"4","timestamp":"1672290687","type":"UPDATE"}`
+
+const tradeMessage = `[{"asset_id":"yes","event_type":"trade","id":"trade-1","last_update":"1672290701",
+"maker_orders":[{"asset_id":"yes","matched_amount":"10","order_id":"0xmaker","outcome":"YES","owner":"owner-1","price":"0.57"},
+{"asset_id":"yes","matched_amount":"5","order_id":"0xother","outcome":"YES","owner":"owner-2","price":"0.57"}],
+"market":"0xmarket","matchtime":"1672290701","outcome":"YES","owner":"owner-3","price":"0.57","side":"BUY","size":"15",
+"status":"MATCHED","taker_order_id":"0xtaker","timestamp":"1672290701000","trade_owner":"owner-3","type":"TRADE"},
+{"event_type":"unknown"}]`
+
+var testCreds = &common.Credentials{APIKey: "key", Secret: "c2VjcmV0", Passphrase: "pass"}
+
+// userServer is a fake USER channel: it records inbound messages and lets tests push or drop connections
+type userServer struct {
+	*httptest.Server
+	inbound chan string
+	conns   chan *websocket.Conn
+}
+
+func newUserServer(t *testing.T) *userServer {
+	t.Helper()
+
+	s := &userServer{inbound: make(chan string, 16), conns: make(chan *websocket.Conn, 4)}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s.conns <- conn
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			s.inbound <- string(data)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *userServer) endpoint() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func (s *userServer) nextConn(t *testing.T) *websocket.Conn {
+	t.Helper()
+	select {
+	case conn := <-s.conns:
+		return conn
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for connection")
+		return nil
+	}
+}
+
+func (s *userServer) nextMessage(t *testing.T) map[string]any {
+	t.Helper()
+	select {
+	case data := <-s.inbound:
+		var msg map[string]any
+		if err := json.Unmarshal([]byte(data), &msg); err != nil {
+			t.Fatalf("decode %q: %v", data, err)
+		}
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil
+	}
+}
+
+func nextEvent(t *testing.T, client *Client) Event {
+	t.Helper()
+	select {
+	case event, ok := <-client.Events():
+		if !ok {
+			t.Fatal("event channel closed")
+		}
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return Event{}
+	}
+}
+
+func newTestClient(t *testing.T, server *userServer) *Client {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.Endpoint = server.endpoint()
+	config.Markets = []string{"0xmarket"}
+	config.ReconnectMinInterval = 10 * time.Millisecond
+	config.ReconnectMaxInterval = 10 * time.Millisecond
+	client, err := NewClient(testCreds, config)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestNewClient_RequiresCredentials(t *testing.T) {
+	if _, err := NewClient(nil, nil); !errors.Is(err, common.ErrInvalidCredentials) {
+		t.Errorf("nil credentials: err = %v", err)
+	}
+	if _, err := NewClient(&common.Credentials{APIKey: "key"}, nil); !errors.Is(err, common.ErrInvalidCredentials) {
+		t.Errorf("partial credentials: err = %v", err)
+	}
+}
+
+func TestClient_SubscribeAndEvents(t *testing.T) {
+	server := newUserServer(t)
+	client := newTestClient(t, server)
+
+	if err := client.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	if err := client.Start(context.Background()); !errors.Is(err, ErrAlreadyStarted) {
+		t.Errorf("second Start() err = %v", err)
+	}
+	conn := server.nextConn(t)
+
+	sub := server.nextMessage(t)
+	authMsg, _ := sub["auth"].(map[string]any)
+	if sub["type"] != "user" || authMsg["apiKey"] != "key" || authMsg["passphrase"] != "pass" {
+		t.Errorf("unexpected subscribe message: %v", sub)
+	}
+	if markets, _ := sub["markets"].([]any); len(markets) != 1 || markets[0] != "0xmarket" {
+		t.Errorf("unexpected markets: %v", sub["markets"])
+	}
+	if event := nextEvent(t, client); event.Type != EventTypeConnected {
+		t.Errorf("first event = %s, expected connected", event.Type)
+	}
+	if client.State() != orderbook.StateConnected {
+		t.Errorf("State() = %s", client.State())
+	}
+
+	conn.WriteMessage(websocket.TextMessage, []byte("PONG"))
+	conn.WriteMessage(websocket.TextMessage, []byte(orderMessage))
+	conn.WriteMessage(websocket.TextMessage, []byte(tradeMessage))
+
+	order := nextEvent(t, client)
+	if order.Type != EventTypeOrder || order.Order.Type != OrderUpdate || order.Order.Side != common.SideSell {
+		t.Fatalf("unexpected order event: %+v", order)
+	}
+	if !order.Order.Remaining().Equal(decimal.NewFromInt(6)) || order.Order.Time() != time.Unix(1672290687, 0) {
+		t.Errorf("unexpected order fields: %+v", order.Order)
+	}
+
+	trade := nextEvent(t, client)
+	if trade.Type != EventTypeTrade || trade.Trade.Status != TradeMatched || trade.Trade.IsFinal() {
+		t.Fatalf("unexpected trade event: %+v", trade)
+	}
+	mine := trade.Trade.MakerOrdersFor("owner-1")
+	if len(mine) != 1 || mine[0].OrderID != "0xmaker" || !mine[0].MatchedAmount.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("MakerOrdersFor() = %+v", mine)
+	}
+	if trade.Trade.Time() != time.UnixMilli(1672290701000) {
+		t.Errorf("millisecond timestamp parsed as %v", trade.Trade.Time())
+	}
+
+	if err := client.Subscribe("0xmarket", "0xother"); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	op := server.nextMessage(t)
+	if markets, _ := op["markets"].([]any); op["operation"] != "subscribe" || len(markets) != 1 || markets[0] != "0xother" {
+		t.Errorf("unexpected subscribe operation: %v", op)
+	}
+	if err := client.Unsubscribe("0xmarket"); err != nil {
+		t.Fatalf("Unsubscribe() error: %v", err)
+	}
+	if op := server.nextMessage(t); op["operation"] != "unsubscribe" {
+		t.Errorf("unexpected unsubscribe operation: %v", op)
+	}
+	if got := client.Markets(); len(got) != 1 || got[0] != "0xother" {
+		t.Errorf("Markets() = %v", got)
+	}
+}
+
+func TestClient_Reconnect(t *testing.T) {
+	server := newUserServer(t)
+	client := newTestClient(t, server)
+
+	if err := client.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	conn := server.nextConn(t)
+	server.nextMessage(t)
+	nextEvent(t, client)
+
+	// credentials rotated while connected apply on the next connection
+	client.SetCredentials(&common.Credentials{APIKey: "rotated", Secret: "c2VjcmV0", Passphrase: "pass"})
+	conn.Close()
+
+	server.nextConn(t)
+	sub := server.nextMessage(t)
+	if authMsg, _ := sub["auth"].(map[string]any); authMsg["apiKey"] != "rotated" {
+		t.Errorf("resubscribe used stale credentials: %v", sub)
+	}
+	if event := nextEvent(t, client); event.Type != EventTypeConnected {
+		t.Errorf("expected connected event after reconnect, got %s", event.Type)
+	}
+}
+
+func TestClient_Close(t *testing.T) {
+	server := newUserServer(t)
+	client := newTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	nextEvent(t, client)
+
+	cancel()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-client.Events():
+			if !ok {
+				if client.State() != orderbook.StateClosed {
+					t.Errorf("State() = %s after close", client.State())
+				}
+				return
+			}
+		case <-deadline:
+			t.Fatal("event channel not closed after context cancel")
+		}
+	}
+}
+
+func TestClient_StartFailure(t *testing.T) {
+	config := DefaultConfig()
+	config.Endpoint = "ws://127.0.0.1:1"
+	config.HandshakeTimeout = time.Second
+	client, err := NewClient(testCreds, config)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+
+	if err := client.Start(context.Background()); err == nil {
+		t.Fatal("expected dial error")
+	}
+	if client.State() != orderbook.StateDisconnected {
+		t.Errorf("State() = %s", client.State())
+	}
+	client.Close()
+	if _, ok := <-client.Events(); ok {
+		t.Error("event channel should be closed")
+	}
+}
@@ -0,0 +1,270 @@
+package userws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/binary-jerry/polymarket-sdk/common"
+)
+
+// EventType 用户频道事件类型
+type EventType string
+
+const (
+	EventTypeOrder EventType = "order" // 订单挂出、部分成交或撤销
+	EventTypeTrade EventType = "trade" // 成交及其链上状态变化
+	// EventTypeConnected 连接（含重连）成功并已发送订阅，由客户端生成
+	// 断线期间的事件不会补发，收到后应通过 GetOpenOrders/GetTrades 对账
+	EventTypeConnected EventType = "connected"
+)
+
+// OrderEventType 订单事件子类型
+type OrderEventType string
+
+const (
+	OrderPlacement    OrderEventType = "PLACEMENT"    // 订单挂出
+	OrderUpdate       OrderEventType = "UPDATE"       // 订单部分成交
+	OrderCancellation OrderEventType = "CANCELLATION" // 订单撤销
+)
+
+// TradeStatus 成交状态
+type TradeStatus string
+
+const (
+	TradeMatched   TradeStatus = "MATCHED"   // 撮合成功，等待上链
+	TradeMined     TradeStatus = "MINED"     // 已打包
+	TradeConfirmed TradeStatus = "CONFIRMED" // 已确认，最终状态
+	TradeRetrying  TradeStatus = "RETRYING"  // 上链失败，正在重试
+	TradeFailed    TradeStatus = "FAILED"    // 失败，最终状态
+)
+
+// Event 用户频道事件，按 Type 只填充 Order 或 Trade 之一
+type Event struct {
+	Type       EventType
+	Order      *OrderEvent
+	Trade      *TradeEvent
+	ReceivedAt time.Time
+}
+
+// OrderEvent 订单事件
+type OrderEvent struct {
+	ID              string         // 订单 ID
+	Type            OrderEventType // PLACEMENT/UPDATE/CANCELLATION
+	Market          string         // condition ID
+	AssetID         string         // token ID
+	Outcome         string
+	Owner           string // API key 对应的 owner
+	Side            common.Side
+	Price           decimal.Decimal
+	OriginalSize    decimal.Decimal
+	SizeMatched     decimal.Decimal
+	AssociateTrades []string // 关联的成交 ID
+	Timestamp       int64    // 服务端时间戳，秒或毫秒，见 Time
+}
+
+// Remaining 订单剩余未成交数量
+func (e *OrderEvent) Remaining() decimal.Decimal {
+	return e.OriginalSize.Sub(e.SizeMatched)
+}
+
+// Time 服务端时间
+func (e *OrderEvent) Time() time.Time {
+	return unixTime(e.Timestamp)
+}
+
+// MakerOrder 成交中的挂单方订单
+type MakerOrder struct {
+	OrderID       string
+	AssetID       string
+	Owner         string
+	Outcome       string
+	Price         decimal.Decimal
+	MatchedAmount decimal.Decimal
+}
+
+// TradeEvent 成交事件，同一成交会随状态变化（MATCHED -> MINED -> CONFIRMED）多次推送
+type TradeEvent struct {
+	ID           string // 成交 ID
+	Status       TradeStatus
+	Market       string // condition ID
+	AssetID      string // token ID
+	Outcome      string
+	Side         common.Side // 吃单方方向
+	Price        decimal.Decimal
+	Size         decimal.Decimal
+	TakerOrderID string
+	MakerOrders  []MakerOrder
+	Owner        string
+	TradeOwner   string
+	MatchTime    int64
+	LastUpdate   int64
+	Timestamp    int64
+}
+
+// IsFinal 成交是否已到最终状态
+func (e *TradeEvent) IsFinal() bool {
+	return e.Status == TradeConfirmed || e.Status == TradeFailed
+}
+
+// MakerOrdersFor 返回属于 owner 的挂单方订单，用于区分自己作为 maker 成交的部分
+func (e *TradeEvent) MakerOrdersFor(owner string) []MakerOrder {
+	var orders []MakerOrder
+	for _, order := range e.MakerOrders {
+		if order.Owner == owner {
+			orders = append(orders, order)
+		}
+	}
+	return orders
+}
+
+// Time 服务端时间
+func (e *TradeEvent) Time() time.Time {
+	return unixTime(e.Timestamp)
+}
+
+// rawMessage 用户频道原始消息，数值字段均为字符串
+type rawMessage struct {
+	EventType string `json:"event_type"`
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	Market    string `json:"market"`
+	AssetID   string `json:"asset_id"`
+	Outcome   string `json:"outcome"`
+	Owner     string `json:"owner"`
+	Side      string `json:"side"`
+	Price     string `json:"price"`
+	Timestamp string `json:"timestamp"`
+
+	// order
+	OriginalSize    string   `json:"original_size"`
+	SizeMatched     string   `json:"size_matched"`
+	AssociateTrades []string `json:"associate_trades"`
+
+	// trade
+	Status       string     `json:"status"`
+	Size         string     `json:"size"`
+	TakerOrderID string     `json:"taker_order_id"`
+	TradeOwner   string     `json:"trade_owner"`
+	MatchTime    string     `json:"matchtime"`
+	LastUpdate   string     `json:"last_update"`
+	MakerOrders  []rawMaker `json:"maker_orders"`
+}
+
+// rawMaker 原始挂单方订单
+type rawMaker struct {
+	OrderID       string `json:"order_id"`
+	AssetID       string `json:"asset_id"`
+	Owner         string `json:"owner"`
+	Outcome       string `json:"outcome"`
+	Price         string `json:"price"`
+	MatchedAmount string `json:"matched_amount"`
+}
+
+// parseEvents 解析一条 WebSocket 消息，消息可能是单个对象或对象数组，未知类型的事件被忽略
+func parseEvents(data []byte, receivedAt time.Time) ([]Event, error) {
+	var raws []rawMessage
+	if trimmed := bytes.TrimLeft(data, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(data, &raws); err != nil {
+			return nil, fmt.Errorf("decode user message: %w", err)
+		}
+	} else {
+		var raw rawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("decode user message: %w", err)
+		}
+		raws = []rawMessage{raw}
+	}
+
+	events := make([]Event, 0, len(raws))
+	for i := range raws {
+		raw := &raws[i]
+		switch EventType(raw.EventType) {
+		case EventTypeOrder:
+			events = append(events, Event{Type: EventTypeOrder, Order: raw.order(), ReceivedAt: receivedAt})
+		case EventTypeTrade:
+			events = append(events, Event{Type: EventTypeTrade, Trade: raw.trade(), ReceivedAt: receivedAt})
+		}
+	}
+	return events, nil
+}
+
+// order 转换为订单事件
+func (r *rawMessage) order() *OrderEvent {
+	return &OrderEvent{
+		ID:              r.ID,
+		Type:            OrderEventType(r.Type),
+		Market:          r.Market,
+		AssetID:         r.AssetID,
+		Outcome:         r.Outcome,
+		Owner:           r.Owner,
+		Side:            common.Side(r.Side),
+		Price:           parseDecimal(r.Price),
+		OriginalSize:    parseDecimal(r.OriginalSize),
+		SizeMatched:     parseDecimal(r.SizeMatched),
+		AssociateTrades: r.AssociateTrades,
+		Timestamp:       parseInt(r.Timestamp),
+	}
+}
+
+// trade 转换为成交事件
+func (r *rawMessage) trade() *TradeEvent {
+	makers := make([]MakerOrder, 0, len(r.MakerOrders))
+	for _, m := range r.MakerOrders {
+		makers = append(makers, MakerOrder{
+			OrderID:       m.OrderID,
+			AssetID:       m.AssetID,
+			Owner:         m.Owner,
+			Outcome:       m.Outcome,
+			Price:         parseDecimal(m.Price),
+			MatchedAmount: parseDecimal(m.MatchedAmount),
+		})
+	}
+	return &TradeEvent{
+		ID:           r.ID,
+		Status:       TradeStatus(r.Status),
+		Market:       r.Market,
+		AssetID:      r.AssetID,
+		Outcome:      r.Outcome,
+		Side:         common.Side(r.Side),
+		Price:        parseDecimal(r.Price),
+		Size:         parseDecimal(r.Size),
+		TakerOrderID: r.TakerOrderID,
+		MakerOrders:  makers,
+		Owner:        r.Owner,
+		TradeOwner:   r.TradeOwner,
+		MatchTime:    parseInt(r.MatchTime),
+		LastUpdate:   parseInt(r.LastUpdate),
+		Timestamp:    parseInt(r.Timestamp),
+	}
+}
+
+// parseDecimal 解析数值字符串，空值或非法值返回零
+func parseDecimal(s string) decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
+
+// parseInt 解析整数字符串，空值或非法值返回零
+func parseInt(s string) int64 {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
+
+// unixTime 将秒或毫秒时间戳转换为时间
+func unixTime(ts int64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	if ts < 1e12 {
+		return time.Unix(ts, 0)
+	}
+	return time.UnixMilli(ts)
+}